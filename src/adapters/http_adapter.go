@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/settings"
 	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
 	handlers "github.com/simon3640/goprojectskeleton/src/infrastructure/handlers/shared"
 )
@@ -40,7 +41,10 @@ func (a *HTTPAdapter) ToHandlerContext(
 	user := r.Context().Value(app_context.UserKey)
 	if user, ok := user.(usermodels.UserWithRole); ok {
 		appContext = app_context.NewContextWithUser(&user)
+	} else {
+		appContext = app_context.NewVoidAppContext()
 	}
+	appContext.AddClientIPToContext(handlers.ClientIP(r.Header.Get(handlers.FORWARDED_FOR.String()), r.RemoteAddr, settings.AppSettingsInstance.TrustedProxies))
 	// If not in context, parse from URL
 	if query == nil && r.URL.Query() != nil {
 		query = parseQueryParams(r.URL.Query())
@@ -48,7 +52,7 @@ func (a *HTTPAdapter) ToHandlerContext(
 
 	body := r.Body
 
-	return handlers.NewHandlerContext(
+	handlerContext := handlers.NewHandlerContext(
 		appContext,
 		&locale,
 		params,
@@ -56,6 +60,8 @@ func (a *HTTPAdapter) ToHandlerContext(
 		query,
 		w,
 	)
+	handlerContext.IdempotencyKey = r.Header.Get("Idempotency-Key")
+	return handlerContext
 }
 
 // ParsePathParams extracts path parameters from a URL pattern and path.
@@ -0,0 +1,12 @@
+package contractsproviders
+
+import application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+
+type IEncryptionProvider interface {
+	// Encrypt encrypts and authenticates the plaintext, the result can only be
+	// decrypted with the same key.
+	Encrypt(plaintext []byte) ([]byte, *application_errors.ApplicationError)
+	// Decrypt decrypts a ciphertext returned by Encrypt, it fails when the
+	// ciphertext was tampered with.
+	Decrypt(ciphertext []byte) ([]byte, *application_errors.ApplicationError)
+}
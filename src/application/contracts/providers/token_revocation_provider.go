@@ -0,0 +1,19 @@
+package contractsproviders
+
+import (
+	"time"
+
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+)
+
+type ITokenRevocationProvider interface {
+	// RevokeToken revokes the token with the given ID (jti claim) until it expires.
+	RevokeToken(tokenID string, expiresAt time.Time) *application_errors.ApplicationError
+	// IsTokenRevoked reports whether the token with the given ID was revoked.
+	IsTokenRevoked(tokenID string) (bool, *application_errors.ApplicationError)
+	// RevokeUserTokens revokes every token issued to the user until now.
+	RevokeUserTokens(userID uint) *application_errors.ApplicationError
+	// UserTokensRevokedBefore returns the time until which the tokens issued to the user are revoked,
+	// the zero time if they were never revoked.
+	UserTokensRevokedBefore(userID uint) (time.Time, *application_errors.ApplicationError)
+}
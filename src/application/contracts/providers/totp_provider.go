@@ -0,0 +1,19 @@
+package contractsproviders
+
+import (
+	"time"
+
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+)
+
+type ITOTPProvider interface {
+	// GenerateSecret generates a new base32 encoded TOTP secret.
+	GenerateSecret() (string, *application_errors.ApplicationError)
+	// ProvisioningURI returns the otpauth:// URI authenticator apps enroll the secret from.
+	ProvisioningURI(secret string, accountName string) string
+	// ValidateCode reports whether the code is valid for the secret at the given time,
+	// and the time step it matched so the code can not be used twice.
+	ValidateCode(secret string, code string, at time.Time) (int64, bool)
+	// GenerateRecoveryCodes generates one-time recovery codes.
+	GenerateRecoveryCodes(count int) ([]string, *application_errors.ApplicationError)
+}
@@ -0,0 +1,15 @@
+package contracts_repositories
+
+import (
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
+
+// IOrganizationRepository is the interface for the organization repository
+type IOrganizationRepository interface {
+	IRepositoryBase[usermodels.OrganizationCreate, usermodels.OrganizationUpdate, usermodels.Organization, usermodels.Organization]
+	// AddMember makes the user a member of the organization
+	AddMember(organizationID uint, userID uint) *application_errors.ApplicationError
+	// RemoveMember removes the user from the organization
+	RemoveMember(organizationID uint, userID uint) *application_errors.ApplicationError
+}
@@ -0,0 +1,22 @@
+package contracts_repositories
+
+import (
+	"time"
+
+	dtos "github.com/simon3640/goprojectskeleton/src/application/shared/DTOs"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+type IOutboxTaskRepository interface {
+	IRepositoryBase[dtos.OutboxTaskCreate, dtos.OutboxTaskUpdate, sharedmodels.OutboxTask, sharedmodels.OutboxTask]
+	GetByTaskID(taskID string) (*sharedmodels.OutboxTask, *application_errors.ApplicationError)
+	// ClaimDue returns up to limit pending tasks whose next attempt is due at now.
+	// Claimed tasks are leased until now+lease, so a crashed dispatcher does not
+	// lose them and concurrent dispatchers do not deliver them twice in the meantime.
+	ClaimDue(now time.Time, limit int, lease time.Duration) ([]sharedmodels.OutboxTask, *application_errors.ApplicationError)
+	// WithContext returns the repository bound to the context, so it takes
+	// part in the unit of work the context runs in (see IUnitOfWork).
+	WithContext(ctx *app_context.AppContext) IOutboxTaskRepository
+}
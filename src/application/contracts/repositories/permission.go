@@ -0,0 +1,19 @@
+package contracts_repositories
+
+import (
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
+
+// IPermissionRepository is the interface for the permission repository
+type IPermissionRepository interface {
+	IRepositoryBase[usermodels.PermissionCreate, usermodels.PermissionUpdate, usermodels.Permission, usermodels.Permission]
+	// GetRolePermissions gets the keys of the permissions granted to a role
+	GetRolePermissions(roleID uint) ([]string, *application_errors.ApplicationError)
+	// SetRolePermissions replaces the permissions granted to a role by those
+	// with the given keys, unknown keys are rejected
+	SetRolePermissions(roleID uint, keys []string) *application_errors.ApplicationError
+	// GetUserPermissions gets the permissions granted or revoked to a user
+	// over those of their role
+	GetUserPermissions(userID uint) ([]usermodels.UserPermission, *application_errors.ApplicationError)
+}
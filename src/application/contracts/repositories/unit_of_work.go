@@ -0,0 +1,15 @@
+package contracts_repositories
+
+import (
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+)
+
+// IUnitOfWork runs functions in a database transaction.
+type IUnitOfWork interface {
+	// Do runs fn in a transaction held by the AppContext: the repositories
+	// bound to the context take part in it while fn runs. The transaction is
+	// committed if fn succeeds and rolled back otherwise. Units of work nested
+	// in a transaction run in a savepoint of it.
+	Do(ctx *app_context.AppContext, fn func() *application_errors.ApplicationError) *application_errors.ApplicationError
+}
@@ -0,0 +1,18 @@
+package authcontracts
+
+import (
+	contractrepositories "github.com/simon3640/goprojectskeleton/src/application/contracts/repositories"
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// IAPIKeyRepository is the interface for the repository of the API keys of
+// the users
+type IAPIKeyRepository interface {
+	contractrepositories.IRepositoryBase[authdtos.APIKeyCreate, authdtos.APIKeyUpdate, sharedmodels.APIKey, sharedmodels.APIKey]
+	GetByHash(hash []byte) (*sharedmodels.APIKey, *applicationerrors.ApplicationError)
+	GetByUserID(userID uint) ([]sharedmodels.APIKey, *applicationerrors.ApplicationError)
+	// Touch records a use of the key
+	Touch(id uint) *applicationerrors.ApplicationError
+}
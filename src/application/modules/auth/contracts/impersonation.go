@@ -0,0 +1,15 @@
+package authcontracts
+
+import (
+	contractrepositories "github.com/simon3640/goprojectskeleton/src/application/contracts/repositories"
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// IImpersonationRepository is the interface for the repository of the
+// impersonations of the users by the admins, it is their audit trail
+type IImpersonationRepository interface {
+	contractrepositories.IRepositoryBase[authdtos.ImpersonationCreate, authdtos.ImpersonationUpdate, sharedmodels.Impersonation, sharedmodels.Impersonation]
+	GetByTokenID(tokenID string) (*sharedmodels.Impersonation, *applicationerrors.ApplicationError)
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"time"
 
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
 	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
 )
 
@@ -16,4 +17,7 @@ type IJWTProvider interface {
 	GenerateAccessToken(ctx context.Context, subject string, claimsMap JWTCLaims) (string, time.Time, *applicationerrors.ApplicationError)
 	GenerateRefreshToken(ctx context.Context, subject string) (string, time.Time, *applicationerrors.ApplicationError)
 	ParseTokenAndValidate(tokenString string) (JWTCLaims, *applicationerrors.ApplicationError)
+	// PublicKeys returns the public keys that verify the tokens at the given time,
+	// symmetric keys are never published
+	PublicKeys(at time.Time) authdtos.JWKS
 }
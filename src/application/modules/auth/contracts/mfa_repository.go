@@ -0,0 +1,30 @@
+package authcontracts
+
+import (
+	contractrepositories "github.com/simon3640/goprojectskeleton/src/application/contracts/repositories"
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// ITOTPDeviceRepository is the interface for the TOTP device repository
+type ITOTPDeviceRepository interface {
+	contractrepositories.IRepositoryBase[authdtos.TOTPDeviceCreate, authdtos.TOTPDeviceUpdate, sharedmodels.TOTPDevice, sharedmodels.TOTPDevice]
+	GetByUserID(userID uint) (*sharedmodels.TOTPDevice, *applicationerrors.ApplicationError)
+	// DeleteByUserID removes the device of the user, e.g. an enrollment that was never confirmed
+	DeleteByUserID(userID uint) *applicationerrors.ApplicationError
+	// UseStep records the time step of an accepted code and reports whether
+	// this call did it. It returns false when a code of the same or a later
+	// step was already used, so every code is only accepted once.
+	UseStep(id uint, step int64) (bool, *applicationerrors.ApplicationError)
+}
+
+// IRecoveryCodeRepository is the interface for the recovery code repository
+type IRecoveryCodeRepository interface {
+	contractrepositories.IRepositoryBase[authdtos.RecoveryCodeCreate, authdtos.RecoveryCodeUpdate, sharedmodels.RecoveryCode, sharedmodels.RecoveryCode]
+	// DeleteByUserID removes every recovery code of the user
+	DeleteByUserID(userID uint) *applicationerrors.ApplicationError
+	// Use marks the unused code of the user with the hash as used and reports
+	// whether this call did it
+	Use(userID uint, hash []byte) (bool, *applicationerrors.ApplicationError)
+}
@@ -0,0 +1,22 @@
+package authcontracts
+
+import (
+	contractrepositories "github.com/simon3640/goprojectskeleton/src/application/contracts/repositories"
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// IOAuthClientRepository is the interface for the repository of the clients
+// of the OAuth2 authorization server
+type IOAuthClientRepository interface {
+	contractrepositories.IRepositoryBase[authdtos.OAuthClientCreate, authdtos.OAuthClientUpdate, sharedmodels.OAuthClient, sharedmodels.OAuthClient]
+	GetByClientID(clientID string) (*sharedmodels.OAuthClient, *applicationerrors.ApplicationError)
+}
+
+// IOAuthConsentRepository is the interface for the repository of the scopes
+// the users granted to the OAuth clients
+type IOAuthConsentRepository interface {
+	contractrepositories.IRepositoryBase[authdtos.OAuthConsentCreate, authdtos.OAuthConsentUpdate, sharedmodels.OAuthConsent, sharedmodels.OAuthConsent]
+	GetByUserAndClient(userID uint, clientID string) (*sharedmodels.OAuthConsent, *applicationerrors.ApplicationError)
+}
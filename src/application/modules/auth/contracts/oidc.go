@@ -0,0 +1,29 @@
+package authcontracts
+
+import (
+	"context"
+
+	contractrepositories "github.com/simon3640/goprojectskeleton/src/application/contracts/repositories"
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// IExternalIdentityRepository is the interface for the repository of the
+// identity provider accounts linked to the users
+type IExternalIdentityRepository interface {
+	contractrepositories.IRepositoryBase[authdtos.ExternalIdentityCreate, authdtos.ExternalIdentityUpdate, sharedmodels.ExternalIdentity, sharedmodels.ExternalIdentity]
+	GetByProviderSubject(provider string, subject string) (*sharedmodels.ExternalIdentity, *applicationerrors.ApplicationError)
+}
+
+// IOIDCProvider is the interface for the OpenID Connect relying party of the
+// configured identity providers, using the authorization code flow with PKCE
+type IOIDCProvider interface {
+	// AuthorizationURL returns the URL the user must be redirected to, the
+	// code challenge is derived from the code verifier with S256
+	AuthorizationURL(ctx context.Context, provider string, state string, nonce string, codeVerifier string) (string, *applicationerrors.ApplicationError)
+	// Exchange exchanges the code for the tokens of the user and returns the
+	// claims of the ID token once its signature, issuer, audience and
+	// expiration are verified. The nonce must be checked by the caller.
+	Exchange(ctx context.Context, provider string, code string, codeVerifier string) (*authdtos.OIDCClaims, *applicationerrors.ApplicationError)
+}
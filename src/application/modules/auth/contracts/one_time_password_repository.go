@@ -11,4 +11,9 @@ import (
 type IOneTimePasswordRepository interface {
 	contractrepositories.IRepositoryBase[authdtos.OneTimePasswordCreate, authdtos.OneTimePasswordUpdate, sharedmodels.OneTimePassword, sharedmodels.OneTimePassword]
 	GetByPasswordHash(tokenHash []byte) (*sharedmodels.OneTimePassword, *applicationerrors.ApplicationError)
+	GetByTransactionHash(transactionHash []byte) (*sharedmodels.OneTimePassword, *applicationerrors.ApplicationError)
+	// IncrementAttempts records a failed attempt, false when no attempt was left
+	IncrementAttempts(id uint, maxAttempts int) (bool, *applicationerrors.ApplicationError)
+	// Use marks the password as used, false when it already was
+	Use(id uint) (bool, *applicationerrors.ApplicationError)
 }
@@ -0,0 +1,22 @@
+package authcontracts
+
+import (
+	contractrepositories "github.com/simon3640/goprojectskeleton/src/application/contracts/repositories"
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// IRefreshTokenRepository is the interface for the refresh token repository
+type IRefreshTokenRepository interface {
+	contractrepositories.IRepositoryBase[authdtos.RefreshTokenCreate, authdtos.RefreshTokenUpdate, sharedmodels.RefreshToken, sharedmodels.RefreshToken]
+	GetByTokenHash(tokenHash []byte) (*sharedmodels.RefreshToken, *applicationerrors.ApplicationError)
+	// Rotate marks the token as rotated and reports whether this call did it.
+	// It returns false when the token was already rotated or revoked, e.g. by a
+	// concurrent refresh with the same token.
+	Rotate(id uint) (bool, *applicationerrors.ApplicationError)
+	// RevokeFamily revokes every token of the session
+	RevokeFamily(familyID string) *applicationerrors.ApplicationError
+	// RevokeAllByUser revokes every token of every session of the user
+	RevokeAllByUser(userID uint) *applicationerrors.ApplicationError
+}
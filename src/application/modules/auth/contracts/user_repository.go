@@ -11,4 +11,6 @@ type IUserRepository interface {
 	GetUserWithRole(id uint) (*usermodels.UserWithRole, *applicationerrors.ApplicationError)
 	// GetByEmailOrPhone gets a user by email or phone
 	GetByEmailOrPhone(emailOrPhone string) (*usermodels.User, *applicationerrors.ApplicationError)
+	// Delete hard deletes a user
+	Delete(id uint) *applicationerrors.ApplicationError
 }
@@ -0,0 +1,34 @@
+package authcontracts
+
+import (
+	contractrepositories "github.com/simon3640/goprojectskeleton/src/application/contracts/repositories"
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// IWebAuthnCredentialRepository is the interface for the passkey repository
+type IWebAuthnCredentialRepository interface {
+	contractrepositories.IRepositoryBase[authdtos.WebAuthnCredentialCreate, authdtos.WebAuthnCredentialUpdate, sharedmodels.WebAuthnCredential, sharedmodels.WebAuthnCredential]
+	GetByCredentialID(credentialID []byte) (*sharedmodels.WebAuthnCredential, *applicationerrors.ApplicationError)
+	GetByUserID(userID uint) ([]sharedmodels.WebAuthnCredential, *applicationerrors.ApplicationError)
+	// UseSignCount records a login with the passkey and its new sign count and
+	// reports whether this call did it. It returns false when the counter did
+	// not increase, the authenticator may have been cloned. Authenticators
+	// without counter always report zero.
+	UseSignCount(id uint, signCount uint32) (bool, *applicationerrors.ApplicationError)
+}
+
+// IWebAuthnProvider is the interface for the WebAuthn provider verifying the
+// ceremonies of the authenticators. Binary values of the credentials are base64url encoded.
+type IWebAuthnProvider interface {
+	// RelyingParty returns the application the passkeys are scoped to
+	RelyingParty() authdtos.WebAuthnRelyingParty
+	// NewChallenge generates the random challenge of a ceremony
+	NewChallenge() ([]byte, *applicationerrors.ApplicationError)
+	// VerifyRegistration verifies a new credential signed the challenge
+	VerifyRegistration(challenge []byte, credential authdtos.WebAuthnRegistrationCredential) (*authdtos.WebAuthnVerifiedCredential, *applicationerrors.ApplicationError)
+	// VerifyAssertion verifies the credential with the public key signed the
+	// challenge and returns the sign count of the authenticator
+	VerifyAssertion(challenge []byte, publicKey []byte, credential authdtos.WebAuthnAssertionCredential) (uint32, *applicationerrors.ApplicationError)
+}
@@ -0,0 +1,51 @@
+package authdtos
+
+import (
+	"time"
+
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// APIKeyRequest is the request of a user to create an API key for itself
+type APIKeyRequest struct {
+	Name      string    `json:"name"`
+	Scopes    []string  `json:"scopes"`
+	ExpiresAt time.Time `json:"expires_at"`
+	UserID    uint      `json:"-"`
+}
+
+// GetUserID returns the user ID
+func (a APIKeyRequest) GetUserID() uint {
+	return a.UserID
+}
+
+// Validate validates the API key request, the key must expire in the future
+func (a APIKeyRequest) Validate() []string {
+	var errs []string
+	if a.Name == "" {
+		errs = append(errs, "name is required")
+	}
+	errs = append(errs, sharedmodels.ValidateAPIKeyScopes(a.Scopes)...)
+	if !a.ExpiresAt.After(time.Now()) {
+		errs = append(errs, "expires_at must be in the future")
+	}
+	return errs
+}
+
+// APIKeyCreate is the DTO for storing an API key
+type APIKeyCreate struct {
+	sharedmodels.APIKeyBase
+}
+
+// APIKeyUpdate is the DTO for updating an API key
+type APIKeyUpdate struct {
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	ID        uint       `json:"id"`
+}
+
+// APIKeyCreated is the created API key with the key, the key is only
+// returned on creation
+type APIKeyCreated struct {
+	sharedmodels.APIKey
+	Key string `json:"key"`
+}
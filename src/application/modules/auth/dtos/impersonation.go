@@ -0,0 +1,48 @@
+package authdtos
+
+import (
+	"time"
+
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// ImpersonationReasonMaxLength is the maximum length of the reason of an
+// impersonation
+const ImpersonationReasonMaxLength = 255
+
+// ImpersonationRequest is the request of an admin to act as another user
+type ImpersonationRequest struct {
+	UserID uint   `json:"-"`
+	Reason string `json:"reason"`
+}
+
+// Validate validates the impersonation request
+func (i ImpersonationRequest) Validate() []string {
+	var errs []string
+	if i.UserID == 0 {
+		errs = append(errs, "user_id is required")
+	}
+	if len(i.Reason) > ImpersonationReasonMaxLength {
+		errs = append(errs, "reason must be at most 255 characters")
+	}
+	return errs
+}
+
+// ImpersonationCreate is the DTO for storing an impersonation
+type ImpersonationCreate struct {
+	sharedmodels.ImpersonationBase
+}
+
+// ImpersonationUpdate is the DTO for updating an impersonation
+type ImpersonationUpdate struct {
+	EndedAt *time.Time `json:"ended_at,omitempty"`
+	ID      uint       `json:"id"`
+}
+
+// ImpersonationStarted is the access token of an impersonation with its
+// record, there is no refresh token so the impersonation cannot outlive it
+type ImpersonationStarted struct {
+	AccessToken   string                     `json:"access_token"`
+	ExpiresAt     time.Time                  `json:"expires_at"`
+	Impersonation sharedmodels.Impersonation `json:"impersonation"`
+}
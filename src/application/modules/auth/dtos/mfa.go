@@ -0,0 +1,71 @@
+package authdtos
+
+import sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+
+// TOTPDeviceCreate is the DTO for creating a TOTP device
+type TOTPDeviceCreate struct {
+	sharedmodels.TOTPDeviceBase
+}
+
+// NewTOTPDeviceCreate creates a new unconfirmed TOTP device create DTO
+func NewTOTPDeviceCreate(userID uint, encryptedSecret []byte) *TOTPDeviceCreate {
+	return &TOTPDeviceCreate{
+		TOTPDeviceBase: sharedmodels.TOTPDeviceBase{
+			UserID: userID,
+			Secret: encryptedSecret,
+		},
+	}
+}
+
+// TOTPDeviceUpdate is the DTO for updating a TOTP device
+type TOTPDeviceUpdate struct {
+	IsConfirmed bool `json:"isConfirmed,omitempty"`
+	ID          uint `json:"id"`
+}
+
+// RecoveryCodeCreate is the DTO for creating a recovery code
+type RecoveryCodeCreate struct {
+	sharedmodels.RecoveryCodeBase
+}
+
+// NewRecoveryCodeCreate creates a new recovery code create DTO
+func NewRecoveryCodeCreate(userID uint, hash []byte) *RecoveryCodeCreate {
+	return &RecoveryCodeCreate{
+		RecoveryCodeBase: sharedmodels.RecoveryCodeBase{
+			UserID: userID,
+			Hash:   hash,
+		},
+	}
+}
+
+// RecoveryCodeUpdate is the DTO for updating a recovery code
+type RecoveryCodeUpdate struct {
+	IsUsed bool `json:"isUsed,omitempty"`
+	ID     uint `json:"id"`
+}
+
+// TOTPConfirm is the DTO confirming the TOTP enrollment of the user with
+// the first code of the authenticator app
+type TOTPConfirm struct {
+	UserID uint   `json:"-"`
+	Code   string `json:"code"`
+}
+
+// GetUserID returns the ID of the user confirming the enrollment
+func (t TOTPConfirm) GetUserID() uint {
+	return t.UserID
+}
+
+// Validate validates the TOTP confirmation
+func (t TOTPConfirm) Validate() []string {
+	var errs []string
+	if t.Code == "" {
+		errs = append(errs, "code is required")
+	}
+	return errs
+}
+
+// RecoveryCodes is the DTO returning the recovery codes, they are only shown once
+type RecoveryCodes struct {
+	Codes []string `json:"recoveryCodes"`
+}
@@ -0,0 +1,206 @@
+package authdtos
+
+import (
+	"time"
+
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// OAuthClientCreate is the DTO for registering an OAuth client.
+// Confidential clients get a secret, public clients, e.g. single page or
+// mobile apps, can only use the authorization_code grant with PKCE.
+type OAuthClientCreate struct {
+	sharedmodels.OAuthClientBase
+	Confidential bool `json:"confidential"`
+}
+
+// Validate validates the client create DTO. The client ID and secret are
+// generated on registration.
+func (o OAuthClientCreate) Validate() []string {
+	client := o.OAuthClientBase
+	client.ClientID = "pending"
+	if o.Confidential {
+		client.SecretHash = []byte("pending")
+	}
+	return client.Validate()
+}
+
+// OAuthClientUpdate is the DTO for updating an OAuth client
+type OAuthClientUpdate struct {
+	Name         string   `json:"name,omitempty"`
+	RedirectURIs []string `json:"redirect_uris,omitempty"`
+	Scopes       []string `json:"scopes,omitempty"`
+	ID           uint     `json:"id"`
+}
+
+// OAuthClientRegistration is the registered client with its secret, the
+// secret is only returned on registration
+type OAuthClientRegistration struct {
+	sharedmodels.OAuthClient
+	ClientSecret string `json:"client_secret,omitempty"`
+}
+
+// OAuthConsentCreate is the DTO for recording the consent of a user
+type OAuthConsentCreate struct {
+	sharedmodels.OAuthConsentBase
+}
+
+// OAuthConsentUpdate is the DTO for updating the scopes a user granted
+type OAuthConsentUpdate struct {
+	Scopes []string `json:"scopes"`
+	ID     uint     `json:"id"`
+}
+
+// OAuthAuthorize is the authorization request of a client, sent by the
+// frontend of this service once the user is logged in. Consent is set when
+// the user accepted the scopes requested by the client.
+type OAuthAuthorize struct {
+	ResponseType        string `json:"response_type"`
+	ClientID            string `json:"client_id"`
+	RedirectURI         string `json:"redirect_uri"`
+	Scope               string `json:"scope"`
+	State               string `json:"state"`
+	CodeChallenge       string `json:"code_challenge"`
+	CodeChallengeMethod string `json:"code_challenge_method"`
+	Consent             bool   `json:"consent"`
+	UserID              uint   `json:"-"`
+}
+
+// GetUserID returns the user ID
+func (o OAuthAuthorize) GetUserID() uint {
+	return o.UserID
+}
+
+// Validate validates the authorize DTO, PKCE with S256 is required
+func (o OAuthAuthorize) Validate() []string {
+	var errs []string
+	if o.ResponseType != "code" {
+		errs = append(errs, "response_type must be code")
+	}
+	if o.ClientID == "" {
+		errs = append(errs, "client_id is required")
+	}
+	if o.RedirectURI == "" {
+		errs = append(errs, "redirect_uri is required")
+	}
+	if o.CodeChallenge == "" {
+		errs = append(errs, "code_challenge is required")
+	}
+	if o.CodeChallengeMethod != "S256" {
+		errs = append(errs, "code_challenge_method must be S256")
+	}
+	return errs
+}
+
+// OAuthAuthorization is the response to an authorization request. The user
+// is redirected to the redirect URI, which carries the code and the state,
+// or asked for consent to the scopes first.
+type OAuthAuthorization struct {
+	RedirectURI     string   `json:"redirect_uri,omitempty"`
+	ConsentRequired bool     `json:"consent_required,omitempty"`
+	ClientName      string   `json:"client_name"`
+	Scopes          []string `json:"scopes"`
+}
+
+// OAuthCode is an authorization code issued to a client, kept until it
+// expires or the client exchanges it
+type OAuthCode struct {
+	ClientID      string    `json:"clientId"`
+	UserID        uint      `json:"userId"`
+	RedirectURI   string    `json:"redirectUri"`
+	Scope         string    `json:"scope"`
+	CodeChallenge string    `json:"codeChallenge"`
+	ExpiresAt     time.Time `json:"expiresAt"`
+}
+
+// OAuthClientCredentials are the credentials a client authenticates with,
+// the secret is empty for public clients
+type OAuthClientCredentials struct {
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret,omitempty"`
+}
+
+// OAuthTokenRequest is the token request of a client (RFC 6749 section 4)
+type OAuthTokenRequest struct {
+	OAuthClientCredentials
+	GrantType    string `json:"grant_type"`
+	Code         string `json:"code,omitempty"`
+	RedirectURI  string `json:"redirect_uri,omitempty"`
+	CodeVerifier string `json:"code_verifier,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+}
+
+// Validate validates the token request
+func (o OAuthTokenRequest) Validate() []string {
+	var errs []string
+	if o.ClientID == "" {
+		errs = append(errs, "client_id is required")
+	}
+	switch o.GrantType {
+	case sharedmodels.OAuthGrantAuthorizationCode:
+		if o.Code == "" {
+			errs = append(errs, "code is required")
+		}
+		if o.RedirectURI == "" {
+			errs = append(errs, "redirect_uri is required")
+		}
+		if o.CodeVerifier == "" {
+			errs = append(errs, "code_verifier is required")
+		}
+	case sharedmodels.OAuthGrantRefreshToken:
+		if o.RefreshToken == "" {
+			errs = append(errs, "refresh_token is required")
+		}
+	case sharedmodels.OAuthGrantClientCredentials:
+	case "":
+		errs = append(errs, "grant_type is required")
+	default:
+		errs = append(errs, "unsupported grant_type")
+	}
+	return errs
+}
+
+// OAuthTokenResponse is the successful token response (RFC 6749 section 5.1)
+type OAuthTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+}
+
+// OAuthTokenReference is the token a client introspects (RFC 7662) or
+// revokes (RFC 7009)
+type OAuthTokenReference struct {
+	OAuthClientCredentials
+	Token         string `json:"token"`
+	TokenTypeHint string `json:"token_type_hint,omitempty"`
+}
+
+// Validate validates the token reference
+func (o OAuthTokenReference) Validate() []string {
+	var errs []string
+	if o.ClientID == "" {
+		errs = append(errs, "client_id is required")
+	}
+	if o.Token == "" {
+		errs = append(errs, "token is required")
+	}
+	return errs
+}
+
+// OAuthIntrospection is the introspection response (RFC 7662 section 2.2),
+// only active is set for inactive tokens
+type OAuthIntrospection struct {
+	Active    bool   `json:"active"`
+	Scope     string `json:"scope,omitempty"`
+	ClientID  string `json:"client_id,omitempty"`
+	TokenType string `json:"token_type,omitempty"`
+	Subject   string `json:"sub,omitempty"`
+	Audience  any    `json:"aud,omitempty"`
+	Issuer    string `json:"iss,omitempty"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+	IssuedAt  int64  `json:"iat,omitempty"`
+	TokenID   string `json:"jti,omitempty"`
+}
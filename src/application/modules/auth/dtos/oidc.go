@@ -0,0 +1,86 @@
+package authdtos
+
+import (
+	"time"
+
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// ExternalIdentityCreate is the DTO for linking an identity provider account
+type ExternalIdentityCreate struct {
+	sharedmodels.ExternalIdentityBase
+}
+
+// ExternalIdentityUpdate is the DTO for updating a linked identity
+type ExternalIdentityUpdate struct {
+	Email string `json:"email,omitempty"`
+	ID    uint   `json:"id"`
+}
+
+// OIDCAuthorize is the DTO starting a login with an identity provider.
+// UserID is set when an authenticated user links the provider to their account.
+type OIDCAuthorize struct {
+	Provider string `json:"provider"`
+	UserID   uint   `json:"-"`
+}
+
+// Validate validates the authorize DTO
+func (o OIDCAuthorize) Validate() []string {
+	var errs []string
+	if o.Provider == "" {
+		errs = append(errs, "provider is required")
+	}
+	return errs
+}
+
+// OIDCAuthorization is the URL of the provider the user must be redirected
+// to, the provider redirects back with the code and the same state
+type OIDCAuthorization struct {
+	AuthorizationURL string `json:"authorizationUrl"`
+	State            string `json:"state"`
+}
+
+// OIDCCallback is the DTO completing a login with an identity provider
+// with the code and state the provider redirected back with
+type OIDCCallback struct {
+	State  string `json:"state"`
+	Code   string `json:"code"`
+	UserID uint   `json:"-"`
+}
+
+// GetUserID returns the user ID
+func (o OIDCCallback) GetUserID() uint {
+	return o.UserID
+}
+
+// Validate validates the callback DTO
+func (o OIDCCallback) Validate() []string {
+	var errs []string
+	if o.State == "" {
+		errs = append(errs, "state is required")
+	}
+	if o.Code == "" {
+		errs = append(errs, "code is required")
+	}
+	return errs
+}
+
+// OIDCClaims are the claims of a verified ID token
+type OIDCClaims struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	Nonce         string
+}
+
+// OIDCSession is a pending authorization with an identity provider, kept
+// until it expires or the provider redirects back. UserID is set when an
+// authenticated user links the provider to their account.
+type OIDCSession struct {
+	Provider     string    `json:"provider"`
+	Nonce        string    `json:"nonce"`
+	CodeVerifier string    `json:"codeVerifier"`
+	UserID       uint      `json:"userId"`
+	ExpiresAt    time.Time `json:"expiresAt"`
+}
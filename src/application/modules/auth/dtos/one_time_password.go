@@ -23,15 +23,17 @@ func PurposePasswordToDuration(purpose sharedmodels.OneTimePasswordPurpose) time
 }
 
 // NewOneTimePasswordCreate creates a new one time password create DTO
-func NewOneTimePasswordCreate(userID uint, purpose sharedmodels.OneTimePasswordPurpose, hash []byte) *OneTimePasswordCreate {
+// bound to the transaction with the given hash
+func NewOneTimePasswordCreate(userID uint, purpose sharedmodels.OneTimePasswordPurpose, hash []byte, transactionHash []byte) *OneTimePasswordCreate {
 	// TODO: move expiration to another place
 	return &OneTimePasswordCreate{
 		OneTimePasswordBase: sharedmodels.OneTimePasswordBase{
-			UserID:  userID,
-			Purpose: purpose,
-			Hash:    hash,
-			Expires: time.Now().Add(PurposePasswordToDuration(purpose)),
-			IsUsed:  false,
+			UserID:          userID,
+			Purpose:         purpose,
+			Hash:            hash,
+			TransactionHash: transactionHash,
+			Expires:         time.Now().Add(PurposePasswordToDuration(purpose)),
+			IsUsed:          false,
 		},
 	}
 }
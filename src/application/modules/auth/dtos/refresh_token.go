@@ -0,0 +1,31 @@
+package authdtos
+
+import (
+	"time"
+
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// RefreshTokenCreate is the DTO for creating a refresh token
+type RefreshTokenCreate struct {
+	sharedmodels.RefreshTokenBase
+}
+
+// NewRefreshTokenCreate creates a new refresh token create DTO
+func NewRefreshTokenCreate(userID uint, familyID string, hash []byte, expires time.Time) *RefreshTokenCreate {
+	return &RefreshTokenCreate{
+		RefreshTokenBase: sharedmodels.RefreshTokenBase{
+			UserID:   userID,
+			FamilyID: familyID,
+			Hash:     hash,
+			Expires:  expires,
+		},
+	}
+}
+
+// RefreshTokenUpdate is the DTO for updating a refresh token
+type RefreshTokenUpdate struct {
+	IsRotated bool `json:"isRotated,omitempty"`
+	IsRevoked bool `json:"isRevoked,omitempty"`
+	ID        uint `json:"id"`
+}
@@ -0,0 +1,14 @@
+package authdtos
+
+// TenantSwitch is the DTO for the organization the user switches to, 0 to act
+// outside of every organization. RefreshToken is the refresh token of the
+// session to move to the organization, optional.
+type TenantSwitch struct {
+	OrganizationID uint   `json:"organization_id"`
+	RefreshToken   string `json:"refresh_token,omitempty"`
+}
+
+// GetOrganizationID returns the organization to switch to
+func (t TenantSwitch) GetOrganizationID() uint {
+	return t.OrganizationID
+}
@@ -1,7 +1,11 @@
 // Package authdtos contains the DTOs for the auth module.
 package authdtos
 
-import "time"
+import (
+	"time"
+
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
 
 // Token is the DTO for the token
 type Token struct {
@@ -12,17 +16,34 @@ type Token struct {
 	RefreshTokenExpiresAt time.Time `json:"refresExpiresAt"`
 }
 
-// UserCredentials is the DTO for the user credentials
+// UserCredentials is the DTO for the user credentials.
+// TOTPCode is the authenticator app or recovery code of the users with MFA enabled.
 type UserCredentials struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
+	TOTPCode string `json:"totpCode,omitempty"`
 }
 
-// AuthResponse is the DTO for the auth response
+// AuthResponse is the DTO for the auth response.
+// It holds the tokens, or the transaction to verify with the OTP sent
+// to the users with OTP login enabled.
 type AuthResponse struct {
-	Token         *Token `json:"token"`
-	RequiresOTP   bool   `json:"requiresOtp"`
-	TransactionID string `json:"transactionId"`
+	*Token
+	RequiresOTP   bool   `json:"requiresOtp,omitempty"`
+	TransactionID string `json:"transactionId,omitempty"`
+}
+
+// AuthenticatedUser is the DTO for the user whose credentials were verified by
+// the login. MFAVerified reports whether an authenticator app code was verified.
+type AuthenticatedUser struct {
+	User        usermodels.UserWithRole
+	MFAVerified bool
+}
+
+// RequiresOTP reports whether the login must be completed with the OTP sent
+// by email. The authenticator app code replaces the OTP email.
+func (a AuthenticatedUser) RequiresOTP() bool {
+	return a.User.OTPLogin && !a.MFAVerified
 }
 
 // OTPVerifyInput is the DTO for the OTP verify input
@@ -31,8 +52,35 @@ type OTPVerifyInput struct {
 	OTP           string `json:"otp"`
 }
 
-// OTPEnrollRequest is the DTO for the OTP enroll request
+// Validate validates the OTP verify input
+func (o OTPVerifyInput) Validate() []string {
+	var errs []string
+	if o.TransactionID == "" {
+		errs = append(errs, "transactionId is required")
+	}
+	if o.OTP == "" {
+		errs = append(errs, "otp is required")
+	}
+	return errs
+}
+
+// OTPEnrollRequest is the DTO returning a new authenticator app secret and
+// the otpauth:// URI it is enrolled from
 type OTPEnrollRequest struct {
 	Secret     string `json:"secret"`
 	OTPAuthURI string `json:"otpAuthUri"`
 }
+
+// AccountUnlock is the DTO for the token of the link unlocking an account
+// locked after too many failed logins
+type AccountUnlock struct {
+	Token string `json:"token"`
+}
+
+// Validate validates the account unlock input
+func (a AccountUnlock) Validate() []string {
+	if a.Token == "" {
+		return []string{"token is required"}
+	}
+	return nil
+}
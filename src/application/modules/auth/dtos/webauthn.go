@@ -0,0 +1,224 @@
+package authdtos
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// WebAuthn credential types and algorithms, as COSE algorithm identifiers
+const (
+	WebAuthnPublicKeyType = "public-key"
+	WebAuthnAlgES256      = -7
+	WebAuthnAlgEdDSA      = -8
+	WebAuthnAlgRS256      = -257
+)
+
+// EncodeWebAuthnBase64 encodes a binary value of the ceremonies as base64url without padding
+func EncodeWebAuthnBase64(value []byte) string {
+	return base64.RawURLEncoding.EncodeToString(value)
+}
+
+// DecodeWebAuthnBase64 decodes the base64url values of the credentials,
+// with or without padding
+func DecodeWebAuthnBase64(value string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+}
+
+// WebAuthnUserHandle is the base64url user handle of the passkeys of a user,
+// the decimal ID of the user
+func WebAuthnUserHandle(userID uint) string {
+	return EncodeWebAuthnBase64([]byte(strconv.FormatUint(uint64(userID), 10)))
+}
+
+// WebAuthnCredentialCreate is the DTO for storing a verified passkey
+type WebAuthnCredentialCreate struct {
+	sharedmodels.WebAuthnCredentialBase
+}
+
+// WebAuthnCredentialUpdate is the DTO for updating a passkey
+type WebAuthnCredentialUpdate struct {
+	Name string `json:"name,omitempty"`
+	ID   uint   `json:"id"`
+}
+
+// WebAuthnRelyingParty is the application the passkeys are scoped to
+type WebAuthnRelyingParty struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// WebAuthnUser is the account a passkey is created for.
+// ID is the user handle, it never contains personal data.
+type WebAuthnUser struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+// WebAuthnCredentialParameter is a credential type and algorithm the relying party accepts
+type WebAuthnCredentialParameter struct {
+	Type string `json:"type"`
+	Alg  int    `json:"alg"`
+}
+
+// WebAuthnCredentialDescriptor identifies a passkey of the user
+type WebAuthnCredentialDescriptor struct {
+	Type       string   `json:"type"`
+	ID         string   `json:"id"`
+	Transports []string `json:"transports,omitempty"`
+}
+
+// NewWebAuthnCredentialDescriptor creates the descriptor of a stored passkey
+func NewWebAuthnCredentialDescriptor(credential sharedmodels.WebAuthnCredential) WebAuthnCredentialDescriptor {
+	return WebAuthnCredentialDescriptor{
+		Type:       WebAuthnPublicKeyType,
+		ID:         EncodeWebAuthnBase64(credential.CredentialID),
+		Transports: credential.Transports,
+	}
+}
+
+// WebAuthnAuthenticatorSelection are the requirements on the authenticator
+type WebAuthnAuthenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+// WebAuthnCreationOptions are the options of navigator.credentials.create,
+// binary values are base64url encoded
+type WebAuthnCreationOptions struct {
+	Challenge              string                         `json:"challenge"`
+	RP                     WebAuthnRelyingParty           `json:"rp"`
+	User                   WebAuthnUser                   `json:"user"`
+	PubKeyCredParams       []WebAuthnCredentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int64                          `json:"timeout"`
+	ExcludeCredentials     []WebAuthnCredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection WebAuthnAuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                         `json:"attestation"`
+}
+
+// WebAuthnRequestOptions are the options of navigator.credentials.get,
+// binary values are base64url encoded
+type WebAuthnRequestOptions struct {
+	Challenge        string                         `json:"challenge"`
+	RPID             string                         `json:"rpId"`
+	Timeout          int64                          `json:"timeout"`
+	AllowCredentials []WebAuthnCredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                         `json:"userVerification"`
+}
+
+// WebAuthnLoginBegin is the DTO starting a passkey login.
+// Without email the authenticator offers its discoverable passkeys.
+type WebAuthnLoginBegin struct {
+	Email string `json:"email,omitempty"`
+}
+
+// WebAuthnLoginOptions is the passkey login challenge, SessionID must be sent
+// back with the assertion
+type WebAuthnLoginOptions struct {
+	SessionID string                 `json:"sessionId"`
+	PublicKey WebAuthnRequestOptions `json:"publicKey"`
+}
+
+// WebAuthnAttestationResponse is the response of the authenticator to a registration
+type WebAuthnAttestationResponse struct {
+	ClientDataJSON    string   `json:"clientDataJSON"`
+	AttestationObject string   `json:"attestationObject"`
+	Transports        []string `json:"transports,omitempty"`
+}
+
+// WebAuthnRegistrationCredential is the credential created by navigator.credentials.create
+type WebAuthnRegistrationCredential struct {
+	ID       string                      `json:"id"`
+	RawID    string                      `json:"rawId"`
+	Type     string                      `json:"type"`
+	Response WebAuthnAttestationResponse `json:"response"`
+}
+
+// WebAuthnAssertionResponse is the response of the authenticator to a login
+type WebAuthnAssertionResponse struct {
+	ClientDataJSON    string `json:"clientDataJSON"`
+	AuthenticatorData string `json:"authenticatorData"`
+	Signature         string `json:"signature"`
+	UserHandle        string `json:"userHandle,omitempty"`
+}
+
+// WebAuthnAssertionCredential is the credential returned by navigator.credentials.get
+type WebAuthnAssertionCredential struct {
+	ID       string                    `json:"id"`
+	RawID    string                    `json:"rawId"`
+	Type     string                    `json:"type"`
+	Response WebAuthnAssertionResponse `json:"response"`
+}
+
+// WebAuthnRegistrationFinish is the DTO completing the registration of a passkey
+type WebAuthnRegistrationFinish struct {
+	UserID     uint                           `json:"-"`
+	Name       string                         `json:"name"`
+	Credential WebAuthnRegistrationCredential `json:"credential"`
+}
+
+// GetUserID returns the user ID
+func (w WebAuthnRegistrationFinish) GetUserID() uint {
+	return w.UserID
+}
+
+// Validate validates the registration finish DTO
+func (w WebAuthnRegistrationFinish) Validate() []string {
+	var errs []string
+	if w.Credential.RawID == "" {
+		errs = append(errs, "credential.rawId is required")
+	}
+	if w.Credential.Type != WebAuthnPublicKeyType {
+		errs = append(errs, "credential.type must be public-key")
+	}
+	if w.Credential.Response.ClientDataJSON == "" || w.Credential.Response.AttestationObject == "" {
+		errs = append(errs, "credential.response is required")
+	}
+	return errs
+}
+
+// WebAuthnLoginFinish is the DTO completing a passkey login
+type WebAuthnLoginFinish struct {
+	SessionID  string                      `json:"sessionId"`
+	Credential WebAuthnAssertionCredential `json:"credential"`
+}
+
+// Validate validates the login finish DTO
+func (w WebAuthnLoginFinish) Validate() []string {
+	var errs []string
+	if w.SessionID == "" {
+		errs = append(errs, "sessionId is required")
+	}
+	if w.Credential.RawID == "" {
+		errs = append(errs, "credential.rawId is required")
+	}
+	if w.Credential.Type != WebAuthnPublicKeyType {
+		errs = append(errs, "credential.type must be public-key")
+	}
+	response := w.Credential.Response
+	if response.ClientDataJSON == "" || response.AuthenticatorData == "" || response.Signature == "" {
+		errs = append(errs, "credential.response is required")
+	}
+	return errs
+}
+
+// WebAuthnVerifiedCredential is a registration verified by the WebAuthn provider.
+// PublicKey is the COSE encoded public key of the credential.
+type WebAuthnVerifiedCredential struct {
+	CredentialID []byte
+	PublicKey    []byte
+	SignCount    uint32
+	AAGUID       []byte
+}
+
+// WebAuthnSession is the pending ceremony of a user, kept until it expires
+// or is completed. UserID is zero for logins with discoverable passkeys.
+type WebAuthnSession struct {
+	UserID    uint      `json:"userId"`
+	Challenge []byte    `json:"challenge"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
@@ -0,0 +1,32 @@
+package authmocks
+
+import (
+	"time"
+
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// APIKeyValue is the key of the mock API key
+const APIKeyValue = sharedmodels.APIKeyPrefix + "abcdefgh12345678"
+
+// APIKeyHash is the hash of the mock API key
+var APIKeyHash = []byte("hashed_api_key")
+
+// APIKey returns a mock active API key of the user 1 for testing
+func APIKey() *sharedmodels.APIKey {
+	return &sharedmodels.APIKey{
+		APIKeyBase: sharedmodels.APIKeyBase{
+			UserID:    1,
+			Name:      "CI",
+			Prefix:    APIKeyValue[:sharedmodels.APIKeyDisplayLength],
+			Hash:      APIKeyHash,
+			Scopes:    []string{sharedmodels.OAuthScopeProfile},
+			ExpiresAt: time.Now().Add(30 * 24 * time.Hour),
+		},
+		DBBaseModel: sharedmodels.DBBaseModel{
+			ID:        1,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		},
+	}
+}
@@ -0,0 +1,30 @@
+package authmocks
+
+import (
+	"time"
+
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// ImpersonationTokenID is the ID of the access token of the mock
+// impersonation
+const ImpersonationTokenID = "impersonation-token-id"
+
+// Impersonation returns a mock active impersonation of the user 1 by the
+// admin 2 for testing
+func Impersonation() *sharedmodels.Impersonation {
+	return &sharedmodels.Impersonation{
+		ImpersonationBase: sharedmodels.ImpersonationBase{
+			AdminID:   2,
+			UserID:    1,
+			TokenID:   ImpersonationTokenID,
+			Reason:    "Support ticket",
+			ExpiresAt: time.Now().Add(15 * time.Minute),
+		},
+		DBBaseModel: sharedmodels.DBBaseModel{
+			ID:        1,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		},
+	}
+}
@@ -0,0 +1,29 @@
+package authmocks
+
+import (
+	"time"
+
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// TOTPSecret is the plain secret of the mock TOTP device
+const TOTPSecret = "JBSWY3DPEHPK3PXP"
+
+// EncryptedTOTPSecret is the encrypted secret of the mock TOTP device
+var EncryptedTOTPSecret = []byte("encrypted_totp_secret")
+
+// TOTPDevice returns a mock confirmed TOTP device for testing
+func TOTPDevice() *sharedmodels.TOTPDevice {
+	return &sharedmodels.TOTPDevice{
+		TOTPDeviceBase: sharedmodels.TOTPDeviceBase{
+			UserID:      1,
+			Secret:      EncryptedTOTPSecret,
+			IsConfirmed: true,
+		},
+		DBBaseModel: sharedmodels.DBBaseModel{
+			ID:        1,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		},
+	}
+}
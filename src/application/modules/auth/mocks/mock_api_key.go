@@ -0,0 +1,48 @@
+package authmocks
+
+import (
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	repositoriesmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/repositories"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// MockAPIKeyRepository is the mock implementation of the API key repository
+type MockAPIKeyRepository struct {
+	repositoriesmocks.MockRepositoryBase[authdtos.APIKeyCreate, authdtos.APIKeyUpdate, sharedmodels.APIKey, sharedmodels.APIKey]
+}
+
+var _ authcontracts.IAPIKeyRepository = (*MockAPIKeyRepository)(nil)
+
+// GetByHash gets an API key by the hash of the key
+func (m *MockAPIKeyRepository) GetByHash(hash []byte) (*sharedmodels.APIKey, *applicationerrors.ApplicationError) {
+	args := m.Called(hash)
+	errorArg := args.Get(1)
+	if errorArg != nil {
+		return nil, errorArg.(*applicationerrors.ApplicationError)
+	}
+	if args.Get(0) == nil {
+		return nil, nil
+	}
+	return args.Get(0).(*sharedmodels.APIKey), nil
+}
+
+// GetByUserID gets the API keys of a user
+func (m *MockAPIKeyRepository) GetByUserID(userID uint) ([]sharedmodels.APIKey, *applicationerrors.ApplicationError) {
+	args := m.Called(userID)
+	errorArg := args.Get(1)
+	if errorArg != nil {
+		return nil, errorArg.(*applicationerrors.ApplicationError)
+	}
+	return args.Get(0).([]sharedmodels.APIKey), nil
+}
+
+// Touch records a use of the key
+func (m *MockAPIKeyRepository) Touch(id uint) *applicationerrors.ApplicationError {
+	args := m.Called(id)
+	if errorArg := args.Get(0); errorArg != nil {
+		return errorArg.(*applicationerrors.ApplicationError)
+	}
+	return nil
+}
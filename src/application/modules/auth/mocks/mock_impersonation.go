@@ -0,0 +1,30 @@
+package authmocks
+
+import (
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	repositoriesmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/repositories"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// MockImpersonationRepository is the mock implementation of the
+// impersonation repository
+type MockImpersonationRepository struct {
+	repositoriesmocks.MockRepositoryBase[authdtos.ImpersonationCreate, authdtos.ImpersonationUpdate, sharedmodels.Impersonation, sharedmodels.Impersonation]
+}
+
+var _ authcontracts.IImpersonationRepository = (*MockImpersonationRepository)(nil)
+
+// GetByTokenID gets an impersonation by the ID of its access token
+func (m *MockImpersonationRepository) GetByTokenID(tokenID string) (*sharedmodels.Impersonation, *applicationerrors.ApplicationError) {
+	args := m.Called(tokenID)
+	errorArg := args.Get(1)
+	if errorArg != nil {
+		return nil, errorArg.(*applicationerrors.ApplicationError)
+	}
+	if args.Get(0) == nil {
+		return nil, nil
+	}
+	return args.Get(0).(*sharedmodels.Impersonation), nil
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
 	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
 
 	"github.com/stretchr/testify/mock"
@@ -46,3 +47,9 @@ func (m *MockJWTProvider) ParseTokenAndValidate(tokenString string) (authcontrac
 	}
 	return args.Get(0).(authcontracts.JWTCLaims), nil
 }
+
+// PublicKeys returns the public keys that verify the tokens at the given time
+func (m *MockJWTProvider) PublicKeys(at time.Time) authdtos.JWKS {
+	args := m.Called(at)
+	return args.Get(0).(authdtos.JWKS)
+}
@@ -0,0 +1,76 @@
+package authmocks
+
+import (
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	repositoriesmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/repositories"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// MockTOTPDeviceRepository is the mock implementation of the TOTP device repository
+type MockTOTPDeviceRepository struct {
+	repositoriesmocks.MockRepositoryBase[dtos.TOTPDeviceCreate, dtos.TOTPDeviceUpdate, sharedmodels.TOTPDevice, sharedmodels.TOTPDevice]
+}
+
+// GetByUserID gets the TOTP device of a user
+func (m *MockTOTPDeviceRepository) GetByUserID(userID uint) (*sharedmodels.TOTPDevice, *applicationerrors.ApplicationError) {
+	args := m.Called(userID)
+	errorArg := args.Get(1)
+	if errorArg != nil {
+		return nil, errorArg.(*applicationerrors.ApplicationError)
+	}
+	if args.Get(0) == nil {
+		return nil, nil
+	}
+	return args.Get(0).(*sharedmodels.TOTPDevice), nil
+}
+
+// DeleteByUserID deletes the TOTP device of a user
+func (m *MockTOTPDeviceRepository) DeleteByUserID(userID uint) *applicationerrors.ApplicationError {
+	args := m.Called(userID)
+	errorArg := args.Get(0)
+	if errorArg != nil {
+		return errorArg.(*applicationerrors.ApplicationError)
+	}
+	return nil
+}
+
+// UseStep records the time step of an accepted code
+func (m *MockTOTPDeviceRepository) UseStep(id uint, step int64) (bool, *applicationerrors.ApplicationError) {
+	args := m.Called(id, step)
+	errorArg := args.Get(1)
+	if errorArg != nil {
+		return false, errorArg.(*applicationerrors.ApplicationError)
+	}
+	return args.Bool(0), nil
+}
+
+var _ authcontracts.ITOTPDeviceRepository = (*MockTOTPDeviceRepository)(nil)
+
+// MockRecoveryCodeRepository is the mock implementation of the recovery code repository
+type MockRecoveryCodeRepository struct {
+	repositoriesmocks.MockRepositoryBase[dtos.RecoveryCodeCreate, dtos.RecoveryCodeUpdate, sharedmodels.RecoveryCode, sharedmodels.RecoveryCode]
+}
+
+// DeleteByUserID deletes every recovery code of a user
+func (m *MockRecoveryCodeRepository) DeleteByUserID(userID uint) *applicationerrors.ApplicationError {
+	args := m.Called(userID)
+	errorArg := args.Get(0)
+	if errorArg != nil {
+		return errorArg.(*applicationerrors.ApplicationError)
+	}
+	return nil
+}
+
+// Use marks a recovery code as used
+func (m *MockRecoveryCodeRepository) Use(userID uint, hash []byte) (bool, *applicationerrors.ApplicationError) {
+	args := m.Called(userID, hash)
+	errorArg := args.Get(1)
+	if errorArg != nil {
+		return false, errorArg.(*applicationerrors.ApplicationError)
+	}
+	return args.Bool(0), nil
+}
+
+var _ authcontracts.IRecoveryCodeRepository = (*MockRecoveryCodeRepository)(nil)
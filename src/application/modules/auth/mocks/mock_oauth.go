@@ -0,0 +1,49 @@
+package authmocks
+
+import (
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	repositoriesmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/repositories"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// MockOAuthClientRepository is the mock implementation of the OAuth client repository
+type MockOAuthClientRepository struct {
+	repositoriesmocks.MockRepositoryBase[authdtos.OAuthClientCreate, authdtos.OAuthClientUpdate, sharedmodels.OAuthClient, sharedmodels.OAuthClient]
+}
+
+var _ authcontracts.IOAuthClientRepository = (*MockOAuthClientRepository)(nil)
+
+// GetByClientID gets a client by its client ID
+func (m *MockOAuthClientRepository) GetByClientID(clientID string) (*sharedmodels.OAuthClient, *applicationerrors.ApplicationError) {
+	args := m.Called(clientID)
+	errorArg := args.Get(1)
+	if errorArg != nil {
+		return nil, errorArg.(*applicationerrors.ApplicationError)
+	}
+	if args.Get(0) == nil {
+		return nil, nil
+	}
+	return args.Get(0).(*sharedmodels.OAuthClient), nil
+}
+
+// MockOAuthConsentRepository is the mock implementation of the OAuth consent repository
+type MockOAuthConsentRepository struct {
+	repositoriesmocks.MockRepositoryBase[authdtos.OAuthConsentCreate, authdtos.OAuthConsentUpdate, sharedmodels.OAuthConsent, sharedmodels.OAuthConsent]
+}
+
+var _ authcontracts.IOAuthConsentRepository = (*MockOAuthConsentRepository)(nil)
+
+// GetByUserAndClient gets the consent of a user to a client
+func (m *MockOAuthConsentRepository) GetByUserAndClient(userID uint, clientID string) (*sharedmodels.OAuthConsent, *applicationerrors.ApplicationError) {
+	args := m.Called(userID, clientID)
+	errorArg := args.Get(1)
+	if errorArg != nil {
+		return nil, errorArg.(*applicationerrors.ApplicationError)
+	}
+	if args.Get(0) == nil {
+		return nil, nil
+	}
+	return args.Get(0).(*sharedmodels.OAuthConsent), nil
+}
@@ -0,0 +1,60 @@
+package authmocks
+
+import (
+	"context"
+
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	repositoriesmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/repositories"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockExternalIdentityRepository is the mock implementation of the external identity repository
+type MockExternalIdentityRepository struct {
+	repositoriesmocks.MockRepositoryBase[authdtos.ExternalIdentityCreate, authdtos.ExternalIdentityUpdate, sharedmodels.ExternalIdentity, sharedmodels.ExternalIdentity]
+}
+
+// GetByProviderSubject gets the identity of an account at an identity provider
+func (m *MockExternalIdentityRepository) GetByProviderSubject(provider string, subject string) (*sharedmodels.ExternalIdentity, *applicationerrors.ApplicationError) {
+	args := m.Called(provider, subject)
+	errorArg := args.Get(1)
+	if errorArg != nil {
+		return nil, errorArg.(*applicationerrors.ApplicationError)
+	}
+	if args.Get(0) == nil {
+		return nil, nil
+	}
+	return args.Get(0).(*sharedmodels.ExternalIdentity), nil
+}
+
+var _ authcontracts.IExternalIdentityRepository = (*MockExternalIdentityRepository)(nil)
+
+// MockOIDCProvider is the mock implementation of the OIDC provider
+type MockOIDCProvider struct {
+	mock.Mock
+}
+
+var _ authcontracts.IOIDCProvider = (*MockOIDCProvider)(nil)
+
+// AuthorizationURL builds the authorization URL
+func (m *MockOIDCProvider) AuthorizationURL(ctx context.Context, provider string, state string, nonce string, codeVerifier string) (string, *applicationerrors.ApplicationError) {
+	args := m.Called(ctx, provider, state, nonce, codeVerifier)
+	errorArg := args.Get(1)
+	if errorArg != nil {
+		return "", errorArg.(*applicationerrors.ApplicationError)
+	}
+	return args.String(0), nil
+}
+
+// Exchange exchanges the code for the claims of the ID token
+func (m *MockOIDCProvider) Exchange(ctx context.Context, provider string, code string, codeVerifier string) (*authdtos.OIDCClaims, *applicationerrors.ApplicationError) {
+	args := m.Called(ctx, provider, code, codeVerifier)
+	errorArg := args.Get(1)
+	if errorArg != nil {
+		return nil, errorArg.(*applicationerrors.ApplicationError)
+	}
+	return args.Get(0).(*authdtos.OIDCClaims), nil
+}
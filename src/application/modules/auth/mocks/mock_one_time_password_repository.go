@@ -23,4 +23,34 @@ func (m *MockOneTimePasswordRepository) GetByPasswordHash(tokenHash []byte) (*sh
 	return args.Get(0).(*sharedmodels.OneTimePassword), nil
 }
 
+// GetByTransactionHash gets a one time password by the hash of its transaction
+func (m *MockOneTimePasswordRepository) GetByTransactionHash(transactionHash []byte) (*sharedmodels.OneTimePassword, *applicationerrors.ApplicationError) {
+	args := m.Called(transactionHash)
+	errorArg := args.Get(1)
+	if errorArg != nil {
+		return nil, errorArg.(*applicationerrors.ApplicationError)
+	}
+	return args.Get(0).(*sharedmodels.OneTimePassword), nil
+}
+
+// IncrementAttempts records a failed attempt
+func (m *MockOneTimePasswordRepository) IncrementAttempts(id uint, maxAttempts int) (bool, *applicationerrors.ApplicationError) {
+	args := m.Called(id, maxAttempts)
+	errorArg := args.Get(1)
+	if errorArg != nil {
+		return false, errorArg.(*applicationerrors.ApplicationError)
+	}
+	return args.Bool(0), nil
+}
+
+// Use marks a one time password as used
+func (m *MockOneTimePasswordRepository) Use(id uint) (bool, *applicationerrors.ApplicationError) {
+	args := m.Called(id)
+	errorArg := args.Get(1)
+	if errorArg != nil {
+		return false, errorArg.(*applicationerrors.ApplicationError)
+	}
+	return args.Bool(0), nil
+}
+
 var _ authcontracts.IOneTimePasswordRepository = (*MockOneTimePasswordRepository)(nil)
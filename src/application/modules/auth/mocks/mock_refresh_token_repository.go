@@ -0,0 +1,56 @@
+package authmocks
+
+import (
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	repositoriesmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/repositories"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// MockRefreshTokenRepository is the mock implementation of the refresh token repository
+type MockRefreshTokenRepository struct {
+	repositoriesmocks.MockRepositoryBase[dtos.RefreshTokenCreate, dtos.RefreshTokenUpdate, sharedmodels.RefreshToken, sharedmodels.RefreshToken]
+}
+
+// GetByTokenHash gets a refresh token by its hash
+func (m *MockRefreshTokenRepository) GetByTokenHash(tokenHash []byte) (*sharedmodels.RefreshToken, *applicationerrors.ApplicationError) {
+	args := m.Called(tokenHash)
+	errorArg := args.Get(1)
+	if errorArg != nil {
+		return nil, errorArg.(*applicationerrors.ApplicationError)
+	}
+	return args.Get(0).(*sharedmodels.RefreshToken), nil
+}
+
+// Rotate marks a refresh token as rotated
+func (m *MockRefreshTokenRepository) Rotate(id uint) (bool, *applicationerrors.ApplicationError) {
+	args := m.Called(id)
+	errorArg := args.Get(1)
+	if errorArg != nil {
+		return false, errorArg.(*applicationerrors.ApplicationError)
+	}
+	return args.Bool(0), nil
+}
+
+// RevokeFamily revokes every token of a session
+func (m *MockRefreshTokenRepository) RevokeFamily(familyID string) *applicationerrors.ApplicationError {
+	args := m.Called(familyID)
+	errorArg := args.Get(0)
+	if errorArg != nil {
+		return errorArg.(*applicationerrors.ApplicationError)
+	}
+	return nil
+}
+
+// RevokeAllByUser revokes every session of a user
+func (m *MockRefreshTokenRepository) RevokeAllByUser(userID uint) *applicationerrors.ApplicationError {
+	args := m.Called(userID)
+	errorArg := args.Get(0)
+	if errorArg != nil {
+		return errorArg.(*applicationerrors.ApplicationError)
+	}
+	return nil
+}
+
+var _ authcontracts.IRefreshTokenRepository = (*MockRefreshTokenRepository)(nil)
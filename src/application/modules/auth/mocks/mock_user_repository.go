@@ -34,3 +34,13 @@ func (m *MockUserRepository) GetByEmailOrPhone(emailOrPhone string) (*usermodels
 	}
 	return args.Get(0).(*usermodels.User), nil
 }
+
+// Delete hard deletes a user
+func (m *MockUserRepository) Delete(id uint) *applicationerrors.ApplicationError {
+	args := m.Called(id)
+	errorArg := args.Get(0)
+	if errorArg != nil {
+		return errorArg.(*applicationerrors.ApplicationError)
+	}
+	return nil
+}
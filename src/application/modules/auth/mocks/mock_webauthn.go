@@ -0,0 +1,97 @@
+package authmocks
+
+import (
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	repositoriesmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/repositories"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockWebAuthnCredentialRepository is the mock implementation of the passkey repository
+type MockWebAuthnCredentialRepository struct {
+	repositoriesmocks.MockRepositoryBase[authdtos.WebAuthnCredentialCreate, authdtos.WebAuthnCredentialUpdate, sharedmodels.WebAuthnCredential, sharedmodels.WebAuthnCredential]
+}
+
+// GetByCredentialID gets a passkey by its credential ID
+func (m *MockWebAuthnCredentialRepository) GetByCredentialID(credentialID []byte) (*sharedmodels.WebAuthnCredential, *applicationerrors.ApplicationError) {
+	args := m.Called(credentialID)
+	errorArg := args.Get(1)
+	if errorArg != nil {
+		return nil, errorArg.(*applicationerrors.ApplicationError)
+	}
+	if args.Get(0) == nil {
+		return nil, nil
+	}
+	return args.Get(0).(*sharedmodels.WebAuthnCredential), nil
+}
+
+// GetByUserID gets the passkeys of a user
+func (m *MockWebAuthnCredentialRepository) GetByUserID(userID uint) ([]sharedmodels.WebAuthnCredential, *applicationerrors.ApplicationError) {
+	args := m.Called(userID)
+	errorArg := args.Get(1)
+	if errorArg != nil {
+		return nil, errorArg.(*applicationerrors.ApplicationError)
+	}
+	if args.Get(0) == nil {
+		return nil, nil
+	}
+	return args.Get(0).([]sharedmodels.WebAuthnCredential), nil
+}
+
+// UseSignCount records a login with the passkey
+func (m *MockWebAuthnCredentialRepository) UseSignCount(id uint, signCount uint32) (bool, *applicationerrors.ApplicationError) {
+	args := m.Called(id, signCount)
+	errorArg := args.Get(1)
+	if errorArg != nil {
+		return false, errorArg.(*applicationerrors.ApplicationError)
+	}
+	return args.Bool(0), nil
+}
+
+var _ authcontracts.IWebAuthnCredentialRepository = (*MockWebAuthnCredentialRepository)(nil)
+
+// MockWebAuthnProvider is the mock implementation of the WebAuthn provider
+type MockWebAuthnProvider struct {
+	mock.Mock
+}
+
+var _ authcontracts.IWebAuthnProvider = (*MockWebAuthnProvider)(nil)
+
+// RelyingParty returns the relying party
+func (m *MockWebAuthnProvider) RelyingParty() authdtos.WebAuthnRelyingParty {
+	args := m.Called()
+	return args.Get(0).(authdtos.WebAuthnRelyingParty)
+}
+
+// NewChallenge generates a challenge
+func (m *MockWebAuthnProvider) NewChallenge() ([]byte, *applicationerrors.ApplicationError) {
+	args := m.Called()
+	errorArg := args.Get(1)
+	if errorArg != nil {
+		return nil, errorArg.(*applicationerrors.ApplicationError)
+	}
+	return args.Get(0).([]byte), nil
+}
+
+// VerifyRegistration verifies a new credential
+func (m *MockWebAuthnProvider) VerifyRegistration(challenge []byte, credential authdtos.WebAuthnRegistrationCredential) (*authdtos.WebAuthnVerifiedCredential, *applicationerrors.ApplicationError) {
+	args := m.Called(challenge, credential)
+	errorArg := args.Get(1)
+	if errorArg != nil {
+		return nil, errorArg.(*applicationerrors.ApplicationError)
+	}
+	return args.Get(0).(*authdtos.WebAuthnVerifiedCredential), nil
+}
+
+// VerifyAssertion verifies a login assertion
+func (m *MockWebAuthnProvider) VerifyAssertion(challenge []byte, publicKey []byte, credential authdtos.WebAuthnAssertionCredential) (uint32, *applicationerrors.ApplicationError) {
+	args := m.Called(challenge, publicKey, credential)
+	errorArg := args.Get(1)
+	if errorArg != nil {
+		return 0, errorArg.(*applicationerrors.ApplicationError)
+	}
+	return args.Get(0).(uint32), nil
+}
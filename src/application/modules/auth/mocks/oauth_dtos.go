@@ -0,0 +1,34 @@
+package authmocks
+
+import (
+	"time"
+
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// OAuthClientSecretHash is the hash of the secret of the mock OAuth client
+var OAuthClientSecretHash = []byte("hashed_client_secret")
+
+// OAuthClient returns a mock confidential third-party OAuth client for testing
+func OAuthClient() *sharedmodels.OAuthClient {
+	return &sharedmodels.OAuthClient{
+		OAuthClientBase: sharedmodels.OAuthClientBase{
+			Name:         "Test App",
+			ClientID:     "client",
+			SecretHash:   OAuthClientSecretHash,
+			RedirectURIs: []string{"https://app.example.com/callback"},
+			Scopes:       []string{sharedmodels.OAuthScopeProfile, sharedmodels.OAuthScopeProfileWrite},
+			GrantTypes: []string{
+				sharedmodels.OAuthGrantAuthorizationCode,
+				sharedmodels.OAuthGrantRefreshToken,
+				sharedmodels.OAuthGrantClientCredentials,
+			},
+			OwnerID: 1,
+		},
+		DBBaseModel: sharedmodels.DBBaseModel{
+			ID:        1,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		},
+	}
+}
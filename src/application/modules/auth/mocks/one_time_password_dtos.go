@@ -8,13 +8,17 @@ import (
 	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
 )
 
+// OTPTransactionHash is a mock hash of a login transaction ID for testing
+var OTPTransactionHash = []byte("hashed_transaction")
+
 // OneTimePasswordBase is a mock one-time password base for testing
 var OneTimePasswordBase = sharedmodels.OneTimePasswordBase{
-	UserID:  1,
-	Purpose: sharedmodels.OneTimePasswordLogin,
-	Hash:    []byte(hex.EncodeToString([]byte("hashed_otp"))),
-	IsUsed:  false,
-	Expires: time.Now().Add(10 * time.Minute),
+	UserID:          1,
+	Purpose:         sharedmodels.OneTimePasswordLogin,
+	Hash:            []byte(hex.EncodeToString([]byte("hashed_otp"))),
+	TransactionHash: OTPTransactionHash,
+	IsUsed:          false,
+	Expires:         time.Now().Add(10 * time.Minute),
 }
 
 var OneTimePasswordCreate = dtos.OneTimePasswordCreate{
@@ -34,11 +38,12 @@ var OneTimePassword = sharedmodels.OneTimePassword{
 // ExpiredOneTimePassword is a mock expired one-time password for testing
 var ExpiredOneTimePassword = sharedmodels.OneTimePassword{
 	OneTimePasswordBase: sharedmodels.OneTimePasswordBase{
-		UserID:  1,
-		Purpose: sharedmodels.OneTimePasswordLogin,
-		Hash:    []byte(hex.EncodeToString([]byte("hashed_otp"))),
-		IsUsed:  false,
-		Expires: time.Now().Add(-10 * time.Minute),
+		UserID:          1,
+		Purpose:         sharedmodels.OneTimePasswordLogin,
+		Hash:            []byte(hex.EncodeToString([]byte("hashed_otp"))),
+		TransactionHash: OTPTransactionHash,
+		IsUsed:          false,
+		Expires:         time.Now().Add(-10 * time.Minute),
 	},
 	DBBaseModel: sharedmodels.DBBaseModel{
 		ID:        1,
@@ -0,0 +1,27 @@
+package authmocks
+
+import (
+	"time"
+
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// RefreshTokenHash is the hash of the mock refresh token
+var RefreshTokenHash = []byte("hashed_refresh_token")
+
+// RefreshToken returns a mock active refresh token for testing
+func RefreshToken() *sharedmodels.RefreshToken {
+	return &sharedmodels.RefreshToken{
+		RefreshTokenBase: sharedmodels.RefreshTokenBase{
+			UserID:   1,
+			FamilyID: "family",
+			Hash:     RefreshTokenHash,
+			Expires:  time.Now().Add(24 * time.Hour),
+		},
+		DBBaseModel: sharedmodels.DBBaseModel{
+			ID:        1,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		},
+	}
+}
@@ -0,0 +1,32 @@
+package authmocks
+
+import (
+	"time"
+
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// WebAuthnCredentialID is the credential ID of the mock passkey
+var WebAuthnCredentialID = []byte("webauthn_credential_id")
+
+// WebAuthnPublicKey is the COSE public key of the mock passkey
+var WebAuthnPublicKey = []byte("webauthn_public_key")
+
+// WebAuthnCredential returns a mock passkey of the mock user for testing
+func WebAuthnCredential() *sharedmodels.WebAuthnCredential {
+	return &sharedmodels.WebAuthnCredential{
+		WebAuthnCredentialBase: sharedmodels.WebAuthnCredentialBase{
+			UserID:       1,
+			Name:         "Laptop",
+			CredentialID: WebAuthnCredentialID,
+			PublicKey:    WebAuthnPublicKey,
+			SignCount:    5,
+			Transports:   []string{"internal"},
+		},
+		DBBaseModel: sharedmodels.DBBaseModel{
+			ID:        1,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		},
+	}
+}
@@ -2,14 +2,36 @@
 package authpipes
 
 import (
+	"time"
+
 	authusecases "github.com/simon3640/goprojectskeleton/src/application/modules/auth/use_cases"
 	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
 
 	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
 	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
 	workers "github.com/simon3640/goprojectskeleton/src/application/shared/workers"
 )
 
+// GetResetPasswordPipeName is the name of the get reset password pipe in the pipe registry.
+const GetResetPasswordPipeName = "get-reset-password"
+
+func init() {
+	usecase.RegisterPipe(GetResetPasswordPipeName, func() usecase.DagGraph {
+		return NewGetResetPasswordPipe(app_context.NewVoidAppContext(), locales.EN_US, nil, nil).Describe()
+	})
+}
+
+// sendEmailPolicy retries the errors of the email provider. A send that times
+// out is not retried, since the email may have been sent anyway.
+var sendEmailPolicy = usecase.StepPolicy{
+	MaxAttempts:       3,
+	Backoff:           500 * time.Millisecond,
+	BackoffMultiplier: 2,
+	RetryOn:           []status.ApplicationStatusEnum{status.ProviderError},
+	Timeout:           10 * time.Second,
+}
+
 // NewGetResetPasswordPipe creates a new get reset password pipe.
 // El token se genera de forma síncrona, y el email se envía en background.
 // Esto permite retornar la respuesta inmediatamente sin esperar el envío del email.
@@ -22,5 +44,6 @@ func NewGetResetPasswordPipe(
 ) *usecase.DAG[string, bool] {
 	dag := usecase.NewDag(ctx, usecase.NewStep(getResetPasswordTokenUC), locale, workers.GetBackgroundExecutor())
 	// The email is sent in background, the response is returned immediately with the token
-	return usecase.ThenBackground(dag, usecase.NewStep(getResetPasswordTokenSendEmailUC), "get-reset-password-send-email")
+	sendEmail := usecase.WithPolicy(usecase.NewStep(getResetPasswordTokenSendEmailUC), sendEmailPolicy)
+	return usecase.ThenBackground(dag, sendEmail, "get-reset-password-send-email")
 }
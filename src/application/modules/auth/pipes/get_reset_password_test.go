@@ -0,0 +1,20 @@
+package authpipes
+
+import (
+	"testing"
+
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetResetPasswordPipe_Describe(t *testing.T) {
+	assert := assert.New(t)
+
+	graph, ok := usecase.GetPipe(GetResetPasswordPipeName)
+	assert.True(ok)
+	assert.Equal(usecase.DagNodeBackground, graph.Nodes[1].Kind)
+	assert.Equal("get-reset-password-send-email", graph.Nodes[1].Name)
+	// The email provider errors are retried
+	assert.Contains(graph.Nodes[1].Policy, "attempts=3")
+}
@@ -0,0 +1,38 @@
+package authpipes
+
+import (
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authusecases "github.com/simon3640/goprojectskeleton/src/application/modules/auth/use_cases"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	workers "github.com/simon3640/goprojectskeleton/src/application/shared/workers"
+)
+
+// LoginPipeName is the name of the login pipe in the pipe registry.
+const LoginPipeName = "login"
+
+func init() {
+	usecase.RegisterPipe(LoginPipeName, func() usecase.DagGraph {
+		return NewLoginPipe(app_context.NewVoidAppContext(), locales.EN_US, nil, nil, nil).Describe()
+	})
+}
+
+// NewLoginPipe creates a new login pipe.
+// The credentials are verified first. The users with OTP login enabled, and
+// no authenticator app code verified, get the transaction of the OTP sent by
+// email; the other users get their tokens.
+func NewLoginPipe(
+	ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	authenticateUC *authusecases.AuthenticateUseCase,
+	startOTPLoginUC *authusecases.StartOTPLoginUseCase,
+	issueLoginTokensUC *authusecases.IssueLoginTokensUseCase,
+) *usecase.DAG[authdtos.UserCredentials, authdtos.AuthResponse] {
+	dag := usecase.NewDag(ctx, usecase.NewStep(authenticateUC), locale, workers.GetBackgroundExecutor())
+	return usecase.When(dag, "login-method",
+		authdtos.AuthenticatedUser.RequiresOTP,
+		usecase.NewStep(startOTPLoginUC),
+		usecase.NewStep(issueLoginTokensUC),
+	)
+}
@@ -0,0 +1,109 @@
+package authpipes
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authmocks "github.com/simon3640/goprojectskeleton/src/application/modules/auth/mocks"
+	authusecases "github.com/simon3640/goprojectskeleton/src/application/modules/auth/use_cases"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	passwordmodels "github.com/simon3640/goprojectskeleton/src/domain/password/models"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// executeLogin executes the login pipe with the password "plainPassword" for
+// the given user and returns the result with the mocked OTP repository
+func executeLogin(t *testing.T, otpLogin bool, password string) (*usecase.UseCaseResult[dtos.AuthResponse], *authmocks.MockOneTimePasswordRepository, *authmocks.MockJWTProvider) {
+	t.Helper()
+	ctx := &app_context.AppContext{Context: context.Background()}
+
+	passwordRepository := new(authmocks.MockPasswordRepository)
+	userRepository := new(authmocks.MockUserRepository)
+	otpRepository := new(authmocks.MockOneTimePasswordRepository)
+	refreshTokenRepository := new(authmocks.MockRefreshTokenRepository)
+	hashProvider := new(providersmocks.MockHashProvider)
+	jwtProvider := new(authmocks.MockJWTProvider)
+
+	passwordBase := passwordmodels.PasswordBase{UserID: uint(1), IsActive: true, Hash: "hashedPassword123"}
+	user := dtomocks.UserWithRole
+	user.OTPLogin = otpLogin
+
+	passwordRepository.On("GetActivePassword", "user@example.com").Return(&passwordmodels.Password{PasswordBase: passwordBase, ID: uint(1)}, nil)
+	hashProvider.On("VerifyPassword", passwordBase.Hash, "plainPassword").Return(true, nil)
+	hashProvider.On("VerifyPassword", passwordBase.Hash, mock.Anything).Return(false, nil)
+	userRepository.On("GetUserWithRole", uint(1)).Return(&user, nil)
+
+	hashProvider.On("GenerateOTP").Return("123456", []byte("hashedOTP"), nil)
+	hashProvider.On("OneTimeToken").Return("transaction", authmocks.OTPTransactionHash, nil)
+	otpRepository.On("Create", mock.Anything).Return(&sharedmodels.OneTimePassword{}, nil)
+
+	jwtProvider.On("GenerateAccessToken", ctx, "1", mock.Anything).Return("accessToken", time.Now().Add(1*time.Hour), nil)
+	jwtProvider.On("GenerateRefreshToken", ctx, "1").Return("refreshToken", time.Now().Add(24*time.Hour), nil)
+	hashProvider.On("HashOneTimeToken", "refreshToken").Return(authmocks.RefreshTokenHash)
+	refreshTokenRepository.On("Create", mock.AnythingOfType("authdtos.RefreshTokenCreate")).Return(authmocks.RefreshToken(), nil)
+
+	result := NewLoginPipe(
+		ctx,
+		locales.EN_US,
+		authusecases.NewAuthenticateUseCase(passwordRepository, userRepository, nil, hashProvider, nil, nil),
+		authusecases.NewStartOTPLoginUseCase(otpRepository, hashProvider),
+		authusecases.NewIssueLoginTokensUseCase(refreshTokenRepository, hashProvider, jwtProvider),
+	).Execute(dtos.UserCredentials{Email: "user@example.com", Password: password})
+	return result, otpRepository, jwtProvider
+}
+
+func TestLoginPipe_Tokens(t *testing.T) {
+	assert := assert.New(t)
+
+	result, otpRepository, _ := executeLogin(t, false, "plainPassword")
+
+	assert.True(result.IsSuccess())
+	assert.Equal("accessToken", result.Data.AccessToken)
+	assert.False(result.Data.RequiresOTP)
+	otpRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestLoginPipe_OTPLogin(t *testing.T) {
+	assert := assert.New(t)
+
+	result, _, jwtProvider := executeLogin(t, true, "plainPassword")
+
+	assert.True(result.IsSuccess())
+	assert.Nil(result.Data.Token)
+	assert.True(result.Data.RequiresOTP)
+	assert.Equal("transaction", result.Data.TransactionID)
+	jwtProvider.AssertNotCalled(t, "GenerateAccessToken", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLoginPipe_InvalidCredentials(t *testing.T) {
+	assert := assert.New(t)
+
+	result, otpRepository, jwtProvider := executeLogin(t, true, "wrongPassword")
+
+	assert.False(result.IsSuccess())
+	assert.Equal(status.NotFound, result.StatusCode)
+	otpRepository.AssertNotCalled(t, "Create", mock.Anything)
+	jwtProvider.AssertNotCalled(t, "GenerateAccessToken", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLoginPipe_Describe(t *testing.T) {
+	assert := assert.New(t)
+
+	graph, ok := usecase.GetPipe(LoginPipeName)
+	assert.True(ok)
+	assert.Equal(usecase.DagNodeSwitch, graph.Nodes[1].Kind)
+	assert.Equal("login-method.then", graph.Nodes[2].Name)
+	assert.Equal("*authusecases.StartOTPLoginUseCase", graph.Nodes[2].UseCase)
+	assert.Equal("login-method.else", graph.Nodes[3].Name)
+	assert.Equal("*authusecases.IssueLoginTokensUseCase", graph.Nodes[3].UseCase)
+}
@@ -0,0 +1,18 @@
+package authservices
+
+import (
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
+
+// BuildAccessClaimsService returns the custom claims of the access tokens
+// of the user. Every flow issuing access tokens builds them here so the
+// tokens carry the same claims whether they come from a login or a refresh.
+// The tenant claim is the organization the user acts in, 0 for none.
+func BuildAccessClaimsService(user *usermodels.UserWithRole) authcontracts.JWTCLaims {
+	tenantID, _ := user.GetTenantID()
+	return authcontracts.JWTCLaims{
+		"role":   user.GetRoleKey(),
+		"tenant": tenantID,
+	}
+}
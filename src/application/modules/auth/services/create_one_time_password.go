@@ -9,21 +9,28 @@ import (
 	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
 )
 
+// CreateOneTimePasswordService creates a one time password for the user and
+// returns it with the ID of the transaction it is bound to. Only the hashes
+// of both are stored.
 func CreateOneTimePasswordService(
 	userID uint,
 	purpose sharedmodels.OneTimePasswordPurpose,
 	hashProvider contractsProviders.IHashProvider,
 	passwordRepository authcontracts.IOneTimePasswordRepository,
-) (string, *applicationerrors.ApplicationError) {
+) (string, string, *applicationerrors.ApplicationError) {
 	password, hash, err := hashProvider.GenerateOTP()
 	if err != nil {
-		return "", err
+		return "", "", err
+	}
+	transactionID, transactionHash, err := hashProvider.OneTimeToken()
+	if err != nil {
+		return "", "", err
 	}
 
-	passwordCreate := dtos.NewOneTimePasswordCreate(userID, purpose, hash)
+	passwordCreate := dtos.NewOneTimePasswordCreate(userID, purpose, hash, transactionHash)
 	_, err = passwordRepository.Create(*passwordCreate)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
-	return password, nil
+	return password, transactionID, nil
 }
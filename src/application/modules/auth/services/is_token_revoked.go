@@ -0,0 +1,38 @@
+package authservices
+
+import (
+	"math"
+
+	contractsProviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+)
+
+// IsTokenRevokedService reports whether the token with the given claims was
+// revoked, either by its jti or because every token of the user issued
+// until its iat was revoked. Both times are compared to the microsecond, so
+// a token issued within the second of the revocation, before it, is revoked
+// too. Without provider no token is revoked.
+func IsTokenRevokedService(
+	claims authcontracts.JWTCLaims,
+	userID uint,
+	revocationProvider contractsProviders.ITokenRevocationProvider,
+) (bool, *applicationerrors.ApplicationError) {
+	if revocationProvider == nil {
+		return false, nil
+	}
+
+	if jti, ok := claims["jti"].(string); ok && jti != "" {
+		revoked, err := revocationProvider.IsTokenRevoked(jti)
+		if err != nil || revoked {
+			return revoked, err
+		}
+	}
+
+	revokedBefore, err := revocationProvider.UserTokensRevokedBefore(userID)
+	if err != nil || revokedBefore.IsZero() {
+		return false, err
+	}
+	iat, ok := claims["iat"].(float64)
+	return !ok || int64(math.Round(iat*1e6)) <= revokedBefore.UnixMicro(), nil
+}
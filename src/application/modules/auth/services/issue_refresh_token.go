@@ -0,0 +1,96 @@
+package authservices
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	contractsProviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// IssueRefreshTokenService generates a refresh token for the user and stores
+// its hash in the given family. An empty familyID starts a new session.
+func IssueRefreshTokenService(
+	ctx context.Context,
+	userID uint,
+	familyID string,
+	jwtProvider authcontracts.IJWTProvider,
+	hashProvider contractsProviders.IHashProvider,
+	refreshTokenRepository authcontracts.IRefreshTokenRepository,
+) (string, time.Time, *applicationerrors.ApplicationError) {
+	return IssueClientRefreshTokenService(ctx, userID, familyID, "", "", jwtProvider, hashProvider, refreshTokenRepository)
+}
+
+// IssueClientRefreshTokenService generates a refresh token for the user like
+// IssueRefreshTokenService, bound to the OAuth client and the scope it was
+// granted. An empty clientID issues a token of the login.
+func IssueClientRefreshTokenService(
+	ctx context.Context,
+	userID uint,
+	familyID string,
+	clientID string,
+	scope string,
+	jwtProvider authcontracts.IJWTProvider,
+	hashProvider contractsProviders.IHashProvider,
+	refreshTokenRepository authcontracts.IRefreshTokenRepository,
+) (string, time.Time, *applicationerrors.ApplicationError) {
+	if familyID == "" {
+		family, _, err := hashProvider.OneTimeToken()
+		if err != nil {
+			return "", time.Time{}, err
+		}
+		familyID = family
+	}
+	return issueRefreshToken(ctx, sharedmodels.RefreshTokenBase{
+		UserID:   userID,
+		FamilyID: familyID,
+		ClientID: clientID,
+		Scope:    scope,
+	}, jwtProvider, hashProvider, refreshTokenRepository)
+}
+
+// RenewRefreshTokenService generates the next refresh token of the session
+// the rotated token belongs to, it keeps the client, the scope and the
+// tenant of the session. The tenant can be changed with tenantID.
+func RenewRefreshTokenService(
+	ctx context.Context,
+	session *sharedmodels.RefreshToken,
+	tenantID uint,
+	jwtProvider authcontracts.IJWTProvider,
+	hashProvider contractsProviders.IHashProvider,
+	refreshTokenRepository authcontracts.IRefreshTokenRepository,
+) (string, time.Time, *applicationerrors.ApplicationError) {
+	return issueRefreshToken(ctx, sharedmodels.RefreshTokenBase{
+		UserID:   session.UserID,
+		FamilyID: session.FamilyID,
+		ClientID: session.ClientID,
+		Scope:    session.Scope,
+		TenantID: tenantID,
+	}, jwtProvider, hashProvider, refreshTokenRepository)
+}
+
+func issueRefreshToken(
+	ctx context.Context,
+	session sharedmodels.RefreshTokenBase,
+	jwtProvider authcontracts.IJWTProvider,
+	hashProvider contractsProviders.IHashProvider,
+	refreshTokenRepository authcontracts.IRefreshTokenRepository,
+) (string, time.Time, *applicationerrors.ApplicationError) {
+	refresh, expires, err := jwtProvider.GenerateRefreshToken(ctx, strconv.FormatUint(uint64(session.UserID), 10))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	refreshTokenCreate := dtos.NewRefreshTokenCreate(session.UserID, session.FamilyID, hashProvider.HashOneTimeToken(refresh), expires)
+	refreshTokenCreate.ClientID = session.ClientID
+	refreshTokenCreate.Scope = session.Scope
+	refreshTokenCreate.TenantID = session.TenantID
+	if _, err := refreshTokenRepository.Create(*refreshTokenCreate); err != nil {
+		return "", time.Time{}, err
+	}
+	return refresh, expires, nil
+}
@@ -0,0 +1,206 @@
+package authservices
+
+import (
+	"fmt"
+	"time"
+
+	contractsProviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/settings"
+)
+
+// loginGlobalFailureWindow is the window the failed logins of every client
+// are counted in to detect credential stuffing
+const loginGlobalFailureWindow = time.Minute
+
+// LoginThrottleService limits the failed logins in layers, all of them
+// counted in the cache:
+//   - per IP and account: the attempts are delayed after a few failures and
+//     locked after LoginMaxAttempts, without locking the account for the
+//     other clients
+//   - per IP: an IP spraying passwords over many accounts is locked
+//   - per account: the account is locked for every client and its user is
+//     alerted by email with a link to unlock it
+//   - globally: when the failures of every client exceed the limit a
+//     credential stuffing attack is assumed, the per IP limit is halved and
+//     the attempts are delayed from the first failure
+//
+// A limit set to zero disables its layer.
+type LoginThrottleService struct {
+	cacheProvider contractsProviders.ICacheProvider
+}
+
+// Check returns the message key of the limit reached by the client on the
+// account, or an empty key when the login can be attempted
+func (s *LoginThrottleService) Check(ip string, email string) (messages.MessageKeysEnum, *applicationerrors.ApplicationError) {
+	underAttack, err := s.underAttack()
+	if err != nil {
+		return "", err
+	}
+
+	if ip != "" {
+		exceeded, err := s.exceeded(ipKey(ip), s.maxAttemptsPerIP(underAttack))
+		if err != nil {
+			return "", err
+		}
+		if exceeded {
+			return messages.MessageKeysInstance.LoginMaxAttemptsExceeded, nil
+		}
+	}
+
+	exceeded, err := s.exceeded(accountKey(email), settings.AppSettingsInstance.LoginMaxAttemptsPerAccount)
+	if err != nil {
+		return "", err
+	}
+	if exceeded {
+		return messages.MessageKeysInstance.LOGIN_ACCOUNT_LOCKED, nil
+	}
+
+	exceeded, err = s.exceeded(ipAccountKey(ip, email), settings.AppSettingsInstance.LoginMaxAttempts)
+	if err != nil {
+		return "", err
+	}
+	if exceeded {
+		return messages.MessageKeysInstance.LoginMaxAttemptsExceeded, nil
+	}
+
+	delayed, err := s.exceeded(delayKey(ip, email), 1)
+	if err != nil {
+		return "", err
+	}
+	if delayed {
+		return messages.MessageKeysInstance.LOGIN_DELAY_REQUIRED, nil
+	}
+	return "", nil
+}
+
+// RecordFailure counts a failed login of the client on the account and
+// reports whether it locked the account, so its user is alerted only once
+func (s *LoginThrottleService) RecordFailure(ip string, email string) (bool, *applicationerrors.ApplicationError) {
+	window := loginAttemptsWindow()
+
+	global, err := s.cacheProvider.Increment(globalKey(), loginGlobalFailureWindow)
+	if err != nil {
+		return false, err
+	}
+	limit := settings.AppSettingsInstance.LoginGlobalFailureLimit
+	underAttack := limit > 0 && global >= int64(limit)
+
+	if ip != "" {
+		if _, err := s.cacheProvider.Increment(ipKey(ip), window); err != nil {
+			return false, err
+		}
+	}
+
+	failures, err := s.cacheProvider.Increment(ipAccountKey(ip, email), window)
+	if err != nil {
+		return false, err
+	}
+	if err := s.delay(ip, email, failures, underAttack); err != nil {
+		return false, err
+	}
+
+	accountFailures, err := s.cacheProvider.Increment(accountKey(email), window)
+	if err != nil {
+		return false, err
+	}
+	maxAttempts := settings.AppSettingsInstance.LoginMaxAttemptsPerAccount
+	return maxAttempts > 0 && accountFailures == int64(maxAttempts), nil
+}
+
+// RecordSuccess clears the failures of the client and of the account after a
+// successful login. The failures of the IP on other accounts are kept.
+func (s *LoginThrottleService) RecordSuccess(ip string, email string) *applicationerrors.ApplicationError {
+	for _, key := range []string{accountKey(email), ipAccountKey(ip, email), delayKey(ip, email)} {
+		if err := s.cacheProvider.Delete(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Unlock clears the failures of the account, used by the link sent to its
+// user when the account is locked
+func (s *LoginThrottleService) Unlock(email string) *applicationerrors.ApplicationError {
+	return s.cacheProvider.Delete(accountKey(email))
+}
+
+// delay makes the next attempt of the client on the account wait, the wait
+// doubles with every failure and never exceeds the attempts window
+func (s *LoginThrottleService) delay(ip string, email string, failures int64, underAttack bool) *applicationerrors.ApplicationError {
+	delayAfter := int64(settings.AppSettingsInstance.LoginDelayAfterAttempts)
+	if underAttack {
+		delayAfter = 1
+	}
+	base := time.Duration(settings.AppSettingsInstance.LoginDelaySeconds) * time.Second
+	if delayAfter <= 0 || base <= 0 || failures < delayAfter {
+		return nil
+	}
+
+	maxWait := loginAttemptsWindow()
+	if maxWait <= 0 {
+		maxWait = time.Hour
+	}
+	wait := base
+	for i := delayAfter; i < failures && wait < maxWait; i++ {
+		wait *= 2
+	}
+	return s.cacheProvider.Set(delayKey(ip, email), 1, min(wait, maxWait))
+}
+
+// underAttack reports whether the failed logins of every client exceed the
+// global limit
+func (s *LoginThrottleService) underAttack() (bool, *applicationerrors.ApplicationError) {
+	return s.exceeded(globalKey(), settings.AppSettingsInstance.LoginGlobalFailureLimit)
+}
+
+func (s *LoginThrottleService) maxAttemptsPerIP(underAttack bool) int {
+	maxAttempts := settings.AppSettingsInstance.LoginMaxAttemptsPerIP
+	if underAttack {
+		return max(maxAttempts/2, 1)
+	}
+	return maxAttempts
+}
+
+// exceeded reports whether the counter of the key reached the limit, a limit
+// of zero is never reached
+func (s *LoginThrottleService) exceeded(key string, limit int) (bool, *applicationerrors.ApplicationError) {
+	if limit <= 0 {
+		return false, nil
+	}
+	count, err := s.cacheProvider.GetInt64(key)
+	if err != nil {
+		return false, err
+	}
+	return count >= int64(limit), nil
+}
+
+func loginAttemptsWindow() time.Duration {
+	return time.Duration(settings.AppSettingsInstance.LoginAttemptsWindowMinutes) * time.Minute
+}
+
+func accountKey(email string) string {
+	return fmt.Sprintf("login_attempts:%s", email)
+}
+
+func ipKey(ip string) string {
+	return fmt.Sprintf("login_attempts:ip:%s", ip)
+}
+
+func ipAccountKey(ip string, email string) string {
+	return fmt.Sprintf("login_attempts:ip_account:%s:%s", ip, email)
+}
+
+func delayKey(ip string, email string) string {
+	return fmt.Sprintf("login_delay:%s:%s", ip, email)
+}
+
+func globalKey() string {
+	return "login_failures:global"
+}
+
+// NewLoginThrottleService creates a new login throttle service
+func NewLoginThrottleService(cacheProvider contractsProviders.ICacheProvider) *LoginThrottleService {
+	return &LoginThrottleService{cacheProvider: cacheProvider}
+}
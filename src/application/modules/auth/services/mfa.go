@@ -0,0 +1,138 @@
+package authservices
+
+import (
+	"strings"
+	"time"
+
+	contractsProviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// RecoveryCodesCount is the number of recovery codes issued on enrollment
+const RecoveryCodesCount = 10
+
+// MFAService groups the TOTP multi-factor authentication operations shared
+// by the enrollment and the login use cases. The TOTP secrets are only
+// decrypted to validate a code, the recovery codes are only stored hashed.
+type MFAService struct {
+	totpDeviceRepo   authcontracts.ITOTPDeviceRepository
+	recoveryCodeRepo authcontracts.IRecoveryCodeRepository
+
+	totpProvider       contractsProviders.ITOTPProvider
+	encryptionProvider contractsProviders.IEncryptionProvider
+	hashProvider       contractsProviders.IHashProvider
+}
+
+// GetDevice returns the TOTP device of the user, nil when they never enrolled one
+func (s *MFAService) GetDevice(userID uint) (*sharedmodels.TOTPDevice, *applicationerrors.ApplicationError) {
+	device, err := s.totpDeviceRepo.GetByUserID(userID)
+	if err != nil {
+		if err.Code == status.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return device, nil
+}
+
+// StartEnrollment creates an unconfirmed device with a new secret for the user,
+// replacing a previous enrollment that was never confirmed
+func (s *MFAService) StartEnrollment(userID uint, accountName string) (dtos.OTPEnrollRequest, *applicationerrors.ApplicationError) {
+	if err := s.totpDeviceRepo.DeleteByUserID(userID); err != nil {
+		return dtos.OTPEnrollRequest{}, err
+	}
+
+	secret, err := s.totpProvider.GenerateSecret()
+	if err != nil {
+		return dtos.OTPEnrollRequest{}, err
+	}
+	encryptedSecret, err := s.encryptionProvider.Encrypt([]byte(secret))
+	if err != nil {
+		return dtos.OTPEnrollRequest{}, err
+	}
+	if _, err := s.totpDeviceRepo.Create(*dtos.NewTOTPDeviceCreate(userID, encryptedSecret)); err != nil {
+		return dtos.OTPEnrollRequest{}, err
+	}
+
+	return dtos.OTPEnrollRequest{
+		Secret:     secret,
+		OTPAuthURI: s.totpProvider.ProvisioningURI(secret, accountName),
+	}, nil
+}
+
+// ConfirmDevice enables the device for the logins and issues the recovery codes
+func (s *MFAService) ConfirmDevice(device *sharedmodels.TOTPDevice) ([]string, *applicationerrors.ApplicationError) {
+	if _, err := s.totpDeviceRepo.Update(device.ID, dtos.TOTPDeviceUpdate{IsConfirmed: true, ID: device.ID}); err != nil {
+		return nil, err
+	}
+	return s.IssueRecoveryCodes(device.UserID)
+}
+
+// IssueRecoveryCodes replaces the recovery codes of the user with new ones
+func (s *MFAService) IssueRecoveryCodes(userID uint) ([]string, *applicationerrors.ApplicationError) {
+	if err := s.recoveryCodeRepo.DeleteByUserID(userID); err != nil {
+		return nil, err
+	}
+	codes, err := s.totpProvider.GenerateRecoveryCodes(RecoveryCodesCount)
+	if err != nil {
+		return nil, err
+	}
+	for _, code := range codes {
+		hash := s.hashProvider.HashOneTimeToken(normalizeRecoveryCode(code))
+		if _, err := s.recoveryCodeRepo.Create(*dtos.NewRecoveryCodeCreate(userID, hash)); err != nil {
+			return nil, err
+		}
+	}
+	return codes, nil
+}
+
+// VerifyTOTP reports whether the code is the current code of the device.
+// A code is only accepted once.
+func (s *MFAService) VerifyTOTP(device *sharedmodels.TOTPDevice, code string) (bool, *applicationerrors.ApplicationError) {
+	secret, err := s.encryptionProvider.Decrypt(device.Secret)
+	if err != nil {
+		return false, err
+	}
+	step, valid := s.totpProvider.ValidateCode(string(secret), code, time.Now())
+	if !valid || step <= device.LastUsedStep {
+		return false, nil
+	}
+	return s.totpDeviceRepo.UseStep(device.ID, step)
+}
+
+// VerifyCode reports whether the code is the current code of the device or
+// one of the unused recovery codes of its user, which is then used up
+func (s *MFAService) VerifyCode(device *sharedmodels.TOTPDevice, code string) (bool, *applicationerrors.ApplicationError) {
+	valid, err := s.VerifyTOTP(device, code)
+	if err != nil || valid {
+		return valid, err
+	}
+	return s.recoveryCodeRepo.Use(device.UserID, s.hashProvider.HashOneTimeToken(normalizeRecoveryCode(code)))
+}
+
+// normalizeRecoveryCode ignores the case and the separators users type
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToUpper(code)
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
+
+// NewMFAService creates a new MFA service
+func NewMFAService(
+	totpDeviceRepo authcontracts.ITOTPDeviceRepository,
+	recoveryCodeRepo authcontracts.IRecoveryCodeRepository,
+	totpProvider contractsProviders.ITOTPProvider,
+	encryptionProvider contractsProviders.IEncryptionProvider,
+	hashProvider contractsProviders.IHashProvider,
+) *MFAService {
+	return &MFAService{
+		totpDeviceRepo:     totpDeviceRepo,
+		recoveryCodeRepo:   recoveryCodeRepo,
+		totpProvider:       totpProvider,
+		encryptionProvider: encryptionProvider,
+		hashProvider:       hashProvider,
+	}
+}
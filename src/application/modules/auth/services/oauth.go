@@ -0,0 +1,111 @@
+package authservices
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	contractsProviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// OAuthClientSubjectPrefix prefixes the client ID in the subject of the
+// tokens a client gets for itself with the client_credentials grant
+const OAuthClientSubjectPrefix = "client:"
+
+// OAuthCodeService keeps the authorization codes issued to the OAuth clients
+// in the cache until they expire. A code can only be exchanged once.
+type OAuthCodeService struct {
+	cacheProvider contractsProviders.ICacheProvider
+	ttl           time.Duration
+}
+
+// OAuthCodeKey is the cache key of an authorization code, the key is
+// derived from the hash of the code
+func OAuthCodeKey(codeHash []byte) string {
+	return fmt.Sprintf("oauth_code:%x", codeHash)
+}
+
+// Issue stores a new authorization code under the key
+func (s *OAuthCodeService) Issue(key string, code dtos.OAuthCode) *applicationerrors.ApplicationError {
+	code.ExpiresAt = time.Now().Add(s.ttl)
+	return s.cacheProvider.Set(key, code, s.ttl)
+}
+
+// Consume returns the authorization code under the key and removes it.
+// It returns nil when there is none, it expired or a concurrent request
+// already consumed it.
+func (s *OAuthCodeService) Consume(key string) (*dtos.OAuthCode, *applicationerrors.ApplicationError) {
+	var code dtos.OAuthCode
+	found, err := s.cacheProvider.Get(key, &code)
+	if err != nil {
+		return nil, err
+	}
+	if !found || code.ExpiresAt.Before(time.Now()) {
+		return nil, nil
+	}
+
+	claims, err := s.cacheProvider.Increment(key+":used", s.ttl)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.cacheProvider.Delete(key); err != nil {
+		return nil, err
+	}
+	if claims != 1 {
+		return nil, nil
+	}
+	return &code, nil
+}
+
+// NewOAuthCodeService creates a new OAuth code service
+func NewOAuthCodeService(cacheProvider contractsProviders.ICacheProvider, ttl time.Duration) *OAuthCodeService {
+	return &OAuthCodeService{
+		cacheProvider: cacheProvider,
+		ttl:           ttl,
+	}
+}
+
+// VerifyPKCEService reports whether the code verifier matches the S256 code
+// challenge of the authorization request (RFC 7636 section 4.6)
+func VerifyPKCEService(codeChallenge string, codeVerifier string) bool {
+	sum := sha256.Sum256([]byte(codeVerifier))
+	expected := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(codeChallenge)) == 1
+}
+
+// AuthenticateOAuthClientService returns the client with the credentials.
+// Confidential clients must send their secret, public clients are only
+// identified by their ID.
+func AuthenticateOAuthClientService(
+	credentials dtos.OAuthClientCredentials,
+	clientRepository authcontracts.IOAuthClientRepository,
+	hashProvider contractsProviders.IHashProvider,
+) (*sharedmodels.OAuthClient, *applicationerrors.ApplicationError) {
+	client, err := clientRepository.GetByClientID(credentials.ClientID)
+	if err != nil && err.Code != status.NotFound {
+		return nil, err
+	}
+	if client == nil {
+		return nil, applicationerrors.NewApplicationError(
+			status.Unauthorized,
+			messages.MessageKeysInstance.OAUTH_INVALID_CLIENT,
+			"unknown client",
+		)
+	}
+	if client.IsConfidential() && !hashProvider.ValidateOneTimeToken(client.SecretHash, credentials.ClientSecret) {
+		return nil, applicationerrors.NewApplicationError(
+			status.Unauthorized,
+			messages.MessageKeysInstance.OAUTH_INVALID_CLIENT,
+			"invalid client secret",
+		)
+	}
+	return client, nil
+}
@@ -0,0 +1,64 @@
+package authservices
+
+import (
+	"fmt"
+	"time"
+
+	contractsProviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+)
+
+// OIDCSessionService keeps the pending authorizations with the identity
+// providers in the cache until they expire. A state can only be consumed
+// once, so a replayed redirect of the provider is always rejected.
+type OIDCSessionService struct {
+	cacheProvider contractsProviders.ICacheProvider
+	ttl           time.Duration
+}
+
+// OIDCSessionKey is the cache key of a pending authorization, the key is
+// derived from the hash of the state sent to the provider
+func OIDCSessionKey(stateHash []byte) string {
+	return fmt.Sprintf("oidc_state:%x", stateHash)
+}
+
+// Start stores a new authorization under the key
+func (s *OIDCSessionService) Start(key string, session dtos.OIDCSession) *applicationerrors.ApplicationError {
+	session.ExpiresAt = time.Now().Add(s.ttl)
+	return s.cacheProvider.Set(key, session, s.ttl)
+}
+
+// Consume returns the pending authorization under the key and removes it.
+// It returns nil when there is none, it expired or a concurrent request
+// already consumed it.
+func (s *OIDCSessionService) Consume(key string) (*dtos.OIDCSession, *applicationerrors.ApplicationError) {
+	var session dtos.OIDCSession
+	found, err := s.cacheProvider.Get(key, &session)
+	if err != nil {
+		return nil, err
+	}
+	if !found || session.ExpiresAt.Before(time.Now()) {
+		return nil, nil
+	}
+
+	claims, err := s.cacheProvider.Increment(key+":used", s.ttl)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.cacheProvider.Delete(key); err != nil {
+		return nil, err
+	}
+	if claims != 1 {
+		return nil, nil
+	}
+	return &session, nil
+}
+
+// NewOIDCSessionService creates a new OIDC session service
+func NewOIDCSessionService(cacheProvider contractsProviders.ICacheProvider, ttl time.Duration) *OIDCSessionService {
+	return &OIDCSessionService{
+		cacheProvider: cacheProvider,
+		ttl:           ttl,
+	}
+}
@@ -0,0 +1,65 @@
+package authservices
+
+import (
+	shareddtos "github.com/simon3640/goprojectskeleton/src/application/shared/DTOs"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	services "github.com/simon3640/goprojectskeleton/src/application/shared/services"
+	emailservices "github.com/simon3640/goprojectskeleton/src/application/shared/services/emails"
+	emailmodels "github.com/simon3640/goprojectskeleton/src/application/shared/services/emails/models"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/settings"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/templates"
+)
+
+// Verify that SendAccountLockedEmailBackgroundService implements BackgroundService interface
+var _ services.BackgroundService[shareddtos.OneTimeTokenUser] = (*SendAccountLockedEmailBackgroundService)(nil)
+
+// SendAccountLockedEmailBackgroundService is a background service that alerts
+// a user that their account was locked, with the link to unlock it
+type SendAccountLockedEmailBackgroundService struct {
+	observabilityComponents *observability.ObservabilityComponents
+}
+
+// NewSendAccountLockedEmailBackgroundService creates a new instance of SendAccountLockedEmailBackgroundService
+func NewSendAccountLockedEmailBackgroundService(
+	observabilityComponents *observability.ObservabilityComponents,
+) *SendAccountLockedEmailBackgroundService {
+	return &SendAccountLockedEmailBackgroundService{
+		observabilityComponents: observabilityComponents,
+	}
+}
+
+// Execute implements the BackgroundService interface
+// It sends the unlock link with the one-time token to the user
+func (s *SendAccountLockedEmailBackgroundService) Execute(
+	ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input shareddtos.OneTimeTokenUser,
+) error {
+	emailData := emailmodels.AccountLockedEmailData{
+		Name:              input.User.Name,
+		UnlockLink:        input.BuildURL(settings.AppSettingsInstance.FrontendUnlockAccountURL),
+		ExpirationMinutes: settings.AppSettingsInstance.LoginAttemptsWindowMinutes,
+		AppName:           settings.AppSettingsInstance.AppName,
+		SupportEmail:      settings.AppSettingsInstance.AppSupportEmail,
+	}
+
+	if err := emailservices.AccountLockedEmailServiceInstance.SendWithTemplate(
+		emailData,
+		input.User.Email,
+		locale,
+		templates.TemplateKeysInstance.AccountLockedEmail,
+		emailservices.SubjectKeysInstance.AccountLockedEmail,
+	); err != nil {
+		s.observabilityComponents.Logger.ErrorWithContext("Error sending account locked email in background service", err.ToError(), ctx)
+		return err.ToError()
+	}
+	s.observabilityComponents.Logger.InfoWithContext("Account locked email sent successfully", ctx)
+	return nil
+}
+
+// Name returns the name of the service for logging and tracing
+func (s *SendAccountLockedEmailBackgroundService) Name() string {
+	return "send-account-locked-email"
+}
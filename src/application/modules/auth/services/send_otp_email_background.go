@@ -2,8 +2,6 @@
 package authservices
 
 import (
-	contractproviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
-	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
 	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
@@ -12,62 +10,45 @@ import (
 	emailmodels "github.com/simon3640/goprojectskeleton/src/application/shared/services/emails/models"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/settings"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/templates"
-	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
 )
 
 // Verify that SendOTPEmailBackgroundService implements BackgroundService interface
 var _ services.BackgroundService[SendOTPEmailInput] = (*SendOTPEmailBackgroundService)(nil)
 
 // SendOTPEmailInput is the input for the SendOTPEmailBackgroundService
+// The OTP is created with its login transaction before the email is sent.
 type SendOTPEmailInput struct {
 	UserID   uint
 	Email    string
 	UserName string
+	OTP      string
 }
 
-// SendOTPEmailBackgroundService is a background service that creates an OTP and sends it via email
+// SendOTPEmailBackgroundService is a background service that sends an OTP via email
 type SendOTPEmailBackgroundService struct {
 	observabilityComponents *observability.ObservabilityComponents
-	otpRepo                 authcontracts.IOneTimePasswordRepository
-	hashProvider            contractproviders.IHashProvider
 }
 
 // NewSendOTPEmailBackgroundService creates a new instance of SendOTPEmailBackgroundService
 func NewSendOTPEmailBackgroundService(
 	observabilityComponents *observability.ObservabilityComponents,
-	otpRepo authcontracts.IOneTimePasswordRepository,
-	hashProvider contractproviders.IHashProvider,
 ) *SendOTPEmailBackgroundService {
 	return &SendOTPEmailBackgroundService{
 		observabilityComponents: observabilityComponents,
-		otpRepo:                 otpRepo,
-		hashProvider:            hashProvider,
 	}
 }
 
 // Execute implements the BackgroundService interface
-// It creates an OTP and sends it via email to the user
+// It sends the OTP via email to the user
 func (s *SendOTPEmailBackgroundService) Execute(
 	ctx *app_context.AppContext,
 	locale locales.LocaleTypeEnum,
 	input SendOTPEmailInput,
 ) error {
-	// Create OTP
-	otp, err := CreateOneTimePasswordService(
-		input.UserID,
-		sharedmodels.OneTimePasswordLogin,
-		s.hashProvider,
-		s.otpRepo,
-	)
-	if err != nil {
-		s.observabilityComponents.Logger.ErrorWithContext("Error creating OTP in background service", err.ToError(), ctx)
-		return err.ToError()
-	}
-
 	// Build email data
 	otpEmailData := emailmodels.OneTimePasswordEmailData{
 		Name:              input.UserName,
-		OTPCode:           otp,
+		OTPCode:           input.OTP,
 		ExpirationMinutes: int(settings.AppSettingsInstance.OneTimeTokenPasswordTTL),
 		AppName:           settings.AppSettingsInstance.AppName,
 		SupportEmail:      settings.AppSettingsInstance.AppSupportEmail,
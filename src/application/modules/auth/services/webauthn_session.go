@@ -0,0 +1,78 @@
+package authservices
+
+import (
+	"fmt"
+	"time"
+
+	contractsProviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+)
+
+// WebAuthnSessionService keeps the pending passkey ceremonies in the cache
+// until they expire. A challenge can only be consumed once, so a replayed
+// response of the authenticator is always rejected.
+type WebAuthnSessionService struct {
+	cacheProvider contractsProviders.ICacheProvider
+	ttl           time.Duration
+}
+
+// RegistrationSessionKey is the cache key of the pending passkey registration of a user
+func RegistrationSessionKey(userID uint) string {
+	return fmt.Sprintf("webauthn_registration:%d", userID)
+}
+
+// LoginSessionKey is the cache key of a pending passkey login, the key is
+// derived from the hash of the session ID returned to the client
+func LoginSessionKey(sessionHash []byte) string {
+	return fmt.Sprintf("webauthn_login:%x", sessionHash)
+}
+
+// Start stores a new ceremony for the challenge under the key, replacing a pending one
+func (s *WebAuthnSessionService) Start(key string, userID uint, challenge []byte) *applicationerrors.ApplicationError {
+	session := dtos.WebAuthnSession{
+		UserID:    userID,
+		Challenge: challenge,
+		ExpiresAt: time.Now().Add(s.ttl),
+	}
+	return s.cacheProvider.Set(key, session, s.ttl)
+}
+
+// Consume returns the pending ceremony under the key and removes it.
+// It returns nil when there is none, it expired or a concurrent request
+// already consumed it.
+func (s *WebAuthnSessionService) Consume(key string) (*dtos.WebAuthnSession, *applicationerrors.ApplicationError) {
+	var session dtos.WebAuthnSession
+	found, err := s.cacheProvider.Get(key, &session)
+	if err != nil {
+		return nil, err
+	}
+	if !found || session.ExpiresAt.Before(time.Now()) {
+		return nil, nil
+	}
+
+	claims, err := s.cacheProvider.Increment(fmt.Sprintf("webauthn_challenge_used:%x", session.Challenge), s.ttl)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.cacheProvider.Delete(key); err != nil {
+		return nil, err
+	}
+	if claims != 1 {
+		return nil, nil
+	}
+	return &session, nil
+}
+
+// TTL returns how long the ceremonies are kept
+func (s *WebAuthnSessionService) TTL() time.Duration {
+	return s.ttl
+}
+
+// NewWebAuthnSessionService creates a new WebAuthn session service
+func NewWebAuthnSessionService(cacheProvider contractsProviders.ICacheProvider, ttl time.Duration) *WebAuthnSessionService {
+	return &WebAuthnSessionService{
+		cacheProvider: cacheProvider,
+		ttl:           ttl,
+	}
+}
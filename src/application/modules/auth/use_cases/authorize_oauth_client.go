@@ -0,0 +1,239 @@
+package authusecases
+
+import (
+	"net/url"
+	"slices"
+
+	contractproviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/guards"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// AuthorizeOAuthClientUseCase is the use case for authorizing an OAuth client
+// to act on behalf of the authenticated user with the authorization_code
+// grant. Users are asked for consent once per client and set of scopes,
+// first-party clients do not need it.
+type AuthorizeOAuthClientUseCase struct {
+	usecase.BaseUseCaseValidation[dtos.OAuthAuthorize, dtos.OAuthAuthorization]
+
+	clientRepo  authcontracts.IOAuthClientRepository
+	consentRepo authcontracts.IOAuthConsentRepository
+
+	hashProvider contractproviders.IHashProvider
+	codeService  *authservices.OAuthCodeService
+}
+
+var _ usecase.BaseUseCase[dtos.OAuthAuthorize, dtos.OAuthAuthorization] = (*AuthorizeOAuthClientUseCase)(nil)
+
+// Execute authorizes the client
+// - Get the client: it must be registered for the grant and the redirect URI
+// - Check the scopes: the client can only request the scopes it was registered for, none requests all of them
+// - Check the consent: without a consent covering the scopes the user is asked for it, an accepted consent is recorded
+// - Issue the code: the code is bound to the client, the user, the redirect URI and the PKCE challenge
+func (uc *AuthorizeOAuthClientUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input dtos.OAuthAuthorize,
+) *usecase.UseCaseResult[dtos.OAuthAuthorization] {
+	result := usecase.NewUseCaseResult[dtos.OAuthAuthorization]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	client := uc.getClient(result, input)
+	if result.HasError() {
+		return result
+	}
+
+	scopes := sharedmodels.ParseOAuthScope(input.Scope)
+	if len(scopes) == 0 {
+		scopes = client.Scopes
+	}
+	if !client.AllowsScopes(scopes) {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("OAuth client requested scopes it is not registered for", uc.AppContext)
+		uc.setError(result, status.InvalidInput, messages.MessageKeysInstance.OAUTH_INVALID_SCOPE)
+		return result
+	}
+
+	if !client.FirstParty {
+		consented := uc.checkConsent(result, input, scopes)
+		if result.HasError() {
+			return result
+		}
+		if !consented {
+			result.SetData(
+				status.Success,
+				dtos.OAuthAuthorization{
+					ConsentRequired: true,
+					ClientName:      client.Name,
+					Scopes:          scopes,
+				},
+				uc.AppMessages.Get(
+					uc.Locale,
+					messages.MessageKeysInstance.OAUTH_CONSENT_REQUIRED,
+				),
+			)
+			return result
+		}
+	}
+
+	redirectURI := uc.issueCode(result, input, scopes)
+	if result.HasError() {
+		return result
+	}
+
+	result.SetData(
+		status.Success,
+		dtos.OAuthAuthorization{
+			RedirectURI: redirectURI,
+			ClientName:  client.Name,
+			Scopes:      scopes,
+		},
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.OAUTH_AUTHORIZATION_GRANTED,
+		),
+	)
+	observability.GetObservabilityComponents().Logger.InfoWithContext("OAuth client authorized successfully", uc.AppContext)
+	return result
+}
+
+// getClient returns the client of the request. The redirect URI must be one
+// of the client, otherwise the user could be redirected anywhere with a code.
+func (uc *AuthorizeOAuthClientUseCase) getClient(result *usecase.UseCaseResult[dtos.OAuthAuthorization], input dtos.OAuthAuthorize) *sharedmodels.OAuthClient {
+	client, err := uc.clientRepo.GetByClientID(input.ClientID)
+	if err != nil && err.Code != status.NotFound {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting OAuth client", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return nil
+	}
+	if client == nil {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Unknown OAuth client", uc.AppContext)
+		uc.setError(result, status.InvalidInput, messages.MessageKeysInstance.OAUTH_INVALID_CLIENT)
+		return nil
+	}
+	if !client.AllowsGrantType(sharedmodels.OAuthGrantAuthorizationCode) {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("OAuth client not registered for the authorization code grant", uc.AppContext)
+		uc.setError(result, status.InvalidInput, messages.MessageKeysInstance.OAUTH_UNAUTHORIZED_GRANT_TYPE)
+		return nil
+	}
+	if !client.AllowsRedirectURI(input.RedirectURI) {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("OAuth redirect URI not registered", uc.AppContext)
+		uc.setError(result, status.InvalidInput, messages.MessageKeysInstance.OAUTH_INVALID_REDIRECT_URI)
+		return nil
+	}
+	return client
+}
+
+// checkConsent reports whether the user granted the scopes to the client. An
+// accepted consent is recorded together with the scopes granted before.
+func (uc *AuthorizeOAuthClientUseCase) checkConsent(result *usecase.UseCaseResult[dtos.OAuthAuthorization], input dtos.OAuthAuthorize, scopes []string) bool {
+	consent, err := uc.consentRepo.GetByUserAndClient(input.UserID, input.ClientID)
+	if err != nil && err.Code != status.NotFound {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting OAuth consent", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return false
+	}
+	if consent != nil && consent.Covers(scopes) {
+		return true
+	}
+	if !input.Consent {
+		return false
+	}
+
+	if consent == nil {
+		_, err = uc.consentRepo.Create(dtos.OAuthConsentCreate{
+			OAuthConsentBase: sharedmodels.OAuthConsentBase{
+				UserID:   input.UserID,
+				ClientID: input.ClientID,
+				Scopes:   scopes,
+			},
+		})
+	} else {
+		granted := slices.Clone(consent.Scopes)
+		for _, scope := range scopes {
+			if !slices.Contains(granted, scope) {
+				granted = append(granted, scope)
+			}
+		}
+		_, err = uc.consentRepo.Update(consent.ID, dtos.OAuthConsentUpdate{Scopes: granted, ID: consent.ID})
+	}
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error recording OAuth consent", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return false
+	}
+	return true
+}
+
+// issueCode stores a new authorization code and returns the redirect URI
+// carrying it and the state of the client
+func (uc *AuthorizeOAuthClientUseCase) issueCode(result *usecase.UseCaseResult[dtos.OAuthAuthorization], input dtos.OAuthAuthorize, scopes []string) string {
+	code, codeHash, err := uc.hashProvider.OneTimeToken()
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error generating OAuth authorization code", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return ""
+	}
+
+	if err := uc.codeService.Issue(authservices.OAuthCodeKey(codeHash), dtos.OAuthCode{
+		ClientID:      input.ClientID,
+		UserID:        input.UserID,
+		RedirectURI:   input.RedirectURI,
+		Scope:         sharedmodels.JoinOAuthScope(scopes),
+		CodeChallenge: input.CodeChallenge,
+	}); err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error storing OAuth authorization code", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return ""
+	}
+
+	// the redirect URI was validated on registration
+	redirectURI, _ := url.Parse(input.RedirectURI)
+	query := redirectURI.Query()
+	query.Set("code", code)
+	if input.State != "" {
+		query.Set("state", input.State)
+	}
+	redirectURI.RawQuery = query.Encode()
+	return redirectURI.String()
+}
+
+func (uc *AuthorizeOAuthClientUseCase) setError(result *usecase.UseCaseResult[dtos.OAuthAuthorization], code status.ApplicationStatusEnum, key messages.MessageKeysEnum) {
+	result.SetError(
+		code,
+		uc.AppMessages.Get(
+			uc.Locale,
+			key,
+		),
+	)
+}
+
+func NewAuthorizeOAuthClientUseCase(
+	clientRepo authcontracts.IOAuthClientRepository,
+	consentRepo authcontracts.IOAuthConsentRepository,
+	hashProvider contractproviders.IHashProvider,
+	codeService *authservices.OAuthCodeService,
+) *AuthorizeOAuthClientUseCase {
+	return &AuthorizeOAuthClientUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[dtos.OAuthAuthorize, dtos.OAuthAuthorization]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(guards.UserResourceGuard[dtos.OAuthAuthorize](), guards.NotImpersonatedGuard),
+		},
+		clientRepo:   clientRepo,
+		consentRepo:  consentRepo,
+		hashProvider: hashProvider,
+		codeService:  codeService,
+	}
+}
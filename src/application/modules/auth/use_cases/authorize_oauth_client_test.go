@@ -0,0 +1,222 @@
+package authusecases
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authmocks "github.com/simon3640/goprojectskeleton/src/application/modules/auth/mocks"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const oauthTestCodeTTL = time.Minute
+
+// oauthTestVerifier is the PKCE verifier of oauthTestChallenge (RFC 7636 appendix B)
+const (
+	oauthTestVerifier  = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	oauthTestChallenge = "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+)
+
+var oauthTestCodeHash = []byte("code_hash")
+
+func oauthAuthorize() dtos.OAuthAuthorize {
+	return dtos.OAuthAuthorize{
+		ResponseType:        "code",
+		ClientID:            "client",
+		RedirectURI:         "https://app.example.com/callback",
+		Scope:               sharedmodels.OAuthScopeProfile,
+		State:               "state",
+		CodeChallenge:       oauthTestChallenge,
+		CodeChallengeMethod: "S256",
+		UserID:              1,
+	}
+}
+
+func oauthTestConsent(scopes ...string) *sharedmodels.OAuthConsent {
+	return &sharedmodels.OAuthConsent{
+		OAuthConsentBase: sharedmodels.OAuthConsentBase{UserID: 1, ClientID: "client", Scopes: scopes},
+		DBBaseModel:      sharedmodels.DBBaseModel{ID: 1},
+	}
+}
+
+type authorizeOAuthTestMocks struct {
+	clientRepository  *authmocks.MockOAuthClientRepository
+	consentRepository *authmocks.MockOAuthConsentRepository
+	hashProvider      *providersmocks.MockHashProvider
+	cache             *providersmocks.MemoryCacheProvider
+}
+
+func newAuthorizeOAuthClientTestUseCase() (*AuthorizeOAuthClientUseCase, *authorizeOAuthTestMocks) {
+	mocks := &authorizeOAuthTestMocks{
+		clientRepository:  new(authmocks.MockOAuthClientRepository),
+		consentRepository: new(authmocks.MockOAuthConsentRepository),
+		hashProvider:      new(providersmocks.MockHashProvider),
+		cache:             providersmocks.NewMemoryCacheProvider(),
+	}
+	mocks.clientRepository.On("GetByClientID", "client").Return(authmocks.OAuthClient(), nil)
+	mocks.hashProvider.On("OneTimeToken").Return("code", oauthTestCodeHash, nil)
+	return NewAuthorizeOAuthClientUseCase(
+		mocks.clientRepository,
+		mocks.consentRepository,
+		mocks.hashProvider,
+		authservices.NewOAuthCodeService(mocks.cache, oauthTestCodeTTL),
+	), mocks
+}
+
+func TestAuthorizeOAuthClientUseCase(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background(), User: &dtomocks.UserWithRole}
+	uc, mocks := newAuthorizeOAuthClientTestUseCase()
+
+	mocks.consentRepository.On("GetByUserAndClient", uint(1), "client").Return(oauthTestConsent(sharedmodels.OAuthScopeProfile), nil)
+
+	result := uc.Execute(ctx, locales.EN_US, oauthAuthorize())
+
+	assert.True(result.IsSuccess())
+	assert.False(result.Data.ConsentRequired)
+	redirectURI, err := url.Parse(result.Data.RedirectURI)
+	assert.NoError(err)
+	assert.Equal("app.example.com", redirectURI.Host)
+	assert.Equal("code", redirectURI.Query().Get("code"))
+	assert.Equal("state", redirectURI.Query().Get("state"))
+
+	// the code is bound to the client, the user, the redirect URI and the challenge
+	var code dtos.OAuthCode
+	found, _ := mocks.cache.Get(authservices.OAuthCodeKey(oauthTestCodeHash), &code)
+	assert.True(found)
+	assert.Equal("client", code.ClientID)
+	assert.Equal(uint(1), code.UserID)
+	assert.Equal("https://app.example.com/callback", code.RedirectURI)
+	assert.Equal(sharedmodels.OAuthScopeProfile, code.Scope)
+	assert.Equal(oauthTestChallenge, code.CodeChallenge)
+	mocks.consentRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestAuthorizeOAuthClientUseCase_ConsentRequired(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background(), User: &dtomocks.UserWithRole}
+	uc, mocks := newAuthorizeOAuthClientTestUseCase()
+
+	mocks.consentRepository.On("GetByUserAndClient", uint(1), "client").Return(nil, notFoundError())
+
+	result := uc.Execute(ctx, locales.EN_US, oauthAuthorize())
+
+	assert.True(result.IsSuccess())
+	assert.True(result.Data.ConsentRequired)
+	assert.Empty(result.Data.RedirectURI)
+	assert.Equal("Test App", result.Data.ClientName)
+	assert.Equal([]string{sharedmodels.OAuthScopeProfile}, result.Data.Scopes)
+	assert.Empty(mocks.cache.Keys())
+}
+
+func TestAuthorizeOAuthClientUseCase_RecordsConsent(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background(), User: &dtomocks.UserWithRole}
+	uc, mocks := newAuthorizeOAuthClientTestUseCase()
+
+	mocks.consentRepository.On("GetByUserAndClient", uint(1), "client").Return(nil, notFoundError())
+	mocks.consentRepository.On("Create", dtos.OAuthConsentCreate{
+		OAuthConsentBase: oauthTestConsent(sharedmodels.OAuthScopeProfile).OAuthConsentBase,
+	}).Return(oauthTestConsent(sharedmodels.OAuthScopeProfile), nil)
+
+	input := oauthAuthorize()
+	input.Consent = true
+	result := uc.Execute(ctx, locales.EN_US, input)
+
+	assert.True(result.IsSuccess())
+	assert.NotEmpty(result.Data.RedirectURI)
+	mocks.consentRepository.AssertExpectations(t)
+}
+
+func TestAuthorizeOAuthClientUseCase_ExtendsConsent(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background(), User: &dtomocks.UserWithRole}
+	uc, mocks := newAuthorizeOAuthClientTestUseCase()
+
+	mocks.consentRepository.On("GetByUserAndClient", uint(1), "client").Return(oauthTestConsent(sharedmodels.OAuthScopeProfile), nil)
+	mocks.consentRepository.On("Update", uint(1), dtos.OAuthConsentUpdate{
+		Scopes: []string{sharedmodels.OAuthScopeProfile, sharedmodels.OAuthScopeProfileWrite},
+		ID:     1,
+	}).Return(oauthTestConsent(sharedmodels.OAuthScopeProfile, sharedmodels.OAuthScopeProfileWrite), nil)
+
+	input := oauthAuthorize()
+	input.Scope = sharedmodels.OAuthScopeProfileWrite
+	input.Consent = true
+	result := uc.Execute(ctx, locales.EN_US, input)
+
+	assert.True(result.IsSuccess())
+	assert.NotEmpty(result.Data.RedirectURI)
+	mocks.consentRepository.AssertExpectations(t)
+}
+
+func TestAuthorizeOAuthClientUseCase_FirstPartySkipsConsent(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background(), User: &dtomocks.UserWithRole}
+	uc, mocks := newAuthorizeOAuthClientTestUseCase()
+
+	client := authmocks.OAuthClient()
+	client.FirstParty = true
+	mocks.clientRepository.ExpectedCalls = nil
+	mocks.clientRepository.On("GetByClientID", "client").Return(client, nil)
+
+	result := uc.Execute(ctx, locales.EN_US, oauthAuthorize())
+
+	assert.True(result.IsSuccess())
+	assert.NotEmpty(result.Data.RedirectURI)
+	mocks.consentRepository.AssertNotCalled(t, "GetByUserAndClient", mock.Anything, mock.Anything)
+}
+
+func TestAuthorizeOAuthClientUseCase_InvalidRequests(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(input *dtos.OAuthAuthorize)
+		status status.ApplicationStatusEnum
+	}{
+		{name: "unregistered redirect uri", modify: func(input *dtos.OAuthAuthorize) { input.RedirectURI = "https://evil.example.com/callback" }, status: status.InvalidInput},
+		{name: "scope not registered", modify: func(input *dtos.OAuthAuthorize) { input.Scope = sharedmodels.OAuthScopeUsersRead }, status: status.InvalidInput},
+		{name: "plain challenge", modify: func(input *dtos.OAuthAuthorize) { input.CodeChallengeMethod = "plain" }, status: status.InvalidInput},
+		{name: "without challenge", modify: func(input *dtos.OAuthAuthorize) { input.CodeChallenge = "" }, status: status.InvalidInput},
+		{name: "for another user", modify: func(input *dtos.OAuthAuthorize) { input.UserID = 2 }, status: status.Unauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			ctx := &app_context.AppContext{Context: context.Background(), User: &dtomocks.UserWithRole}
+			uc, mocks := newAuthorizeOAuthClientTestUseCase()
+
+			input := oauthAuthorize()
+			tt.modify(&input)
+			result := uc.Execute(ctx, locales.EN_US, input)
+
+			assert.True(result.HasError())
+			assert.Equal(tt.status, result.StatusCode)
+			assert.Empty(mocks.cache.Keys())
+		})
+	}
+}
+
+func TestAuthorizeOAuthClientUseCase_DelegatedTokensCanNotAuthorize(t *testing.T) {
+	assert := assert.New(t)
+	user := dtomocks.UserWithRole
+	user.SetScopes([]string{sharedmodels.OAuthScopeProfile, sharedmodels.OAuthScopeProfileWrite})
+	ctx := &app_context.AppContext{Context: context.Background(), User: &user}
+	uc, mocks := newAuthorizeOAuthClientTestUseCase()
+
+	result := uc.Execute(ctx, locales.EN_US, oauthAuthorize())
+
+	assert.True(result.HasError())
+	assert.Equal(status.Unauthorized, result.StatusCode)
+	mocks.clientRepository.AssertNotCalled(t, "GetByClientID", mock.Anything)
+}
@@ -0,0 +1,155 @@
+package authusecases
+
+import (
+	contractproviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+)
+
+// BeginWebAuthnLoginUseCase is the use case for starting a passkey login.
+// It returns the options of navigator.credentials.get and the ID of the
+// session the assertion must be sent with.
+type BeginWebAuthnLoginUseCase struct {
+	usecase.BaseUseCaseValidation[dtos.WebAuthnLoginBegin, dtos.WebAuthnLoginOptions]
+
+	userRepo       authcontracts.IUserRepository
+	credentialRepo authcontracts.IWebAuthnCredentialRepository
+
+	webAuthnProvider authcontracts.IWebAuthnProvider
+	hashProvider     contractproviders.IHashProvider
+	sessionService   *authservices.WebAuthnSessionService
+}
+
+var _ usecase.BaseUseCase[dtos.WebAuthnLoginBegin, dtos.WebAuthnLoginOptions] = (*BeginWebAuthnLoginUseCase)(nil)
+
+// Execute starts a passkey login. With the email of a user the login is
+// restricted to their passkeys, otherwise the authenticator offers its
+// discoverable passkeys. Unknown emails behave as logins without email.
+func (uc *BeginWebAuthnLoginUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input dtos.WebAuthnLoginBegin,
+) *usecase.UseCaseResult[dtos.WebAuthnLoginOptions] {
+	result := usecase.NewUseCaseResult[dtos.WebAuthnLoginOptions]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	userID, allowCredentials := uc.getAllowedCredentials(result, input.Email)
+	if result.HasError() {
+		return result
+	}
+
+	challenge, err := uc.webAuthnProvider.NewChallenge()
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error generating passkey challenge", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return result
+	}
+
+	sessionID, sessionHash, err := uc.hashProvider.OneTimeToken()
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error generating passkey login session", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return result
+	}
+
+	if err := uc.sessionService.Start(authservices.LoginSessionKey(sessionHash), userID, challenge); err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error storing passkey login", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return result
+	}
+
+	options := dtos.WebAuthnLoginOptions{
+		SessionID: sessionID,
+		PublicKey: dtos.WebAuthnRequestOptions{
+			Challenge:        dtos.EncodeWebAuthnBase64(challenge),
+			RPID:             uc.webAuthnProvider.RelyingParty().ID,
+			Timeout:          uc.sessionService.TTL().Milliseconds(),
+			AllowCredentials: allowCredentials,
+			UserVerification: "preferred",
+		},
+	}
+
+	result.SetData(
+		status.Success,
+		options,
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.WEBAUTHN_CHALLENGE_CREATED,
+		),
+	)
+	observability.GetObservabilityComponents().Logger.InfoWithContext("Passkey login started", uc.AppContext)
+	return result
+}
+
+// getAllowedCredentials returns the user of the email and their passkeys,
+// zero and no passkeys without email or when the user is unknown
+func (uc *BeginWebAuthnLoginUseCase) getAllowedCredentials(result *usecase.UseCaseResult[dtos.WebAuthnLoginOptions], email string) (uint, []dtos.WebAuthnCredentialDescriptor) {
+	allowCredentials := []dtos.WebAuthnCredentialDescriptor{}
+	if email == "" {
+		return 0, allowCredentials
+	}
+
+	user, err := uc.userRepo.GetByEmailOrPhone(email)
+	if err != nil {
+		if err.Code != status.NotFound {
+			observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting user by email", err.ToError(), uc.AppContext)
+			uc.setError(result, err.Code, err.Context)
+		}
+		return 0, allowCredentials
+	}
+	if user == nil {
+		return 0, allowCredentials
+	}
+
+	credentials, err := uc.credentialRepo.GetByUserID(user.ID)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting passkeys of user", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return 0, nil
+	}
+	for _, credential := range credentials {
+		allowCredentials = append(allowCredentials, dtos.NewWebAuthnCredentialDescriptor(credential))
+	}
+	return user.ID, allowCredentials
+}
+
+func (uc *BeginWebAuthnLoginUseCase) setError(result *usecase.UseCaseResult[dtos.WebAuthnLoginOptions], code status.ApplicationStatusEnum, key messages.MessageKeysEnum) {
+	result.SetError(
+		code,
+		uc.AppMessages.Get(
+			uc.Locale,
+			key,
+		),
+	)
+}
+
+func NewBeginWebAuthnLoginUseCase(
+	userRepo authcontracts.IUserRepository,
+	credentialRepo authcontracts.IWebAuthnCredentialRepository,
+	webAuthnProvider authcontracts.IWebAuthnProvider,
+	hashProvider contractproviders.IHashProvider,
+	sessionService *authservices.WebAuthnSessionService,
+) *BeginWebAuthnLoginUseCase {
+	return &BeginWebAuthnLoginUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[dtos.WebAuthnLoginBegin, dtos.WebAuthnLoginOptions]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(),
+		},
+		userRepo:         userRepo,
+		credentialRepo:   credentialRepo,
+		webAuthnProvider: webAuthnProvider,
+		hashProvider:     hashProvider,
+		sessionService:   sessionService,
+	}
+}
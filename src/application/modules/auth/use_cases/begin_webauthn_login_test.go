@@ -0,0 +1,79 @@
+package authusecases
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authmocks "github.com/simon3640/goprojectskeleton/src/application/modules/auth/mocks"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var webAuthnSessionHash = []byte("webauthn_session_hash")
+
+func TestBeginWebAuthnLoginUseCase(t *testing.T) {
+	user := usermodels.User{
+		UserBase:    dtomocks.UserBase,
+		DBBaseModel: sharedmodels.DBBaseModel{ID: 1},
+	}
+
+	cases := []struct {
+		name             string
+		input            dtos.WebAuthnLoginBegin
+		knownUser        bool
+		sessionUserID    uint
+		allowCredentials int
+	}{
+		{name: "discoverable passkeys", input: dtos.WebAuthnLoginBegin{}},
+		{name: "passkeys of the user", input: dtos.WebAuthnLoginBegin{Email: user.Email}, knownUser: true, sessionUserID: 1, allowCredentials: 1},
+		{name: "unknown email", input: dtos.WebAuthnLoginBegin{Email: "unknown@example.com"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			ctx := &app_context.AppContext{Context: context.Background()}
+
+			testUserRepository := new(authmocks.MockUserRepository)
+			testCredentialRepository := new(authmocks.MockWebAuthnCredentialRepository)
+			testWebAuthnProvider := new(authmocks.MockWebAuthnProvider)
+			testHashProvider := new(providersmocks.MockHashProvider)
+			sessions, cache := newWebAuthnTestSessions()
+			uc := NewBeginWebAuthnLoginUseCase(testUserRepository, testCredentialRepository, testWebAuthnProvider, testHashProvider, sessions)
+
+			if tc.knownUser {
+				testUserRepository.On("GetByEmailOrPhone", tc.input.Email).Return(&user, nil)
+				testCredentialRepository.On("GetByUserID", uint(1)).Return([]sharedmodels.WebAuthnCredential{*authmocks.WebAuthnCredential()}, nil)
+			} else if tc.input.Email != "" {
+				testUserRepository.On("GetByEmailOrPhone", tc.input.Email).Return(nil, notFoundError())
+			}
+			testWebAuthnProvider.On("NewChallenge").Return(webAuthnTestChallenge, nil)
+			testWebAuthnProvider.On("RelyingParty").Return(webAuthnTestRelyingParty)
+			testHashProvider.On("OneTimeToken").Return("session", webAuthnSessionHash, nil)
+			cache.On("Set", authservices.LoginSessionKey(webAuthnSessionHash), mock.MatchedBy(func(session dtos.WebAuthnSession) bool {
+				return session.UserID == tc.sessionUserID && bytes.Equal(session.Challenge, webAuthnTestChallenge)
+			}), webAuthnTestTTL).Return(nil)
+
+			result := uc.Execute(ctx, locales.EN_US, tc.input)
+
+			assert.True(result.IsSuccess())
+			assert.Equal("session", result.Data.SessionID)
+			assert.Equal(dtos.EncodeWebAuthnBase64(webAuthnTestChallenge), result.Data.PublicKey.Challenge)
+			assert.Equal(webAuthnTestRelyingParty.ID, result.Data.PublicKey.RPID)
+			assert.NotNil(result.Data.PublicKey.AllowCredentials)
+			assert.Len(result.Data.PublicKey.AllowCredentials, tc.allowCredentials)
+			cache.AssertExpectations(t)
+			testUserRepository.AssertExpectations(t)
+		})
+	}
+}
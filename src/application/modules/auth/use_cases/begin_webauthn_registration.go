@@ -0,0 +1,127 @@
+package authusecases
+
+import (
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/guards"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+)
+
+// BeginWebAuthnRegistrationUseCase is the use case for starting the
+// registration of a passkey. It returns the options of
+// navigator.credentials.create, the challenge is kept until it expires.
+type BeginWebAuthnRegistrationUseCase struct {
+	usecase.BaseUseCaseValidation[uint, dtos.WebAuthnCreationOptions]
+
+	credentialRepo authcontracts.IWebAuthnCredentialRepository
+
+	webAuthnProvider authcontracts.IWebAuthnProvider
+	sessionService   *authservices.WebAuthnSessionService
+}
+
+var _ usecase.BaseUseCase[uint, dtos.WebAuthnCreationOptions] = (*BeginWebAuthnRegistrationUseCase)(nil)
+
+// Execute starts the passkey registration of the user whose ID is the input,
+// the passkeys the user already registered are excluded
+func (uc *BeginWebAuthnRegistrationUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input uint,
+) *usecase.UseCaseResult[dtos.WebAuthnCreationOptions] {
+	result := usecase.NewUseCaseResult[dtos.WebAuthnCreationOptions]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	credentials, err := uc.credentialRepo.GetByUserID(input)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting passkeys of user", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return result
+	}
+
+	challenge, err := uc.webAuthnProvider.NewChallenge()
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error generating passkey challenge", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return result
+	}
+
+	if err := uc.sessionService.Start(authservices.RegistrationSessionKey(input), input, challenge); err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error storing passkey registration", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return result
+	}
+
+	excludeCredentials := make([]dtos.WebAuthnCredentialDescriptor, 0, len(credentials))
+	for _, credential := range credentials {
+		excludeCredentials = append(excludeCredentials, dtos.NewWebAuthnCredentialDescriptor(credential))
+	}
+
+	options := dtos.WebAuthnCreationOptions{
+		Challenge: dtos.EncodeWebAuthnBase64(challenge),
+		RP:        uc.webAuthnProvider.RelyingParty(),
+		User: dtos.WebAuthnUser{
+			ID:          dtos.WebAuthnUserHandle(input),
+			Name:        uc.AppContext.User.Email,
+			DisplayName: uc.AppContext.User.Name,
+		},
+		PubKeyCredParams: []dtos.WebAuthnCredentialParameter{
+			{Type: dtos.WebAuthnPublicKeyType, Alg: dtos.WebAuthnAlgES256},
+			{Type: dtos.WebAuthnPublicKeyType, Alg: dtos.WebAuthnAlgEdDSA},
+			{Type: dtos.WebAuthnPublicKeyType, Alg: dtos.WebAuthnAlgRS256},
+		},
+		Timeout:            uc.sessionService.TTL().Milliseconds(),
+		ExcludeCredentials: excludeCredentials,
+		AuthenticatorSelection: dtos.WebAuthnAuthenticatorSelection{
+			ResidentKey:      "preferred",
+			UserVerification: "preferred",
+		},
+		Attestation: "none",
+	}
+
+	result.SetData(
+		status.Success,
+		options,
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.WEBAUTHN_CHALLENGE_CREATED,
+		),
+	)
+	observability.GetObservabilityComponents().Logger.InfoWithContext("Passkey registration started", uc.AppContext)
+	return result
+}
+
+func (uc *BeginWebAuthnRegistrationUseCase) setError(result *usecase.UseCaseResult[dtos.WebAuthnCreationOptions], code status.ApplicationStatusEnum, key messages.MessageKeysEnum) {
+	result.SetError(
+		code,
+		uc.AppMessages.Get(
+			uc.Locale,
+			key,
+		),
+	)
+}
+
+func NewBeginWebAuthnRegistrationUseCase(
+	credentialRepo authcontracts.IWebAuthnCredentialRepository,
+	webAuthnProvider authcontracts.IWebAuthnProvider,
+	sessionService *authservices.WebAuthnSessionService,
+) *BeginWebAuthnRegistrationUseCase {
+	return &BeginWebAuthnRegistrationUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[uint, dtos.WebAuthnCreationOptions]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(guards.UserGetItSelf, guards.NotImpersonatedGuard),
+		},
+		credentialRepo:   credentialRepo,
+		webAuthnProvider: webAuthnProvider,
+		sessionService:   sessionService,
+	}
+}
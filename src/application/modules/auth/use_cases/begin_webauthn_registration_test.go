@@ -0,0 +1,93 @@
+package authusecases
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authmocks "github.com/simon3640/goprojectskeleton/src/application/modules/auth/mocks"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const webAuthnTestTTL = 5 * time.Minute
+
+var webAuthnTestChallenge = []byte("webauthn_challenge")
+
+var webAuthnTestRelyingParty = dtos.WebAuthnRelyingParty{ID: "localhost", Name: "GoProjectSkeleton"}
+
+func newWebAuthnTestSessions() (*authservices.WebAuthnSessionService, *providersmocks.MockCacheProvider) {
+	cache := new(providersmocks.MockCacheProvider)
+	return authservices.NewWebAuthnSessionService(cache, webAuthnTestTTL), cache
+}
+
+// mockPendingWebAuthnSession mocks a pending ceremony of the user under the
+// key, claims is the number of requests that consumed it, one for the first
+func mockPendingWebAuthnSession(cache *providersmocks.MockCacheProvider, key string, userID uint, claims int64) {
+	cache.On("Get", key, mock.Anything).Return(true, nil).Run(func(args mock.Arguments) {
+		session := args.Get(1).(*dtos.WebAuthnSession)
+		session.UserID = userID
+		session.Challenge = webAuthnTestChallenge
+		session.ExpiresAt = time.Now().Add(webAuthnTestTTL)
+	})
+	cache.On("Increment", fmt.Sprintf("webauthn_challenge_used:%x", webAuthnTestChallenge), webAuthnTestTTL).Return(claims, nil)
+	cache.On("Delete", key).Return(nil)
+}
+
+func TestBeginWebAuthnRegistrationUseCase(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background(), User: &dtomocks.UserWithRole}
+
+	testCredentialRepository := new(authmocks.MockWebAuthnCredentialRepository)
+	testWebAuthnProvider := new(authmocks.MockWebAuthnProvider)
+	sessions, cache := newWebAuthnTestSessions()
+	uc := NewBeginWebAuthnRegistrationUseCase(testCredentialRepository, testWebAuthnProvider, sessions)
+
+	testCredentialRepository.On("GetByUserID", uint(1)).Return([]sharedmodels.WebAuthnCredential{*authmocks.WebAuthnCredential()}, nil)
+	testWebAuthnProvider.On("NewChallenge").Return(webAuthnTestChallenge, nil)
+	testWebAuthnProvider.On("RelyingParty").Return(webAuthnTestRelyingParty)
+	cache.On("Set", authservices.RegistrationSessionKey(1), mock.MatchedBy(func(session dtos.WebAuthnSession) bool {
+		return session.UserID == 1 && bytes.Equal(session.Challenge, webAuthnTestChallenge)
+	}), webAuthnTestTTL).Return(nil)
+
+	result := uc.Execute(ctx, locales.EN_US, uint(1))
+
+	assert.True(result.IsSuccess())
+	assert.Equal(dtos.EncodeWebAuthnBase64(webAuthnTestChallenge), result.Data.Challenge)
+	assert.Equal(webAuthnTestRelyingParty, result.Data.RP)
+	assert.Equal(dtos.WebAuthnUserHandle(1), result.Data.User.ID)
+	assert.Equal(dtomocks.UserWithRole.Email, result.Data.User.Name)
+	assert.Equal(webAuthnTestTTL.Milliseconds(), result.Data.Timeout)
+	// The passkeys of the user cannot be registered again
+	assert.Len(result.Data.ExcludeCredentials, 1)
+	assert.Equal(dtos.EncodeWebAuthnBase64(authmocks.WebAuthnCredentialID), result.Data.ExcludeCredentials[0].ID)
+	cache.AssertExpectations(t)
+}
+
+func TestBeginWebAuthnRegistrationUseCase_OtherUser(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background(), User: &dtomocks.UserWithRole}
+
+	testCredentialRepository := new(authmocks.MockWebAuthnCredentialRepository)
+	testWebAuthnProvider := new(authmocks.MockWebAuthnProvider)
+	sessions, cache := newWebAuthnTestSessions()
+	uc := NewBeginWebAuthnRegistrationUseCase(testCredentialRepository, testWebAuthnProvider, sessions)
+
+	result := uc.Execute(ctx, locales.EN_US, uint(2))
+
+	assert.True(result.HasError())
+	assert.Equal(status.Unauthorized, result.StatusCode)
+	testWebAuthnProvider.AssertNotCalled(t, "NewChallenge")
+	cache.AssertNotCalled(t, "Set", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,147 @@
+package authusecases
+
+import (
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/guards"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// ConfirmTOTPUseCase is the use case for confirming the enrollment of an
+// authenticator app with its first code. Once confirmed the logins of the
+// user require a code and the recovery codes are returned, only this time.
+type ConfirmTOTPUseCase struct {
+	usecase.BaseUseCaseValidation[dtos.TOTPConfirm, dtos.RecoveryCodes]
+
+	mfaService *authservices.MFAService
+}
+
+var _ usecase.BaseUseCase[dtos.TOTPConfirm, dtos.RecoveryCodes] = (*ConfirmTOTPUseCase)(nil)
+
+// Execute confirms the TOTP enrollment of the user
+func (uc *ConfirmTOTPUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input dtos.TOTPConfirm,
+) *usecase.UseCaseResult[dtos.RecoveryCodes] {
+	result := usecase.NewUseCaseResult[dtos.RecoveryCodes]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	device := uc.getPendingDevice(result, input.UserID)
+	if result.HasError() {
+		return result
+	}
+
+	uc.verifyCode(result, device, input.Code)
+	if result.HasError() {
+		return result
+	}
+
+	codes, err := uc.mfaService.ConfirmDevice(device)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error confirming TOTP device", err.ToError(), uc.AppContext)
+		result.SetError(
+			err.Code,
+			uc.AppMessages.Get(
+				uc.Locale,
+				err.Context,
+			),
+		)
+		return result
+	}
+
+	result.SetData(
+		status.Success,
+		dtos.RecoveryCodes{Codes: codes},
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.MFA_ENABLED,
+		),
+	)
+	observability.GetObservabilityComponents().Logger.InfoWithContext("TOTP enabled", uc.AppContext)
+	return result
+}
+
+// getPendingDevice returns the enrolled device of the user that is not confirmed yet
+func (uc *ConfirmTOTPUseCase) getPendingDevice(result *usecase.UseCaseResult[dtos.RecoveryCodes], userID uint) *sharedmodels.TOTPDevice {
+	device, err := uc.mfaService.GetDevice(userID)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting TOTP device", err.ToError(), uc.AppContext)
+		result.SetError(
+			err.Code,
+			uc.AppMessages.Get(
+				uc.Locale,
+				err.Context,
+			),
+		)
+		return nil
+	}
+	if device == nil {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("No TOTP enrollment to confirm", uc.AppContext)
+		result.SetError(
+			status.Conflict,
+			uc.AppMessages.Get(
+				uc.Locale,
+				messages.MessageKeysInstance.MFA_NOT_ENROLLED,
+			),
+		)
+		return nil
+	}
+	if device.IsConfirmed {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("TOTP already enabled", uc.AppContext)
+		result.SetError(
+			status.Conflict,
+			uc.AppMessages.Get(
+				uc.Locale,
+				messages.MessageKeysInstance.MFA_ALREADY_ENABLED,
+			),
+		)
+		return nil
+	}
+	return device
+}
+
+func (uc *ConfirmTOTPUseCase) verifyCode(result *usecase.UseCaseResult[dtos.RecoveryCodes], device *sharedmodels.TOTPDevice, code string) {
+	valid, err := uc.mfaService.VerifyTOTP(device, code)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error verifying TOTP code", err.ToError(), uc.AppContext)
+		result.SetError(
+			err.Code,
+			uc.AppMessages.Get(
+				uc.Locale,
+				err.Context,
+			),
+		)
+		return
+	}
+	if !valid {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Invalid TOTP code", uc.AppContext)
+		result.SetError(
+			status.InvalidInput,
+			uc.AppMessages.Get(
+				uc.Locale,
+				messages.MessageKeysInstance.INVALID_MFA_CODE,
+			),
+		)
+	}
+}
+
+func NewConfirmTOTPUseCase(mfaService *authservices.MFAService) *ConfirmTOTPUseCase {
+	return &ConfirmTOTPUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[dtos.TOTPConfirm, dtos.RecoveryCodes]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(guards.UserResourceGuard[dtos.TOTPConfirm](), guards.NotImpersonatedGuard),
+		},
+		mfaService: mfaService,
+	}
+}
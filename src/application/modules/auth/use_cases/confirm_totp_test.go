@@ -0,0 +1,129 @@
+package authusecases
+
+import (
+	"context"
+	"testing"
+
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authmocks "github.com/simon3640/goprojectskeleton/src/application/modules/auth/mocks"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func pendingTOTPDevice() *sharedmodels.TOTPDevice {
+	device := authmocks.TOTPDevice()
+	device.IsConfirmed = false
+	return device
+}
+
+func TestConfirmTOTPUseCase(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background(), User: &dtomocks.UserWithRole}
+
+	mfaService, mocks := newMFATestService(new(providersmocks.MockHashProvider))
+	uc := NewConfirmTOTPUseCase(mfaService)
+
+	device := pendingTOTPDevice()
+	recoveryCodes := []string{"AAAAA-BBBBB", "CCCCC-DDDDD"}
+	mocks.totpDeviceRepository.On("GetByUserID", uint(1)).Return(device, nil)
+	mocks.totpProvider.On("ValidateCode", authmocks.TOTPSecret, "123456", mock.Anything).Return(int64(100), true)
+	mocks.totpDeviceRepository.On("UseStep", device.ID, int64(100)).Return(true, nil)
+	mocks.totpDeviceRepository.On("Update", device.ID, dtos.TOTPDeviceUpdate{IsConfirmed: true, ID: device.ID}).Return(authmocks.TOTPDevice(), nil)
+	mocks.recoveryCodeRepository.On("DeleteByUserID", uint(1)).Return(nil)
+	mocks.totpProvider.On("GenerateRecoveryCodes", authservices.RecoveryCodesCount).Return(recoveryCodes, nil)
+	// The recovery codes are stored hashed, without separators
+	mocks.hashProvider.On("HashOneTimeToken", "AAAAABBBBB").Return([]byte("hash_1"))
+	mocks.hashProvider.On("HashOneTimeToken", "CCCCCDDDDD").Return([]byte("hash_2"))
+	mocks.recoveryCodeRepository.On("Create", mock.AnythingOfType("authdtos.RecoveryCodeCreate")).Return(&sharedmodels.RecoveryCode{}, nil)
+
+	result := uc.Execute(ctx, locales.EN_US, dtos.TOTPConfirm{UserID: 1, Code: "123456"})
+
+	assert.True(result.IsSuccess())
+	assert.Equal(recoveryCodes, result.Data.Codes)
+	mocks.recoveryCodeRepository.AssertNumberOfCalls(t, "Create", 2)
+	mocks.totpDeviceRepository.AssertExpectations(t)
+}
+
+func TestConfirmTOTPUseCase_InvalidCode(t *testing.T) {
+	cases := []struct {
+		name   string
+		valid  bool
+		unused bool
+	}{
+		{name: "wrong code", valid: false},
+		{name: "replayed code", valid: true, unused: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			ctx := &app_context.AppContext{Context: context.Background(), User: &dtomocks.UserWithRole}
+
+			mfaService, mocks := newMFATestService(new(providersmocks.MockHashProvider))
+			uc := NewConfirmTOTPUseCase(mfaService)
+
+			device := pendingTOTPDevice()
+			mocks.totpDeviceRepository.On("GetByUserID", uint(1)).Return(device, nil)
+			mocks.totpProvider.On("ValidateCode", authmocks.TOTPSecret, "123456", mock.Anything).Return(int64(100), tc.valid)
+			mocks.totpDeviceRepository.On("UseStep", device.ID, int64(100)).Return(tc.unused, nil)
+
+			result := uc.Execute(ctx, locales.EN_US, dtos.TOTPConfirm{UserID: 1, Code: "123456"})
+
+			assert.True(result.HasError())
+			assert.Equal(status.InvalidInput, result.StatusCode)
+			mocks.totpDeviceRepository.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+			mocks.totpProvider.AssertNotCalled(t, "GenerateRecoveryCodes", mock.Anything)
+		})
+	}
+}
+
+func TestConfirmTOTPUseCase_NoPendingEnrollment(t *testing.T) {
+	cases := []struct {
+		name   string
+		device *sharedmodels.TOTPDevice
+		err    *applicationerrors.ApplicationError
+	}{
+		{name: "not enrolled", err: notFoundError()},
+		{name: "already confirmed", device: authmocks.TOTPDevice()},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			ctx := &app_context.AppContext{Context: context.Background(), User: &dtomocks.UserWithRole}
+
+			mfaService, mocks := newMFATestService(new(providersmocks.MockHashProvider))
+			uc := NewConfirmTOTPUseCase(mfaService)
+			mocks.totpDeviceRepository.On("GetByUserID", uint(1)).Return(tc.device, tc.err)
+
+			result := uc.Execute(ctx, locales.EN_US, dtos.TOTPConfirm{UserID: 1, Code: "123456"})
+
+			assert.True(result.HasError())
+			assert.Equal(status.Conflict, result.StatusCode)
+			mocks.totpProvider.AssertNotCalled(t, "ValidateCode", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestConfirmTOTPUseCase_OtherUser(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background(), User: &dtomocks.UserWithRole}
+
+	mfaService, mocks := newMFATestService(new(providersmocks.MockHashProvider))
+	uc := NewConfirmTOTPUseCase(mfaService)
+
+	result := uc.Execute(ctx, locales.EN_US, dtos.TOTPConfirm{UserID: 2, Code: "123456"})
+
+	assert.True(result.HasError())
+	assert.Equal(status.Unauthorized, result.StatusCode)
+	mocks.totpDeviceRepository.AssertNotCalled(t, "GetByUserID", mock.Anything)
+}
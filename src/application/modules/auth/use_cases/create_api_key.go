@@ -0,0 +1,106 @@
+package authusecases
+
+import (
+	contractproviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/guards"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// CreateAPIKeyUseCase is the use case for creating an API key the user
+// authenticates machine clients with
+type CreateAPIKeyUseCase struct {
+	usecase.BaseUseCaseValidation[dtos.APIKeyRequest, dtos.APIKeyCreated]
+
+	apiKeyRepo authcontracts.IAPIKeyRepository
+
+	hashProvider contractproviders.IHashProvider
+}
+
+var _ usecase.BaseUseCase[dtos.APIKeyRequest, dtos.APIKeyCreated] = (*CreateAPIKeyUseCase)(nil)
+
+// Execute creates the API key
+// - Generate the key: a random token behind the API key prefix
+// - Create the key: only its hash and its start, to list it, are stored
+// - The key is only returned in this response
+func (uc *CreateAPIKeyUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input dtos.APIKeyRequest,
+) *usecase.UseCaseResult[dtos.APIKeyCreated] {
+	result := usecase.NewUseCaseResult[dtos.APIKeyCreated]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	token, _, err := uc.hashProvider.OneTimeToken()
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error generating API key", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return result
+	}
+	key := sharedmodels.APIKeyPrefix + token
+
+	apiKey, err := uc.apiKeyRepo.Create(dtos.APIKeyCreate{
+		APIKeyBase: sharedmodels.APIKeyBase{
+			UserID:    input.UserID,
+			Name:      input.Name,
+			Prefix:    key[:sharedmodels.APIKeyDisplayLength],
+			Hash:      uc.hashProvider.HashOneTimeToken(key),
+			Scopes:    input.Scopes,
+			ExpiresAt: input.ExpiresAt,
+		},
+	})
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error creating API key", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return result
+	}
+
+	result.SetData(
+		status.Created,
+		dtos.APIKeyCreated{
+			APIKey: *apiKey,
+			Key:    key,
+		},
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.API_KEY_CREATED,
+		),
+	)
+	observability.GetObservabilityComponents().Logger.InfoWithContext("API key created successfully", uc.AppContext)
+	return result
+}
+
+func (uc *CreateAPIKeyUseCase) setError(result *usecase.UseCaseResult[dtos.APIKeyCreated], code status.ApplicationStatusEnum, key messages.MessageKeysEnum) {
+	result.SetError(
+		code,
+		uc.AppMessages.Get(
+			uc.Locale,
+			key,
+		),
+	)
+}
+
+func NewCreateAPIKeyUseCase(
+	apiKeyRepo authcontracts.IAPIKeyRepository,
+	hashProvider contractproviders.IHashProvider,
+) *CreateAPIKeyUseCase {
+	return &CreateAPIKeyUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[dtos.APIKeyRequest, dtos.APIKeyCreated]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(guards.UserResourceGuard[dtos.APIKeyRequest](), guards.NotImpersonatedGuard),
+		},
+		apiKeyRepo:   apiKeyRepo,
+		hashProvider: hashProvider,
+	}
+}
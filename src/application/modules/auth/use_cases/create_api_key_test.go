@@ -0,0 +1,102 @@
+package authusecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authmocks "github.com/simon3640/goprojectskeleton/src/application/modules/auth/mocks"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func apiKeyRequest() dtos.APIKeyRequest {
+	return dtos.APIKeyRequest{
+		Name:      "CI",
+		Scopes:    []string{sharedmodels.OAuthScopeProfile},
+		ExpiresAt: time.Now().Add(30 * 24 * time.Hour),
+		UserID:    1,
+	}
+}
+
+func TestCreateAPIKeyUseCase(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background(), User: &dtomocks.UserWithRole}
+
+	testAPIKeyRepository := new(authmocks.MockAPIKeyRepository)
+	testHashProvider := new(providersmocks.MockHashProvider)
+	uc := NewCreateAPIKeyUseCase(testAPIKeyRepository, testHashProvider)
+
+	testHashProvider.On("OneTimeToken").Return("abcdefgh12345678", []byte("token_hash"), nil)
+	testHashProvider.On("HashOneTimeToken", authmocks.APIKeyValue).Return(authmocks.APIKeyHash)
+	testAPIKeyRepository.On("Create", mock.MatchedBy(func(create dtos.APIKeyCreate) bool {
+		return create.UserID == 1 &&
+			create.Prefix == "gpk_abcdefgh" &&
+			string(create.Hash) == string(authmocks.APIKeyHash)
+	})).Return(authmocks.APIKey(), nil)
+
+	result := uc.Execute(ctx, locales.EN_US, apiKeyRequest())
+
+	assert.True(result.IsSuccess())
+	assert.Equal(status.Created, result.StatusCode)
+	assert.Equal(authmocks.APIKeyValue, result.Data.Key)
+	assert.Equal("gpk_abcdefgh", result.Data.Prefix)
+}
+
+func TestCreateAPIKeyUseCase_InvalidRequests(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(input *dtos.APIKeyRequest)
+		status status.ApplicationStatusEnum
+	}{
+		{name: "without name", modify: func(input *dtos.APIKeyRequest) { input.Name = "" }, status: status.InvalidInput},
+		{name: "without scopes", modify: func(input *dtos.APIKeyRequest) { input.Scopes = nil }, status: status.InvalidInput},
+		{name: "unknown scope", modify: func(input *dtos.APIKeyRequest) { input.Scopes = []string{"admin"} }, status: status.InvalidInput},
+		{name: "already expired", modify: func(input *dtos.APIKeyRequest) { input.ExpiresAt = time.Now().Add(-time.Minute) }, status: status.InvalidInput},
+		{name: "for another user", modify: func(input *dtos.APIKeyRequest) { input.UserID = 2 }, status: status.Unauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			ctx := &app_context.AppContext{Context: context.Background(), User: &dtomocks.UserWithRole}
+
+			testAPIKeyRepository := new(authmocks.MockAPIKeyRepository)
+			testHashProvider := new(providersmocks.MockHashProvider)
+			uc := NewCreateAPIKeyUseCase(testAPIKeyRepository, testHashProvider)
+
+			input := apiKeyRequest()
+			tt.modify(&input)
+			result := uc.Execute(ctx, locales.EN_US, input)
+
+			assert.True(result.HasError())
+			assert.Equal(tt.status, result.StatusCode)
+			testAPIKeyRepository.AssertNotCalled(t, "Create", mock.Anything)
+		})
+	}
+}
+
+func TestCreateAPIKeyUseCase_APIKeysCanNotCreateKeys(t *testing.T) {
+	assert := assert.New(t)
+	user := dtomocks.UserWithRole
+	user.SetScopes([]string{sharedmodels.OAuthScopeProfile})
+	ctx := &app_context.AppContext{Context: context.Background(), User: &user}
+
+	testAPIKeyRepository := new(authmocks.MockAPIKeyRepository)
+	testHashProvider := new(providersmocks.MockHashProvider)
+	uc := NewCreateAPIKeyUseCase(testAPIKeyRepository, testHashProvider)
+
+	result := uc.Execute(ctx, locales.EN_US, apiKeyRequest())
+
+	assert.True(result.HasError())
+	assert.Equal(status.Unauthorized, result.StatusCode)
+	testAPIKeyRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
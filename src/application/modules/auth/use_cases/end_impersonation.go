@@ -0,0 +1,103 @@
+package authusecases
+
+import (
+	"fmt"
+	"time"
+
+	contractsProviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/guards"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+)
+
+// EndImpersonationUseCase is the use case for an admin to stop acting as a
+// user. It is called with the token of the impersonation, the token is
+// revoked and the end of the impersonation is recorded.
+type EndImpersonationUseCase struct {
+	usecase.BaseUseCaseValidation[uint, bool]
+
+	impersonationRepository authcontracts.IImpersonationRepository
+	revocationProvider      contractsProviders.ITokenRevocationProvider
+}
+
+var _ usecase.BaseUseCase[uint, bool] = (*EndImpersonationUseCase)(nil)
+
+// Execute ends the impersonation of the user whose ID is the input
+func (uc *EndImpersonationUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input uint,
+) *usecase.UseCaseResult[bool] {
+	result := usecase.NewUseCaseResult[bool]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	if !uc.AppContext.User.IsImpersonated() {
+		result.SetError(
+			status.Unauthorized,
+			uc.AppMessages.Get(
+				uc.Locale,
+				messages.MessageKeysInstance.NOT_IMPERSONATING,
+			),
+		)
+		return result
+	}
+
+	impersonation, err := uc.impersonationRepository.GetByTokenID(uc.AppContext.User.GetImpersonationID())
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting impersonation by token ID", err.ToError(), uc.AppContext)
+		result.SetError(err.Code, uc.AppMessages.Get(uc.Locale, err.Context))
+		return result
+	}
+
+	if err := uc.revocationProvider.RevokeToken(impersonation.TokenID, impersonation.ExpiresAt); err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error revoking impersonation token", err.ToError(), uc.AppContext)
+		result.SetError(err.Code, uc.AppMessages.Get(uc.Locale, err.Context))
+		return result
+	}
+
+	now := time.Now()
+	if _, err := uc.impersonationRepository.Update(impersonation.ID, dtos.ImpersonationUpdate{ID: impersonation.ID, EndedAt: &now}); err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error ending impersonation", err.ToError(), uc.AppContext)
+		result.SetError(err.Code, uc.AppMessages.Get(uc.Locale, err.Context))
+		return result
+	}
+
+	result.SetData(
+		status.Success,
+		true,
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.IMPERSONATION_ENDED,
+		),
+	)
+	observability.GetObservabilityComponents().Logger.InfoWithContext(
+		fmt.Sprintf("audit impersonation=%d admin=user:%d user=user:%d event=ended", impersonation.ID, impersonation.AdminID, impersonation.UserID),
+		uc.AppContext,
+	)
+	return result
+}
+
+// NewEndImpersonationUseCase creates a new end impersonation use case
+func NewEndImpersonationUseCase(
+	impersonationRepository authcontracts.IImpersonationRepository,
+	revocationProvider contractsProviders.ITokenRevocationProvider,
+) *EndImpersonationUseCase {
+	return &EndImpersonationUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[uint, bool]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(guards.UserGetItSelf),
+		},
+		impersonationRepository: impersonationRepository,
+		revocationProvider:      revocationProvider,
+	}
+}
@@ -0,0 +1,83 @@
+package authusecases
+
+import (
+	"testing"
+
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authmocks "github.com/simon3640/goprojectskeleton/src/application/modules/auth/mocks"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEndImpersonationUseCase(t *testing.T) {
+	assert := assert.New(t)
+
+	user := dtomocks.UserWithRole
+	user.SetImpersonator(dtomocks.AdminUserWithRole.ID, authmocks.ImpersonationTokenID)
+	ctx := app_context.NewContextWithUser(&user)
+	impersonation := authmocks.Impersonation()
+
+	testImpersonationRepository := new(authmocks.MockImpersonationRepository)
+	testRevocationProvider := new(providersmocks.MockTokenRevocationProvider)
+	testImpersonationRepository.On("GetByTokenID", authmocks.ImpersonationTokenID).Return(impersonation, nil)
+	testRevocationProvider.On("RevokeToken", authmocks.ImpersonationTokenID, impersonation.ExpiresAt).Return(nil)
+	testImpersonationRepository.On("Update", impersonation.ID, mock.MatchedBy(func(update dtos.ImpersonationUpdate) bool {
+		return update.EndedAt != nil
+	})).Return(impersonation, nil)
+
+	uc := NewEndImpersonationUseCase(testImpersonationRepository, testRevocationProvider)
+	result := uc.Execute(ctx, locales.EN_US, user.ID)
+
+	assert.True(result.IsSuccess())
+	assert.True(*result.Data)
+	testRevocationProvider.AssertCalled(t, "RevokeToken", authmocks.ImpersonationTokenID, impersonation.ExpiresAt)
+	testImpersonationRepository.AssertCalled(t, "Update", impersonation.ID, mock.Anything)
+}
+
+func TestEndImpersonationUseCase_NotImpersonating(t *testing.T) {
+	assert := assert.New(t)
+
+	user := dtomocks.UserWithRole
+	ctx := app_context.NewContextWithUser(&user)
+
+	testImpersonationRepository := new(authmocks.MockImpersonationRepository)
+	testRevocationProvider := new(providersmocks.MockTokenRevocationProvider)
+
+	uc := NewEndImpersonationUseCase(testImpersonationRepository, testRevocationProvider)
+	result := uc.Execute(ctx, locales.EN_US, user.ID)
+
+	assert.True(result.HasError())
+	assert.Equal(status.Unauthorized, result.StatusCode)
+	testRevocationProvider.AssertNotCalled(t, "RevokeToken", mock.Anything, mock.Anything)
+}
+
+func TestEndImpersonationUseCase_RevocationError(t *testing.T) {
+	assert := assert.New(t)
+
+	user := dtomocks.UserWithRole
+	user.SetImpersonator(dtomocks.AdminUserWithRole.ID, authmocks.ImpersonationTokenID)
+	ctx := app_context.NewContextWithUser(&user)
+	impersonation := authmocks.Impersonation()
+
+	testImpersonationRepository := new(authmocks.MockImpersonationRepository)
+	testRevocationProvider := new(providersmocks.MockTokenRevocationProvider)
+	testImpersonationRepository.On("GetByTokenID", authmocks.ImpersonationTokenID).Return(impersonation, nil)
+	testRevocationProvider.On("RevokeToken", authmocks.ImpersonationTokenID, impersonation.ExpiresAt).Return(
+		applicationerrors.NewApplicationError(status.ProviderError, messages.MessageKeysInstance.SOMETHING_WENT_WRONG, "cache unavailable"),
+	)
+
+	uc := NewEndImpersonationUseCase(testImpersonationRepository, testRevocationProvider)
+	result := uc.Execute(ctx, locales.EN_US, user.ID)
+
+	assert.True(result.HasError())
+	// The impersonation is not recorded as ended while its token still works
+	testImpersonationRepository.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
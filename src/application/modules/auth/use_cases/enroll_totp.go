@@ -0,0 +1,96 @@
+package authusecases
+
+import (
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/guards"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+)
+
+// EnrollTOTPUseCase is the use case for enrolling an authenticator app.
+// It returns a new secret and its otpauth:// URI, the enrollment only
+// protects the logins once it is confirmed with a first code.
+type EnrollTOTPUseCase struct {
+	usecase.BaseUseCaseValidation[uint, dtos.OTPEnrollRequest]
+
+	mfaService *authservices.MFAService
+}
+
+var _ usecase.BaseUseCase[uint, dtos.OTPEnrollRequest] = (*EnrollTOTPUseCase)(nil)
+
+// Execute starts the TOTP enrollment of the user whose ID is the input
+func (uc *EnrollTOTPUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input uint,
+) *usecase.UseCaseResult[dtos.OTPEnrollRequest] {
+	result := usecase.NewUseCaseResult[dtos.OTPEnrollRequest]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	device, err := uc.mfaService.GetDevice(input)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting TOTP device", err.ToError(), uc.AppContext)
+		result.SetError(
+			err.Code,
+			uc.AppMessages.Get(
+				uc.Locale,
+				err.Context,
+			),
+		)
+		return result
+	}
+	if device != nil && device.IsConfirmed {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("TOTP already enabled", uc.AppContext)
+		result.SetError(
+			status.Conflict,
+			uc.AppMessages.Get(
+				uc.Locale,
+				messages.MessageKeysInstance.MFA_ALREADY_ENABLED,
+			),
+		)
+		return result
+	}
+
+	enrollment, err := uc.mfaService.StartEnrollment(input, uc.AppContext.User.Email)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error starting TOTP enrollment", err.ToError(), uc.AppContext)
+		result.SetError(
+			err.Code,
+			uc.AppMessages.Get(
+				uc.Locale,
+				err.Context,
+			),
+		)
+		return result
+	}
+
+	result.SetData(
+		status.Success,
+		enrollment,
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.MFA_ENROLLMENT_STARTED,
+		),
+	)
+	observability.GetObservabilityComponents().Logger.InfoWithContext("TOTP enrollment started", uc.AppContext)
+	return result
+}
+
+func NewEnrollTOTPUseCase(mfaService *authservices.MFAService) *EnrollTOTPUseCase {
+	return &EnrollTOTPUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[uint, dtos.OTPEnrollRequest]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(guards.UserGetItSelf, guards.NotImpersonatedGuard),
+		},
+		mfaService: mfaService,
+	}
+}
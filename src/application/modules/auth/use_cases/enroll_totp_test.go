@@ -0,0 +1,110 @@
+package authusecases
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authmocks "github.com/simon3640/goprojectskeleton/src/application/modules/auth/mocks"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type mfaTestMocks struct {
+	totpDeviceRepository   *authmocks.MockTOTPDeviceRepository
+	recoveryCodeRepository *authmocks.MockRecoveryCodeRepository
+	totpProvider           *providersmocks.MockTOTPProvider
+	encryptionProvider     *providersmocks.MockEncryptionProvider
+	hashProvider           *providersmocks.MockHashProvider
+}
+
+func newMFATestService(hashProvider *providersmocks.MockHashProvider) (*authservices.MFAService, *mfaTestMocks) {
+	mocks := &mfaTestMocks{
+		totpDeviceRepository:   new(authmocks.MockTOTPDeviceRepository),
+		recoveryCodeRepository: new(authmocks.MockRecoveryCodeRepository),
+		totpProvider:           new(providersmocks.MockTOTPProvider),
+		encryptionProvider:     new(providersmocks.MockEncryptionProvider),
+		hashProvider:           hashProvider,
+	}
+	mocks.encryptionProvider.On("Decrypt", authmocks.EncryptedTOTPSecret).Return([]byte(authmocks.TOTPSecret), nil)
+	return authservices.NewMFAService(
+		mocks.totpDeviceRepository,
+		mocks.recoveryCodeRepository,
+		mocks.totpProvider,
+		mocks.encryptionProvider,
+		mocks.hashProvider,
+	), mocks
+}
+
+func notFoundError() *applicationerrors.ApplicationError {
+	return applicationerrors.NewApplicationError(
+		status.NotFound,
+		messages.MessageKeysInstance.RESOURCE_NOT_FOUND,
+		"Resource not found",
+	)
+}
+
+func TestEnrollTOTPUseCase(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background(), User: &dtomocks.UserWithRole}
+
+	mfaService, mocks := newMFATestService(new(providersmocks.MockHashProvider))
+	uc := NewEnrollTOTPUseCase(mfaService)
+
+	mocks.totpDeviceRepository.On("GetByUserID", uint(1)).Return(nil, notFoundError())
+	mocks.totpDeviceRepository.On("DeleteByUserID", uint(1)).Return(nil)
+	mocks.totpProvider.On("GenerateSecret").Return(authmocks.TOTPSecret, nil)
+	mocks.encryptionProvider.On("Encrypt", []byte(authmocks.TOTPSecret)).Return(authmocks.EncryptedTOTPSecret, nil)
+	// Only the encrypted secret is stored
+	mocks.totpDeviceRepository.On("Create", mock.MatchedBy(func(create dtos.TOTPDeviceCreate) bool {
+		return create.UserID == 1 && bytes.Equal(create.Secret, authmocks.EncryptedTOTPSecret) && !create.IsConfirmed
+	})).Return(authmocks.TOTPDevice(), nil)
+	mocks.totpProvider.On("ProvisioningURI", authmocks.TOTPSecret, dtomocks.UserWithRole.Email).Return("otpauth://totp/uri")
+
+	result := uc.Execute(ctx, locales.EN_US, uint(1))
+
+	assert.True(result.IsSuccess())
+	assert.Equal(authmocks.TOTPSecret, result.Data.Secret)
+	assert.Equal("otpauth://totp/uri", result.Data.OTPAuthURI)
+	mocks.totpDeviceRepository.AssertExpectations(t)
+}
+
+func TestEnrollTOTPUseCase_AlreadyEnabled(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background(), User: &dtomocks.UserWithRole}
+
+	mfaService, mocks := newMFATestService(new(providersmocks.MockHashProvider))
+	uc := NewEnrollTOTPUseCase(mfaService)
+	mocks.totpDeviceRepository.On("GetByUserID", uint(1)).Return(authmocks.TOTPDevice(), nil)
+
+	result := uc.Execute(ctx, locales.EN_US, uint(1))
+
+	assert.True(result.HasError())
+	assert.Equal(status.Conflict, result.StatusCode)
+	mocks.totpDeviceRepository.AssertNotCalled(t, "DeleteByUserID", mock.Anything)
+	mocks.totpProvider.AssertNotCalled(t, "GenerateSecret")
+}
+
+func TestEnrollTOTPUseCase_OtherUser(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background(), User: &dtomocks.UserWithRole}
+
+	mfaService, mocks := newMFATestService(new(providersmocks.MockHashProvider))
+	uc := NewEnrollTOTPUseCase(mfaService)
+
+	result := uc.Execute(ctx, locales.EN_US, uint(2))
+
+	assert.True(result.HasError())
+	assert.Equal(status.Unauthorized, result.StatusCode)
+	mocks.totpDeviceRepository.AssertNotCalled(t, "GetByUserID", mock.Anything)
+}
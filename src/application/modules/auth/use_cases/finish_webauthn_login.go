@@ -0,0 +1,238 @@
+package authusecases
+
+import (
+	"context"
+
+	contractproviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
+
+// FinishWebAuthnLoginUseCase is the use case for authenticating a user with
+// the assertion of one of their passkeys
+type FinishWebAuthnLoginUseCase struct {
+	usecase.BaseUseCaseValidation[dtos.WebAuthnLoginFinish, dtos.Token]
+
+	userRepo         authcontracts.IUserRepository
+	credentialRepo   authcontracts.IWebAuthnCredentialRepository
+	refreshTokenRepo authcontracts.IRefreshTokenRepository
+
+	jwtProvider      authcontracts.IJWTProvider
+	hashProvider     contractproviders.IHashProvider
+	webAuthnProvider authcontracts.IWebAuthnProvider
+	sessionService   *authservices.WebAuthnSessionService
+}
+
+var _ usecase.BaseUseCase[dtos.WebAuthnLoginFinish, dtos.Token] = (*FinishWebAuthnLoginUseCase)(nil)
+
+// Execute authenticates a user with a passkey
+// - Consume the session: the challenge of the login can only be used once
+// - Get the credential: the passkey must belong to the user the login was started for
+// - Verify the assertion: the authenticator must have signed the challenge for this application
+// - Use the sign count: a counter that did not increase reveals a cloned authenticator
+// - Generate the tokens: generate the tokens of the active owner of the passkey
+func (uc *FinishWebAuthnLoginUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input dtos.WebAuthnLoginFinish,
+) *usecase.UseCaseResult[dtos.Token] {
+	result := usecase.NewUseCaseResult[dtos.Token]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	session := uc.consumeSession(result, input.SessionID)
+	if result.HasError() {
+		return result
+	}
+
+	credential := uc.getCredential(result, session, input.Credential)
+	if result.HasError() {
+		return result
+	}
+
+	signCount, err := uc.webAuthnProvider.VerifyAssertion(session.Challenge, credential.PublicKey, input.Credential)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Invalid passkey assertion", uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return result
+	}
+
+	uc.useSignCount(result, credential.ID, signCount)
+	if result.HasError() {
+		return result
+	}
+
+	user := uc.getActiveUser(result, credential.UserID)
+	if result.HasError() {
+		return result
+	}
+
+	token := uc.generateTokens(ctx, result, user)
+	if result.HasError() {
+		return result
+	}
+
+	result.SetData(
+		status.Success,
+		token,
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.AUTHORIZATION_GENERATED,
+		),
+	)
+	observability.GetObservabilityComponents().Logger.InfoWithContext("Passkey authenticated successfully", uc.AppContext)
+	return result
+}
+
+func (uc *FinishWebAuthnLoginUseCase) consumeSession(result *usecase.UseCaseResult[dtos.Token], sessionID string) *dtos.WebAuthnSession {
+	sessionHash := uc.hashProvider.HashOneTimeToken(sessionID)
+	session, err := uc.sessionService.Consume(authservices.LoginSessionKey(sessionHash))
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error consuming passkey login", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return nil
+	}
+	if session == nil {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("No pending passkey login", uc.AppContext)
+		uc.setError(result, status.Unauthorized, messages.MessageKeysInstance.WEBAUTHN_SESSION_EXPIRED)
+		return nil
+	}
+	return session
+}
+
+// getCredential gets the passkey of the assertion, it must belong to the
+// user the login was started for and to the user handle the authenticator returned
+func (uc *FinishWebAuthnLoginUseCase) getCredential(result *usecase.UseCaseResult[dtos.Token], session *dtos.WebAuthnSession, assertion dtos.WebAuthnAssertionCredential) *sharedmodels.WebAuthnCredential {
+	credentialID, decodeErr := dtos.DecodeWebAuthnBase64(assertion.RawID)
+	if decodeErr != nil {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Invalid passkey credential ID", uc.AppContext)
+		uc.setError(result, status.Unauthorized, messages.MessageKeysInstance.INVALID_WEBAUTHN_CREDENTIAL)
+		return nil
+	}
+
+	credential, err := uc.credentialRepo.GetByCredentialID(credentialID)
+	if err != nil && err.Code != status.NotFound {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting passkey by credential ID", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return nil
+	}
+
+	if credential == nil ||
+		(session.UserID != 0 && credential.UserID != session.UserID) ||
+		(assertion.Response.UserHandle != "" && assertion.Response.UserHandle != dtos.WebAuthnUserHandle(credential.UserID)) {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Passkey does not belong to the login", uc.AppContext)
+		uc.setError(result, status.Unauthorized, messages.MessageKeysInstance.INVALID_WEBAUTHN_CREDENTIAL)
+		return nil
+	}
+	return credential
+}
+
+// useSignCount records the sign count of the authenticator, only one of
+// concurrent logins with the same counter gets the tokens
+func (uc *FinishWebAuthnLoginUseCase) useSignCount(result *usecase.UseCaseResult[dtos.Token], credentialID uint, signCount uint32) {
+	used, err := uc.credentialRepo.UseSignCount(credentialID, signCount)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error updating passkey sign count", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return
+	}
+	if !used {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Passkey sign count did not increase, the authenticator may be cloned", uc.AppContext)
+		uc.setError(result, status.Unauthorized, messages.MessageKeysInstance.INVALID_WEBAUTHN_CREDENTIAL)
+	}
+}
+
+func (uc *FinishWebAuthnLoginUseCase) getActiveUser(result *usecase.UseCaseResult[dtos.Token], userID uint) *usermodels.UserWithRole {
+	user, err := uc.userRepo.GetUserWithRole(userID)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting user by ID", err.ToError(), uc.AppContext)
+		uc.setError(result, status.Unauthorized, messages.MessageKeysInstance.INVALID_WEBAUTHN_CREDENTIAL)
+		return nil
+	}
+	if user.Status == nil || *user.Status != usermodels.UserStatusActive {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Passkey login attempted by a user that is not active", uc.AppContext)
+		uc.setError(result, status.Unauthorized, messages.MessageKeysInstance.USER_NOT_ACTIVE)
+		return nil
+	}
+	return user
+}
+
+func (uc *FinishWebAuthnLoginUseCase) generateTokens(ctx context.Context, result *usecase.UseCaseResult[dtos.Token], user *usermodels.UserWithRole) dtos.Token {
+	claims := authservices.BuildAccessClaimsService(user)
+
+	access, exp, err := uc.jwtProvider.GenerateAccessToken(ctx, user.GetUserIDString(), claims)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error generating access token", err.ToError(), uc.AppContext)
+		uc.setError(result, status.Conflict, messages.MessageKeysInstance.SOMETHING_WENT_WRONG)
+		return dtos.Token{}
+	}
+
+	// Every login starts a new session
+	refresh, expRefresh, err := authservices.IssueRefreshTokenService(
+		ctx,
+		user.ID,
+		"",
+		uc.jwtProvider,
+		uc.hashProvider,
+		uc.refreshTokenRepo,
+	)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error generating refresh token", err.ToError(), uc.AppContext)
+		uc.setError(result, status.Conflict, messages.MessageKeysInstance.SOMETHING_WENT_WRONG)
+		return dtos.Token{}
+	}
+
+	return dtos.Token{
+		AccessToken:           access,
+		RefreshToken:          refresh,
+		TokenType:             "Bearer",
+		AccessTokenExpiresAt:  exp,
+		RefreshTokenExpiresAt: expRefresh,
+	}
+}
+
+func (uc *FinishWebAuthnLoginUseCase) setError(result *usecase.UseCaseResult[dtos.Token], code status.ApplicationStatusEnum, key messages.MessageKeysEnum) {
+	result.SetError(
+		code,
+		uc.AppMessages.Get(
+			uc.Locale,
+			key,
+		),
+	)
+}
+
+func NewFinishWebAuthnLoginUseCase(
+	userRepo authcontracts.IUserRepository,
+	credentialRepo authcontracts.IWebAuthnCredentialRepository,
+	refreshTokenRepo authcontracts.IRefreshTokenRepository,
+	hashProvider contractproviders.IHashProvider,
+	jwtProvider authcontracts.IJWTProvider,
+	webAuthnProvider authcontracts.IWebAuthnProvider,
+	sessionService *authservices.WebAuthnSessionService,
+) *FinishWebAuthnLoginUseCase {
+	return &FinishWebAuthnLoginUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[dtos.WebAuthnLoginFinish, dtos.Token]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(),
+		},
+		userRepo:         userRepo,
+		credentialRepo:   credentialRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		jwtProvider:      jwtProvider,
+		hashProvider:     hashProvider,
+		webAuthnProvider: webAuthnProvider,
+		sessionService:   sessionService,
+	}
+}
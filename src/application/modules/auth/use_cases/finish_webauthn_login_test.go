@@ -0,0 +1,169 @@
+package authusecases
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authmocks "github.com/simon3640/goprojectskeleton/src/application/modules/auth/mocks"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var webAuthnLoginFinish = dtos.WebAuthnLoginFinish{
+	SessionID: "session",
+	Credential: dtos.WebAuthnAssertionCredential{
+		ID:    dtos.EncodeWebAuthnBase64(authmocks.WebAuthnCredentialID),
+		RawID: dtos.EncodeWebAuthnBase64(authmocks.WebAuthnCredentialID),
+		Type:  dtos.WebAuthnPublicKeyType,
+		Response: dtos.WebAuthnAssertionResponse{
+			ClientDataJSON:    "client_data",
+			AuthenticatorData: "authenticator_data",
+			Signature:         "signature",
+			UserHandle:        dtos.WebAuthnUserHandle(1),
+		},
+	},
+}
+
+type webAuthnLoginTestMocks struct {
+	userRepository         *authmocks.MockUserRepository
+	credentialRepository   *authmocks.MockWebAuthnCredentialRepository
+	refreshTokenRepository *authmocks.MockRefreshTokenRepository
+	hashProvider           *providersmocks.MockHashProvider
+	jwtProvider            *authmocks.MockJWTProvider
+	webAuthnProvider       *authmocks.MockWebAuthnProvider
+	cache                  *providersmocks.MockCacheProvider
+}
+
+func newFinishWebAuthnLoginTestUseCase() (*FinishWebAuthnLoginUseCase, *webAuthnLoginTestMocks) {
+	sessions, cache := newWebAuthnTestSessions()
+	mocks := &webAuthnLoginTestMocks{
+		userRepository:         new(authmocks.MockUserRepository),
+		credentialRepository:   new(authmocks.MockWebAuthnCredentialRepository),
+		refreshTokenRepository: new(authmocks.MockRefreshTokenRepository),
+		hashProvider:           new(providersmocks.MockHashProvider),
+		jwtProvider:            new(authmocks.MockJWTProvider),
+		webAuthnProvider:       new(authmocks.MockWebAuthnProvider),
+		cache:                  cache,
+	}
+	mocks.hashProvider.On("HashOneTimeToken", webAuthnLoginFinish.SessionID).Return(webAuthnSessionHash)
+	return NewFinishWebAuthnLoginUseCase(
+		mocks.userRepository,
+		mocks.credentialRepository,
+		mocks.refreshTokenRepository,
+		mocks.hashProvider,
+		mocks.jwtProvider,
+		mocks.webAuthnProvider,
+		sessions,
+	), mocks
+}
+
+func TestFinishWebAuthnLoginUseCase(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
+	uc, mocks := newFinishWebAuthnLoginTestUseCase()
+	userID := strconv.FormatUint(uint64(dtomocks.UserWithRole.ID), 10)
+
+	mockPendingWebAuthnSession(mocks.cache, authservices.LoginSessionKey(webAuthnSessionHash), 0, 1)
+	mocks.credentialRepository.On("GetByCredentialID", authmocks.WebAuthnCredentialID).Return(authmocks.WebAuthnCredential(), nil)
+	mocks.webAuthnProvider.On("VerifyAssertion", webAuthnTestChallenge, authmocks.WebAuthnPublicKey, webAuthnLoginFinish.Credential).Return(uint32(6), nil)
+	mocks.credentialRepository.On("UseSignCount", uint(1), uint32(6)).Return(true, nil)
+	mocks.userRepository.On("GetUserWithRole", uint(1)).Return(&dtomocks.UserWithRole, nil)
+	mocks.jwtProvider.On("GenerateAccessToken", ctx, userID, mock.Anything).Return("newAccessToken", time.Now().Add(1*time.Hour), nil)
+	mocks.jwtProvider.On("GenerateRefreshToken", ctx, userID).Return("newRefreshToken", time.Now().Add(24*time.Hour), nil)
+	mocks.hashProvider.On("OneTimeToken").Return("family", []byte("family"), nil)
+	mocks.hashProvider.On("HashOneTimeToken", "newRefreshToken").Return(authmocks.RefreshTokenHash)
+	mocks.refreshTokenRepository.On("Create", mock.AnythingOfType("authdtos.RefreshTokenCreate")).Return(authmocks.RefreshToken(), nil)
+
+	result := uc.Execute(ctx, locales.EN_US, webAuthnLoginFinish)
+
+	assert.True(result.IsSuccess())
+	assert.Equal("newAccessToken", result.Data.AccessToken)
+	assert.Equal("newRefreshToken", result.Data.RefreshToken)
+	mocks.credentialRepository.AssertExpectations(t)
+	mocks.cache.AssertExpectations(t)
+}
+
+func TestFinishWebAuthnLoginUseCase_Errors(t *testing.T) {
+	invalidSignature := applicationerrors.NewApplicationError(
+		status.Unauthorized,
+		messages.MessageKeysInstance.INVALID_WEBAUTHN_CREDENTIAL,
+		"invalid signature",
+	)
+	otherUserHandle := webAuthnLoginFinish
+	otherUserHandle.Credential.Response.UserHandle = dtos.WebAuthnUserHandle(2)
+	suspendedStatus := usermodels.UserStatusSuspended
+	suspendedUser := dtomocks.UserWithRole
+	suspendedUser.Status = &suspendedStatus
+
+	cases := []struct {
+		name          string
+		input         dtos.WebAuthnLoginFinish
+		hasSession    bool
+		claims        int64
+		sessionUserID uint
+		credential    *sharedmodels.WebAuthnCredential
+		verifyErr     *applicationerrors.ApplicationError
+		counterUsed   bool
+		user          *usermodels.UserWithRole
+		statusCode    status.ApplicationStatusEnum
+	}{
+		{name: "missing session", input: dtos.WebAuthnLoginFinish{Credential: webAuthnLoginFinish.Credential}, statusCode: status.InvalidInput},
+		{name: "no pending login", input: webAuthnLoginFinish, statusCode: status.Unauthorized},
+		{name: "challenge already used", input: webAuthnLoginFinish, hasSession: true, claims: 2, statusCode: status.Unauthorized},
+		{name: "unknown credential", input: webAuthnLoginFinish, hasSession: true, claims: 1, statusCode: status.Unauthorized},
+		{name: "credential of another user", input: webAuthnLoginFinish, hasSession: true, claims: 1, sessionUserID: 2, credential: authmocks.WebAuthnCredential(), statusCode: status.Unauthorized},
+		{name: "user handle of another user", input: otherUserHandle, hasSession: true, claims: 1, credential: authmocks.WebAuthnCredential(), statusCode: status.Unauthorized},
+		{name: "invalid signature", input: webAuthnLoginFinish, hasSession: true, claims: 1, credential: authmocks.WebAuthnCredential(), verifyErr: invalidSignature, statusCode: status.Unauthorized},
+		{name: "sign count did not increase", input: webAuthnLoginFinish, hasSession: true, claims: 1, credential: authmocks.WebAuthnCredential(), statusCode: status.Unauthorized},
+		{name: "user not active", input: webAuthnLoginFinish, hasSession: true, claims: 1, credential: authmocks.WebAuthnCredential(), counterUsed: true, user: &suspendedUser, statusCode: status.Unauthorized},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			ctx := &app_context.AppContext{Context: context.Background()}
+			uc, mocks := newFinishWebAuthnLoginTestUseCase()
+
+			key := authservices.LoginSessionKey(webAuthnSessionHash)
+			if tc.hasSession {
+				mockPendingWebAuthnSession(mocks.cache, key, tc.sessionUserID, tc.claims)
+			} else {
+				mocks.cache.On("Get", key, mock.Anything).Return(false, nil)
+			}
+			if tc.credential != nil {
+				mocks.credentialRepository.On("GetByCredentialID", authmocks.WebAuthnCredentialID).Return(tc.credential, nil)
+			} else {
+				mocks.credentialRepository.On("GetByCredentialID", authmocks.WebAuthnCredentialID).Return(nil, notFoundError())
+			}
+			if tc.verifyErr != nil {
+				mocks.webAuthnProvider.On("VerifyAssertion", webAuthnTestChallenge, authmocks.WebAuthnPublicKey, tc.input.Credential).Return(nil, tc.verifyErr)
+			} else {
+				mocks.webAuthnProvider.On("VerifyAssertion", webAuthnTestChallenge, authmocks.WebAuthnPublicKey, tc.input.Credential).Return(uint32(6), nil)
+			}
+			mocks.credentialRepository.On("UseSignCount", uint(1), uint32(6)).Return(tc.counterUsed, nil)
+			if tc.user != nil {
+				mocks.userRepository.On("GetUserWithRole", uint(1)).Return(tc.user, nil)
+			}
+
+			result := uc.Execute(ctx, locales.EN_US, tc.input)
+
+			assert.True(result.HasError())
+			assert.Equal(tc.statusCode, result.StatusCode)
+			mocks.jwtProvider.AssertNotCalled(t, "GenerateAccessToken", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package authusecases
+
+import (
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/guards"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// DefaultWebAuthnCredentialName is the name of the passkeys registered without one
+const DefaultWebAuthnCredentialName = "Passkey"
+
+// FinishWebAuthnRegistrationUseCase is the use case for completing the
+// registration of a passkey with the credential created by the authenticator
+type FinishWebAuthnRegistrationUseCase struct {
+	usecase.BaseUseCaseValidation[dtos.WebAuthnRegistrationFinish, sharedmodels.WebAuthnCredential]
+
+	credentialRepo authcontracts.IWebAuthnCredentialRepository
+
+	webAuthnProvider authcontracts.IWebAuthnProvider
+	sessionService   *authservices.WebAuthnSessionService
+}
+
+var _ usecase.BaseUseCase[dtos.WebAuthnRegistrationFinish, sharedmodels.WebAuthnCredential] = (*FinishWebAuthnRegistrationUseCase)(nil)
+
+// Execute completes the passkey registration of the user
+// - Consume the session: the challenge of the registration can only be used once
+// - Verify the credential: the authenticator must have signed the challenge for this application
+// - Store the credential: a passkey can only be registered once
+func (uc *FinishWebAuthnRegistrationUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input dtos.WebAuthnRegistrationFinish,
+) *usecase.UseCaseResult[sharedmodels.WebAuthnCredential] {
+	result := usecase.NewUseCaseResult[sharedmodels.WebAuthnCredential]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	session, err := uc.sessionService.Consume(authservices.RegistrationSessionKey(input.UserID))
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error consuming passkey registration", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return result
+	}
+	if session == nil || session.UserID != input.UserID {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("No pending passkey registration", uc.AppContext)
+		uc.setError(result, status.InvalidInput, messages.MessageKeysInstance.WEBAUTHN_SESSION_EXPIRED)
+		return result
+	}
+
+	verified, err := uc.webAuthnProvider.VerifyRegistration(session.Challenge, input.Credential)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Invalid passkey registration", uc.AppContext)
+		// The user is authenticated, an invalid passkey must not end their session
+		uc.setError(result, status.InvalidInput, err.Context)
+		return result
+	}
+
+	uc.checkNotRegistered(result, verified.CredentialID)
+	if result.HasError() {
+		return result
+	}
+
+	name := input.Name
+	if name == "" {
+		name = DefaultWebAuthnCredentialName
+	}
+	credential, err := uc.credentialRepo.Create(dtos.WebAuthnCredentialCreate{
+		WebAuthnCredentialBase: sharedmodels.WebAuthnCredentialBase{
+			UserID:       input.UserID,
+			Name:         name,
+			CredentialID: verified.CredentialID,
+			PublicKey:    verified.PublicKey,
+			SignCount:    verified.SignCount,
+			Transports:   input.Credential.Response.Transports,
+			AAGUID:       verified.AAGUID,
+		},
+	})
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error creating passkey", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return result
+	}
+
+	result.SetData(
+		status.Created,
+		*credential,
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.WEBAUTHN_CREDENTIAL_REGISTERED,
+		),
+	)
+	observability.GetObservabilityComponents().Logger.InfoWithContext("Passkey registered", uc.AppContext)
+	return result
+}
+
+func (uc *FinishWebAuthnRegistrationUseCase) checkNotRegistered(result *usecase.UseCaseResult[sharedmodels.WebAuthnCredential], credentialID []byte) {
+	existing, err := uc.credentialRepo.GetByCredentialID(credentialID)
+	if err != nil && err.Code != status.NotFound {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting passkey by credential ID", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return
+	}
+	if existing != nil {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Passkey already registered", uc.AppContext)
+		uc.setError(result, status.Conflict, messages.MessageKeysInstance.WEBAUTHN_CREDENTIAL_ALREADY_REGISTERED)
+	}
+}
+
+func (uc *FinishWebAuthnRegistrationUseCase) setError(result *usecase.UseCaseResult[sharedmodels.WebAuthnCredential], code status.ApplicationStatusEnum, key messages.MessageKeysEnum) {
+	result.SetError(
+		code,
+		uc.AppMessages.Get(
+			uc.Locale,
+			key,
+		),
+	)
+}
+
+func NewFinishWebAuthnRegistrationUseCase(
+	credentialRepo authcontracts.IWebAuthnCredentialRepository,
+	webAuthnProvider authcontracts.IWebAuthnProvider,
+	sessionService *authservices.WebAuthnSessionService,
+) *FinishWebAuthnRegistrationUseCase {
+	return &FinishWebAuthnRegistrationUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[dtos.WebAuthnRegistrationFinish, sharedmodels.WebAuthnCredential]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(guards.UserResourceGuard[dtos.WebAuthnRegistrationFinish](), guards.NotImpersonatedGuard),
+		},
+		credentialRepo:   credentialRepo,
+		webAuthnProvider: webAuthnProvider,
+		sessionService:   sessionService,
+	}
+}
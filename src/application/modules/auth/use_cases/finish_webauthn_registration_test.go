@@ -0,0 +1,131 @@
+package authusecases
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authmocks "github.com/simon3640/goprojectskeleton/src/application/modules/auth/mocks"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var webAuthnRegistrationFinish = dtos.WebAuthnRegistrationFinish{
+	UserID: 1,
+	Name:   "Laptop",
+	Credential: dtos.WebAuthnRegistrationCredential{
+		ID:    "credential",
+		RawID: "credential",
+		Type:  dtos.WebAuthnPublicKeyType,
+		Response: dtos.WebAuthnAttestationResponse{
+			ClientDataJSON:    "client_data",
+			AttestationObject: "attestation",
+			Transports:        []string{"internal"},
+		},
+	},
+}
+
+var webAuthnVerifiedCredential = &dtos.WebAuthnVerifiedCredential{
+	CredentialID: authmocks.WebAuthnCredentialID,
+	PublicKey:    authmocks.WebAuthnPublicKey,
+}
+
+func TestFinishWebAuthnRegistrationUseCase(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background(), User: &dtomocks.UserWithRole}
+
+	testCredentialRepository := new(authmocks.MockWebAuthnCredentialRepository)
+	testWebAuthnProvider := new(authmocks.MockWebAuthnProvider)
+	sessions, cache := newWebAuthnTestSessions()
+	uc := NewFinishWebAuthnRegistrationUseCase(testCredentialRepository, testWebAuthnProvider, sessions)
+
+	mockPendingWebAuthnSession(cache, authservices.RegistrationSessionKey(1), 1, 1)
+	testWebAuthnProvider.On("VerifyRegistration", webAuthnTestChallenge, webAuthnRegistrationFinish.Credential).Return(webAuthnVerifiedCredential, nil)
+	testCredentialRepository.On("GetByCredentialID", authmocks.WebAuthnCredentialID).Return(nil, notFoundError())
+	testCredentialRepository.On("Create", mock.MatchedBy(func(create dtos.WebAuthnCredentialCreate) bool {
+		return create.UserID == 1 &&
+			create.Name == "Laptop" &&
+			bytes.Equal(create.CredentialID, authmocks.WebAuthnCredentialID) &&
+			bytes.Equal(create.PublicKey, authmocks.WebAuthnPublicKey) &&
+			len(create.Transports) == 1 && create.Transports[0] == "internal"
+	})).Return(authmocks.WebAuthnCredential(), nil)
+
+	result := uc.Execute(ctx, locales.EN_US, webAuthnRegistrationFinish)
+
+	assert.True(result.IsSuccess())
+	assert.Equal(status.Created, result.StatusCode)
+	assert.Equal(authmocks.WebAuthnCredentialID, result.Data.CredentialID)
+	testCredentialRepository.AssertExpectations(t)
+	cache.AssertExpectations(t)
+}
+
+func TestFinishWebAuthnRegistrationUseCase_Errors(t *testing.T) {
+	invalidCredential := applicationerrors.NewApplicationError(
+		status.Unauthorized,
+		messages.MessageKeysInstance.INVALID_WEBAUTHN_CREDENTIAL,
+		"invalid signature",
+	)
+
+	cases := []struct {
+		name        string
+		input       dtos.WebAuthnRegistrationFinish
+		hasSession  bool
+		claims      int64
+		verifyErr   *applicationerrors.ApplicationError
+		registered  bool
+		statusCode  status.ApplicationStatusEnum
+		wantsVerify bool
+	}{
+		{name: "other user", input: dtos.WebAuthnRegistrationFinish{UserID: 2, Credential: webAuthnRegistrationFinish.Credential}, statusCode: status.Unauthorized},
+		{name: "missing response", input: dtos.WebAuthnRegistrationFinish{UserID: 1, Credential: dtos.WebAuthnRegistrationCredential{RawID: "id", Type: dtos.WebAuthnPublicKeyType}}, statusCode: status.InvalidInput},
+		{name: "no pending registration", input: webAuthnRegistrationFinish, statusCode: status.InvalidInput},
+		{name: "challenge already used", input: webAuthnRegistrationFinish, hasSession: true, claims: 2, statusCode: status.InvalidInput},
+		{name: "invalid credential", input: webAuthnRegistrationFinish, hasSession: true, claims: 1, verifyErr: invalidCredential, statusCode: status.InvalidInput, wantsVerify: true},
+		{name: "already registered", input: webAuthnRegistrationFinish, hasSession: true, claims: 1, registered: true, statusCode: status.Conflict, wantsVerify: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			ctx := &app_context.AppContext{Context: context.Background(), User: &dtomocks.UserWithRole}
+
+			testCredentialRepository := new(authmocks.MockWebAuthnCredentialRepository)
+			testWebAuthnProvider := new(authmocks.MockWebAuthnProvider)
+			sessions, cache := newWebAuthnTestSessions()
+			uc := NewFinishWebAuthnRegistrationUseCase(testCredentialRepository, testWebAuthnProvider, sessions)
+
+			key := authservices.RegistrationSessionKey(1)
+			if tc.hasSession {
+				mockPendingWebAuthnSession(cache, key, 1, tc.claims)
+			} else {
+				cache.On("Get", key, mock.Anything).Return(false, nil)
+			}
+			if tc.verifyErr != nil {
+				testWebAuthnProvider.On("VerifyRegistration", webAuthnTestChallenge, tc.input.Credential).Return(nil, tc.verifyErr)
+			} else {
+				testWebAuthnProvider.On("VerifyRegistration", webAuthnTestChallenge, tc.input.Credential).Return(webAuthnVerifiedCredential, nil)
+			}
+			if tc.registered {
+				testCredentialRepository.On("GetByCredentialID", authmocks.WebAuthnCredentialID).Return(authmocks.WebAuthnCredential(), nil)
+			}
+
+			result := uc.Execute(ctx, locales.EN_US, tc.input)
+
+			assert.True(result.HasError())
+			assert.Equal(tc.statusCode, result.StatusCode)
+			if !tc.wantsVerify {
+				testWebAuthnProvider.AssertNotCalled(t, "VerifyRegistration", mock.Anything, mock.Anything)
+			}
+			testCredentialRepository.AssertNotCalled(t, "Create", mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package authusecases
+
+import (
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/guards"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// GetAPIKeysUseCase is the use case for listing the API keys of the user.
+// Keys are listed by their prefix, the keys themselves are never returned.
+type GetAPIKeysUseCase struct {
+	usecase.BaseUseCaseValidation[uint, []sharedmodels.APIKey]
+
+	apiKeyRepo authcontracts.IAPIKeyRepository
+}
+
+var _ usecase.BaseUseCase[uint, []sharedmodels.APIKey] = (*GetAPIKeysUseCase)(nil)
+
+// Execute lists the API keys of the user, including the expired and revoked ones
+func (uc *GetAPIKeysUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input uint,
+) *usecase.UseCaseResult[[]sharedmodels.APIKey] {
+	result := usecase.NewUseCaseResult[[]sharedmodels.APIKey]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	apiKeys, err := uc.apiKeyRepo.GetByUserID(input)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting API keys of user", err.ToError(), uc.AppContext)
+		result.SetError(
+			err.Code,
+			uc.AppMessages.Get(
+				uc.Locale,
+				err.Context,
+			),
+		)
+		return result
+	}
+
+	result.SetData(
+		status.Success,
+		apiKeys,
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.API_KEY_LIST_SUCCESS,
+		),
+	)
+	return result
+}
+
+func NewGetAPIKeysUseCase(apiKeyRepo authcontracts.IAPIKeyRepository) *GetAPIKeysUseCase {
+	return &GetAPIKeysUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[uint, []sharedmodels.APIKey]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(guards.UserGetItSelf),
+		},
+		apiKeyRepo: apiKeyRepo,
+	}
+}
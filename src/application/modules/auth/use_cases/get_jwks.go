@@ -0,0 +1,59 @@
+package authusecases
+
+import (
+	"time"
+
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+)
+
+// GetJWKSUseCase is the use case for publishing the public keys that verify
+// the issued tokens, so other services can verify them without a shared secret
+type GetJWKSUseCase struct {
+	usecase.BaseUseCaseValidation[time.Time, dtos.JWKS]
+
+	jwtProvider authcontracts.IJWTProvider
+}
+
+var _ usecase.BaseUseCase[time.Time, dtos.JWKS] = (*GetJWKSUseCase)(nil)
+
+// Execute returns the public keys that verify the tokens at the input time
+func (uc *GetJWKSUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input time.Time,
+) *usecase.UseCaseResult[dtos.JWKS] {
+	result := usecase.NewUseCaseResult[dtos.JWKS]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+
+	jwks := uc.jwtProvider.PublicKeys(input)
+	if jwks.Keys == nil {
+		jwks.Keys = []dtos.JWK{}
+	}
+	result.SetData(
+		status.Success,
+		jwks,
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.JWKS_RETRIEVED,
+		),
+	)
+	return result
+}
+
+func NewGetJWKSUseCase(
+	jwtProvider authcontracts.IJWTProvider,
+) *GetJWKSUseCase {
+	return &GetJWKSUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[time.Time, dtos.JWKS]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(),
+		},
+		jwtProvider: jwtProvider,
+	}
+}
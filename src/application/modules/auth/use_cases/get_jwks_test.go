@@ -0,0 +1,49 @@
+package authusecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authmocks "github.com/simon3640/goprojectskeleton/src/application/modules/auth/mocks"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetJWKSUseCase(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
+	now := time.Now()
+
+	testJWTProvider := new(authmocks.MockJWTProvider)
+	jwks := authdtos.JWKS{Keys: []authdtos.JWK{{Kty: "OKP", Use: "sig", Alg: "EdDSA", Kid: "2026-01", Crv: "Ed25519", X: "x"}}}
+	testJWTProvider.On("PublicKeys", now).Return(jwks)
+
+	uc := NewGetJWKSUseCase(testJWTProvider)
+	result := uc.Execute(ctx, locales.EN_US, now)
+
+	assert.True(result.IsSuccess())
+	assert.Equal(status.Success, result.StatusCode)
+	assert.Equal(jwks, *result.Data)
+}
+
+func TestGetJWKSUseCase_SymmetricKeys(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
+	now := time.Now()
+
+	testJWTProvider := new(authmocks.MockJWTProvider)
+	testJWTProvider.On("PublicKeys", now).Return(authdtos.JWKS{})
+
+	uc := NewGetJWKSUseCase(testJWTProvider)
+	result := uc.Execute(ctx, locales.EN_US, now)
+
+	// The key set is empty, not null
+	assert.True(result.IsSuccess())
+	assert.NotNil(result.Data.Keys)
+	assert.Empty(result.Data.Keys)
+}
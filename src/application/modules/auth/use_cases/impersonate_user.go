@@ -0,0 +1,204 @@
+package authusecases
+
+import (
+	"fmt"
+	"time"
+
+	contractsProviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	contracts_repositories "github.com/simon3640/goprojectskeleton/src/application/contracts/repositories"
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/guards"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/services"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
+
+// ImpersonateUserUseCase is the use case for an admin to act as another user.
+// It returns a short-lived access token whose subject is the user and whose
+// act claim names the admin, without refresh token. Every impersonation is
+// recorded with the ID of its token so it can be ended and audited.
+type ImpersonateUserUseCase struct {
+	usecase.BaseUseCaseValidation[dtos.ImpersonationRequest, dtos.ImpersonationStarted]
+
+	userRepository          authcontracts.IUserRepository
+	impersonationRepository authcontracts.IImpersonationRepository
+
+	jwtProvider  authcontracts.IJWTProvider
+	hashProvider contractsProviders.IHashProvider
+
+	permissionService *services.PermissionService
+	ttl               time.Duration
+}
+
+var _ usecase.BaseUseCase[dtos.ImpersonationRequest, dtos.ImpersonationStarted] = (*ImpersonateUserUseCase)(nil)
+
+// Execute starts the impersonation
+// - Admins cannot impersonate themselves nor the users allowed to impersonate
+// - Record the impersonation
+// - Issue the access token of the impersonation
+func (uc *ImpersonateUserUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input dtos.ImpersonationRequest,
+) *usecase.UseCaseResult[dtos.ImpersonationStarted] {
+	result := usecase.NewUseCaseResult[dtos.ImpersonationStarted]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	target := uc.getTarget(result, input.UserID)
+	if result.HasError() {
+		return result
+	}
+
+	tokenID, _, err := uc.hashProvider.OneTimeToken()
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error generating impersonation token ID", err.ToError(), uc.AppContext)
+		result.SetError(err.Code, uc.AppMessages.Get(uc.Locale, err.Context))
+		return result
+	}
+
+	impersonation, err := uc.impersonationRepository.Create(dtos.ImpersonationCreate{
+		ImpersonationBase: sharedmodels.ImpersonationBase{
+			AdminID:   uc.AppContext.User.ID,
+			UserID:    target.ID,
+			TokenID:   tokenID,
+			Reason:    input.Reason,
+			ExpiresAt: time.Now().Add(uc.ttl),
+		},
+	})
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error recording impersonation", err.ToError(), uc.AppContext)
+		result.SetError(err.Code, uc.AppMessages.Get(uc.Locale, err.Context))
+		return result
+	}
+
+	access := uc.generateAccessToken(result, target, impersonation)
+	if result.HasError() {
+		uc.endImpersonation(impersonation)
+		return result
+	}
+
+	result.SetData(
+		status.Created,
+		dtos.ImpersonationStarted{
+			AccessToken:   access,
+			ExpiresAt:     impersonation.ExpiresAt,
+			Impersonation: *impersonation,
+		},
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.IMPERSONATION_STARTED,
+		),
+	)
+	observability.GetObservabilityComponents().Logger.InfoWithContext(
+		fmt.Sprintf("audit impersonation=%d admin=user:%d user=user:%d event=started", impersonation.ID, impersonation.AdminID, impersonation.UserID),
+		uc.AppContext,
+	)
+	return result
+}
+
+// getTarget gets the user to impersonate with their permissions. Admins
+// allowed to impersonate cannot be impersonated, impersonations would let
+// them borrow each other's identities.
+func (uc *ImpersonateUserUseCase) getTarget(result *usecase.UseCaseResult[dtos.ImpersonationStarted], userID uint) *usermodels.UserWithRole {
+	if userID == uc.AppContext.User.ID {
+		uc.setCannotImpersonate(result)
+		return nil
+	}
+	target, err := uc.userRepository.GetUserWithRole(userID)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting user to impersonate", err.ToError(), uc.AppContext)
+		result.SetError(err.Code, uc.AppMessages.Get(uc.Locale, err.Context))
+		return nil
+	}
+	if err := uc.permissionService.SetUserPermissions(target); err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting user permissions", err.ToError(), uc.AppContext)
+		result.SetError(err.Code, uc.AppMessages.Get(uc.Locale, err.Context))
+		return nil
+	}
+	if target.HasPermission(usermodels.PermissionUserImpersonate) {
+		uc.setCannotImpersonate(result)
+		return nil
+	}
+	return target
+}
+
+func (uc *ImpersonateUserUseCase) setCannotImpersonate(result *usecase.UseCaseResult[dtos.ImpersonationStarted]) {
+	result.SetError(
+		status.Unauthorized,
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.CANNOT_IMPERSONATE,
+		),
+	)
+}
+
+// generateAccessToken issues the token of the impersonation, its jti and
+// expiry are those of the record
+func (uc *ImpersonateUserUseCase) generateAccessToken(result *usecase.UseCaseResult[dtos.ImpersonationStarted], target *usermodels.UserWithRole, impersonation *sharedmodels.Impersonation) string {
+	claims := authservices.BuildAccessClaimsService(target)
+	claims["act"] = map[string]any{"sub": uc.AppContext.User.GetUserIDString()}
+	claims["jti"] = impersonation.TokenID
+	claims["exp"] = impersonation.ExpiresAt.Unix()
+	access, _, err := uc.jwtProvider.GenerateAccessToken(uc.AppContext, target.GetUserIDString(), claims)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error generating impersonation access token", err.ToError(), uc.AppContext)
+		result.SetError(
+			status.InternalError,
+			uc.AppMessages.Get(
+				uc.Locale,
+				messages.MessageKeysInstance.SOMETHING_WENT_WRONG,
+			),
+		)
+		return ""
+	}
+	return access
+}
+
+// endImpersonation ends the record of an impersonation whose token could not
+// be issued, errors are logged
+func (uc *ImpersonateUserUseCase) endImpersonation(impersonation *sharedmodels.Impersonation) {
+	now := time.Now()
+	if _, err := uc.impersonationRepository.Update(impersonation.ID, dtos.ImpersonationUpdate{ID: impersonation.ID, EndedAt: &now}); err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error ending impersonation", err.ToError(), uc.AppContext)
+	}
+}
+
+// NewImpersonateUserUseCase creates a new impersonate user use case, the
+// tokens of the impersonations live for the ttl
+func NewImpersonateUserUseCase(
+	userRepository authcontracts.IUserRepository,
+	impersonationRepository authcontracts.IImpersonationRepository,
+	jwtProvider authcontracts.IJWTProvider,
+	hashProvider contractsProviders.IHashProvider,
+	permissionRepository contracts_repositories.IPermissionRepository,
+	cacheProvider contractsProviders.ICacheProvider,
+	ttl time.Duration,
+) *ImpersonateUserUseCase {
+	return &ImpersonateUserUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[dtos.ImpersonationRequest, dtos.ImpersonationStarted]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards: usecase.NewGuards(
+				guards.PermissionGuard(usermodels.PermissionUserImpersonate),
+				guards.NotImpersonatedGuard,
+			),
+		},
+		userRepository:          userRepository,
+		impersonationRepository: impersonationRepository,
+		jwtProvider:             jwtProvider,
+		hashProvider:            hashProvider,
+		permissionService:       services.NewPermissionService(permissionRepository, cacheProvider),
+		ttl:                     ttl,
+	}
+}
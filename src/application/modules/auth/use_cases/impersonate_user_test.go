@@ -0,0 +1,174 @@
+package authusecases
+
+import (
+	"testing"
+	"time"
+
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authmocks "github.com/simon3640/goprojectskeleton/src/application/modules/auth/mocks"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
+	repositoriesmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/repositories"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestImpersonateUserUseCase(t *testing.T) {
+	assert := assert.New(t)
+
+	admin := dtomocks.AdminUserWithRole
+	ctx := app_context.NewContextWithUser(&admin)
+	target := dtomocks.UserWithRole
+
+	testUserRepository := new(authmocks.MockUserRepository)
+	testImpersonationRepository := new(authmocks.MockImpersonationRepository)
+	testJWTProvider := new(authmocks.MockJWTProvider)
+	testHashProvider := new(providersmocks.MockHashProvider)
+
+	testUserRepository.On("GetUserWithRole", uint(1)).Return(&target, nil)
+	testHashProvider.On("OneTimeToken").Return(authmocks.ImpersonationTokenID, []byte("hash"), nil)
+	testImpersonationRepository.On("Create", mock.MatchedBy(func(create dtos.ImpersonationCreate) bool {
+		return create.AdminID == 2 && create.UserID == 1 &&
+			create.TokenID == authmocks.ImpersonationTokenID && create.Reason == "Support ticket"
+	})).Return(authmocks.Impersonation(), nil)
+	testJWTProvider.On("GenerateAccessToken", mock.Anything, "1", mock.MatchedBy(func(claims authcontracts.JWTCLaims) bool {
+		act, _ := claims["act"].(map[string]any)
+		return act["sub"] == "2" && claims["jti"] == authmocks.ImpersonationTokenID
+	})).Return("impersonation-access", time.Now().Add(time.Hour), nil)
+
+	uc := NewImpersonateUserUseCase(
+		testUserRepository,
+		testImpersonationRepository,
+		testJWTProvider,
+		testHashProvider,
+		testPermissionRepository(),
+		nil,
+		15*time.Minute,
+	)
+	result := uc.Execute(ctx, locales.EN_US, dtos.ImpersonationRequest{UserID: 1, Reason: "Support ticket"})
+
+	assert.True(result.IsSuccess())
+	assert.Equal(status.Created, result.StatusCode)
+	assert.Equal("impersonation-access", result.Data.AccessToken)
+	assert.Equal(uint(2), result.Data.Impersonation.AdminID)
+	assert.Equal(uint(1), result.Data.Impersonation.UserID)
+}
+
+func TestImpersonateUserUseCase_Unauthorized(t *testing.T) {
+	impersonatedAdmin := dtomocks.AdminUserWithRole
+	impersonatedAdmin.SetImpersonator(3, authmocks.ImpersonationTokenID)
+
+	testCases := []struct {
+		name  string
+		actor usermodels.UserWithRole
+		input dtos.ImpersonationRequest
+	}{
+		{"not allowed to impersonate", dtomocks.UserWithRole, dtos.ImpersonationRequest{UserID: 2}},
+		{"already impersonating", impersonatedAdmin, dtos.ImpersonationRequest{UserID: 1}},
+		{"impersonating itself", dtomocks.AdminUserWithRole, dtos.ImpersonationRequest{UserID: 2}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			actor := tc.actor
+			ctx := app_context.NewContextWithUser(&actor)
+			testUserRepository := new(authmocks.MockUserRepository)
+			testImpersonationRepository := new(authmocks.MockImpersonationRepository)
+			testJWTProvider := new(authmocks.MockJWTProvider)
+
+			uc := NewImpersonateUserUseCase(
+				testUserRepository,
+				testImpersonationRepository,
+				testJWTProvider,
+				nil,
+				testPermissionRepository(),
+				nil,
+				15*time.Minute,
+			)
+			result := uc.Execute(ctx, locales.EN_US, tc.input)
+
+			assert.True(result.HasError())
+			assert.Equal(status.Unauthorized, result.StatusCode)
+			testImpersonationRepository.AssertNotCalled(t, "Create", mock.Anything)
+			testJWTProvider.AssertNotCalled(t, "GenerateAccessToken", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestImpersonateUserUseCase_TargetCanImpersonate(t *testing.T) {
+	assert := assert.New(t)
+
+	admin := dtomocks.AdminUserWithRole
+	ctx := app_context.NewContextWithUser(&admin)
+	target := dtomocks.UserWithRole
+
+	testUserRepository := new(authmocks.MockUserRepository)
+	testImpersonationRepository := new(authmocks.MockImpersonationRepository)
+	testPermissionRepository := new(repositoriesmocks.MockPermissionRepository)
+	testUserRepository.On("GetUserWithRole", uint(1)).Return(&target, nil)
+	testPermissionRepository.On("GetRolePermissions", mock.Anything).Return(dtomocks.AdminRolePermissions, nil)
+	testPermissionRepository.On("GetUserPermissions", mock.Anything).Return([]usermodels.UserPermission{}, nil)
+
+	uc := NewImpersonateUserUseCase(
+		testUserRepository,
+		testImpersonationRepository,
+		nil,
+		nil,
+		testPermissionRepository,
+		nil,
+		15*time.Minute,
+	)
+	result := uc.Execute(ctx, locales.EN_US, dtos.ImpersonationRequest{UserID: 1})
+
+	assert.True(result.HasError())
+	assert.Equal(status.Unauthorized, result.StatusCode)
+	testImpersonationRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestImpersonateUserUseCase_TokenError(t *testing.T) {
+	assert := assert.New(t)
+
+	admin := dtomocks.AdminUserWithRole
+	ctx := app_context.NewContextWithUser(&admin)
+	target := dtomocks.UserWithRole
+	impersonation := authmocks.Impersonation()
+
+	testUserRepository := new(authmocks.MockUserRepository)
+	testImpersonationRepository := new(authmocks.MockImpersonationRepository)
+	testJWTProvider := new(authmocks.MockJWTProvider)
+	testHashProvider := new(providersmocks.MockHashProvider)
+
+	testUserRepository.On("GetUserWithRole", uint(1)).Return(&target, nil)
+	testHashProvider.On("OneTimeToken").Return(authmocks.ImpersonationTokenID, []byte("hash"), nil)
+	testImpersonationRepository.On("Create", mock.Anything).Return(impersonation, nil)
+	testImpersonationRepository.On("Update", impersonation.ID, mock.MatchedBy(func(update dtos.ImpersonationUpdate) bool {
+		return update.EndedAt != nil
+	})).Return(impersonation, nil)
+	testJWTProvider.On("GenerateAccessToken", mock.Anything, "1", mock.Anything).Return("", time.Time{}, applicationerrors.NewApplicationError(status.InternalError, messages.MessageKeysInstance.SOMETHING_WENT_WRONG, "sign failed"))
+
+	uc := NewImpersonateUserUseCase(
+		testUserRepository,
+		testImpersonationRepository,
+		testJWTProvider,
+		testHashProvider,
+		testPermissionRepository(),
+		nil,
+		15*time.Minute,
+	)
+	result := uc.Execute(ctx, locales.EN_US, dtos.ImpersonationRequest{UserID: 1})
+
+	assert.True(result.HasError())
+	assert.Equal(status.InternalError, result.StatusCode)
+	assert.Equal(locales.NewLocale(locales.EN_US).Get(locales.EN_US, messages.MessageKeysInstance.SOMETHING_WENT_WRONG), *result.Error)
+	testImpersonationRepository.AssertCalled(t, "Update", impersonation.ID, mock.Anything)
+}
@@ -0,0 +1,172 @@
+package authusecases
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	contractproviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+)
+
+// IntrospectOAuthTokenUseCase is the introspection endpoint (RFC 7662) the
+// resource servers ask whether a token is active. Only confidential clients
+// can introspect tokens, refresh tokens only by the client they belong to.
+type IntrospectOAuthTokenUseCase struct {
+	usecase.BaseUseCaseValidation[dtos.OAuthTokenReference, dtos.OAuthIntrospection]
+
+	clientRepo       authcontracts.IOAuthClientRepository
+	refreshTokenRepo authcontracts.IRefreshTokenRepository
+
+	jwtProvider        authcontracts.IJWTProvider
+	hashProvider       contractproviders.IHashProvider
+	revocationProvider contractproviders.ITokenRevocationProvider
+}
+
+var _ usecase.BaseUseCase[dtos.OAuthTokenReference, dtos.OAuthIntrospection] = (*IntrospectOAuthTokenUseCase)(nil)
+
+// Execute introspects the token
+// - Authenticate the client: it must be confidential
+// - Verify the token: invalid, expired and revoked tokens are reported inactive, not as an error
+// - Access tokens are active until they expire or are revoked, refresh tokens while their session is active
+func (uc *IntrospectOAuthTokenUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input dtos.OAuthTokenReference,
+) *usecase.UseCaseResult[dtos.OAuthIntrospection] {
+	result := usecase.NewUseCaseResult[dtos.OAuthIntrospection]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	client, err := authservices.AuthenticateOAuthClientService(input.OAuthClientCredentials, uc.clientRepo, uc.hashProvider)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("OAuth client authentication failed", uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return result
+	}
+	if !client.IsConfidential() {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Public OAuth client attempted to introspect a token", uc.AppContext)
+		uc.setError(result, status.Unauthorized, messages.MessageKeysInstance.OAUTH_INVALID_CLIENT)
+		return result
+	}
+
+	introspection := dtos.OAuthIntrospection{Active: false}
+	claims, err := uc.jwtProvider.ParseTokenAndValidate(input.Token)
+	if err == nil {
+		switch claims["typ"] {
+		case "access":
+			if uc.isAccessTokenActive(claims) {
+				introspection = uc.describe(claims, "access_token")
+			}
+		case "refresh":
+			if uc.isRefreshTokenActive(input.Token, client.ClientID) {
+				introspection = uc.describe(claims, "refresh_token")
+			}
+		}
+	}
+
+	result.SetData(
+		status.Success,
+		introspection,
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.OAUTH_TOKEN_INTROSPECTED,
+		),
+	)
+	return result
+}
+
+// isAccessTokenActive reports whether the access token was not revoked.
+// Revocation errors are logged and the token is reported inactive.
+func (uc *IntrospectOAuthTokenUseCase) isAccessTokenActive(claims authcontracts.JWTCLaims) bool {
+	sub, _ := claims["sub"].(string)
+	var userID uint
+	if !strings.HasPrefix(sub, authservices.OAuthClientSubjectPrefix) {
+		id, err := strconv.ParseUint(sub, 10, 64)
+		if err != nil {
+			return false
+		}
+		userID = uint(id)
+	}
+	revoked, err := authservices.IsTokenRevokedService(claims, userID, uc.revocationProvider)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error checking token revocation on introspection", err.ToError(), uc.AppContext)
+		return false
+	}
+	return !revoked
+}
+
+// isRefreshTokenActive reports whether the refresh token can still be used by
+// the client. Tokens of other clients and of the login are reported inactive.
+func (uc *IntrospectOAuthTokenUseCase) isRefreshTokenActive(token string, clientID string) bool {
+	session, err := uc.refreshTokenRepo.GetByTokenHash(uc.hashProvider.HashOneTimeToken(token))
+	if err != nil {
+		if err.Code != status.NotFound {
+			observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting refresh token by hash on introspection", err.ToError(), uc.AppContext)
+		}
+		return false
+	}
+	return session != nil && session.ClientID == clientID && session.IsActive(time.Now())
+}
+
+// describe returns the introspection of an active token from its claims
+func (uc *IntrospectOAuthTokenUseCase) describe(claims authcontracts.JWTCLaims, tokenType string) dtos.OAuthIntrospection {
+	introspection := dtos.OAuthIntrospection{
+		Active:    true,
+		TokenType: tokenType,
+		Audience:  claims["aud"],
+	}
+	introspection.Scope, _ = claims["scope"].(string)
+	introspection.ClientID, _ = claims["client_id"].(string)
+	introspection.Subject, _ = claims["sub"].(string)
+	introspection.Issuer, _ = claims["iss"].(string)
+	introspection.TokenID, _ = claims["jti"].(string)
+	if exp, ok := claims["exp"].(float64); ok {
+		introspection.ExpiresAt = int64(exp)
+	}
+	if iat, ok := claims["iat"].(float64); ok {
+		introspection.IssuedAt = int64(iat)
+	}
+	return introspection
+}
+
+func (uc *IntrospectOAuthTokenUseCase) setError(result *usecase.UseCaseResult[dtos.OAuthIntrospection], code status.ApplicationStatusEnum, key messages.MessageKeysEnum) {
+	result.SetError(
+		code,
+		uc.AppMessages.Get(
+			uc.Locale,
+			key,
+		),
+	)
+}
+
+func NewIntrospectOAuthTokenUseCase(
+	clientRepo authcontracts.IOAuthClientRepository,
+	refreshTokenRepo authcontracts.IRefreshTokenRepository,
+	jwtProvider authcontracts.IJWTProvider,
+	hashProvider contractproviders.IHashProvider,
+	revocationProvider contractproviders.ITokenRevocationProvider,
+) *IntrospectOAuthTokenUseCase {
+	return &IntrospectOAuthTokenUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[dtos.OAuthTokenReference, dtos.OAuthIntrospection]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(),
+		},
+		clientRepo:         clientRepo,
+		refreshTokenRepo:   refreshTokenRepo,
+		jwtProvider:        jwtProvider,
+		hashProvider:       hashProvider,
+		revocationProvider: revocationProvider,
+	}
+}
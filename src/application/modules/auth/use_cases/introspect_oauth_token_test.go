@@ -0,0 +1,159 @@
+package authusecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authmocks "github.com/simon3640/goprojectskeleton/src/application/modules/auth/mocks"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type oauthTokenReferenceTestMocks struct {
+	clientRepository       *authmocks.MockOAuthClientRepository
+	refreshTokenRepository *authmocks.MockRefreshTokenRepository
+	jwtProvider            *authmocks.MockJWTProvider
+	hashProvider           *providersmocks.MockHashProvider
+	revocationProvider     *providersmocks.MockTokenRevocationProvider
+}
+
+func newOAuthTokenReferenceTestMocks() *oauthTokenReferenceTestMocks {
+	mocks := &oauthTokenReferenceTestMocks{
+		clientRepository:       new(authmocks.MockOAuthClientRepository),
+		refreshTokenRepository: new(authmocks.MockRefreshTokenRepository),
+		jwtProvider:            new(authmocks.MockJWTProvider),
+		hashProvider:           new(providersmocks.MockHashProvider),
+		revocationProvider:     new(providersmocks.MockTokenRevocationProvider),
+	}
+	mocks.clientRepository.On("GetByClientID", "client").Return(authmocks.OAuthClient(), nil)
+	mocks.hashProvider.On("ValidateOneTimeToken", authmocks.OAuthClientSecretHash, "secret").Return(true)
+	return mocks
+}
+
+func oauthAccessTokenClaims() authcontracts.JWTCLaims {
+	return authcontracts.JWTCLaims{
+		"sub":       "1",
+		"typ":       "access",
+		"jti":       "jti",
+		"scope":     sharedmodels.OAuthScopeProfile,
+		"client_id": "client",
+		"exp":       float64(time.Now().Add(time.Hour).Unix()),
+		"iat":       float64(time.Now().Unix()),
+	}
+}
+
+func newIntrospectOAuthTokenTestUseCase(mocks *oauthTokenReferenceTestMocks) *IntrospectOAuthTokenUseCase {
+	return NewIntrospectOAuthTokenUseCase(
+		mocks.clientRepository,
+		mocks.refreshTokenRepository,
+		mocks.jwtProvider,
+		mocks.hashProvider,
+		mocks.revocationProvider,
+	)
+}
+
+func TestIntrospectOAuthTokenUseCase_ActiveAccessToken(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
+	mocks := newOAuthTokenReferenceTestMocks()
+	uc := newIntrospectOAuthTokenTestUseCase(mocks)
+
+	mocks.jwtProvider.On("ParseTokenAndValidate", "accessToken").Return(oauthAccessTokenClaims(), nil)
+	mocks.revocationProvider.On("IsTokenRevoked", "jti").Return(false, nil)
+	mocks.revocationProvider.On("UserTokensRevokedBefore", uint(1)).Return(time.Time{}, nil)
+
+	result := uc.Execute(ctx, locales.EN_US, dtos.OAuthTokenReference{
+		OAuthClientCredentials: oauthTestCredentials,
+		Token:                  "accessToken",
+	})
+
+	assert.True(result.IsSuccess())
+	assert.True(result.Data.Active)
+	assert.Equal("access_token", result.Data.TokenType)
+	assert.Equal(sharedmodels.OAuthScopeProfile, result.Data.Scope)
+	assert.Equal("client", result.Data.ClientID)
+	assert.Equal("1", result.Data.Subject)
+}
+
+func TestIntrospectOAuthTokenUseCase_RevokedAccessToken(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
+	mocks := newOAuthTokenReferenceTestMocks()
+	uc := newIntrospectOAuthTokenTestUseCase(mocks)
+
+	mocks.jwtProvider.On("ParseTokenAndValidate", "accessToken").Return(oauthAccessTokenClaims(), nil)
+	mocks.revocationProvider.On("IsTokenRevoked", "jti").Return(true, nil)
+
+	result := uc.Execute(ctx, locales.EN_US, dtos.OAuthTokenReference{
+		OAuthClientCredentials: oauthTestCredentials,
+		Token:                  "accessToken",
+	})
+
+	assert.True(result.IsSuccess())
+	assert.Equal(&dtos.OAuthIntrospection{Active: false}, result.Data)
+}
+
+func TestIntrospectOAuthTokenUseCase_RefreshToken(t *testing.T) {
+	tests := []struct {
+		name     string
+		clientID string
+		active   bool
+	}{
+		{name: "of the client", clientID: "client", active: true},
+		{name: "of another client", clientID: "other", active: false},
+		{name: "of the login", clientID: "", active: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			ctx := &app_context.AppContext{Context: context.Background()}
+			mocks := newOAuthTokenReferenceTestMocks()
+			uc := newIntrospectOAuthTokenTestUseCase(mocks)
+
+			session := authmocks.RefreshToken()
+			session.ClientID = tt.clientID
+			mocks.jwtProvider.On("ParseTokenAndValidate", validRefreshToken).Return(authcontracts.JWTCLaims{"sub": "1", "typ": "refresh"}, nil)
+			mocks.hashProvider.On("HashOneTimeToken", validRefreshToken).Return(authmocks.RefreshTokenHash)
+			mocks.refreshTokenRepository.On("GetByTokenHash", authmocks.RefreshTokenHash).Return(session, nil)
+
+			result := uc.Execute(ctx, locales.EN_US, dtos.OAuthTokenReference{
+				OAuthClientCredentials: oauthTestCredentials,
+				Token:                  validRefreshToken,
+			})
+
+			assert.True(result.IsSuccess())
+			assert.Equal(tt.active, result.Data.Active)
+		})
+	}
+}
+
+func TestIntrospectOAuthTokenUseCase_PublicClientRejected(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
+	mocks := newOAuthTokenReferenceTestMocks()
+	uc := newIntrospectOAuthTokenTestUseCase(mocks)
+
+	client := authmocks.OAuthClient()
+	client.SecretHash = nil
+	mocks.clientRepository.ExpectedCalls = nil
+	mocks.clientRepository.On("GetByClientID", "client").Return(client, nil)
+
+	result := uc.Execute(ctx, locales.EN_US, dtos.OAuthTokenReference{
+		OAuthClientCredentials: dtos.OAuthClientCredentials{ClientID: "client"},
+		Token:                  "accessToken",
+	})
+
+	assert.True(result.HasError())
+	assert.Equal(status.Unauthorized, result.StatusCode)
+	mocks.jwtProvider.AssertNotCalled(t, "ParseTokenAndValidate", mock.Anything)
+}
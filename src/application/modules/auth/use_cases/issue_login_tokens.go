@@ -0,0 +1,112 @@
+package authusecases
+
+import (
+	contractproviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+)
+
+// IssueLoginTokensUseCase is the use case generating the tokens of the users
+// whose credentials are verified and that do not log in with an OTP
+type IssueLoginTokensUseCase struct {
+	usecase.BaseUseCaseValidation[dtos.AuthenticatedUser, dtos.AuthResponse]
+
+	refreshTokenRepo authcontracts.IRefreshTokenRepository
+
+	jwtProvider  authcontracts.IJWTProvider
+	hashProvider contractproviders.IHashProvider
+}
+
+var _ usecase.BaseUseCase[dtos.AuthenticatedUser, dtos.AuthResponse] = (*IssueLoginTokensUseCase)(nil)
+
+// Execute execute the use case
+// - Generate the access token: generate the access token with the claims of the user
+// - Issue the refresh token: every login starts a new session
+// - Set the success result: set the tokens
+// - Return the result: return the result
+func (uc *IssueLoginTokensUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input dtos.AuthenticatedUser,
+) *usecase.UseCaseResult[dtos.AuthResponse] {
+	result := usecase.NewUseCaseResult[dtos.AuthResponse]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	claims := authservices.BuildAccessClaimsService(&input.User)
+	access, exp, err := uc.jwtProvider.GenerateAccessToken(ctx, input.User.GetUserIDString(), claims)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error generating access token", err.ToError(), uc.AppContext)
+		uc.setSomethingWentWrong(result)
+		return result
+	}
+
+	// Every login starts a new session
+	refresh, expRefresh, err := authservices.IssueRefreshTokenService(
+		ctx,
+		input.User.ID,
+		"",
+		uc.jwtProvider,
+		uc.hashProvider,
+		uc.refreshTokenRepo,
+	)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error generating refresh token", err.ToError(), uc.AppContext)
+		uc.setSomethingWentWrong(result)
+		return result
+	}
+
+	token := dtos.Token{
+		AccessToken:           access,
+		RefreshToken:          refresh,
+		TokenType:             "Bearer",
+		AccessTokenExpiresAt:  exp,
+		RefreshTokenExpiresAt: expRefresh,
+	}
+	result.SetData(
+		status.Success,
+		dtos.AuthResponse{Token: &token},
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.AUTHORIZATION_GENERATED,
+		),
+	)
+	observability.GetObservabilityComponents().Logger.InfoWithContext("Authentication successful", uc.AppContext)
+	return result
+}
+
+func (uc *IssueLoginTokensUseCase) setSomethingWentWrong(result *usecase.UseCaseResult[dtos.AuthResponse]) {
+	result.SetError(
+		status.Conflict,
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.SOMETHING_WENT_WRONG,
+		),
+	)
+}
+
+func NewIssueLoginTokensUseCase(
+	refreshTokenRepo authcontracts.IRefreshTokenRepository,
+	hashProvider contractproviders.IHashProvider,
+	jwtProvider authcontracts.IJWTProvider,
+) *IssueLoginTokensUseCase {
+	return &IssueLoginTokensUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[dtos.AuthenticatedUser, dtos.AuthResponse]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(),
+		},
+		refreshTokenRepo: refreshTokenRepo,
+		jwtProvider:      jwtProvider,
+		hashProvider:     hashProvider,
+	}
+}
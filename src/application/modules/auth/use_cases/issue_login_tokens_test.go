@@ -0,0 +1,61 @@
+package authusecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authmocks "github.com/simon3640/goprojectskeleton/src/application/modules/auth/mocks"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestIssueLoginTokensUseCase(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
+
+	testJWTProvider := new(authmocks.MockJWTProvider)
+	testHashProvider := new(providersmocks.MockHashProvider)
+	testRefreshTokenRepository := new(authmocks.MockRefreshTokenRepository)
+
+	uc := NewIssueLoginTokensUseCase(testRefreshTokenRepository, testHashProvider, testJWTProvider)
+
+	testJWTProvider.On("GenerateAccessToken", ctx, "1", mock.Anything).Return("accessToken", time.Now().Add(1*time.Hour), nil)
+	testJWTProvider.On("GenerateRefreshToken", ctx, "1").Return("refreshToken", time.Now().Add(24*time.Hour), nil)
+	testHashProvider.On("OneTimeToken").Return("family", []byte("family"), nil)
+	testHashProvider.On("HashOneTimeToken", "refreshToken").Return(authmocks.RefreshTokenHash)
+	testRefreshTokenRepository.On("Create", mock.AnythingOfType("authdtos.RefreshTokenCreate")).Return(authmocks.RefreshToken(), nil)
+
+	result := uc.Execute(ctx, locales.EN_US, dtos.AuthenticatedUser{User: dtomocks.UserWithRole})
+	assert.True(result.IsSuccess())
+	assert.Equal("accessToken", result.Data.AccessToken)
+	assert.Equal("refreshToken", result.Data.RefreshToken)
+	assert.Equal("Bearer", result.Data.TokenType)
+	assert.False(result.Data.RequiresOTP)
+	testRefreshTokenRepository.AssertExpectations(t)
+}
+
+func TestIssueLoginTokensUseCase_AccessTokenNotGenerated(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
+
+	testJWTProvider := new(authmocks.MockJWTProvider)
+	testHashProvider := new(providersmocks.MockHashProvider)
+	testRefreshTokenRepository := new(authmocks.MockRefreshTokenRepository)
+
+	uc := NewIssueLoginTokensUseCase(testRefreshTokenRepository, testHashProvider, testJWTProvider)
+
+	testJWTProvider.On("GenerateAccessToken", ctx, "1", mock.Anything).Return("", time.Time{}, notFoundError())
+
+	result := uc.Execute(ctx, locales.EN_US, dtos.AuthenticatedUser{User: dtomocks.UserWithRole})
+	assert.False(result.IsSuccess())
+	assert.Equal(status.Conflict, result.StatusCode)
+	testRefreshTokenRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
@@ -3,55 +3,56 @@ package authusecases
 
 import (
 	"errors"
-	"fmt"
-	"time"
 
 	contractproviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	contractrepositories "github.com/simon3640/goprojectskeleton/src/application/contracts/repositories"
 	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
 	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
 	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
+	shareddtos "github.com/simon3640/goprojectskeleton/src/application/shared/DTOs"
 	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
-	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
 	services "github.com/simon3640/goprojectskeleton/src/application/shared/services"
-	"github.com/simon3640/goprojectskeleton/src/application/shared/settings"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
 	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
 	passwordmodels "github.com/simon3640/goprojectskeleton/src/domain/password/models"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
 	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
 )
 
-// AuthenticateUseCase is the use case for the authentication of a user
+// AuthenticateUseCase is the use case verifying the credentials of a user.
+// It is the first step of the login pipe, which completes the login with the
+// OTP sent by email or with the tokens of the user.
 type AuthenticateUseCase struct {
-	usecase.BaseUseCaseValidation[dtos.UserCredentials, dtos.Token]
+	usecase.BaseUseCaseValidation[dtos.UserCredentials, dtos.AuthenticatedUser]
 
-	pass     authcontracts.IPasswordRepository
-	userRepo authcontracts.IUserRepository
-	otpRepo  authcontracts.IOneTimePasswordRepository
+	pass      authcontracts.IPasswordRepository
+	userRepo  authcontracts.IUserRepository
+	tokenRepo contractrepositories.IOneTimeTokenRepository
 
-	jwtProvider   authcontracts.IJWTProvider
-	hashProvider  contractproviders.IHashProvider
-	cacheProvider contractproviders.ICacheProvider
+	hashProvider contractproviders.IHashProvider
+
+	throttle   *authservices.LoginThrottleService
+	mfaService *authservices.MFAService
 }
 
-var _ usecase.BaseUseCase[dtos.UserCredentials, dtos.Token] = (*AuthenticateUseCase)(nil)
+var _ usecase.BaseUseCase[dtos.UserCredentials, dtos.AuthenticatedUser] = (*AuthenticateUseCase)(nil)
 
 // Execute execute the use case
-// - Check the rate limit: if the user has exceeded the login failed attempts limit, set the error and return the result
+// - Check the rate limit: if the client IP or the account reached a failed login limit, set the error and return the result
 // - Get the password: get the password from the database
 // - Get the user: get the user from the database
 // - Validate the password: validate the password
-// - Generate the tokens: generate the tokens
-// - Set the success result: set the success result
-// - Send the OTP email in background: send the OTP email in background
+// - Verify the MFA code: users with an authenticator app must send its code, which replaces the OTP email
+// - Set the success result: set the authenticated user
 // - Return the result: return the result
 func (uc *AuthenticateUseCase) Execute(ctx *app_context.AppContext,
 	locale locales.LocaleTypeEnum,
 	input dtos.UserCredentials,
-) *usecase.UseCaseResult[dtos.Token] {
-	result := usecase.NewUseCaseResult[dtos.Token]()
+) *usecase.UseCaseResult[dtos.AuthenticatedUser] {
+	result := usecase.NewUseCaseResult[dtos.AuthenticatedUser]()
 	uc.SetLocale(locale)
 	uc.SetAppContext(ctx)
 	uc.Validate(input, result)
@@ -79,59 +80,54 @@ func (uc *AuthenticateUseCase) Execute(ctx *app_context.AppContext,
 		return result
 	}
 
-	uc.clearFailedAttempts(input.Email)
-
-	if user.OTPLogin {
-		// OTP login: send OTP email in background
-		uc.sendOTPEmailInBackground(ctx, user, locale)
-		result.SetSuccess(true)
-		result.SetDetails(uc.AppMessages.Get(
-			uc.Locale,
-			messages.MessageKeysInstance.OTP_LOGIN_ENABLED,
-		))
-		return result
-	}
-
-	token := uc.generateTokens(ctx, result, password.UserIDString(), user)
+	mfaVerified := uc.verifyMFACode(result, user.ID, input.TOTPCode, input.Email)
 	if result.HasError() {
 		return result
 	}
 
-	uc.setSuccessResult(result, token)
-	observability.GetObservabilityComponents().Logger.InfoWithContext("Authentication successful", uc.AppContext)
+	uc.clearFailedAttempts(input.Email)
+
+	result.SetData(
+		status.Success,
+		dtos.AuthenticatedUser{User: *user, MFAVerified: mfaVerified},
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.AUTHORIZATION_GENERATED,
+		),
+	)
 	return result
 }
 
-func (uc *AuthenticateUseCase) checkRateLimitAndSetError(result *usecase.UseCaseResult[dtos.Token], email string) {
-	if uc.cacheProvider == nil {
+// checkRateLimitAndSetError rejects the login when the client IP or the
+// account reached a failed login limit. Cache errors do not block the logins.
+func (uc *AuthenticateUseCase) checkRateLimitAndSetError(result *usecase.UseCaseResult[dtos.AuthenticatedUser], email string) {
+	if uc.throttle == nil {
 		return
 	}
 
-	exceeded, err := uc.checkRateLimit(email)
+	limit, err := uc.throttle.Check(uc.AppContext.ClientIP, email)
 	if err != nil {
 		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error checking rate limit, continuing with authentication", err.ToError(), uc.AppContext)
 		return
 	}
 
-	if exceeded {
-		observability.GetObservabilityComponents().Logger.WarningWithContext("Rate limit exceeded, continuing with authentication", uc.AppContext)
+	if limit != "" {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Rate limit exceeded", uc.AppContext)
 		result.SetError(
 			status.TooManyRequests,
 			uc.AppMessages.Get(
 				uc.Locale,
-				messages.MessageKeysInstance.LoginMaxAttemptsExceeded,
+				limit,
 			),
 		)
 		return
 	}
 }
 
-func (uc *AuthenticateUseCase) getPassword(result *usecase.UseCaseResult[dtos.Token], email string) *passwordmodels.Password {
+func (uc *AuthenticateUseCase) getPassword(result *usecase.UseCaseResult[dtos.AuthenticatedUser], email string) *passwordmodels.Password {
 	password, err := uc.pass.GetActivePassword(email)
 	if err != nil {
-		if uc.cacheProvider != nil {
-			uc.incrementFailedAttempts(email)
-		}
+		uc.incrementFailedAttempts(email)
 		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting password", err.ToError(), uc.AppContext)
 		result.SetError(
 			status.NotFound,
@@ -145,12 +141,10 @@ func (uc *AuthenticateUseCase) getPassword(result *usecase.UseCaseResult[dtos.To
 	return password
 }
 
-func (uc *AuthenticateUseCase) getUser(result *usecase.UseCaseResult[dtos.Token], userID uint, email string) *usermodels.UserWithRole {
+func (uc *AuthenticateUseCase) getUser(result *usecase.UseCaseResult[dtos.AuthenticatedUser], userID uint, email string) *usermodels.UserWithRole {
 	user, err := uc.userRepo.GetUserWithRole(userID)
 	if err != nil {
-		if uc.cacheProvider != nil {
-			uc.incrementFailedAttempts(email)
-		}
+		uc.incrementFailedAttempts(email)
 		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting user", err.ToError(), uc.AppContext)
 		result.SetError(
 			status.NotFound,
@@ -164,12 +158,10 @@ func (uc *AuthenticateUseCase) getUser(result *usecase.UseCaseResult[dtos.Token]
 	return user
 }
 
-func (uc *AuthenticateUseCase) validatePassword(result *usecase.UseCaseResult[dtos.Token], passwordHash string, inputPassword string, email string) {
+func (uc *AuthenticateUseCase) validatePassword(result *usecase.UseCaseResult[dtos.AuthenticatedUser], passwordHash string, inputPassword string, email string) {
 	valid, verifyErr := uc.hashProvider.VerifyPassword(passwordHash, inputPassword)
 	if !valid || verifyErr != nil {
-		if uc.cacheProvider != nil {
-			uc.incrementFailedAttempts(email)
-		}
+		uc.incrementFailedAttempts(email)
 		var err error
 		if verifyErr != nil {
 			err = verifyErr.ToError()
@@ -188,156 +180,152 @@ func (uc *AuthenticateUseCase) validatePassword(result *usecase.UseCaseResult[dt
 	}
 }
 
-func (uc *AuthenticateUseCase) generateTokens(ctx *app_context.AppContext, result *usecase.UseCaseResult[dtos.Token], userIDString string, user *usermodels.UserWithRole) dtos.Token {
-	claims := authcontracts.JWTCLaims{
-		"role": user.GetRoleKey(),
+// verifyMFACode requires the authenticator app code, or a recovery code, of
+// the users with a confirmed TOTP device and reports whether it was verified.
+// Invalid codes count as failed login attempts.
+func (uc *AuthenticateUseCase) verifyMFACode(result *usecase.UseCaseResult[dtos.AuthenticatedUser], userID uint, code string, email string) bool {
+	if uc.mfaService == nil {
+		return false
 	}
 
-	access, exp, err := uc.jwtProvider.GenerateAccessToken(ctx, userIDString, claims)
+	device, err := uc.mfaService.GetDevice(userID)
 	if err != nil {
-		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error generating access token", err.ToError(), uc.AppContext)
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting TOTP device", err.ToError(), uc.AppContext)
 		result.SetError(
-			status.Conflict,
+			err.Code,
 			uc.AppMessages.Get(
 				uc.Locale,
-				messages.MessageKeysInstance.SOMETHING_WENT_WRONG,
+				err.Context,
 			),
 		)
-		return dtos.Token{}
+		return false
+	}
+	if device == nil || !device.IsConfirmed {
+		return false
 	}
 
-	refresh, expRefresh, err := uc.jwtProvider.GenerateRefreshToken(ctx, userIDString)
-	if err != nil {
-		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error generating refresh token", err.ToError(), uc.AppContext)
+	if code == "" {
+		observability.GetObservabilityComponents().Logger.InfoWithContext("MFA code required", uc.AppContext)
 		result.SetError(
-			status.Conflict,
+			status.Unauthorized,
 			uc.AppMessages.Get(
 				uc.Locale,
-				messages.MessageKeysInstance.SOMETHING_WENT_WRONG,
+				messages.MessageKeysInstance.MFA_CODE_REQUIRED,
 			),
 		)
-		return dtos.Token{}
+		return false
 	}
 
-	return dtos.Token{
-		AccessToken:           access,
-		RefreshToken:          refresh,
-		TokenType:             "Bearer",
-		AccessTokenExpiresAt:  exp,
-		RefreshTokenExpiresAt: expRefresh,
+	valid, err := uc.mfaService.VerifyCode(device, code)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error verifying MFA code", err.ToError(), uc.AppContext)
+		result.SetError(
+			err.Code,
+			uc.AppMessages.Get(
+				uc.Locale,
+				err.Context,
+			),
+		)
+		return false
 	}
+	if !valid {
+		uc.incrementFailedAttempts(email)
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Invalid MFA code", uc.AppContext)
+		result.SetError(
+			status.Unauthorized,
+			uc.AppMessages.Get(
+				uc.Locale,
+				messages.MessageKeysInstance.INVALID_MFA_CODE,
+			),
+		)
+		return false
+	}
+	return true
 }
 
-func (uc *AuthenticateUseCase) setSuccessResult(result *usecase.UseCaseResult[dtos.Token], token dtos.Token) {
-	result.SetData(
-		status.Success,
-		token,
-		uc.AppMessages.Get(
-			uc.Locale,
-			messages.MessageKeysInstance.AUTHORIZATION_GENERATED,
-		),
-	)
-}
-
-// sendOTPEmailInBackground sends an OTP email to the user in the background
-func (uc *AuthenticateUseCase) sendOTPEmailInBackground(
-	ctx *app_context.AppContext,
-	user *usermodels.UserWithRole,
-	locale locales.LocaleTypeEnum,
-) {
-	// Create the background service
-	observability.GetObservabilityComponents().Logger.InfoWithContext("Creating OTP email background service", ctx)
-	sendOTPService := authservices.NewSendOTPEmailBackgroundService(
-		observability.GetObservabilityComponents(),
-		uc.otpRepo,
-		uc.hashProvider,
-	)
-
-	// Prepare the input
-	input := authservices.SendOTPEmailInput{
-		UserID:   user.ID,
-		Email:    user.Email,
-		UserName: user.Name,
+// incrementFailedAttempts counts a failed login of the client on the account,
+// the user is alerted by email when the failure locks their account
+func (uc *AuthenticateUseCase) incrementFailedAttempts(email string) {
+	if uc.throttle == nil {
+		return
 	}
 
-	// Execute the service in background (fire-and-forget)
-	if err := services.ExecuteBackgroundService(sendOTPService, ctx, locale, input); err != nil {
-		// Log error but don't fail the authentication
-		// The service will log its own errors internally
-		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error submitting OTP email service to background executor", err, ctx)
+	locked, err := uc.throttle.RecordFailure(uc.AppContext.ClientIP, email)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error incrementing failed attempts", err.ToError(), uc.AppContext)
+		return
+	}
+	if locked {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Account locked after too many failed login attempts", uc.AppContext)
+		uc.sendAccountLockedEmail(email)
 	}
 }
 
-// checkRateLimit verify if the user has exceeded the login failed attempts limit
-func (uc *AuthenticateUseCase) checkRateLimit(email string) (bool, *applicationerrors.ApplicationError) {
-	if uc.cacheProvider == nil {
-		return false, nil
+// clearFailedAttempts clear the failed attempts of the client and the account
+func (uc *AuthenticateUseCase) clearFailedAttempts(email string) {
+	if uc.throttle == nil {
+		return
 	}
 
-	maxAttempts := settings.AppSettingsInstance.LoginMaxAttempts
-	if maxAttempts <= 0 {
-		return false, nil
+	if err := uc.throttle.RecordSuccess(uc.AppContext.ClientIP, email); err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error clearing failed attempts", err.ToError(), uc.AppContext)
 	}
+}
 
-	key := uc.getRateLimitKey(email)
-	attempts, err := uc.cacheProvider.GetInt64(key)
-	if err != nil {
-		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting failed attempts", err.ToError(), uc.AppContext)
-		return false, err
+// sendAccountLockedEmail creates the one-time token unlocking the account and
+// sends it to the user in background. Nothing is sent for unknown emails.
+func (uc *AuthenticateUseCase) sendAccountLockedEmail(email string) {
+	if uc.tokenRepo == nil {
+		return
 	}
 
-	return attempts >= int64(maxAttempts), nil
-}
-
-// incrementFailedAttempts increment the failed attempts counter for an email
-func (uc *AuthenticateUseCase) incrementFailedAttempts(email string) {
-	if uc.cacheProvider == nil {
+	user, err := uc.userRepo.GetByEmailOrPhone(email)
+	if err != nil || user == nil {
 		return
 	}
 
-	key := uc.getRateLimitKey(email)
-	_, err := uc.cacheProvider.Increment(key, time.Duration(settings.AppSettingsInstance.LoginAttemptsWindowMinutes)*time.Minute)
+	token, hash, err := uc.hashProvider.OneTimeToken()
 	if err != nil {
-		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error incrementing failed attempts", err.ToError(), uc.AppContext)
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error generating account unlock token", err.ToError(), uc.AppContext)
 		return
 	}
-}
-
-// clearFailedAttempts clear the failed attempts counter for an email
-func (uc *AuthenticateUseCase) clearFailedAttempts(email string) {
-	if uc.cacheProvider == nil {
+	tokenCreate := shareddtos.NewOneTimeTokenCreate(user.ID, sharedmodels.OneTimeTokenPurposeAccountUnlock, hash)
+	if _, err := uc.tokenRepo.Create(*tokenCreate); err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error creating account unlock token", err.ToError(), uc.AppContext)
 		return
 	}
 
-	key := uc.getRateLimitKey(email)
-	if err := uc.cacheProvider.Delete(key); err != nil {
-		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error clearing failed attempts", err.ToError(), uc.AppContext)
+	sendService := authservices.NewSendAccountLockedEmailBackgroundService(
+		observability.GetObservabilityComponents(),
+	)
+	input := shareddtos.OneTimeTokenUser{User: *user, Token: token}
+	if err := services.ExecuteBackgroundService(sendService, uc.AppContext, uc.Locale, input); err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error submitting account locked email service to background executor", err, uc.AppContext)
 	}
 }
 
-// getRateLimitKey generate the cache key for the rate limiting
-func (uc *AuthenticateUseCase) getRateLimitKey(email string) string {
-	return fmt.Sprintf("login_attempts:%s", email)
-}
-
 func NewAuthenticateUseCase(
 	pass authcontracts.IPasswordRepository,
 	userRepo authcontracts.IUserRepository,
-	otpRepo authcontracts.IOneTimePasswordRepository,
+	tokenRepo contractrepositories.IOneTimeTokenRepository,
 	hashProvider contractproviders.IHashProvider,
-	jwtProvider authcontracts.IJWTProvider,
 	cacheProvider contractproviders.ICacheProvider,
+	mfaService *authservices.MFAService,
 ) *AuthenticateUseCase {
+	var throttle *authservices.LoginThrottleService
+	if cacheProvider != nil {
+		throttle = authservices.NewLoginThrottleService(cacheProvider)
+	}
 	return &AuthenticateUseCase{
-		BaseUseCaseValidation: usecase.BaseUseCaseValidation[dtos.UserCredentials, dtos.Token]{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[dtos.UserCredentials, dtos.AuthenticatedUser]{
 			AppMessages: locales.NewLocale(locales.EN_US),
 			Guards:      usecase.NewGuards(),
 		},
-		pass:          pass,
-		userRepo:      userRepo,
-		otpRepo:       otpRepo,
-		jwtProvider:   jwtProvider,
-		hashProvider:  hashProvider,
-		cacheProvider: cacheProvider,
+		pass:         pass,
+		userRepo:     userRepo,
+		tokenRepo:    tokenRepo,
+		hashProvider: hashProvider,
+		throttle:     throttle,
+		mfaService:   mfaService,
 	}
 }
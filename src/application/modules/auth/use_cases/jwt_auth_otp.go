@@ -7,33 +7,41 @@ import (
 	contractproviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
 	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
 	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
 	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/settings"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
 	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
 	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
 	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
 )
 
-// AuthenticateOTPUseCase is the use case for authenticating a user with an OTP
+// AuthenticateOTPUseCase is the use case for authenticating a user with the
+// OTP of a login transaction
 type AuthenticateOTPUseCase struct {
-	usecase.BaseUseCaseValidation[string, dtos.Token]
+	usecase.BaseUseCaseValidation[dtos.OTPVerifyInput, dtos.Token]
 
-	userRepo authcontracts.IUserRepository
-	otpRepo  authcontracts.IOneTimePasswordRepository
+	userRepo         authcontracts.IUserRepository
+	otpRepo          authcontracts.IOneTimePasswordRepository
+	refreshTokenRepo authcontracts.IRefreshTokenRepository
 
 	jwtProvider  authcontracts.IJWTProvider
 	hashProvider contractproviders.IHashProvider
 }
 
-var _ usecase.BaseUseCase[string, dtos.Token] = (*AuthenticateOTPUseCase)(nil)
+var _ usecase.BaseUseCase[dtos.OTPVerifyInput, dtos.Token] = (*AuthenticateOTPUseCase)(nil)
 
-// Execute authenticates a user with an OTP
+// Execute authenticates a user with the OTP of a login transaction
+// - Get the transaction: the OTP must not be used nor expired and have attempts left
+// - Verify the OTP: an invalid OTP uses one of the attempts of the transaction
+// - Use the OTP: the OTP is marked as used before the tokens are generated
+// - Generate the tokens: generate the tokens of the user of the transaction
 func (uc *AuthenticateOTPUseCase) Execute(ctx *app_context.AppContext,
 	locale locales.LocaleTypeEnum,
-	input string,
+	input dtos.OTPVerifyInput,
 ) *usecase.UseCaseResult[dtos.Token] {
 	result := usecase.NewUseCaseResult[dtos.Token]()
 	uc.SetLocale(locale)
@@ -43,22 +51,27 @@ func (uc *AuthenticateOTPUseCase) Execute(ctx *app_context.AppContext,
 		return result
 	}
 
-	oneTimePassword := uc.validateAndGetOTP(result, input)
+	oneTimePassword := uc.getTransaction(result, input.TransactionID)
 	if result.HasError() {
 		return result
 	}
 
-	user := uc.getUser(result, oneTimePassword.UserID)
+	uc.verifyOTP(result, oneTimePassword, input.OTP)
 	if result.HasError() {
 		return result
 	}
 
-	token := uc.generateTokens(ctx, result, user)
+	uc.useOTP(result, oneTimePassword.ID)
 	if result.HasError() {
 		return result
 	}
 
-	uc.markOTPAsUsed(result, oneTimePassword.ID)
+	user := uc.getUser(result, oneTimePassword.UserID)
+	if result.HasError() {
+		return result
+	}
+
+	token := uc.generateTokens(ctx, result, user)
 	if result.HasError() {
 		return result
 	}
@@ -68,12 +81,12 @@ func (uc *AuthenticateOTPUseCase) Execute(ctx *app_context.AppContext,
 	return result
 }
 
-func (uc *AuthenticateOTPUseCase) validateAndGetOTP(result *usecase.UseCaseResult[dtos.Token], otp string) *sharedmodels.OneTimePassword {
-	hash := uc.hashProvider.HashOneTimeToken(otp)
-	oneTimePassword, err := uc.otpRepo.GetByPasswordHash(hash)
+func (uc *AuthenticateOTPUseCase) getTransaction(result *usecase.UseCaseResult[dtos.Token], transactionID string) *sharedmodels.OneTimePassword {
+	hash := uc.hashProvider.HashOneTimeToken(transactionID)
+	oneTimePassword, err := uc.otpRepo.GetByTransactionHash(hash)
 
-	if err != nil {
-		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting one time password by hash", err.ToError(), uc.AppContext)
+	if err != nil && err.Code != status.NotFound {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting one time password by transaction hash", err.ToError(), uc.AppContext)
 		result.SetError(
 			err.Code,
 			uc.AppMessages.Get(
@@ -84,19 +97,90 @@ func (uc *AuthenticateOTPUseCase) validateAndGetOTP(result *usecase.UseCaseResul
 		return nil
 	}
 
-	if oneTimePassword == nil || oneTimePassword.IsUsed || oneTimePassword.Expires.Before(time.Now()) {
-		observability.GetObservabilityComponents().Logger.WarningWithContext("One time password is not valid or has incorrect purpose", uc.AppContext)
+	if oneTimePassword == nil ||
+		oneTimePassword.Purpose != sharedmodels.OneTimePasswordLogin ||
+		oneTimePassword.IsUsed ||
+		oneTimePassword.Expires.Before(time.Now()) {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("OTP login transaction is not valid", uc.AppContext)
+		uc.setInvalidOTPError(result)
+		return nil
+	}
+
+	if oneTimePassword.Attempts >= settings.AppSettingsInstance.OneTimePasswordMaxAttempts {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("OTP login transaction has no attempts left", uc.AppContext)
+		uc.setMaxAttemptsError(result)
+		return nil
+	}
+
+	return oneTimePassword
+}
+
+// verifyOTP compares the OTP with the one of the transaction,
+// every invalid OTP uses one of the attempts of the transaction
+func (uc *AuthenticateOTPUseCase) verifyOTP(result *usecase.UseCaseResult[dtos.Token], oneTimePassword *sharedmodels.OneTimePassword, otp string) {
+	if uc.hashProvider.ValidateOTP(oneTimePassword.Hash, otp) {
+		return
+	}
+
+	recorded, err := uc.otpRepo.IncrementAttempts(oneTimePassword.ID, settings.AppSettingsInstance.OneTimePasswordMaxAttempts)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error incrementing one time password attempts", err.ToError(), uc.AppContext)
 		result.SetError(
-			status.Unauthorized,
+			err.Code,
 			uc.AppMessages.Get(
 				uc.Locale,
-				messages.MessageKeysInstance.INVALID_OTP,
+				err.Context,
 			),
 		)
-		return nil
+		return
 	}
 
-	return oneTimePassword
+	observability.GetObservabilityComponents().Logger.WarningWithContext("Invalid OTP for login transaction", uc.AppContext)
+	if !recorded {
+		uc.setMaxAttemptsError(result)
+		return
+	}
+	uc.setInvalidOTPError(result)
+}
+
+// useOTP marks the OTP as used, only one of concurrent verifications gets the tokens
+func (uc *AuthenticateOTPUseCase) useOTP(result *usecase.UseCaseResult[dtos.Token], otpID uint) {
+	used, err := uc.otpRepo.Use(otpID)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error updating one time password as used", err.ToError(), uc.AppContext)
+		result.SetError(
+			err.Code,
+			uc.AppMessages.Get(
+				uc.Locale,
+				err.Context,
+			),
+		)
+		return
+	}
+	if !used {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("One time password already used", uc.AppContext)
+		uc.setInvalidOTPError(result)
+	}
+}
+
+func (uc *AuthenticateOTPUseCase) setInvalidOTPError(result *usecase.UseCaseResult[dtos.Token]) {
+	result.SetError(
+		status.Unauthorized,
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.INVALID_OTP,
+		),
+	)
+}
+
+func (uc *AuthenticateOTPUseCase) setMaxAttemptsError(result *usecase.UseCaseResult[dtos.Token]) {
+	result.SetError(
+		status.TooManyRequests,
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.OTP_MAX_ATTEMPTS_EXCEEDED,
+		),
+	)
 }
 
 func (uc *AuthenticateOTPUseCase) getUser(result *usecase.UseCaseResult[dtos.Token], userID uint) *usermodels.UserWithRole {
@@ -116,9 +200,7 @@ func (uc *AuthenticateOTPUseCase) getUser(result *usecase.UseCaseResult[dtos.Tok
 }
 
 func (uc *AuthenticateOTPUseCase) generateTokens(ctx context.Context, result *usecase.UseCaseResult[dtos.Token], user *usermodels.UserWithRole) dtos.Token {
-	claims := authcontracts.JWTCLaims{
-		"role": user.GetRoleKey(),
-	}
+	claims := authservices.BuildAccessClaimsService(user)
 
 	access, exp, err := uc.jwtProvider.GenerateAccessToken(ctx, user.GetUserIDString(), claims)
 	if err != nil {
@@ -133,7 +215,15 @@ func (uc *AuthenticateOTPUseCase) generateTokens(ctx context.Context, result *us
 		return dtos.Token{}
 	}
 
-	refresh, expRefresh, err := uc.jwtProvider.GenerateRefreshToken(ctx, user.GetUserIDString())
+	// Every login starts a new session
+	refresh, expRefresh, err := authservices.IssueRefreshTokenService(
+		ctx,
+		user.ID,
+		"",
+		uc.jwtProvider,
+		uc.hashProvider,
+		uc.refreshTokenRepo,
+	)
 	if err != nil {
 		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error generating refresh token", err.ToError(), uc.AppContext)
 		result.SetError(
@@ -155,22 +245,6 @@ func (uc *AuthenticateOTPUseCase) generateTokens(ctx context.Context, result *us
 	}
 }
 
-func (uc *AuthenticateOTPUseCase) markOTPAsUsed(result *usecase.UseCaseResult[dtos.Token], otpID uint) {
-	_, err := uc.otpRepo.Update(otpID,
-		dtos.OneTimePasswordUpdate{IsUsed: true, ID: otpID})
-	if err != nil {
-		observability.GetObservabilityComponents().Logger.Error("Error updating one time password as used", err.ToError())
-		result.SetError(
-			err.Code,
-			uc.AppMessages.Get(
-				uc.Locale,
-				err.Context,
-			),
-		)
-		return
-	}
-}
-
 func (uc *AuthenticateOTPUseCase) setSuccessResult(result *usecase.UseCaseResult[dtos.Token], token dtos.Token) {
 	result.SetData(
 		status.Success,
@@ -182,8 +256,8 @@ func (uc *AuthenticateOTPUseCase) setSuccessResult(result *usecase.UseCaseResult
 	)
 }
 
-func (uc *AuthenticateOTPUseCase) Validate(input string, result *usecase.UseCaseResult[dtos.Token]) {
-	if input == "" {
+func (uc *AuthenticateOTPUseCase) Validate(input dtos.OTPVerifyInput, result *usecase.UseCaseResult[dtos.Token]) {
+	if errs := input.Validate(); len(errs) > 0 {
 		result.SetError(
 			status.InvalidInput,
 			uc.AppMessages.Get(
@@ -198,17 +272,19 @@ func (uc *AuthenticateOTPUseCase) Validate(input string, result *usecase.UseCase
 func NewAuthenticateOTPUseCase(
 	userRepo authcontracts.IUserRepository,
 	otpRepo authcontracts.IOneTimePasswordRepository,
+	refreshTokenRepo authcontracts.IRefreshTokenRepository,
 	hashProvider contractproviders.IHashProvider,
 	jwtProvider authcontracts.IJWTProvider,
 ) *AuthenticateOTPUseCase {
 	return &AuthenticateOTPUseCase{
-		BaseUseCaseValidation: usecase.BaseUseCaseValidation[string, dtos.Token]{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[dtos.OTPVerifyInput, dtos.Token]{
 			AppMessages: locales.NewLocale(locales.EN_US),
 			Guards:      usecase.NewGuards(),
 		},
-		userRepo:     userRepo,
-		otpRepo:      otpRepo,
-		jwtProvider:  jwtProvider,
-		hashProvider: hashProvider,
+		userRepo:         userRepo,
+		otpRepo:          otpRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		jwtProvider:      jwtProvider,
+		hashProvider:     hashProvider,
 	}
 }
@@ -6,39 +6,56 @@ import (
 	"testing"
 	"time"
 
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
 	authmocks "github.com/simon3640/goprojectskeleton/src/application/modules/auth/mocks"
 	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
 	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
 	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/settings"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
+var otpVerifyInput = dtos.OTPVerifyInput{TransactionID: "transaction", OTP: "123456"}
+
+func setOTPMaxAttempts(t *testing.T, maxAttempts int) {
+	originalMaxAttempts := settings.AppSettingsInstance.OneTimePasswordMaxAttempts
+	settings.AppSettingsInstance.OneTimePasswordMaxAttempts = maxAttempts
+	t.Cleanup(func() {
+		settings.AppSettingsInstance.OneTimePasswordMaxAttempts = originalMaxAttempts
+	})
+}
+
 func TestAuthenticateOTPUseCase_Valid(t *testing.T) {
 	assert := assert.New(t)
 	ctx := &app_context.AppContext{Context: context.Background()}
+	setOTPMaxAttempts(t, 5)
 
 	testUserRepository := new(authmocks.MockUserRepository)
 	testOTPRepository := new(authmocks.MockOneTimePasswordRepository)
+	testRefreshTokenRepository := new(authmocks.MockRefreshTokenRepository)
 	testJWTProvider := new(authmocks.MockJWTProvider)
 	testHashProvider := new(providersmocks.MockHashProvider)
 
 	authOTPUseCase := NewAuthenticateOTPUseCase(
 		testUserRepository,
 		testOTPRepository,
+		testRefreshTokenRepository,
 		testHashProvider,
 		testJWTProvider,
 	)
 
 	// Mocking Methods
-	testHashProvider.On("HashOneTimeToken", "validOTP").Return(authmocks.OneTimePassword.Hash)
-
+	testHashProvider.On("HashOneTimeToken", otpVerifyInput.TransactionID).Return(authmocks.OTPTransactionHash)
 	testOTPRepository.On(
-		"GetByPasswordHash", authmocks.OneTimePassword.Hash,
+		"GetByTransactionHash", authmocks.OTPTransactionHash,
 	).Return(&authmocks.OneTimePassword, nil)
+	testHashProvider.On("ValidateOTP", authmocks.OneTimePassword.Hash, otpVerifyInput.OTP).Return(true)
+	testOTPRepository.On("Use", authmocks.OneTimePassword.ID).Return(true, nil)
 
 	testUserRepository.On(
 		"GetUserWithRole",
@@ -57,47 +74,172 @@ func TestAuthenticateOTPUseCase_Valid(t *testing.T) {
 		ctx,
 		strconv.FormatUint(uint64(authmocks.OneTimePassword.UserID), 10),
 	).Return("newRefreshToken", time.Now().Add(24*time.Hour), nil)
+	testHashProvider.On("OneTimeToken").Return("family", []byte("family"), nil)
+	testHashProvider.On("HashOneTimeToken", "newRefreshToken").Return(authmocks.RefreshTokenHash)
+	testRefreshTokenRepository.On(
+		"Create",
+		mock.AnythingOfType("authdtos.RefreshTokenCreate"),
+	).Return(authmocks.RefreshToken(), nil)
 
-	testOTPRepository.On(
-		"Update",
-		mock.Anything,
-		mock.AnythingOfType("authdtos.OneTimePasswordUpdate"),
-	).Return(&authmocks.OneTimePassword, nil)
-
-	result := authOTPUseCase.Execute(ctx, locales.EN_US, "validOTP")
+	result := authOTPUseCase.Execute(ctx, locales.EN_US, otpVerifyInput)
 
 	assert.NotNil(result)
 	assert.True(result.IsSuccess())
-
+	assert.Equal("newAccessToken", result.Data.AccessToken)
+	testOTPRepository.AssertExpectations(t)
 }
 
 func TestAuthenticateOTPUseCase_InvalidOTP(t *testing.T) {
 	assert := assert.New(t)
 	ctx := &app_context.AppContext{Context: context.Background()}
+	setOTPMaxAttempts(t, 5)
 
 	testUserRepository := new(authmocks.MockUserRepository)
 	testOTPRepository := new(authmocks.MockOneTimePasswordRepository)
+	testRefreshTokenRepository := new(authmocks.MockRefreshTokenRepository)
 	testJWTProvider := new(authmocks.MockJWTProvider)
 	testHashProvider := new(providersmocks.MockHashProvider)
 
 	authOTPUseCase := NewAuthenticateOTPUseCase(
 		testUserRepository,
 		testOTPRepository,
+		testRefreshTokenRepository,
 		testHashProvider,
 		testJWTProvider,
 	)
 
 	// Mocking Methods
-	testHashProvider.On("HashOneTimeToken", "invalidOTP").Return(authmocks.ExpiredOneTimePassword.Hash)
-
+	testHashProvider.On("HashOneTimeToken", otpVerifyInput.TransactionID).Return(authmocks.OTPTransactionHash)
 	testOTPRepository.On(
-		"GetByPasswordHash", authmocks.ExpiredOneTimePassword.Hash,
+		"GetByTransactionHash", authmocks.OTPTransactionHash,
 	).Return(&authmocks.ExpiredOneTimePassword, nil)
 
-	result := authOTPUseCase.Execute(ctx, locales.EN_US, "invalidOTP")
+	result := authOTPUseCase.Execute(ctx, locales.EN_US, otpVerifyInput)
 
 	assert.NotNil(result)
 	assert.False(result.IsSuccess())
 	assert.Equal(result.GetStatusCode(), status.Unauthorized)
 	assert.NotNil(result.Details)
+	testHashProvider.AssertNotCalled(t, "ValidateOTP", mock.Anything, mock.Anything)
+}
+
+func TestAuthenticateOTPUseCase_InvalidTransaction(t *testing.T) {
+	usedOneTimePassword := authmocks.OneTimePassword
+	usedOneTimePassword.IsUsed = true
+	lockedOneTimePassword := authmocks.OneTimePassword
+	lockedOneTimePassword.Attempts = 5
+
+	cases := []struct {
+		name            string
+		input           dtos.OTPVerifyInput
+		oneTimePassword *sharedmodels.OneTimePassword
+		statusCode      status.ApplicationStatusEnum
+	}{
+		{name: "missing transaction", input: dtos.OTPVerifyInput{OTP: "123456"}, statusCode: status.InvalidInput},
+		{name: "missing otp", input: dtos.OTPVerifyInput{TransactionID: "transaction"}, statusCode: status.InvalidInput},
+		{name: "unknown transaction", input: otpVerifyInput, statusCode: status.Unauthorized},
+		{name: "used otp", input: otpVerifyInput, oneTimePassword: &usedOneTimePassword, statusCode: status.Unauthorized},
+		{name: "no attempts left", input: otpVerifyInput, oneTimePassword: &lockedOneTimePassword, statusCode: status.TooManyRequests},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			ctx := &app_context.AppContext{Context: context.Background()}
+			setOTPMaxAttempts(t, 5)
+
+			testOTPRepository := new(authmocks.MockOneTimePasswordRepository)
+			testHashProvider := new(providersmocks.MockHashProvider)
+			testJWTProvider := new(authmocks.MockJWTProvider)
+			authOTPUseCase := NewAuthenticateOTPUseCase(
+				new(authmocks.MockUserRepository),
+				testOTPRepository,
+				new(authmocks.MockRefreshTokenRepository),
+				testHashProvider,
+				testJWTProvider,
+			)
+
+			testHashProvider.On("HashOneTimeToken", otpVerifyInput.TransactionID).Return(authmocks.OTPTransactionHash)
+			if tc.oneTimePassword != nil {
+				testOTPRepository.On("GetByTransactionHash", authmocks.OTPTransactionHash).Return(tc.oneTimePassword, nil)
+			} else {
+				testOTPRepository.On("GetByTransactionHash", authmocks.OTPTransactionHash).Return(nil, notFoundError())
+			}
+
+			result := authOTPUseCase.Execute(ctx, locales.EN_US, tc.input)
+
+			assert.True(result.HasError())
+			assert.Equal(tc.statusCode, result.StatusCode)
+			testHashProvider.AssertNotCalled(t, "ValidateOTP", mock.Anything, mock.Anything)
+			testJWTProvider.AssertNotCalled(t, "GenerateAccessToken", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestAuthenticateOTPUseCase_WrongOTP(t *testing.T) {
+	cases := []struct {
+		name       string
+		recorded   bool
+		statusCode status.ApplicationStatusEnum
+	}{
+		{name: "attempt recorded", recorded: true, statusCode: status.Unauthorized},
+		{name: "last attempt used concurrently", recorded: false, statusCode: status.TooManyRequests},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			ctx := &app_context.AppContext{Context: context.Background()}
+			setOTPMaxAttempts(t, 5)
+
+			testOTPRepository := new(authmocks.MockOneTimePasswordRepository)
+			testHashProvider := new(providersmocks.MockHashProvider)
+			authOTPUseCase := NewAuthenticateOTPUseCase(
+				new(authmocks.MockUserRepository),
+				testOTPRepository,
+				new(authmocks.MockRefreshTokenRepository),
+				testHashProvider,
+				new(authmocks.MockJWTProvider),
+			)
+
+			testHashProvider.On("HashOneTimeToken", otpVerifyInput.TransactionID).Return(authmocks.OTPTransactionHash)
+			testOTPRepository.On("GetByTransactionHash", authmocks.OTPTransactionHash).Return(&authmocks.OneTimePassword, nil)
+			testHashProvider.On("ValidateOTP", authmocks.OneTimePassword.Hash, otpVerifyInput.OTP).Return(false)
+			testOTPRepository.On("IncrementAttempts", authmocks.OneTimePassword.ID, 5).Return(tc.recorded, nil)
+
+			result := authOTPUseCase.Execute(ctx, locales.EN_US, otpVerifyInput)
+
+			assert.True(result.HasError())
+			assert.Equal(tc.statusCode, result.StatusCode)
+			testOTPRepository.AssertNotCalled(t, "Use", mock.Anything)
+		})
+	}
+}
+
+func TestAuthenticateOTPUseCase_AlreadyUsedConcurrently(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
+	setOTPMaxAttempts(t, 5)
+
+	testOTPRepository := new(authmocks.MockOneTimePasswordRepository)
+	testHashProvider := new(providersmocks.MockHashProvider)
+	testJWTProvider := new(authmocks.MockJWTProvider)
+	authOTPUseCase := NewAuthenticateOTPUseCase(
+		new(authmocks.MockUserRepository),
+		testOTPRepository,
+		new(authmocks.MockRefreshTokenRepository),
+		testHashProvider,
+		testJWTProvider,
+	)
+
+	testHashProvider.On("HashOneTimeToken", otpVerifyInput.TransactionID).Return(authmocks.OTPTransactionHash)
+	testOTPRepository.On("GetByTransactionHash", authmocks.OTPTransactionHash).Return(&authmocks.OneTimePassword, nil)
+	testHashProvider.On("ValidateOTP", authmocks.OneTimePassword.Hash, otpVerifyInput.OTP).Return(true)
+	testOTPRepository.On("Use", authmocks.OneTimePassword.ID).Return(false, nil)
+
+	result := authOTPUseCase.Execute(ctx, locales.EN_US, otpVerifyInput)
+
+	assert.True(result.HasError())
+	assert.Equal(status.Unauthorized, result.StatusCode)
+	testJWTProvider.AssertNotCalled(t, "GenerateAccessToken", mock.Anything, mock.Anything, mock.Anything)
 }
@@ -6,21 +6,35 @@ import (
 	"strings"
 	"time"
 
+	contractproviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
 	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
 	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
 	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
 	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
 )
 
-// AuthenticationRefreshUseCase is the use case for refreshing a JWT token
+// AuthenticationRefreshUseCase is the use case for refreshing a JWT token.
+// Every refresh rotates the refresh token inside its session, presenting an
+// already rotated token again revokes the whole session. The user is reloaded
+// so the new access token carries their current claims, users that are no
+// longer active can not refresh. The session keeps the organization chosen
+// with a switch of tenant while the user is allowed in it.
 type AuthenticationRefreshUseCase struct {
 	usecase.BaseUseCaseValidation[string, dtos.Token]
 
-	jwtProvider authcontracts.IJWTProvider
+	refreshTokenRepo authcontracts.IRefreshTokenRepository
+	userRepo         authcontracts.IUserRepository
+
+	jwtProvider        authcontracts.IJWTProvider
+	hashProvider       contractproviders.IHashProvider
+	revocationProvider contractproviders.ITokenRevocationProvider
 }
 
 var _ usecase.BaseUseCase[string, dtos.Token] = (*AuthenticationRefreshUseCase)(nil)
@@ -43,12 +57,32 @@ func (uc *AuthenticationRefreshUseCase) Execute(ctx *app_context.AppContext,
 		return result
 	}
 
-	subject := uc.validateClaims(result, claims)
+	uc.validateClaims(result, claims)
+	if result.HasError() {
+		return result
+	}
+
+	session := uc.getSession(result, input)
 	if result.HasError() {
 		return result
 	}
 
-	token := uc.generateTokens(ctx, result, subject)
+	uc.checkRevocation(result, claims, session)
+	if result.HasError() {
+		return result
+	}
+
+	user := uc.getActiveUser(result, session)
+	if result.HasError() {
+		return result
+	}
+
+	uc.rotateSession(result, session)
+	if result.HasError() {
+		return result
+	}
+
+	token := uc.generateTokens(ctx, result, user, session)
 	if result.HasError() {
 		return result
 	}
@@ -86,9 +120,8 @@ func (uc *AuthenticationRefreshUseCase) parseAndValidateToken(result *usecase.Us
 	return claims
 }
 
-func (uc *AuthenticationRefreshUseCase) validateClaims(result *usecase.UseCaseResult[dtos.Token], claims authcontracts.JWTCLaims) string {
-	sub, ok := claims["sub"].(string)
-	if !ok {
+func (uc *AuthenticationRefreshUseCase) validateClaims(result *usecase.UseCaseResult[dtos.Token], claims authcontracts.JWTCLaims) {
+	if _, ok := claims["sub"].(string); !ok {
 		observability.GetObservabilityComponents().Logger.ErrorWithContext("Invalid subject in claims", nil, uc.AppContext)
 		result.SetError(
 			status.Unauthorized,
@@ -97,7 +130,7 @@ func (uc *AuthenticationRefreshUseCase) validateClaims(result *usecase.UseCaseRe
 				messages.MessageKeysInstance.AUTHORIZATION_HEADER_INVALID,
 			),
 		)
-		return ""
+		return
 	}
 
 	if claims["typ"] != "refresh" {
@@ -109,7 +142,7 @@ func (uc *AuthenticationRefreshUseCase) validateClaims(result *usecase.UseCaseRe
 				messages.MessageKeysInstance.AUTHORIZATION_HEADER_INVALID,
 			),
 		)
-		return ""
+		return
 	}
 
 	if exp, ok := claims["exp"].(float64); !ok || exp < float64(time.Now().Unix()) {
@@ -121,15 +154,158 @@ func (uc *AuthenticationRefreshUseCase) validateClaims(result *usecase.UseCaseRe
 				messages.MessageKeysInstance.AUTHORIZATION_TOKEN_EXPIRED,
 			),
 		)
-		return ""
 	}
+}
 
-	return sub
+// getSession returns the stored refresh token, an unknown token or a token
+// issued to an OAuth client is rejected
+func (uc *AuthenticationRefreshUseCase) getSession(result *usecase.UseCaseResult[dtos.Token], token string) *sharedmodels.RefreshToken {
+	session, err := uc.refreshTokenRepo.GetByTokenHash(uc.hashProvider.HashOneTimeToken(token))
+	if err != nil && err.Code != status.NotFound {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting refresh token by hash", err.ToError(), uc.AppContext)
+		result.SetError(
+			err.Code,
+			uc.AppMessages.Get(
+				uc.Locale,
+				err.Context,
+			),
+		)
+		return nil
+	}
+	if session == nil {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Refresh token not found", uc.AppContext)
+		uc.setInvalidSession(result)
+		return nil
+	}
+	if session.ClientID != "" {
+		// tokens delegated to an OAuth client are refreshed by the client on the token endpoint
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Refresh token of an OAuth client presented", uc.AppContext)
+		uc.setInvalidSession(result)
+		return nil
+	}
+	return session
 }
 
-func (uc *AuthenticationRefreshUseCase) generateTokens(ctx context.Context, result *usecase.UseCaseResult[dtos.Token], subject string) dtos.Token {
-	var claimsMap map[string]interface{}
-	access, exp, err := uc.jwtProvider.GenerateAccessToken(ctx, subject, claimsMap)
+// checkRevocation rejects refresh tokens issued before every token of the
+// user was revoked. Revocation errors are logged and the token is accepted.
+func (uc *AuthenticationRefreshUseCase) checkRevocation(result *usecase.UseCaseResult[dtos.Token], claims authcontracts.JWTCLaims, session *sharedmodels.RefreshToken) {
+	revoked, err := authservices.IsTokenRevokedService(claims, session.UserID, uc.revocationProvider)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error checking token revocation, continuing with refresh", err.ToError(), uc.AppContext)
+		return
+	}
+	if revoked {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Revoked refresh token presented", uc.AppContext)
+		uc.setInvalidSession(result)
+	}
+}
+
+// getActiveUser reloads the owner of the session. A user that is no longer
+// active loses the whole session.
+func (uc *AuthenticationRefreshUseCase) getActiveUser(result *usecase.UseCaseResult[dtos.Token], session *sharedmodels.RefreshToken) *usermodels.UserWithRole {
+	user, err := uc.userRepo.GetUserWithRole(session.UserID)
+	if err != nil && err.Code != status.NotFound {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting user with role", err.ToError(), uc.AppContext)
+		result.SetError(
+			err.Code,
+			uc.AppMessages.Get(
+				uc.Locale,
+				err.Context,
+			),
+		)
+		return nil
+	}
+	if user == nil {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("User of the refresh token not found", uc.AppContext)
+		uc.revokeSession(session)
+		uc.setInvalidSession(result)
+		return nil
+	}
+	if user.Status == nil || *user.Status != usermodels.UserStatusActive {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Refresh attempted by a user that is not active", uc.AppContext)
+		uc.revokeSession(session)
+		result.SetError(
+			status.Unauthorized,
+			uc.AppMessages.Get(
+				uc.Locale,
+				messages.MessageKeysInstance.USER_NOT_ACTIVE,
+			),
+		)
+		return nil
+	}
+	return user
+}
+
+// rotateSession invalidates the presented refresh token. A token that was
+// already rotated is being reused, e.g. it was stolen, so the whole session
+// is revoked.
+func (uc *AuthenticationRefreshUseCase) rotateSession(result *usecase.UseCaseResult[dtos.Token], session *sharedmodels.RefreshToken) {
+	if session.IsRevoked || session.Expires.Before(time.Now()) {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Refresh token revoked or expired", uc.AppContext)
+		uc.setInvalidSession(result)
+		return
+	}
+
+	if !session.IsRotated {
+		rotated, err := uc.refreshTokenRepo.Rotate(session.ID)
+		if err != nil {
+			observability.GetObservabilityComponents().Logger.ErrorWithContext("Error rotating refresh token", err.ToError(), uc.AppContext)
+			result.SetError(
+				err.Code,
+				uc.AppMessages.Get(
+					uc.Locale,
+					err.Context,
+				),
+			)
+			return
+		}
+		if rotated {
+			return
+		}
+	}
+
+	observability.GetObservabilityComponents().Logger.WarningWithContext("Refresh token reuse detected, revoking session", uc.AppContext)
+	uc.revokeSession(session)
+	uc.setInvalidSession(result)
+}
+
+// revokeSession revokes every refresh token of the session, errors are only
+// logged since the refresh is rejected anyway
+func (uc *AuthenticationRefreshUseCase) revokeSession(session *sharedmodels.RefreshToken) {
+	if err := uc.refreshTokenRepo.RevokeFamily(session.FamilyID); err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error revoking refresh token family", err.ToError(), uc.AppContext)
+	}
+}
+
+func (uc *AuthenticationRefreshUseCase) setInvalidSession(result *usecase.UseCaseResult[dtos.Token]) {
+	result.SetError(
+		status.Unauthorized,
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.INVALID_SESSION,
+		),
+	)
+}
+
+// setTenant makes the user act in the organization of the session and
+// returns it. The default organization of the user is kept when the session
+// has none or the user is no longer allowed in it.
+func (uc *AuthenticationRefreshUseCase) setTenant(user *usermodels.UserWithRole, session *sharedmodels.RefreshToken) uint {
+	if session.TenantID == 0 {
+		return 0
+	}
+	if !user.CanAccessTenant(session.TenantID) {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Session of an organization the user is no longer a member of, returning to the default one", uc.AppContext)
+		return 0
+	}
+	user.SetTenant(session.TenantID)
+	return session.TenantID
+}
+
+func (uc *AuthenticationRefreshUseCase) generateTokens(ctx context.Context, result *usecase.UseCaseResult[dtos.Token], user *usermodels.UserWithRole, session *sharedmodels.RefreshToken) dtos.Token {
+	tenantID := uc.setTenant(user, session)
+	claims := authservices.BuildAccessClaimsService(user)
+	access, exp, err := uc.jwtProvider.GenerateAccessToken(ctx, user.GetUserIDString(), claims)
 	if err != nil {
 		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error generating access token", err.ToError(), uc.AppContext)
 		result.SetError(
@@ -142,7 +318,14 @@ func (uc *AuthenticationRefreshUseCase) generateTokens(ctx context.Context, resu
 		return dtos.Token{}
 	}
 
-	refresh, expRefresh, err := uc.jwtProvider.GenerateRefreshToken(ctx, subject)
+	refresh, expRefresh, err := authservices.RenewRefreshTokenService(
+		ctx,
+		session,
+		tenantID,
+		uc.jwtProvider,
+		uc.hashProvider,
+		uc.refreshTokenRepo,
+	)
 	if err != nil {
 		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error generating refresh token", err.ToError(), uc.AppContext)
 		result.SetError(
@@ -190,13 +373,21 @@ func (uc *AuthenticationRefreshUseCase) validate(input string) (bool, []string)
 }
 
 func NewAuthenticationRefreshUseCase(
+	refreshTokenRepo authcontracts.IRefreshTokenRepository,
+	userRepo authcontracts.IUserRepository,
+	hashProvider contractproviders.IHashProvider,
 	jwtProvider authcontracts.IJWTProvider,
+	revocationProvider contractproviders.ITokenRevocationProvider,
 ) *AuthenticationRefreshUseCase {
 	return &AuthenticationRefreshUseCase{
 		BaseUseCaseValidation: usecase.BaseUseCaseValidation[string, dtos.Token]{
 			AppMessages: locales.NewLocale(locales.EN_US),
 			Guards:      usecase.NewGuards(),
 		},
-		jwtProvider: jwtProvider,
+		refreshTokenRepo:   refreshTokenRepo,
+		userRepo:           userRepo,
+		jwtProvider:        jwtProvider,
+		hashProvider:       hashProvider,
+		revocationProvider: revocationProvider,
 	}
 }
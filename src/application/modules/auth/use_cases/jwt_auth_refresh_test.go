@@ -6,35 +6,63 @@ import (
 	"time"
 
 	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
 	authmocks "github.com/simon3640/goprojectskeleton/src/application/modules/auth/mocks"
 	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
 
-func TestAuthenticationRefreshUseCase(t *testing.T) {
-	assert := assert.New(t)
-	ctx := &app_context.AppContext{Context: context.Background()}
+const validRefreshToken = "validRefreshToken.123"
 
+func newRefreshTestMocks() (*authmocks.MockJWTProvider, *providersmocks.MockHashProvider, *authmocks.MockRefreshTokenRepository, *authmocks.MockUserRepository) {
 	testJWTProvider := new(authmocks.MockJWTProvider)
+	testHashProvider := new(providersmocks.MockHashProvider)
+	testRefreshTokenRepository := new(authmocks.MockRefreshTokenRepository)
+	testUserRepository := new(authmocks.MockUserRepository)
 
-	uc := NewAuthenticationRefreshUseCase(testJWTProvider)
-
-	// Valid Token Refresh
-	validToken := "validAccessToken.123"
 	claimsReturn := authcontracts.JWTCLaims{
 		"sub": "1",
 		"typ": "refresh",
 		"exp": float64(time.Now().Add(1 * time.Hour).Unix()),
 	}
-	testJWTProvider.On("ParseTokenAndValidate", validToken).Return(claimsReturn, nil)
+	testJWTProvider.On("ParseTokenAndValidate", validRefreshToken).Return(claimsReturn, nil)
+	testHashProvider.On("HashOneTimeToken", validRefreshToken).Return(authmocks.RefreshTokenHash)
+	return testJWTProvider, testHashProvider, testRefreshTokenRepository, testUserRepository
+}
+
+func TestAuthenticationRefreshUseCase(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
 
-	testJWTProvider.On("GenerateAccessToken", ctx, "1", mock.Anything).Return("newAccessToken", time.Now().Add(1*time.Hour), nil)
+	testJWTProvider, testHashProvider, testRefreshTokenRepository, testUserRepository := newRefreshTestMocks()
+	uc := NewAuthenticationRefreshUseCase(testRefreshTokenRepository, testUserRepository, testHashProvider, testJWTProvider, nil)
 
+	session := authmocks.RefreshToken()
+	testRefreshTokenRepository.On("GetByTokenHash", authmocks.RefreshTokenHash).Return(session, nil)
+	testRefreshTokenRepository.On("Rotate", session.ID).Return(true, nil)
+	testUserRepository.On("GetUserWithRole", session.UserID).Return(&dtomocks.UserWithRole, nil)
+	// The refreshed access token keeps the claims of the login
+	testJWTProvider.On("GenerateAccessToken", ctx, "1", mock.MatchedBy(func(claims authcontracts.JWTCLaims) bool {
+		return claims["role"] == dtomocks.UserWithRole.GetRoleKey()
+	})).Return("newAccessToken", time.Now().Add(1*time.Hour), nil)
 	testJWTProvider.On("GenerateRefreshToken", ctx, "1").Return("newRefreshToken", time.Now().Add(24*time.Hour), nil)
-	result := uc.Execute(ctx, locales.EN_US, validToken)
+	testHashProvider.On("HashOneTimeToken", "newRefreshToken").Return([]byte("hashed_new_refresh_token"))
+	// The new token stays in the session of the rotated one
+	testRefreshTokenRepository.On("Create", mock.MatchedBy(func(create authdtos.RefreshTokenCreate) bool {
+		return create.FamilyID == session.FamilyID && create.UserID == session.UserID
+	})).Return(authmocks.RefreshToken(), nil)
+
+	result := uc.Execute(ctx, locales.EN_US, validRefreshToken)
 
 	assert.NotNil(result)
 	assert.True(result.IsSuccess())
@@ -42,4 +70,216 @@ func TestAuthenticationRefreshUseCase(t *testing.T) {
 	assert.Equal("newRefreshToken", result.Data.RefreshToken)
 	assert.NotNil(result.Data.AccessTokenExpiresAt)
 	assert.NotNil(result.Data.RefreshTokenExpiresAt)
+	testRefreshTokenRepository.AssertNotCalled(t, "RevokeFamily", mock.Anything)
+	testHashProvider.AssertNotCalled(t, "OneTimeToken")
+}
+
+func TestAuthenticationRefreshUseCase_ReuseRevokesFamily(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
+
+	testJWTProvider, testHashProvider, testRefreshTokenRepository, testUserRepository := newRefreshTestMocks()
+	uc := NewAuthenticationRefreshUseCase(testRefreshTokenRepository, testUserRepository, testHashProvider, testJWTProvider, nil)
+
+	session := authmocks.RefreshToken()
+	session.IsRotated = true
+	testRefreshTokenRepository.On("GetByTokenHash", authmocks.RefreshTokenHash).Return(session, nil)
+	testUserRepository.On("GetUserWithRole", session.UserID).Return(&dtomocks.UserWithRole, nil)
+	testRefreshTokenRepository.On("RevokeFamily", session.FamilyID).Return(nil)
+
+	result := uc.Execute(ctx, locales.EN_US, validRefreshToken)
+
+	assert.True(result.HasError())
+	assert.Equal(status.Unauthorized, result.StatusCode)
+	testRefreshTokenRepository.AssertCalled(t, "RevokeFamily", session.FamilyID)
+	testRefreshTokenRepository.AssertNotCalled(t, "Rotate", mock.Anything)
+	testJWTProvider.AssertNotCalled(t, "GenerateAccessToken", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuthenticationRefreshUseCase_ConcurrentRotationRevokesFamily(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
+
+	testJWTProvider, testHashProvider, testRefreshTokenRepository, testUserRepository := newRefreshTestMocks()
+	uc := NewAuthenticationRefreshUseCase(testRefreshTokenRepository, testUserRepository, testHashProvider, testJWTProvider, nil)
+
+	// Another request rotated the token between the read and the rotation
+	session := authmocks.RefreshToken()
+	testRefreshTokenRepository.On("GetByTokenHash", authmocks.RefreshTokenHash).Return(session, nil)
+	testRefreshTokenRepository.On("Rotate", session.ID).Return(false, nil)
+	testUserRepository.On("GetUserWithRole", session.UserID).Return(&dtomocks.UserWithRole, nil)
+	testRefreshTokenRepository.On("RevokeFamily", session.FamilyID).Return(nil)
+
+	result := uc.Execute(ctx, locales.EN_US, validRefreshToken)
+
+	assert.True(result.HasError())
+	assert.Equal(status.Unauthorized, result.StatusCode)
+	testRefreshTokenRepository.AssertCalled(t, "RevokeFamily", session.FamilyID)
+}
+
+func TestAuthenticationRefreshUseCase_InvalidSession(t *testing.T) {
+	ctx := &app_context.AppContext{Context: context.Background()}
+
+	notFound := applicationerrors.NewApplicationError(
+		status.NotFound,
+		messages.MessageKeysInstance.RESOURCE_NOT_FOUND,
+		"Resource not found",
+	)
+	revoked := authmocks.RefreshToken()
+	revoked.IsRevoked = true
+	expired := authmocks.RefreshToken()
+	expired.Expires = time.Now().Add(-time.Minute)
+
+	cases := []struct {
+		name    string
+		session *sharedmodels.RefreshToken
+		err     *applicationerrors.ApplicationError
+	}{
+		{name: "unknown token", err: notFound},
+		{name: "revoked session", session: revoked},
+		{name: "expired session", session: expired},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			testJWTProvider, testHashProvider, testRefreshTokenRepository, testUserRepository := newRefreshTestMocks()
+			uc := NewAuthenticationRefreshUseCase(testRefreshTokenRepository, testUserRepository, testHashProvider, testJWTProvider, nil)
+			testRefreshTokenRepository.On("GetByTokenHash", authmocks.RefreshTokenHash).Return(tc.session, tc.err)
+
+			result := uc.Execute(ctx, locales.EN_US, validRefreshToken)
+
+			assert.True(result.HasError())
+			assert.Equal(status.Unauthorized, result.StatusCode)
+			testRefreshTokenRepository.AssertNotCalled(t, "Rotate", mock.Anything)
+			testRefreshTokenRepository.AssertNotCalled(t, "RevokeFamily", mock.Anything)
+		})
+	}
+}
+
+func TestAuthenticationRefreshUseCase_RevokedUserTokens(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
+
+	testJWTProvider := new(authmocks.MockJWTProvider)
+	testHashProvider := new(providersmocks.MockHashProvider)
+	testRefreshTokenRepository := new(authmocks.MockRefreshTokenRepository)
+	testRevocationProvider := new(providersmocks.MockTokenRevocationProvider)
+	uc := NewAuthenticationRefreshUseCase(testRefreshTokenRepository, new(authmocks.MockUserRepository), testHashProvider, testJWTProvider, testRevocationProvider)
+
+	// The password changed after the refresh token was issued
+	issuedAt := time.Now().Add(-time.Hour)
+	claimsReturn := authcontracts.JWTCLaims{
+		"sub": "1",
+		"typ": "refresh",
+		"exp": float64(time.Now().Add(1 * time.Hour).Unix()),
+		"iat": float64(issuedAt.Unix()),
+	}
+	session := authmocks.RefreshToken()
+	testJWTProvider.On("ParseTokenAndValidate", validRefreshToken).Return(claimsReturn, nil)
+	testHashProvider.On("HashOneTimeToken", validRefreshToken).Return(authmocks.RefreshTokenHash)
+	testRefreshTokenRepository.On("GetByTokenHash", authmocks.RefreshTokenHash).Return(session, nil)
+	testRevocationProvider.On("UserTokensRevokedBefore", session.UserID).Return(issuedAt.Add(time.Minute), nil)
+
+	result := uc.Execute(ctx, locales.EN_US, validRefreshToken)
+
+	assert.True(result.HasError())
+	assert.Equal(status.Unauthorized, result.StatusCode)
+	testRefreshTokenRepository.AssertNotCalled(t, "Rotate", mock.Anything)
+	testJWTProvider.AssertNotCalled(t, "GenerateAccessToken", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuthenticationRefreshUseCase_UserNotActive(t *testing.T) {
+	ctx := &app_context.AppContext{Context: context.Background()}
+
+	notFound := applicationerrors.NewApplicationError(
+		status.NotFound,
+		messages.MessageKeysInstance.RESOURCE_NOT_FOUND,
+		"Resource not found",
+	)
+	suspendedStatus := usermodels.UserStatusSuspended
+	suspended := dtomocks.UserWithRole
+	suspended.Status = &suspendedStatus
+	withoutStatus := dtomocks.UserWithRole
+	withoutStatus.Status = nil
+
+	cases := []struct {
+		name string
+		user *usermodels.UserWithRole
+		err  *applicationerrors.ApplicationError
+	}{
+		{name: "deleted user", err: notFound},
+		{name: "suspended user", user: &suspended},
+		{name: "user without status", user: &withoutStatus},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			testJWTProvider, testHashProvider, testRefreshTokenRepository, testUserRepository := newRefreshTestMocks()
+			uc := NewAuthenticationRefreshUseCase(testRefreshTokenRepository, testUserRepository, testHashProvider, testJWTProvider, nil)
+
+			session := authmocks.RefreshToken()
+			testRefreshTokenRepository.On("GetByTokenHash", authmocks.RefreshTokenHash).Return(session, nil)
+			testRefreshTokenRepository.On("RevokeFamily", session.FamilyID).Return(nil)
+			testUserRepository.On("GetUserWithRole", session.UserID).Return(tc.user, tc.err)
+
+			result := uc.Execute(ctx, locales.EN_US, validRefreshToken)
+
+			assert.True(result.HasError())
+			assert.Equal(status.Unauthorized, result.StatusCode)
+			testRefreshTokenRepository.AssertCalled(t, "RevokeFamily", session.FamilyID)
+			testRefreshTokenRepository.AssertNotCalled(t, "Rotate", mock.Anything)
+			testJWTProvider.AssertNotCalled(t, "GenerateAccessToken", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestAuthenticationRefreshUseCase_SessionTenant(t *testing.T) {
+	tests := []struct {
+		name          string
+		organizations []uint
+		tenant        uint
+	}{
+		{name: "member of the organization of the session", organizations: []uint{1, 2}, tenant: 2},
+		{name: "no longer a member returns to the default organization", organizations: []uint{1}, tenant: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			ctx := &app_context.AppContext{Context: context.Background()}
+
+			testJWTProvider, testHashProvider, testRefreshTokenRepository, testUserRepository := newRefreshTestMocks()
+			uc := NewAuthenticationRefreshUseCase(testRefreshTokenRepository, testUserRepository, testHashProvider, testJWTProvider, nil)
+
+			// The session was switched to the organization 2
+			session := authmocks.RefreshToken()
+			session.TenantID = 2
+			user := dtomocks.UserWithRole
+			user.SetOrganizations(tt.organizations)
+			user.SetTenant(tt.organizations[0])
+			accessTenant := tt.tenant
+			if accessTenant == 0 {
+				accessTenant = tt.organizations[0]
+			}
+			testRefreshTokenRepository.On("GetByTokenHash", authmocks.RefreshTokenHash).Return(session, nil)
+			testRefreshTokenRepository.On("Rotate", session.ID).Return(true, nil)
+			testUserRepository.On("GetUserWithRole", session.UserID).Return(&user, nil)
+			testJWTProvider.On("GenerateAccessToken", ctx, "1", mock.MatchedBy(func(claims authcontracts.JWTCLaims) bool {
+				return claims["tenant"] == accessTenant
+			})).Return("newAccessToken", time.Now().Add(1*time.Hour), nil)
+			testJWTProvider.On("GenerateRefreshToken", ctx, "1").Return("newRefreshToken", time.Now().Add(24*time.Hour), nil)
+			testHashProvider.On("HashOneTimeToken", "newRefreshToken").Return([]byte("hashed_new_refresh_token"))
+			testRefreshTokenRepository.On("Create", mock.MatchedBy(func(create authdtos.RefreshTokenCreate) bool {
+				return create.FamilyID == session.FamilyID && create.TenantID == tt.tenant
+			})).Return(authmocks.RefreshToken(), nil)
+
+			result := uc.Execute(ctx, locales.EN_US, validRefreshToken)
+
+			assert.True(result.IsSuccess())
+			testRefreshTokenRepository.AssertExpectations(t)
+			testJWTProvider.AssertExpectations(t)
+		})
+	}
 }
@@ -2,16 +2,18 @@ package authusecases
 
 import (
 	"context"
-	"sync"
 	"testing"
 	"time"
 
 	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
 	authmocks "github.com/simon3640/goprojectskeleton/src/application/modules/auth/mocks"
+	shareddtos "github.com/simon3640/goprojectskeleton/src/application/shared/DTOs"
 	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
 	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
 	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
+	repositoriesmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/repositories"
 	services "github.com/simon3640/goprojectskeleton/src/application/shared/services"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/settings"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
@@ -19,6 +21,7 @@ import (
 	"github.com/simon3640/goprojectskeleton/src/application/shared/workers"
 	passwordmodels "github.com/simon3640/goprojectskeleton/src/domain/password/models"
 	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -28,13 +31,11 @@ func TestAuthenticationUseCase(t *testing.T) {
 	assert := assert.New(t)
 	ctx := &app_context.AppContext{Context: context.Background()}
 
-	testJWTProvider := new(authmocks.MockJWTProvider)
 	testHashProvider := new(providersmocks.MockHashProvider)
 	testPasswordRepository := new(authmocks.MockPasswordRepository)
 	testUserRepository := new(authmocks.MockUserRepository)
-	testOTPRepository := new(authmocks.MockOneTimePasswordRepository)
 
-	uc := NewAuthenticateUseCase(testPasswordRepository, testUserRepository, testOTPRepository, testHashProvider, testJWTProvider, nil)
+	uc := NewAuthenticateUseCase(testPasswordRepository, testUserRepository, nil, testHashProvider, nil, nil)
 
 	// Valid User Authentication
 	userCredentials := dtos.UserCredentials{
@@ -53,46 +54,29 @@ func TestAuthenticationUseCase(t *testing.T) {
 		ID:           uint(1),
 	}, nil)
 	testHashProvider.On("VerifyPassword", passwordBase.Hash, userCredentials.Password).Return(true, nil)
-	testJWTProvider.On("GenerateAccessToken", ctx, "1", mock.Anything).Return("accessToken", time.Now().Add(1*time.Hour), nil)
-	testJWTProvider.On("GenerateRefreshToken", ctx, "1").Return("refreshToken", time.Now().Add(24*time.Hour), nil)
 	testUserRepository.On("GetUserWithRole", uint(1)).Return(&dtomocks.UserWithRole, nil)
 	result := uc.Execute(ctx, locales.EN_US, userCredentials)
 	assert.NotNil(result)
 	assert.True(result.IsSuccess())
-	assert.Equal("accessToken", result.Data.AccessToken)
-	assert.Equal("refreshToken", result.Data.RefreshToken)
+	assert.Equal(uint(1), result.Data.User.ID)
+	assert.False(result.Data.MFAVerified)
+	assert.False(result.Data.RequiresOTP())
 }
 
 func TestAuthenticationUseCase_OTPLoginEnabled(t *testing.T) {
 	assert := assert.New(t)
 	ctx := &app_context.AppContext{Context: context.Background()}
 
-	// Setup background service factory for testing
-	workers.ResetBackgroundExecutorSingleton()
-	services.ResetBackgroundServiceFactory()
-
-	// Initialize background executor and factory
-	executorCtx := context.Background()
-	workers.InitializeBackgroundExecutor(executorCtx, 2, 10)
-	defer workers.ResetBackgroundExecutorSingleton()
-
-	services.InitializeBackgroundServiceFactory()
-	defer services.ResetBackgroundServiceFactory()
-
-	testJWTProvider := new(authmocks.MockJWTProvider)
 	testHashProvider := new(providersmocks.MockHashProvider)
 	testPasswordRepository := new(authmocks.MockPasswordRepository)
 	testUserRepository := new(authmocks.MockUserRepository)
-	testOTPRepository := new(authmocks.MockOneTimePasswordRepository)
 
-	uc := NewAuthenticateUseCase(testPasswordRepository, testUserRepository, testOTPRepository, testHashProvider, testJWTProvider, nil)
+	uc := NewAuthenticateUseCase(testPasswordRepository, testUserRepository, nil, testHashProvider, nil, nil)
 
-	// User with OTP login enabled
 	userCredentials := dtos.UserCredentials{
 		Email:    "user@example.com",
 		Password: "plainPassword",
 	}
-
 	passwordExpiresAt := time.Now().Add(30 * 24 * time.Hour)
 	passwordBase := passwordmodels.PasswordBase{
 		UserID:    uint(1),
@@ -100,8 +84,6 @@ func TestAuthenticationUseCase_OTPLoginEnabled(t *testing.T) {
 		IsActive:  true,
 		Hash:      "hashedPassword123",
 	}
-
-	// Create user with OTP login enabled
 	userWithOTP := dtomocks.UserWithRole
 	userWithOTP.OTPLogin = true
 
@@ -112,121 +94,21 @@ func TestAuthenticationUseCase_OTPLoginEnabled(t *testing.T) {
 	testHashProvider.On("VerifyPassword", passwordBase.Hash, userCredentials.Password).Return(true, nil)
 	testUserRepository.On("GetUserWithRole", uint(1)).Return(&userWithOTP, nil)
 
-	// Track execution time to verify it doesn't block
-	startTime := time.Now()
+	// The login pipe completes the login with the OTP sent by email
 	result := uc.Execute(ctx, locales.EN_US, userCredentials)
-	executionTime := time.Since(startTime)
-
-	// Verify result is returned immediately (should be very fast, < 50ms)
-	// This is the key test: the execution should not wait for the background service
-	assert.True(executionTime < 50*time.Millisecond, "Execution should not block, took %v", executionTime)
-	assert.NotNil(result)
 	assert.True(result.IsSuccess())
-	assert.Nil(result.Data, "Result should not contain token when OTP login is enabled")
-	assert.NotEmpty(result.Details, "Result should have details indicating OTP login is enabled")
-
-	// Wait a bit for background service to execute (even if it fails, it shouldn't affect the test)
-	// The background service may fail due to uninitialized email service, but that's OK for this test
-	time.Sleep(200 * time.Millisecond)
-
-	// Verify expectations for the synchronous part
-	testPasswordRepository.AssertExpectations(t)
-	testHashProvider.AssertExpectations(t)
-	testUserRepository.AssertExpectations(t)
-	// Note: We don't assert OTP repository expectations because the background service
-	// may fail due to uninitialized email service, and that's acceptable for this test
-}
-
-func TestAuthenticationUseCase_OTPLoginEnabled_NonBlocking(t *testing.T) {
-	assert := assert.New(t)
-	ctx := &app_context.AppContext{Context: context.Background()}
-
-	// Setup background service factory for testing
-	workers.ResetBackgroundExecutorSingleton()
-	services.ResetBackgroundServiceFactory()
-
-	executorCtx := context.Background()
-	workers.InitializeBackgroundExecutor(executorCtx, 2, 10)
-	defer workers.ResetBackgroundExecutorSingleton()
-
-	services.InitializeBackgroundServiceFactory()
-	defer services.ResetBackgroundServiceFactory()
-
-	testJWTProvider := new(authmocks.MockJWTProvider)
-	testHashProvider := new(providersmocks.MockHashProvider)
-	testPasswordRepository := new(authmocks.MockPasswordRepository)
-	testUserRepository := new(authmocks.MockUserRepository)
-	testOTPRepository := new(authmocks.MockOneTimePasswordRepository)
-
-	uc := NewAuthenticateUseCase(testPasswordRepository, testUserRepository, testOTPRepository, testHashProvider, testJWTProvider, nil)
-
-	userCredentials := dtos.UserCredentials{
-		Email:    "user@example.com",
-		Password: "plainPassword",
-	}
-
-	passwordExpiresAt := time.Now().Add(30 * 24 * time.Hour)
-	passwordBase := passwordmodels.PasswordBase{
-		UserID:    uint(1),
-		ExpiresAt: &passwordExpiresAt,
-		IsActive:  true,
-		Hash:      "hashedPassword123",
-	}
-
-	userWithOTP := dtomocks.UserWithRole
-	userWithOTP.OTPLogin = true
-
-	testPasswordRepository.On("GetActivePassword", "user@example.com").Return(&passwordmodels.Password{
-		PasswordBase: passwordBase,
-		ID:           uint(1),
-	}, nil).Maybe()
-	testHashProvider.On("VerifyPassword", passwordBase.Hash, userCredentials.Password).Return(true, nil).Maybe()
-	testUserRepository.On("GetUserWithRole", uint(1)).Return(&userWithOTP, nil).Maybe()
-
-	// Mock OTP generation for background service (will be called asynchronously)
-	testHashProvider.On("GenerateOTP").Return("123456", []byte("hashedOTP"), nil).Maybe()
-	testOTPRepository.On("Create", mock.Anything).Return(&sharedmodels.OneTimePassword{}, nil).Maybe()
-
-	// Execute multiple times to verify non-blocking behavior
-	var wg sync.WaitGroup
-	numExecutions := 5
-	results := make([]*usecase.UseCaseResult[dtos.Token], numExecutions)
-	executionTimes := make([]time.Duration, numExecutions)
-
-	for i := 0; i < numExecutions; i++ {
-		wg.Add(1)
-		go func(index int) {
-			defer wg.Done()
-			startTime := time.Now()
-			results[index] = uc.Execute(ctx, locales.EN_US, userCredentials)
-			executionTimes[index] = time.Since(startTime)
-		}(i)
-	}
-
-	wg.Wait()
-
-	// All executions should complete quickly (non-blocking)
-	for i, execTime := range executionTimes {
-		assert.True(execTime < 50*time.Millisecond, "Execution %d should not block, took %v", i, execTime)
-		assert.NotNil(results[i], "Result %d should not be nil", i)
-		assert.True(results[i].IsSuccess(), "Result %d should be successful", i)
-	}
-
-	// Wait for background services to complete
-	time.Sleep(200 * time.Millisecond)
+	assert.True(result.Data.RequiresOTP())
 }
 
 func TestAuthenticationUseCase_InvalidCredentials(t *testing.T) {
 	assert := assert.New(t)
 	ctx := &app_context.AppContext{Context: context.Background()}
 
-	testJWTProvider := new(authmocks.MockJWTProvider)
 	testHashProvider := new(providersmocks.MockHashProvider)
 	testPasswordRepository := new(authmocks.MockPasswordRepository)
 	testUserRepository := new(authmocks.MockUserRepository)
-	testOTPRepository := new(authmocks.MockOneTimePasswordRepository)
 
-	uc := NewAuthenticateUseCase(testPasswordRepository, testUserRepository, testOTPRepository, testHashProvider, testJWTProvider, nil)
+	uc := NewAuthenticateUseCase(testPasswordRepository, testUserRepository, nil, testHashProvider, nil, nil)
 
 	// Invalid User Authentication
 	userCredentials := dtos.UserCredentials{
@@ -253,78 +135,31 @@ func TestAuthenticationUseCase_InvalidCredentials(t *testing.T) {
 	assert.True(result.HasError())
 	testPasswordRepository.AssertExpectations(t)
 	testHashProvider.AssertExpectations(t)
-	testJWTProvider.AssertExpectations(t)
 }
 
-func TestAuthenticationUseCase_RateLimitExceeded(t *testing.T) {
-	assert := assert.New(t)
-	ctx := &app_context.AppContext{Context: context.Background()}
-
-	// Guardar valores originales
-	originalMaxAttempts := settings.AppSettingsInstance.LoginMaxAttempts
-	defer func() {
-		settings.AppSettingsInstance.LoginMaxAttempts = originalMaxAttempts
-	}()
-
-	// Configurar max attempts para el test
-	settings.AppSettingsInstance.LoginMaxAttempts = 5
-
-	testJWTProvider := new(authmocks.MockJWTProvider)
-	testHashProvider := new(providersmocks.MockHashProvider)
-	testPasswordRepository := new(authmocks.MockPasswordRepository)
-	testUserRepository := new(authmocks.MockUserRepository)
-	testOTPRepository := new(authmocks.MockOneTimePasswordRepository)
-	cacheProvider := new(providersmocks.MockCacheProvider)
-
-	uc := NewAuthenticateUseCase(testPasswordRepository, testUserRepository, testOTPRepository, testHashProvider, testJWTProvider, cacheProvider)
-
-	// Rate Limit Exceeded - usuario ha intentado 5 veces (igual al límite)
-	userCredentials := dtos.UserCredentials{
-		Email:    "user@example.com",
-		Password: "plainPassword",
-	}
-
-	attempts := 5
-	cacheProvider.On("GetInt64", "login_attempts:user@example.com").Return(int64(attempts), nil)
-
-	result := uc.Execute(ctx, locales.EN_US, userCredentials)
-	assert.NotNil(result)
-	assert.False(result.IsSuccess())
-	assert.Equal(status.TooManyRequests, result.StatusCode)
-	assert.True(result.HasError())
-	cacheProvider.AssertExpectations(t)
+// setLoginThrottleSettings sets the login limits of a test and restores the
+// configured ones when it finishes
+func setLoginThrottleSettings(t *testing.T, maxAttempts int, maxAttemptsPerIP int, maxAttemptsPerAccount int, globalFailureLimit int) {
+	original := *settings.AppSettingsInstance
+	t.Cleanup(func() {
+		*settings.AppSettingsInstance = original
+	})
+	settings.AppSettingsInstance.LoginMaxAttempts = maxAttempts
+	settings.AppSettingsInstance.LoginMaxAttemptsPerIP = maxAttemptsPerIP
+	settings.AppSettingsInstance.LoginMaxAttemptsPerAccount = maxAttemptsPerAccount
+	settings.AppSettingsInstance.LoginGlobalFailureLimit = globalFailureLimit
+	settings.AppSettingsInstance.LoginAttemptsWindowMinutes = 15
+	settings.AppSettingsInstance.LoginDelayAfterAttempts = 0
+	settings.AppSettingsInstance.LoginDelaySeconds = 0
 }
 
-func TestAuthenticationUseCase_RateLimitNotExceeded(t *testing.T) {
-	assert := assert.New(t)
-	ctx := &app_context.AppContext{Context: context.Background()}
-
-	// Guardar valores originales
-	originalMaxAttempts := settings.AppSettingsInstance.LoginMaxAttempts
-	defer func() {
-		settings.AppSettingsInstance.LoginMaxAttempts = originalMaxAttempts
-	}()
-
-	// Configurar max attempts para el test
-	settings.AppSettingsInstance.LoginMaxAttempts = 5
-
-	testJWTProvider := new(authmocks.MockJWTProvider)
+// newThrottledTestUseCase creates an authentication use case whose password
+// is "plainPassword" for every email
+func newThrottledTestUseCase(cacheProvider *providersmocks.MemoryCacheProvider) (*AuthenticateUseCase, *authmocks.MockUserRepository, *repositoriesmocks.MockOneTimeTokenRepository) {
 	testHashProvider := new(providersmocks.MockHashProvider)
 	testPasswordRepository := new(authmocks.MockPasswordRepository)
 	testUserRepository := new(authmocks.MockUserRepository)
-	testOTPRepository := new(authmocks.MockOneTimePasswordRepository)
-	cacheProvider := new(providersmocks.MockCacheProvider)
-
-	uc := NewAuthenticateUseCase(testPasswordRepository, testUserRepository, testOTPRepository, testHashProvider, testJWTProvider, cacheProvider)
-
-	// Rate Limit Not Exceeded - usuario ha intentado 3 veces (menos que el límite)
-	userCredentials := dtos.UserCredentials{
-		Email:    "user@example.com",
-		Password: "plainPassword",
-	}
-
-	attempts := 3
-	cacheProvider.On("GetInt64", "login_attempts:user@example.com").Return(int64(attempts), nil)
+	testTokenRepository := new(repositoriesmocks.MockOneTimeTokenRepository)
 
 	passwordExpiresAt := time.Now().Add(30 * 24 * time.Hour)
 	passwordBase := passwordmodels.PasswordBase{
@@ -333,60 +168,104 @@ func TestAuthenticationUseCase_RateLimitNotExceeded(t *testing.T) {
 		IsActive:  true,
 		Hash:      "hashedPassword123",
 	}
-	testPasswordRepository.On("GetActivePassword", "user@example.com").Return(&passwordmodels.Password{
+	testPasswordRepository.On("GetActivePassword", mock.Anything).Return(&passwordmodels.Password{
 		PasswordBase: passwordBase,
 		ID:           uint(1),
 	}, nil)
-	testHashProvider.On("VerifyPassword", passwordBase.Hash, userCredentials.Password).Return(true, nil)
-	testJWTProvider.On("GenerateAccessToken", ctx, "1", mock.Anything).Return("accessToken", time.Now().Add(1*time.Hour), nil)
-	testJWTProvider.On("GenerateRefreshToken", ctx, "1").Return("refreshToken", time.Now().Add(24*time.Hour), nil)
+	testHashProvider.On("VerifyPassword", passwordBase.Hash, "plainPassword").Return(true, nil)
+	testHashProvider.On("VerifyPassword", passwordBase.Hash, mock.Anything).Return(false, nil)
 	testUserRepository.On("GetUserWithRole", uint(1)).Return(&dtomocks.UserWithRole, nil)
-	cacheProvider.On("Delete", "login_attempts:user@example.com").Return(nil)
+	testHashProvider.On("OneTimeToken").Return("token", []byte("token_hash"), nil)
 
-	result := uc.Execute(ctx, locales.EN_US, userCredentials)
-	assert.NotNil(result)
-	assert.True(result.IsSuccess())
-	assert.Equal("accessToken", result.Data.AccessToken)
-	cacheProvider.AssertExpectations(t)
-	testPasswordRepository.AssertExpectations(t)
-	testHashProvider.AssertExpectations(t)
-	testJWTProvider.AssertExpectations(t)
+	uc := NewAuthenticateUseCase(testPasswordRepository, testUserRepository, testTokenRepository, testHashProvider, cacheProvider, nil)
+	return uc, testUserRepository, testTokenRepository
 }
 
-func TestAuthenticationUseCase_IncrementFailedAttempts(t *testing.T) {
-	assert := assert.New(t)
-	ctx := &app_context.AppContext{Context: context.Background()}
+func loginFrom(uc *AuthenticateUseCase, ip string, email string, password string) *usecase.UseCaseResult[dtos.AuthenticatedUser] {
+	ctx := &app_context.AppContext{Context: context.Background(), ClientIP: ip}
+	return uc.Execute(ctx, locales.EN_US, dtos.UserCredentials{Email: email, Password: password})
+}
 
-	// Guardar valores originales
-	originalMaxAttempts := settings.AppSettingsInstance.LoginMaxAttempts
-	originalWindowMinutes := settings.AppSettingsInstance.LoginAttemptsWindowMinutes
-	defer func() {
-		settings.AppSettingsInstance.LoginMaxAttempts = originalMaxAttempts
-		settings.AppSettingsInstance.LoginAttemptsWindowMinutes = originalWindowMinutes
-	}()
+func assertLoginLimited(t *testing.T, result *usecase.UseCaseResult[dtos.AuthenticatedUser], key messages.MessageKeysEnum) {
+	t.Helper()
+	assert.False(t, result.IsSuccess())
+	assert.Equal(t, status.TooManyRequests, result.StatusCode)
+	assert.Equal(t, locales.NewLocale(locales.EN_US).Get(locales.EN_US, key), *result.Error)
+}
 
-	// Configurar para el test
-	settings.AppSettingsInstance.LoginMaxAttempts = 5
-	settings.AppSettingsInstance.LoginAttemptsWindowMinutes = 15
+func TestAuthenticationUseCase_RateLimitExceeded(t *testing.T) {
+	setLoginThrottleSettings(t, 5, 0, 0, 0)
+	cacheProvider := providersmocks.NewMemoryCacheProvider()
+	uc, _, _ := newThrottledTestUseCase(cacheProvider)
+
+	for range 5 {
+		result := loginFrom(uc, "10.0.0.1", "user@example.com", "wrongPassword")
+		assert.Equal(t, status.NotFound, result.StatusCode)
+	}
+
+	// The client reached the limit on the account, even with the right password
+	result := loginFrom(uc, "10.0.0.1", "user@example.com", "plainPassword")
+	assertLoginLimited(t, result, messages.MessageKeysInstance.LoginMaxAttemptsExceeded)
+
+	// The account is not locked for the other clients
+	result = loginFrom(uc, "10.0.0.2", "user@example.com", "plainPassword")
+	assert.True(t, result.IsSuccess())
+}
+
+func TestAuthenticationUseCase_RateLimitNotExceeded(t *testing.T) {
+	setLoginThrottleSettings(t, 5, 0, 0, 0)
+	cacheProvider := providersmocks.NewMemoryCacheProvider()
+	uc, _, _ := newThrottledTestUseCase(cacheProvider)
+
+	for range 3 {
+		loginFrom(uc, "10.0.0.1", "user@example.com", "wrongPassword")
+	}
+	count, _ := cacheProvider.GetInt64("login_attempts:ip_account:10.0.0.1:user@example.com")
+	assert.Equal(t, int64(3), count)
+
+	result := loginFrom(uc, "10.0.0.1", "user@example.com", "plainPassword")
+	assert.True(t, result.IsSuccess())
+	assert.Equal(t, uint(1), result.Data.User.ID)
+
+	// A successful login clears the failures of the client on the account
+	count, _ = cacheProvider.GetInt64("login_attempts:ip_account:10.0.0.1:user@example.com")
+	assert.Zero(t, count)
+	count, _ = cacheProvider.GetInt64("login_attempts:user@example.com")
+	assert.Zero(t, count)
+}
+
+func TestAuthenticationUseCase_IncrementFailedAttempts(t *testing.T) {
+	setLoginThrottleSettings(t, 5, 50, 20, 200)
+	cacheProvider := providersmocks.NewMemoryCacheProvider()
+	uc, _, _ := newThrottledTestUseCase(cacheProvider)
+
+	result := loginFrom(uc, "10.0.0.1", "user@example.com", "wrongPassword")
+	assert.False(t, result.IsSuccess())
+	assert.Equal(t, status.NotFound, result.StatusCode)
+
+	for _, key := range []string{
+		"login_attempts:user@example.com",
+		"login_attempts:ip:10.0.0.1",
+		"login_attempts:ip_account:10.0.0.1:user@example.com",
+		"login_failures:global",
+	} {
+		count, err := cacheProvider.GetInt64(key)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), count, key)
+	}
+}
+
+func TestAuthenticationUseCase_IncrementFailedAttemptsWhenNoPreviousAttempts(t *testing.T) {
+	assert := assert.New(t)
+	setLoginThrottleSettings(t, 5, 50, 20, 200)
+	window := time.Duration(settings.AppSettingsInstance.LoginAttemptsWindowMinutes) * time.Minute
 
-	testJWTProvider := new(authmocks.MockJWTProvider)
 	testHashProvider := new(providersmocks.MockHashProvider)
 	testPasswordRepository := new(authmocks.MockPasswordRepository)
 	testUserRepository := new(authmocks.MockUserRepository)
-	testOTPRepository := new(authmocks.MockOneTimePasswordRepository)
 	cacheProvider := new(providersmocks.MockCacheProvider)
 
-	uc := NewAuthenticateUseCase(testPasswordRepository, testUserRepository, testOTPRepository, testHashProvider, testJWTProvider, cacheProvider)
-
-	// Invalid credentials - debe incrementar el contador
-	userCredentials := dtos.UserCredentials{
-		Email:    "user@example.com",
-		Password: "wrongPassword",
-	}
-
-	// Primero verifica rate limit (no excedido)
-	existingAttempts := 2
-	cacheProvider.On("GetInt64", "login_attempts:user@example.com").Return(int64(existingAttempts), nil)
+	uc := NewAuthenticateUseCase(testPasswordRepository, testUserRepository, nil, testHashProvider, cacheProvider, nil)
 
 	passwordExpiresAt := time.Now().Add(30 * 24 * time.Hour)
 	passwordBase := passwordmodels.PasswordBase{
@@ -399,149 +278,146 @@ func TestAuthenticationUseCase_IncrementFailedAttempts(t *testing.T) {
 		PasswordBase: passwordBase,
 		ID:           uint(1),
 	}, nil)
-	testHashProvider.On("VerifyPassword", passwordBase.Hash, userCredentials.Password).Return(false, nil)
+	testHashProvider.On("VerifyPassword", passwordBase.Hash, "wrongPassword").Return(false, nil)
 	testUserRepository.On("GetUserWithRole", uint(1)).Return(&dtomocks.UserWithRole, nil)
 
-	// Debe incrementar el contador (2 -> 3)
-	cacheProvider.On("GetInt64", "login_attempts:user@example.com").Return(int64(existingAttempts), nil)
-	cacheProvider.On("Increment", "login_attempts:user@example.com", time.Duration(settings.AppSettingsInstance.LoginAttemptsWindowMinutes)*time.Minute).Return(int64(3), nil)
+	// No counter exists yet, the first failure creates each of them with the
+	// window of its layer
+	cacheProvider.On("GetInt64", mock.Anything).Return(int64(0), nil)
+	cacheProvider.On("Increment", "login_failures:global", time.Minute).Return(int64(1), nil).Once()
+	cacheProvider.On("Increment", "login_attempts:ip:10.0.0.1", window).Return(int64(1), nil).Once()
+	cacheProvider.On("Increment", "login_attempts:ip_account:10.0.0.1:user@example.com", window).Return(int64(1), nil).Once()
+	cacheProvider.On("Increment", "login_attempts:user@example.com", window).Return(int64(1), nil).Once()
 
-	result := uc.Execute(ctx, locales.EN_US, userCredentials)
+	result := loginFrom(uc, "10.0.0.1", "user@example.com", "wrongPassword")
 	assert.NotNil(result)
 	assert.False(result.IsSuccess())
 	assert.Equal(status.NotFound, result.StatusCode)
 	cacheProvider.AssertExpectations(t)
+	cacheProvider.AssertNotCalled(t, "Set", mock.Anything, mock.Anything, mock.Anything)
 }
 
-func TestAuthenticationUseCase_RateLimitWithNoCacheProvider(t *testing.T) {
-	assert := assert.New(t)
-	ctx := &app_context.AppContext{Context: context.Background()}
+func TestAuthenticationUseCase_LoginDelay(t *testing.T) {
+	setLoginThrottleSettings(t, 10, 0, 0, 0)
+	settings.AppSettingsInstance.LoginDelayAfterAttempts = 3
+	settings.AppSettingsInstance.LoginDelaySeconds = 2
+	cacheProvider := providersmocks.NewMemoryCacheProvider()
+	uc, _, _ := newThrottledTestUseCase(cacheProvider)
 
-	testJWTProvider := new(authmocks.MockJWTProvider)
-	testHashProvider := new(providersmocks.MockHashProvider)
-	testPasswordRepository := new(authmocks.MockPasswordRepository)
-	testUserRepository := new(authmocks.MockUserRepository)
-	testOTPRepository := new(authmocks.MockOneTimePasswordRepository)
+	for range 2 {
+		loginFrom(uc, "10.0.0.1", "user@example.com", "wrongPassword")
+	}
+	assert.NotContains(t, cacheProvider.Keys(), "login_delay:10.0.0.1:user@example.com")
 
-	// Cache provider es nil - no debe aplicar rate limiting
-	uc := NewAuthenticateUseCase(testPasswordRepository, testUserRepository, testOTPRepository, testHashProvider, testJWTProvider, nil)
+	loginFrom(uc, "10.0.0.1", "user@example.com", "wrongPassword")
+	assert.Contains(t, cacheProvider.Keys(), "login_delay:10.0.0.1:user@example.com")
 
-	userCredentials := dtos.UserCredentials{
-		Email:    "user@example.com",
-		Password: "plainPassword",
-	}
+	result := loginFrom(uc, "10.0.0.1", "user@example.com", "plainPassword")
+	assertLoginLimited(t, result, messages.MessageKeysInstance.LOGIN_DELAY_REQUIRED)
 
-	passwordExpiresAt := time.Now().Add(30 * 24 * time.Hour)
-	passwordBase := passwordmodels.PasswordBase{
-		UserID:    uint(1),
-		ExpiresAt: &passwordExpiresAt,
-		IsActive:  true,
-		Hash:      "hashedPassword123",
+	// The delay only applies to the client that failed
+	result = loginFrom(uc, "10.0.0.2", "user@example.com", "plainPassword")
+	assert.True(t, result.IsSuccess())
+}
+
+func TestAuthenticationUseCase_RateLimitPerIP(t *testing.T) {
+	setLoginThrottleSettings(t, 5, 3, 0, 0)
+	cacheProvider := providersmocks.NewMemoryCacheProvider()
+	uc, _, _ := newThrottledTestUseCase(cacheProvider)
+
+	// One password sprayed over many accounts
+	for _, email := range []string{"a@example.com", "b@example.com", "c@example.com"} {
+		loginFrom(uc, "10.0.0.1", email, "wrongPassword")
 	}
-	testPasswordRepository.On("GetActivePassword", "user@example.com").Return(&passwordmodels.Password{
-		PasswordBase: passwordBase,
-		ID:           uint(1),
-	}, nil)
-	testHashProvider.On("VerifyPassword", passwordBase.Hash, userCredentials.Password).Return(true, nil)
-	testJWTProvider.On("GenerateAccessToken", ctx, "1", mock.Anything).Return("accessToken", time.Now().Add(1*time.Hour), nil)
-	testJWTProvider.On("GenerateRefreshToken", ctx, "1").Return("refreshToken", time.Now().Add(24*time.Hour), nil)
-	testUserRepository.On("GetUserWithRole", uint(1)).Return(&dtomocks.UserWithRole, nil)
 
-	result := uc.Execute(ctx, locales.EN_US, userCredentials)
-	assert.NotNil(result)
-	assert.True(result.IsSuccess())
-	testPasswordRepository.AssertExpectations(t)
-	testHashProvider.AssertExpectations(t)
-	testJWTProvider.AssertExpectations(t)
+	result := loginFrom(uc, "10.0.0.1", "user@example.com", "plainPassword")
+	assertLoginLimited(t, result, messages.MessageKeysInstance.LoginMaxAttemptsExceeded)
+
+	result = loginFrom(uc, "10.0.0.2", "user@example.com", "plainPassword")
+	assert.True(t, result.IsSuccess())
 }
 
-func TestAuthenticationUseCase_IncrementFailedAttemptsWhenNoPreviousAttempts(t *testing.T) {
-	assert := assert.New(t)
-	ctx := &app_context.AppContext{Context: context.Background()}
+func TestAuthenticationUseCase_AccountLocked(t *testing.T) {
+	workers.ResetBackgroundExecutorSingleton()
+	services.ResetBackgroundServiceFactory()
+	workers.InitializeBackgroundExecutor(context.Background(), 2, 10)
+	defer workers.ResetBackgroundExecutorSingleton()
+	services.InitializeBackgroundServiceFactory()
+	defer services.ResetBackgroundServiceFactory()
 
-	// Guardar valores originales
-	originalMaxAttempts := settings.AppSettingsInstance.LoginMaxAttempts
-	originalWindowMinutes := settings.AppSettingsInstance.LoginAttemptsWindowMinutes
-	defer func() {
-		settings.AppSettingsInstance.LoginMaxAttempts = originalMaxAttempts
-		settings.AppSettingsInstance.LoginAttemptsWindowMinutes = originalWindowMinutes
-	}()
+	setLoginThrottleSettings(t, 5, 0, 3, 0)
+	cacheProvider := providersmocks.NewMemoryCacheProvider()
+	uc, testUserRepository, testTokenRepository := newThrottledTestUseCase(cacheProvider)
+
+	user := &usermodels.User{UserBase: dtomocks.UserBase, DBBaseModel: sharedmodels.DBBaseModel{ID: 1}}
+	testUserRepository.On("GetByEmailOrPhone", "user@example.com").Return(user, nil)
+	testTokenRepository.On("Create", mock.MatchedBy(func(create shareddtos.OneTimeTokenCreate) bool {
+		return create.UserID == 1 &&
+			create.Purpose == sharedmodels.OneTimeTokenPurposeAccountUnlock &&
+			string(create.Hash) == "token_hash"
+	})).Return(&sharedmodels.OneTimeToken{}, nil)
+
+	// Failures from several clients lock the account for every client
+	for _, ip := range []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"} {
+		loginFrom(uc, ip, "user@example.com", "wrongPassword")
+	}
 
-	// Configurar para el test
-	settings.AppSettingsInstance.LoginMaxAttempts = 5
-	settings.AppSettingsInstance.LoginAttemptsWindowMinutes = 15
+	result := loginFrom(uc, "10.0.0.4", "user@example.com", "plainPassword")
+	assertLoginLimited(t, result, messages.MessageKeysInstance.LOGIN_ACCOUNT_LOCKED)
 
-	testJWTProvider := new(authmocks.MockJWTProvider)
-	testHashProvider := new(providersmocks.MockHashProvider)
-	testPasswordRepository := new(authmocks.MockPasswordRepository)
-	testUserRepository := new(authmocks.MockUserRepository)
-	testOTPRepository := new(authmocks.MockOneTimePasswordRepository)
-	cacheProvider := new(providersmocks.MockCacheProvider)
+	// The user is alerted once, with the token to unlock the account
+	testTokenRepository.AssertNumberOfCalls(t, "Create", 1)
 
-	uc := NewAuthenticateUseCase(testPasswordRepository, testUserRepository, testOTPRepository, testHashProvider, testJWTProvider, cacheProvider)
+	// Wait for the background email service, it may fail due to the uninitialized email service
+	time.Sleep(100 * time.Millisecond)
+}
 
-	// Invalid credentials - debe crear e incrementar el contador desde 0
-	userCredentials := dtos.UserCredentials{
-		Email:    "user@example.com",
-		Password: "wrongPassword",
+func TestAuthenticationUseCase_CredentialStuffing(t *testing.T) {
+	setLoginThrottleSettings(t, 10, 4, 0, 3)
+	cacheProvider := providersmocks.NewMemoryCacheProvider()
+	uc, _, _ := newThrottledTestUseCase(cacheProvider)
+
+	// Failures of many clients reach the global limit
+	for _, ip := range []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"} {
+		loginFrom(uc, ip, "other@example.com", "wrongPassword")
 	}
+	loginFrom(uc, "10.0.0.4", "a@example.com", "wrongPassword")
+	loginFrom(uc, "10.0.0.4", "b@example.com", "wrongPassword")
 
-	// Primero verifica rate limit (no existe contador previo)
-	cacheProvider.On("GetInt64", "login_attempts:user@example.com").Return(int64(0), nil)
+	// The per IP limit is halved during the attack
+	result := loginFrom(uc, "10.0.0.4", "user@example.com", "plainPassword")
+	assertLoginLimited(t, result, messages.MessageKeysInstance.LoginMaxAttemptsExceeded)
+}
 
-	passwordExpiresAt := time.Now().Add(30 * 24 * time.Hour)
-	passwordBase := passwordmodels.PasswordBase{
-		UserID:    uint(1),
-		ExpiresAt: &passwordExpiresAt,
-		IsActive:  true,
-		Hash:      "hashedPassword123",
-	}
-	testPasswordRepository.On("GetActivePassword", "user@example.com").Return(&passwordmodels.Password{
-		PasswordBase: passwordBase,
-		ID:           uint(1),
-	}, nil)
-	testHashProvider.On("VerifyPassword", passwordBase.Hash, userCredentials.Password).Return(false, nil)
-	testUserRepository.On("GetUserWithRole", uint(1)).Return(&dtomocks.UserWithRole, nil)
+func TestAuthenticationUseCase_RateLimitWithMaxAttemptsZero(t *testing.T) {
+	setLoginThrottleSettings(t, 0, 0, 0, 0)
+	cacheProvider := providersmocks.NewMemoryCacheProvider()
+	uc, _, _ := newThrottledTestUseCase(cacheProvider)
 
-	// Debe crear el contador con valor 1 (desde 0)
-	cacheProvider.On("GetInt64", "login_attempts:user@example.com").Return(int64(0), nil)
-	cacheProvider.On("Increment", "login_attempts:user@example.com", time.Duration(settings.AppSettingsInstance.LoginAttemptsWindowMinutes)*time.Minute).Return(int64(1), nil)
+	for range 10 {
+		loginFrom(uc, "10.0.0.1", "user@example.com", "wrongPassword")
+	}
 
-	result := uc.Execute(ctx, locales.EN_US, userCredentials)
-	assert.NotNil(result)
-	assert.False(result.IsSuccess())
-	assert.Equal(status.NotFound, result.StatusCode)
-	cacheProvider.AssertExpectations(t)
+	result := loginFrom(uc, "10.0.0.1", "user@example.com", "plainPassword")
+	assert.True(t, result.IsSuccess())
 }
 
-func TestAuthenticationUseCase_RateLimitWithMaxAttemptsZero(t *testing.T) {
+func TestAuthenticationUseCase_RateLimitWithNoCacheProvider(t *testing.T) {
 	assert := assert.New(t)
 	ctx := &app_context.AppContext{Context: context.Background()}
 
-	// Guardar valores originales
-	originalMaxAttempts := settings.AppSettingsInstance.LoginMaxAttempts
-	defer func() {
-		settings.AppSettingsInstance.LoginMaxAttempts = originalMaxAttempts
-	}()
-
-	// Configurar max attempts a 0 - no debe aplicar rate limiting
-	settings.AppSettingsInstance.LoginMaxAttempts = 0
-
-	testJWTProvider := new(authmocks.MockJWTProvider)
 	testHashProvider := new(providersmocks.MockHashProvider)
 	testPasswordRepository := new(authmocks.MockPasswordRepository)
 	testUserRepository := new(authmocks.MockUserRepository)
-	testOTPRepository := new(authmocks.MockOneTimePasswordRepository)
-	cacheProvider := new(providersmocks.MockCacheProvider)
 
-	uc := NewAuthenticateUseCase(testPasswordRepository, testUserRepository, testOTPRepository, testHashProvider, testJWTProvider, cacheProvider)
+	// Cache provider es nil - no debe aplicar rate limiting
+	uc := NewAuthenticateUseCase(testPasswordRepository, testUserRepository, nil, testHashProvider, nil, nil)
 
 	userCredentials := dtos.UserCredentials{
 		Email:    "user@example.com",
 		Password: "plainPassword",
 	}
 
-	// No debe llamar a Get del cache porque maxAttempts es 0
 	passwordExpiresAt := time.Now().Add(30 * 24 * time.Hour)
 	passwordBase := passwordmodels.PasswordBase{
 		UserID:    uint(1),
@@ -554,19 +430,94 @@ func TestAuthenticationUseCase_RateLimitWithMaxAttemptsZero(t *testing.T) {
 		ID:           uint(1),
 	}, nil)
 	testHashProvider.On("VerifyPassword", passwordBase.Hash, userCredentials.Password).Return(true, nil)
-	testJWTProvider.On("GenerateAccessToken", ctx, "1", mock.Anything).Return("accessToken", time.Now().Add(1*time.Hour), nil)
-	testJWTProvider.On("GenerateRefreshToken", ctx, "1").Return("refreshToken", time.Now().Add(24*time.Hour), nil)
 	testUserRepository.On("GetUserWithRole", uint(1)).Return(&dtomocks.UserWithRole, nil)
-	// Cuando la autenticación es exitosa, se limpia el contador
-	cacheProvider.On("Delete", "login_attempts:user@example.com").Return(nil)
 
 	result := uc.Execute(ctx, locales.EN_US, userCredentials)
 	assert.NotNil(result)
 	assert.True(result.IsSuccess())
-	// No debe llamar a Get del cache porque maxAttempts es 0
-	cacheProvider.AssertNotCalled(t, "GetInt64", "login_attempts:user@example.com")
 	testPasswordRepository.AssertExpectations(t)
 	testHashProvider.AssertExpectations(t)
-	testJWTProvider.AssertExpectations(t)
-	cacheProvider.AssertExpectations(t)
+}
+
+func TestAuthenticationUseCase_TOTPEnabled(t *testing.T) {
+	originalMaxAttempts := settings.AppSettingsInstance.LoginMaxAttempts
+	originalWindowMinutes := settings.AppSettingsInstance.LoginAttemptsWindowMinutes
+	defer func() {
+		settings.AppSettingsInstance.LoginMaxAttempts = originalMaxAttempts
+		settings.AppSettingsInstance.LoginAttemptsWindowMinutes = originalWindowMinutes
+	}()
+	settings.AppSettingsInstance.LoginMaxAttempts = 5
+	settings.AppSettingsInstance.LoginAttemptsWindowMinutes = 15
+
+	cases := []struct {
+		name          string
+		code          string
+		totpValid     bool
+		recoveryValid bool
+		success       bool
+		statusCode    status.ApplicationStatusEnum
+	}{
+		{name: "missing code", code: "", statusCode: status.Unauthorized},
+		{name: "valid totp code", code: "123456", totpValid: true, success: true},
+		{name: "valid recovery code", code: "aaaaa-bbbbb", recoveryValid: true, success: true},
+		{name: "invalid code", code: "654321", statusCode: status.Unauthorized},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			ctx := &app_context.AppContext{Context: context.Background()}
+
+			testHashProvider := new(providersmocks.MockHashProvider)
+			testPasswordRepository := new(authmocks.MockPasswordRepository)
+			testUserRepository := new(authmocks.MockUserRepository)
+			cacheProvider := providersmocks.NewMemoryCacheProvider()
+			mfaService, mfaMocks := newMFATestService(testHashProvider)
+
+			uc := NewAuthenticateUseCase(testPasswordRepository, testUserRepository, nil, testHashProvider, cacheProvider, mfaService)
+
+			userCredentials := dtos.UserCredentials{
+				Email:    "user@example.com",
+				Password: "plainPassword",
+				TOTPCode: tc.code,
+			}
+			passwordExpiresAt := time.Now().Add(30 * 24 * time.Hour)
+			passwordBase := passwordmodels.PasswordBase{
+				UserID:    uint(1),
+				ExpiresAt: &passwordExpiresAt,
+				IsActive:  true,
+				Hash:      "hashedPassword123",
+			}
+			// The TOTP code replaces the email OTP
+			userWithOTP := dtomocks.UserWithRole
+			userWithOTP.OTPLogin = true
+
+			testPasswordRepository.On("GetActivePassword", "user@example.com").Return(&passwordmodels.Password{
+				PasswordBase: passwordBase,
+				ID:           uint(1),
+			}, nil)
+			testHashProvider.On("VerifyPassword", passwordBase.Hash, userCredentials.Password).Return(true, nil)
+			testUserRepository.On("GetUserWithRole", uint(1)).Return(&userWithOTP, nil)
+
+			mfaMocks.totpDeviceRepository.On("GetByUserID", uint(1)).Return(authmocks.TOTPDevice(), nil)
+			mfaMocks.totpProvider.On("ValidateCode", authmocks.TOTPSecret, tc.code, mock.Anything).Return(int64(100), tc.totpValid)
+			mfaMocks.totpDeviceRepository.On("UseStep", uint(1), int64(100)).Return(true, nil)
+			testHashProvider.On("HashOneTimeToken", mock.Anything).Return([]byte("recovery_hash"))
+			mfaMocks.recoveryCodeRepository.On("Use", uint(1), []byte("recovery_hash")).Return(tc.recoveryValid, nil)
+
+			result := uc.Execute(ctx, locales.EN_US, userCredentials)
+
+			assert.Equal(tc.success, result.IsSuccess())
+			if tc.success {
+				assert.True(result.Data.MFAVerified)
+				assert.False(result.Data.RequiresOTP())
+				return
+			}
+			assert.Equal(tc.statusCode, result.StatusCode)
+			if tc.code != "" {
+				count, _ := cacheProvider.GetInt64("login_attempts:user@example.com")
+				assert.Equal(int64(1), count)
+			}
+		})
+	}
 }
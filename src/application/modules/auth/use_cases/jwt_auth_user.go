@@ -6,23 +6,40 @@ import (
 	"strings"
 	"time"
 
+	contractsProviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	contracts_repositories "github.com/simon3640/goprojectskeleton/src/application/contracts/repositories"
 	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
 	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/services"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
 	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
 	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
 )
 
 // AuthUserUseCase is the use case for authenticating a user with a JWT token
+// or an API key, a Bearer prefix is ignored. Keys are told apart from tokens
+// by the API key prefix, a key authenticates its user restricted to its scopes.
+// The authenticated user carries the effective permissions of its role and
+// acts in the organization of the tenant claim, or in its default one.
+// Impersonation tokens authenticate their subject, the admin of the act claim
+// is carried as its impersonator. Tokens of the client_credentials grant
+// authenticate the OAuth client itself, restricted to its scopes.
 type AuthUserUseCase struct {
 	usecase.BaseUseCaseValidation[string, usermodels.UserWithRole]
 
-	userRepository authcontracts.IUserRepository
+	userRepository   authcontracts.IUserRepository
+	apiKeyRepository authcontracts.IAPIKeyRepository
 
-	jwtProvider authcontracts.IJWTProvider
+	jwtProvider        authcontracts.IJWTProvider
+	revocationProvider contractsProviders.ITokenRevocationProvider
+	hashProvider       contractsProviders.IHashProvider
+
+	permissionService *services.PermissionService
 }
 
 var _ usecase.BaseUseCase[string, usermodels.UserWithRole] = (*AuthUserUseCase)(nil)
@@ -34,31 +51,154 @@ func (uc *AuthUserUseCase) Execute(ctx *app_context.AppContext,
 	result := usecase.NewUseCaseResult[usermodels.UserWithRole]()
 	uc.SetLocale(locale)
 	uc.SetAppContext(ctx)
+	input = strings.TrimPrefix(input, "Bearer ")
+	if strings.HasPrefix(input, sharedmodels.APIKeyPrefix) {
+		return uc.authenticateAPIKey(result, input)
+	}
 	uc.validate(input, result)
 	if result.HasError() {
 		return result
 	}
 
-	sub := uc.parseTokenAndValidate(input, result)
+	claims, sub := uc.parseTokenAndValidate(input, result)
 	if result.HasError() {
 		return result
 	}
+	if strings.HasPrefix(*sub, authservices.OAuthClientSubjectPrefix) {
+		return uc.authenticateClient(result, claims, strings.TrimPrefix(*sub, authservices.OAuthClientSubjectPrefix))
+	}
 
 	userID := uc.convertSubjectToID(result, sub)
 	if result.HasError() {
 		return result
 	}
 
+	uc.checkRevocation(result, claims, userID)
+	if result.HasError() {
+		return result
+	}
+
 	user := uc.getUser(result, userID)
 	if result.HasError() {
 		return result
 	}
+	uc.setScopes(user, claims)
+	uc.setTenant(result, user, claims)
+	if result.HasError() {
+		return result
+	}
+	uc.setImpersonator(result, user, claims)
+	if result.HasError() {
+		return result
+	}
 
 	uc.setSuccessResult(result, user)
 	observability.GetObservabilityComponents().Logger.InfoWithContext("JWT token authenticated successfully", uc.AppContext)
 	return result
 }
 
+// authenticateAPIKey authenticates the active user of an active API key. Keys
+// are revoked along with the tokens of their user, e.g. by a logout of every
+// session or a suspension, and act in the default organization of their user
+// like the tokens without tenant claim. The use of the key is recorded,
+// errors recording it are logged.
+func (uc *AuthUserUseCase) authenticateAPIKey(result *usecase.UseCaseResult[usermodels.UserWithRole], key string) *usecase.UseCaseResult[usermodels.UserWithRole] {
+	if uc.apiKeyRepository == nil || uc.hashProvider == nil {
+		uc.setInvalidAPIKey(result)
+		return result
+	}
+	apiKey, err := uc.apiKeyRepository.GetByHash(uc.hashProvider.HashOneTimeToken(key))
+	if err != nil {
+		if err.Code != status.NotFound {
+			observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting API key by hash", err.ToError(), uc.AppContext)
+		}
+		uc.setInvalidAPIKey(result)
+		return result
+	}
+	if !apiKey.IsActive(time.Now()) {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Expired or revoked API key presented", uc.AppContext)
+		uc.setInvalidAPIKey(result)
+		return result
+	}
+
+	// The key is issued when it is created, as the iat of a token
+	uc.checkRevocation(result, authcontracts.JWTCLaims{"iat": float64(apiKey.CreatedAt.UnixMicro()) / 1e6}, apiKey.UserID)
+	if result.HasError() {
+		return result
+	}
+
+	user := uc.getUser(result, apiKey.UserID)
+	if result.HasError() {
+		return result
+	}
+	if user.Status == nil || *user.Status != usermodels.UserStatusActive {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("API key of a user that is not active presented", uc.AppContext)
+		result.SetError(
+			status.Unauthorized,
+			uc.AppMessages.Get(
+				uc.Locale,
+				messages.MessageKeysInstance.USER_NOT_ACTIVE,
+			),
+		)
+		return result
+	}
+	user.SetScopes(apiKey.Scopes)
+	uc.setTenant(result, user, nil)
+	if result.HasError() {
+		return result
+	}
+	if err := uc.apiKeyRepository.Touch(apiKey.ID); err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error recording API key use", err.ToError(), uc.AppContext)
+	}
+
+	uc.setSuccessResult(result, user)
+	observability.GetObservabilityComponents().Logger.InfoWithContext("API key authenticated successfully", uc.AppContext)
+	return result
+}
+
+// authenticateClient authenticates an OAuth client acting for itself. The
+// client is no user, it acts in the organization of the tenant claim, the
+// one of its owner when the token was issued.
+func (uc *AuthUserUseCase) authenticateClient(result *usecase.UseCaseResult[usermodels.UserWithRole], claims authcontracts.JWTCLaims, clientID string) *usecase.UseCaseResult[usermodels.UserWithRole] {
+	scope, _ := claims["scope"].(string)
+	if clientID == "" || claims["client_id"] != clientID {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Invalid client subject in token claims", uc.AppContext)
+		result.SetError(
+			status.Unauthorized,
+			uc.AppMessages.Get(
+				uc.Locale,
+				messages.MessageKeysInstance.AUTHORIZATION_HEADER_INVALID,
+			),
+		)
+		return result
+	}
+
+	uc.checkRevocation(result, claims, 0)
+	if result.HasError() {
+		return result
+	}
+
+	client := &usermodels.UserWithRole{}
+	client.SetClient(clientID, sharedmodels.ParseOAuthScope(scope))
+	if tenant, ok := claims["tenant"].(float64); ok {
+		client.SetTenant(uint(tenant))
+	}
+
+	uc.setSuccessResult(result, client)
+	observability.GetObservabilityComponents().Logger.InfoWithContext("OAuth client token authenticated successfully", uc.AppContext)
+	return result
+}
+
+func (uc *AuthUserUseCase) setInvalidAPIKey(result *usecase.UseCaseResult[usermodels.UserWithRole]) {
+	result.SetError(
+		status.Unauthorized,
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.INVALID_API_KEY,
+		),
+	)
+}
+
 func (uc *AuthUserUseCase) convertSubjectToID(result *usecase.UseCaseResult[usermodels.UserWithRole], sub *string) uint {
 	subInt, err := strconv.Atoi(*sub)
 	if err != nil {
@@ -74,6 +214,27 @@ func (uc *AuthUserUseCase) convertSubjectToID(result *usecase.UseCaseResult[user
 	return uint(subInt)
 }
 
+// checkRevocation rejects tokens that were revoked by their jti or by a
+// revocation of every token of the user. Revocation errors are logged and the
+// token is accepted, the cache must not lock every user out.
+func (uc *AuthUserUseCase) checkRevocation(result *usecase.UseCaseResult[usermodels.UserWithRole], claims authcontracts.JWTCLaims, userID uint) {
+	revoked, err := authservices.IsTokenRevokedService(claims, userID, uc.revocationProvider)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error checking token revocation, continuing with authentication", err.ToError(), uc.AppContext)
+		return
+	}
+	if revoked {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Revoked token presented", uc.AppContext)
+		result.SetError(
+			status.Unauthorized,
+			uc.AppMessages.Get(
+				uc.Locale,
+				messages.MessageKeysInstance.AUTHORIZATION_TOKEN_REVOKED,
+			),
+		)
+	}
+}
+
 func (uc *AuthUserUseCase) getUser(result *usecase.UseCaseResult[usermodels.UserWithRole], userID uint) *usermodels.UserWithRole {
 	user, appError := uc.userRepository.GetUserWithRole(userID)
 	if appError != nil {
@@ -87,9 +248,82 @@ func (uc *AuthUserUseCase) getUser(result *usecase.UseCaseResult[usermodels.User
 		)
 		return nil
 	}
+	if appError := uc.permissionService.SetUserPermissions(user); appError != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting user permissions", appError.ToError(), uc.AppContext)
+		result.SetError(
+			appError.Code,
+			uc.AppMessages.Get(
+				uc.Locale,
+				appError.Context,
+			),
+		)
+		return nil
+	}
 	return user
 }
 
+// setScopes limits the user to the scopes of tokens delegated to an OAuth
+// client, tokens of the login carry no scope claim
+func (uc *AuthUserUseCase) setScopes(user *usermodels.UserWithRole, claims authcontracts.JWTCLaims) {
+	if scope, ok := claims["scope"].(string); ok {
+		user.SetScopes(sharedmodels.ParseOAuthScope(scope))
+	}
+}
+
+// setTenant sets the organization the user acts in from the tenant claim.
+// Tokens without the claim, or issued without tenant before the user joined
+// an organization, keep the default organization of the user. Tokens of an
+// organization the user is no longer a member of are rejected.
+func (uc *AuthUserUseCase) setTenant(result *usecase.UseCaseResult[usermodels.UserWithRole], user *usermodels.UserWithRole, claims authcontracts.JWTCLaims) {
+	tenant, ok := claims["tenant"].(float64)
+	if !ok || (tenant == 0 && !user.CanAccessTenant(0)) {
+		return
+	}
+	tenantID := uint(tenant)
+	if !user.CanAccessTenant(tenantID) {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Token of an organization the user is not a member of presented", uc.AppContext)
+		result.SetError(
+			status.Unauthorized,
+			uc.AppMessages.Get(
+				uc.Locale,
+				messages.MessageKeysInstance.NOT_ORGANIZATION_MEMBER,
+			),
+		)
+		return
+	}
+	user.SetTenant(tenantID)
+}
+
+// setImpersonator marks the user as impersonated by the admin of the act
+// claim. The tokens of the admin revoked by a logout of every session are
+// revoked for their impersonations as well.
+func (uc *AuthUserUseCase) setImpersonator(result *usecase.UseCaseResult[usermodels.UserWithRole], user *usermodels.UserWithRole, claims authcontracts.JWTCLaims) {
+	act, ok := claims["act"]
+	if !ok {
+		return
+	}
+	actor, _ := act.(map[string]any)
+	sub, _ := actor["sub"].(string)
+	adminID, err := strconv.ParseUint(sub, 10, 64)
+	jti, _ := claims["jti"].(string)
+	if err != nil || adminID == 0 || jti == "" {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Invalid act claim in impersonation token", uc.AppContext)
+		result.SetError(
+			status.Unauthorized,
+			uc.AppMessages.Get(
+				uc.Locale,
+				messages.MessageKeysInstance.AUTHORIZATION_HEADER_INVALID,
+			),
+		)
+		return
+	}
+	uc.checkRevocation(result, claims, uint(adminID))
+	if result.HasError() {
+		return
+	}
+	user.SetImpersonator(uint(adminID), jti)
+}
+
 func (uc *AuthUserUseCase) setSuccessResult(result *usecase.UseCaseResult[usermodels.UserWithRole], user *usermodels.UserWithRole) {
 	result.SetData(
 		status.Success,
@@ -120,7 +354,7 @@ func (uc *AuthUserUseCase) validate(input string, result *usecase.UseCaseResult[
 	}
 }
 
-func (uc *AuthUserUseCase) parseTokenAndValidate(tokenString string, result *usecase.UseCaseResult[usermodels.UserWithRole]) *string {
+func (uc *AuthUserUseCase) parseTokenAndValidate(tokenString string, result *usecase.UseCaseResult[usermodels.UserWithRole]) (authcontracts.JWTCLaims, *string) {
 	claims, err := uc.jwtProvider.ParseTokenAndValidate(tokenString)
 	if err != nil {
 		observability.GetObservabilityComponents().Logger.ErrorWithContext("Failed to parse and validate token", err.ToError(), uc.AppContext)
@@ -131,7 +365,7 @@ func (uc *AuthUserUseCase) parseTokenAndValidate(tokenString string, result *use
 				err.Context,
 			),
 		)
-		return nil
+		return nil, nil
 	}
 
 	// Validate that is not refresh token
@@ -143,7 +377,7 @@ func (uc *AuthUserUseCase) parseTokenAndValidate(tokenString string, result *use
 				messages.MessageKeysInstance.AUTHORIZATION_HEADER_INVALID,
 			),
 		)
-		return nil
+		return nil, nil
 	}
 
 	if exp, ok := claims["exp"].(float64); !ok || exp < float64(time.Now().Unix()) {
@@ -155,7 +389,7 @@ func (uc *AuthUserUseCase) parseTokenAndValidate(tokenString string, result *use
 				messages.MessageKeysInstance.AUTHORIZATION_TOKEN_EXPIRED,
 			),
 		)
-		return nil
+		return nil, nil
 	}
 
 	// Extract subject from claims
@@ -169,22 +403,31 @@ func (uc *AuthUserUseCase) parseTokenAndValidate(tokenString string, result *use
 				messages.MessageKeysInstance.AUTHORIZATION_HEADER_INVALID,
 			),
 		)
-		return nil
+		return nil, nil
 	}
 
-	return &sub
+	return claims, &sub
 }
 
 func NewAuthUserUseCase(
 	userRepository authcontracts.IUserRepository,
 	jwtProvider authcontracts.IJWTProvider,
+	revocationProvider contractsProviders.ITokenRevocationProvider,
+	apiKeyRepository authcontracts.IAPIKeyRepository,
+	hashProvider contractsProviders.IHashProvider,
+	permissionRepository contracts_repositories.IPermissionRepository,
+	cacheProvider contractsProviders.ICacheProvider,
 ) *AuthUserUseCase {
 	return &AuthUserUseCase{
 		BaseUseCaseValidation: usecase.BaseUseCaseValidation[string, usermodels.UserWithRole]{
 			AppMessages: locales.NewLocale(locales.EN_US),
 			Guards:      usecase.NewGuards(),
 		},
-		userRepository: userRepository,
-		jwtProvider:    jwtProvider,
+		userRepository:     userRepository,
+		jwtProvider:        jwtProvider,
+		revocationProvider: revocationProvider,
+		apiKeyRepository:   apiKeyRepository,
+		hashProvider:       hashProvider,
+		permissionService:  services.NewPermissionService(permissionRepository, cacheProvider),
 	}
 }
@@ -10,11 +10,18 @@ import (
 	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
 	authmocks "github.com/simon3640/goprojectskeleton/src/application/modules/auth/mocks"
 	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
 	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
+	repositoriesmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/repositories"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestAuthUserCase(t *testing.T) {
@@ -22,10 +29,16 @@ func TestAuthUserCase(t *testing.T) {
 
 	testUserRepository := new(authmocks.MockUserRepository)
 	testJWTProvider := new(authmocks.MockJWTProvider)
+	testRevocationProvider := new(providersmocks.MockTokenRevocationProvider)
 
 	authUserUseCase := NewAuthUserUseCase(
 		testUserRepository,
 		testJWTProvider,
+		testRevocationProvider,
+		nil,
+		nil,
+		testPermissionRepository(),
+		nil,
 	)
 
 	validToken := "validToken.123"
@@ -33,9 +46,13 @@ func TestAuthUserCase(t *testing.T) {
 		"sub": "1",
 		"typ": "access",
 		"exp": float64(time.Now().Add(1 * time.Hour).Unix()),
+		"iat": float64(time.Now().Unix()),
+		"jti": "token-id",
 	}
 
 	testJWTProvider.On("ParseTokenAndValidate", validToken).Return(claimsReturn, nil)
+	testRevocationProvider.On("IsTokenRevoked", "token-id").Return(false, nil)
+	testRevocationProvider.On("UserTokensRevokedBefore", uint(1)).Return(time.Now().Add(-1*time.Hour), nil)
 	testUserRepository.On("GetUserWithRole", uint(1)).Return(&dtomocks.UserWithRole, nil)
 
 	result := authUserUseCase.Execute(&app_context.AppContext{Context: context.Background()}, locales.EN_US, validToken)
@@ -60,6 +77,11 @@ func TestAuthUserCase_InvalidToken(t *testing.T) {
 	authUserUseCase := NewAuthUserUseCase(
 		testUserRepository,
 		testJWTProvider,
+		nil,
+		nil,
+		nil,
+		testPermissionRepository(),
+		nil,
 	)
 
 	invalidToken := "invalidToken"
@@ -81,6 +103,11 @@ func TestAuthUserCase_ExpiredToken(t *testing.T) {
 	authUserUseCase := NewAuthUserUseCase(
 		testUserRepository,
 		testJWTProvider,
+		nil,
+		nil,
+		nil,
+		testPermissionRepository(),
+		nil,
 	)
 
 	expiredToken := "expiredToken.123"
@@ -109,6 +136,11 @@ func TestAuthUserCase_NoAccessToken(t *testing.T) {
 	authUserUseCase := NewAuthUserUseCase(
 		testUserRepository,
 		testJWTProvider,
+		nil,
+		nil,
+		nil,
+		testPermissionRepository(),
+		nil,
 	)
 
 	noAccessToken := "noAccessToken.123"
@@ -127,3 +159,711 @@ func TestAuthUserCase_NoAccessToken(t *testing.T) {
 	assert.Equal(status.Unauthorized, result.StatusCode)
 	assert.NotNil(result.Error)
 }
+
+func TestAuthUserCase_RevokedToken(t *testing.T) {
+	assert := assert.New(t)
+
+	issuedAt := time.Now().Add(-10 * time.Minute)
+	claimsReturn := authcontracts.JWTCLaims{
+		"sub": "1",
+		"typ": "access",
+		"exp": float64(time.Now().Add(1 * time.Hour).Unix()),
+		"iat": float64(issuedAt.Unix()),
+		"jti": "token-id",
+	}
+
+	tests := []struct {
+		name          string
+		revoked       bool
+		revokedBefore time.Time
+	}{
+		{name: "revoked token ID", revoked: true},
+		{name: "issued before the user tokens were revoked", revokedBefore: issuedAt.Add(time.Minute)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testUserRepository := new(authmocks.MockUserRepository)
+			testJWTProvider := new(authmocks.MockJWTProvider)
+			testRevocationProvider := new(providersmocks.MockTokenRevocationProvider)
+
+			testJWTProvider.On("ParseTokenAndValidate", "revokedToken.123").Return(claimsReturn, nil)
+			testRevocationProvider.On("IsTokenRevoked", "token-id").Return(tt.revoked, nil)
+			testRevocationProvider.On("UserTokensRevokedBefore", uint(1)).Return(tt.revokedBefore, nil)
+
+			uc := NewAuthUserUseCase(testUserRepository, testJWTProvider, testRevocationProvider, nil, nil, testPermissionRepository(), nil)
+			result := uc.Execute(&app_context.AppContext{Context: context.Background()}, locales.EN_US, "revokedToken.123")
+
+			assert.True(result.HasError())
+			assert.Equal(status.Unauthorized, result.StatusCode)
+			assert.Equal(uc.AppMessages.Get(locales.EN_US, messages.MessageKeysInstance.AUTHORIZATION_TOKEN_REVOKED), *result.Error)
+			testUserRepository.AssertNotCalled(t, "GetUserWithRole", uint(1))
+		})
+	}
+}
+
+func TestAuthUserCase_RevokedWithinTheSecond(t *testing.T) {
+	revokedAt := time.Unix(1700000000, 500_000_000)
+
+	tests := []struct {
+		name     string
+		issuedAt time.Time
+		revoked  bool
+	}{
+		{name: "issued before the revocation", issuedAt: revokedAt.Add(-300 * time.Millisecond), revoked: true},
+		{name: "issued at the revocation", issuedAt: revokedAt, revoked: true},
+		{name: "issued after the revocation", issuedAt: revokedAt.Add(300 * time.Millisecond)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			testUserRepository := new(authmocks.MockUserRepository)
+			testJWTProvider := new(authmocks.MockJWTProvider)
+			testRevocationProvider := new(providersmocks.MockTokenRevocationProvider)
+
+			// Every token is issued within the second of the revocation
+			testJWTProvider.On("ParseTokenAndValidate", "token.123").Return(authcontracts.JWTCLaims{
+				"sub": "1",
+				"typ": "access",
+				"exp": float64(time.Now().Add(1 * time.Hour).Unix()),
+				"iat": float64(tt.issuedAt.UnixMicro()) / 1e6,
+				"jti": "token-id",
+			}, nil)
+			testRevocationProvider.On("IsTokenRevoked", "token-id").Return(false, nil)
+			testRevocationProvider.On("UserTokensRevokedBefore", uint(1)).Return(revokedAt, nil)
+			testUserRepository.On("GetUserWithRole", uint(1)).Return(&dtomocks.UserWithRole, nil)
+
+			uc := NewAuthUserUseCase(testUserRepository, testJWTProvider, testRevocationProvider, nil, nil, testPermissionRepository(), nil)
+			result := uc.Execute(&app_context.AppContext{Context: context.Background()}, locales.EN_US, "token.123")
+
+			assert.Equal(tt.revoked, result.HasError())
+			if tt.revoked {
+				assert.Equal(status.Unauthorized, result.StatusCode)
+			}
+		})
+	}
+}
+
+func TestAuthUserCase_RevocationProviderError(t *testing.T) {
+	assert := assert.New(t)
+
+	testUserRepository := new(authmocks.MockUserRepository)
+	testJWTProvider := new(authmocks.MockJWTProvider)
+	testRevocationProvider := new(providersmocks.MockTokenRevocationProvider)
+
+	claimsReturn := authcontracts.JWTCLaims{
+		"sub": "1",
+		"typ": "access",
+		"exp": float64(time.Now().Add(1 * time.Hour).Unix()),
+		"iat": float64(time.Now().Unix()),
+		"jti": "token-id",
+	}
+	appErr := applicationerrors.NewApplicationError(
+		status.ProviderError,
+		messages.MessageKeysInstance.SOMETHING_WENT_WRONG,
+		"cache error",
+	)
+
+	testJWTProvider.On("ParseTokenAndValidate", "validToken.123").Return(claimsReturn, nil)
+	testRevocationProvider.On("IsTokenRevoked", "token-id").Return(false, appErr)
+	testUserRepository.On("GetUserWithRole", uint(1)).Return(&dtomocks.UserWithRole, nil)
+
+	uc := NewAuthUserUseCase(testUserRepository, testJWTProvider, testRevocationProvider, nil, nil, testPermissionRepository(), nil)
+	result := uc.Execute(&app_context.AppContext{Context: context.Background()}, locales.EN_US, "validToken.123")
+
+	// The cache being down must not lock every user out
+	assert.True(result.IsSuccess())
+}
+
+func TestAuthUserCase_DelegatedToken(t *testing.T) {
+	assert := assert.New(t)
+
+	testUserRepository := new(authmocks.MockUserRepository)
+	testJWTProvider := new(authmocks.MockJWTProvider)
+
+	authUserUseCase := NewAuthUserUseCase(
+		testUserRepository,
+		testJWTProvider,
+		nil,
+		nil,
+		nil,
+		testPermissionRepository(),
+		nil,
+	)
+
+	user := dtomocks.UserWithRole
+	claimsReturn := authcontracts.JWTCLaims{
+		"sub":       "1",
+		"typ":       "access",
+		"exp":       float64(time.Now().Add(1 * time.Hour).Unix()),
+		"scope":     "profile users:read",
+		"client_id": "client",
+	}
+
+	testJWTProvider.On("ParseTokenAndValidate", "delegatedToken.123").Return(claimsReturn, nil)
+	testUserRepository.On("GetUserWithRole", uint(1)).Return(&user, nil)
+
+	result := authUserUseCase.Execute(&app_context.AppContext{Context: context.Background()}, locales.EN_US, "delegatedToken.123")
+
+	assert.True(result.IsSuccess())
+	assert.True(result.Data.IsDelegated())
+	assert.True(result.Data.HasAnyScope("users:read"))
+	assert.False(result.Data.HasAnyScope("profile:write"))
+}
+
+func TestAuthUserCase_ClientToken(t *testing.T) {
+	assert := assert.New(t)
+
+	testUserRepository := new(authmocks.MockUserRepository)
+	testJWTProvider := new(authmocks.MockJWTProvider)
+	testRevocationProvider := new(providersmocks.MockTokenRevocationProvider)
+
+	authUserUseCase := NewAuthUserUseCase(
+		testUserRepository,
+		testJWTProvider,
+		testRevocationProvider,
+		nil,
+		nil,
+		testPermissionRepository(),
+		nil,
+	)
+
+	claimsReturn := authcontracts.JWTCLaims{
+		"sub":       "client:client",
+		"typ":       "access",
+		"exp":       float64(time.Now().Add(1 * time.Hour).Unix()),
+		"iat":       float64(time.Now().Unix()),
+		"jti":       "token-id",
+		"scope":     "users:read",
+		"client_id": "client",
+		"tenant":    float64(3),
+	}
+
+	testJWTProvider.On("ParseTokenAndValidate", "clientToken.123").Return(claimsReturn, nil)
+	testRevocationProvider.On("IsTokenRevoked", "token-id").Return(false, nil)
+	testRevocationProvider.On("UserTokensRevokedBefore", uint(0)).Return(time.Time{}, nil)
+
+	result := authUserUseCase.Execute(&app_context.AppContext{Context: context.Background()}, locales.EN_US, "clientToken.123")
+
+	assert.True(result.IsSuccess())
+	assert.True(result.Data.IsClient())
+	assert.Equal("client", result.Data.GetClientID())
+	assert.Equal(uint(0), result.Data.ID)
+	assert.True(result.Data.HasAnyScope("users:read"))
+	tenantID, scoped := result.Data.GetTenantID()
+	assert.True(scoped)
+	assert.Equal(uint(3), tenantID)
+	testUserRepository.AssertNotCalled(t, "GetUserWithRole", mock.Anything)
+
+	// The subject must be the client of the token
+	claimsReturn["client_id"] = "other"
+	testJWTProvider.On("ParseTokenAndValidate", "otherClientToken.123").Return(claimsReturn, nil)
+	result = authUserUseCase.Execute(&app_context.AppContext{Context: context.Background()}, locales.EN_US, "otherClientToken.123")
+	assert.True(result.HasError())
+	assert.Equal(status.Unauthorized, result.StatusCode)
+}
+
+func TestAuthUserCase_APIKey(t *testing.T) {
+	assert := assert.New(t)
+
+	testUserRepository := new(authmocks.MockUserRepository)
+	testJWTProvider := new(authmocks.MockJWTProvider)
+	testAPIKeyRepository := new(authmocks.MockAPIKeyRepository)
+	testHashProvider := new(providersmocks.MockHashProvider)
+
+	authUserUseCase := NewAuthUserUseCase(
+		testUserRepository,
+		testJWTProvider,
+		nil,
+		testAPIKeyRepository,
+		testHashProvider,
+		testPermissionRepository(),
+		nil,
+	)
+
+	user := dtomocks.UserWithRole
+	testHashProvider.On("HashOneTimeToken", authmocks.APIKeyValue).Return(authmocks.APIKeyHash)
+	testAPIKeyRepository.On("GetByHash", authmocks.APIKeyHash).Return(authmocks.APIKey(), nil)
+	testAPIKeyRepository.On("Touch", uint(1)).Return(nil)
+	testUserRepository.On("GetUserWithRole", uint(1)).Return(&user, nil)
+
+	result := authUserUseCase.Execute(&app_context.AppContext{Context: context.Background()}, locales.EN_US, "Bearer "+authmocks.APIKeyValue)
+
+	assert.True(result.IsSuccess())
+	assert.Equal(uint(1), result.Data.ID)
+	assert.True(result.Data.IsDelegated())
+	assert.True(result.Data.HasAnyScope("profile"))
+	testAPIKeyRepository.AssertCalled(t, "Touch", uint(1))
+	testJWTProvider.AssertNotCalled(t, "ParseTokenAndValidate", mock.Anything)
+}
+
+func TestAuthUserCase_InactiveAPIKey(t *testing.T) {
+	expired := authmocks.APIKey()
+	expired.ExpiresAt = time.Now().Add(-time.Minute)
+	revoked := authmocks.APIKey()
+	revokedAt := time.Now()
+	revoked.RevokedAt = &revokedAt
+
+	tests := []struct {
+		name   string
+		apiKey *sharedmodels.APIKey
+		err    *applicationerrors.ApplicationError
+	}{
+		{name: "unknown", err: notFoundError()},
+		{name: "expired", apiKey: expired},
+		{name: "revoked", apiKey: revoked},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+
+			testUserRepository := new(authmocks.MockUserRepository)
+			testAPIKeyRepository := new(authmocks.MockAPIKeyRepository)
+			testHashProvider := new(providersmocks.MockHashProvider)
+
+			authUserUseCase := NewAuthUserUseCase(
+				testUserRepository,
+				new(authmocks.MockJWTProvider),
+				nil,
+				testAPIKeyRepository,
+				testHashProvider,
+				testPermissionRepository(),
+				nil,
+			)
+
+			testHashProvider.On("HashOneTimeToken", authmocks.APIKeyValue).Return(authmocks.APIKeyHash)
+			if tt.err != nil {
+				testAPIKeyRepository.On("GetByHash", authmocks.APIKeyHash).Return(nil, tt.err)
+			} else {
+				testAPIKeyRepository.On("GetByHash", authmocks.APIKeyHash).Return(tt.apiKey, nil)
+			}
+
+			result := authUserUseCase.Execute(&app_context.AppContext{Context: context.Background()}, locales.EN_US, authmocks.APIKeyValue)
+
+			assert.True(result.HasError())
+			assert.Equal(status.Unauthorized, result.StatusCode)
+			testUserRepository.AssertNotCalled(t, "GetUserWithRole", mock.Anything)
+			testAPIKeyRepository.AssertNotCalled(t, "Touch", mock.Anything)
+		})
+	}
+}
+
+func TestAuthUserCase_APIKeyRevokedUserTokens(t *testing.T) {
+	assert := assert.New(t)
+
+	testUserRepository := new(authmocks.MockUserRepository)
+	testAPIKeyRepository := new(authmocks.MockAPIKeyRepository)
+	testHashProvider := new(providersmocks.MockHashProvider)
+	testRevocationProvider := new(providersmocks.MockTokenRevocationProvider)
+
+	authUserUseCase := NewAuthUserUseCase(
+		testUserRepository,
+		new(authmocks.MockJWTProvider),
+		testRevocationProvider,
+		testAPIKeyRepository,
+		testHashProvider,
+		testPermissionRepository(),
+		nil,
+	)
+
+	// Every token of the user was revoked after the key was created
+	apiKey := authmocks.APIKey()
+	testHashProvider.On("HashOneTimeToken", authmocks.APIKeyValue).Return(authmocks.APIKeyHash)
+	testAPIKeyRepository.On("GetByHash", authmocks.APIKeyHash).Return(apiKey, nil)
+	testRevocationProvider.On("UserTokensRevokedBefore", uint(1)).Return(apiKey.CreatedAt.Add(time.Minute), nil)
+
+	result := authUserUseCase.Execute(&app_context.AppContext{Context: context.Background()}, locales.EN_US, authmocks.APIKeyValue)
+
+	assert.True(result.HasError())
+	assert.Equal(status.Unauthorized, result.StatusCode)
+	assert.Equal(authUserUseCase.AppMessages.Get(locales.EN_US, messages.MessageKeysInstance.AUTHORIZATION_TOKEN_REVOKED), *result.Error)
+	testUserRepository.AssertNotCalled(t, "GetUserWithRole", mock.Anything)
+	testAPIKeyRepository.AssertNotCalled(t, "Touch", mock.Anything)
+}
+
+func TestAuthUserCase_APIKeyUserNotActive(t *testing.T) {
+	assert := assert.New(t)
+
+	testUserRepository := new(authmocks.MockUserRepository)
+	testAPIKeyRepository := new(authmocks.MockAPIKeyRepository)
+	testHashProvider := new(providersmocks.MockHashProvider)
+
+	authUserUseCase := NewAuthUserUseCase(
+		testUserRepository,
+		new(authmocks.MockJWTProvider),
+		nil,
+		testAPIKeyRepository,
+		testHashProvider,
+		testPermissionRepository(),
+		nil,
+	)
+
+	user := dtomocks.UserWithRole
+	suspended := usermodels.UserStatusSuspended
+	user.Status = &suspended
+	testHashProvider.On("HashOneTimeToken", authmocks.APIKeyValue).Return(authmocks.APIKeyHash)
+	testAPIKeyRepository.On("GetByHash", authmocks.APIKeyHash).Return(authmocks.APIKey(), nil)
+	testUserRepository.On("GetUserWithRole", uint(1)).Return(&user, nil)
+
+	result := authUserUseCase.Execute(&app_context.AppContext{Context: context.Background()}, locales.EN_US, authmocks.APIKeyValue)
+
+	assert.True(result.HasError())
+	assert.Equal(status.Unauthorized, result.StatusCode)
+	assert.Equal(authUserUseCase.AppMessages.Get(locales.EN_US, messages.MessageKeysInstance.USER_NOT_ACTIVE), *result.Error)
+	testAPIKeyRepository.AssertNotCalled(t, "Touch", mock.Anything)
+}
+
+func TestAuthUserCase_APIKeyTenant(t *testing.T) {
+	assert := assert.New(t)
+
+	testUserRepository := new(authmocks.MockUserRepository)
+	testAPIKeyRepository := new(authmocks.MockAPIKeyRepository)
+	testHashProvider := new(providersmocks.MockHashProvider)
+
+	authUserUseCase := NewAuthUserUseCase(
+		testUserRepository,
+		new(authmocks.MockJWTProvider),
+		nil,
+		testAPIKeyRepository,
+		testHashProvider,
+		testPermissionRepository(),
+		nil,
+	)
+
+	// The repository sets the default organization of the user as its tenant
+	user := dtomocks.UserWithRole
+	user.SetOrganizations([]uint{3, 4})
+	user.SetTenant(3)
+	testHashProvider.On("HashOneTimeToken", authmocks.APIKeyValue).Return(authmocks.APIKeyHash)
+	testAPIKeyRepository.On("GetByHash", authmocks.APIKeyHash).Return(authmocks.APIKey(), nil)
+	testAPIKeyRepository.On("Touch", uint(1)).Return(nil)
+	testUserRepository.On("GetUserWithRole", uint(1)).Return(&user, nil)
+
+	result := authUserUseCase.Execute(&app_context.AppContext{Context: context.Background()}, locales.EN_US, authmocks.APIKeyValue)
+
+	assert.True(result.IsSuccess())
+	tenantID, scoped := result.Data.GetTenantID()
+	assert.True(scoped)
+	assert.Equal(uint(3), tenantID)
+}
+
+func TestAuthUserCase_Permissions(t *testing.T) {
+	assert := assert.New(t)
+
+	testUserRepository := new(authmocks.MockUserRepository)
+	testJWTProvider := new(authmocks.MockJWTProvider)
+	testPermissionRepository := new(repositoriesmocks.MockPermissionRepository)
+
+	authUserUseCase := NewAuthUserUseCase(
+		testUserRepository,
+		testJWTProvider,
+		nil,
+		nil,
+		nil,
+		testPermissionRepository,
+		nil,
+	)
+
+	user := dtomocks.UserWithRole
+	claimsReturn := authcontracts.JWTCLaims{
+		"sub": "1",
+		"typ": "access",
+		"exp": float64(time.Now().Add(1 * time.Hour).Unix()),
+	}
+
+	testJWTProvider.On("ParseTokenAndValidate", "validToken.123").Return(claimsReturn, nil)
+	testUserRepository.On("GetUserWithRole", uint(1)).Return(&user, nil)
+	testPermissionRepository.On("GetRolePermissions", dtomocks.UserRole.ID).Return(dtomocks.UserRolePermissions, nil)
+	testPermissionRepository.On("GetUserPermissions", uint(1)).Return([]usermodels.UserPermission{
+		{UserID: 1, PermissionKey: usermodels.PermissionUserDelete, Granted: false},
+	}, nil)
+
+	result := authUserUseCase.Execute(&app_context.AppContext{Context: context.Background()}, locales.EN_US, "validToken.123")
+
+	assert.True(result.IsSuccess())
+	assert.True(result.Data.HasPermission(usermodels.PermissionUserRead))
+	assert.False(result.Data.HasPermission(usermodels.PermissionUserDelete))
+	assert.False(result.Data.HasPermission(usermodels.PermissionUserList))
+}
+
+func TestAuthUserCase_PermissionsError(t *testing.T) {
+	assert := assert.New(t)
+
+	testUserRepository := new(authmocks.MockUserRepository)
+	testJWTProvider := new(authmocks.MockJWTProvider)
+	testPermissionRepository := new(repositoriesmocks.MockPermissionRepository)
+
+	authUserUseCase := NewAuthUserUseCase(
+		testUserRepository,
+		testJWTProvider,
+		nil,
+		nil,
+		nil,
+		testPermissionRepository,
+		nil,
+	)
+
+	user := dtomocks.UserWithRole
+	claimsReturn := authcontracts.JWTCLaims{
+		"sub": "1",
+		"typ": "access",
+		"exp": float64(time.Now().Add(1 * time.Hour).Unix()),
+	}
+	appErr := applicationerrors.NewApplicationError(
+		status.InternalError,
+		messages.MessageKeysInstance.SOMETHING_WENT_WRONG,
+		"database error",
+	)
+
+	testJWTProvider.On("ParseTokenAndValidate", "validToken.123").Return(claimsReturn, nil)
+	testUserRepository.On("GetUserWithRole", uint(1)).Return(&user, nil)
+	testPermissionRepository.On("GetRolePermissions", dtomocks.UserRole.ID).Return(nil, appErr)
+
+	result := authUserUseCase.Execute(&app_context.AppContext{Context: context.Background()}, locales.EN_US, "validToken.123")
+
+	// Users are not authenticated without their permissions
+	assert.True(result.HasError())
+	assert.Equal(status.InternalError, result.StatusCode)
+}
+
+func TestAuthUserCase_TenantClaim(t *testing.T) {
+	assert := assert.New(t)
+
+	testUserRepository := new(authmocks.MockUserRepository)
+	testJWTProvider := new(authmocks.MockJWTProvider)
+
+	authUserUseCase := NewAuthUserUseCase(
+		testUserRepository,
+		testJWTProvider,
+		nil,
+		nil,
+		nil,
+		testPermissionRepository(),
+		nil,
+	)
+
+	user := dtomocks.UserWithRole
+	user.SetOrganizations([]uint{1, 2})
+	user.SetTenant(1)
+	claimsReturn := authcontracts.JWTCLaims{
+		"sub":    "1",
+		"typ":    "access",
+		"exp":    float64(time.Now().Add(1 * time.Hour).Unix()),
+		"tenant": float64(2),
+	}
+
+	testJWTProvider.On("ParseTokenAndValidate", "validToken.123").Return(claimsReturn, nil)
+	testUserRepository.On("GetUserWithRole", uint(1)).Return(&user, nil)
+
+	result := authUserUseCase.Execute(&app_context.AppContext{Context: context.Background()}, locales.EN_US, "validToken.123")
+
+	assert.True(result.IsSuccess())
+	tenantID, scoped := result.Data.GetTenantID()
+	assert.True(scoped)
+	assert.Equal(uint(2), tenantID)
+}
+
+func TestAuthUserCase_TenantClaimNotMember(t *testing.T) {
+	assert := assert.New(t)
+
+	testUserRepository := new(authmocks.MockUserRepository)
+	testJWTProvider := new(authmocks.MockJWTProvider)
+
+	authUserUseCase := NewAuthUserUseCase(
+		testUserRepository,
+		testJWTProvider,
+		nil,
+		nil,
+		nil,
+		testPermissionRepository(),
+		nil,
+	)
+
+	// The user was removed from organization 2 after the token was issued
+	user := dtomocks.UserWithRole
+	user.SetOrganizations([]uint{1})
+	user.SetTenant(1)
+	claimsReturn := authcontracts.JWTCLaims{
+		"sub":    "1",
+		"typ":    "access",
+		"exp":    float64(time.Now().Add(1 * time.Hour).Unix()),
+		"tenant": float64(2),
+	}
+
+	testJWTProvider.On("ParseTokenAndValidate", "validToken.123").Return(claimsReturn, nil)
+	testUserRepository.On("GetUserWithRole", uint(1)).Return(&user, nil)
+
+	result := authUserUseCase.Execute(&app_context.AppContext{Context: context.Background()}, locales.EN_US, "validToken.123")
+
+	assert.True(result.HasError())
+	assert.Equal(status.Unauthorized, result.StatusCode)
+}
+
+func TestAuthUserCase_TenantClaimNone(t *testing.T) {
+	assert := assert.New(t)
+
+	testUserRepository := new(authmocks.MockUserRepository)
+	testJWTProvider := new(authmocks.MockJWTProvider)
+
+	authUserUseCase := NewAuthUserUseCase(
+		testUserRepository,
+		testJWTProvider,
+		nil,
+		nil,
+		nil,
+		testPermissionRepository(),
+		nil,
+	)
+
+	// The token was issued before the user joined organization 3
+	user := dtomocks.UserWithRole
+	user.SetOrganizations([]uint{3})
+	user.SetTenant(3)
+	claimsReturn := authcontracts.JWTCLaims{
+		"sub":    "1",
+		"typ":    "access",
+		"exp":    float64(time.Now().Add(1 * time.Hour).Unix()),
+		"tenant": float64(0),
+	}
+
+	testJWTProvider.On("ParseTokenAndValidate", "validToken.123").Return(claimsReturn, nil)
+	testUserRepository.On("GetUserWithRole", uint(1)).Return(&user, nil)
+
+	result := authUserUseCase.Execute(&app_context.AppContext{Context: context.Background()}, locales.EN_US, "validToken.123")
+
+	assert.True(result.IsSuccess())
+	tenantID, scoped := result.Data.GetTenantID()
+	assert.True(scoped)
+	assert.Equal(uint(3), tenantID)
+}
+
+func TestAuthUserCase_ImpersonationToken(t *testing.T) {
+	assert := assert.New(t)
+
+	testUserRepository := new(authmocks.MockUserRepository)
+	testJWTProvider := new(authmocks.MockJWTProvider)
+	testRevocationProvider := new(providersmocks.MockTokenRevocationProvider)
+
+	authUserUseCase := NewAuthUserUseCase(
+		testUserRepository,
+		testJWTProvider,
+		testRevocationProvider,
+		nil,
+		nil,
+		testPermissionRepository(),
+		nil,
+	)
+
+	user := dtomocks.UserWithRole
+	claimsReturn := authcontracts.JWTCLaims{
+		"sub": "1",
+		"typ": "access",
+		"jti": authmocks.ImpersonationTokenID,
+		"iat": float64(time.Now().Unix()),
+		"exp": float64(time.Now().Add(15 * time.Minute).Unix()),
+		"act": map[string]any{"sub": "2"},
+	}
+
+	testJWTProvider.On("ParseTokenAndValidate", "validToken.123").Return(claimsReturn, nil)
+	testRevocationProvider.On("IsTokenRevoked", authmocks.ImpersonationTokenID).Return(false, nil)
+	testRevocationProvider.On("UserTokensRevokedBefore", mock.Anything).Return(time.Time{}, nil)
+	testUserRepository.On("GetUserWithRole", uint(1)).Return(&user, nil)
+
+	ctx := &app_context.AppContext{Context: context.Background()}
+	result := authUserUseCase.Execute(ctx, locales.EN_US, "validToken.123")
+
+	assert.True(result.IsSuccess())
+	assert.Equal(uint(1), result.Data.ID)
+	assert.True(result.Data.IsImpersonated())
+	assert.Equal(authmocks.ImpersonationTokenID, result.Data.GetImpersonationID())
+	ctx.AddUserToContext(result.Data)
+	adminID, impersonated := ctx.Impersonator()
+	assert.True(impersonated)
+	assert.Equal(uint(2), adminID)
+	// The admin logging out of every session ends its impersonations too
+	testRevocationProvider.AssertCalled(t, "UserTokensRevokedBefore", uint(2))
+}
+
+func TestAuthUserCase_ImpersonationTokenRevokedAdmin(t *testing.T) {
+	assert := assert.New(t)
+
+	testUserRepository := new(authmocks.MockUserRepository)
+	testJWTProvider := new(authmocks.MockJWTProvider)
+	testRevocationProvider := new(providersmocks.MockTokenRevocationProvider)
+
+	authUserUseCase := NewAuthUserUseCase(
+		testUserRepository,
+		testJWTProvider,
+		testRevocationProvider,
+		nil,
+		nil,
+		testPermissionRepository(),
+		nil,
+	)
+
+	user := dtomocks.UserWithRole
+	claimsReturn := authcontracts.JWTCLaims{
+		"sub": "1",
+		"typ": "access",
+		"jti": authmocks.ImpersonationTokenID,
+		"iat": float64(time.Now().Add(-time.Minute).Unix()),
+		"exp": float64(time.Now().Add(15 * time.Minute).Unix()),
+		"act": map[string]any{"sub": "2"},
+	}
+
+	testJWTProvider.On("ParseTokenAndValidate", "validToken.123").Return(claimsReturn, nil)
+	testRevocationProvider.On("IsTokenRevoked", authmocks.ImpersonationTokenID).Return(false, nil)
+	testRevocationProvider.On("UserTokensRevokedBefore", uint(1)).Return(time.Time{}, nil)
+	testRevocationProvider.On("UserTokensRevokedBefore", uint(2)).Return(time.Now(), nil)
+	testUserRepository.On("GetUserWithRole", uint(1)).Return(&user, nil)
+
+	result := authUserUseCase.Execute(&app_context.AppContext{Context: context.Background()}, locales.EN_US, "validToken.123")
+
+	assert.True(result.HasError())
+	assert.Equal(status.Unauthorized, result.StatusCode)
+}
+
+func TestAuthUserCase_InvalidActClaim(t *testing.T) {
+	assert := assert.New(t)
+
+	testUserRepository := new(authmocks.MockUserRepository)
+	testJWTProvider := new(authmocks.MockJWTProvider)
+
+	authUserUseCase := NewAuthUserUseCase(
+		testUserRepository,
+		testJWTProvider,
+		nil,
+		nil,
+		nil,
+		testPermissionRepository(),
+		nil,
+	)
+
+	user := dtomocks.UserWithRole
+	claimsReturn := authcontracts.JWTCLaims{
+		"sub": "1",
+		"typ": "access",
+		"jti": authmocks.ImpersonationTokenID,
+		"exp": float64(time.Now().Add(15 * time.Minute).Unix()),
+		"act": "2",
+	}
+
+	testJWTProvider.On("ParseTokenAndValidate", "validToken.123").Return(claimsReturn, nil)
+	testUserRepository.On("GetUserWithRole", uint(1)).Return(&user, nil)
+
+	result := authUserUseCase.Execute(&app_context.AppContext{Context: context.Background()}, locales.EN_US, "validToken.123")
+
+	assert.True(result.HasError())
+	assert.Equal(status.Unauthorized, result.StatusCode)
+}
+
+// testPermissionRepository returns a permission repository with the
+// permissions of the user role and no overrides
+func testPermissionRepository() *repositoriesmocks.MockPermissionRepository {
+	permissionRepository := new(repositoriesmocks.MockPermissionRepository)
+	permissionRepository.On("GetRolePermissions", mock.Anything).Return(dtomocks.UserRolePermissions, nil)
+	permissionRepository.On("GetUserPermissions", mock.Anything).Return([]usermodels.UserPermission{}, nil)
+	return permissionRepository
+}
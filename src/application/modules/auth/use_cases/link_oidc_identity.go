@@ -0,0 +1,173 @@
+package authusecases
+
+import (
+	"context"
+
+	contractproviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/guards"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// LinkOIDCIdentityUseCase is the use case for linking an identity provider
+// account to the authenticated user, so they can log in with the provider
+type LinkOIDCIdentityUseCase struct {
+	usecase.BaseUseCaseValidation[dtos.OIDCCallback, sharedmodels.ExternalIdentity]
+
+	identityRepo authcontracts.IExternalIdentityRepository
+
+	hashProvider   contractproviders.IHashProvider
+	oidcProvider   authcontracts.IOIDCProvider
+	sessionService *authservices.OIDCSessionService
+}
+
+var _ usecase.BaseUseCase[dtos.OIDCCallback, sharedmodels.ExternalIdentity] = (*LinkOIDCIdentityUseCase)(nil)
+
+// Execute links the identity provider account to the user
+// - Consume the authorization: the state can only be used once and must have been started by the user
+// - Exchange the code: the ID token must be valid and carry the nonce of the authorization
+// - Link the identity: an account of the provider can only be linked to one user
+func (uc *LinkOIDCIdentityUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input dtos.OIDCCallback,
+) *usecase.UseCaseResult[sharedmodels.ExternalIdentity] {
+	result := usecase.NewUseCaseResult[sharedmodels.ExternalIdentity]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	session := uc.consumeSession(result, input.State)
+	if result.HasError() {
+		return result
+	}
+	if session.UserID == 0 || session.UserID != input.UserID {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Identity provider authorization not started by the user", uc.AppContext)
+		uc.setError(result, status.Unauthorized, messages.MessageKeysInstance.OIDC_INVALID_STATE)
+		return result
+	}
+
+	claims := uc.exchangeCode(ctx, result, session, input.Code)
+	if result.HasError() {
+		return result
+	}
+
+	identity, err := uc.identityRepo.GetByProviderSubject(session.Provider, claims.Subject)
+	if err != nil && err.Code != status.NotFound {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting external identity", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return result
+	}
+	if identity != nil {
+		if identity.UserID != input.UserID {
+			observability.GetObservabilityComponents().Logger.WarningWithContext("Identity provider account linked to another user", uc.AppContext)
+			uc.setError(result, status.Conflict, messages.MessageKeysInstance.OIDC_IDENTITY_ALREADY_LINKED)
+			return result
+		}
+		result.SetData(
+			status.Success,
+			*identity,
+			uc.AppMessages.Get(
+				uc.Locale,
+				messages.MessageKeysInstance.OIDC_IDENTITY_LINKED,
+			),
+		)
+		return result
+	}
+
+	identity, err = uc.identityRepo.Create(dtos.ExternalIdentityCreate{
+		ExternalIdentityBase: sharedmodels.ExternalIdentityBase{
+			UserID:   input.UserID,
+			Provider: session.Provider,
+			Subject:  claims.Subject,
+			Email:    claims.Email,
+		},
+	})
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error creating external identity", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return result
+	}
+
+	result.SetData(
+		status.Created,
+		*identity,
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.OIDC_IDENTITY_LINKED,
+		),
+	)
+	observability.GetObservabilityComponents().Logger.InfoWithContext("Identity provider linked successfully", uc.AppContext)
+	return result
+}
+
+func (uc *LinkOIDCIdentityUseCase) consumeSession(result *usecase.UseCaseResult[sharedmodels.ExternalIdentity], state string) *dtos.OIDCSession {
+	stateHash := uc.hashProvider.HashOneTimeToken(state)
+	session, err := uc.sessionService.Consume(authservices.OIDCSessionKey(stateHash))
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error consuming identity provider authorization", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return nil
+	}
+	if session == nil {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("No pending identity provider authorization", uc.AppContext)
+		uc.setError(result, status.Unauthorized, messages.MessageKeysInstance.OIDC_INVALID_STATE)
+		return nil
+	}
+	return session
+}
+
+// exchangeCode exchanges the code for the claims of the ID token, the token
+// must carry the nonce of the authorization
+func (uc *LinkOIDCIdentityUseCase) exchangeCode(ctx context.Context, result *usecase.UseCaseResult[sharedmodels.ExternalIdentity], session *dtos.OIDCSession, code string) *dtos.OIDCClaims {
+	claims, err := uc.oidcProvider.Exchange(ctx, session.Provider, code, session.CodeVerifier)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Error exchanging identity provider code", uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return nil
+	}
+	if claims.Nonce != session.Nonce {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Identity provider ID token with another nonce", uc.AppContext)
+		uc.setError(result, status.Unauthorized, messages.MessageKeysInstance.OIDC_INVALID_TOKEN)
+		return nil
+	}
+	return claims
+}
+
+func (uc *LinkOIDCIdentityUseCase) setError(result *usecase.UseCaseResult[sharedmodels.ExternalIdentity], code status.ApplicationStatusEnum, key messages.MessageKeysEnum) {
+	result.SetError(
+		code,
+		uc.AppMessages.Get(
+			uc.Locale,
+			key,
+		),
+	)
+}
+
+func NewLinkOIDCIdentityUseCase(
+	identityRepo authcontracts.IExternalIdentityRepository,
+	hashProvider contractproviders.IHashProvider,
+	oidcProvider authcontracts.IOIDCProvider,
+	sessionService *authservices.OIDCSessionService,
+) *LinkOIDCIdentityUseCase {
+	return &LinkOIDCIdentityUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[dtos.OIDCCallback, sharedmodels.ExternalIdentity]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(guards.UserResourceGuard[dtos.OIDCCallback](), guards.NotImpersonatedGuard),
+		},
+		identityRepo:   identityRepo,
+		hashProvider:   hashProvider,
+		oidcProvider:   oidcProvider,
+		sessionService: sessionService,
+	}
+}
@@ -0,0 +1,114 @@
+package authusecases
+
+import (
+	"context"
+	"testing"
+
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authmocks "github.com/simon3640/goprojectskeleton/src/application/modules/auth/mocks"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var oidcLinkCallback = dtos.OIDCCallback{State: "state", Code: "code", UserID: 1}
+
+type linkOIDCTestMocks struct {
+	identityRepository *authmocks.MockExternalIdentityRepository
+	hashProvider       *providersmocks.MockHashProvider
+	oidcProvider       *authmocks.MockOIDCProvider
+	cache              *providersmocks.MockCacheProvider
+}
+
+func newLinkOIDCIdentityTestUseCase() (*LinkOIDCIdentityUseCase, *linkOIDCTestMocks) {
+	sessions, cache := newOIDCTestSessions()
+	mocks := &linkOIDCTestMocks{
+		identityRepository: new(authmocks.MockExternalIdentityRepository),
+		hashProvider:       new(providersmocks.MockHashProvider),
+		oidcProvider:       new(authmocks.MockOIDCProvider),
+		cache:              cache,
+	}
+	mocks.hashProvider.On("HashOneTimeToken", oidcLinkCallback.State).Return(oidcTestStateHash)
+	return NewLinkOIDCIdentityUseCase(
+		mocks.identityRepository,
+		mocks.hashProvider,
+		mocks.oidcProvider,
+		sessions,
+	), mocks
+}
+
+func TestLinkOIDCIdentityUseCase(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background(), User: &dtomocks.UserWithRole}
+	uc, mocks := newLinkOIDCIdentityTestUseCase()
+
+	mockPendingOIDCSession(mocks.cache, authservices.OIDCSessionKey(oidcTestStateHash), 1, 1)
+	mocks.oidcProvider.On("Exchange", ctx, "google", "code", "verifier").Return(oidcTestClaims(), nil)
+	mocks.identityRepository.On("GetByProviderSubject", "google", "subject").Return(nil, notFoundError())
+	mocks.identityRepository.On("Create", dtos.ExternalIdentityCreate{ExternalIdentityBase: oidcTestIdentity(1).ExternalIdentityBase}).Return(oidcTestIdentity(1), nil)
+
+	result := uc.Execute(ctx, locales.EN_US, oidcLinkCallback)
+
+	assert.True(result.IsSuccess())
+	assert.Equal(status.Created, result.StatusCode)
+	assert.Equal("subject", result.Data.Subject)
+	mocks.identityRepository.AssertExpectations(t)
+}
+
+func TestLinkOIDCIdentityUseCase_AlreadyLinkedToUser(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background(), User: &dtomocks.UserWithRole}
+	uc, mocks := newLinkOIDCIdentityTestUseCase()
+
+	mockPendingOIDCSession(mocks.cache, authservices.OIDCSessionKey(oidcTestStateHash), 1, 1)
+	mocks.oidcProvider.On("Exchange", ctx, "google", "code", "verifier").Return(oidcTestClaims(), nil)
+	mocks.identityRepository.On("GetByProviderSubject", "google", "subject").Return(oidcTestIdentity(1), nil)
+
+	result := uc.Execute(ctx, locales.EN_US, oidcLinkCallback)
+
+	assert.True(result.IsSuccess())
+	assert.Equal(status.Success, result.StatusCode)
+	mocks.identityRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestLinkOIDCIdentityUseCase_Errors(t *testing.T) {
+	cases := []struct {
+		name          string
+		input         dtos.OIDCCallback
+		sessionUserID uint
+		identity      *sharedmodels.ExternalIdentity
+		statusCode    status.ApplicationStatusEnum
+	}{
+		{name: "callback for another user", input: dtos.OIDCCallback{State: "state", Code: "code", UserID: 2}, sessionUserID: 2, statusCode: status.Unauthorized},
+		{name: "authorization of a login", input: oidcLinkCallback, sessionUserID: 0, statusCode: status.Unauthorized},
+		{name: "authorization of another user", input: oidcLinkCallback, sessionUserID: 2, statusCode: status.Unauthorized},
+		{name: "identity linked to another user", input: oidcLinkCallback, sessionUserID: 1, identity: oidcTestIdentity(2), statusCode: status.Conflict},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			ctx := &app_context.AppContext{Context: context.Background(), User: &dtomocks.UserWithRole}
+			uc, mocks := newLinkOIDCIdentityTestUseCase()
+
+			mockPendingOIDCSession(mocks.cache, authservices.OIDCSessionKey(oidcTestStateHash), tc.sessionUserID, 1)
+			mocks.oidcProvider.On("Exchange", ctx, "google", "code", "verifier").Return(oidcTestClaims(), nil)
+			if tc.identity != nil {
+				mocks.identityRepository.On("GetByProviderSubject", "google", "subject").Return(tc.identity, nil)
+			}
+
+			result := uc.Execute(ctx, locales.EN_US, tc.input)
+
+			assert.True(result.HasError())
+			assert.Equal(tc.statusCode, result.StatusCode)
+			mocks.identityRepository.AssertNotCalled(t, "Create", mock.Anything)
+		})
+	}
+}
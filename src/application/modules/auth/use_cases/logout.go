@@ -0,0 +1,168 @@
+package authusecases
+
+import (
+	contractproviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/guards"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/services"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+)
+
+// LogoutUseCase is the use case for closing the session of a refresh token
+type LogoutUseCase struct {
+	usecase.BaseUseCaseValidation[string, bool]
+
+	refreshTokenRepo authcontracts.IRefreshTokenRepository
+	hashProvider     contractproviders.IHashProvider
+}
+
+var _ usecase.BaseUseCase[string, bool] = (*LogoutUseCase)(nil)
+
+// Execute revokes every refresh token of the session the input token belongs to
+func (uc *LogoutUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input string,
+) *usecase.UseCaseResult[bool] {
+	result := usecase.NewUseCaseResult[bool]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	if input == "" {
+		result.SetError(
+			status.InvalidInput,
+			uc.AppMessages.Get(
+				uc.Locale,
+				messages.MessageKeysInstance.SOME_PARAMETERS_ARE_MISSING,
+			),
+		)
+		return result
+	}
+
+	session, err := uc.refreshTokenRepo.GetByTokenHash(uc.hashProvider.HashOneTimeToken(input))
+	if err != nil && err.Code != status.NotFound {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting refresh token by hash", err.ToError(), uc.AppContext)
+		result.SetError(
+			err.Code,
+			uc.AppMessages.Get(
+				uc.Locale,
+				err.Context,
+			),
+		)
+		return result
+	}
+	if session == nil {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Refresh token not found", uc.AppContext)
+		result.SetError(
+			status.Unauthorized,
+			uc.AppMessages.Get(
+				uc.Locale,
+				messages.MessageKeysInstance.INVALID_SESSION,
+			),
+		)
+		return result
+	}
+
+	if err := uc.refreshTokenRepo.RevokeFamily(session.FamilyID); err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error revoking refresh token family", err.ToError(), uc.AppContext)
+		result.SetError(
+			err.Code,
+			uc.AppMessages.Get(
+				uc.Locale,
+				err.Context,
+			),
+		)
+		return result
+	}
+
+	result.SetData(
+		status.Success,
+		true,
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.LOGOUT_SUCCESS,
+		),
+	)
+	observability.GetObservabilityComponents().Logger.InfoWithContext("Session closed successfully", uc.AppContext)
+	return result
+}
+
+func NewLogoutUseCase(
+	refreshTokenRepo authcontracts.IRefreshTokenRepository,
+	hashProvider contractproviders.IHashProvider,
+) *LogoutUseCase {
+	return &LogoutUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[string, bool]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(),
+		},
+		refreshTokenRepo: refreshTokenRepo,
+		hashProvider:     hashProvider,
+	}
+}
+
+// LogoutAllUseCase is the use case for closing every session of a user,
+// the access tokens already issued to the user are revoked as well
+type LogoutAllUseCase struct {
+	usecase.BaseUseCaseValidation[uint, bool]
+
+	refreshTokenRepo   authcontracts.IRefreshTokenRepository
+	revocationProvider contractproviders.ITokenRevocationProvider
+}
+
+var _ usecase.BaseUseCase[uint, bool] = (*LogoutAllUseCase)(nil)
+
+// Execute revokes every refresh token of the user whose ID is the input
+func (uc *LogoutAllUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input uint,
+) *usecase.UseCaseResult[bool] {
+	result := usecase.NewUseCaseResult[bool]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	if err := uc.refreshTokenRepo.RevokeAllByUser(input); err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error revoking refresh tokens of user", err.ToError(), uc.AppContext)
+		result.SetError(
+			err.Code,
+			uc.AppMessages.Get(
+				uc.Locale,
+				err.Context,
+			),
+		)
+		return result
+	}
+	services.RevokeUserTokensService(uc.AppContext, input, uc.revocationProvider)
+
+	result.SetData(
+		status.Success,
+		true,
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.LOGOUT_ALL_SUCCESS,
+		),
+	)
+	observability.GetObservabilityComponents().Logger.InfoWithContext("All sessions closed successfully", uc.AppContext)
+	return result
+}
+
+func NewLogoutAllUseCase(
+	refreshTokenRepo authcontracts.IRefreshTokenRepository,
+	revocationProvider contractproviders.ITokenRevocationProvider,
+) *LogoutAllUseCase {
+	return &LogoutAllUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[uint, bool]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(guards.UserGetItSelf, guards.NotImpersonatedGuard),
+		},
+		refreshTokenRepo:   refreshTokenRepo,
+		revocationProvider: revocationProvider,
+	}
+}
@@ -0,0 +1,88 @@
+package authusecases
+
+import (
+	"context"
+	"testing"
+
+	authmocks "github.com/simon3640/goprojectskeleton/src/application/modules/auth/mocks"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestLogoutUseCase(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
+
+	testHashProvider := new(providersmocks.MockHashProvider)
+	testRefreshTokenRepository := new(authmocks.MockRefreshTokenRepository)
+	uc := NewLogoutUseCase(testRefreshTokenRepository, testHashProvider)
+
+	session := authmocks.RefreshToken()
+	testHashProvider.On("HashOneTimeToken", validRefreshToken).Return(authmocks.RefreshTokenHash)
+	testRefreshTokenRepository.On("GetByTokenHash", authmocks.RefreshTokenHash).Return(session, nil)
+	testRefreshTokenRepository.On("RevokeFamily", session.FamilyID).Return(nil)
+
+	result := uc.Execute(ctx, locales.EN_US, validRefreshToken)
+
+	assert.True(result.IsSuccess())
+	assert.True(*result.Data)
+	testRefreshTokenRepository.AssertCalled(t, "RevokeFamily", session.FamilyID)
+}
+
+func TestLogoutUseCase_UnknownToken(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
+
+	testHashProvider := new(providersmocks.MockHashProvider)
+	testRefreshTokenRepository := new(authmocks.MockRefreshTokenRepository)
+	uc := NewLogoutUseCase(testRefreshTokenRepository, testHashProvider)
+
+	testHashProvider.On("HashOneTimeToken", validRefreshToken).Return(authmocks.RefreshTokenHash)
+	testRefreshTokenRepository.On("GetByTokenHash", authmocks.RefreshTokenHash).Return(nil, applicationerrors.NewApplicationError(
+		status.NotFound,
+		messages.MessageKeysInstance.RESOURCE_NOT_FOUND,
+		"Resource not found",
+	))
+
+	result := uc.Execute(ctx, locales.EN_US, validRefreshToken)
+	assert.True(result.HasError())
+	assert.Equal(status.Unauthorized, result.StatusCode)
+
+	result = uc.Execute(ctx, locales.EN_US, "")
+	assert.True(result.HasError())
+	assert.Equal(status.InvalidInput, result.StatusCode)
+	testRefreshTokenRepository.AssertNotCalled(t, "RevokeFamily", mock.Anything)
+}
+
+func TestLogoutAllUseCase(t *testing.T) {
+	assert := assert.New(t)
+	ctx := app_context.NewContextWithUser(&usermodels.UserWithRole{ID: 1})
+
+	testRefreshTokenRepository := new(authmocks.MockRefreshTokenRepository)
+	testRevocationProvider := new(providersmocks.MockTokenRevocationProvider)
+	uc := NewLogoutAllUseCase(testRefreshTokenRepository, testRevocationProvider)
+
+	testRefreshTokenRepository.On("RevokeAllByUser", uint(1)).Return(nil)
+	testRevocationProvider.On("RevokeUserTokens", uint(1)).Return(nil)
+
+	result := uc.Execute(ctx, locales.EN_US, 1)
+	assert.True(result.IsSuccess())
+	testRefreshTokenRepository.AssertCalled(t, "RevokeAllByUser", uint(1))
+	// The access tokens already issued are revoked as well
+	testRevocationProvider.AssertCalled(t, "RevokeUserTokens", uint(1))
+
+	// Users can only close their own sessions
+	result = uc.Execute(ctx, locales.EN_US, 2)
+	assert.True(result.HasError())
+	assert.Equal(status.Unauthorized, result.StatusCode)
+	testRefreshTokenRepository.AssertNotCalled(t, "RevokeAllByUser", uint(2))
+	testRevocationProvider.AssertNotCalled(t, "RevokeUserTokens", uint(2))
+}
@@ -0,0 +1,339 @@
+package authusecases
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	contractproviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
+
+// OAuthTokenUseCase is the token endpoint of the OAuth2 authorization server.
+// The access tokens are JWT signed like the ones of the login, they carry
+// the granted scope and the client, so they only reach the use cases
+// guarded by one of the scopes. Refresh tokens are rotated like the ones of
+// the login, inside a session bound to the client.
+type OAuthTokenUseCase struct {
+	usecase.BaseUseCaseValidation[dtos.OAuthTokenRequest, dtos.OAuthTokenResponse]
+
+	clientRepo       authcontracts.IOAuthClientRepository
+	userRepo         authcontracts.IUserRepository
+	refreshTokenRepo authcontracts.IRefreshTokenRepository
+
+	jwtProvider        authcontracts.IJWTProvider
+	hashProvider       contractproviders.IHashProvider
+	revocationProvider contractproviders.ITokenRevocationProvider
+	codeService        *authservices.OAuthCodeService
+}
+
+var _ usecase.BaseUseCase[dtos.OAuthTokenRequest, dtos.OAuthTokenResponse] = (*OAuthTokenUseCase)(nil)
+
+// Execute issues the tokens of the grant
+// - Authenticate the client: confidential clients must send their secret
+// - Check the grant type: the client must be registered for it
+// - authorization_code: the code is consumed once and must match the client, the redirect URI and the PKCE verifier
+// - refresh_token: the token is rotated, it must belong to the client, not be revoked with every token of the user and the scope can only be narrowed
+// - client_credentials: the client gets an access token for itself with its registered scopes, without refresh token
+func (uc *OAuthTokenUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input dtos.OAuthTokenRequest,
+) *usecase.UseCaseResult[dtos.OAuthTokenResponse] {
+	result := usecase.NewUseCaseResult[dtos.OAuthTokenResponse]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	client, err := authservices.AuthenticateOAuthClientService(input.OAuthClientCredentials, uc.clientRepo, uc.hashProvider)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("OAuth client authentication failed", uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return result
+	}
+	if !client.AllowsGrantType(input.GrantType) {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("OAuth client not registered for the grant type", uc.AppContext)
+		uc.setError(result, status.InvalidInput, messages.MessageKeysInstance.OAUTH_UNAUTHORIZED_GRANT_TYPE)
+		return result
+	}
+
+	var token dtos.OAuthTokenResponse
+	switch input.GrantType {
+	case sharedmodels.OAuthGrantAuthorizationCode:
+		token = uc.exchangeCode(ctx, result, client, input)
+	case sharedmodels.OAuthGrantRefreshToken:
+		token = uc.refresh(ctx, result, client, input)
+	case sharedmodels.OAuthGrantClientCredentials:
+		token = uc.clientCredentials(ctx, result, client, input)
+	}
+	if result.HasError() {
+		return result
+	}
+
+	result.SetData(
+		status.Success,
+		token,
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.OAUTH_TOKEN_ISSUED,
+		),
+	)
+	observability.GetObservabilityComponents().Logger.InfoWithContext("OAuth token issued successfully", uc.AppContext)
+	return result
+}
+
+// exchangeCode exchanges an authorization code for the tokens of the user
+func (uc *OAuthTokenUseCase) exchangeCode(ctx context.Context, result *usecase.UseCaseResult[dtos.OAuthTokenResponse], client *sharedmodels.OAuthClient, input dtos.OAuthTokenRequest) dtos.OAuthTokenResponse {
+	code, err := uc.codeService.Consume(authservices.OAuthCodeKey(uc.hashProvider.HashOneTimeToken(input.Code)))
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error consuming OAuth authorization code", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return dtos.OAuthTokenResponse{}
+	}
+	if code == nil || code.ClientID != client.ClientID || code.RedirectURI != input.RedirectURI {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Invalid OAuth authorization code", uc.AppContext)
+		uc.setError(result, status.InvalidInput, messages.MessageKeysInstance.OAUTH_INVALID_GRANT)
+		return dtos.OAuthTokenResponse{}
+	}
+	if !authservices.VerifyPKCEService(code.CodeChallenge, input.CodeVerifier) {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("OAuth code verifier does not match the challenge", uc.AppContext)
+		uc.setError(result, status.InvalidInput, messages.MessageKeysInstance.OAUTH_INVALID_GRANT)
+		return dtos.OAuthTokenResponse{}
+	}
+
+	user := uc.getActiveUser(result, code.UserID)
+	if result.HasError() {
+		return dtos.OAuthTokenResponse{}
+	}
+	return uc.generateTokens(ctx, result, client, user, "", code.Scope)
+}
+
+// refresh rotates a refresh token of the client. A token that was already
+// rotated is being reused, and a token issued before every token of the user
+// was revoked, e.g. by a password change or a suspension, must not outlive
+// the revocation: the whole session is revoked.
+func (uc *OAuthTokenUseCase) refresh(ctx context.Context, result *usecase.UseCaseResult[dtos.OAuthTokenResponse], client *sharedmodels.OAuthClient, input dtos.OAuthTokenRequest) dtos.OAuthTokenResponse {
+	claims, err := uc.jwtProvider.ParseTokenAndValidate(input.RefreshToken)
+	if err != nil || claims["typ"] != "refresh" {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Invalid OAuth refresh token", uc.AppContext)
+		uc.setError(result, status.InvalidInput, messages.MessageKeysInstance.OAUTH_INVALID_GRANT)
+		return dtos.OAuthTokenResponse{}
+	}
+
+	session, err := uc.refreshTokenRepo.GetByTokenHash(uc.hashProvider.HashOneTimeToken(input.RefreshToken))
+	if err != nil && err.Code != status.NotFound {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting refresh token by hash", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return dtos.OAuthTokenResponse{}
+	}
+	if session == nil || session.ClientID != client.ClientID || session.IsRevoked || session.Expires.Before(time.Now()) {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("OAuth refresh token not found, of another client, revoked or expired", uc.AppContext)
+		uc.setError(result, status.InvalidInput, messages.MessageKeysInstance.OAUTH_INVALID_GRANT)
+		return dtos.OAuthTokenResponse{}
+	}
+
+	uc.checkRevocation(result, claims, session)
+	if result.HasError() {
+		return dtos.OAuthTokenResponse{}
+	}
+
+	scope := session.Scope
+	if input.Scope != "" {
+		granted := sharedmodels.ParseOAuthScope(session.Scope)
+		for _, requested := range sharedmodels.ParseOAuthScope(input.Scope) {
+			if !slices.Contains(granted, requested) {
+				observability.GetObservabilityComponents().Logger.WarningWithContext("OAuth refresh requested scopes that were not granted", uc.AppContext)
+				uc.setError(result, status.InvalidInput, messages.MessageKeysInstance.OAUTH_INVALID_SCOPE)
+				return dtos.OAuthTokenResponse{}
+			}
+		}
+		scope = input.Scope
+	}
+
+	rotated := false
+	if !session.IsRotated {
+		rotated, err = uc.refreshTokenRepo.Rotate(session.ID)
+		if err != nil {
+			observability.GetObservabilityComponents().Logger.ErrorWithContext("Error rotating refresh token", err.ToError(), uc.AppContext)
+			uc.setError(result, err.Code, err.Context)
+			return dtos.OAuthTokenResponse{}
+		}
+	}
+	if !rotated {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("OAuth refresh token reuse detected, revoking session", uc.AppContext)
+		if err := uc.refreshTokenRepo.RevokeFamily(session.FamilyID); err != nil {
+			observability.GetObservabilityComponents().Logger.ErrorWithContext("Error revoking refresh token family", err.ToError(), uc.AppContext)
+		}
+		uc.setError(result, status.InvalidInput, messages.MessageKeysInstance.OAUTH_INVALID_GRANT)
+		return dtos.OAuthTokenResponse{}
+	}
+
+	user := uc.getActiveUser(result, session.UserID)
+	if result.HasError() {
+		return dtos.OAuthTokenResponse{}
+	}
+	return uc.generateTokens(ctx, result, client, user, session.FamilyID, scope)
+}
+
+// clientCredentials issues an access token for the client itself, its
+// subject is the client and not a user. The token only carries scopes the
+// client was registered for and acts in the organization of the owner of the
+// client, clients of owners that are no longer active get no token.
+func (uc *OAuthTokenUseCase) clientCredentials(ctx context.Context, result *usecase.UseCaseResult[dtos.OAuthTokenResponse], client *sharedmodels.OAuthClient, input dtos.OAuthTokenRequest) dtos.OAuthTokenResponse {
+	scopes := sharedmodels.ParseOAuthScope(input.Scope)
+	if len(scopes) == 0 {
+		scopes = client.Scopes
+	}
+	if !client.AllowsScopes(scopes) {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("OAuth client requested scopes it is not registered for", uc.AppContext)
+		uc.setError(result, status.InvalidInput, messages.MessageKeysInstance.OAUTH_INVALID_SCOPE)
+		return dtos.OAuthTokenResponse{}
+	}
+	scope := sharedmodels.JoinOAuthScope(scopes)
+
+	owner := uc.getActiveUser(result, client.OwnerID)
+	if result.HasError() {
+		return dtos.OAuthTokenResponse{}
+	}
+	tenantID, _ := owner.GetTenantID()
+
+	access, exp, err := uc.jwtProvider.GenerateAccessToken(ctx, authservices.OAuthClientSubjectPrefix+client.ClientID, authcontracts.JWTCLaims{
+		"scope":     scope,
+		"client_id": client.ClientID,
+		"tenant":    tenantID,
+	})
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error generating access token", err.ToError(), uc.AppContext)
+		uc.setError(result, status.InternalError, messages.MessageKeysInstance.SOMETHING_WENT_WRONG)
+		return dtos.OAuthTokenResponse{}
+	}
+	return dtos.OAuthTokenResponse{
+		AccessToken: access,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(time.Until(exp).Seconds()),
+		Scope:       scope,
+	}
+}
+
+// checkRevocation rejects refresh tokens issued before every token of the
+// user was revoked and revokes their session, like the refresh of the login.
+// Revocation errors are logged and the refresh continues.
+func (uc *OAuthTokenUseCase) checkRevocation(result *usecase.UseCaseResult[dtos.OAuthTokenResponse], claims authcontracts.JWTCLaims, session *sharedmodels.RefreshToken) {
+	revoked, err := authservices.IsTokenRevokedService(claims, session.UserID, uc.revocationProvider)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error checking token revocation, continuing with refresh", err.ToError(), uc.AppContext)
+		return
+	}
+	if !revoked {
+		return
+	}
+	observability.GetObservabilityComponents().Logger.WarningWithContext("Revoked OAuth refresh token presented, revoking session", uc.AppContext)
+	if err := uc.refreshTokenRepo.RevokeFamily(session.FamilyID); err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error revoking refresh token family", err.ToError(), uc.AppContext)
+	}
+	uc.setError(result, status.InvalidInput, messages.MessageKeysInstance.OAUTH_INVALID_GRANT)
+}
+
+// getActiveUser returns the user the client acts for, users that are no
+// longer active can not authorize clients
+func (uc *OAuthTokenUseCase) getActiveUser(result *usecase.UseCaseResult[dtos.OAuthTokenResponse], userID uint) *usermodels.UserWithRole {
+	user, err := uc.userRepo.GetUserWithRole(userID)
+	if err != nil && err.Code != status.NotFound {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting user with role", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return nil
+	}
+	if user == nil || user.Status == nil || *user.Status != usermodels.UserStatusActive {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("OAuth grant of a user that is not active", uc.AppContext)
+		uc.setError(result, status.InvalidInput, messages.MessageKeysInstance.OAUTH_INVALID_GRANT)
+		return nil
+	}
+	return user
+}
+
+// generateTokens issues the access and refresh tokens the client acts for the
+// user with. An empty familyID starts a new session.
+func (uc *OAuthTokenUseCase) generateTokens(ctx context.Context, result *usecase.UseCaseResult[dtos.OAuthTokenResponse], client *sharedmodels.OAuthClient, user *usermodels.UserWithRole, familyID string, scope string) dtos.OAuthTokenResponse {
+	claims := authservices.BuildAccessClaimsService(user)
+	claims["scope"] = scope
+	claims["client_id"] = client.ClientID
+	access, exp, err := uc.jwtProvider.GenerateAccessToken(ctx, user.GetUserIDString(), claims)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error generating access token", err.ToError(), uc.AppContext)
+		uc.setError(result, status.InternalError, messages.MessageKeysInstance.SOMETHING_WENT_WRONG)
+		return dtos.OAuthTokenResponse{}
+	}
+
+	token := dtos.OAuthTokenResponse{
+		AccessToken: access,
+		TokenType:   "Bearer",
+		ExpiresIn:   int64(time.Until(exp).Seconds()),
+		Scope:       scope,
+	}
+	if !client.AllowsGrantType(sharedmodels.OAuthGrantRefreshToken) {
+		return token
+	}
+
+	token.RefreshToken, _, err = authservices.IssueClientRefreshTokenService(
+		ctx,
+		user.ID,
+		familyID,
+		client.ClientID,
+		scope,
+		uc.jwtProvider,
+		uc.hashProvider,
+		uc.refreshTokenRepo,
+	)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error generating refresh token", err.ToError(), uc.AppContext)
+		uc.setError(result, status.InternalError, messages.MessageKeysInstance.SOMETHING_WENT_WRONG)
+		return dtos.OAuthTokenResponse{}
+	}
+	return token
+}
+
+func (uc *OAuthTokenUseCase) setError(result *usecase.UseCaseResult[dtos.OAuthTokenResponse], code status.ApplicationStatusEnum, key messages.MessageKeysEnum) {
+	result.SetError(
+		code,
+		uc.AppMessages.Get(
+			uc.Locale,
+			key,
+		),
+	)
+}
+
+func NewOAuthTokenUseCase(
+	clientRepo authcontracts.IOAuthClientRepository,
+	userRepo authcontracts.IUserRepository,
+	refreshTokenRepo authcontracts.IRefreshTokenRepository,
+	jwtProvider authcontracts.IJWTProvider,
+	hashProvider contractproviders.IHashProvider,
+	revocationProvider contractproviders.ITokenRevocationProvider,
+	codeService *authservices.OAuthCodeService,
+) *OAuthTokenUseCase {
+	return &OAuthTokenUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[dtos.OAuthTokenRequest, dtos.OAuthTokenResponse]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(),
+		},
+		clientRepo:         clientRepo,
+		userRepo:           userRepo,
+		refreshTokenRepo:   refreshTokenRepo,
+		jwtProvider:        jwtProvider,
+		hashProvider:       hashProvider,
+		revocationProvider: revocationProvider,
+		codeService:        codeService,
+	}
+}
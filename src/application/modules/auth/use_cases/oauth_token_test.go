@@ -0,0 +1,339 @@
+package authusecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authmocks "github.com/simon3640/goprojectskeleton/src/application/modules/auth/mocks"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var oauthTestCredentials = dtos.OAuthClientCredentials{ClientID: "client", ClientSecret: "secret"}
+
+type oauthTokenTestMocks struct {
+	clientRepository       *authmocks.MockOAuthClientRepository
+	userRepository         *authmocks.MockUserRepository
+	refreshTokenRepository *authmocks.MockRefreshTokenRepository
+	jwtProvider            *authmocks.MockJWTProvider
+	hashProvider           *providersmocks.MockHashProvider
+	revocationProvider     *providersmocks.MockTokenRevocationProvider
+	codes                  *authservices.OAuthCodeService
+}
+
+func newOAuthTokenTestUseCase() (*OAuthTokenUseCase, *oauthTokenTestMocks) {
+	mocks := &oauthTokenTestMocks{
+		clientRepository:       new(authmocks.MockOAuthClientRepository),
+		userRepository:         new(authmocks.MockUserRepository),
+		refreshTokenRepository: new(authmocks.MockRefreshTokenRepository),
+		jwtProvider:            new(authmocks.MockJWTProvider),
+		hashProvider:           new(providersmocks.MockHashProvider),
+		revocationProvider:     new(providersmocks.MockTokenRevocationProvider),
+		codes:                  authservices.NewOAuthCodeService(providersmocks.NewMemoryCacheProvider(), oauthTestCodeTTL),
+	}
+	mocks.clientRepository.On("GetByClientID", "client").Return(authmocks.OAuthClient(), nil)
+	mocks.hashProvider.On("ValidateOneTimeToken", authmocks.OAuthClientSecretHash, "secret").Return(true)
+	mocks.hashProvider.On("ValidateOneTimeToken", authmocks.OAuthClientSecretHash, mock.Anything).Return(false)
+	mocks.hashProvider.On("HashOneTimeToken", "code").Return(oauthTestCodeHash)
+	mocks.revocationProvider.On("UserTokensRevokedBefore", uint(1)).Return(time.Time{}, nil)
+	return NewOAuthTokenUseCase(
+		mocks.clientRepository,
+		mocks.userRepository,
+		mocks.refreshTokenRepository,
+		mocks.jwtProvider,
+		mocks.hashProvider,
+		mocks.revocationProvider,
+		mocks.codes,
+	), mocks
+}
+
+// issueOAuthTestCode stores an authorization code of the user for the client
+func issueOAuthTestCode(t *testing.T, codes *authservices.OAuthCodeService) {
+	t.Helper()
+	if err := codes.Issue(authservices.OAuthCodeKey(oauthTestCodeHash), dtos.OAuthCode{
+		ClientID:      "client",
+		UserID:        1,
+		RedirectURI:   "https://app.example.com/callback",
+		Scope:         sharedmodels.OAuthScopeProfile,
+		CodeChallenge: oauthTestChallenge,
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// mockOAuthTokens mocks the tokens the client gets for user 1
+func mockOAuthTokens(ctx *app_context.AppContext, mocks *oauthTokenTestMocks, familyID string) {
+	mocks.userRepository.On("GetUserWithRole", uint(1)).Return(&dtomocks.UserWithRole, nil)
+	mocks.jwtProvider.On("GenerateAccessToken", ctx, "1", mock.MatchedBy(func(claims authcontracts.JWTCLaims) bool {
+		return claims["scope"] == sharedmodels.OAuthScopeProfile && claims["client_id"] == "client"
+	})).Return("accessToken", time.Now().Add(time.Hour), nil)
+	mocks.jwtProvider.On("GenerateRefreshToken", ctx, "1").Return("newRefreshToken", time.Now().Add(24*time.Hour), nil)
+	mocks.hashProvider.On("HashOneTimeToken", "newRefreshToken").Return([]byte("hashed_new_refresh_token"))
+	mocks.refreshTokenRepository.On("Create", mock.MatchedBy(func(create dtos.RefreshTokenCreate) bool {
+		return create.ClientID == "client" && create.Scope == sharedmodels.OAuthScopeProfile &&
+			(familyID == "" || create.FamilyID == familyID)
+	})).Return(authmocks.RefreshToken(), nil)
+}
+
+func TestOAuthTokenUseCase_AuthorizationCode(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
+	uc, mocks := newOAuthTokenTestUseCase()
+
+	issueOAuthTestCode(t, mocks.codes)
+	mocks.hashProvider.On("OneTimeToken").Return("family", []byte("family_hash"), nil)
+	mockOAuthTokens(ctx, mocks, "family")
+
+	input := dtos.OAuthTokenRequest{
+		OAuthClientCredentials: oauthTestCredentials,
+		GrantType:              sharedmodels.OAuthGrantAuthorizationCode,
+		Code:                   "code",
+		RedirectURI:            "https://app.example.com/callback",
+		CodeVerifier:           oauthTestVerifier,
+	}
+	result := uc.Execute(ctx, locales.EN_US, input)
+
+	assert.True(result.IsSuccess())
+	assert.Equal("accessToken", result.Data.AccessToken)
+	assert.Equal("newRefreshToken", result.Data.RefreshToken)
+	assert.Equal("Bearer", result.Data.TokenType)
+	assert.Equal(sharedmodels.OAuthScopeProfile, result.Data.Scope)
+	assert.InDelta(3600, result.Data.ExpiresIn, 5)
+
+	// the code can only be exchanged once
+	result = uc.Execute(ctx, locales.EN_US, input)
+	assert.True(result.HasError())
+	assert.Equal(status.InvalidInput, result.StatusCode)
+}
+
+func TestOAuthTokenUseCase_AuthorizationCodeRejected(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(input *dtos.OAuthTokenRequest)
+		status status.ApplicationStatusEnum
+	}{
+		{name: "wrong verifier", modify: func(input *dtos.OAuthTokenRequest) { input.CodeVerifier = "other-verifier" }, status: status.InvalidInput},
+		{name: "other redirect uri", modify: func(input *dtos.OAuthTokenRequest) { input.RedirectURI = "https://app.example.com/other" }, status: status.InvalidInput},
+		{name: "wrong secret", modify: func(input *dtos.OAuthTokenRequest) { input.ClientSecret = "wrong" }, status: status.Unauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			ctx := &app_context.AppContext{Context: context.Background()}
+			uc, mocks := newOAuthTokenTestUseCase()
+
+			issueOAuthTestCode(t, mocks.codes)
+			input := dtos.OAuthTokenRequest{
+				OAuthClientCredentials: oauthTestCredentials,
+				GrantType:              sharedmodels.OAuthGrantAuthorizationCode,
+				Code:                   "code",
+				RedirectURI:            "https://app.example.com/callback",
+				CodeVerifier:           oauthTestVerifier,
+			}
+			tt.modify(&input)
+			result := uc.Execute(ctx, locales.EN_US, input)
+
+			assert.True(result.HasError())
+			assert.Equal(tt.status, result.StatusCode)
+			mocks.jwtProvider.AssertNotCalled(t, "GenerateAccessToken", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOAuthTokenUseCase_RefreshToken(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
+	uc, mocks := newOAuthTokenTestUseCase()
+
+	session := authmocks.RefreshToken()
+	session.ClientID = "client"
+	session.Scope = sharedmodels.OAuthScopeProfile
+	mocks.jwtProvider.On("ParseTokenAndValidate", validRefreshToken).Return(authcontracts.JWTCLaims{"sub": "1", "typ": "refresh"}, nil)
+	mocks.hashProvider.On("HashOneTimeToken", validRefreshToken).Return(authmocks.RefreshTokenHash)
+	mocks.refreshTokenRepository.On("GetByTokenHash", authmocks.RefreshTokenHash).Return(session, nil)
+	mocks.refreshTokenRepository.On("Rotate", session.ID).Return(true, nil)
+	mockOAuthTokens(ctx, mocks, session.FamilyID)
+
+	result := uc.Execute(ctx, locales.EN_US, dtos.OAuthTokenRequest{
+		OAuthClientCredentials: oauthTestCredentials,
+		GrantType:              sharedmodels.OAuthGrantRefreshToken,
+		RefreshToken:           validRefreshToken,
+	})
+
+	assert.True(result.IsSuccess())
+	assert.Equal("newRefreshToken", result.Data.RefreshToken)
+	mocks.refreshTokenRepository.AssertNotCalled(t, "RevokeFamily", mock.Anything)
+}
+
+func TestOAuthTokenUseCase_RefreshTokenOfRevokedUser(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
+	uc, mocks := newOAuthTokenTestUseCase()
+
+	// Every token of the user was revoked after the session was issued, e.g.
+	// by a password change
+	issuedAt := time.Now().Add(-time.Hour)
+	session := authmocks.RefreshToken()
+	session.ClientID = "client"
+	session.Scope = sharedmodels.OAuthScopeProfile
+	mocks.revocationProvider.ExpectedCalls = nil
+	mocks.revocationProvider.On("UserTokensRevokedBefore", uint(1)).Return(issuedAt.Add(time.Minute), nil)
+	mocks.jwtProvider.On("ParseTokenAndValidate", validRefreshToken).Return(authcontracts.JWTCLaims{"sub": "1", "typ": "refresh", "iat": float64(issuedAt.Unix())}, nil)
+	mocks.hashProvider.On("HashOneTimeToken", validRefreshToken).Return(authmocks.RefreshTokenHash)
+	mocks.refreshTokenRepository.On("GetByTokenHash", authmocks.RefreshTokenHash).Return(session, nil)
+	mocks.refreshTokenRepository.On("RevokeFamily", session.FamilyID).Return(nil)
+
+	result := uc.Execute(ctx, locales.EN_US, dtos.OAuthTokenRequest{
+		OAuthClientCredentials: oauthTestCredentials,
+		GrantType:              sharedmodels.OAuthGrantRefreshToken,
+		RefreshToken:           validRefreshToken,
+	})
+
+	assert.True(result.HasError())
+	assert.Equal(status.InvalidInput, result.StatusCode)
+	mocks.refreshTokenRepository.AssertCalled(t, "RevokeFamily", session.FamilyID)
+	mocks.refreshTokenRepository.AssertNotCalled(t, "Rotate", mock.Anything)
+	mocks.jwtProvider.AssertNotCalled(t, "GenerateAccessToken", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOAuthTokenUseCase_RefreshTokenRejected(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(session *sharedmodels.RefreshToken, input *dtos.OAuthTokenRequest)
+		revoke bool
+	}{
+		{name: "token of the login", modify: func(session *sharedmodels.RefreshToken, _ *dtos.OAuthTokenRequest) { session.ClientID = "" }},
+		{name: "token of another client", modify: func(session *sharedmodels.RefreshToken, _ *dtos.OAuthTokenRequest) { session.ClientID = "other" }},
+		{name: "broader scope", modify: func(_ *sharedmodels.RefreshToken, input *dtos.OAuthTokenRequest) {
+			input.Scope = sharedmodels.OAuthScopeProfileWrite
+		}},
+		{name: "reused token", modify: func(session *sharedmodels.RefreshToken, _ *dtos.OAuthTokenRequest) { session.IsRotated = true }, revoke: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			ctx := &app_context.AppContext{Context: context.Background()}
+			uc, mocks := newOAuthTokenTestUseCase()
+
+			session := authmocks.RefreshToken()
+			session.ClientID = "client"
+			session.Scope = sharedmodels.OAuthScopeProfile
+			input := dtos.OAuthTokenRequest{
+				OAuthClientCredentials: oauthTestCredentials,
+				GrantType:              sharedmodels.OAuthGrantRefreshToken,
+				RefreshToken:           validRefreshToken,
+			}
+			tt.modify(session, &input)
+			mocks.jwtProvider.On("ParseTokenAndValidate", validRefreshToken).Return(authcontracts.JWTCLaims{"sub": "1", "typ": "refresh"}, nil)
+			mocks.hashProvider.On("HashOneTimeToken", validRefreshToken).Return(authmocks.RefreshTokenHash)
+			mocks.refreshTokenRepository.On("GetByTokenHash", authmocks.RefreshTokenHash).Return(session, nil)
+			mocks.refreshTokenRepository.On("RevokeFamily", session.FamilyID).Return(nil)
+
+			result := uc.Execute(ctx, locales.EN_US, input)
+
+			assert.True(result.HasError())
+			assert.Equal(status.InvalidInput, result.StatusCode)
+			if tt.revoke {
+				mocks.refreshTokenRepository.AssertCalled(t, "RevokeFamily", session.FamilyID)
+			} else {
+				mocks.refreshTokenRepository.AssertNotCalled(t, "RevokeFamily", mock.Anything)
+			}
+			mocks.jwtProvider.AssertNotCalled(t, "GenerateAccessToken", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOAuthTokenUseCase_ClientCredentials(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
+	uc, mocks := newOAuthTokenTestUseCase()
+
+	// The client acts in the organization of its owner
+	owner := dtomocks.UserWithRole
+	owner.SetOrganizations([]uint{3})
+	owner.SetTenant(3)
+	mocks.userRepository.On("GetUserWithRole", uint(1)).Return(&owner, nil)
+	mocks.jwtProvider.On("GenerateAccessToken", ctx, "client:client", authcontracts.JWTCLaims{
+		"scope":     sharedmodels.OAuthScopeProfile,
+		"client_id": "client",
+		"tenant":    uint(3),
+	}).Return("accessToken", time.Now().Add(time.Hour), nil)
+
+	result := uc.Execute(ctx, locales.EN_US, dtos.OAuthTokenRequest{
+		OAuthClientCredentials: oauthTestCredentials,
+		GrantType:              sharedmodels.OAuthGrantClientCredentials,
+		Scope:                  sharedmodels.OAuthScopeProfile,
+	})
+
+	assert.True(result.IsSuccess())
+	assert.Equal("accessToken", result.Data.AccessToken)
+	assert.Empty(result.Data.RefreshToken)
+	mocks.refreshTokenRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestOAuthTokenUseCase_ClientCredentialsRejected(t *testing.T) {
+	suspended := usermodels.UserStatusSuspended
+	suspendedOwner := dtomocks.UserWithRole
+	suspendedOwner.Status = &suspended
+
+	testCases := []struct {
+		name  string
+		scope string
+		owner *usermodels.UserWithRole
+	}{
+		{name: "scope not registered", scope: sharedmodels.OAuthScopeUsersRead, owner: &dtomocks.UserWithRole},
+		{name: "owner not active", scope: sharedmodels.OAuthScopeProfile, owner: &suspendedOwner},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			ctx := &app_context.AppContext{Context: context.Background()}
+			uc, mocks := newOAuthTokenTestUseCase()
+			mocks.userRepository.On("GetUserWithRole", uint(1)).Return(tc.owner, nil)
+
+			result := uc.Execute(ctx, locales.EN_US, dtos.OAuthTokenRequest{
+				OAuthClientCredentials: oauthTestCredentials,
+				GrantType:              sharedmodels.OAuthGrantClientCredentials,
+				Scope:                  tc.scope,
+			})
+
+			assert.True(result.HasError())
+			assert.Equal(status.InvalidInput, result.StatusCode)
+			mocks.jwtProvider.AssertNotCalled(t, "GenerateAccessToken", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOAuthTokenUseCase_GrantTypeNotRegistered(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
+	uc, mocks := newOAuthTokenTestUseCase()
+
+	client := authmocks.OAuthClient()
+	client.GrantTypes = []string{sharedmodels.OAuthGrantAuthorizationCode}
+	mocks.clientRepository.ExpectedCalls = nil
+	mocks.clientRepository.On("GetByClientID", "client").Return(client, nil)
+
+	result := uc.Execute(ctx, locales.EN_US, dtos.OAuthTokenRequest{
+		OAuthClientCredentials: oauthTestCredentials,
+		GrantType:              sharedmodels.OAuthGrantClientCredentials,
+	})
+
+	assert.True(result.HasError())
+	assert.Equal(status.InvalidInput, result.StatusCode)
+	mocks.jwtProvider.AssertNotCalled(t, "GenerateAccessToken", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,298 @@
+package authusecases
+
+import (
+	"context"
+
+	contractproviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
+	userdtos "github.com/simon3640/goprojectskeleton/src/application/modules/user/dtos"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/settings"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
+
+// OIDCLoginUseCase is the use case for authenticating a user with the code
+// an identity provider redirected back with. Users are created on their
+// first login, accounts are never linked by email.
+type OIDCLoginUseCase struct {
+	usecase.BaseUseCaseValidation[dtos.OIDCCallback, dtos.Token]
+
+	userRepo         authcontracts.IUserRepository
+	identityRepo     authcontracts.IExternalIdentityRepository
+	refreshTokenRepo authcontracts.IRefreshTokenRepository
+	createUser       usecase.BaseUseCase[userdtos.UserCreate, usermodels.User]
+
+	jwtProvider    authcontracts.IJWTProvider
+	hashProvider   contractproviders.IHashProvider
+	oidcProvider   authcontracts.IOIDCProvider
+	sessionService *authservices.OIDCSessionService
+}
+
+var _ usecase.BaseUseCase[dtos.OIDCCallback, dtos.Token] = (*OIDCLoginUseCase)(nil)
+
+// Execute authenticates a user with an identity provider
+// - Consume the authorization: the state can only be used once and must not belong to a linking
+// - Exchange the code: the ID token must be valid and carry the nonce of the authorization
+// - Get the user of the identity, or create it on the first login when the
+// provider verified the email and no account uses it
+// - Generate the tokens: generate the tokens of the active user
+func (uc *OIDCLoginUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input dtos.OIDCCallback,
+) *usecase.UseCaseResult[dtos.Token] {
+	result := usecase.NewUseCaseResult[dtos.Token]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	session := uc.consumeSession(result, input.State)
+	if result.HasError() {
+		return result
+	}
+	if session.UserID != 0 {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Identity provider linking used for a login", uc.AppContext)
+		uc.setError(result, status.Unauthorized, messages.MessageKeysInstance.OIDC_INVALID_STATE)
+		return result
+	}
+
+	claims := uc.exchangeCode(ctx, result, session, input.Code)
+	if result.HasError() {
+		return result
+	}
+
+	userID := uc.getOrCreateUser(result, session.Provider, claims)
+	if result.HasError() {
+		return result
+	}
+
+	user := uc.getActiveUser(result, userID)
+	if result.HasError() {
+		return result
+	}
+
+	token := uc.generateTokens(ctx, result, user)
+	if result.HasError() {
+		return result
+	}
+
+	result.SetData(
+		status.Success,
+		token,
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.AUTHORIZATION_GENERATED,
+		),
+	)
+	observability.GetObservabilityComponents().Logger.InfoWithContext("Identity provider login successful", uc.AppContext)
+	return result
+}
+
+func (uc *OIDCLoginUseCase) consumeSession(result *usecase.UseCaseResult[dtos.Token], state string) *dtos.OIDCSession {
+	stateHash := uc.hashProvider.HashOneTimeToken(state)
+	session, err := uc.sessionService.Consume(authservices.OIDCSessionKey(stateHash))
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error consuming identity provider authorization", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return nil
+	}
+	if session == nil {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("No pending identity provider authorization", uc.AppContext)
+		uc.setError(result, status.Unauthorized, messages.MessageKeysInstance.OIDC_INVALID_STATE)
+		return nil
+	}
+	return session
+}
+
+// exchangeCode exchanges the code for the claims of the ID token, the token
+// must carry the nonce of the authorization
+func (uc *OIDCLoginUseCase) exchangeCode(ctx context.Context, result *usecase.UseCaseResult[dtos.Token], session *dtos.OIDCSession, code string) *dtos.OIDCClaims {
+	claims, err := uc.oidcProvider.Exchange(ctx, session.Provider, code, session.CodeVerifier)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Error exchanging identity provider code", uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return nil
+	}
+	if claims.Nonce != session.Nonce {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Identity provider ID token with another nonce", uc.AppContext)
+		uc.setError(result, status.Unauthorized, messages.MessageKeysInstance.OIDC_INVALID_TOKEN)
+		return nil
+	}
+	return claims
+}
+
+// getOrCreateUser returns the user linked to the identity, a user is created
+// with the identity when none is linked
+func (uc *OIDCLoginUseCase) getOrCreateUser(result *usecase.UseCaseResult[dtos.Token], provider string, claims *dtos.OIDCClaims) uint {
+	identity, err := uc.identityRepo.GetByProviderSubject(provider, claims.Subject)
+	if err != nil && err.Code != status.NotFound {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting external identity", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return 0
+	}
+	if identity != nil {
+		return identity.UserID
+	}
+
+	if !claims.EmailVerified || claims.Email == "" {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Identity provider did not verify the email", uc.AppContext)
+		uc.setError(result, status.Unauthorized, messages.MessageKeysInstance.OIDC_EMAIL_NOT_VERIFIED)
+		return 0
+	}
+
+	// Linking by email would let whoever controls the email at the provider
+	// take over the account, the user must link the provider while signed in
+	existing, err := uc.userRepo.GetByEmailOrPhone(claims.Email)
+	if err != nil && err.Code != status.NotFound {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting user by email", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return 0
+	}
+	if existing != nil {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Identity provider login for an existing account", uc.AppContext)
+		uc.setError(result, status.Conflict, messages.MessageKeysInstance.OIDC_ACCOUNT_EXISTS)
+		return 0
+	}
+
+	name := claims.Name
+	if name == "" {
+		name = claims.Email
+	}
+	// The provider verified the email, so the user is active
+	userStatus := usermodels.UserStatusActive
+	userResult := uc.createUser.Execute(uc.AppContext, uc.Locale, userdtos.UserCreate{
+		UserBase: usermodels.UserBase{
+			Name:   name,
+			Email:  claims.Email,
+			Status: &userStatus,
+			RoleID: uint(settings.AppSettingsInstance.OIDCDefaultRoleID),
+		},
+		ExternalIdentity: true,
+	})
+	if userResult.HasError() {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Error creating user of identity provider login", uc.AppContext)
+		result.SetError(userResult.StatusCode, *userResult.Error)
+		return 0
+	}
+	user := userResult.Data
+
+	if _, err := uc.identityRepo.Create(dtos.ExternalIdentityCreate{
+		ExternalIdentityBase: sharedmodels.ExternalIdentityBase{
+			UserID:   user.ID,
+			Provider: provider,
+			Subject:  claims.Subject,
+			Email:    claims.Email,
+		},
+	}); err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error creating external identity", err.ToError(), uc.AppContext)
+		uc.deleteUser(user.ID)
+		uc.setError(result, err.Code, err.Context)
+		return 0
+	}
+	observability.GetObservabilityComponents().Logger.InfoWithContext("User created on first identity provider login", uc.AppContext)
+	return user.ID
+}
+
+// deleteUser deletes the user created for an identity that could not be
+// stored, the user could never sign in with the provider and would hold the
+// email. Errors are logged
+func (uc *OIDCLoginUseCase) deleteUser(userID uint) {
+	if err := uc.userRepo.Delete(userID); err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error deleting user of identity provider login", err.ToError(), uc.AppContext)
+	}
+}
+
+func (uc *OIDCLoginUseCase) getActiveUser(result *usecase.UseCaseResult[dtos.Token], userID uint) *usermodels.UserWithRole {
+	user, err := uc.userRepo.GetUserWithRole(userID)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting user by ID", err.ToError(), uc.AppContext)
+		uc.setError(result, status.Unauthorized, messages.MessageKeysInstance.OIDC_INVALID_TOKEN)
+		return nil
+	}
+	if user.Status == nil || *user.Status != usermodels.UserStatusActive {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Identity provider login attempted by a user that is not active", uc.AppContext)
+		uc.setError(result, status.Unauthorized, messages.MessageKeysInstance.USER_NOT_ACTIVE)
+		return nil
+	}
+	return user
+}
+
+func (uc *OIDCLoginUseCase) generateTokens(ctx context.Context, result *usecase.UseCaseResult[dtos.Token], user *usermodels.UserWithRole) dtos.Token {
+	claims := authservices.BuildAccessClaimsService(user)
+
+	access, exp, err := uc.jwtProvider.GenerateAccessToken(ctx, user.GetUserIDString(), claims)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error generating access token", err.ToError(), uc.AppContext)
+		uc.setError(result, status.Conflict, messages.MessageKeysInstance.SOMETHING_WENT_WRONG)
+		return dtos.Token{}
+	}
+
+	// Every login starts a new session
+	refresh, expRefresh, err := authservices.IssueRefreshTokenService(
+		ctx,
+		user.ID,
+		"",
+		uc.jwtProvider,
+		uc.hashProvider,
+		uc.refreshTokenRepo,
+	)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error generating refresh token", err.ToError(), uc.AppContext)
+		uc.setError(result, status.Conflict, messages.MessageKeysInstance.SOMETHING_WENT_WRONG)
+		return dtos.Token{}
+	}
+
+	return dtos.Token{
+		AccessToken:           access,
+		RefreshToken:          refresh,
+		TokenType:             "Bearer",
+		AccessTokenExpiresAt:  exp,
+		RefreshTokenExpiresAt: expRefresh,
+	}
+}
+
+func (uc *OIDCLoginUseCase) setError(result *usecase.UseCaseResult[dtos.Token], code status.ApplicationStatusEnum, key messages.MessageKeysEnum) {
+	result.SetError(
+		code,
+		uc.AppMessages.Get(
+			uc.Locale,
+			key,
+		),
+	)
+}
+
+func NewOIDCLoginUseCase(
+	userRepo authcontracts.IUserRepository,
+	identityRepo authcontracts.IExternalIdentityRepository,
+	refreshTokenRepo authcontracts.IRefreshTokenRepository,
+	createUser usecase.BaseUseCase[userdtos.UserCreate, usermodels.User],
+	hashProvider contractproviders.IHashProvider,
+	jwtProvider authcontracts.IJWTProvider,
+	oidcProvider authcontracts.IOIDCProvider,
+	sessionService *authservices.OIDCSessionService,
+) *OIDCLoginUseCase {
+	return &OIDCLoginUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[dtos.OIDCCallback, dtos.Token]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(),
+		},
+		userRepo:         userRepo,
+		identityRepo:     identityRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		createUser:       createUser,
+		jwtProvider:      jwtProvider,
+		hashProvider:     hashProvider,
+		oidcProvider:     oidcProvider,
+		sessionService:   sessionService,
+	}
+}
@@ -0,0 +1,256 @@
+package authusecases
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authmocks "github.com/simon3640/goprojectskeleton/src/application/modules/auth/mocks"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
+	userdtos "github.com/simon3640/goprojectskeleton/src/application/modules/user/dtos"
+	usermocks "github.com/simon3640/goprojectskeleton/src/application/modules/user/mocks"
+	userusecases "github.com/simon3640/goprojectskeleton/src/application/modules/user/use_cases"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
+	repositoriesmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/repositories"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/settings"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var oidcCallback = dtos.OIDCCallback{State: "state", Code: "code"}
+
+func oidcTestClaims() *dtos.OIDCClaims {
+	return &dtos.OIDCClaims{
+		Subject:       "subject",
+		Email:         dtomocks.UserWithRole.Email,
+		EmailVerified: true,
+		Name:          dtomocks.UserWithRole.Name,
+		Nonce:         "nonce",
+	}
+}
+
+func oidcTestIdentity(userID uint) *sharedmodels.ExternalIdentity {
+	return &sharedmodels.ExternalIdentity{
+		ExternalIdentityBase: sharedmodels.ExternalIdentityBase{
+			UserID:   userID,
+			Provider: "google",
+			Subject:  "subject",
+			Email:    dtomocks.UserWithRole.Email,
+		},
+		DBBaseModel: sharedmodels.DBBaseModel{ID: 1},
+	}
+}
+
+type oidcLoginTestMocks struct {
+	userRepository         *authmocks.MockUserRepository
+	createUserRepository   *usermocks.MockUserRepository
+	identityRepository     *authmocks.MockExternalIdentityRepository
+	refreshTokenRepository *authmocks.MockRefreshTokenRepository
+	hashProvider           *providersmocks.MockHashProvider
+	jwtProvider            *authmocks.MockJWTProvider
+	oidcProvider           *authmocks.MockOIDCProvider
+	cache                  *providersmocks.MockCacheProvider
+}
+
+func newOIDCLoginTestUseCase() (*OIDCLoginUseCase, *oidcLoginTestMocks) {
+	sessions, cache := newOIDCTestSessions()
+	mocks := &oidcLoginTestMocks{
+		userRepository:         new(authmocks.MockUserRepository),
+		createUserRepository:   new(usermocks.MockUserRepository),
+		identityRepository:     new(authmocks.MockExternalIdentityRepository),
+		refreshTokenRepository: new(authmocks.MockRefreshTokenRepository),
+		hashProvider:           new(providersmocks.MockHashProvider),
+		jwtProvider:            new(authmocks.MockJWTProvider),
+		oidcProvider:           new(authmocks.MockOIDCProvider),
+		cache:                  cache,
+	}
+	mocks.hashProvider.On("HashOneTimeToken", oidcCallback.State).Return(oidcTestStateHash)
+	return NewOIDCLoginUseCase(
+		mocks.userRepository,
+		mocks.identityRepository,
+		mocks.refreshTokenRepository,
+		userusecases.NewCreateUserUseCase(mocks.createUserRepository, new(repositoriesmocks.MockOrganizationRepository)),
+		mocks.hashProvider,
+		mocks.jwtProvider,
+		mocks.oidcProvider,
+		sessions,
+	), mocks
+}
+
+func mockOIDCTokens(ctx *app_context.AppContext, mocks *oidcLoginTestMocks) {
+	userID := strconv.FormatUint(uint64(dtomocks.UserWithRole.ID), 10)
+	mocks.userRepository.On("GetUserWithRole", uint(1)).Return(&dtomocks.UserWithRole, nil)
+	mocks.jwtProvider.On("GenerateAccessToken", ctx, userID, mock.Anything).Return("newAccessToken", time.Now().Add(1*time.Hour), nil)
+	mocks.jwtProvider.On("GenerateRefreshToken", ctx, userID).Return("newRefreshToken", time.Now().Add(24*time.Hour), nil)
+	mocks.hashProvider.On("OneTimeToken").Return("family", []byte("family"), nil)
+	mocks.hashProvider.On("HashOneTimeToken", "newRefreshToken").Return(authmocks.RefreshTokenHash)
+	mocks.refreshTokenRepository.On("Create", mock.AnythingOfType("authdtos.RefreshTokenCreate")).Return(authmocks.RefreshToken(), nil)
+}
+
+func TestOIDCLoginUseCase_LinkedIdentity(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
+	uc, mocks := newOIDCLoginTestUseCase()
+
+	mockPendingOIDCSession(mocks.cache, authservices.OIDCSessionKey(oidcTestStateHash), 0, 1)
+	mocks.oidcProvider.On("Exchange", ctx, "google", "code", "verifier").Return(oidcTestClaims(), nil)
+	mocks.identityRepository.On("GetByProviderSubject", "google", "subject").Return(oidcTestIdentity(1), nil)
+	mockOIDCTokens(ctx, mocks)
+
+	result := uc.Execute(ctx, locales.EN_US, oidcCallback)
+
+	assert.True(result.IsSuccess())
+	assert.Equal("newAccessToken", result.Data.AccessToken)
+	assert.Equal("newRefreshToken", result.Data.RefreshToken)
+	mocks.createUserRepository.AssertNotCalled(t, "Create", mock.Anything)
+	mocks.cache.AssertExpectations(t)
+}
+
+func TestOIDCLoginUseCase_CreatesUser(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
+	uc, mocks := newOIDCLoginTestUseCase()
+	settings.AppSettingsInstance.OIDCDefaultRoleID = 2
+
+	mockPendingOIDCSession(mocks.cache, authservices.OIDCSessionKey(oidcTestStateHash), 0, 1)
+	mocks.oidcProvider.On("Exchange", ctx, "google", "code", "verifier").Return(oidcTestClaims(), nil)
+	mocks.identityRepository.On("GetByProviderSubject", "google", "subject").Return(nil, notFoundError())
+	mocks.userRepository.On("GetByEmailOrPhone", dtomocks.UserWithRole.Email).Return(nil, notFoundError())
+	// The provider verified the email, the user is active without a phone
+	mocks.createUserRepository.On("Create", mock.MatchedBy(func(create userdtos.UserCreate) bool {
+		return create.ExternalIdentity && create.Phone == "" && create.RoleID == 2 &&
+			create.Status != nil && *create.Status == usermodels.UserStatusActive
+	})).Return(&usermodels.User{UserBase: dtomocks.UserBase, DBBaseModel: sharedmodels.DBBaseModel{ID: 1}}, nil)
+	mocks.identityRepository.On("Create", dtos.ExternalIdentityCreate{ExternalIdentityBase: oidcTestIdentity(1).ExternalIdentityBase}).Return(oidcTestIdentity(1), nil)
+	mockOIDCTokens(ctx, mocks)
+
+	result := uc.Execute(ctx, locales.EN_US, oidcCallback)
+
+	assert.True(result.IsSuccess())
+	assert.Equal("newAccessToken", result.Data.AccessToken)
+	mocks.createUserRepository.AssertExpectations(t)
+	mocks.identityRepository.AssertExpectations(t)
+}
+
+func TestOIDCLoginUseCase_IdentityNotStored(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
+	uc, mocks := newOIDCLoginTestUseCase()
+	settings.AppSettingsInstance.OIDCDefaultRoleID = 2
+
+	mockPendingOIDCSession(mocks.cache, authservices.OIDCSessionKey(oidcTestStateHash), 0, 1)
+	mocks.oidcProvider.On("Exchange", ctx, "google", "code", "verifier").Return(oidcTestClaims(), nil)
+	mocks.identityRepository.On("GetByProviderSubject", "google", "subject").Return(nil, notFoundError())
+	mocks.userRepository.On("GetByEmailOrPhone", dtomocks.UserWithRole.Email).Return(nil, notFoundError())
+	mocks.createUserRepository.On("Create", mock.AnythingOfType("userdtos.UserCreate")).
+		Return(&usermodels.User{UserBase: dtomocks.UserBase, DBBaseModel: sharedmodels.DBBaseModel{ID: 1}}, nil)
+	mocks.identityRepository.On("Create", mock.AnythingOfType("authdtos.ExternalIdentityCreate")).Return((*sharedmodels.ExternalIdentity)(nil), applicationerrors.NewApplicationError(
+		status.Conflict,
+		messages.MessageKeysInstance.RESOURCE_EXISTS,
+		"Identity already exists",
+	))
+	// The user created would hold the email without any way to sign in
+	mocks.userRepository.On("Delete", uint(1)).Return(nil)
+
+	result := uc.Execute(ctx, locales.EN_US, oidcCallback)
+
+	assert.True(result.HasError())
+	assert.Equal(status.Conflict, result.StatusCode)
+	mocks.userRepository.AssertExpectations(t)
+}
+
+func TestOIDCLoginUseCase_Errors(t *testing.T) {
+	invalidToken := applicationerrors.NewApplicationError(
+		status.Unauthorized,
+		messages.MessageKeysInstance.OIDC_INVALID_TOKEN,
+		"invalid id token",
+	)
+	otherNonce := oidcTestClaims()
+	otherNonce.Nonce = "other"
+	unverifiedEmail := oidcTestClaims()
+	unverifiedEmail.EmailVerified = false
+	suspendedStatus := usermodels.UserStatusSuspended
+	suspendedUser := dtomocks.UserWithRole
+	suspendedUser.Status = &suspendedStatus
+
+	cases := []struct {
+		name          string
+		input         dtos.OIDCCallback
+		hasSession    bool
+		claims        int64
+		sessionUserID uint
+		idClaims      *dtos.OIDCClaims
+		exchangeErr   *applicationerrors.ApplicationError
+		identity      *sharedmodels.ExternalIdentity
+		existingUser  *usermodels.User
+		user          *usermodels.UserWithRole
+		statusCode    status.ApplicationStatusEnum
+		message       messages.MessageKeysEnum
+	}{
+		{name: "missing code", input: dtos.OIDCCallback{State: "state"}, statusCode: status.InvalidInput},
+		{name: "no pending authorization", input: oidcCallback, statusCode: status.Unauthorized, message: messages.MessageKeysInstance.OIDC_INVALID_STATE},
+		{name: "state already used", input: oidcCallback, hasSession: true, claims: 2, statusCode: status.Unauthorized, message: messages.MessageKeysInstance.OIDC_INVALID_STATE},
+		{name: "state of a linking", input: oidcCallback, hasSession: true, claims: 1, sessionUserID: 1, statusCode: status.Unauthorized, message: messages.MessageKeysInstance.OIDC_INVALID_STATE},
+		{name: "invalid id token", input: oidcCallback, hasSession: true, claims: 1, exchangeErr: invalidToken, statusCode: status.Unauthorized, message: messages.MessageKeysInstance.OIDC_INVALID_TOKEN},
+		{name: "nonce of another authorization", input: oidcCallback, hasSession: true, claims: 1, idClaims: otherNonce, statusCode: status.Unauthorized, message: messages.MessageKeysInstance.OIDC_INVALID_TOKEN},
+		{name: "email not verified", input: oidcCallback, hasSession: true, claims: 1, idClaims: unverifiedEmail, statusCode: status.Unauthorized, message: messages.MessageKeysInstance.OIDC_EMAIL_NOT_VERIFIED},
+		{name: "account with the email exists", input: oidcCallback, hasSession: true, claims: 1, existingUser: &usermodels.User{UserBase: dtomocks.UserBase, DBBaseModel: sharedmodels.DBBaseModel{ID: 1}}, statusCode: status.Conflict, message: messages.MessageKeysInstance.OIDC_ACCOUNT_EXISTS},
+		{name: "user not active", input: oidcCallback, hasSession: true, claims: 1, identity: oidcTestIdentity(1), user: &suspendedUser, statusCode: status.Unauthorized, message: messages.MessageKeysInstance.USER_NOT_ACTIVE},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			ctx := &app_context.AppContext{Context: context.Background()}
+			uc, mocks := newOIDCLoginTestUseCase()
+			key := authservices.OIDCSessionKey(oidcTestStateHash)
+
+			if tc.hasSession {
+				mockPendingOIDCSession(mocks.cache, key, tc.sessionUserID, tc.claims)
+			} else {
+				mocks.cache.On("Get", key, mock.Anything).Return(false, nil)
+			}
+			idClaims := tc.idClaims
+			if idClaims == nil {
+				idClaims = oidcTestClaims()
+			}
+			if tc.exchangeErr != nil {
+				mocks.oidcProvider.On("Exchange", ctx, "google", "code", "verifier").Return(nil, tc.exchangeErr)
+			} else {
+				mocks.oidcProvider.On("Exchange", ctx, "google", "code", "verifier").Return(idClaims, nil)
+			}
+			if tc.identity != nil {
+				mocks.identityRepository.On("GetByProviderSubject", "google", "subject").Return(tc.identity, nil)
+			} else {
+				mocks.identityRepository.On("GetByProviderSubject", "google", "subject").Return(nil, notFoundError())
+			}
+			if tc.existingUser != nil {
+				mocks.userRepository.On("GetByEmailOrPhone", dtomocks.UserWithRole.Email).Return(tc.existingUser, nil)
+			}
+			if tc.user != nil {
+				mocks.userRepository.On("GetUserWithRole", uint(1)).Return(tc.user, nil)
+			}
+
+			result := uc.Execute(ctx, locales.EN_US, tc.input)
+
+			assert.True(result.HasError())
+			assert.Equal(tc.statusCode, result.StatusCode)
+			if tc.message != "" {
+				assert.Equal(locales.NewLocale(locales.EN_US).Get(locales.EN_US, tc.message), *result.Error)
+			}
+			mocks.createUserRepository.AssertNotCalled(t, "Create", mock.Anything)
+			mocks.jwtProvider.AssertNotCalled(t, "GenerateAccessToken", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package authusecases
+
+import (
+	contractproviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/guards"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
+
+// RegisterOAuthClientUseCase is the use case for registering an application
+// that delegates the login of its users to this service
+type RegisterOAuthClientUseCase struct {
+	usecase.BaseUseCaseValidation[dtos.OAuthClientCreate, dtos.OAuthClientRegistration]
+
+	clientRepo authcontracts.IOAuthClientRepository
+
+	hashProvider contractproviders.IHashProvider
+}
+
+var _ usecase.BaseUseCase[dtos.OAuthClientCreate, dtos.OAuthClientRegistration] = (*RegisterOAuthClientUseCase)(nil)
+
+// Execute registers the client
+// - Generate the client ID: a random identifier the client sends on every request
+// - Generate the secret: confidential clients get a secret, only its hash is stored
+// - Create the client: the secret is only returned in this response
+func (uc *RegisterOAuthClientUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input dtos.OAuthClientCreate,
+) *usecase.UseCaseResult[dtos.OAuthClientRegistration] {
+	result := usecase.NewUseCaseResult[dtos.OAuthClientRegistration]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	clientID, _, err := uc.hashProvider.OneTimeToken()
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error generating OAuth client ID", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return result
+	}
+	input.ClientID = clientID
+	input.SecretHash = nil
+
+	var secret string
+	if input.Confidential {
+		secret, input.SecretHash, err = uc.hashProvider.OneTimeToken()
+		if err != nil {
+			observability.GetObservabilityComponents().Logger.ErrorWithContext("Error generating OAuth client secret", err.ToError(), uc.AppContext)
+			uc.setError(result, err.Code, err.Context)
+			return result
+		}
+	}
+
+	client, err := uc.clientRepo.Create(input)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error creating OAuth client", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return result
+	}
+
+	result.SetData(
+		status.Created,
+		dtos.OAuthClientRegistration{
+			OAuthClient:  *client,
+			ClientSecret: secret,
+		},
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.OAUTH_CLIENT_REGISTERED,
+		),
+	)
+	observability.GetObservabilityComponents().Logger.InfoWithContext("OAuth client registered successfully", uc.AppContext)
+	return result
+}
+
+func (uc *RegisterOAuthClientUseCase) setError(result *usecase.UseCaseResult[dtos.OAuthClientRegistration], code status.ApplicationStatusEnum, key messages.MessageKeysEnum) {
+	result.SetError(
+		code,
+		uc.AppMessages.Get(
+			uc.Locale,
+			key,
+		),
+	)
+}
+
+func NewRegisterOAuthClientUseCase(
+	clientRepo authcontracts.IOAuthClientRepository,
+	hashProvider contractproviders.IHashProvider,
+) *RegisterOAuthClientUseCase {
+	return &RegisterOAuthClientUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[dtos.OAuthClientCreate, dtos.OAuthClientRegistration]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(guards.PermissionGuard(usermodels.PermissionOAuthClientCreate)),
+		},
+		clientRepo:   clientRepo,
+		hashProvider: hashProvider,
+	}
+}
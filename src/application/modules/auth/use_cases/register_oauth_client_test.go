@@ -0,0 +1,116 @@
+package authusecases
+
+import (
+	"context"
+	"testing"
+
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authmocks "github.com/simon3640/goprojectskeleton/src/application/modules/auth/mocks"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func oauthTestAdmin() *usermodels.UserWithRole {
+	admin := dtomocks.UserWithRole
+	admin.SetRole(dtomocks.AdminRole)
+	admin.SetPermissions(dtomocks.AdminRolePermissions)
+	return &admin
+}
+
+func oauthClientCreate(confidential bool) dtos.OAuthClientCreate {
+	return dtos.OAuthClientCreate{
+		OAuthClientBase: sharedmodels.OAuthClientBase{
+			Name:         "Test App",
+			RedirectURIs: []string{"https://app.example.com/callback"},
+			Scopes:       []string{sharedmodels.OAuthScopeProfile},
+			GrantTypes:   []string{sharedmodels.OAuthGrantAuthorizationCode, sharedmodels.OAuthGrantRefreshToken},
+		},
+		Confidential: confidential,
+	}
+}
+
+func TestRegisterOAuthClientUseCase(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background(), User: oauthTestAdmin()}
+
+	testClientRepository := new(authmocks.MockOAuthClientRepository)
+	testHashProvider := new(providersmocks.MockHashProvider)
+	uc := NewRegisterOAuthClientUseCase(testClientRepository, testHashProvider)
+
+	testHashProvider.On("OneTimeToken").Return("client", []byte("client_hash"), nil).Once()
+	testHashProvider.On("OneTimeToken").Return("secret", authmocks.OAuthClientSecretHash, nil).Once()
+	testClientRepository.On("Create", mock.MatchedBy(func(create dtos.OAuthClientCreate) bool {
+		return create.ClientID == "client" && string(create.SecretHash) == string(authmocks.OAuthClientSecretHash)
+	})).Return(authmocks.OAuthClient(), nil)
+
+	result := uc.Execute(ctx, locales.EN_US, oauthClientCreate(true))
+
+	assert.True(result.IsSuccess())
+	assert.Equal(status.Created, result.StatusCode)
+	assert.Equal("client", result.Data.ClientID)
+	assert.Equal("secret", result.Data.ClientSecret)
+}
+
+func TestRegisterOAuthClientUseCase_PublicClientWithoutSecret(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background(), User: oauthTestAdmin()}
+
+	testClientRepository := new(authmocks.MockOAuthClientRepository)
+	testHashProvider := new(providersmocks.MockHashProvider)
+	uc := NewRegisterOAuthClientUseCase(testClientRepository, testHashProvider)
+
+	client := authmocks.OAuthClient()
+	client.SecretHash = nil
+	testHashProvider.On("OneTimeToken").Return("client", []byte("client_hash"), nil).Once()
+	testClientRepository.On("Create", mock.MatchedBy(func(create dtos.OAuthClientCreate) bool {
+		return create.ClientID == "client" && create.SecretHash == nil
+	})).Return(client, nil)
+
+	result := uc.Execute(ctx, locales.EN_US, oauthClientCreate(false))
+
+	assert.True(result.IsSuccess())
+	assert.Empty(result.Data.ClientSecret)
+	testHashProvider.AssertNumberOfCalls(t, "OneTimeToken", 1)
+}
+
+func TestRegisterOAuthClientUseCase_InvalidClient(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background(), User: oauthTestAdmin()}
+
+	testClientRepository := new(authmocks.MockOAuthClientRepository)
+	testHashProvider := new(providersmocks.MockHashProvider)
+	uc := NewRegisterOAuthClientUseCase(testClientRepository, testHashProvider)
+
+	// public clients can not authenticate for themselves
+	input := oauthClientCreate(false)
+	input.GrantTypes = []string{sharedmodels.OAuthGrantClientCredentials}
+
+	result := uc.Execute(ctx, locales.EN_US, input)
+
+	assert.True(result.HasError())
+	assert.Equal(status.InvalidInput, result.StatusCode)
+	testClientRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestRegisterOAuthClientUseCase_OnlyAdmins(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background(), User: &dtomocks.UserWithRole}
+
+	testClientRepository := new(authmocks.MockOAuthClientRepository)
+	testHashProvider := new(providersmocks.MockHashProvider)
+	uc := NewRegisterOAuthClientUseCase(testClientRepository, testHashProvider)
+
+	result := uc.Execute(ctx, locales.EN_US, oauthClientCreate(true))
+
+	assert.True(result.HasError())
+	assert.Equal(status.Unauthorized, result.StatusCode)
+	testClientRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
@@ -0,0 +1,94 @@
+package authusecases
+
+import (
+	"time"
+
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/guards"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
+
+// RevokeAPIKeyUseCase is the use case for revoking an API key by its ID.
+// Users revoke their own keys, admins can revoke the key of any user.
+type RevokeAPIKeyUseCase struct {
+	usecase.BaseUseCaseValidation[uint, bool]
+
+	apiKeyRepo authcontracts.IAPIKeyRepository
+}
+
+var _ usecase.BaseUseCase[uint, bool] = (*RevokeAPIKeyUseCase)(nil)
+
+// Execute revokes the API key
+// - Get the key: keys of other users are not found unless the user is an admin
+// - Revoke the key: revoking a revoked key succeeds without changing it
+func (uc *RevokeAPIKeyUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input uint,
+) *usecase.UseCaseResult[bool] {
+	result := usecase.NewUseCaseResult[bool]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	apiKey, err := uc.apiKeyRepo.GetByID(input)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting API key", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return result
+	}
+	if apiKey.UserID != uc.AppContext.User.ID && !uc.AppContext.User.HasPermission(usermodels.PermissionAPIKeyRevokeAny) {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("User attempted to revoke an API key of another user", uc.AppContext)
+		uc.setError(result, status.NotFound, messages.MessageKeysInstance.RESOURCE_NOT_FOUND)
+		return result
+	}
+
+	if apiKey.RevokedAt == nil {
+		revokedAt := time.Now()
+		if _, err := uc.apiKeyRepo.Update(apiKey.ID, dtos.APIKeyUpdate{RevokedAt: &revokedAt, ID: apiKey.ID}); err != nil {
+			observability.GetObservabilityComponents().Logger.ErrorWithContext("Error revoking API key", err.ToError(), uc.AppContext)
+			uc.setError(result, err.Code, err.Context)
+			return result
+		}
+	}
+
+	result.SetData(
+		status.Success,
+		true,
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.API_KEY_REVOKED,
+		),
+	)
+	observability.GetObservabilityComponents().Logger.InfoWithContext("API key revoked successfully", uc.AppContext)
+	return result
+}
+
+func (uc *RevokeAPIKeyUseCase) setError(result *usecase.UseCaseResult[bool], code status.ApplicationStatusEnum, key messages.MessageKeysEnum) {
+	result.SetError(
+		code,
+		uc.AppMessages.Get(
+			uc.Locale,
+			key,
+		),
+	)
+}
+
+func NewRevokeAPIKeyUseCase(apiKeyRepo authcontracts.IAPIKeyRepository) *RevokeAPIKeyUseCase {
+	return &RevokeAPIKeyUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[uint, bool]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(guards.PermissionGuard(usermodels.PermissionAPIKeyRevoke)),
+		},
+		apiKeyRepo: apiKeyRepo,
+	}
+}
@@ -0,0 +1,73 @@
+package authusecases
+
+import (
+	"context"
+	"testing"
+
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authmocks "github.com/simon3640/goprojectskeleton/src/application/modules/auth/mocks"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRevokeAPIKeyUseCase(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background(), User: &dtomocks.UserWithRole}
+
+	testAPIKeyRepository := new(authmocks.MockAPIKeyRepository)
+	uc := NewRevokeAPIKeyUseCase(testAPIKeyRepository)
+
+	testAPIKeyRepository.On("GetByID", uint(1)).Return(authmocks.APIKey(), nil)
+	testAPIKeyRepository.On("Update", uint(1), mock.MatchedBy(func(update dtos.APIKeyUpdate) bool {
+		return update.ID == 1 && update.RevokedAt != nil
+	})).Return(authmocks.APIKey(), nil)
+
+	result := uc.Execute(ctx, locales.EN_US, uint(1))
+
+	assert.True(result.IsSuccess())
+	testAPIKeyRepository.AssertExpectations(t)
+}
+
+func TestRevokeAPIKeyUseCase_KeyOfAnotherUser(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background(), User: &dtomocks.UserWithRole}
+
+	testAPIKeyRepository := new(authmocks.MockAPIKeyRepository)
+	uc := NewRevokeAPIKeyUseCase(testAPIKeyRepository)
+
+	apiKey := authmocks.APIKey()
+	apiKey.UserID = 2
+	testAPIKeyRepository.On("GetByID", uint(1)).Return(apiKey, nil)
+
+	result := uc.Execute(ctx, locales.EN_US, uint(1))
+
+	assert.True(result.HasError())
+	assert.Equal(status.NotFound, result.StatusCode)
+	testAPIKeyRepository.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+}
+
+func TestRevokeAPIKeyUseCase_AdminRevokesKeyOfAnotherUser(t *testing.T) {
+	assert := assert.New(t)
+	admin := dtomocks.UserWithRole
+	admin.SetRole(dtomocks.AdminRole)
+	admin.SetPermissions(dtomocks.AdminRolePermissions)
+	ctx := &app_context.AppContext{Context: context.Background(), User: &admin}
+
+	testAPIKeyRepository := new(authmocks.MockAPIKeyRepository)
+	uc := NewRevokeAPIKeyUseCase(testAPIKeyRepository)
+
+	apiKey := authmocks.APIKey()
+	apiKey.UserID = 2
+	testAPIKeyRepository.On("GetByID", uint(1)).Return(apiKey, nil)
+	testAPIKeyRepository.On("Update", uint(1), mock.Anything).Return(apiKey, nil)
+
+	result := uc.Execute(ctx, locales.EN_US, uint(1))
+
+	assert.True(result.IsSuccess())
+	testAPIKeyRepository.AssertCalled(t, "Update", uint(1), mock.Anything)
+}
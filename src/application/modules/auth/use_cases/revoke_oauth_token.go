@@ -0,0 +1,152 @@
+package authusecases
+
+import (
+	"time"
+
+	contractproviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+)
+
+// RevokeOAuthTokenUseCase is the revocation endpoint (RFC 7009) a client
+// revokes its tokens with, e.g. when the user logs out of the client.
+// Revoking a refresh token ends the whole session, revoking an access token
+// only revokes that token.
+type RevokeOAuthTokenUseCase struct {
+	usecase.BaseUseCaseValidation[dtos.OAuthTokenReference, bool]
+
+	clientRepo       authcontracts.IOAuthClientRepository
+	refreshTokenRepo authcontracts.IRefreshTokenRepository
+
+	jwtProvider        authcontracts.IJWTProvider
+	hashProvider       contractproviders.IHashProvider
+	revocationProvider contractproviders.ITokenRevocationProvider
+}
+
+var _ usecase.BaseUseCase[dtos.OAuthTokenReference, bool] = (*RevokeOAuthTokenUseCase)(nil)
+
+// Execute revokes the token
+// - Authenticate the client: confidential clients must send their secret
+// - Verify the token: invalid or unknown tokens are answered as revoked, there is nothing left to revoke
+// - Check the client: a client can only revoke the tokens issued to it
+// - Revoke the token: a refresh token revokes its session, an access token is revoked until it expires
+func (uc *RevokeOAuthTokenUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input dtos.OAuthTokenReference,
+) *usecase.UseCaseResult[bool] {
+	result := usecase.NewUseCaseResult[bool]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	client, err := authservices.AuthenticateOAuthClientService(input.OAuthClientCredentials, uc.clientRepo, uc.hashProvider)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("OAuth client authentication failed", uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return result
+	}
+
+	claims, err := uc.jwtProvider.ParseTokenAndValidate(input.Token)
+	if err == nil {
+		switch claims["typ"] {
+		case "access":
+			uc.revokeAccessToken(result, claims, client.ClientID)
+		case "refresh":
+			uc.revokeRefreshToken(result, input.Token, client.ClientID)
+		}
+		if result.HasError() {
+			return result
+		}
+	}
+
+	result.SetData(
+		status.Success,
+		true,
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.OAUTH_TOKEN_REVOKED,
+		),
+	)
+	observability.GetObservabilityComponents().Logger.InfoWithContext("OAuth token revoked successfully", uc.AppContext)
+	return result
+}
+
+// revokeAccessToken revokes the access token by its jti until it expires
+func (uc *RevokeOAuthTokenUseCase) revokeAccessToken(result *usecase.UseCaseResult[bool], claims authcontracts.JWTCLaims, clientID string) {
+	if tokenClientID, _ := claims["client_id"].(string); tokenClientID != clientID {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("OAuth client attempted to revoke a token issued to another client", uc.AppContext)
+		uc.setError(result, status.Unauthorized, messages.MessageKeysInstance.OAUTH_INVALID_CLIENT)
+		return
+	}
+	jti, _ := claims["jti"].(string)
+	exp, _ := claims["exp"].(float64)
+	if jti == "" || uc.revocationProvider == nil {
+		return
+	}
+	if err := uc.revocationProvider.RevokeToken(jti, time.Unix(int64(exp), 0)); err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error revoking OAuth access token", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+	}
+}
+
+// revokeRefreshToken revokes every refresh token of the session
+func (uc *RevokeOAuthTokenUseCase) revokeRefreshToken(result *usecase.UseCaseResult[bool], token string, clientID string) {
+	session, err := uc.refreshTokenRepo.GetByTokenHash(uc.hashProvider.HashOneTimeToken(token))
+	if err != nil && err.Code != status.NotFound {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting refresh token by hash", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return
+	}
+	if session == nil {
+		return
+	}
+	if session.ClientID != clientID {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("OAuth client attempted to revoke a token issued to another client", uc.AppContext)
+		uc.setError(result, status.Unauthorized, messages.MessageKeysInstance.OAUTH_INVALID_CLIENT)
+		return
+	}
+	if err := uc.refreshTokenRepo.RevokeFamily(session.FamilyID); err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error revoking refresh token family", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+	}
+}
+
+func (uc *RevokeOAuthTokenUseCase) setError(result *usecase.UseCaseResult[bool], code status.ApplicationStatusEnum, key messages.MessageKeysEnum) {
+	result.SetError(
+		code,
+		uc.AppMessages.Get(
+			uc.Locale,
+			key,
+		),
+	)
+}
+
+func NewRevokeOAuthTokenUseCase(
+	clientRepo authcontracts.IOAuthClientRepository,
+	refreshTokenRepo authcontracts.IRefreshTokenRepository,
+	jwtProvider authcontracts.IJWTProvider,
+	hashProvider contractproviders.IHashProvider,
+	revocationProvider contractproviders.ITokenRevocationProvider,
+) *RevokeOAuthTokenUseCase {
+	return &RevokeOAuthTokenUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[dtos.OAuthTokenReference, bool]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(),
+		},
+		clientRepo:         clientRepo,
+		refreshTokenRepo:   refreshTokenRepo,
+		jwtProvider:        jwtProvider,
+		hashProvider:       hashProvider,
+		revocationProvider: revocationProvider,
+	}
+}
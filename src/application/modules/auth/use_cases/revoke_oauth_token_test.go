@@ -0,0 +1,112 @@
+package authusecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authmocks "github.com/simon3640/goprojectskeleton/src/application/modules/auth/mocks"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newRevokeOAuthTokenTestUseCase(mocks *oauthTokenReferenceTestMocks) *RevokeOAuthTokenUseCase {
+	return NewRevokeOAuthTokenUseCase(
+		mocks.clientRepository,
+		mocks.refreshTokenRepository,
+		mocks.jwtProvider,
+		mocks.hashProvider,
+		mocks.revocationProvider,
+	)
+}
+
+func TestRevokeOAuthTokenUseCase_AccessToken(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
+	mocks := newOAuthTokenReferenceTestMocks()
+	uc := newRevokeOAuthTokenTestUseCase(mocks)
+
+	claims := oauthAccessTokenClaims()
+	mocks.jwtProvider.On("ParseTokenAndValidate", "accessToken").Return(claims, nil)
+	mocks.revocationProvider.On("RevokeToken", "jti", time.Unix(int64(claims["exp"].(float64)), 0)).Return(nil)
+
+	result := uc.Execute(ctx, locales.EN_US, dtos.OAuthTokenReference{
+		OAuthClientCredentials: oauthTestCredentials,
+		Token:                  "accessToken",
+	})
+
+	assert.True(result.IsSuccess())
+	mocks.revocationProvider.AssertExpectations(t)
+}
+
+func TestRevokeOAuthTokenUseCase_RefreshToken(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
+	mocks := newOAuthTokenReferenceTestMocks()
+	uc := newRevokeOAuthTokenTestUseCase(mocks)
+
+	session := authmocks.RefreshToken()
+	session.ClientID = "client"
+	mocks.jwtProvider.On("ParseTokenAndValidate", validRefreshToken).Return(authcontracts.JWTCLaims{"sub": "1", "typ": "refresh"}, nil)
+	mocks.hashProvider.On("HashOneTimeToken", validRefreshToken).Return(authmocks.RefreshTokenHash)
+	mocks.refreshTokenRepository.On("GetByTokenHash", authmocks.RefreshTokenHash).Return(session, nil)
+	mocks.refreshTokenRepository.On("RevokeFamily", session.FamilyID).Return(nil)
+
+	result := uc.Execute(ctx, locales.EN_US, dtos.OAuthTokenReference{
+		OAuthClientCredentials: oauthTestCredentials,
+		Token:                  validRefreshToken,
+	})
+
+	assert.True(result.IsSuccess())
+	mocks.refreshTokenRepository.AssertCalled(t, "RevokeFamily", session.FamilyID)
+}
+
+func TestRevokeOAuthTokenUseCase_TokenOfAnotherClient(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
+	mocks := newOAuthTokenReferenceTestMocks()
+	uc := newRevokeOAuthTokenTestUseCase(mocks)
+
+	claims := oauthAccessTokenClaims()
+	claims["client_id"] = "other"
+	mocks.jwtProvider.On("ParseTokenAndValidate", "accessToken").Return(claims, nil)
+
+	result := uc.Execute(ctx, locales.EN_US, dtos.OAuthTokenReference{
+		OAuthClientCredentials: oauthTestCredentials,
+		Token:                  "accessToken",
+	})
+
+	assert.True(result.HasError())
+	assert.Equal(status.Unauthorized, result.StatusCode)
+	mocks.revocationProvider.AssertNotCalled(t, "RevokeToken", mock.Anything, mock.Anything)
+}
+
+func TestRevokeOAuthTokenUseCase_InvalidTokenIsRevoked(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
+	mocks := newOAuthTokenReferenceTestMocks()
+	uc := newRevokeOAuthTokenTestUseCase(mocks)
+
+	mocks.jwtProvider.On("ParseTokenAndValidate", "invalid").Return(authcontracts.JWTCLaims(nil), applicationerrors.NewApplicationError(
+		status.Unauthorized,
+		messages.MessageKeysInstance.INVALID_JWT_TOKEN,
+		"invalid token",
+	))
+
+	result := uc.Execute(ctx, locales.EN_US, dtos.OAuthTokenReference{
+		OAuthClientCredentials: oauthTestCredentials,
+		Token:                  "invalid",
+	})
+
+	assert.True(result.IsSuccess())
+	mocks.revocationProvider.AssertNotCalled(t, "RevokeToken", mock.Anything, mock.Anything)
+	mocks.refreshTokenRepository.AssertNotCalled(t, "RevokeFamily", mock.Anything)
+}
@@ -0,0 +1,128 @@
+package authusecases
+
+import (
+	contractproviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+)
+
+// StartOIDCAuthorizationUseCase is the use case for starting a login with an
+// identity provider. It returns the URL of the provider the user must be
+// redirected to, the provider redirects back with a code and the state.
+type StartOIDCAuthorizationUseCase struct {
+	usecase.BaseUseCaseValidation[dtos.OIDCAuthorize, dtos.OIDCAuthorization]
+
+	oidcProvider   authcontracts.IOIDCProvider
+	hashProvider   contractproviders.IHashProvider
+	sessionService *authservices.OIDCSessionService
+}
+
+var _ usecase.BaseUseCase[dtos.OIDCAuthorize, dtos.OIDCAuthorization] = (*StartOIDCAuthorizationUseCase)(nil)
+
+// Execute starts an authorization with the identity provider
+// - Generate the state, nonce and PKCE code verifier of the authorization
+// - Build the authorization URL: the provider must be configured
+// - Store the authorization: it is keyed by the hash of the state and holds
+// the user linking the provider, if any
+func (uc *StartOIDCAuthorizationUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input dtos.OIDCAuthorize,
+) *usecase.UseCaseResult[dtos.OIDCAuthorization] {
+	result := usecase.NewUseCaseResult[dtos.OIDCAuthorization]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	state, stateHash := uc.newToken(result)
+	if result.HasError() {
+		return result
+	}
+	nonce, _ := uc.newToken(result)
+	if result.HasError() {
+		return result
+	}
+	codeVerifier, _ := uc.newToken(result)
+	if result.HasError() {
+		return result
+	}
+
+	authorizationURL, err := uc.oidcProvider.AuthorizationURL(ctx, input.Provider, state, nonce, codeVerifier)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Error building identity provider authorization URL", uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return result
+	}
+
+	session := dtos.OIDCSession{
+		Provider:     input.Provider,
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		UserID:       input.UserID,
+	}
+	if err := uc.sessionService.Start(authservices.OIDCSessionKey(stateHash), session); err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error storing identity provider authorization", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return result
+	}
+
+	result.SetData(
+		status.Success,
+		dtos.OIDCAuthorization{
+			AuthorizationURL: authorizationURL,
+			State:            state,
+		},
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.OIDC_AUTHORIZATION_CREATED,
+		),
+	)
+	observability.GetObservabilityComponents().Logger.InfoWithContext("Identity provider authorization started", uc.AppContext)
+	return result
+}
+
+// newToken generates a random token and its hash
+func (uc *StartOIDCAuthorizationUseCase) newToken(result *usecase.UseCaseResult[dtos.OIDCAuthorization]) (string, []byte) {
+	token, hash, err := uc.hashProvider.OneTimeToken()
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error generating identity provider authorization token", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return "", nil
+	}
+	return token, hash
+}
+
+func (uc *StartOIDCAuthorizationUseCase) setError(result *usecase.UseCaseResult[dtos.OIDCAuthorization], code status.ApplicationStatusEnum, key messages.MessageKeysEnum) {
+	result.SetError(
+		code,
+		uc.AppMessages.Get(
+			uc.Locale,
+			key,
+		),
+	)
+}
+
+func NewStartOIDCAuthorizationUseCase(
+	oidcProvider authcontracts.IOIDCProvider,
+	hashProvider contractproviders.IHashProvider,
+	sessionService *authservices.OIDCSessionService,
+) *StartOIDCAuthorizationUseCase {
+	return &StartOIDCAuthorizationUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[dtos.OIDCAuthorize, dtos.OIDCAuthorization]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(),
+		},
+		oidcProvider:   oidcProvider,
+		hashProvider:   hashProvider,
+		sessionService: sessionService,
+	}
+}
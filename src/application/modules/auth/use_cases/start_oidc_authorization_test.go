@@ -0,0 +1,116 @@
+package authusecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authmocks "github.com/simon3640/goprojectskeleton/src/application/modules/auth/mocks"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const oidcTestTTL = 10 * time.Minute
+
+var oidcTestStateHash = []byte("state_hash")
+
+func newOIDCTestSessions() (*authservices.OIDCSessionService, *providersmocks.MockCacheProvider) {
+	cache := new(providersmocks.MockCacheProvider)
+	return authservices.NewOIDCSessionService(cache, oidcTestTTL), cache
+}
+
+// mockPendingOIDCSession mocks a pending authorization of the user with the
+// provider under the key, claims is the number of requests that consumed it
+func mockPendingOIDCSession(cache *providersmocks.MockCacheProvider, key string, userID uint, claims int64) {
+	cache.On("Get", key, mock.Anything).Return(true, nil).Run(func(args mock.Arguments) {
+		session := args.Get(1).(*dtos.OIDCSession)
+		session.Provider = "google"
+		session.Nonce = "nonce"
+		session.CodeVerifier = "verifier"
+		session.UserID = userID
+		session.ExpiresAt = time.Now().Add(oidcTestTTL)
+	})
+	cache.On("Increment", key+":used", oidcTestTTL).Return(claims, nil)
+	cache.On("Delete", key).Return(nil)
+}
+
+func TestStartOIDCAuthorizationUseCase(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
+
+	testOIDCProvider := new(authmocks.MockOIDCProvider)
+	testHashProvider := new(providersmocks.MockHashProvider)
+	sessions, cache := newOIDCTestSessions()
+	uc := NewStartOIDCAuthorizationUseCase(testOIDCProvider, testHashProvider, sessions)
+
+	testHashProvider.On("OneTimeToken").Return("state", oidcTestStateHash, nil).Once()
+	testHashProvider.On("OneTimeToken").Return("nonce", []byte("nonce_hash"), nil).Once()
+	testHashProvider.On("OneTimeToken").Return("verifier", []byte("verifier_hash"), nil).Once()
+	testOIDCProvider.On("AuthorizationURL", ctx, "google", "state", "nonce", "verifier").Return("https://accounts.example.com/authorize?state=state", nil)
+	cache.On("Set", authservices.OIDCSessionKey(oidcTestStateHash), mock.MatchedBy(func(session dtos.OIDCSession) bool {
+		return session.Provider == "google" && session.Nonce == "nonce" && session.CodeVerifier == "verifier" && session.UserID == 0
+	}), oidcTestTTL).Return(nil)
+
+	result := uc.Execute(ctx, locales.EN_US, dtos.OIDCAuthorize{Provider: "google"})
+
+	assert.True(result.IsSuccess())
+	assert.Equal("https://accounts.example.com/authorize?state=state", result.Data.AuthorizationURL)
+	assert.Equal("state", result.Data.State)
+	cache.AssertExpectations(t)
+}
+
+func TestStartOIDCAuthorizationUseCase_Link(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
+
+	testOIDCProvider := new(authmocks.MockOIDCProvider)
+	testHashProvider := new(providersmocks.MockHashProvider)
+	sessions, cache := newOIDCTestSessions()
+	uc := NewStartOIDCAuthorizationUseCase(testOIDCProvider, testHashProvider, sessions)
+
+	testHashProvider.On("OneTimeToken").Return("token", oidcTestStateHash, nil)
+	testOIDCProvider.On("AuthorizationURL", ctx, "google", "token", "token", "token").Return("https://accounts.example.com/authorize", nil)
+	cache.On("Set", authservices.OIDCSessionKey(oidcTestStateHash), mock.MatchedBy(func(session dtos.OIDCSession) bool {
+		return session.UserID == 1
+	}), oidcTestTTL).Return(nil)
+
+	result := uc.Execute(ctx, locales.EN_US, dtos.OIDCAuthorize{Provider: "google", UserID: 1})
+
+	assert.True(result.IsSuccess())
+	cache.AssertExpectations(t)
+}
+
+func TestStartOIDCAuthorizationUseCase_Errors(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
+
+	testOIDCProvider := new(authmocks.MockOIDCProvider)
+	testHashProvider := new(providersmocks.MockHashProvider)
+	sessions, cache := newOIDCTestSessions()
+	uc := NewStartOIDCAuthorizationUseCase(testOIDCProvider, testHashProvider, sessions)
+
+	result := uc.Execute(ctx, locales.EN_US, dtos.OIDCAuthorize{})
+	assert.True(result.HasError())
+	assert.Equal(status.InvalidInput, result.StatusCode)
+
+	testHashProvider.On("OneTimeToken").Return("token", oidcTestStateHash, nil)
+	testOIDCProvider.On("AuthorizationURL", ctx, "unknown", "token", "token", "token").Return("", applicationerrors.NewApplicationError(
+		status.NotFound,
+		messages.MessageKeysInstance.OIDC_PROVIDER_NOT_FOUND,
+		"oidc provider is not configured",
+	))
+
+	result = uc.Execute(ctx, locales.EN_US, dtos.OIDCAuthorize{Provider: "unknown"})
+	assert.True(result.HasError())
+	assert.Equal(status.NotFound, result.StatusCode)
+	cache.AssertNotCalled(t, "Set", mock.Anything, mock.Anything, mock.Anything)
+}
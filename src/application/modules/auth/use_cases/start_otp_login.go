@@ -0,0 +1,118 @@
+package authusecases
+
+import (
+	contractproviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	services "github.com/simon3640/goprojectskeleton/src/application/shared/services"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
+
+// StartOTPLoginUseCase is the use case starting the OTP login of the users
+// with OTP login enabled, once their credentials are verified
+type StartOTPLoginUseCase struct {
+	usecase.BaseUseCaseValidation[dtos.AuthenticatedUser, dtos.AuthResponse]
+
+	otpRepo      authcontracts.IOneTimePasswordRepository
+	hashProvider contractproviders.IHashProvider
+}
+
+var _ usecase.BaseUseCase[dtos.AuthenticatedUser, dtos.AuthResponse] = (*StartOTPLoginUseCase)(nil)
+
+// Execute execute the use case
+// - Create the OTP: create the OTP of the user bound to a new login transaction
+// - Send the OTP: send the OTP by email in background
+// - Set the success result: set the transaction ID, the OTP is only accepted together with it
+// - Return the result: return the result
+func (uc *StartOTPLoginUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input dtos.AuthenticatedUser,
+) *usecase.UseCaseResult[dtos.AuthResponse] {
+	result := usecase.NewUseCaseResult[dtos.AuthResponse]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	otp, transactionID, err := authservices.CreateOneTimePasswordService(
+		input.User.ID,
+		sharedmodels.OneTimePasswordLogin,
+		uc.hashProvider,
+		uc.otpRepo,
+	)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error creating OTP login transaction", err.ToError(), uc.AppContext)
+		result.SetError(
+			status.InternalError,
+			uc.AppMessages.Get(
+				uc.Locale,
+				messages.MessageKeysInstance.SOMETHING_WENT_WRONG,
+			),
+		)
+		return result
+	}
+
+	uc.sendOTPEmailInBackground(ctx, &input.User, otp, locale)
+	result.SetData(
+		status.Success,
+		dtos.AuthResponse{RequiresOTP: true, TransactionID: transactionID},
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.OTP_LOGIN_ENABLED,
+		),
+	)
+	return result
+}
+
+// sendOTPEmailInBackground sends an OTP email to the user in the background
+func (uc *StartOTPLoginUseCase) sendOTPEmailInBackground(
+	ctx *app_context.AppContext,
+	user *usermodels.UserWithRole,
+	otp string,
+	locale locales.LocaleTypeEnum,
+) {
+	// Create the background service
+	observability.GetObservabilityComponents().Logger.InfoWithContext("Creating OTP email background service", ctx)
+	sendOTPService := authservices.NewSendOTPEmailBackgroundService(
+		observability.GetObservabilityComponents(),
+	)
+
+	// Prepare the input
+	input := authservices.SendOTPEmailInput{
+		UserID:   user.ID,
+		Email:    user.Email,
+		UserName: user.Name,
+		OTP:      otp,
+	}
+
+	// Execute the service in background (fire-and-forget)
+	if err := services.ExecuteBackgroundService(sendOTPService, ctx, locale, input); err != nil {
+		// Log error but don't fail the authentication
+		// The service will log its own errors internally
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error submitting OTP email service to background executor", err, ctx)
+	}
+}
+
+func NewStartOTPLoginUseCase(
+	otpRepo authcontracts.IOneTimePasswordRepository,
+	hashProvider contractproviders.IHashProvider,
+) *StartOTPLoginUseCase {
+	return &StartOTPLoginUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[dtos.AuthenticatedUser, dtos.AuthResponse]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(),
+		},
+		otpRepo:      otpRepo,
+		hashProvider: hashProvider,
+	}
+}
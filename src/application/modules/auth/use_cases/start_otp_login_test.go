@@ -0,0 +1,140 @@
+package authusecases
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authmocks "github.com/simon3640/goprojectskeleton/src/application/modules/auth/mocks"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
+	services "github.com/simon3640/goprojectskeleton/src/application/shared/services"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/workers"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// useBackgroundServices initializes the background executor and service
+// factory of a test and resets them when it finishes
+func useBackgroundServices(t *testing.T) {
+	workers.ResetBackgroundExecutorSingleton()
+	services.ResetBackgroundServiceFactory()
+	workers.InitializeBackgroundExecutor(context.Background(), 2, 10)
+	services.InitializeBackgroundServiceFactory()
+	t.Cleanup(func() {
+		services.ResetBackgroundServiceFactory()
+		workers.ResetBackgroundExecutorSingleton()
+	})
+}
+
+func otpLoginUser() dtos.AuthenticatedUser {
+	user := dtomocks.UserWithRole
+	user.OTPLogin = true
+	return dtos.AuthenticatedUser{User: user}
+}
+
+func TestStartOTPLoginUseCase(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
+	useBackgroundServices(t)
+
+	testHashProvider := new(providersmocks.MockHashProvider)
+	testOTPRepository := new(authmocks.MockOneTimePasswordRepository)
+
+	uc := NewStartOTPLoginUseCase(testOTPRepository, testHashProvider)
+
+	// The OTP is created with its login transaction before responding
+	testHashProvider.On("GenerateOTP").Return("123456", []byte("hashedOTP"), nil)
+	testHashProvider.On("OneTimeToken").Return("transaction", authmocks.OTPTransactionHash, nil)
+	testOTPRepository.On("Create", mock.MatchedBy(func(create dtos.OneTimePasswordCreate) bool {
+		return string(create.Hash) == "hashedOTP" && string(create.TransactionHash) == string(authmocks.OTPTransactionHash)
+	})).Return(&sharedmodels.OneTimePassword{}, nil)
+
+	// Track execution time to verify it doesn't block
+	startTime := time.Now()
+	result := uc.Execute(ctx, locales.EN_US, otpLoginUser())
+	executionTime := time.Since(startTime)
+
+	// The execution should not wait for the background email service
+	assert.True(executionTime < 50*time.Millisecond, "Execution should not block, took %v", executionTime)
+	assert.NotNil(result)
+	assert.True(result.IsSuccess())
+	assert.Nil(result.Data.Token, "Result should not contain token when OTP login is enabled")
+	assert.True(result.Data.RequiresOTP)
+	assert.Equal("transaction", result.Data.TransactionID)
+	assert.NotEmpty(result.Details, "Result should have details indicating OTP login is enabled")
+
+	// Wait for the background service, it may fail due to the uninitialized email service
+	time.Sleep(200 * time.Millisecond)
+
+	testHashProvider.AssertExpectations(t)
+	testOTPRepository.AssertExpectations(t)
+}
+
+func TestStartOTPLoginUseCase_NonBlocking(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
+	useBackgroundServices(t)
+
+	testHashProvider := new(providersmocks.MockHashProvider)
+	testOTPRepository := new(authmocks.MockOneTimePasswordRepository)
+
+	uc := NewStartOTPLoginUseCase(testOTPRepository, testHashProvider)
+
+	testHashProvider.On("GenerateOTP").Return("123456", []byte("hashedOTP"), nil)
+	testHashProvider.On("OneTimeToken").Return("transaction", authmocks.OTPTransactionHash, nil)
+	testOTPRepository.On("Create", mock.Anything).Return(&sharedmodels.OneTimePassword{}, nil)
+
+	// Execute multiple times to verify non-blocking behavior
+	var wg sync.WaitGroup
+	numExecutions := 5
+	results := make([]*usecase.UseCaseResult[dtos.AuthResponse], numExecutions)
+	executionTimes := make([]time.Duration, numExecutions)
+
+	for i := 0; i < numExecutions; i++ {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			startTime := time.Now()
+			results[index] = uc.Execute(ctx, locales.EN_US, otpLoginUser())
+			executionTimes[index] = time.Since(startTime)
+		}(i)
+	}
+
+	wg.Wait()
+
+	for i, execTime := range executionTimes {
+		assert.True(execTime < 50*time.Millisecond, "Execution %d should not block, took %v", i, execTime)
+		assert.NotNil(results[i], "Result %d should not be nil", i)
+		assert.True(results[i].IsSuccess(), "Result %d should be successful", i)
+	}
+
+	// Wait for background services to complete
+	time.Sleep(200 * time.Millisecond)
+}
+
+func TestStartOTPLoginUseCase_OTPNotCreated(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
+
+	testHashProvider := new(providersmocks.MockHashProvider)
+	testOTPRepository := new(authmocks.MockOneTimePasswordRepository)
+
+	uc := NewStartOTPLoginUseCase(testOTPRepository, testHashProvider)
+
+	testHashProvider.On("GenerateOTP").Return("123456", []byte("hashedOTP"), nil)
+	testHashProvider.On("OneTimeToken").Return("transaction", authmocks.OTPTransactionHash, nil)
+	testOTPRepository.On("Create", mock.Anything).Return((*sharedmodels.OneTimePassword)(nil), notFoundError())
+
+	result := uc.Execute(ctx, locales.EN_US, otpLoginUser())
+	assert.False(result.IsSuccess())
+	assert.Equal(status.InternalError, result.StatusCode)
+}
@@ -0,0 +1,225 @@
+package authusecases
+
+import (
+	"time"
+
+	contractproviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	contracts_repositories "github.com/simon3640/goprojectskeleton/src/application/contracts/repositories"
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/guards"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
+
+// SwitchTenantUseCase is the use case for switching the organization the user
+// acts in. It returns an access token carrying the new tenant. When the
+// refresh token of the session is given it is rotated into one of the new
+// tenant, so the refreshes keep acting in it, otherwise the session is kept
+// and a refresh returns to the organization of the session.
+type SwitchTenantUseCase struct {
+	usecase.BaseUseCaseValidation[dtos.TenantSwitch, dtos.Token]
+
+	organizationRepo contracts_repositories.IOrganizationRepository
+	refreshTokenRepo authcontracts.IRefreshTokenRepository
+
+	jwtProvider  authcontracts.IJWTProvider
+	hashProvider contractproviders.IHashProvider
+}
+
+var _ usecase.BaseUseCase[dtos.TenantSwitch, dtos.Token] = (*SwitchTenantUseCase)(nil)
+
+// Execute switches the tenant
+// - Members switch to their organizations, super-admins to any organization
+// - The organization must be active
+// - Issue an access token for the organization
+// - Rotate the refresh token given into one of the organization
+func (uc *SwitchTenantUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input dtos.TenantSwitch,
+) *usecase.UseCaseResult[dtos.Token] {
+	result := usecase.NewUseCaseResult[dtos.Token]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	if input.OrganizationID != 0 {
+		uc.checkOrganization(result, input.OrganizationID)
+		if result.HasError() {
+			return result
+		}
+	}
+
+	user := *uc.AppContext.User
+	user.SetTenant(input.OrganizationID)
+	token := uc.generateAccessToken(result, &user)
+	if result.HasError() {
+		return result
+	}
+
+	if input.RefreshToken != "" {
+		uc.renewSession(result, &token, input)
+		if result.HasError() {
+			return result
+		}
+	}
+
+	result.SetData(
+		status.Success,
+		token,
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.TENANT_SWITCHED,
+		),
+	)
+	observability.GetObservabilityComponents().Logger.InfoWithContext("Tenant switched successfully", uc.AppContext)
+	return result
+}
+
+// checkOrganization checks that the organization exists and is active
+func (uc *SwitchTenantUseCase) checkOrganization(result *usecase.UseCaseResult[dtos.Token], organizationID uint) {
+	organization, err := uc.organizationRepo.GetByID(organizationID)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting organization", err.ToError(), uc.AppContext)
+		result.SetError(err.Code, uc.AppMessages.Get(uc.Locale, err.Context))
+		return
+	}
+	if !organization.IsActive {
+		result.SetError(
+			status.Unauthorized,
+			uc.AppMessages.Get(
+				uc.Locale,
+				messages.MessageKeysInstance.ORGANIZATION_INACTIVE,
+			),
+		)
+	}
+}
+
+// renewSession rotates the refresh token of the session of the user into one
+// acting in the organization. Tokens of another user, of an OAuth client or
+// no longer active are rejected, a token already rotated revokes the session.
+func (uc *SwitchTenantUseCase) renewSession(result *usecase.UseCaseResult[dtos.Token], token *dtos.Token, input dtos.TenantSwitch) {
+	session, err := uc.refreshTokenRepo.GetByTokenHash(uc.hashProvider.HashOneTimeToken(input.RefreshToken))
+	if err != nil && err.Code != status.NotFound {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting refresh token by hash", err.ToError(), uc.AppContext)
+		result.SetError(err.Code, uc.AppMessages.Get(uc.Locale, err.Context))
+		return
+	}
+	if session == nil || session.UserID != uc.AppContext.User.ID || session.ClientID != "" {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Refresh token of another session presented on tenant switch", uc.AppContext)
+		uc.setInvalidSession(result)
+		return
+	}
+	if !uc.rotateSession(result, session) {
+		return
+	}
+
+	refresh, expRefresh, err := authservices.RenewRefreshTokenService(
+		uc.AppContext,
+		session,
+		input.OrganizationID,
+		uc.jwtProvider,
+		uc.hashProvider,
+		uc.refreshTokenRepo,
+	)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error generating refresh token", err.ToError(), uc.AppContext)
+		result.SetError(
+			status.InternalError,
+			uc.AppMessages.Get(
+				uc.Locale,
+				messages.MessageKeysInstance.SOMETHING_WENT_WRONG,
+			),
+		)
+		return
+	}
+	token.RefreshToken = refresh
+	token.RefreshTokenExpiresAt = expRefresh
+}
+
+// rotateSession invalidates the refresh token of the session and reports
+// whether it was rotated. A token that was already rotated is being reused,
+// the whole session is revoked.
+func (uc *SwitchTenantUseCase) rotateSession(result *usecase.UseCaseResult[dtos.Token], session *sharedmodels.RefreshToken) bool {
+	if session.IsRevoked || session.Expires.Before(time.Now()) {
+		uc.setInvalidSession(result)
+		return false
+	}
+	if !session.IsRotated {
+		rotated, err := uc.refreshTokenRepo.Rotate(session.ID)
+		if err != nil {
+			observability.GetObservabilityComponents().Logger.ErrorWithContext("Error rotating refresh token", err.ToError(), uc.AppContext)
+			result.SetError(err.Code, uc.AppMessages.Get(uc.Locale, err.Context))
+			return false
+		}
+		if rotated {
+			return true
+		}
+	}
+	observability.GetObservabilityComponents().Logger.WarningWithContext("Refresh token reuse detected, revoking session", uc.AppContext)
+	if err := uc.refreshTokenRepo.RevokeFamily(session.FamilyID); err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error revoking refresh token family", err.ToError(), uc.AppContext)
+	}
+	uc.setInvalidSession(result)
+	return false
+}
+
+func (uc *SwitchTenantUseCase) setInvalidSession(result *usecase.UseCaseResult[dtos.Token]) {
+	result.SetError(
+		status.Unauthorized,
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.INVALID_SESSION,
+		),
+	)
+}
+
+func (uc *SwitchTenantUseCase) generateAccessToken(result *usecase.UseCaseResult[dtos.Token], user *usermodels.UserWithRole) dtos.Token {
+	claims := authservices.BuildAccessClaimsService(user)
+	access, exp, err := uc.jwtProvider.GenerateAccessToken(uc.AppContext, user.GetUserIDString(), claims)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error generating access token", err.ToError(), uc.AppContext)
+		result.SetError(
+			status.InternalError,
+			uc.AppMessages.Get(
+				uc.Locale,
+				messages.MessageKeysInstance.AUTHORIZATION_GENERATED,
+			),
+		)
+		return dtos.Token{}
+	}
+	return dtos.Token{
+		AccessToken:          access,
+		TokenType:            "Bearer",
+		AccessTokenExpiresAt: exp,
+	}
+}
+
+// NewSwitchTenantUseCase creates a new switch tenant use case
+func NewSwitchTenantUseCase(
+	organizationRepo contracts_repositories.IOrganizationRepository,
+	refreshTokenRepo authcontracts.IRefreshTokenRepository,
+	jwtProvider authcontracts.IJWTProvider,
+	hashProvider contractproviders.IHashProvider,
+) *SwitchTenantUseCase {
+	return &SwitchTenantUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[dtos.TenantSwitch, dtos.Token]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(guards.TenantMemberGuard[dtos.TenantSwitch]()),
+		},
+		organizationRepo: organizationRepo,
+		refreshTokenRepo: refreshTokenRepo,
+		jwtProvider:      jwtProvider,
+		hashProvider:     hashProvider,
+	}
+}
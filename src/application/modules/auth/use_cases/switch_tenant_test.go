@@ -0,0 +1,190 @@
+package authusecases
+
+import (
+	"testing"
+	"time"
+
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authmocks "github.com/simon3640/goprojectskeleton/src/application/modules/auth/mocks"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
+	repositoriesmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/repositories"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// organizationMember returns a user member of the organizations, acting in
+// the first one
+func organizationMember(organizations ...uint) usermodels.UserWithRole {
+	user := dtomocks.UserWithRole
+	user.SetOrganizations(organizations)
+	user.SetTenant(organizations[0])
+	return user
+}
+
+func TestSwitchTenantUseCase(t *testing.T) {
+	assert := assert.New(t)
+
+	user := organizationMember(1, 2)
+	ctx := app_context.NewContextWithUser(&user)
+
+	testOrganizationRepository := new(repositoriesmocks.MockOrganizationRepository)
+	testJWTProvider := new(authmocks.MockJWTProvider)
+	testOrganizationRepository.On("GetByID", uint(2)).Return(&usermodels.Organization{
+		OrganizationBase: usermodels.OrganizationBase{Name: "Acme", Slug: "acme", IsActive: true},
+		ID:               2,
+	}, nil)
+	testJWTProvider.On("GenerateAccessToken", mock.Anything, "1", mock.MatchedBy(func(claims authcontracts.JWTCLaims) bool {
+		return claims["tenant"] == uint(2)
+	})).Return("access", time.Now().Add(time.Hour), nil)
+
+	uc := NewSwitchTenantUseCase(testOrganizationRepository, nil, testJWTProvider, nil)
+	result := uc.Execute(ctx, locales.EN_US, dtos.TenantSwitch{OrganizationID: 2})
+
+	assert.True(result.IsSuccess())
+	assert.Equal("access", result.Data.AccessToken)
+	assert.Empty(result.Data.RefreshToken)
+	// The user of the request keeps its tenant
+	tenantID, _ := user.GetTenantID()
+	assert.Equal(uint(1), tenantID)
+}
+
+func TestSwitchTenantUseCase_RefreshToken(t *testing.T) {
+	assert := assert.New(t)
+
+	user := organizationMember(1, 2)
+	ctx := app_context.NewContextWithUser(&user)
+
+	testOrganizationRepository := new(repositoriesmocks.MockOrganizationRepository)
+	testRefreshTokenRepository := new(authmocks.MockRefreshTokenRepository)
+	testJWTProvider := new(authmocks.MockJWTProvider)
+	testHashProvider := new(providersmocks.MockHashProvider)
+	session := authmocks.RefreshToken()
+	testOrganizationRepository.On("GetByID", uint(2)).Return(&usermodels.Organization{
+		OrganizationBase: usermodels.OrganizationBase{Name: "Acme", Slug: "acme", IsActive: true},
+		ID:               2,
+	}, nil)
+	testJWTProvider.On("GenerateAccessToken", mock.Anything, "1", mock.Anything).Return("access", time.Now().Add(time.Hour), nil)
+	testHashProvider.On("HashOneTimeToken", "refresh").Return(authmocks.RefreshTokenHash)
+	testRefreshTokenRepository.On("GetByTokenHash", authmocks.RefreshTokenHash).Return(session, nil)
+	testRefreshTokenRepository.On("Rotate", session.ID).Return(true, nil)
+	testJWTProvider.On("GenerateRefreshToken", mock.Anything, "1").Return("newRefresh", time.Now().Add(24*time.Hour), nil)
+	testHashProvider.On("HashOneTimeToken", "newRefresh").Return([]byte("hashed_new_refresh"))
+	testRefreshTokenRepository.On("Create", mock.MatchedBy(func(create dtos.RefreshTokenCreate) bool {
+		return create.FamilyID == session.FamilyID && create.TenantID == 2
+	})).Return(authmocks.RefreshToken(), nil)
+
+	uc := NewSwitchTenantUseCase(testOrganizationRepository, testRefreshTokenRepository, testJWTProvider, testHashProvider)
+	result := uc.Execute(ctx, locales.EN_US, dtos.TenantSwitch{OrganizationID: 2, RefreshToken: "refresh"})
+
+	assert.True(result.IsSuccess())
+	assert.Equal("newRefresh", result.Data.RefreshToken)
+	testRefreshTokenRepository.AssertExpectations(t)
+}
+
+func TestSwitchTenantUseCase_RefreshTokenOfAnotherUser(t *testing.T) {
+	assert := assert.New(t)
+
+	user := organizationMember(1, 2)
+	ctx := app_context.NewContextWithUser(&user)
+
+	testOrganizationRepository := new(repositoriesmocks.MockOrganizationRepository)
+	testRefreshTokenRepository := new(authmocks.MockRefreshTokenRepository)
+	testJWTProvider := new(authmocks.MockJWTProvider)
+	testHashProvider := new(providersmocks.MockHashProvider)
+	session := authmocks.RefreshToken()
+	session.UserID = 2
+	testOrganizationRepository.On("GetByID", uint(2)).Return(&usermodels.Organization{
+		OrganizationBase: usermodels.OrganizationBase{Name: "Acme", Slug: "acme", IsActive: true},
+		ID:               2,
+	}, nil)
+	testJWTProvider.On("GenerateAccessToken", mock.Anything, "1", mock.Anything).Return("access", time.Now().Add(time.Hour), nil)
+	testHashProvider.On("HashOneTimeToken", "refresh").Return(authmocks.RefreshTokenHash)
+	testRefreshTokenRepository.On("GetByTokenHash", authmocks.RefreshTokenHash).Return(session, nil)
+
+	uc := NewSwitchTenantUseCase(testOrganizationRepository, testRefreshTokenRepository, testJWTProvider, testHashProvider)
+	result := uc.Execute(ctx, locales.EN_US, dtos.TenantSwitch{OrganizationID: 2, RefreshToken: "refresh"})
+
+	assert.True(result.HasError())
+	assert.Equal(status.Unauthorized, result.StatusCode)
+	testRefreshTokenRepository.AssertNotCalled(t, "Rotate", mock.Anything)
+}
+
+func TestSwitchTenantUseCase_NotMember(t *testing.T) {
+	assert := assert.New(t)
+
+	user := organizationMember(1)
+	ctx := app_context.NewContextWithUser(&user)
+
+	testOrganizationRepository := new(repositoriesmocks.MockOrganizationRepository)
+	testJWTProvider := new(authmocks.MockJWTProvider)
+
+	uc := NewSwitchTenantUseCase(testOrganizationRepository, nil, testJWTProvider, nil)
+	result := uc.Execute(ctx, locales.EN_US, dtos.TenantSwitch{OrganizationID: 2})
+
+	assert.True(result.HasError())
+	assert.Equal(status.Unauthorized, result.StatusCode)
+	testJWTProvider.AssertNotCalled(t, "GenerateAccessToken")
+}
+
+func TestSwitchTenantUseCase_InactiveOrganization(t *testing.T) {
+	assert := assert.New(t)
+
+	user := organizationMember(1, 2)
+	ctx := app_context.NewContextWithUser(&user)
+
+	testOrganizationRepository := new(repositoriesmocks.MockOrganizationRepository)
+	testJWTProvider := new(authmocks.MockJWTProvider)
+	testOrganizationRepository.On("GetByID", uint(2)).Return(&usermodels.Organization{ID: 2}, nil)
+
+	uc := NewSwitchTenantUseCase(testOrganizationRepository, nil, testJWTProvider, nil)
+	result := uc.Execute(ctx, locales.EN_US, dtos.TenantSwitch{OrganizationID: 2})
+
+	assert.True(result.HasError())
+	assert.Equal(status.Unauthorized, result.StatusCode)
+	testJWTProvider.AssertNotCalled(t, "GenerateAccessToken")
+}
+
+func TestSwitchTenantUseCase_SuperAdmin(t *testing.T) {
+	assert := assert.New(t)
+
+	user := dtomocks.AdminUserWithRole
+	ctx := app_context.NewContextWithUser(&user)
+
+	testOrganizationRepository := new(repositoriesmocks.MockOrganizationRepository)
+	testJWTProvider := new(authmocks.MockJWTProvider)
+	testOrganizationRepository.On("GetByID", uint(7)).Return(&usermodels.Organization{
+		OrganizationBase: usermodels.OrganizationBase{Name: "Other", Slug: "other", IsActive: true},
+		ID:               7,
+	}, nil)
+	testJWTProvider.On("GenerateAccessToken", mock.Anything, "2", mock.Anything).Return("access", time.Now().Add(time.Hour), nil)
+
+	uc := NewSwitchTenantUseCase(testOrganizationRepository, nil, testJWTProvider, nil)
+	result := uc.Execute(ctx, locales.EN_US, dtos.TenantSwitch{OrganizationID: 7})
+
+	assert.True(result.IsSuccess())
+}
+
+func TestSwitchTenantUseCase_Delegated(t *testing.T) {
+	assert := assert.New(t)
+
+	user := organizationMember(1, 2)
+	user.SetScopes([]string{sharedmodels.OAuthScopeProfile})
+	ctx := app_context.NewContextWithUser(&user)
+
+	testOrganizationRepository := new(repositoriesmocks.MockOrganizationRepository)
+	testJWTProvider := new(authmocks.MockJWTProvider)
+
+	uc := NewSwitchTenantUseCase(testOrganizationRepository, nil, testJWTProvider, nil)
+	result := uc.Execute(ctx, locales.EN_US, dtos.TenantSwitch{OrganizationID: 2})
+
+	assert.True(result.HasError())
+	assert.Equal(status.Unauthorized, result.StatusCode)
+}
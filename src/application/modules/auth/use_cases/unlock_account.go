@@ -0,0 +1,129 @@
+package authusecases
+
+import (
+	"time"
+
+	contractproviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	contractrepositories "github.com/simon3640/goprojectskeleton/src/application/contracts/repositories"
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
+	shareddtos "github.com/simon3640/goprojectskeleton/src/application/shared/DTOs"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// UnlockAccountUseCase is the use case for unlocking an account locked after
+// too many failed logins, with the token of the link emailed to its user
+type UnlockAccountUseCase struct {
+	usecase.BaseUseCaseValidation[dtos.AccountUnlock, bool]
+
+	tokenRepo contractrepositories.IOneTimeTokenRepository
+	userRepo  authcontracts.IUserRepository
+
+	hashProvider contractproviders.IHashProvider
+	throttle     *authservices.LoginThrottleService
+}
+
+var _ usecase.BaseUseCase[dtos.AccountUnlock, bool] = (*UnlockAccountUseCase)(nil)
+
+// Execute clears the failed logins of the account and marks the token as used
+func (uc *UnlockAccountUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input dtos.AccountUnlock,
+) *usecase.UseCaseResult[bool] {
+	result := usecase.NewUseCaseResult[bool]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	token := uc.getToken(result, input.Token)
+	if result.HasError() {
+		return result
+	}
+
+	user, err := uc.userRepo.GetUserWithRole(token.UserID)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting user of the account unlock token", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return result
+	}
+
+	if err := uc.throttle.Unlock(user.Email); err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error unlocking account", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return result
+	}
+
+	if _, err := uc.tokenRepo.Update(token.ID, shareddtos.OneTimeTokenUpdate{IsUsed: true, ID: token.ID}); err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error updating one time token as used", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return result
+	}
+
+	result.SetData(
+		status.Success,
+		true,
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.ACCOUNT_UNLOCKED,
+		),
+	)
+	observability.GetObservabilityComponents().Logger.InfoWithContext("Account unlocked successfully", uc.AppContext)
+	return result
+}
+
+// getToken returns the unlock token of the input, it must not be used nor expired
+func (uc *UnlockAccountUseCase) getToken(result *usecase.UseCaseResult[bool], token string) *sharedmodels.OneTimeToken {
+	oneTimeToken, err := uc.tokenRepo.GetByTokenHash(uc.hashProvider.HashOneTimeToken(token))
+	if err != nil && err.Code != status.NotFound {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting one time token by hash", err.ToError(), uc.AppContext)
+		uc.setError(result, err.Code, err.Context)
+		return nil
+	}
+
+	if oneTimeToken == nil || oneTimeToken.IsUsed || oneTimeToken.Expires.Before(time.Now()) ||
+		oneTimeToken.Purpose != sharedmodels.OneTimeTokenPurposeAccountUnlock {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Account unlock token is not valid", uc.AppContext)
+		uc.setError(result, status.Conflict, messages.MessageKeysInstance.INVALID_ACCOUNT_UNLOCK_TOKEN)
+		return nil
+	}
+	return oneTimeToken
+}
+
+func (uc *UnlockAccountUseCase) setError(result *usecase.UseCaseResult[bool], code status.ApplicationStatusEnum, key messages.MessageKeysEnum) {
+	result.SetError(
+		code,
+		uc.AppMessages.Get(
+			uc.Locale,
+			key,
+		),
+	)
+}
+
+// NewUnlockAccountUseCase creates a new unlock account use case
+func NewUnlockAccountUseCase(
+	tokenRepo contractrepositories.IOneTimeTokenRepository,
+	userRepo authcontracts.IUserRepository,
+	hashProvider contractproviders.IHashProvider,
+	cacheProvider contractproviders.ICacheProvider,
+) *UnlockAccountUseCase {
+	return &UnlockAccountUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[dtos.AccountUnlock, bool]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(),
+		},
+		tokenRepo:    tokenRepo,
+		userRepo:     userRepo,
+		hashProvider: hashProvider,
+		throttle:     authservices.NewLoginThrottleService(cacheProvider),
+	}
+}
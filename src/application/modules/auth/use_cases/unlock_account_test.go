@@ -0,0 +1,139 @@
+package authusecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authmocks "github.com/simon3640/goprojectskeleton/src/application/modules/auth/mocks"
+	shareddtos "github.com/simon3640/goprojectskeleton/src/application/shared/DTOs"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
+	repositoriesmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/repositories"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func accountUnlockToken() *sharedmodels.OneTimeToken {
+	return &sharedmodels.OneTimeToken{
+		OneTimeTokenBase: sharedmodels.OneTimeTokenBase{
+			UserID:  1,
+			Purpose: sharedmodels.OneTimeTokenPurposeAccountUnlock,
+			Hash:    []byte("unlock_hash"),
+			Expires: time.Now().Add(15 * time.Minute),
+		},
+		DBBaseModel: sharedmodels.DBBaseModel{ID: 1},
+	}
+}
+
+func TestUnlockAccountUseCase(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
+
+	testTokenRepository := new(repositoriesmocks.MockOneTimeTokenRepository)
+	testUserRepository := new(authmocks.MockUserRepository)
+	testHashProvider := new(providersmocks.MockHashProvider)
+	cacheProvider := providersmocks.NewMemoryCacheProvider()
+	uc := NewUnlockAccountUseCase(testTokenRepository, testUserRepository, testHashProvider, cacheProvider)
+
+	email := dtomocks.UserWithRole.Email
+	_ = cacheProvider.Set("login_attempts:"+email, 20, time.Hour)
+
+	testHashProvider.On("HashOneTimeToken", "unlock_token").Return([]byte("unlock_hash"))
+	testTokenRepository.On("GetByTokenHash", []byte("unlock_hash")).Return(accountUnlockToken(), nil)
+	testUserRepository.On("GetUserWithRole", uint(1)).Return(&dtomocks.UserWithRole, nil)
+	testTokenRepository.On("Update", uint(1), shareddtos.OneTimeTokenUpdate{IsUsed: true, ID: 1}).Return(accountUnlockToken(), nil)
+
+	result := uc.Execute(ctx, locales.EN_US, dtos.AccountUnlock{Token: "unlock_token"})
+
+	assert.True(result.IsSuccess())
+	assert.True(*result.Data)
+	count, _ := cacheProvider.GetInt64("login_attempts:" + email)
+	assert.Zero(count)
+	testTokenRepository.AssertExpectations(t)
+}
+
+func TestUnlockAccountUseCase_InvalidToken(t *testing.T) {
+	cases := []struct {
+		name  string
+		token func() *sharedmodels.OneTimeToken
+		err   *applicationerrors.ApplicationError
+	}{
+		{
+			name: "used token",
+			token: func() *sharedmodels.OneTimeToken {
+				token := accountUnlockToken()
+				token.IsUsed = true
+				return token
+			},
+		},
+		{
+			name: "expired token",
+			token: func() *sharedmodels.OneTimeToken {
+				token := accountUnlockToken()
+				token.Expires = time.Now().Add(-time.Minute)
+				return token
+			},
+		},
+		{
+			name: "token of another purpose",
+			token: func() *sharedmodels.OneTimeToken {
+				token := accountUnlockToken()
+				token.Purpose = sharedmodels.OneTimeTokenPurposePasswordReset
+				return token
+			},
+		},
+		{
+			name:  "unknown token",
+			token: func() *sharedmodels.OneTimeToken { return nil },
+			err:   notFoundError(),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			ctx := &app_context.AppContext{Context: context.Background()}
+
+			testTokenRepository := new(repositoriesmocks.MockOneTimeTokenRepository)
+			testUserRepository := new(authmocks.MockUserRepository)
+			testHashProvider := new(providersmocks.MockHashProvider)
+			uc := NewUnlockAccountUseCase(testTokenRepository, testUserRepository, testHashProvider, providersmocks.NewMemoryCacheProvider())
+
+			testHashProvider.On("HashOneTimeToken", "unlock_token").Return([]byte("unlock_hash"))
+			if tc.err != nil {
+				testTokenRepository.On("GetByTokenHash", []byte("unlock_hash")).Return(nil, tc.err)
+			} else {
+				testTokenRepository.On("GetByTokenHash", []byte("unlock_hash")).Return(tc.token(), nil)
+			}
+
+			result := uc.Execute(ctx, locales.EN_US, dtos.AccountUnlock{Token: "unlock_token"})
+
+			assert.True(result.HasError())
+			assert.Equal(status.Conflict, result.StatusCode)
+			testUserRepository.AssertNotCalled(t, "GetUserWithRole", mock.Anything)
+			testTokenRepository.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestUnlockAccountUseCase_MissingToken(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
+
+	testTokenRepository := new(repositoriesmocks.MockOneTimeTokenRepository)
+	uc := NewUnlockAccountUseCase(testTokenRepository, new(authmocks.MockUserRepository), new(providersmocks.MockHashProvider), providersmocks.NewMemoryCacheProvider())
+
+	result := uc.Execute(ctx, locales.EN_US, dtos.AccountUnlock{})
+
+	assert.True(result.HasError())
+	assert.Equal(status.InvalidInput, result.StatusCode)
+	testTokenRepository.AssertNotCalled(t, "GetByTokenHash", mock.Anything)
+}
@@ -10,15 +10,19 @@ import (
 	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/services"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
 	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
 )
 
-// CreatePasswordUseCase is the use case for creating a password
+// CreatePasswordUseCase is the use case for creating a password,
+// the tokens issued to the user with the previous password are revoked
 type CreatePasswordUseCase struct {
 	usecase.BaseUseCaseValidation[dtos.PasswordCreateNoHash, bool]
-	repo         passwordcontracts.IPasswordRepository
-	hashProvider contractsproviders.IHashProvider
+	repo               passwordcontracts.IPasswordRepository
+	hashProvider       contractsproviders.IHashProvider
+	revocationProvider contractsproviders.ITokenRevocationProvider
 }
 
 var _ usecase.BaseUseCase[dtos.PasswordCreateNoHash, bool] = (*CreatePasswordUseCase)(nil)
@@ -46,6 +50,7 @@ func (uc *CreatePasswordUseCase) Execute(ctx *app_context.AppContext,
 	if result.HasError() {
 		return result
 	}
+	services.RevokeUserTokensService(uc.AppContext, input.UserID, uc.revocationProvider)
 
 	uc.setSuccessResult(result)
 	observability.GetObservabilityComponents().Logger.InfoWithContext("password_created", uc.AppContext)
@@ -82,16 +87,19 @@ func (uc *CreatePasswordUseCase) setSuccessResult(result *usecase.UseCaseResult[
 func NewCreatePasswordUseCase(
 	repo passwordcontracts.IPasswordRepository,
 	hashProvider contractsproviders.IHashProvider,
+	revocationProvider contractsproviders.ITokenRevocationProvider,
 ) *CreatePasswordUseCase {
 	return &CreatePasswordUseCase{
 		BaseUseCaseValidation: usecase.BaseUseCaseValidation[dtos.PasswordCreateNoHash, bool]{
 			AppMessages: locales.NewLocale(locales.EN_US),
 			Guards: usecase.NewGuards(
-				guards.RoleGuard("admin", "user"),
+				guards.PermissionGuard(usermodels.PermissionPasswordCreate),
 				guards.UserResourceGuard[dtos.PasswordCreateNoHash](),
+				guards.NotImpersonatedGuard,
 			),
 		},
-		repo:         repo,
-		hashProvider: hashProvider,
+		repo:               repo,
+		hashProvider:       hashProvider,
+		revocationProvider: revocationProvider,
 	}
 }
@@ -50,11 +50,16 @@ func TestCreatePasswordUseCase(t *testing.T) {
 
 	testHashProvider.On("HashPassword", testPassword.NoHashedPassword).Return("HashedPassword123!", nil)
 
-	uc := NewCreatePasswordUseCase(testPasswordRepository, testHashProvider)
+	testRevocationProvider := new(providersmocks.MockTokenRevocationProvider)
+	testRevocationProvider.On("RevokeUserTokens", testPassword.UserID).Return(nil)
+
+	uc := NewCreatePasswordUseCase(testPasswordRepository, testHashProvider, testRevocationProvider)
 
 	result := uc.Execute(ctxWithUser, locales.EN_US, testPassword)
 
 	assert.NotNil(result)
 	assert.True(result.IsSuccess())
 	assert.Equal(*result.Data, true)
+	// The tokens issued with the previous password are revoked
+	testRevocationProvider.AssertCalled(t, "RevokeUserTokens", testPassword.UserID)
 }
@@ -14,17 +14,20 @@ import (
 	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/services"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
 	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
 	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
 )
 
-// CreatePasswordTokenUseCase is the use case for creating a password token
+// CreatePasswordTokenUseCase is the use case for creating a password token,
+// the tokens issued to the user with the previous password are revoked
 type CreatePasswordTokenUseCase struct {
 	usecase.BaseUseCaseValidation[dtos.PasswordTokenCreate, bool]
-	passRepo         passwordcontracts.IPasswordRepository
-	hashProvider     contractsproviders.IHashProvider
-	oneTimetokenRepo contractsrepositories.IOneTimeTokenRepository
+	passRepo           passwordcontracts.IPasswordRepository
+	hashProvider       contractsproviders.IHashProvider
+	oneTimetokenRepo   contractsrepositories.IOneTimeTokenRepository
+	revocationProvider contractsproviders.ITokenRevocationProvider
 }
 
 var _ usecase.BaseUseCase[dtos.PasswordTokenCreate, bool] = (*CreatePasswordTokenUseCase)(nil)
@@ -58,6 +61,7 @@ func (uc *CreatePasswordTokenUseCase) Execute(ctx *app_context.AppContext,
 	if result.HasError() {
 		return result
 	}
+	services.RevokeUserTokensService(uc.AppContext, oneTimeToken.UserID, uc.revocationProvider)
 
 	uc.markTokenAsUsed(result, oneTimeToken.ID)
 	if result.HasError() {
@@ -153,14 +157,16 @@ func NewCreatePasswordTokenUseCase(
 	passRepo passwordcontracts.IPasswordRepository,
 	hashProvider contractsproviders.IHashProvider,
 	repo contractsrepositories.IOneTimeTokenRepository,
+	revocationProvider contractsproviders.ITokenRevocationProvider,
 ) *CreatePasswordTokenUseCase {
 	return &CreatePasswordTokenUseCase{
 		BaseUseCaseValidation: usecase.BaseUseCaseValidation[dtos.PasswordTokenCreate, bool]{
 			AppMessages: locales.NewLocale(locales.EN_US),
 			Guards:      usecase.NewGuards(),
 		},
-		passRepo:         passRepo,
-		hashProvider:     hashProvider,
-		oneTimetokenRepo: repo,
+		passRepo:           passRepo,
+		hashProvider:       hashProvider,
+		oneTimetokenRepo:   repo,
+		revocationProvider: revocationProvider,
 	}
 }
@@ -93,11 +93,14 @@ func TestCreatePasswordTokenUseCase_Execute_Success(t *testing.T) {
 		},
 	}
 	testOneTimeTokenRepository.On("Update", validToken.ID, tokenUpdate).Return(updatedToken, nil)
+	testRevocationProvider := new(providersmocks.MockTokenRevocationProvider)
+	testRevocationProvider.On("RevokeUserTokens", validToken.UserID).Return(nil)
 
 	uc := NewCreatePasswordTokenUseCase(
 		testPasswordRepository,
 		testHashProvider,
 		testOneTimeTokenRepository,
+		testRevocationProvider,
 	)
 
 	result := uc.Execute(ctx, locales.EN_US, input)
@@ -106,6 +109,7 @@ func TestCreatePasswordTokenUseCase_Execute_Success(t *testing.T) {
 	assert.True(result.IsSuccess())
 	assert.Equal(true, *result.Data)
 	assert.Equal(status.Success, result.StatusCode)
+	testRevocationProvider.AssertCalled(t, "RevokeUserTokens", validToken.UserID)
 
 	testHashProvider.AssertExpectations(t)
 	testOneTimeTokenRepository.AssertExpectations(t)
@@ -142,6 +146,7 @@ func TestCreatePasswordTokenUseCase_Execute_ErrorGettingToken(t *testing.T) {
 		testPasswordRepository,
 		testHashProvider,
 		testOneTimeTokenRepository,
+		nil,
 	)
 
 	result := uc.Execute(ctx, locales.EN_US, input)
@@ -178,6 +183,7 @@ func TestCreatePasswordTokenUseCase_Execute_TokenIsNil(t *testing.T) {
 		testPasswordRepository,
 		testHashProvider,
 		testOneTimeTokenRepository,
+		nil,
 	)
 
 	result := uc.Execute(ctx, locales.EN_US, input)
@@ -230,6 +236,7 @@ func TestCreatePasswordTokenUseCase_Execute_TokenIsUsed(t *testing.T) {
 		testPasswordRepository,
 		testHashProvider,
 		testOneTimeTokenRepository,
+		nil,
 	)
 
 	result := uc.Execute(ctx, locales.EN_US, input)
@@ -282,6 +289,7 @@ func TestCreatePasswordTokenUseCase_Execute_TokenExpired(t *testing.T) {
 		testPasswordRepository,
 		testHashProvider,
 		testOneTimeTokenRepository,
+		nil,
 	)
 
 	result := uc.Execute(ctx, locales.EN_US, input)
@@ -343,6 +351,7 @@ func TestCreatePasswordTokenUseCase_Execute_ErrorCreatingPassword(t *testing.T)
 		testPasswordRepository,
 		testHashProvider,
 		testOneTimeTokenRepository,
+		nil,
 	)
 
 	result := uc.Execute(ctx, locales.EN_US, input)
@@ -422,6 +431,7 @@ func TestCreatePasswordTokenUseCase_Execute_ErrorMarkingTokenAsUsed(t *testing.T
 		testPasswordRepository,
 		testHashProvider,
 		testOneTimeTokenRepository,
+		nil,
 	)
 
 	result := uc.Execute(ctx, locales.EN_US, input)
@@ -477,6 +487,7 @@ func TestCreatePasswordTokenUseCase_Execute_TokenPurposeIsNotPasswordReset(t *te
 		testPasswordRepository,
 		testHashProvider,
 		testOneTimeTokenRepository,
+		nil,
 	)
 
 	result := uc.Execute(ctx, locales.EN_US, input)
@@ -0,0 +1,23 @@
+// Package statusdtos contains the DTOs for the status module
+package statusdtos
+
+import (
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+)
+
+// PipeDescription is the description of a registered pipe with its
+// Graphviz DOT and Mermaid exports
+type PipeDescription struct {
+	usecase.DagGraph
+	DOT     string `json:"dot"`
+	Mermaid string `json:"mermaid"`
+}
+
+// NewPipeDescription creates a new pipe description from its graph
+func NewPipeDescription(graph usecase.DagGraph) PipeDescription {
+	return PipeDescription{
+		DagGraph: graph,
+		DOT:      graph.ToDOT(),
+		Mermaid:  graph.ToMermaid(),
+	}
+}
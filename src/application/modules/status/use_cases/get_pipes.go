@@ -0,0 +1,64 @@
+package usecases
+
+import (
+	statusdtos "github.com/simon3640/goprojectskeleton/src/application/modules/status/dtos"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/guards"
+	locales "github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	messages "github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	status "github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
+
+// GetPipesUseCase describes the registered pipes.
+// The input is the name of a pipe; an empty name lists every pipe.
+type GetPipesUseCase struct {
+	usecase.BaseUseCaseValidation[string, []statusdtos.PipeDescription]
+}
+
+var _ usecase.BaseUseCase[string, []statusdtos.PipeDescription] = (*GetPipesUseCase)(nil)
+
+func (uc *GetPipesUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input string,
+) *usecase.UseCaseResult[[]statusdtos.PipeDescription] {
+	result := usecase.NewUseCaseResult[[]statusdtos.PipeDescription]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	graphs := usecase.GetPipes()
+	if input != "" {
+		graph, ok := usecase.GetPipe(input)
+		if !ok {
+			result.SetError(
+				status.NotFound,
+				uc.AppMessages.Get(uc.Locale, messages.MessageKeysInstance.RESOURCE_NOT_FOUND),
+			)
+			return result
+		}
+		graphs = []usecase.DagGraph{graph}
+	}
+
+	pipes := make([]statusdtos.PipeDescription, 0, len(graphs))
+	for _, graph := range graphs {
+		pipes = append(pipes, statusdtos.NewPipeDescription(graph))
+	}
+	result.SetData(status.Success, pipes, "")
+	observability.GetObservabilityComponents().Logger.InfoWithContext("pipes_retrieved", uc.AppContext)
+	return result
+}
+
+func NewGetPipesUseCase() *GetPipesUseCase {
+	return &GetPipesUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[string, []statusdtos.PipeDescription]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(guards.PermissionGuard(usermodels.PermissionStatusPipes)),
+		},
+	}
+}
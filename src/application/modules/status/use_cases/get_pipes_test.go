@@ -0,0 +1,55 @@
+package usecases
+
+import (
+	"testing"
+
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPipesUseCase(t *testing.T) {
+	assert := assert.New(t)
+	usecase.ResetPipes()
+	defer usecase.ResetPipes()
+
+	usecase.RegisterPipe("test-pipe", func() usecase.DagGraph {
+		return usecase.DagGraph{Nodes: []usecase.DagNode{{ID: "n0", Name: "first", Kind: usecase.DagNodeStep, Input: "string", Output: "int"}}}
+	})
+
+	admin := usermodels.UserWithRole{ID: 1}
+	admin.SetRole(dtomocks.AdminRole)
+	admin.SetPermissions(dtomocks.AdminRolePermissions)
+	ctx := app_context.NewContextWithUser(&admin)
+
+	uc := NewGetPipesUseCase()
+
+	result := uc.Execute(ctx, locales.EN_US, "")
+	assert.True(result.IsSuccess())
+	assert.Len(*result.Data, 1)
+	pipe := (*result.Data)[0]
+	assert.Equal("test-pipe", pipe.Name)
+	assert.Contains(pipe.DOT, `digraph "test-pipe"`)
+	assert.Contains(pipe.Mermaid, "flowchart LR")
+
+	result = uc.Execute(ctx, locales.EN_US, "test-pipe")
+	assert.True(result.IsSuccess())
+	assert.Len(*result.Data, 1)
+
+	result = uc.Execute(ctx, locales.EN_US, "missing")
+	assert.True(result.HasError())
+	assert.Equal(status.NotFound, result.StatusCode)
+
+	// Only admins can describe the pipes
+	user := usermodels.UserWithRole{ID: 2}
+	user.SetRole(dtomocks.UserRole)
+	user.SetPermissions(dtomocks.UserRolePermissions)
+	result = uc.Execute(app_context.NewContextWithUser(&user), locales.EN_US, "")
+	assert.True(result.HasError())
+	assert.Equal(status.Unauthorized, result.StatusCode)
+}
@@ -0,0 +1,88 @@
+package usecases
+
+import (
+	contractsrepositories "github.com/simon3640/goprojectskeleton/src/application/contracts/repositories"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	locales "github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	messages "github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	status "github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
+
+// GetTaskUseCase returns the status of a durable background task by its task
+// ID. Users read the tasks enqueued by their requests, the users allowed to
+// get the status of the pipes read every task. The tasks of other users, and
+// those of anonymous requests, whose ID is not returned, are not found.
+type GetTaskUseCase struct {
+	usecase.BaseUseCaseValidation[string, sharedmodels.OutboxTask]
+	repo contractsrepositories.IOutboxTaskRepository
+}
+
+var _ usecase.BaseUseCase[string, sharedmodels.OutboxTask] = (*GetTaskUseCase)(nil)
+
+func (uc *GetTaskUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input string,
+) *usecase.UseCaseResult[sharedmodels.OutboxTask] {
+	result := usecase.NewUseCaseResult[sharedmodels.OutboxTask]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	if input == "" {
+		result.SetError(
+			status.InvalidInput,
+			uc.AppMessages.Get(uc.Locale, messages.MessageKeysInstance.INVALID_DATA),
+		)
+		return result
+	}
+
+	task, err := uc.repo.GetByTaskID(input)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting task by task ID", err.ToError(), uc.AppContext)
+		result.SetError(
+			err.Code,
+			uc.AppMessages.Get(
+				uc.Locale,
+				err.Context,
+			),
+		)
+		return result
+	}
+	if !uc.canRead(task) {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Task of another user requested", uc.AppContext)
+		result.SetError(
+			status.NotFound,
+			uc.AppMessages.Get(
+				uc.Locale,
+				messages.MessageKeysInstance.RESOURCE_NOT_FOUND,
+			),
+		)
+		return result
+	}
+	result.SetData(status.Success, *task, "")
+	observability.GetObservabilityComponents().Logger.InfoWithContext("task_retrieved", uc.AppContext)
+	return result
+}
+
+// canRead reports whether the user of the request can read the task
+func (uc *GetTaskUseCase) canRead(task *sharedmodels.OutboxTask) bool {
+	user := uc.AppContext.User
+	if user == nil {
+		return false
+	}
+	return (task.UserID != 0 && task.UserID == user.ID) || user.HasPermission(usermodels.PermissionStatusPipes)
+}
+
+func NewGetTaskUseCase(
+	repo contractsrepositories.IOutboxTaskRepository,
+) *GetTaskUseCase {
+	return &GetTaskUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[string, sharedmodels.OutboxTask]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(),
+		},
+		repo: repo,
+	}
+}
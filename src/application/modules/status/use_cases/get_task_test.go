@@ -0,0 +1,79 @@
+package usecases
+
+import (
+	"testing"
+
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	repositoriesmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/repositories"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTaskUseCase(t *testing.T) {
+	assert := assert.New(t)
+
+	user := dtomocks.UserWithRole
+	ctx := app_context.NewContextWithUser(&user)
+	repo := new(repositoriesmocks.MockOutboxTaskRepository)
+	repo.On("GetByTaskID", "task-1").Return(&sharedmodels.OutboxTask{
+		OutboxTaskBase: sharedmodels.OutboxTaskBase{
+			TaskID:   "task-1",
+			Name:     "create-user-send-email",
+			Status:   sharedmodels.OutboxTaskStatusSucceeded,
+			Attempts: 1,
+			UserID:   1,
+		},
+	}, nil)
+	repo.On("GetByTaskID", "missing").Return(nil, application_errors.NewApplicationError(
+		status.NotFound,
+		messages.MessageKeysInstance.RESOURCE_NOT_FOUND,
+		"Resource not found",
+	))
+
+	uc := NewGetTaskUseCase(repo)
+
+	result := uc.Execute(ctx, locales.EN_US, "task-1")
+	assert.True(result.IsSuccess())
+	assert.Equal(sharedmodels.OutboxTaskStatusSucceeded, result.Data.Status)
+	assert.Equal(1, result.Data.Attempts)
+
+	result = uc.Execute(ctx, locales.EN_US, "missing")
+	assert.True(result.HasError())
+	assert.Equal(status.NotFound, result.StatusCode)
+
+	result = uc.Execute(ctx, locales.EN_US, "")
+	assert.True(result.HasError())
+	assert.Equal(status.InvalidInput, result.StatusCode)
+}
+
+func TestGetTaskUseCase_OtherUser(t *testing.T) {
+	assert := assert.New(t)
+
+	repo := new(repositoriesmocks.MockOutboxTaskRepository)
+	// The task was enqueued by the request of an anonymous user
+	repo.On("GetByTaskID", "task-1").Return(&sharedmodels.OutboxTask{
+		OutboxTaskBase: sharedmodels.OutboxTaskBase{
+			TaskID: "task-1",
+			Name:   "create-user-send-email",
+			Status: sharedmodels.OutboxTaskStatusPending,
+		},
+	}, nil)
+
+	uc := NewGetTaskUseCase(repo)
+
+	user := dtomocks.UserWithRole
+	result := uc.Execute(app_context.NewContextWithUser(&user), locales.EN_US, "task-1")
+	assert.True(result.HasError())
+	assert.Equal(status.NotFound, result.StatusCode)
+
+	// The users allowed to get the status of the pipes read every task
+	admin := dtomocks.AdminUserWithRole
+	result = uc.Execute(app_context.NewContextWithUser(&admin), locales.EN_US, "task-1")
+	assert.True(result.IsSuccess())
+}
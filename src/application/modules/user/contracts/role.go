@@ -2,6 +2,7 @@ package usercontracts
 
 import (
 	contractsrepositories "github.com/simon3640/goprojectskeleton/src/application/contracts/repositories"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
 
 	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
 )
@@ -9,4 +10,6 @@ import (
 // IRoleRepository is the interface for the role repository
 type IRoleRepository interface {
 	contractsrepositories.IRepositoryBase[usermodels.RoleCreate, usermodels.RoleUpdate, usermodels.Role, usermodels.RoleInDB]
+	// HasUsers reports whether any user holds the role
+	HasUsers(id uint) (bool, *applicationerrors.ApplicationError)
 }
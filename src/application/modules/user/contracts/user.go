@@ -11,8 +11,8 @@ import (
 // IUserRepository is the interface for the user repository
 type IUserRepository interface {
 	contractsrepositories.IRepositoryBase[userdtos.UserCreate, userdtos.UserUpdate, usermodels.User, usermodels.UserInDB]
-	// CreateWithPassword creates a new user with a password
-	CreateWithPassword(input userdtos.UserAndPasswordCreate) (*usermodels.User, *applicationerrors.ApplicationError)
+	// CreatePassword creates the active password of a user from its hash
+	CreatePassword(userID uint, passwordHash string) *applicationerrors.ApplicationError
 	// GetUserWithRole gets a user with their role
 	GetUserWithRole(id uint) (*usermodels.UserWithRole, *applicationerrors.ApplicationError)
 	// GetByEmailOrPhone gets a user by email or phone
@@ -0,0 +1,32 @@
+package userdtos
+
+import (
+	shareddtos "github.com/simon3640/goprojectskeleton/src/application/shared/DTOs"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
+
+// OrganizationMember is the structure for a member of an organization
+type OrganizationMember struct {
+	OrganizationID uint `json:"organization_id"`
+	UserID         uint `json:"user_id"`
+}
+
+// GetOrganizationID returns the organization of the member
+func (m OrganizationMember) GetOrganizationID() uint {
+	return m.OrganizationID
+}
+
+// Validate validates the organization member
+func (m OrganizationMember) Validate() []string {
+	var errs []string
+	if m.OrganizationID == 0 {
+		errs = append(errs, "organization_id is required")
+	}
+	if m.UserID == 0 {
+		errs = append(errs, "user_id is required")
+	}
+	return errs
+}
+
+// OrganizationMultiResponse is the multiple response for an organization
+type OrganizationMultiResponse = shareddtos.MultipleResponse[usermodels.Organization]
@@ -0,0 +1,30 @@
+package userdtos
+
+import (
+	shareddtos "github.com/simon3640/goprojectskeleton/src/application/shared/DTOs"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
+
+// RolePermissions is the structure for the permissions granted to a role
+type RolePermissions struct {
+	RoleID      uint     `json:"role_id"`
+	Permissions []string `json:"permissions"`
+}
+
+// Validate validates the role permissions
+func (r RolePermissions) Validate() []string {
+	var errs []string
+	if r.Permissions == nil {
+		errs = append(errs, "permissions are required")
+	}
+	for _, permission := range r.Permissions {
+		if permission == "" {
+			errs = append(errs, "permission is required")
+			break
+		}
+	}
+	return errs
+}
+
+// RoleMultiResponse is the multiple response for a role
+type RoleMultiResponse = shareddtos.MultipleResponse[usermodels.Role]
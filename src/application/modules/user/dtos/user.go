@@ -10,11 +10,17 @@ import (
 // UserCreate is the create structure for a user
 type UserCreate struct {
 	usermodels.UserBase
+	// ExternalIdentity is set for users created on their first login with an
+	// identity provider, it can never be set by clients
+	ExternalIdentity bool `json:"-"`
 }
 
 // Validate validates the user create
 // This validates also create defaults that are set in the ValidateCreate method, this is a pointer method because it needs to modify the user base
 func (u *UserCreate) Validate() []string {
+	if u.ExternalIdentity {
+		return u.ValidateCreateExternal()
+	}
 	return u.ValidateCreate()
 }
 
@@ -33,6 +39,13 @@ func (u *UserAndPasswordCreate) Validate() []string {
 	return errs
 }
 
+// UserWithPasswordHash is the user created by the create user pipe with the
+// hash of the password created for them by the next step
+type UserWithPasswordHash struct {
+	User         usermodels.User
+	PasswordHash string
+}
+
 // UserUpdate is the update structure for a user
 type UserUpdate struct {
 	usermodels.UserUpdateBase
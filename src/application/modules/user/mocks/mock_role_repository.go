@@ -0,0 +1,25 @@
+package usermocks
+
+import (
+	usercontracts "github.com/simon3640/goprojectskeleton/src/application/modules/user/contracts"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	repositoriesmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/repositories"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
+
+// MockRoleRepository is the mock implementation of the RoleRepository interface
+type MockRoleRepository struct {
+	repositoriesmocks.MockRepositoryBase[usermodels.RoleCreate, usermodels.RoleUpdate, usermodels.Role, usermodels.Role]
+}
+
+var _ usercontracts.IRoleRepository = (*MockRoleRepository)(nil)
+
+// HasUsers reports whether any user holds the role
+func (m *MockRoleRepository) HasUsers(id uint) (bool, *applicationerrors.ApplicationError) {
+	args := m.Called(id)
+	errorArg := args.Get(1)
+	if errorArg != nil {
+		return false, errorArg.(*applicationerrors.ApplicationError)
+	}
+	return args.Bool(0), nil
+}
@@ -16,14 +16,14 @@ type MockUserRepository struct {
 
 var _ usercontracts.IUserRepository = (*MockUserRepository)(nil)
 
-// CreateWithPassword creates a new user with a password
-func (m *MockUserRepository) CreateWithPassword(input userdtos.UserAndPasswordCreate) (*usermodels.User, *applicationerror.ApplicationError) {
-	args := m.Called(input)
-	errorArg := args.Get(1)
+// CreatePassword creates the active password of a user from its hash
+func (m *MockUserRepository) CreatePassword(userID uint, passwordHash string) *applicationerror.ApplicationError {
+	args := m.Called(userID, passwordHash)
+	errorArg := args.Get(0)
 	if errorArg != nil {
-		return args.Get(0).(*usermodels.User), errorArg.(*applicationerror.ApplicationError)
+		return errorArg.(*applicationerror.ApplicationError)
 	}
-	return args.Get(0).(*usermodels.User), nil
+	return nil
 }
 
 // GetUserWithRole gets a user with their role
@@ -2,25 +2,75 @@
 package userpipes
 
 import (
+	"time"
+
 	userdtos "github.com/simon3640/goprojectskeleton/src/application/modules/user/dtos"
 	userusecases "github.com/simon3640/goprojectskeleton/src/application/modules/user/use_cases"
 	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
 	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
 	workers "github.com/simon3640/goprojectskeleton/src/application/shared/workers"
 	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
 )
 
+// CreateUserPipeName is the name of the create user pipe in the pipe registry.
+const CreateUserPipeName = "create-user"
+
+// CreateUserSendEmailTask is the name of the durable task that sends the welcome email.
+const CreateUserSendEmailTask = "create-user-send-email"
+
+func init() {
+	usecase.RegisterPipe(CreateUserPipeName, func() usecase.DagGraph {
+		return NewCreateUserPipe(app_context.NewVoidAppContext(), locales.EN_US, nil, nil, nil, nil, nil).Describe()
+	})
+}
+
+// sendEmailPolicy retries the errors of the email provider. A send that times
+// out is not retried, since the email may have been sent anyway.
+var sendEmailPolicy = usecase.StepPolicy{
+	MaxAttempts:       3,
+	Backoff:           500 * time.Millisecond,
+	BackoffMultiplier: 2,
+	RetryOn:           []status.ApplicationStatusEnum{status.ProviderError},
+	Timeout:           10 * time.Second,
+}
+
+// NewCreateUserSendEmailStep creates the step sending the welcome email, with
+// the retries and timeout of the email provider. It is shared by the pipe and
+// the outbox handler of CreateUserSendEmailTask.
+func NewCreateUserSendEmailStep(
+	createUserSendEmailUseCase *userusecases.CreateUserSendEmailUseCase,
+) usecase.DagStep[usermodels.User, usermodels.User] {
+	return usecase.WithPolicy(usecase.NewStep(createUserSendEmailUseCase), sendEmailPolicy)
+}
+
 // NewCreateUserPipe creates a new create user pipe.
-// The user is created synchronously, and the welcome email is sent in background.
+// The user is created synchronously, and then their password and their
+// membership of the organization of the actor in parallel. The welcome email
+// is sent in background.
 // This allows returning the response immediately without waiting for the email to be sent.
+// The user is deleted if the password is not created. The email task is
+// persisted in the outbox in the transaction creating the user, so it is not
+// lost if the process stops before sending it.
 func NewCreateUserPipe(
 	ctx *app_context.AppContext,
 	locale locales.LocaleTypeEnum,
 	createUserPasswordUC *userusecases.CreateUserAndPasswordUseCase,
+	deleteCreatedUserUC *userusecases.DeleteCreatedUserUseCase,
+	createUserPasswordHashUC *userusecases.CreateUserPasswordHashUseCase,
+	addCreatedUserMembershipUC *userusecases.AddCreatedUserMembershipUseCase,
 	createUserSendEmailUseCase *userusecases.CreateUserSendEmailUseCase,
 ) *usecase.DAG[userdtos.UserAndPasswordCreate, usermodels.User] {
-	dag := usecase.NewDag(ctx, usecase.NewStep(createUserPasswordUC), locale, workers.GetBackgroundExecutor())
+	createUser := usecase.WithCompensation(usecase.NewStep(createUserPasswordUC), deleteCreatedUserUC, "create-user-delete")
+	dag := usecase.NewDag(ctx, createUser, locale, workers.GetBackgroundExecutor())
+	password := usecase.NewBranch(usecase.NewStep(createUserPasswordHashUC), "create-user-password")
+	membership := usecase.NewBranch(usecase.NewStep(addCreatedUserMembershipUC), "create-user-membership")
+	created := usecase.Join(usecase.Parallel(dag, password, membership),
+		func(_ userdtos.UserWithPasswordHash, results usecase.BranchResults) usermodels.User {
+			return password.Output(results)
+		},
+	)
 	// The email is sent in background, the response is returned immediately
-	return usecase.ThenBackground(dag, usecase.NewStep(createUserSendEmailUseCase), "create-user-send-email")
+	return usecase.ThenDurable(created, NewCreateUserSendEmailStep(createUserSendEmailUseCase), CreateUserSendEmailTask)
 }
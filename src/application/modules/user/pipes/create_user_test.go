@@ -0,0 +1,128 @@
+package userpipes
+
+import (
+	"context"
+	"testing"
+
+	userdtos "github.com/simon3640/goprojectskeleton/src/application/modules/user/dtos"
+	usermocks "github.com/simon3640/goprojectskeleton/src/application/modules/user/mocks"
+	userusecases "github.com/simon3640/goprojectskeleton/src/application/modules/user/use_cases"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	applicationerror "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
+	repositoriesmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/repositories"
+	appstatus "github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateUserPipe_PasswordNotCreated(t *testing.T) {
+	assert := assert.New(t)
+	ctx := &app_context.AppContext{Context: context.Background()}
+
+	testUserRepository := new(usermocks.MockUserRepository)
+	testHashProvider := new(providersmocks.MockHashProvider)
+	testTokenRepository := new(repositoriesmocks.MockOneTimeTokenRepository)
+
+	status := usermodels.UserStatusPending
+	input := userdtos.UserAndPasswordCreate{
+		UserCreate: userdtos.UserCreate{
+			UserBase: usermodels.UserBase{
+				Name:   "Test User",
+				Email:  "test@example.com",
+				Phone:  "1234567890",
+				Status: &status,
+				RoleID: 2,
+			},
+		},
+		Password: "P@ssw0rd",
+	}
+	created := &usermodels.User{UserBase: input.UserBase, DBBaseModel: sharedmodels.DBBaseModel{ID: 7}}
+
+	testHashProvider.On("HashPassword", "P@ssw0rd").Return("hashed_password", nil)
+	testUserRepository.On("Create", input.UserCreate).Return(created, nil)
+	testUserRepository.On("CreatePassword", uint(7), "hashed_password").Return(applicationerror.NewApplicationError(
+		appstatus.InternalError,
+		messages.MessageKeysInstance.SOMETHING_WENT_WRONG,
+		"connection lost",
+	))
+	testUserRepository.On("Delete", uint(7)).Return(nil)
+
+	result := NewCreateUserPipe(
+		ctx,
+		locales.EN_US,
+		userusecases.NewCreateUserAndPasswordUseCase(testUserRepository, testHashProvider),
+		userusecases.NewDeleteCreatedUserUseCase(testUserRepository),
+		userusecases.NewCreateUserPasswordHashUseCase(testUserRepository),
+		userusecases.NewAddCreatedUserMembershipUseCase(new(repositoriesmocks.MockOrganizationRepository)),
+		userusecases.NewCreateUserSendEmailUseCase(testHashProvider, testTokenRepository),
+	).Execute(input)
+
+	assert.False(result.IsSuccess())
+	assert.Equal(appstatus.InternalError, result.StatusCode)
+	// The user created without password is removed
+	testUserRepository.AssertCalled(t, "Delete", uint(7))
+	assert.Contains(result.Details, "create-user-delete: success")
+	testTokenRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestCreateUserPipe_MembershipNotCreated(t *testing.T) {
+	assert := assert.New(t)
+
+	admin := dtomocks.AdminUserWithRole
+	admin.SetOrganizations([]uint{3})
+	admin.SetTenant(3)
+	ctx := app_context.NewContextWithUser(&admin)
+
+	testUserRepository := new(usermocks.MockUserRepository)
+	testHashProvider := new(providersmocks.MockHashProvider)
+	testTokenRepository := new(repositoriesmocks.MockOneTimeTokenRepository)
+	testOrganizationRepository := new(repositoriesmocks.MockOrganizationRepository)
+
+	input := userdtos.UserAndPasswordCreate{UserCreate: dtomocks.UserCreate, Password: "P@ssw0rd"}
+	created := &usermodels.User{UserBase: input.UserBase, DBBaseModel: sharedmodels.DBBaseModel{ID: 7}}
+
+	testHashProvider.On("HashPassword", "P@ssw0rd").Return("hashed_password", nil)
+	testUserRepository.On("Create", mock.Anything).Return(created, nil)
+	testUserRepository.On("CreatePassword", uint(7), "hashed_password").Return(nil)
+	testUserRepository.On("Delete", uint(7)).Return(nil)
+	testOrganizationRepository.On("AddMember", uint(3), uint(7)).
+		Return(applicationerror.NewApplicationError(appstatus.ProviderError, "", "database unavailable"))
+
+	result := NewCreateUserPipe(
+		ctx,
+		locales.EN_US,
+		userusecases.NewCreateUserAndPasswordUseCase(testUserRepository, testHashProvider),
+		userusecases.NewDeleteCreatedUserUseCase(testUserRepository),
+		userusecases.NewCreateUserPasswordHashUseCase(testUserRepository),
+		userusecases.NewAddCreatedUserMembershipUseCase(testOrganizationRepository),
+		userusecases.NewCreateUserSendEmailUseCase(testHashProvider, testTokenRepository),
+	).Execute(input)
+
+	assert.False(result.IsSuccess())
+	assert.Equal(appstatus.ProviderError, result.StatusCode)
+	// The user out of the organization of the admin is removed
+	testUserRepository.AssertCalled(t, "Delete", uint(7))
+	testTokenRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestCreateUserPipe_Describe(t *testing.T) {
+	assert := assert.New(t)
+
+	graph, ok := usecase.GetPipe(CreateUserPipeName)
+	assert.True(ok)
+	assert.Equal("create-user-delete", graph.Nodes[0].Compensation)
+	assert.Equal("create-user-password", graph.Nodes[1].Name)
+	assert.Equal("create-user-membership", graph.Nodes[2].Name)
+	assert.Equal(usecase.DagNodeJoin, graph.Nodes[3].Kind)
+	assert.Equal(usecase.DagNodeDurable, graph.Nodes[4].Kind)
+	// The email provider errors are retried
+	assert.Contains(graph.Nodes[4].Policy, "attempts=3")
+}
@@ -0,0 +1,70 @@
+package userusecases
+
+import (
+	contracts_repositories "github.com/simon3640/goprojectskeleton/src/application/contracts/repositories"
+	userdtos "github.com/simon3640/goprojectskeleton/src/application/modules/user/dtos"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+)
+
+// AddCreatedUserMembershipUseCase is a step of the create user pipe that makes
+// the user created a member of the organization the actor acts in. Users
+// registering themselves are not scoped to any organization and are not
+// added to one. The membership is deleted with the user, so it needs no
+// compensation of its own.
+type AddCreatedUserMembershipUseCase struct {
+	usecase.BaseUseCaseValidation[userdtos.UserWithPasswordHash, bool]
+	organizationRepo contracts_repositories.IOrganizationRepository
+}
+
+var _ usecase.BaseUseCase[userdtos.UserWithPasswordHash, bool] = (*AddCreatedUserMembershipUseCase)(nil)
+
+// Execute executes the use case
+func (uc *AddCreatedUserMembershipUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input userdtos.UserWithPasswordHash,
+) *usecase.UseCaseResult[bool] {
+	result := usecase.NewUseCaseResult[bool]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+
+	tenantID, scoped := uc.AppContext.TenantID()
+	if !scoped {
+		result.SetData(status.Success, false, "")
+		return result
+	}
+
+	if err := uc.organizationRepo.AddMember(tenantID, input.User.ID); err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error adding user to organization", err.ToError(), uc.AppContext)
+		result.SetError(
+			err.Code,
+			uc.AppMessages.Get(uc.Locale, err.Context),
+		)
+		return result
+	}
+
+	result.SetData(
+		status.Success,
+		true,
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.ORGANIZATION_MEMBER_ADDED,
+		),
+	)
+	return result
+}
+
+// NewAddCreatedUserMembershipUseCase creates a new add created user membership use case
+func NewAddCreatedUserMembershipUseCase(organizationRepo contracts_repositories.IOrganizationRepository) *AddCreatedUserMembershipUseCase {
+	return &AddCreatedUserMembershipUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[userdtos.UserWithPasswordHash, bool]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(),
+		},
+		organizationRepo: organizationRepo,
+	}
+}
@@ -0,0 +1,72 @@
+package userusecases
+
+import (
+	"context"
+	"testing"
+
+	userdtos "github.com/simon3640/goprojectskeleton/src/application/modules/user/dtos"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	repositoriesmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/repositories"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAddCreatedUserMembershipUseCase(t *testing.T) {
+	created := userdtos.UserWithPasswordHash{
+		User: usermodels.User{DBBaseModel: sharedmodels.DBBaseModel{ID: 7}},
+	}
+
+	t.Run("scoped", func(t *testing.T) {
+		assert := assert.New(t)
+		admin := dtomocks.AdminUserWithRole
+		admin.SetOrganizations([]uint{3})
+		admin.SetTenant(3)
+
+		testOrganizationRepository := new(repositoriesmocks.MockOrganizationRepository)
+		testOrganizationRepository.On("AddMember", uint(3), uint(7)).Return(nil)
+
+		uc := NewAddCreatedUserMembershipUseCase(testOrganizationRepository)
+		result := uc.Execute(app_context.NewContextWithUser(&admin), locales.EN_US, created)
+
+		assert.True(result.IsSuccess())
+		assert.True(*result.Data)
+		testOrganizationRepository.AssertExpectations(t)
+	})
+
+	t.Run("not scoped", func(t *testing.T) {
+		assert := assert.New(t)
+		testOrganizationRepository := new(repositoriesmocks.MockOrganizationRepository)
+
+		// Users registering themselves are not added to any organization
+		uc := NewAddCreatedUserMembershipUseCase(testOrganizationRepository)
+		result := uc.Execute(&app_context.AppContext{Context: context.Background()}, locales.EN_US, created)
+
+		assert.True(result.IsSuccess())
+		assert.False(*result.Data)
+		testOrganizationRepository.AssertNotCalled(t, "AddMember", mock.Anything, mock.Anything)
+	})
+
+	t.Run("not added", func(t *testing.T) {
+		assert := assert.New(t)
+		admin := dtomocks.AdminUserWithRole
+		admin.SetOrganizations([]uint{3})
+		admin.SetTenant(3)
+
+		testOrganizationRepository := new(repositoriesmocks.MockOrganizationRepository)
+		testOrganizationRepository.On("AddMember", uint(3), uint(7)).
+			Return(application_errors.NewApplicationError(status.ProviderError, "", "database unavailable"))
+
+		uc := NewAddCreatedUserMembershipUseCase(testOrganizationRepository)
+		result := uc.Execute(app_context.NewContextWithUser(&admin), locales.EN_US, created)
+
+		assert.True(result.HasError())
+		assert.Equal(status.ProviderError, result.StatusCode)
+	})
+}
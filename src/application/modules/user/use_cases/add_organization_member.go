@@ -0,0 +1,78 @@
+package userusecases
+
+import (
+	contracts_repositories "github.com/simon3640/goprojectskeleton/src/application/contracts/repositories"
+	userdtos "github.com/simon3640/goprojectskeleton/src/application/modules/user/dtos"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/guards"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
+
+// AddOrganizationMemberUseCase is a use case that makes a user a member of an
+// organization. Only members acting in the organization, or super-admins, add
+// members.
+type AddOrganizationMemberUseCase struct {
+	usecase.BaseUseCaseValidation[userdtos.OrganizationMember, userdtos.OrganizationMember]
+	repo contracts_repositories.IOrganizationRepository
+}
+
+var _ usecase.BaseUseCase[userdtos.OrganizationMember, userdtos.OrganizationMember] = (*AddOrganizationMemberUseCase)(nil)
+
+// Execute executes the use case
+func (uc *AddOrganizationMemberUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input userdtos.OrganizationMember,
+) *usecase.UseCaseResult[userdtos.OrganizationMember] {
+	result := usecase.NewUseCaseResult[userdtos.OrganizationMember]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	uc.addMember(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	result.SetData(
+		status.Created,
+		input,
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.ORGANIZATION_MEMBER_ADDED,
+		),
+	)
+	observability.GetObservabilityComponents().Logger.InfoWithContext("Organization member added successfully", uc.AppContext)
+	return result
+}
+
+func (uc *AddOrganizationMemberUseCase) addMember(input userdtos.OrganizationMember, result *usecase.UseCaseResult[userdtos.OrganizationMember]) {
+	err := uc.repo.AddMember(input.OrganizationID, input.UserID)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error adding organization member", err.ToError(), uc.AppContext)
+		result.SetError(err.Code, uc.AppMessages.Get(uc.Locale, err.Context))
+	}
+}
+
+// NewAddOrganizationMemberUseCase creates a new add organization member use case
+func NewAddOrganizationMemberUseCase(
+	repo contracts_repositories.IOrganizationRepository,
+) *AddOrganizationMemberUseCase {
+	return &AddOrganizationMemberUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[userdtos.OrganizationMember, userdtos.OrganizationMember]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards: usecase.NewGuards(
+				guards.PermissionGuard(usermodels.PermissionOrganizationWrite),
+				guards.TenantGuard[userdtos.OrganizationMember](),
+			),
+		},
+		repo: repo,
+	}
+}
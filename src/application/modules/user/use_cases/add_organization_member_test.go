@@ -0,0 +1,75 @@
+package userusecases
+
+import (
+	"testing"
+
+	userdtos "github.com/simon3640/goprojectskeleton/src/application/modules/user/dtos"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	repositoriesmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/repositories"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// organizationManager returns a user managing the members of the
+// organization it acts in
+func organizationManager(organizationID uint) usermodels.UserWithRole {
+	actor := dtomocks.UserWithRole
+	actor.SetPermissions([]string{usermodels.PermissionOrganizationWrite})
+	actor.SetOrganizations([]uint{organizationID})
+	actor.SetTenant(organizationID)
+	return actor
+}
+
+func TestAddOrganizationMemberUseCase(t *testing.T) {
+	assert := assert.New(t)
+
+	actor := organizationManager(1)
+	ctxWithUser := app_context.NewContextWithUser(&actor)
+
+	testOrganizationRepository := new(repositoriesmocks.MockOrganizationRepository)
+	testOrganizationRepository.On("AddMember", uint(1), uint(5)).Return(nil)
+
+	uc := NewAddOrganizationMemberUseCase(testOrganizationRepository)
+	result := uc.Execute(ctxWithUser, locales.EN_US, userdtos.OrganizationMember{OrganizationID: 1, UserID: 5})
+
+	assert.True(result.IsSuccess())
+	assert.Equal(status.Created, result.StatusCode)
+	assert.Equal(uint(5), result.Data.UserID)
+	testOrganizationRepository.AssertExpectations(t)
+}
+
+func TestAddOrganizationMemberUseCase_CrossTenant(t *testing.T) {
+	assert := assert.New(t)
+
+	actor := organizationManager(1)
+	ctxWithUser := app_context.NewContextWithUser(&actor)
+
+	testOrganizationRepository := new(repositoriesmocks.MockOrganizationRepository)
+
+	uc := NewAddOrganizationMemberUseCase(testOrganizationRepository)
+	result := uc.Execute(ctxWithUser, locales.EN_US, userdtos.OrganizationMember{OrganizationID: 2, UserID: 5})
+
+	assert.True(result.HasError())
+	assert.Equal(status.Unauthorized, result.StatusCode)
+	testOrganizationRepository.AssertNotCalled(t, "AddMember")
+}
+
+func TestAddOrganizationMemberUseCase_SuperAdmin(t *testing.T) {
+	assert := assert.New(t)
+
+	actor := dtomocks.AdminUserWithRole
+	ctxWithUser := app_context.NewContextWithUser(&actor)
+
+	testOrganizationRepository := new(repositoriesmocks.MockOrganizationRepository)
+	testOrganizationRepository.On("AddMember", uint(2), uint(5)).Return(nil)
+
+	uc := NewAddOrganizationMemberUseCase(testOrganizationRepository)
+	result := uc.Execute(ctxWithUser, locales.EN_US, userdtos.OrganizationMember{OrganizationID: 2, UserID: 5})
+
+	assert.True(result.IsSuccess())
+	testOrganizationRepository.AssertExpectations(t)
+}
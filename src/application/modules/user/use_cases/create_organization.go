@@ -0,0 +1,78 @@
+package userusecases
+
+import (
+	contracts_repositories "github.com/simon3640/goprojectskeleton/src/application/contracts/repositories"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/guards"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
+
+// CreateOrganizationUseCase is a use case that creates an organization, the
+// organization is created without members. Only super-admins create
+// organizations.
+type CreateOrganizationUseCase struct {
+	usecase.BaseUseCaseValidation[usermodels.OrganizationCreate, usermodels.Organization]
+	repo contracts_repositories.IOrganizationRepository
+}
+
+var _ usecase.BaseUseCase[usermodels.OrganizationCreate, usermodels.Organization] = (*CreateOrganizationUseCase)(nil)
+
+// Execute executes the use case
+func (uc *CreateOrganizationUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input usermodels.OrganizationCreate,
+) *usecase.UseCaseResult[usermodels.Organization] {
+	result := usecase.NewUseCaseResult[usermodels.Organization]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	res := uc.createOrganization(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	result.SetData(
+		status.Created,
+		*res,
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.ORGANIZATION_WAS_CREATED,
+		),
+	)
+	observability.GetObservabilityComponents().Logger.InfoWithContext("Organization created successfully", uc.AppContext)
+	return result
+}
+
+func (uc *CreateOrganizationUseCase) createOrganization(input usermodels.OrganizationCreate, result *usecase.UseCaseResult[usermodels.Organization]) *usermodels.Organization {
+	res, err := uc.repo.Create(input)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error creating organization", err.ToError(), uc.AppContext)
+		result.SetError(err.Code, uc.AppMessages.Get(uc.Locale, err.Context))
+	}
+	return res
+}
+
+// NewCreateOrganizationUseCase creates a new create organization use case
+func NewCreateOrganizationUseCase(
+	repo contracts_repositories.IOrganizationRepository,
+) *CreateOrganizationUseCase {
+	return &CreateOrganizationUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[usermodels.OrganizationCreate, usermodels.Organization]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards: usecase.NewGuards(
+				guards.PermissionGuard(usermodels.PermissionOrganizationWrite),
+				guards.PermissionGuard(usermodels.PermissionTenantCross),
+			),
+		},
+		repo: repo,
+	}
+}
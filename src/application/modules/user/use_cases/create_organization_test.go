@@ -0,0 +1,70 @@
+package userusecases
+
+import (
+	"testing"
+
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	repositoriesmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/repositories"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateOrganizationUseCase(t *testing.T) {
+	assert := assert.New(t)
+
+	actor := dtomocks.AdminUserWithRole
+	ctxWithUser := app_context.NewContextWithUser(&actor)
+
+	testOrganizationRepository := new(repositoriesmocks.MockOrganizationRepository)
+	input := usermodels.OrganizationCreate{OrganizationBase: usermodels.OrganizationBase{Name: "Acme", Slug: "acme", IsActive: true}}
+	testOrganizationRepository.On("Create", input).Return(&usermodels.Organization{OrganizationBase: input.OrganizationBase, ID: 1}, nil)
+
+	uc := NewCreateOrganizationUseCase(testOrganizationRepository)
+	result := uc.Execute(ctxWithUser, locales.EN_US, input)
+
+	assert.True(result.IsSuccess())
+	assert.Equal(status.Created, result.StatusCode)
+	assert.Equal(uint(1), result.Data.ID)
+	assert.Equal("acme", result.Data.Slug)
+}
+
+func TestCreateOrganizationUseCase_InvalidSlug(t *testing.T) {
+	assert := assert.New(t)
+
+	actor := dtomocks.AdminUserWithRole
+	ctxWithUser := app_context.NewContextWithUser(&actor)
+
+	testOrganizationRepository := new(repositoriesmocks.MockOrganizationRepository)
+
+	uc := NewCreateOrganizationUseCase(testOrganizationRepository)
+	result := uc.Execute(ctxWithUser, locales.EN_US, usermodels.OrganizationCreate{
+		OrganizationBase: usermodels.OrganizationBase{Name: "Acme", Slug: "Acme Inc"},
+	})
+
+	assert.True(result.HasError())
+	assert.Equal(status.InvalidInput, result.StatusCode)
+	testOrganizationRepository.AssertNotCalled(t, "Create")
+}
+
+func TestCreateOrganizationUseCase_NotSuperAdmin(t *testing.T) {
+	assert := assert.New(t)
+
+	actor := dtomocks.UserWithRole
+	actor.SetPermissions([]string{usermodels.PermissionOrganizationWrite})
+	ctxWithUser := app_context.NewContextWithUser(&actor)
+
+	testOrganizationRepository := new(repositoriesmocks.MockOrganizationRepository)
+
+	uc := NewCreateOrganizationUseCase(testOrganizationRepository)
+	result := uc.Execute(ctxWithUser, locales.EN_US, usermodels.OrganizationCreate{
+		OrganizationBase: usermodels.OrganizationBase{Name: "Acme", Slug: "acme"},
+	})
+
+	assert.True(result.HasError())
+	assert.Equal(status.Unauthorized, result.StatusCode)
+	testOrganizationRepository.AssertNotCalled(t, "Create")
+}
@@ -0,0 +1,74 @@
+package userusecases
+
+import (
+	usercontracts "github.com/simon3640/goprojectskeleton/src/application/modules/user/contracts"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/guards"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
+
+// CreateRoleUseCase is a use case that creates a role, the role is created
+// without permissions
+type CreateRoleUseCase struct {
+	usecase.BaseUseCaseValidation[usermodels.RoleCreate, usermodels.Role]
+	repo usercontracts.IRoleRepository
+}
+
+var _ usecase.BaseUseCase[usermodels.RoleCreate, usermodels.Role] = (*CreateRoleUseCase)(nil)
+
+// Execute executes the use case
+func (uc *CreateRoleUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input usermodels.RoleCreate,
+) *usecase.UseCaseResult[usermodels.Role] {
+	result := usecase.NewUseCaseResult[usermodels.Role]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	res := uc.createRole(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	result.SetData(
+		status.Created,
+		*res,
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.ROLE_WAS_CREATED,
+		),
+	)
+	observability.GetObservabilityComponents().Logger.InfoWithContext("Role created successfully", uc.AppContext)
+	return result
+}
+
+func (uc *CreateRoleUseCase) createRole(input usermodels.RoleCreate, result *usecase.UseCaseResult[usermodels.Role]) *usermodels.Role {
+	res, err := uc.repo.Create(input)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error creating role", err.ToError(), uc.AppContext)
+		result.SetError(err.Code, uc.AppMessages.Get(uc.Locale, err.Context))
+	}
+	return res
+}
+
+// NewCreateRoleUseCase creates a new create role use case
+func NewCreateRoleUseCase(
+	repo usercontracts.IRoleRepository,
+) *CreateRoleUseCase {
+	return &CreateRoleUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[usermodels.RoleCreate, usermodels.Role]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(guards.PermissionGuard(usermodels.PermissionRoleWrite)),
+		},
+		repo: repo,
+	}
+}
@@ -0,0 +1,65 @@
+package userusecases
+
+import (
+	"testing"
+
+	usermocks "github.com/simon3640/goprojectskeleton/src/application/modules/user/mocks"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateRoleUseCase(t *testing.T) {
+	assert := assert.New(t)
+
+	actor := dtomocks.AdminUserWithRole
+	ctxWithUser := app_context.NewContextWithUser(&actor)
+
+	testRoleRepository := new(usermocks.MockRoleRepository)
+	input := usermodels.RoleCreate{RoleBase: usermodels.RoleBase{Key: "support", IsActive: true, Priority: 3}}
+	testRoleRepository.On("Create", input).Return(&usermodels.Role{RoleBase: input.RoleBase, ID: 3}, nil)
+
+	uc := NewCreateRoleUseCase(testRoleRepository)
+	result := uc.Execute(ctxWithUser, locales.EN_US, input)
+
+	assert.True(result.IsSuccess())
+	assert.Equal(status.Created, result.StatusCode)
+	assert.Equal(uint(3), result.Data.ID)
+	assert.Equal("support", result.Data.Key)
+}
+
+func TestCreateRoleUseCase_InvalidInput(t *testing.T) {
+	assert := assert.New(t)
+
+	actor := dtomocks.AdminUserWithRole
+	ctxWithUser := app_context.NewContextWithUser(&actor)
+
+	testRoleRepository := new(usermocks.MockRoleRepository)
+
+	uc := NewCreateRoleUseCase(testRoleRepository)
+	result := uc.Execute(ctxWithUser, locales.EN_US, usermodels.RoleCreate{})
+
+	assert.True(result.HasError())
+	assert.Equal(status.InvalidInput, result.StatusCode)
+	testRoleRepository.AssertNotCalled(t, "Create")
+}
+
+func TestCreateRoleUseCase_Unauthorized(t *testing.T) {
+	assert := assert.New(t)
+
+	actor := dtomocks.UserWithRole
+	ctxWithUser := app_context.NewContextWithUser(&actor)
+
+	testRoleRepository := new(usermocks.MockRoleRepository)
+
+	uc := NewCreateRoleUseCase(testRoleRepository)
+	result := uc.Execute(ctxWithUser, locales.EN_US, usermodels.RoleCreate{RoleBase: usermodels.RoleBase{Key: "support"}})
+
+	assert.True(result.HasError())
+	assert.Equal(status.Unauthorized, result.StatusCode)
+	testRoleRepository.AssertNotCalled(t, "Create")
+}
@@ -3,6 +3,7 @@ package userusecases
 import (
 	"strings"
 
+	contracts_repositories "github.com/simon3640/goprojectskeleton/src/application/contracts/repositories"
 	usercontracts "github.com/simon3640/goprojectskeleton/src/application/modules/user/contracts"
 	userdtos "github.com/simon3640/goprojectskeleton/src/application/modules/user/dtos"
 	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
@@ -14,10 +15,12 @@ import (
 	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
 )
 
-// CreateUserUseCase is a use case that creates a user
+// CreateUserUseCase is a use case that creates a user. Users created by an
+// actor acting in an organization are members of it.
 type CreateUserUseCase struct {
 	usecase.BaseUseCaseValidation[userdtos.UserCreate, usermodels.User]
-	repo usercontracts.IUserRepository
+	repo             usercontracts.IUserRepository
+	organizationRepo contracts_repositories.IOrganizationRepository
 }
 
 var _ usecase.BaseUseCase[userdtos.UserCreate, usermodels.User] = (*CreateUserUseCase)(nil)
@@ -30,6 +33,7 @@ func (uc *CreateUserUseCase) Execute(ctx *app_context.AppContext,
 	result := usecase.NewUseCaseResult[usermodels.User]()
 	uc.SetLocale(locale)
 	uc.SetAppContext(ctx)
+	assignRole(uc.AppContext.User, &input)
 	uc.validate(input, result)
 
 	if result.HasError() {
@@ -40,6 +44,10 @@ func (uc *CreateUserUseCase) Execute(ctx *app_context.AppContext,
 	if result.HasError() {
 		return result
 	}
+	uc.addMembership(res, result)
+	if result.HasError() {
+		return result
+	}
 
 	result.SetData(
 		status.Created,
@@ -61,6 +69,36 @@ func (uc *CreateUserUseCase) createUser(input userdtos.UserCreate, result *useca
 	return res
 }
 
+// addMembership makes the user created a member of the organization the
+// actor acts in. The user is deleted if the membership cannot be created, so
+// it is not left out of every tenant.
+func (uc *CreateUserUseCase) addMembership(user *usermodels.User, result *usecase.UseCaseResult[usermodels.User]) {
+	tenantID, scoped := uc.AppContext.TenantID()
+	if !scoped {
+		return
+	}
+	if err := uc.organizationRepo.AddMember(tenantID, user.ID); err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error adding user to organization", err.ToError(), uc.AppContext)
+		if deleteErr := uc.repo.Delete(user.ID); deleteErr != nil {
+			observability.GetObservabilityComponents().Logger.ErrorWithContext("Error deleting user without organization", deleteErr.ToError(), uc.AppContext)
+		}
+		result.SetError(err.Code, uc.AppMessages.Get(uc.Locale, err.Context))
+	}
+}
+
+// assignRole sets the role of the user to create. Users registering
+// themselves get the default user role whatever they ask for, only actors
+// allowed to assign roles choose it. Users of an identity provider keep the
+// role configured for the provider.
+func assignRole(actor *usermodels.UserWithRole, input *userdtos.UserCreate) {
+	if input.ExternalIdentity {
+		return
+	}
+	if actor == nil || !actor.HasPermission(usermodels.PermissionUserAssignRole) || input.RoleID == 0 {
+		input.RoleID = usermodels.UserRoleID
+	}
+}
+
 func (uc *CreateUserUseCase) validate(
 	input userdtos.UserCreate,
 	result *usecase.UseCaseResult[usermodels.User]) {
@@ -78,12 +116,14 @@ func (uc *CreateUserUseCase) validate(
 // NewCreateUserUseCase creates a new create user use case
 func NewCreateUserUseCase(
 	repo usercontracts.IUserRepository,
+	organizationRepo contracts_repositories.IOrganizationRepository,
 ) *CreateUserUseCase {
 	return &CreateUserUseCase{
 		BaseUseCaseValidation: usecase.BaseUseCaseValidation[userdtos.UserCreate, usermodels.User]{
 			AppMessages: locales.NewLocale(locales.EN_US),
 			Guards:      usecase.NewGuards(),
 		},
-		repo: repo,
+		repo:             repo,
+		organizationRepo: organizationRepo,
 	}
 }
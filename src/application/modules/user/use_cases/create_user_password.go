@@ -16,23 +16,26 @@ import (
 	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
 )
 
-// CreateUserAndPasswordUseCase is a use case that creates a user and a password
+// CreateUserAndPasswordUseCase is the first step of the create user pipe.
+// It validates the user and the password, hashes the password and creates
+// the user; the password is created by the next step of the pipe.
 type CreateUserAndPasswordUseCase struct {
-	usecase.BaseUseCaseValidation[userdtos.UserAndPasswordCreate, usermodels.User]
+	usecase.BaseUseCaseValidation[userdtos.UserAndPasswordCreate, userdtos.UserWithPasswordHash]
 	repo         usercontracts.IUserRepository
 	hashProvider contractsProviders.IHashProvider
 }
 
-var _ usecase.BaseUseCase[userdtos.UserAndPasswordCreate, usermodels.User] = (*CreateUserAndPasswordUseCase)(nil)
+var _ usecase.BaseUseCase[userdtos.UserAndPasswordCreate, userdtos.UserWithPasswordHash] = (*CreateUserAndPasswordUseCase)(nil)
 
 // Execute executes the use case
 func (uc *CreateUserAndPasswordUseCase) Execute(ctx *app_context.AppContext,
 	locale locales.LocaleTypeEnum,
 	input userdtos.UserAndPasswordCreate,
-) *usecase.UseCaseResult[usermodels.User] {
-	result := usecase.NewUseCaseResult[usermodels.User]()
+) *usecase.UseCaseResult[userdtos.UserWithPasswordHash] {
+	result := usecase.NewUseCaseResult[userdtos.UserWithPasswordHash]()
 	uc.SetLocale(locale)
 	uc.SetAppContext(ctx)
+	assignRole(uc.AppContext.User, &input.UserCreate)
 	uc.validate(&input, result)
 
 	if result.HasError() {
@@ -51,7 +54,7 @@ func (uc *CreateUserAndPasswordUseCase) Execute(ctx *app_context.AppContext,
 
 	result.SetData(
 		status.Created,
-		*res,
+		userdtos.UserWithPasswordHash{User: *res, PasswordHash: input.Password},
 		uc.AppMessages.Get(
 			uc.Locale,
 			messages.MessageKeysInstance.USER_WAS_CREATED,
@@ -61,10 +64,10 @@ func (uc *CreateUserAndPasswordUseCase) Execute(ctx *app_context.AppContext,
 	return result
 }
 
-func (uc *CreateUserAndPasswordUseCase) createUser(input userdtos.UserAndPasswordCreate, result *usecase.UseCaseResult[usermodels.User]) *usermodels.User {
-	res, err := uc.repo.CreateWithPassword(input)
+func (uc *CreateUserAndPasswordUseCase) createUser(input userdtos.UserAndPasswordCreate, result *usecase.UseCaseResult[userdtos.UserWithPasswordHash]) *usermodels.User {
+	res, err := uc.repo.Create(input.UserCreate)
 	if err != nil {
-		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error creating user with password", err.ToError(), uc.AppContext)
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error creating user", err.ToError(), uc.AppContext)
 		result.SetError(
 			err.Code,
 			uc.AppMessages.Get(uc.Locale, err.Context),
@@ -74,7 +77,7 @@ func (uc *CreateUserAndPasswordUseCase) createUser(input userdtos.UserAndPasswor
 	return res
 }
 
-func (uc *CreateUserAndPasswordUseCase) hashPassword(input *userdtos.UserAndPasswordCreate, result *usecase.UseCaseResult[usermodels.User]) {
+func (uc *CreateUserAndPasswordUseCase) hashPassword(input *userdtos.UserAndPasswordCreate, result *usecase.UseCaseResult[userdtos.UserWithPasswordHash]) {
 	var err *applicationerror.ApplicationError
 	input.Password, err = uc.hashProvider.HashPassword(input.Password)
 	if err != nil {
@@ -88,7 +91,7 @@ func (uc *CreateUserAndPasswordUseCase) hashPassword(input *userdtos.UserAndPass
 
 func (uc *CreateUserAndPasswordUseCase) validate(
 	input *userdtos.UserAndPasswordCreate,
-	result *usecase.UseCaseResult[usermodels.User]) {
+	result *usecase.UseCaseResult[userdtos.UserWithPasswordHash]) {
 	msgs := input.Validate()
 
 	if len(msgs) > 0 {
@@ -106,7 +109,7 @@ func NewCreateUserAndPasswordUseCase(
 	hashProvider contractsProviders.IHashProvider,
 ) *CreateUserAndPasswordUseCase {
 	return &CreateUserAndPasswordUseCase{
-		BaseUseCaseValidation: usecase.BaseUseCaseValidation[userdtos.UserAndPasswordCreate, usermodels.User]{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[userdtos.UserAndPasswordCreate, userdtos.UserWithPasswordHash]{
 			AppMessages: locales.NewLocale(locales.EN_US),
 			Guards:      usecase.NewGuards(),
 		},
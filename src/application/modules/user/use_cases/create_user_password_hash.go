@@ -0,0 +1,62 @@
+package userusecases
+
+import (
+	usercontracts "github.com/simon3640/goprojectskeleton/src/application/modules/user/contracts"
+	userdtos "github.com/simon3640/goprojectskeleton/src/application/modules/user/dtos"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
+
+// CreateUserPasswordHashUseCase is the step of the create user pipe creating
+// the password of the user from the hash of the previous step
+type CreateUserPasswordHashUseCase struct {
+	usecase.BaseUseCaseValidation[userdtos.UserWithPasswordHash, usermodels.User]
+	repo usercontracts.IUserRepository
+}
+
+var _ usecase.BaseUseCase[userdtos.UserWithPasswordHash, usermodels.User] = (*CreateUserPasswordHashUseCase)(nil)
+
+// Execute executes the use case
+func (uc *CreateUserPasswordHashUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input userdtos.UserWithPasswordHash,
+) *usecase.UseCaseResult[usermodels.User] {
+	result := usecase.NewUseCaseResult[usermodels.User]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+
+	if err := uc.repo.CreatePassword(input.User.ID, input.PasswordHash); err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error creating password for user", err.ToError(), uc.AppContext)
+		result.SetError(
+			err.Code,
+			uc.AppMessages.Get(uc.Locale, err.Context),
+		)
+		return result
+	}
+
+	result.SetData(
+		status.Created,
+		input.User,
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.USER_WAS_CREATED,
+		),
+	)
+	return result
+}
+
+// NewCreateUserPasswordHashUseCase creates a new create user password hash use case
+func NewCreateUserPasswordHashUseCase(repo usercontracts.IUserRepository) *CreateUserPasswordHashUseCase {
+	return &CreateUserPasswordHashUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[userdtos.UserWithPasswordHash, usermodels.User]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(),
+		},
+		repo: repo,
+	}
+}
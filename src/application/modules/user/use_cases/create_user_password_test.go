@@ -11,6 +11,7 @@ import (
 	applicationerror "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
 	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
 	appstatus "github.com/simon3640/goprojectskeleton/src/application/shared/status"
 	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
@@ -43,10 +44,7 @@ func TestCreateUserAndPassword(t *testing.T) {
 		Password: "P@ssw0rd",
 	}
 
-	testUserAndPasswordHash := testUserAndPassword
-	testUserAndPasswordHash.Password = "hashed_password"
-
-	testUserRepository.On("CreateWithPassword", testUserAndPasswordHash).Return(&usermodels.User{
+	testUserRepository.On("Create", testUserAndPassword.UserCreate).Return(&usermodels.User{
 		UserBase: userBase,
 		DBBaseModel: sharedmodels.DBBaseModel{
 			ID:        1,
@@ -70,7 +68,9 @@ func TestCreateUserAndPassword(t *testing.T) {
 	// Assert the result
 	assert.True(result.IsSuccess())
 	assert.NotNil(result.Data)
-	assert.Equal(uint(1), result.Data.ID)
+	assert.Equal(uint(1), result.Data.User.ID)
+	// The password is created with the hash by the next step of the pipe
+	assert.Equal("hashed_password", result.Data.PasswordHash)
 }
 
 func TestCreateUserAndPassword_InvalidPassword(t *testing.T) {
@@ -154,7 +154,9 @@ func TestCreateUserAndPassword_InvalidEmail(t *testing.T) {
 func TestCreateUserAndPassword_InvalidRoleID(t *testing.T) {
 	assert := assert.New(t)
 
-	ctx := &app_context.AppContext{Context: context.Background()}
+	// Even the actors allowed to assign roles cannot create admins
+	admin := dtomocks.AdminUserWithRole
+	ctx := app_context.NewContextWithUser(&admin)
 
 	testUserRepository := new(usermocks.MockUserRepository)
 	testHashProvider := new(providersmocks.MockHashProvider)
@@ -261,20 +263,17 @@ func TestCreateUserAndPassword_RepositoryError(t *testing.T) {
 		Password: "P@ssw0rd123",
 	}
 
-	testUserAndPasswordHash := testUserAndPassword
-	testUserAndPasswordHash.Password = "hashed_password"
-
 	// Mock HashPassword success
 	testHashProvider.On("HashPassword", testUserAndPassword.Password).Return("hashed_password", nil)
 
-	// Mock CreateWithPassword returning error
+	// Mock Create returning error
 	appErr := applicationerror.NewApplicationError(
 		appstatus.InternalError,
 		messages.MessageKeysInstance.SOMETHING_WENT_WRONG,
 		"Failed to create user",
 	)
 	var nilUser *usermodels.User
-	testUserRepository.On("CreateWithPassword", testUserAndPasswordHash).Return(nilUser, appErr)
+	testUserRepository.On("Create", testUserAndPassword.UserCreate).Return(nilUser, appErr)
 
 	// Create the use case
 	useCase := NewCreateUserAndPasswordUseCase(
@@ -8,13 +8,16 @@ import (
 	userdtos "github.com/simon3640/goprojectskeleton/src/application/modules/user/dtos"
 	usermocks "github.com/simon3640/goprojectskeleton/src/application/modules/user/mocks"
 	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
 	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	repositoriesmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/repositories"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
 	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
 	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestCreateUserUseCase(t *testing.T) {
@@ -40,7 +43,7 @@ func TestCreateUserUseCase(t *testing.T) {
 		},
 	}, nil)
 
-	uc := NewCreateUserUseCase(testUserRepository)
+	uc := NewCreateUserUseCase(testUserRepository, new(repositoriesmocks.MockOrganizationRepository))
 
 	result := uc.Execute(ctx, locales.EN_US, testUser)
 
@@ -52,7 +55,9 @@ func TestCreateUserUseCase(t *testing.T) {
 func TestCreateUserUseCase_InvalidInput(t *testing.T) {
 	assert := assert.New(t)
 
-	ctx := &app_context.AppContext{Context: context.Background()}
+	// Even the actors allowed to assign roles cannot create admins
+	admin := dtomocks.AdminUserWithRole
+	ctx := app_context.NewContextWithUser(&admin)
 
 	testUserRepository := new(usermocks.MockUserRepository)
 	userStatus := usermodels.UserStatusPending
@@ -65,7 +70,7 @@ func TestCreateUserUseCase_InvalidInput(t *testing.T) {
 		},
 	}
 
-	uc := NewCreateUserUseCase(testUserRepository)
+	uc := NewCreateUserUseCase(testUserRepository, new(repositoriesmocks.MockOrganizationRepository))
 
 	result := uc.Execute(ctx, locales.EN_US, testUserInvalidRoleID)
 
@@ -75,3 +80,98 @@ func TestCreateUserUseCase_InvalidInput(t *testing.T) {
 	assert.Equal(result.StatusCode, status.InvalidInput)
 
 }
+
+func TestCreateUserUseCase_SelfRegistrationRole(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := &app_context.AppContext{Context: context.Background()}
+
+	testUserRepository := new(usermocks.MockUserRepository)
+	testUser := dtomocks.UserCreate
+	testUser.RoleID = 5
+	testUserRepository.On("Create", mock.MatchedBy(func(input userdtos.UserCreate) bool {
+		return input.RoleID == usermodels.UserRoleID
+	})).Return(&usermodels.User{UserBase: testUser.UserBase}, nil)
+
+	uc := NewCreateUserUseCase(testUserRepository, new(repositoriesmocks.MockOrganizationRepository))
+
+	result := uc.Execute(ctx, locales.EN_US, testUser)
+
+	assert.True(result.IsSuccess())
+	testUserRepository.AssertCalled(t, "Create", mock.Anything)
+}
+
+func TestCreateUserUseCase_AssignRole(t *testing.T) {
+	assert := assert.New(t)
+
+	admin := dtomocks.AdminUserWithRole
+	ctx := app_context.NewContextWithUser(&admin)
+
+	testUserRepository := new(usermocks.MockUserRepository)
+	testUser := dtomocks.UserCreate
+	testUser.RoleID = 5
+	testUserRepository.On("Create", mock.MatchedBy(func(input userdtos.UserCreate) bool {
+		return input.RoleID == 5
+	})).Return(&usermodels.User{UserBase: testUser.UserBase}, nil)
+
+	uc := NewCreateUserUseCase(testUserRepository, new(repositoriesmocks.MockOrganizationRepository))
+
+	result := uc.Execute(ctx, locales.EN_US, testUser)
+
+	assert.True(result.IsSuccess())
+}
+
+func TestCreateUserUseCase_Membership(t *testing.T) {
+	assert := assert.New(t)
+
+	admin := dtomocks.AdminUserWithRole
+	admin.SetOrganizations([]uint{3})
+	admin.SetTenant(3)
+	ctx := app_context.NewContextWithUser(&admin)
+
+	testUserRepository := new(usermocks.MockUserRepository)
+	testUser := dtomocks.UserCreate
+	testUserRepository.On("Create", mock.Anything).Return(&usermodels.User{
+		UserBase:    testUser.UserBase,
+		DBBaseModel: sharedmodels.DBBaseModel{ID: 7},
+	}, nil)
+	testOrganizationRepository := new(repositoriesmocks.MockOrganizationRepository)
+	testOrganizationRepository.On("AddMember", uint(3), uint(7)).Return(nil)
+
+	uc := NewCreateUserUseCase(testUserRepository, testOrganizationRepository)
+
+	// The user is a member of the organization of the admin
+	result := uc.Execute(ctx, locales.EN_US, testUser)
+
+	assert.True(result.IsSuccess())
+	testOrganizationRepository.AssertExpectations(t)
+}
+
+func TestCreateUserUseCase_MembershipNotCreated(t *testing.T) {
+	assert := assert.New(t)
+
+	admin := dtomocks.AdminUserWithRole
+	admin.SetOrganizations([]uint{3})
+	admin.SetTenant(3)
+	ctx := app_context.NewContextWithUser(&admin)
+
+	testUserRepository := new(usermocks.MockUserRepository)
+	testUser := dtomocks.UserCreate
+	testUserRepository.On("Create", mock.Anything).Return(&usermodels.User{
+		UserBase:    testUser.UserBase,
+		DBBaseModel: sharedmodels.DBBaseModel{ID: 7},
+	}, nil)
+	testUserRepository.On("Delete", uint(7)).Return(nil)
+	testOrganizationRepository := new(repositoriesmocks.MockOrganizationRepository)
+	testOrganizationRepository.On("AddMember", uint(3), uint(7)).
+		Return(application_errors.NewApplicationError(status.ProviderError, "", "database unavailable"))
+
+	uc := NewCreateUserUseCase(testUserRepository, testOrganizationRepository)
+
+	// The user out of every organization is deleted
+	result := uc.Execute(ctx, locales.EN_US, testUser)
+
+	assert.True(result.HasError())
+	assert.Equal(status.ProviderError, result.StatusCode)
+	testUserRepository.AssertCalled(t, "Delete", uint(7))
+}
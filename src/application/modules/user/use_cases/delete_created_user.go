@@ -0,0 +1,63 @@
+package userusecases
+
+import (
+	usercontracts "github.com/simon3640/goprojectskeleton/src/application/modules/user/contracts"
+	userdtos "github.com/simon3640/goprojectskeleton/src/application/modules/user/dtos"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+)
+
+// DeleteCreatedUserUseCase is the compensation of the user creation step of
+// the create user pipe. It hard deletes the user, so the email and phone can
+// be used again. A user already removed by the rollback of the transaction
+// of the pipe is not an error.
+type DeleteCreatedUserUseCase struct {
+	usecase.BaseUseCaseValidation[userdtos.UserWithPasswordHash, bool]
+	repo usercontracts.IUserRepository
+}
+
+var _ usecase.BaseUseCase[userdtos.UserWithPasswordHash, bool] = (*DeleteCreatedUserUseCase)(nil)
+
+// Execute executes the use case
+func (uc *DeleteCreatedUserUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input userdtos.UserWithPasswordHash,
+) *usecase.UseCaseResult[bool] {
+	result := usecase.NewUseCaseResult[bool]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+
+	if err := uc.repo.Delete(input.User.ID); err != nil && err.Code != status.NotFound {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error deleting the created user", err.ToError(), uc.AppContext)
+		result.SetError(
+			err.Code,
+			uc.AppMessages.Get(uc.Locale, err.Context),
+		)
+		return result
+	}
+
+	result.SetData(
+		status.Success,
+		true,
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.USER_DELETE_SUCCESS,
+		),
+	)
+	return result
+}
+
+// NewDeleteCreatedUserUseCase creates a new delete created user use case
+func NewDeleteCreatedUserUseCase(repo usercontracts.IUserRepository) *DeleteCreatedUserUseCase {
+	return &DeleteCreatedUserUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[userdtos.UserWithPasswordHash, bool]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(),
+		},
+		repo: repo,
+	}
+}
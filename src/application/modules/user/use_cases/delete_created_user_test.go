@@ -0,0 +1,49 @@
+package userusecases
+
+import (
+	"context"
+	"testing"
+
+	userdtos "github.com/simon3640/goprojectskeleton/src/application/modules/user/dtos"
+	usermocks "github.com/simon3640/goprojectskeleton/src/application/modules/user/mocks"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	applicationerror "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	appstatus "github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteCreatedUserUseCase(t *testing.T) {
+	cases := []struct {
+		name    string
+		err     *applicationerror.ApplicationError
+		success bool
+	}{
+		{name: "deleted", success: true},
+		// The rollback of the transaction of the pipe already removed the user
+		{name: "already removed", err: applicationerror.NewApplicationError(appstatus.NotFound, messages.MessageKeysInstance.RESOURCE_NOT_FOUND, "not found"), success: true},
+		{name: "not deleted", err: applicationerror.NewApplicationError(appstatus.InternalError, messages.MessageKeysInstance.SOMETHING_WENT_WRONG, "connection lost")},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			ctx := &app_context.AppContext{Context: context.Background()}
+
+			testUserRepository := new(usermocks.MockUserRepository)
+			testUserRepository.On("Delete", uint(7)).Return(tc.err)
+
+			uc := NewDeleteCreatedUserUseCase(testUserRepository)
+			result := uc.Execute(ctx, locales.EN_US, userdtos.UserWithPasswordHash{
+				User: usermodels.User{DBBaseModel: sharedmodels.DBBaseModel{ID: 7}},
+			})
+
+			assert.Equal(tc.success, result.IsSuccess())
+			testUserRepository.AssertCalled(t, "Delete", uint(7))
+		})
+	}
+}
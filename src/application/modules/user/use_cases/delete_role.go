@@ -0,0 +1,121 @@
+package userusecases
+
+import (
+	contractsproviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	contracts_repositories "github.com/simon3640/goprojectskeleton/src/application/contracts/repositories"
+	usercontracts "github.com/simon3640/goprojectskeleton/src/application/modules/user/contracts"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/guards"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/services"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
+
+// DeleteRoleUseCase is a use case that deletes a role with its permissions.
+// The built-in roles and the roles held by users can not be deleted.
+type DeleteRoleUseCase struct {
+	usecase.BaseUseCaseValidation[uint, bool]
+	repo              usercontracts.IRoleRepository
+	permissionService *services.PermissionService
+}
+
+var _ usecase.BaseUseCase[uint, bool] = (*DeleteRoleUseCase)(nil)
+
+// Execute executes the use case
+func (uc *DeleteRoleUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input uint,
+) *usecase.UseCaseResult[bool] {
+	result := usecase.NewUseCaseResult[bool]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	uc.checkBuiltIn(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	uc.checkUsers(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	if err := uc.repo.Delete(input); err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error deleting role", err.ToError(), uc.AppContext)
+		result.SetError(err.Code, uc.AppMessages.Get(uc.Locale, err.Context))
+		return result
+	}
+	if err := uc.permissionService.InvalidateRole(input); err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error invalidating role permissions", err.ToError(), uc.AppContext)
+	}
+
+	result.SetData(
+		status.Success,
+		true,
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.ROLE_DELETE_SUCCESS,
+		),
+	)
+	observability.GetObservabilityComponents().Logger.InfoWithContext("Role deleted successfully", uc.AppContext)
+	return result
+}
+
+// checkBuiltIn rejects deleting a built-in role
+func (uc *DeleteRoleUseCase) checkBuiltIn(id uint, result *usecase.UseCaseResult[bool]) {
+	role, err := uc.repo.GetByID(id)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting role by ID", err.ToError(), uc.AppContext)
+		result.SetError(err.Code, uc.AppMessages.Get(uc.Locale, err.Context))
+		return
+	}
+	if role.IsBuiltIn() {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Attempt to delete a built-in role", uc.AppContext)
+		result.SetError(
+			status.Conflict,
+			uc.AppMessages.Get(uc.Locale, messages.MessageKeysInstance.BUILT_IN_ROLE_PROTECTED),
+		)
+	}
+}
+
+// checkUsers rejects deleting a role held by users, they would be left
+// without role
+func (uc *DeleteRoleUseCase) checkUsers(id uint, result *usecase.UseCaseResult[bool]) {
+	hasUsers, err := uc.repo.HasUsers(id)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error checking users of role", err.ToError(), uc.AppContext)
+		result.SetError(err.Code, uc.AppMessages.Get(uc.Locale, err.Context))
+		return
+	}
+	if hasUsers {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Attempt to delete a role held by users", uc.AppContext)
+		result.SetError(
+			status.Conflict,
+			uc.AppMessages.Get(uc.Locale, messages.MessageKeysInstance.ROLE_HAS_USERS),
+		)
+	}
+}
+
+// NewDeleteRoleUseCase creates a new delete role use case
+func NewDeleteRoleUseCase(
+	repo usercontracts.IRoleRepository,
+	permissionRepository contracts_repositories.IPermissionRepository,
+	cacheProvider contractsproviders.ICacheProvider,
+) *DeleteRoleUseCase {
+	return &DeleteRoleUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[uint, bool]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(guards.PermissionGuard(usermodels.PermissionRoleWrite)),
+		},
+		repo:              repo,
+		permissionService: services.NewPermissionService(permissionRepository, cacheProvider),
+	}
+}
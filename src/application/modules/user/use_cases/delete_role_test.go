@@ -0,0 +1,81 @@
+package userusecases
+
+import (
+	"testing"
+
+	usermocks "github.com/simon3640/goprojectskeleton/src/application/modules/user/mocks"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
+	repositoriesmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/repositories"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/services"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteRoleUseCase(t *testing.T) {
+	assert := assert.New(t)
+
+	actor := dtomocks.AdminUserWithRole
+	ctxWithUser := app_context.NewContextWithUser(&actor)
+
+	testRoleRepository := new(usermocks.MockRoleRepository)
+	testPermissionRepository := new(repositoriesmocks.MockPermissionRepository)
+	cache := providersmocks.NewMemoryCacheProvider()
+	_ = cache.Set(services.RolePermissionsKey(3), []string{usermodels.PermissionUserRead}, 0)
+
+	testRoleRepository.On("GetByID", uint(3)).Return(&usermodels.Role{RoleBase: usermodels.RoleBase{Key: "support"}, ID: 3}, nil)
+	testRoleRepository.On("HasUsers", uint(3)).Return(false, nil)
+	testRoleRepository.On("Delete", uint(3)).Return(nil)
+
+	uc := NewDeleteRoleUseCase(testRoleRepository, testPermissionRepository, cache)
+	result := uc.Execute(ctxWithUser, locales.EN_US, uint(3))
+
+	assert.True(result.IsSuccess())
+	assert.True(*result.Data)
+	testRoleRepository.AssertCalled(t, "Delete", uint(3))
+	assert.Empty(cache.Keys())
+}
+
+func TestDeleteRoleUseCase_BuiltInRole(t *testing.T) {
+	for _, role := range []usermodels.Role{dtomocks.AdminRole, dtomocks.UserRole} {
+		t.Run(role.Key, func(t *testing.T) {
+			assert := assert.New(t)
+
+			actor := dtomocks.AdminUserWithRole
+			ctxWithUser := app_context.NewContextWithUser(&actor)
+
+			testRoleRepository := new(usermocks.MockRoleRepository)
+			testRoleRepository.On("GetByID", role.ID).Return(&role, nil)
+
+			uc := NewDeleteRoleUseCase(testRoleRepository, new(repositoriesmocks.MockPermissionRepository), nil)
+			result := uc.Execute(ctxWithUser, locales.EN_US, role.ID)
+
+			assert.True(result.HasError())
+			assert.Equal(status.Conflict, result.StatusCode)
+			testRoleRepository.AssertNotCalled(t, "Delete", role.ID)
+		})
+	}
+}
+
+func TestDeleteRoleUseCase_RoleWithUsers(t *testing.T) {
+	assert := assert.New(t)
+
+	actor := dtomocks.AdminUserWithRole
+	ctxWithUser := app_context.NewContextWithUser(&actor)
+
+	testRoleRepository := new(usermocks.MockRoleRepository)
+	testRoleRepository.On("GetByID", uint(3)).Return(&usermodels.Role{RoleBase: usermodels.RoleBase{Key: "support"}, ID: 3}, nil)
+	testRoleRepository.On("HasUsers", uint(3)).Return(true, nil)
+
+	uc := NewDeleteRoleUseCase(testRoleRepository, new(repositoriesmocks.MockPermissionRepository), nil)
+	result := uc.Execute(ctxWithUser, locales.EN_US, uint(3))
+
+	assert.True(result.HasError())
+	assert.Equal(status.Conflict, result.StatusCode)
+	assert.Equal("The role can not be deleted while users hold it.", *result.Error)
+	testRoleRepository.AssertNotCalled(t, "Delete", uint(3))
+}
@@ -1,20 +1,25 @@
 package userusecases
 
 import (
+	contractsproviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
 	usercontracts "github.com/simon3640/goprojectskeleton/src/application/modules/user/contracts"
 	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/guards"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/services"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
 	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
 )
 
 // DeleteUserUseCase is a use case that deletes a user
 type DeleteUserUseCase struct {
 	usecase.BaseUseCaseValidation[uint, bool]
-	repo usercontracts.IUserRepository
+	repo               usercontracts.IUserRepository
+	revocationProvider contractsproviders.ITokenRevocationProvider
 }
 
 var _ usecase.BaseUseCase[uint, bool] = (*DeleteUserUseCase)(nil)
@@ -36,6 +41,7 @@ func (uc *DeleteUserUseCase) Execute(ctx *app_context.AppContext,
 	if result.HasError() {
 		return result
 	}
+	services.RevokeUserTokensService(uc.AppContext, input, uc.revocationProvider)
 
 	result.SetData(
 		status.Success,
@@ -62,12 +68,14 @@ func (uc *DeleteUserUseCase) deleteUser(id uint, result *usecase.UseCaseResult[b
 // NewDeleteUserUseCase creates a new delete user use case
 func NewDeleteUserUseCase(
 	repo usercontracts.IUserRepository,
+	revocationProvider contractsproviders.ITokenRevocationProvider,
 ) *DeleteUserUseCase {
 	return &DeleteUserUseCase{
 		BaseUseCaseValidation: usecase.BaseUseCaseValidation[uint, bool]{
-			Guards:      usecase.NewGuards(guards.RoleGuard("admin", "user"), guards.UserGetItSelf),
+			Guards:      usecase.NewGuards(guards.PermissionGuard(usermodels.PermissionUserDelete), guards.UserGetItSelf, guards.NotImpersonatedGuard).WithScopes(sharedmodels.OAuthScopeProfileWrite),
 			AppMessages: locales.NewLocale(locales.EN_US),
 		},
-		repo: repo,
+		repo:               repo,
+		revocationProvider: revocationProvider,
 	}
 }
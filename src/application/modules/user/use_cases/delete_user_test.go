@@ -7,6 +7,7 @@ import (
 	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
 	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
 
 	"github.com/stretchr/testify/assert"
@@ -22,14 +23,17 @@ func TestDeleteUserUseCase(t *testing.T) {
 	var testIDToDelete = actor.ID
 
 	testUserRepository.On("SoftDelete", testIDToDelete).Return(nil)
+	testRevocationProvider := new(providersmocks.MockTokenRevocationProvider)
+	testRevocationProvider.On("RevokeUserTokens", testIDToDelete).Return(nil)
 
-	uc := NewDeleteUserUseCase(testUserRepository)
+	uc := NewDeleteUserUseCase(testUserRepository, testRevocationProvider)
 
 	result := uc.Execute(ctxWithUser, locales.EN_US, testIDToDelete)
 
 	assert.NotNil(result)
 	assert.Equal(result.StatusCode, status.Success)
 	assert.Equal(*result.Data, true)
+	testRevocationProvider.AssertCalled(t, "RevokeUserTokens", testIDToDelete)
 }
 
 func TestDeleteUserUseCase_DifferentUser(t *testing.T) {
@@ -43,10 +47,28 @@ func TestDeleteUserUseCase_DifferentUser(t *testing.T) {
 
 	testUserRepository.On("SoftDelete", testIDToDelete).Return(nil)
 
-	uc := NewDeleteUserUseCase(testUserRepository)
+	uc := NewDeleteUserUseCase(testUserRepository, nil)
 
 	result := uc.Execute(ctxWithUser, locales.EN_US, testIDToDelete)
 
 	assert.NotNil(result)
 	assert.Equal(result.StatusCode, status.Unauthorized)
 }
+
+func TestDeleteUserUseCase_Impersonated(t *testing.T) {
+	assert := assert.New(t)
+
+	actor := dtomocks.UserWithRole
+	actor.SetImpersonator(dtomocks.AdminUserWithRole.ID, "impersonation-token-id")
+	ctxWithUser := app_context.NewContextWithUser(&actor)
+
+	testUserRepository := new(usermocks.MockUserRepository)
+
+	uc := NewDeleteUserUseCase(testUserRepository, nil)
+
+	result := uc.Execute(ctxWithUser, locales.EN_US, actor.ID)
+
+	assert.NotNil(result)
+	assert.Equal(result.StatusCode, status.Unauthorized)
+	testUserRepository.AssertNotCalled(t, "SoftDelete", actor.ID)
+}
@@ -0,0 +1,91 @@
+package userusecases
+
+import (
+	contracts_repositories "github.com/simon3640/goprojectskeleton/src/application/contracts/repositories"
+	userdtos "github.com/simon3640/goprojectskeleton/src/application/modules/user/dtos"
+	shareddtos "github.com/simon3640/goprojectskeleton/src/application/shared/DTOs"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/guards"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	domainutils "github.com/simon3640/goprojectskeleton/src/domain/shared/utils"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
+
+// GetAllOrganizationUseCase is a use case that gets the organizations with
+// filters, sorts and pagination. Users acting in a tenant only get its
+// organization.
+type GetAllOrganizationUseCase struct {
+	usecase.BaseUseCaseValidation[domainutils.QueryPayloadBuilder[usermodels.Organization], userdtos.OrganizationMultiResponse]
+	repo contracts_repositories.IOrganizationRepository
+}
+
+var _ usecase.BaseUseCase[domainutils.QueryPayloadBuilder[usermodels.Organization], userdtos.OrganizationMultiResponse] = (*GetAllOrganizationUseCase)(nil)
+
+// Execute executes the use case
+func (uc *GetAllOrganizationUseCase) Execute(
+	ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input domainutils.QueryPayloadBuilder[usermodels.Organization],
+) *usecase.UseCaseResult[userdtos.OrganizationMultiResponse] {
+	result := usecase.NewUseCaseResult[userdtos.OrganizationMultiResponse]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	data, total, err := uc.repo.GetAll(&input, input.Pagination.GetOffset(), input.Pagination.GetLimit())
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting all organizations", err.ToError(), uc.AppContext)
+		result.SetError(
+			err.Code,
+			uc.AppMessages.Get(uc.Locale, err.Context),
+		)
+		return result
+	}
+
+	result.SetData(
+		status.Success,
+		uc.buildMultiResponse(data, total, input),
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.ORGANIZATION_LIST_SUCCESS,
+		),
+	)
+	return result
+}
+
+// buildMultiResponse builds the multi response for the organizations
+func (uc *GetAllOrganizationUseCase) buildMultiResponse(
+	data []usermodels.Organization, total int64,
+	input domainutils.QueryPayloadBuilder[usermodels.Organization],
+) userdtos.OrganizationMultiResponse {
+	var response userdtos.OrganizationMultiResponse
+	response.Records = data
+	hasNext, hasPrev := input.HasNextPrev(total)
+	response.Meta = shareddtos.NewMetaMultiResponse(len(data), total, hasNext, hasPrev, false)
+	response.Meta.BuildLinks(
+		"/organization",
+		input.Pagination.Page,
+		input.Pagination.PageSize, input.BuildQueryParamsURL(),
+	)
+	return response
+}
+
+// NewGetAllOrganizationUseCase creates a new get all organization use case
+func NewGetAllOrganizationUseCase(
+	repo contracts_repositories.IOrganizationRepository,
+) *GetAllOrganizationUseCase {
+	return &GetAllOrganizationUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[domainutils.QueryPayloadBuilder[usermodels.Organization], userdtos.OrganizationMultiResponse]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(guards.PermissionGuard(usermodels.PermissionOrganizationRead)),
+		},
+		repo: repo,
+	}
+}
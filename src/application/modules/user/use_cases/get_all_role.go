@@ -0,0 +1,90 @@
+package userusecases
+
+import (
+	usercontracts "github.com/simon3640/goprojectskeleton/src/application/modules/user/contracts"
+	userdtos "github.com/simon3640/goprojectskeleton/src/application/modules/user/dtos"
+	shareddtos "github.com/simon3640/goprojectskeleton/src/application/shared/DTOs"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/guards"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	domainutils "github.com/simon3640/goprojectskeleton/src/domain/shared/utils"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
+
+// GetAllRoleUseCase is a use case that gets the roles with filters, sorts
+// and pagination
+type GetAllRoleUseCase struct {
+	usecase.BaseUseCaseValidation[domainutils.QueryPayloadBuilder[usermodels.Role], userdtos.RoleMultiResponse]
+	repo usercontracts.IRoleRepository
+}
+
+var _ usecase.BaseUseCase[domainutils.QueryPayloadBuilder[usermodels.Role], userdtos.RoleMultiResponse] = (*GetAllRoleUseCase)(nil)
+
+// Execute executes the use case
+func (uc *GetAllRoleUseCase) Execute(
+	ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input domainutils.QueryPayloadBuilder[usermodels.Role],
+) *usecase.UseCaseResult[userdtos.RoleMultiResponse] {
+	result := usecase.NewUseCaseResult[userdtos.RoleMultiResponse]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	data, total, err := uc.repo.GetAll(&input, input.Pagination.GetOffset(), input.Pagination.GetLimit())
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting all roles", err.ToError(), uc.AppContext)
+		result.SetError(
+			err.Code,
+			uc.AppMessages.Get(uc.Locale, err.Context),
+		)
+		return result
+	}
+
+	result.SetData(
+		status.Success,
+		uc.buildMultiResponse(data, total, input),
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.ROLE_LIST_SUCCESS,
+		),
+	)
+	return result
+}
+
+// buildMultiResponse builds the multi response for the roles
+func (uc *GetAllRoleUseCase) buildMultiResponse(
+	data []usermodels.Role, total int64,
+	input domainutils.QueryPayloadBuilder[usermodels.Role],
+) userdtos.RoleMultiResponse {
+	var response userdtos.RoleMultiResponse
+	response.Records = data
+	hasNext, hasPrev := input.HasNextPrev(total)
+	response.Meta = shareddtos.NewMetaMultiResponse(len(data), total, hasNext, hasPrev, false)
+	response.Meta.BuildLinks(
+		"/role",
+		input.Pagination.Page,
+		input.Pagination.PageSize, input.BuildQueryParamsURL(),
+	)
+	return response
+}
+
+// NewGetAllRoleUseCase creates a new get all role use case
+func NewGetAllRoleUseCase(
+	repo usercontracts.IRoleRepository,
+) *GetAllRoleUseCase {
+	return &GetAllRoleUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[domainutils.QueryPayloadBuilder[usermodels.Role], userdtos.RoleMultiResponse]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(guards.PermissionGuard(usermodels.PermissionRoleRead)),
+		},
+		repo: repo,
+	}
+}
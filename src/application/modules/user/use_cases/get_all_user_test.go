@@ -12,6 +12,7 @@ import (
 	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
 	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
 	appstatus "github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
 	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
 	domain_utils "github.com/simon3640/goprojectskeleton/src/domain/shared/utils"
 	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
@@ -34,17 +35,18 @@ func TestGetAllUserUseCase_Execute_SuccessFromCache(t *testing.T) {
 		ID: 1,
 	}
 	adminUser.SetRole(dtomocks.AdminRole)
+	adminUser.SetPermissions(dtomocks.AdminRolePermissions)
 	ctxWithUser := app_context.NewContextWithUser(&adminUser)
 
 	testUserRepository := new(usermocks.MockUserRepository)
-	testCacheProvider := new(providersmocks.MockCacheProvider)
+	cache := providersmocks.NewMemoryCacheProvider()
 
 	// Create query payload
 	page := 1
 	pageSize := 10
 	queryPayload := domain_utils.NewQueryPayloadBuilder[usermodels.User](nil, nil, &page, &pageSize)
 
-	// Mock cache - data found
+	// Mock repository, only called on the cache miss
 	testUsers := []usermodels.User{
 		{
 			UserBase: usermodels.UserBase{
@@ -54,30 +56,28 @@ func TestGetAllUserUseCase_Execute_SuccessFromCache(t *testing.T) {
 				RoleID: 2,
 			},
 			DBBaseModel: sharedmodels.DBBaseModel{
-				ID:        1,
-				CreatedAt: time.Now(),
-				UpdatedAt: time.Now(),
+				ID: 1,
 			},
 		},
 	}
 	var total int64 = 1
-
-	cacheKey := "users:" + queryPayload.GetQueryKey()
-	testCacheProvider.On("Get", cacheKey, mock.AnythingOfType("*[]models.User")).Return(true, nil, testUsers).Run(func(args mock.Arguments) {
-		dest := args.Get(1).(*[]usermodels.User)
-		*dest = testUsers
-	})
-	testCacheProvider.On("Get", cacheKey+":total", mock.AnythingOfType("*int64")).Return(true, nil, total).Run(func(args mock.Arguments) {
-		dest := args.Get(1).(*int64)
-		*dest = total
-	})
-
-	uc := NewGetAllUserUseCase(
-		testUserRepository,
-		testCacheProvider,
+	testUserRepository.On("GetAll", mock.Anything, 0, 10).Return(testUsers, total, nil).Once()
+
+	uc := usecase.Intercept(
+		NewGetAllUserUseCase(testUserRepository),
+		"get_all_user_use_case",
+		usecase.Cache(usecase.CacheOptions{
+			Provider: cache,
+			TTL:      time.Minute,
+			Tags:     func(any) []string { return []string{"user:*"} },
+		}),
 	)
 
 	result := uc.Execute(ctxWithUser, locales.EN_US, queryPayload)
+	assert.True(result.IsSuccess())
+	assert.False(result.Data.Meta.Cached)
+
+	result = uc.Execute(ctxWithUser, locales.EN_US, queryPayload)
 
 	assert.NotNil(result)
 	assert.True(result.IsSuccess())
@@ -86,6 +86,7 @@ func TestGetAllUserUseCase_Execute_SuccessFromCache(t *testing.T) {
 	assert.Equal(1, len(result.Data.Records))
 	assert.Equal(int64(1), result.Data.Meta.Total)
 	assert.True(result.Data.Meta.Cached)
+	testUserRepository.AssertNumberOfCalls(t, "GetAll", 1)
 }
 
 func TestGetAllUserUseCase_Execute_SuccessFromRepository(t *testing.T) {
@@ -102,21 +103,16 @@ func TestGetAllUserUseCase_Execute_SuccessFromRepository(t *testing.T) {
 		ID: 1,
 	}
 	adminUser.SetRole(dtomocks.AdminRole)
+	adminUser.SetPermissions(dtomocks.AdminRolePermissions)
 	ctxWithUser := app_context.NewContextWithUser(&adminUser)
 
 	testUserRepository := new(usermocks.MockUserRepository)
-	testCacheProvider := new(providersmocks.MockCacheProvider)
 
 	// Create query payload
 	page := 1
 	pageSize := 10
 	queryPayload := domain_utils.NewQueryPayloadBuilder[usermodels.User](nil, nil, &page, &pageSize)
 
-	// Mock cache - not found
-	cacheKey := "users:" + queryPayload.GetQueryKey()
-	testCacheProvider.On("Get", cacheKey, mock.AnythingOfType("*[]models.User")).Return(false, nil)
-	testCacheProvider.On("Get", cacheKey+":total", mock.AnythingOfType("*int64")).Return(false, nil)
-
 	// Mock repository
 	testUsers := []usermodels.User{
 		{
@@ -136,13 +132,8 @@ func TestGetAllUserUseCase_Execute_SuccessFromRepository(t *testing.T) {
 	var total int64 = 1
 	testUserRepository.On("GetAll", mock.Anything, 0, 10).Return(testUsers, total, nil)
 
-	// Mock cache set
-	testCacheProvider.On("Set", cacheKey, testUsers, mock.AnythingOfType("time.Duration")).Return(nil)
-	testCacheProvider.On("Set", cacheKey+":total", total, mock.AnythingOfType("time.Duration")).Return(nil)
-
 	uc := NewGetAllUserUseCase(
 		testUserRepository,
-		testCacheProvider,
 	)
 
 	result := uc.Execute(ctxWithUser, locales.EN_US, queryPayload)
@@ -169,21 +160,16 @@ func TestGetAllUserUseCase_Execute_RepositoryError(t *testing.T) {
 		ID: 1,
 	}
 	adminUser.SetRole(dtomocks.AdminRole)
+	adminUser.SetPermissions(dtomocks.AdminRolePermissions)
 	ctxWithUser := app_context.NewContextWithUser(&adminUser)
 
 	testUserRepository := new(usermocks.MockUserRepository)
-	testCacheProvider := new(providersmocks.MockCacheProvider)
 
 	// Create query payload
 	page := 1
 	pageSize := 10
 	queryPayload := domain_utils.NewQueryPayloadBuilder[usermodels.User](nil, nil, &page, &pageSize)
 
-	// Mock cache - not found
-	cacheKey := "users:" + queryPayload.GetQueryKey()
-	testCacheProvider.On("Get", cacheKey, mock.AnythingOfType("*[]models.User")).Return(false, nil)
-	testCacheProvider.On("Get", cacheKey+":total", mock.AnythingOfType("*int64")).Return(false, nil)
-
 	// Mock repository error
 	appErr := applicationerrors.NewApplicationError(
 		appstatus.InternalError,
@@ -194,7 +180,6 @@ func TestGetAllUserUseCase_Execute_RepositoryError(t *testing.T) {
 
 	uc := NewGetAllUserUseCase(
 		testUserRepository,
-		testCacheProvider,
 	)
 
 	result := uc.Execute(ctxWithUser, locales.EN_US, queryPayload)
@@ -205,182 +190,64 @@ func TestGetAllUserUseCase_Execute_RepositoryError(t *testing.T) {
 	assert.Equal(appstatus.InternalError, result.StatusCode)
 }
 
-func TestGetAllUserUseCase_Execute_CacheGetError(t *testing.T) {
+func TestGetAllUserUseCase_Execute_Unauthorized(t *testing.T) {
 	assert := assert.New(t)
 
-	// Create admin user for context
-	adminUser := usermodels.UserWithRole{
+	// Create non-admin user for context
+	regularUser := usermodels.UserWithRole{
 		UserBase: usermodels.UserBase{
-			Name:   "Admin User",
-			Email:  "admin@example.com",
+			Name:   "Regular User",
+			Email:  "user@example.com",
 			Phone:  "1234567890",
-			RoleID: 1,
+			RoleID: 2,
 		},
 		ID: 1,
 	}
-	adminUser.SetRole(dtomocks.AdminRole)
-	ctxWithUser := app_context.NewContextWithUser(&adminUser)
+	regularUser.SetRole(dtomocks.UserRole)
+	regularUser.SetPermissions(dtomocks.UserRolePermissions)
+	ctxWithUser := app_context.NewContextWithUser(&regularUser)
 
 	testUserRepository := new(usermocks.MockUserRepository)
-	testCacheProvider := new(providersmocks.MockCacheProvider)
 
 	// Create query payload
 	page := 1
 	pageSize := 10
 	queryPayload := domain_utils.NewQueryPayloadBuilder[usermodels.User](nil, nil, &page, &pageSize)
 
-	// Mock cache - error getting cache (should not fail, just log)
-	cacheKey := "users:" + queryPayload.GetQueryKey()
-	appErr := applicationerrors.NewApplicationError(
-		appstatus.InternalError,
-		messages.MessageKeysInstance.SOMETHING_WENT_WRONG,
-		"Cache error",
-	)
-	testCacheProvider.On("Get", cacheKey, mock.AnythingOfType("*[]models.User")).Return(false, appErr)
-
-	// Mock repository
-	testUsers := []usermodels.User{
-		{
-			UserBase: usermodels.UserBase{
-				Name:   "User 1",
-				Email:  "user1@example.com",
-				Phone:  "1234567890",
-				RoleID: 2,
-			},
-			DBBaseModel: sharedmodels.DBBaseModel{
-				ID:        1,
-				CreatedAt: time.Now(),
-				UpdatedAt: time.Now(),
-			},
-		},
-	}
-	var total int64 = 1
-	testUserRepository.On("GetAll", mock.Anything, 0, 10).Return(testUsers, total, nil)
-
-	// Mock cache set
-	testCacheProvider.On("Set", cacheKey, testUsers, mock.AnythingOfType("time.Duration")).Return(nil)
-	testCacheProvider.On("Set", cacheKey+":total", total, mock.AnythingOfType("time.Duration")).Return(nil)
-
 	uc := NewGetAllUserUseCase(
 		testUserRepository,
-		testCacheProvider,
 	)
 
 	result := uc.Execute(ctxWithUser, locales.EN_US, queryPayload)
 
-	// Should still succeed even if cache has error
 	assert.NotNil(result)
-	assert.True(result.IsSuccess())
-	assert.False(result.HasError())
-	assert.NotNil(result.Data)
+	assert.False(result.IsSuccess())
+	assert.True(result.HasError())
+	assert.Equal(appstatus.Unauthorized, result.StatusCode)
 }
 
-func TestGetAllUserUseCase_Execute_CacheSetError(t *testing.T) {
+func TestGetAllUserUseCase_Execute_Client(t *testing.T) {
 	assert := assert.New(t)
 
-	// Create admin user for context
-	adminUser := usermodels.UserWithRole{
-		UserBase: usermodels.UserBase{
-			Name:   "Admin User",
-			Email:  "admin@example.com",
-			Phone:  "1234567890",
-			RoleID: 1,
-		},
-		ID: 1,
-	}
-	adminUser.SetRole(dtomocks.AdminRole)
-	ctxWithUser := app_context.NewContextWithUser(&adminUser)
-
-	testUserRepository := new(usermocks.MockUserRepository)
-	testCacheProvider := new(providersmocks.MockCacheProvider)
-
-	// Create query payload
 	page := 1
 	pageSize := 10
 	queryPayload := domain_utils.NewQueryPayloadBuilder[usermodels.User](nil, nil, &page, &pageSize)
 
-	// Mock cache - not found
-	cacheKey := "users:" + queryPayload.GetQueryKey()
-	testCacheProvider.On("Get", cacheKey, mock.AnythingOfType("*[]models.User")).Return(false, nil)
-	testCacheProvider.On("Get", cacheKey+":total", mock.AnythingOfType("*int64")).Return(false, nil)
-
-	// Mock repository
-	testUsers := []usermodels.User{
-		{
-			UserBase: usermodels.UserBase{
-				Name:   "User 1",
-				Email:  "user1@example.com",
-				Phone:  "1234567890",
-				RoleID: 2,
-			},
-			DBBaseModel: sharedmodels.DBBaseModel{
-				ID:        1,
-				CreatedAt: time.Now(),
-				UpdatedAt: time.Now(),
-			},
-		},
-	}
-	var total int64 = 1
-	testUserRepository.On("GetAll", mock.Anything, 0, 10).Return(testUsers, total, nil)
-
-	// Mock cache set error (should not fail, just log)
-	appErr := applicationerrors.NewApplicationError(
-		appstatus.InternalError,
-		messages.MessageKeysInstance.SOMETHING_WENT_WRONG,
-		"Cache set error",
-	)
-	testCacheProvider.On("Set", cacheKey, testUsers, mock.AnythingOfType("time.Duration")).Return(appErr)
-	testCacheProvider.On("Set", cacheKey+":total", total, mock.AnythingOfType("time.Duration")).Return(appErr)
-
-	uc := NewGetAllUserUseCase(
-		testUserRepository,
-		testCacheProvider,
-	)
-
-	result := uc.Execute(ctxWithUser, locales.EN_US, queryPayload)
-
-	// Should still succeed even if cache set has error
-	assert.NotNil(result)
-	assert.True(result.IsSuccess())
-	assert.False(result.HasError())
-	assert.NotNil(result.Data)
-}
-
-func TestGetAllUserUseCase_Execute_Unauthorized(t *testing.T) {
-	assert := assert.New(t)
-
-	// Create non-admin user for context
-	regularUser := usermodels.UserWithRole{
-		UserBase: usermodels.UserBase{
-			Name:   "Regular User",
-			Email:  "user@example.com",
-			Phone:  "1234567890",
-			RoleID: 2,
-		},
-		ID: 1,
-	}
-	regularUser.SetRole(dtomocks.UserRole)
-	ctxWithUser := app_context.NewContextWithUser(&regularUser)
-
 	testUserRepository := new(usermocks.MockUserRepository)
-	testCacheProvider := new(providersmocks.MockCacheProvider)
-
-	// Create query payload
-	page := 1
-	pageSize := 10
-	queryPayload := domain_utils.NewQueryPayloadBuilder[usermodels.User](nil, nil, &page, &pageSize)
+	testUserRepository.On("GetAll", mock.Anything, 0, 10).Return([]usermodels.User{}, int64(0), nil)
+	uc := NewGetAllUserUseCase(testUserRepository)
 
-	uc := NewGetAllUserUseCase(
-		testUserRepository,
-		testCacheProvider,
-	)
-
-	result := uc.Execute(ctxWithUser, locales.EN_US, queryPayload)
+	// A client acting for itself has no permissions, the client scope grants it access
+	client := usermodels.UserWithRole{}
+	client.SetClient("client", []string{sharedmodels.OAuthScopeUsersRead})
+	result := uc.Execute(app_context.NewContextWithUser(&client), locales.EN_US, queryPayload)
+	assert.True(result.IsSuccess())
 
-	assert.NotNil(result)
-	assert.False(result.IsSuccess())
+	client.SetClient("client", []string{sharedmodels.OAuthScopeProfile})
+	result = uc.Execute(app_context.NewContextWithUser(&client), locales.EN_US, queryPayload)
 	assert.True(result.HasError())
 	assert.Equal(appstatus.Unauthorized, result.StatusCode)
+	testUserRepository.AssertNumberOfCalls(t, "GetAll", 1)
 }
 
 func TestGetAllUserUseCase_Execute_InvalidInput(t *testing.T) {
@@ -397,10 +264,10 @@ func TestGetAllUserUseCase_Execute_InvalidInput(t *testing.T) {
 		ID: 1,
 	}
 	adminUser.SetRole(dtomocks.AdminRole)
+	adminUser.SetPermissions(dtomocks.AdminRolePermissions)
 	ctxWithUser := app_context.NewContextWithUser(&adminUser)
 
 	testUserRepository := new(usermocks.MockUserRepository)
-	testCacheProvider := new(providersmocks.MockCacheProvider)
 
 	// Create invalid query payload (page = 0, which will be validated)
 	queryPayload := domain_utils.QueryPayloadBuilder[usermodels.User]{
@@ -412,7 +279,6 @@ func TestGetAllUserUseCase_Execute_InvalidInput(t *testing.T) {
 
 	uc := NewGetAllUserUseCase(
 		testUserRepository,
-		testCacheProvider,
 	)
 
 	result := uc.Execute(ctxWithUser, locales.EN_US, queryPayload)
@@ -427,11 +293,9 @@ func TestGetAllUserUseCase_SetLocale(t *testing.T) {
 	assert := assert.New(t)
 
 	testUserRepository := new(usermocks.MockUserRepository)
-	testCacheProvider := new(providersmocks.MockCacheProvider)
 
 	uc := NewGetAllUserUseCase(
 		testUserRepository,
-		testCacheProvider,
 	)
 
 	// Test setting locale
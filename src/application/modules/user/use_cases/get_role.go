@@ -0,0 +1,56 @@
+package userusecases
+
+import (
+	usercontracts "github.com/simon3640/goprojectskeleton/src/application/modules/user/contracts"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/guards"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
+
+// GetRoleUseCase is a use case that gets a role by its ID
+type GetRoleUseCase struct {
+	usecase.BaseUseCaseValidation[uint, usermodels.Role]
+	repo usercontracts.IRoleRepository
+}
+
+var _ usecase.BaseUseCase[uint, usermodels.Role] = (*GetRoleUseCase)(nil)
+
+// Execute executes the use case
+func (uc *GetRoleUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input uint,
+) *usecase.UseCaseResult[usermodels.Role] {
+	result := usecase.NewUseCaseResult[usermodels.Role]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	res, err := uc.repo.GetByID(input)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting role by ID", err.ToError(), uc.AppContext)
+		result.SetError(err.Code, uc.AppMessages.Get(uc.Locale, err.Context))
+		return result
+	}
+	result.SetData(status.Success, *res, "")
+	return result
+}
+
+// NewGetRoleUseCase creates a new get role use case
+func NewGetRoleUseCase(
+	repo usercontracts.IRoleRepository,
+) *GetRoleUseCase {
+	return &GetRoleUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[uint, usermodels.Role]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(guards.PermissionGuard(usermodels.PermissionRoleRead)),
+		},
+		repo: repo,
+	}
+}
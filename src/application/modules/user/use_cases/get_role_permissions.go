@@ -0,0 +1,76 @@
+package userusecases
+
+import (
+	contracts_repositories "github.com/simon3640/goprojectskeleton/src/application/contracts/repositories"
+	usercontracts "github.com/simon3640/goprojectskeleton/src/application/modules/user/contracts"
+	userdtos "github.com/simon3640/goprojectskeleton/src/application/modules/user/dtos"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/guards"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
+
+// GetRolePermissionsUseCase is a use case that gets the permissions granted
+// to a role
+type GetRolePermissionsUseCase struct {
+	usecase.BaseUseCaseValidation[uint, userdtos.RolePermissions]
+	repo                 usercontracts.IRoleRepository
+	permissionRepository contracts_repositories.IPermissionRepository
+}
+
+var _ usecase.BaseUseCase[uint, userdtos.RolePermissions] = (*GetRolePermissionsUseCase)(nil)
+
+// Execute executes the use case
+func (uc *GetRolePermissionsUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input uint,
+) *usecase.UseCaseResult[userdtos.RolePermissions] {
+	result := usecase.NewUseCaseResult[userdtos.RolePermissions]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	if _, err := uc.repo.GetByID(input); err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting role by ID", err.ToError(), uc.AppContext)
+		result.SetError(err.Code, uc.AppMessages.Get(uc.Locale, err.Context))
+		return result
+	}
+	permissions, err := uc.permissionRepository.GetRolePermissions(input)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting role permissions", err.ToError(), uc.AppContext)
+		result.SetError(err.Code, uc.AppMessages.Get(uc.Locale, err.Context))
+		return result
+	}
+
+	result.SetData(
+		status.Success,
+		userdtos.RolePermissions{RoleID: input, Permissions: permissions},
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.ROLE_PERMISSIONS_SUCCESS,
+		),
+	)
+	return result
+}
+
+// NewGetRolePermissionsUseCase creates a new get role permissions use case
+func NewGetRolePermissionsUseCase(
+	repo usercontracts.IRoleRepository,
+	permissionRepository contracts_repositories.IPermissionRepository,
+) *GetRolePermissionsUseCase {
+	return &GetRolePermissionsUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[uint, userdtos.RolePermissions]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(guards.PermissionGuard(usermodels.PermissionRoleRead)),
+		},
+		repo:                 repo,
+		permissionRepository: permissionRepository,
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
 	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
 	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
 )
 
@@ -64,7 +65,7 @@ func NewGetUserUseCase(
 	return &GetUserUseCase{
 		BaseUseCaseValidation: usecase.BaseUseCaseValidation[uint, usermodels.User]{
 			AppMessages: locales.NewLocale(locales.EN_US),
-			Guards:      usecase.NewGuards(guards.RoleGuard("admin", "user"), guards.UserGetItSelf),
+			Guards:      usecase.NewGuards(guards.PermissionGuard(usermodels.PermissionUserRead), guards.UserGetItSelf).WithScopes(sharedmodels.OAuthScopeProfile),
 		},
 		repo: repo,
 	}
@@ -0,0 +1,76 @@
+package userusecases
+
+import (
+	contracts_repositories "github.com/simon3640/goprojectskeleton/src/application/contracts/repositories"
+	userdtos "github.com/simon3640/goprojectskeleton/src/application/modules/user/dtos"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/guards"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
+
+// RemoveOrganizationMemberUseCase is a use case that removes a user from an
+// organization
+type RemoveOrganizationMemberUseCase struct {
+	usecase.BaseUseCaseValidation[userdtos.OrganizationMember, bool]
+	repo contracts_repositories.IOrganizationRepository
+}
+
+var _ usecase.BaseUseCase[userdtos.OrganizationMember, bool] = (*RemoveOrganizationMemberUseCase)(nil)
+
+// Execute executes the use case
+func (uc *RemoveOrganizationMemberUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input userdtos.OrganizationMember,
+) *usecase.UseCaseResult[bool] {
+	result := usecase.NewUseCaseResult[bool]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	uc.removeMember(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	result.SetData(
+		status.Success,
+		true,
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.ORGANIZATION_MEMBER_REMOVED,
+		),
+	)
+	return result
+}
+
+func (uc *RemoveOrganizationMemberUseCase) removeMember(input userdtos.OrganizationMember, result *usecase.UseCaseResult[bool]) {
+	err := uc.repo.RemoveMember(input.OrganizationID, input.UserID)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error removing organization member", err.ToError(), uc.AppContext)
+		result.SetError(err.Code, uc.AppMessages.Get(uc.Locale, err.Context))
+	}
+}
+
+// NewRemoveOrganizationMemberUseCase creates a new remove organization member use case
+func NewRemoveOrganizationMemberUseCase(
+	repo contracts_repositories.IOrganizationRepository,
+) *RemoveOrganizationMemberUseCase {
+	return &RemoveOrganizationMemberUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[userdtos.OrganizationMember, bool]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards: usecase.NewGuards(
+				guards.PermissionGuard(usermodels.PermissionOrganizationWrite),
+				guards.TenantGuard[userdtos.OrganizationMember](),
+			),
+		},
+		repo: repo,
+	}
+}
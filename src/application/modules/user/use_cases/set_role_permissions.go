@@ -0,0 +1,103 @@
+package userusecases
+
+import (
+	contractsproviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	contracts_repositories "github.com/simon3640/goprojectskeleton/src/application/contracts/repositories"
+	usercontracts "github.com/simon3640/goprojectskeleton/src/application/modules/user/contracts"
+	userdtos "github.com/simon3640/goprojectskeleton/src/application/modules/user/dtos"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/guards"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/services"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
+
+// SetRolePermissionsUseCase is a use case that replaces the permissions
+// granted to a role. The permissions of the admin role can not be changed,
+// admins could lock themselves out. The cached permissions of the role are
+// invalidated so they apply on the next request of its users.
+type SetRolePermissionsUseCase struct {
+	usecase.BaseUseCaseValidation[userdtos.RolePermissions, userdtos.RolePermissions]
+	repo                 usercontracts.IRoleRepository
+	permissionRepository contracts_repositories.IPermissionRepository
+	permissionService    *services.PermissionService
+}
+
+var _ usecase.BaseUseCase[userdtos.RolePermissions, userdtos.RolePermissions] = (*SetRolePermissionsUseCase)(nil)
+
+// Execute executes the use case
+func (uc *SetRolePermissionsUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input userdtos.RolePermissions,
+) *usecase.UseCaseResult[userdtos.RolePermissions] {
+	result := usecase.NewUseCaseResult[userdtos.RolePermissions]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	uc.checkAdminRole(input.RoleID, result)
+	if result.HasError() {
+		return result
+	}
+
+	if err := uc.permissionRepository.SetRolePermissions(input.RoleID, input.Permissions); err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error setting role permissions", err.ToError(), uc.AppContext)
+		result.SetError(err.Code, uc.AppMessages.Get(uc.Locale, err.Context))
+		return result
+	}
+	if err := uc.permissionService.InvalidateRole(input.RoleID); err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error invalidating role permissions", err.ToError(), uc.AppContext)
+	}
+
+	result.SetData(
+		status.Updated,
+		input,
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.ROLE_PERMISSIONS_UPDATED,
+		),
+	)
+	observability.GetObservabilityComponents().Logger.InfoWithContext("Role permissions updated successfully", uc.AppContext)
+	return result
+}
+
+// checkAdminRole rejects changing the permissions of the admin role
+func (uc *SetRolePermissionsUseCase) checkAdminRole(id uint, result *usecase.UseCaseResult[userdtos.RolePermissions]) {
+	role, err := uc.repo.GetByID(id)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting role by ID", err.ToError(), uc.AppContext)
+		result.SetError(err.Code, uc.AppMessages.Get(uc.Locale, err.Context))
+		return
+	}
+	if role.Key == usermodels.RoleKeyAdmin {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Attempt to change the permissions of the admin role", uc.AppContext)
+		result.SetError(
+			status.Conflict,
+			uc.AppMessages.Get(uc.Locale, messages.MessageKeysInstance.BUILT_IN_ROLE_PROTECTED),
+		)
+	}
+}
+
+// NewSetRolePermissionsUseCase creates a new set role permissions use case
+func NewSetRolePermissionsUseCase(
+	repo usercontracts.IRoleRepository,
+	permissionRepository contracts_repositories.IPermissionRepository,
+	cacheProvider contractsproviders.ICacheProvider,
+) *SetRolePermissionsUseCase {
+	return &SetRolePermissionsUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[userdtos.RolePermissions, userdtos.RolePermissions]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(guards.PermissionGuard(usermodels.PermissionRoleWrite)),
+		},
+		repo:                 repo,
+		permissionRepository: permissionRepository,
+		permissionService:    services.NewPermissionService(permissionRepository, cacheProvider),
+	}
+}
@@ -0,0 +1,103 @@
+package userusecases
+
+import (
+	"testing"
+
+	userdtos "github.com/simon3640/goprojectskeleton/src/application/modules/user/dtos"
+	usermocks "github.com/simon3640/goprojectskeleton/src/application/modules/user/mocks"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
+	repositoriesmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/repositories"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/services"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetRolePermissionsUseCase(t *testing.T) {
+	assert := assert.New(t)
+
+	actor := dtomocks.AdminUserWithRole
+	ctxWithUser := app_context.NewContextWithUser(&actor)
+
+	input := userdtos.RolePermissions{
+		RoleID:      dtomocks.UserRole.ID,
+		Permissions: []string{usermodels.PermissionUserRead},
+	}
+
+	testRoleRepository := new(usermocks.MockRoleRepository)
+	testPermissionRepository := new(repositoriesmocks.MockPermissionRepository)
+	cache := providersmocks.NewMemoryCacheProvider()
+	_ = cache.Set(services.RolePermissionsKey(input.RoleID), dtomocks.UserRolePermissions, 0)
+
+	testRoleRepository.On("GetByID", input.RoleID).Return(&dtomocks.UserRole, nil)
+	testPermissionRepository.On("SetRolePermissions", input.RoleID, input.Permissions).Return(nil)
+
+	uc := NewSetRolePermissionsUseCase(testRoleRepository, testPermissionRepository, cache)
+	result := uc.Execute(ctxWithUser, locales.EN_US, input)
+
+	assert.True(result.IsSuccess())
+	assert.Equal(input, *result.Data)
+	// The cached permissions of the role are invalidated
+	assert.Empty(cache.Keys())
+}
+
+func TestSetRolePermissionsUseCase_AdminRole(t *testing.T) {
+	assert := assert.New(t)
+
+	actor := dtomocks.AdminUserWithRole
+	ctxWithUser := app_context.NewContextWithUser(&actor)
+
+	testRoleRepository := new(usermocks.MockRoleRepository)
+	testPermissionRepository := new(repositoriesmocks.MockPermissionRepository)
+	testRoleRepository.On("GetByID", dtomocks.AdminRole.ID).Return(&dtomocks.AdminRole, nil)
+
+	uc := NewSetRolePermissionsUseCase(testRoleRepository, testPermissionRepository, nil)
+	result := uc.Execute(ctxWithUser, locales.EN_US, userdtos.RolePermissions{RoleID: dtomocks.AdminRole.ID, Permissions: []string{}})
+
+	assert.True(result.HasError())
+	assert.Equal(status.Conflict, result.StatusCode)
+	testPermissionRepository.AssertNotCalled(t, "SetRolePermissions")
+}
+
+func TestSetRolePermissionsUseCase_UnknownPermission(t *testing.T) {
+	assert := assert.New(t)
+
+	actor := dtomocks.AdminUserWithRole
+	ctxWithUser := app_context.NewContextWithUser(&actor)
+
+	input := userdtos.RolePermissions{RoleID: dtomocks.UserRole.ID, Permissions: []string{"unknown:permission"}}
+	appErr := applicationerrors.NewApplicationError(status.InvalidInput, messages.MessageKeysInstance.UNKNOWN_PERMISSION, "unknown permission")
+
+	testRoleRepository := new(usermocks.MockRoleRepository)
+	testPermissionRepository := new(repositoriesmocks.MockPermissionRepository)
+	testRoleRepository.On("GetByID", input.RoleID).Return(&dtomocks.UserRole, nil)
+	testPermissionRepository.On("SetRolePermissions", input.RoleID, input.Permissions).Return(appErr)
+
+	uc := NewSetRolePermissionsUseCase(testRoleRepository, testPermissionRepository, nil)
+	result := uc.Execute(ctxWithUser, locales.EN_US, input)
+
+	assert.True(result.HasError())
+	assert.Equal(status.InvalidInput, result.StatusCode)
+}
+
+func TestSetRolePermissionsUseCase_Unauthorized(t *testing.T) {
+	assert := assert.New(t)
+
+	actor := dtomocks.UserWithRole
+	ctxWithUser := app_context.NewContextWithUser(&actor)
+
+	testPermissionRepository := new(repositoriesmocks.MockPermissionRepository)
+
+	uc := NewSetRolePermissionsUseCase(new(usermocks.MockRoleRepository), testPermissionRepository, nil)
+	result := uc.Execute(ctxWithUser, locales.EN_US, userdtos.RolePermissions{RoleID: dtomocks.UserRole.ID, Permissions: []string{}})
+
+	assert.True(result.HasError())
+	assert.Equal(status.Unauthorized, result.StatusCode)
+	testPermissionRepository.AssertNotCalled(t, "SetRolePermissions")
+}
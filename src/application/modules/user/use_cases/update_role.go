@@ -0,0 +1,105 @@
+package userusecases
+
+import (
+	usercontracts "github.com/simon3640/goprojectskeleton/src/application/modules/user/contracts"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/guards"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
+
+// UpdateRoleUseCase is a use case that updates a role.
+// The built-in roles can not be renamed, the application relies on their keys.
+type UpdateRoleUseCase struct {
+	usecase.BaseUseCaseValidation[usermodels.RoleUpdate, usermodels.Role]
+	repo usercontracts.IRoleRepository
+}
+
+var _ usecase.BaseUseCase[usermodels.RoleUpdate, usermodels.Role] = (*UpdateRoleUseCase)(nil)
+
+// Execute executes the use case
+func (uc *UpdateRoleUseCase) Execute(ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input usermodels.RoleUpdate,
+) *usecase.UseCaseResult[usermodels.Role] {
+	result := usecase.NewUseCaseResult[usermodels.Role]()
+	uc.SetLocale(locale)
+	uc.SetAppContext(ctx)
+	uc.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	if input.Key != nil {
+		uc.checkRename(input, result)
+		if result.HasError() {
+			return result
+		}
+	}
+
+	res := uc.updateRole(input, result)
+	if result.HasError() {
+		return result
+	}
+
+	result.SetData(
+		status.Updated,
+		*res,
+		uc.AppMessages.Get(
+			uc.Locale,
+			messages.MessageKeysInstance.ROLE_UPDATE_SUCCESS,
+		),
+	)
+	observability.GetObservabilityComponents().Logger.InfoWithContext("Role updated successfully", uc.AppContext)
+	return result
+}
+
+// checkRename rejects changing the key of a built-in role
+func (uc *UpdateRoleUseCase) checkRename(input usermodels.RoleUpdate, result *usecase.UseCaseResult[usermodels.Role]) {
+	role, err := uc.repo.GetByID(input.ID)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error getting role by ID", err.ToError(), uc.AppContext)
+		result.SetError(err.Code, uc.AppMessages.Get(uc.Locale, err.Context))
+		return
+	}
+	if role.IsBuiltIn() && *input.Key != role.Key {
+		observability.GetObservabilityComponents().Logger.WarningWithContext("Attempt to rename a built-in role", uc.AppContext)
+		result.SetError(
+			status.Conflict,
+			uc.AppMessages.Get(uc.Locale, messages.MessageKeysInstance.BUILT_IN_ROLE_PROTECTED),
+		)
+	}
+}
+
+func (uc *UpdateRoleUseCase) updateRole(input usermodels.RoleUpdate, result *usecase.UseCaseResult[usermodels.Role]) *usermodels.Role {
+	res, err := uc.repo.Update(input.ID, input)
+	if err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error updating role", err.ToError(), uc.AppContext)
+		result.SetError(err.Code, uc.AppMessages.Get(uc.Locale, err.Context))
+		return nil
+	}
+	if res == nil {
+		result.SetError(
+			status.NotFound,
+			uc.AppMessages.Get(uc.Locale, messages.MessageKeysInstance.RESOURCE_NOT_FOUND),
+		)
+	}
+	return res
+}
+
+// NewUpdateRoleUseCase creates a new update role use case
+func NewUpdateRoleUseCase(
+	repo usercontracts.IRoleRepository,
+) *UpdateRoleUseCase {
+	return &UpdateRoleUseCase{
+		BaseUseCaseValidation: usecase.BaseUseCaseValidation[usermodels.RoleUpdate, usermodels.Role]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      usecase.NewGuards(guards.PermissionGuard(usermodels.PermissionRoleWrite)),
+		},
+		repo: repo,
+	}
+}
@@ -0,0 +1,55 @@
+package userusecases
+
+import (
+	"testing"
+
+	usermocks "github.com/simon3640/goprojectskeleton/src/application/modules/user/mocks"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateRoleUseCase(t *testing.T) {
+	assert := assert.New(t)
+
+	actor := dtomocks.AdminUserWithRole
+	ctxWithUser := app_context.NewContextWithUser(&actor)
+
+	priority := 1
+	input := usermodels.RoleUpdate{RoleUpdateBase: usermodels.RoleUpdateBase{Priority: &priority}, ID: dtomocks.UserRole.ID}
+	updated := dtomocks.UserRole
+	updated.Priority = priority
+
+	testRoleRepository := new(usermocks.MockRoleRepository)
+	testRoleRepository.On("Update", input.ID, input).Return(&updated, nil)
+
+	uc := NewUpdateRoleUseCase(testRoleRepository)
+	result := uc.Execute(ctxWithUser, locales.EN_US, input)
+
+	assert.True(result.IsSuccess())
+	assert.Equal(priority, result.Data.Priority)
+}
+
+func TestUpdateRoleUseCase_RenameBuiltInRole(t *testing.T) {
+	assert := assert.New(t)
+
+	actor := dtomocks.AdminUserWithRole
+	ctxWithUser := app_context.NewContextWithUser(&actor)
+
+	key := "member"
+	input := usermodels.RoleUpdate{RoleUpdateBase: usermodels.RoleUpdateBase{Key: &key}, ID: dtomocks.UserRole.ID}
+
+	testRoleRepository := new(usermocks.MockRoleRepository)
+	testRoleRepository.On("GetByID", dtomocks.UserRole.ID).Return(&dtomocks.UserRole, nil)
+
+	uc := NewUpdateRoleUseCase(testRoleRepository)
+	result := uc.Execute(ctxWithUser, locales.EN_US, input)
+
+	assert.True(result.HasError())
+	assert.Equal(status.Conflict, result.StatusCode)
+	testRoleRepository.AssertNotCalled(t, "Update")
+}
@@ -1,22 +1,31 @@
 package userusecases
 
 import (
+	contractsproviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
 	usercontracts "github.com/simon3640/goprojectskeleton/src/application/modules/user/contracts"
 	userdtos "github.com/simon3640/goprojectskeleton/src/application/modules/user/dtos"
 	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/guards"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/policy"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/services"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
 	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
 	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
 )
 
-// UpdateUserUseCase is a use case that updates a user
+// UpdateUserUseCase is a use case that updates a user.
+// Users update themselves unless the policy grants the update of another user.
+// Only the users allowed to assign roles change the role of a user.
+// Leaving the active status revokes every token issued to the user.
 type UpdateUserUseCase struct {
 	usecase.BaseUseCaseValidation[userdtos.UserUpdate, usermodels.User]
-	repo usercontracts.IUserRepository
+	repo               usercontracts.IUserRepository
+	revocationProvider contractsproviders.ITokenRevocationProvider
 }
 
 var _ usecase.BaseUseCase[userdtos.UserUpdate, usermodels.User] = (*UpdateUserUseCase)(nil)
@@ -38,6 +47,9 @@ func (uc *UpdateUserUseCase) Execute(ctx *app_context.AppContext,
 	if result.HasError() {
 		return result
 	}
+	if input.Status != nil && *input.Status != usermodels.UserStatusActive {
+		services.RevokeUserTokensService(uc.AppContext, input.ID, uc.revocationProvider)
+	}
 	observability.GetObservabilityComponents().Logger.InfoWithContext("User updated successfully", uc.AppContext)
 	return result
 }
@@ -59,12 +71,26 @@ func (uc *UpdateUserUseCase) updateUser(input userdtos.UserUpdate, result *useca
 // NewUpdateUserUseCase creates a new update user use case
 func NewUpdateUserUseCase(
 	repo usercontracts.IUserRepository,
+	revocationProvider contractsproviders.ITokenRevocationProvider,
 ) *UpdateUserUseCase {
 	return &UpdateUserUseCase{
 		BaseUseCaseValidation: usecase.BaseUseCaseValidation[userdtos.UserUpdate, usermodels.User]{
 			AppMessages: locales.NewLocale(locales.EN_US),
-			Guards:      usecase.NewGuards(guards.RoleGuard("admin", "user"), guards.UserResourceGuard[userdtos.UserUpdate]()),
+			Guards: usecase.NewGuards(
+				guards.PermissionGuard(usermodels.PermissionUserUpdate),
+				guards.AnyGuard(
+					guards.UserResourceGuard[userdtos.UserUpdate](),
+					guards.PolicyGuard(usermodels.PermissionUserUpdate, policy.Loader(
+						func(input userdtos.UserUpdate) (*usermodels.User, *application_errors.ApplicationError) {
+							return repo.GetByID(input.ID)
+						},
+					)),
+				),
+				guards.RoleAssignmentGuard[userdtos.UserUpdate](),
+				guards.NotImpersonatedGuard,
+			).WithScopes(sharedmodels.OAuthScopeProfileWrite),
 		},
-		repo: repo,
+		repo:               repo,
+		revocationProvider: revocationProvider,
 	}
 }
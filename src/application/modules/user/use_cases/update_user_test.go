@@ -9,6 +9,8 @@ import (
 	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
 	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/policy"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
 	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
 	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
@@ -42,13 +44,16 @@ func TestUpdateUserUseCase(t *testing.T) {
 		},
 	}, nil)
 
-	uc := NewUpdateUserUseCase(testUserRepository)
+	testRevocationProvider := new(providersmocks.MockTokenRevocationProvider)
+
+	uc := NewUpdateUserUseCase(testUserRepository, testRevocationProvider)
 
 	result := uc.Execute(ctxWithUser, locales.EN_US, testUser)
 
 	assert.NotNil(result)
 	assert.Equal(result.Data.ID == 1, true)
 	assert.Equal(result.Data.Name == "Update", true)
+	testRevocationProvider.AssertNotCalled(t, "RevokeUserTokens", actor.ID)
 
 }
 
@@ -67,10 +72,127 @@ func TestUpdateUserUseCase_DifferentUser(t *testing.T) {
 
 	testUserRepository.On("Update", testUser.ID, testUser).Return(nil)
 
-	uc := NewUpdateUserUseCase(testUserRepository)
+	uc := NewUpdateUserUseCase(testUserRepository, nil)
 
 	result := uc.Execute(ctxWithUser, locales.EN_US, testUser)
 
 	assert.NotNil(result)
 	assert.Equal(result.StatusCode, status.Unauthorized)
 }
+
+func TestUpdateUserUseCase_SuspendRevokesTokens(t *testing.T) {
+	assert := assert.New(t)
+
+	actor := dtomocks.UserWithRole
+	ctxWithUser := app_context.NewContextWithUser(&actor)
+
+	testUserRepository := new(usermocks.MockUserRepository)
+	testRevocationProvider := new(providersmocks.MockTokenRevocationProvider)
+	userStatus := usermodels.UserStatusSuspended
+	testUser := userdtos.UserUpdate{
+		UserUpdateBase: usermodels.UserUpdateBase{Status: &userStatus},
+		ID:             actor.ID,
+	}
+	testUserRepository.On("Update", testUser.ID, testUser).Return(&usermodels.User{
+		UserBase:    usermodels.UserBase{Name: "Suspended", Status: &userStatus},
+		DBBaseModel: sharedmodels.DBBaseModel{ID: actor.ID},
+	}, nil)
+	testRevocationProvider.On("RevokeUserTokens", actor.ID).Return(nil)
+
+	uc := NewUpdateUserUseCase(testUserRepository, testRevocationProvider)
+
+	result := uc.Execute(ctxWithUser, locales.EN_US, testUser)
+
+	assert.True(result.IsSuccess())
+	testRevocationProvider.AssertCalled(t, "RevokeUserTokens", actor.ID)
+}
+
+func TestUpdateUserUseCase_PolicyGrantsOtherUser(t *testing.T) {
+	assert := assert.New(t)
+
+	rules, err := policy.ParsePolicy([]byte(`{"rules": [{
+		"id": "users-update-pending",
+		"effect": "allow",
+		"actions": ["user:update"],
+		"conditions": [{"attribute": "resource.status", "operator": "eq", "value": "pending"}]
+	}]}`))
+	assert.NoError(err)
+	policy.InitializeEngine(*rules, true)
+	t.Cleanup(policy.ResetEngineSingleton)
+
+	actor := dtomocks.UserWithRole
+	ctxWithUser := app_context.NewContextWithUser(&actor)
+
+	testUserRepository := new(usermocks.MockUserRepository)
+	name := "Update"
+	pending := usermodels.UserStatusPending
+	active := usermodels.UserStatusActive
+	pendingUser := userdtos.UserUpdate{UserUpdateBase: usermodels.UserUpdateBase{Name: &name}, ID: actor.ID + 1}
+	activeUser := userdtos.UserUpdate{UserUpdateBase: usermodels.UserUpdateBase{Name: &name}, ID: actor.ID + 2}
+	testUserRepository.On("GetByID", pendingUser.ID).Return(&usermodels.User{
+		UserBase:    usermodels.UserBase{Status: &pending},
+		DBBaseModel: sharedmodels.DBBaseModel{ID: pendingUser.ID},
+	}, nil)
+	testUserRepository.On("GetByID", activeUser.ID).Return(&usermodels.User{
+		UserBase:    usermodels.UserBase{Status: &active},
+		DBBaseModel: sharedmodels.DBBaseModel{ID: activeUser.ID},
+	}, nil)
+	testUserRepository.On("Update", pendingUser.ID, pendingUser).Return(&usermodels.User{
+		UserBase:    usermodels.UserBase{Name: name, Status: &pending},
+		DBBaseModel: sharedmodels.DBBaseModel{ID: pendingUser.ID},
+	}, nil)
+
+	uc := NewUpdateUserUseCase(testUserRepository, nil)
+
+	result := uc.Execute(ctxWithUser, locales.EN_US, pendingUser)
+	assert.True(result.IsSuccess())
+
+	result = uc.Execute(ctxWithUser, locales.EN_US, activeUser)
+	assert.Equal(status.Unauthorized, result.StatusCode)
+	testUserRepository.AssertNotCalled(t, "Update", activeUser.ID, activeUser)
+}
+
+func TestUpdateUserUseCase_OwnRole(t *testing.T) {
+	assert := assert.New(t)
+
+	actor := dtomocks.UserWithRole
+	ctxWithUser := app_context.NewContextWithUser(&actor)
+
+	testUserRepository := new(usermocks.MockUserRepository)
+	roleID := uint(5)
+	testUser := userdtos.UserUpdate{
+		UserUpdateBase: usermodels.UserUpdateBase{RoleID: &roleID},
+		ID:             actor.ID,
+	}
+
+	uc := NewUpdateUserUseCase(testUserRepository, nil)
+
+	result := uc.Execute(ctxWithUser, locales.EN_US, testUser)
+
+	assert.True(result.HasError())
+	assert.Equal(status.Unauthorized, result.StatusCode)
+	testUserRepository.AssertNotCalled(t, "Update", testUser.ID, testUser)
+}
+
+func TestUpdateUserUseCase_Impersonated(t *testing.T) {
+	assert := assert.New(t)
+
+	actor := dtomocks.UserWithRole
+	actor.SetImpersonator(dtomocks.AdminUserWithRole.ID, "impersonation-token-id")
+	ctxWithUser := app_context.NewContextWithUser(&actor)
+
+	testUserRepository := new(usermocks.MockUserRepository)
+	email := "admin@example.com"
+	testUser := userdtos.UserUpdate{
+		UserUpdateBase: usermodels.UserUpdateBase{Email: &email},
+		ID:             actor.ID,
+	}
+
+	uc := NewUpdateUserUseCase(testUserRepository, nil)
+
+	result := uc.Execute(ctxWithUser, locales.EN_US, testUser)
+
+	assert.True(result.HasError())
+	assert.Equal(status.Unauthorized, result.StatusCode)
+	testUserRepository.AssertNotCalled(t, "Update", testUser.ID, testUser)
+}
@@ -55,6 +55,11 @@ type MultipleResponse[D any] struct {
 	Meta    MetaMultiResponse `json:"meta"`
 }
 
+// MarkCached flags the response as served from the cache
+func (mr *MultipleResponse[D]) MarkCached() {
+	mr.Meta.Cached = true
+}
+
 type MetaSingleResponse struct {
 	Timestamp string `json:"timestamp"`
 	Version   string `json:"version"`
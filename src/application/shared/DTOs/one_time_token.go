@@ -19,6 +19,8 @@ func PurposeTokenToDuration(purpose sharedmodels.OneTimeTokenPurpose) time.Durat
 		return time.Duration(settings.AppSettingsInstance.OneTimeTokenPasswordTTL) * time.Minute
 	case sharedmodels.OneTimeTokenPurposeEmailVerify:
 		return time.Duration(settings.AppSettingsInstance.OneTimeTokenEmailVerifyTTL) * time.Minute
+	case sharedmodels.OneTimeTokenPurposeAccountUnlock:
+		return time.Duration(settings.AppSettingsInstance.LoginAttemptsWindowMinutes) * time.Minute
 	default:
 		return time.Duration(settings.AppSettingsInstance.OneTimeTokenEmailVerifyTTL) * time.Minute
 	}
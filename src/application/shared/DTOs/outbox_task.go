@@ -0,0 +1,37 @@
+package dtos
+
+import (
+	"time"
+
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+type OutboxTaskCreate struct {
+	sharedmodels.OutboxTaskBase
+}
+
+// NewOutboxTaskCreate creates a new pending outbox task create DTO.
+// The task is not dispatched before notBefore.
+func NewOutboxTaskCreate(taskID string, name string, payload []byte, locale string, maxAttempts int, notBefore time.Time) *OutboxTaskCreate {
+	return &OutboxTaskCreate{
+		OutboxTaskBase: sharedmodels.OutboxTaskBase{
+			TaskID:        taskID,
+			Name:          name,
+			Payload:       payload,
+			Locale:        locale,
+			Status:        sharedmodels.OutboxTaskStatusPending,
+			Attempts:      0,
+			MaxAttempts:   maxAttempts,
+			NextAttemptAt: notBefore,
+		},
+	}
+}
+
+type OutboxTaskUpdate struct {
+	ID            uint                          `json:"id"`
+	Status        sharedmodels.OutboxTaskStatus `json:"status,omitempty"`
+	Attempts      int                           `json:"attempts,omitempty"`
+	NextAttemptAt time.Time                     `json:"nextAttemptAt,omitempty"`
+	LastError     string                        `json:"lastError,omitempty"`
+	Result        string                        `json:"result,omitempty"`
+}
@@ -16,8 +16,10 @@ type AppContext struct {
 	context.Context
 	User         *models.UserWithRole
 	OneTimeToken *dtos.OneTimeTokenUser
+	ClientIP     string
 	trace        *Trace
 	traceCtx     contractsobservability.TraceContext
+	transaction  any
 }
 
 // NewContextWithUser creates a new AppContext with a user
@@ -40,6 +42,30 @@ func (a *AppContext) AddUserToContext(user *models.UserWithRole) {
 	a.User = user
 }
 
+// TenantID returns the organization the request is scoped to, that of the
+// authenticated user, and whether it is scoped
+func (a *AppContext) TenantID() (uint, bool) {
+	if a == nil || a.User == nil {
+		return 0, false
+	}
+	return a.User.GetTenantID()
+}
+
+// Impersonator returns the admin acting as the authenticated user, and
+// whether the request is an impersonation. The user of the context is always
+// the impersonated one.
+func (a *AppContext) Impersonator() (uint, bool) {
+	if a == nil || a.User == nil {
+		return 0, false
+	}
+	return a.User.GetImpersonatorID()
+}
+
+// AddClientIPToContext adds the IP address of the client to the AppContext
+func (a *AppContext) AddClientIPToContext(ip string) {
+	a.ClientIP = ip
+}
+
 // AddOneTimeTokenToContext adds a one-time token to the AppContext
 func (a *AppContext) AddOneTimeTokenToContext(oneTimeToken dtos.OneTimeTokenUser) {
 	a.OneTimeToken = &oneTimeToken
@@ -71,3 +97,27 @@ func (a *AppContext) WithTraceContext(tc contractsobservability.TraceContext) in
 func (a *AppContext) HasTrace() bool {
 	return a.traceCtx != nil && a.traceCtx.IsValid()
 }
+
+// SetTransaction sets the transaction the repositories bound to the
+// AppContext run their queries in. The unit of work sets it while its
+// function runs, nil ends it.
+func (a *AppContext) SetTransaction(tx any) {
+	a.transaction = tx
+}
+
+// Transaction returns the transaction of the unit of work the AppContext
+// runs in, or nil
+func (a *AppContext) Transaction() any {
+	if a == nil {
+		return nil
+	}
+	return a.transaction
+}
+
+// Clone returns a shallow copy of the AppContext.
+// Concurrent branches use it so each one can carry its own TraceContext
+// without racing on the parent AppContext.
+func (a *AppContext) Clone() *AppContext {
+	clone := *a
+	return &clone
+}
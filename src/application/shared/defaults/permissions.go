@@ -0,0 +1,65 @@
+package defaults
+
+import (
+	"slices"
+
+	"github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
+
+// DefaultPermissions is the default permissions
+var DefaultPermissions = []models.PermissionCreate{
+	{PermissionBase: models.PermissionBase{Key: models.PermissionUserRead, Description: "Get users"}},
+	{PermissionBase: models.PermissionBase{Key: models.PermissionUserList, Description: "List every user"}},
+	{PermissionBase: models.PermissionBase{Key: models.PermissionUserUpdate, Description: "Update users"}},
+	{PermissionBase: models.PermissionBase{Key: models.PermissionUserDelete, Description: "Delete users"}},
+	{PermissionBase: models.PermissionBase{Key: models.PermissionPasswordCreate, Description: "Create passwords"}},
+	{PermissionBase: models.PermissionBase{Key: models.PermissionAPIKeyRevoke, Description: "Revoke own API keys"}},
+	{PermissionBase: models.PermissionBase{Key: models.PermissionAPIKeyRevokeAny, Description: "Revoke the API keys of any user"}},
+	{PermissionBase: models.PermissionBase{Key: models.PermissionOAuthClientCreate, Description: "Register OAuth clients"}},
+	{PermissionBase: models.PermissionBase{Key: models.PermissionStatusPipes, Description: "Get the status of the pipes"}},
+	{PermissionBase: models.PermissionBase{Key: models.PermissionRoleRead, Description: "Get roles and their permissions"}},
+	{PermissionBase: models.PermissionBase{Key: models.PermissionRoleWrite, Description: "Create, update and delete roles and set their permissions"}},
+	{PermissionBase: models.PermissionBase{Key: models.PermissionOrganizationRead, Description: "Get organizations"}},
+	{PermissionBase: models.PermissionBase{Key: models.PermissionOrganizationWrite, Description: "Create organizations and manage their members"}},
+	{PermissionBase: models.PermissionBase{Key: models.PermissionTenantCross, Description: "Act in every organization, as a super-admin"}},
+	{PermissionBase: models.PermissionBase{Key: models.PermissionUserImpersonate, Description: "Act as another user to reproduce their issues"}},
+	{PermissionBase: models.PermissionBase{Key: models.PermissionUserAssignRole, Description: "Set the role of users"}},
+}
+
+// UserRolePermissions is the permissions of the user role, the guards also
+// restrict them to the resources of the user
+var UserRolePermissions = []string{
+	models.PermissionUserRead,
+	models.PermissionUserUpdate,
+	models.PermissionUserDelete,
+	models.PermissionPasswordCreate,
+	models.PermissionAPIKeyRevoke,
+}
+
+// DefaultRolePermissions grants every default permission to the admin role
+// and the user role permissions to the user role. The IDs are those the
+// default roles and permissions get when they are created in order.
+var DefaultRolePermissions = slices.Concat(
+	rolePermissions(models.AdminRoleID, permissionKeys(DefaultPermissions)...),
+	rolePermissions(models.UserRoleID, UserRolePermissions...),
+)
+
+func permissionKeys(permissions []models.PermissionCreate) []string {
+	keys := make([]string, 0, len(permissions))
+	for _, permission := range permissions {
+		keys = append(keys, permission.Key)
+	}
+	return keys
+}
+
+func rolePermissions(roleID uint, keys ...string) []models.RolePermission {
+	defaultKeys := permissionKeys(DefaultPermissions)
+	grants := make([]models.RolePermission, 0, len(keys))
+	for _, key := range keys {
+		grants = append(grants, models.RolePermission{
+			RoleID:       roleID,
+			PermissionID: uint(slices.Index(defaultKeys, key) + 1),
+		})
+	}
+	return grants
+}
@@ -6,7 +6,7 @@ import "github.com/simon3640/goprojectskeleton/src/domain/user/models"
 
 var AdminRole = models.RoleCreate{
 	RoleBase: models.RoleBase{
-		Key:      "admin",
+		Key:      models.RoleKeyAdmin,
 		IsActive: true,
 		Priority: 0,
 	},
@@ -14,7 +14,7 @@ var AdminRole = models.RoleCreate{
 
 var UserRole = models.RoleCreate{
 	RoleBase: models.RoleBase{
-		Key:      "user",
+		Key:      models.RoleKeyUser,
 		IsActive: true,
 		Priority: 5,
 	},
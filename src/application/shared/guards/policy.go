@@ -0,0 +1,49 @@
+package guards
+
+import (
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/policy"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
+
+// PolicyGuard checks the action against the policy engine, with the resource
+// loaded from the input. The loader may be nil for actions without resource.
+// Without a policy engine, or when the resource can not be loaded, the access
+// is refused.
+func PolicyGuard(action string, loader policy.ResourceLoader) usecase.Guard {
+	return func(user usermodels.UserWithRole, input any) *messages.MessageKeysEnum {
+		engine := policy.GetEngine()
+		if engine == nil {
+			return &messages.MessageKeysInstance.UNAUTHORIZED_RESOURCE
+		}
+		var resource any
+		if loader != nil {
+			loaded, err := loader(input)
+			if err != nil {
+				observability.GetObservabilityComponents().Logger.Error("Error loading the resource of the policy", err.ToError())
+				return &messages.MessageKeysInstance.UNAUTHORIZED_RESOURCE
+			}
+			resource = loaded
+		}
+		if !engine.Authorize(action, user, resource).Allowed() {
+			return &messages.MessageKeysInstance.UNAUTHORIZED_RESOURCE
+		}
+		return nil
+	}
+}
+
+// AnyGuard grants the access when one of the guards does, it returns the
+// error of the last guard otherwise
+func AnyGuard(guards ...usecase.Guard) usecase.Guard {
+	return func(user usermodels.UserWithRole, input any) *messages.MessageKeysEnum {
+		err := &messages.MessageKeysInstance.UNAUTHORIZED_RESOURCE
+		for _, guard := range guards {
+			if err = guard(user, input); err == nil {
+				return nil
+			}
+		}
+		return err
+	}
+}
@@ -0,0 +1,41 @@
+package guards
+
+import (
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
+
+// TenantGuard checks that the resource belongs to the organization the user
+// acts in. Super-admins cross tenants.
+func TenantGuard[T sharedmodels.HasOrganizationID]() usecase.Guard {
+	return func(user usermodels.UserWithRole, input any) *messages.MessageKeysEnum {
+		resource, ok := input.(T)
+		if !ok {
+			return &messages.MessageKeysInstance.UNAUTHORIZED_RESOURCE
+		}
+		if user.HasPermission(usermodels.PermissionTenantCross) {
+			return nil
+		}
+		if tenantID, scoped := user.GetTenantID(); !scoped || tenantID != resource.GetOrganizationID() {
+			return &messages.MessageKeysInstance.CROSS_TENANT_ACCESS
+		}
+		return nil
+	}
+}
+
+// TenantMemberGuard checks that the user may act in the organization of the
+// input, as a member or as a super-admin
+func TenantMemberGuard[T sharedmodels.HasOrganizationID]() usecase.Guard {
+	return func(user usermodels.UserWithRole, input any) *messages.MessageKeysEnum {
+		resource, ok := input.(T)
+		if !ok {
+			return &messages.MessageKeysInstance.UNAUTHORIZED_RESOURCE
+		}
+		if !user.CanAccessTenant(resource.GetOrganizationID()) {
+			return &messages.MessageKeysInstance.NOT_ORGANIZATION_MEMBER
+		}
+		return nil
+	}
+}
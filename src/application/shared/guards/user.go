@@ -18,6 +18,18 @@ func RoleGuard(allowedRoles ...string) usecase.Guard {
 	}
 }
 
+// PermissionGuard checks if the user has at least one of the given permissions
+func PermissionGuard(permissions ...string) usecase.Guard {
+	return func(user usermodels.UserWithRole, _ any) *messages.MessageKeysEnum {
+		for _, permission := range permissions {
+			if user.HasPermission(permission) {
+				return nil
+			}
+		}
+		return &messages.MessageKeysInstance.UNAUTHORIZED_RESOURCE
+	}
+}
+
 // Partial Resource with UserID
 func UserResourceGuard[T sharedmodels.HasUserID]() usecase.Guard {
 	return func(user usermodels.UserWithRole, input any) *messages.MessageKeysEnum {
@@ -32,6 +44,31 @@ func UserResourceGuard[T sharedmodels.HasUserID]() usecase.Guard {
 	}
 }
 
+// RoleAssignmentGuard checks that only the users allowed to assign roles set
+// the role of a user, users cannot grant themselves the permissions of
+// another role
+func RoleAssignmentGuard[T sharedmodels.HasRoleAssignment]() usecase.Guard {
+	return func(user usermodels.UserWithRole, input any) *messages.MessageKeysEnum {
+		resource, ok := input.(T)
+		if !ok {
+			return &messages.MessageKeysInstance.UNAUTHORIZED_RESOURCE
+		}
+		if resource.AssignsRole() && !user.HasPermission(usermodels.PermissionUserAssignRole) {
+			return &messages.MessageKeysInstance.UNAUTHORIZED_RESOURCE
+		}
+		return nil
+	}
+}
+
+// NotImpersonatedGuard refuses the dangerous actions to the admins acting
+// as another user, they must be done by the user themselves
+func NotImpersonatedGuard(user usermodels.UserWithRole, _ any) *messages.MessageKeysEnum {
+	if user.IsImpersonated() {
+		return &messages.MessageKeysInstance.IMPERSONATION_FORBIDDEN
+	}
+	return nil
+}
+
 // UserGetItSelf checks if the user is trying to get their own data
 func UserGetItSelf(user usermodels.UserWithRole, input any) *messages.MessageKeysEnum {
 	id, ok := input.(uint)
@@ -24,6 +24,7 @@ var EnMessages = map[MessageKeysEnum]string{
 	"USER_DELETE_SUCCESS":            "User deleted successfully.",
 	"INVALID_USER_ID":                "Invalid user ID.",
 	"INVALID_USER_ACTIVATION_TOKEN":  "Invalid user activation token.",
+	"USER_NOT_ACTIVE":                "User is not active.",
 	"WELCOME_EMAIL_RESENT":           "Welcome email has been resent successfully.",
 	"USER_ALREADY_VERIFIED":          "User is already verified.",
 
@@ -37,14 +38,83 @@ var EnMessages = map[MessageKeysEnum]string{
 	"INVALID_PASSWORD_RESET_TOKEN":           "Invalid password reset token.",
 	"RESET_PASSWORD_TOKEN_VALID":             "Password reset token is valid.",
 
-	"AUTHORIZATION_HEADER_MISSING": "Authorization header is missing.",
-	"AUTHORIZATION_HEADER_INVALID": "Authorization header is invalid.",
-	"AUTHORIZATION_TOKEN_EXPIRED":  "Authorization token has expired.",
-	"AUTHORIZATION_GENERATED":      "Authorization token generated successfully.",
-	"INVALID_JWT_TOKEN":            "Invalid JWT token.",
-	"OTP_LOGIN_ENABLED":            "OTP login is enabled for this user. An OTP code has been sent to your email or phone.",
-	"INVALID_OTP":                  "The provided OTP code is invalid. Is used, expired or incorrect.",
-	"LOGIN_MAX_ATTEMPTS_EXCEEDED":  "Too many failed login attempts. Please try again later.",
+	"AUTHORIZATION_HEADER_MISSING":           "Authorization header is missing.",
+	"AUTHORIZATION_HEADER_INVALID":           "Authorization header is invalid.",
+	"AUTHORIZATION_TOKEN_EXPIRED":            "Authorization token has expired.",
+	"AUTHORIZATION_TOKEN_REVOKED":            "Authorization token has been revoked.",
+	"JWKS_RETRIEVED":                         "Token signing public keys retrieved successfully.",
+	"AUTHORIZATION_GENERATED":                "Authorization token generated successfully.",
+	"INVALID_JWT_TOKEN":                      "Invalid JWT token.",
+	"OTP_LOGIN_ENABLED":                      "OTP login is enabled for this user. An OTP code has been sent to your email or phone.",
+	"INVALID_OTP":                            "The provided OTP code is invalid. Is used, expired or incorrect.",
+	"OTP_MAX_ATTEMPTS_EXCEEDED":              "Too many invalid OTP codes. Log in again to receive a new one.",
+	"MFA_CODE_REQUIRED":                      "An authenticator app code is required.",
+	"INVALID_MFA_CODE":                       "The authenticator app or recovery code is invalid.",
+	"MFA_ENROLLMENT_STARTED":                 "Scan the secret with your authenticator app and confirm it with a code.",
+	"MFA_ENABLED":                            "Multi-factor authentication enabled. Store the recovery codes in a safe place.",
+	"MFA_ALREADY_ENABLED":                    "Multi-factor authentication is already enabled.",
+	"MFA_NOT_ENROLLED":                       "There is no authenticator app enrollment to confirm.",
+	"INVALID_WEBAUTHN_CREDENTIAL":            "The passkey could not be verified.",
+	"WEBAUTHN_SESSION_EXPIRED":               "The passkey request expired or was already used. Start it again.",
+	"WEBAUTHN_CREDENTIAL_REGISTERED":         "Passkey registered successfully.",
+	"WEBAUTHN_CREDENTIAL_ALREADY_REGISTERED": "This passkey is already registered.",
+	"WEBAUTHN_CHALLENGE_CREATED":             "Passkey challenge created.",
+	"OIDC_PROVIDER_NOT_FOUND":                "The identity provider is not configured.",
+	"OIDC_INVALID_STATE":                     "The sign in request expired or was already used. Start it again.",
+	"OIDC_INVALID_TOKEN":                     "The identity provider response could not be verified.",
+	"OIDC_EMAIL_NOT_VERIFIED":                "The identity provider did not verify your email.",
+	"OIDC_ACCOUNT_EXISTS":                    "An account with this email already exists. Sign in and link the identity provider from your account.",
+	"OIDC_IDENTITY_ALREADY_LINKED":           "This identity provider account is already linked to another user.",
+	"OIDC_IDENTITY_LINKED":                   "Identity provider linked successfully.",
+	"OIDC_AUTHORIZATION_CREATED":             "Identity provider authorization created.",
+	"INSUFFICIENT_SCOPE":                     "The token was not granted the scope required for this resource.",
+	"OAUTH_INVALID_CLIENT":                   "The client is unknown or its authentication failed.",
+	"OAUTH_INVALID_REDIRECT_URI":             "The redirect URI is not registered for the client.",
+	"OAUTH_INVALID_SCOPE":                    "The requested scope is invalid or not allowed for the client.",
+	"OAUTH_UNAUTHORIZED_GRANT_TYPE":          "The client is not allowed to use this grant type.",
+	"OAUTH_INVALID_GRANT":                    "The authorization grant is invalid, expired or was already used.",
+	"OAUTH_CONSENT_REQUIRED":                 "The application requests access to your account.",
+	"OAUTH_CLIENT_REGISTERED":                "OAuth client registered successfully. Store the secret, it is not shown again.",
+	"OAUTH_AUTHORIZATION_GRANTED":            "Authorization granted.",
+	"OAUTH_TOKEN_ISSUED":                     "Token issued successfully.",
+	"OAUTH_TOKEN_INTROSPECTED":               "Token introspected successfully.",
+	"OAUTH_TOKEN_REVOKED":                    "Token revoked successfully.",
+	"INVALID_API_KEY":                        "The API key is invalid, expired or revoked.",
+	"API_KEY_CREATED":                        "API key created successfully. Store the key, it will not be shown again.",
+	"API_KEY_LIST_SUCCESS":                   "API keys retrieved successfully.",
+	"API_KEY_REVOKED":                        "API key revoked successfully.",
+	"LOGIN_MAX_ATTEMPTS_EXCEEDED":            "Too many failed login attempts. Please try again later.",
+	"LOGIN_DELAY_REQUIRED":                   "Too many failed login attempts. Please wait a few seconds before trying again.",
+	"LOGIN_ACCOUNT_LOCKED":                   "The account is temporarily locked after too many failed login attempts. Check your email to unlock it.",
+	"ACCOUNT_UNLOCKED":                       "Account unlocked successfully.",
+	"INVALID_ACCOUNT_UNLOCK_TOKEN":           "Invalid or expired account unlock token.",
+	"LOGOUT_SUCCESS":                         "Session closed successfully.",
+	"LOGOUT_ALL_SUCCESS":                     "All sessions closed successfully.",
+	"ROLE_WAS_CREATED":                       "Role was created.",
+	"ROLE_LIST_SUCCESS":                      "Role list retrieved successfully.",
+	"ROLE_UPDATE_SUCCESS":                    "Role updated successfully.",
+	"ROLE_DELETE_SUCCESS":                    "Role deleted successfully.",
+	"ROLE_PERMISSIONS_SUCCESS":               "Role permissions retrieved successfully.",
+	"ROLE_PERMISSIONS_UPDATED":               "Role permissions updated successfully.",
+	"BUILT_IN_ROLE_PROTECTED":                "The built-in roles can not be deleted nor renamed, and the permissions of the admin role can not be changed.",
+	"ROLE_HAS_USERS":                         "The role can not be deleted while users hold it.",
+	"UNKNOWN_PERMISSION":                     "Unknown permission.",
+	"ORGANIZATION_WAS_CREATED":               "Organization created successfully.",
+	"ORGANIZATION_LIST_SUCCESS":              "Organizations retrieved successfully.",
+	"ORGANIZATION_MEMBER_ADDED":              "Member added to the organization successfully.",
+	"ORGANIZATION_MEMBER_REMOVED":            "Member removed from the organization successfully.",
+	"TENANT_SWITCHED":                        "Organization switched successfully.",
+	"CROSS_TENANT_ACCESS":                    "The resource belongs to another organization.",
+	"NOT_ORGANIZATION_MEMBER":                "The user is not a member of the organization.",
+	"ORGANIZATION_INACTIVE":                  "The organization is not active.",
+	"IMPERSONATION_STARTED":                  "Impersonation started successfully.",
+	"IMPERSONATION_ENDED":                    "Impersonation ended successfully.",
+	"IMPERSONATION_FORBIDDEN":                "This action is not allowed while impersonating a user.",
+	"CANNOT_IMPERSONATE":                     "The user cannot be impersonated.",
+	"NOT_IMPERSONATING":                      "The session is not an impersonation.",
+
+	"IDEMPOTENCY_KEY_CONFLICT":    "The idempotency key was already used with a different request.",
+	"IDEMPOTENCY_KEY_IN_PROGRESS": "A request with the same idempotency key is still being processed.",
 
 	"INVALID_EMAIL":    "Invalid email.",
 	"INVALID_PASSWORD": "Invalid password.",
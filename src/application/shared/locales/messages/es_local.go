@@ -24,6 +24,7 @@ var EsMessages = map[MessageKeysEnum]string{
 	"USER_DELETE_SUCCESS":            "Usuario eliminado con éxito.",
 	"INVALID_USER_ID":                "El ID de usuario es inválido.",
 	"INVALID_USER_ACTIVATION_TOKEN":  "El token de activación de usuario es inválido.",
+	"USER_NOT_ACTIVE":                "El usuario no está activo.",
 	"WELCOME_EMAIL_RESENT":           "El correo de bienvenida ha sido reenviado con éxito.",
 	"USER_ALREADY_VERIFIED":          "El usuario ya está verificado.",
 
@@ -37,14 +38,83 @@ var EsMessages = map[MessageKeysEnum]string{
 	"INVALID_PASSWORD_RESET_TOKEN":           "Token de restablecimiento de contraseña inválido.",
 	"RESET_PASSWORD_TOKEN_VALID":             "El token de restablecimiento de contraseña es válido.",
 
-	"AUTHORIZATION_HEADER_MISSING": "Falta el encabezado de autorización.",
-	"AUTHORIZATION_HEADER_INVALID": "El encabezado de autorización es inválido.",
-	"AUTHORIZATION_TOKEN_EXPIRED":  "El token de autorización ha expirado.",
-	"AUTHORIZATION_GENERATED":      "Token de autorización generado con éxito.",
-	"INVALID_JWT_TOKEN":            "Token JWT inválido.",
-	"OTP_LOGIN_ENABLED":            "El inicio de sesión OTP está habilitado para este usuario. Por favor, un código OTP ha sido enviado a tu correo electrónico o teléfono.",
-	"INVALID_OTP":                  "El código OTP proporcionado es inválido. Está usado, expirado o es incorrecto.",
-	"LOGIN_MAX_ATTEMPTS_EXCEEDED":  "Demasiados intentos de inicio de sesión fallidos. Por favor, inténtalo de nuevo más tarde.",
+	"AUTHORIZATION_HEADER_MISSING":           "Falta el encabezado de autorización.",
+	"AUTHORIZATION_HEADER_INVALID":           "El encabezado de autorización es inválido.",
+	"AUTHORIZATION_TOKEN_EXPIRED":            "El token de autorización ha expirado.",
+	"AUTHORIZATION_TOKEN_REVOKED":            "El token de autorización ha sido revocado.",
+	"JWKS_RETRIEVED":                         "Claves públicas de firma de tokens obtenidas con éxito.",
+	"AUTHORIZATION_GENERATED":                "Token de autorización generado con éxito.",
+	"INVALID_JWT_TOKEN":                      "Token JWT inválido.",
+	"OTP_LOGIN_ENABLED":                      "El inicio de sesión OTP está habilitado para este usuario. Por favor, un código OTP ha sido enviado a tu correo electrónico o teléfono.",
+	"INVALID_OTP":                            "El código OTP proporcionado es inválido. Está usado, expirado o es incorrecto.",
+	"OTP_MAX_ATTEMPTS_EXCEEDED":              "Demasiados códigos OTP inválidos. Inicia sesión de nuevo para recibir uno nuevo.",
+	"MFA_CODE_REQUIRED":                      "Se requiere un código de la aplicación de autenticación.",
+	"INVALID_MFA_CODE":                       "El código de la aplicación de autenticación o de recuperación es inválido.",
+	"MFA_ENROLLMENT_STARTED":                 "Escanea el secreto con tu aplicación de autenticación y confírmalo con un código.",
+	"MFA_ENABLED":                            "Autenticación multifactor activada. Guarda los códigos de recuperación en un lugar seguro.",
+	"MFA_ALREADY_ENABLED":                    "La autenticación multifactor ya está activada.",
+	"MFA_NOT_ENROLLED":                       "No hay un registro de aplicación de autenticación para confirmar.",
+	"INVALID_WEBAUTHN_CREDENTIAL":            "No se pudo verificar la passkey.",
+	"WEBAUTHN_SESSION_EXPIRED":               "La solicitud de passkey expiró o ya fue usada. Iníciala de nuevo.",
+	"WEBAUTHN_CREDENTIAL_REGISTERED":         "Passkey registrada exitosamente.",
+	"WEBAUTHN_CREDENTIAL_ALREADY_REGISTERED": "Esta passkey ya está registrada.",
+	"WEBAUTHN_CHALLENGE_CREATED":             "Desafío de passkey creado.",
+	"OIDC_PROVIDER_NOT_FOUND":                "El proveedor de identidad no está configurado.",
+	"OIDC_INVALID_STATE":                     "La solicitud de inicio de sesión expiró o ya fue usada. Iníciala de nuevo.",
+	"OIDC_INVALID_TOKEN":                     "No se pudo verificar la respuesta del proveedor de identidad.",
+	"OIDC_EMAIL_NOT_VERIFIED":                "El proveedor de identidad no verificó tu correo electrónico.",
+	"OIDC_ACCOUNT_EXISTS":                    "Ya existe una cuenta con este correo electrónico. Inicia sesión y vincula el proveedor de identidad desde tu cuenta.",
+	"OIDC_IDENTITY_ALREADY_LINKED":           "Esta cuenta del proveedor de identidad ya está vinculada a otro usuario.",
+	"OIDC_IDENTITY_LINKED":                   "Proveedor de identidad vinculado exitosamente.",
+	"OIDC_AUTHORIZATION_CREATED":             "Autorización del proveedor de identidad creada.",
+	"INSUFFICIENT_SCOPE":                     "El token no tiene el alcance requerido para este recurso.",
+	"OAUTH_INVALID_CLIENT":                   "El cliente es desconocido o su autenticación falló.",
+	"OAUTH_INVALID_REDIRECT_URI":             "La URI de redirección no está registrada para el cliente.",
+	"OAUTH_INVALID_SCOPE":                    "El alcance solicitado no es válido o no está permitido para el cliente.",
+	"OAUTH_UNAUTHORIZED_GRANT_TYPE":          "El cliente no tiene permitido usar este tipo de concesión.",
+	"OAUTH_INVALID_GRANT":                    "La concesión de autorización no es válida, expiró o ya fue usada.",
+	"OAUTH_CONSENT_REQUIRED":                 "La aplicación solicita acceso a tu cuenta.",
+	"OAUTH_CLIENT_REGISTERED":                "Cliente OAuth registrado exitosamente. Guarda el secreto, no se volverá a mostrar.",
+	"OAUTH_AUTHORIZATION_GRANTED":            "Autorización concedida.",
+	"OAUTH_TOKEN_ISSUED":                     "Token emitido exitosamente.",
+	"OAUTH_TOKEN_INTROSPECTED":               "Token inspeccionado exitosamente.",
+	"OAUTH_TOKEN_REVOKED":                    "Token revocado exitosamente.",
+	"INVALID_API_KEY":                        "La API key es inválida, expiró o fue revocada.",
+	"API_KEY_CREATED":                        "API key creada exitosamente. Guarda la key, no se volverá a mostrar.",
+	"API_KEY_LIST_SUCCESS":                   "API keys obtenidas exitosamente.",
+	"API_KEY_REVOKED":                        "API key revocada exitosamente.",
+	"LOGIN_MAX_ATTEMPTS_EXCEEDED":            "Demasiados intentos de inicio de sesión fallidos. Por favor, inténtalo de nuevo más tarde.",
+	"LOGIN_DELAY_REQUIRED":                   "Demasiados intentos de inicio de sesión fallidos. Por favor, espera unos segundos antes de volver a intentarlo.",
+	"LOGIN_ACCOUNT_LOCKED":                   "La cuenta está bloqueada temporalmente por demasiados intentos de inicio de sesión fallidos. Revisa tu correo para desbloquearla.",
+	"ACCOUNT_UNLOCKED":                       "Cuenta desbloqueada exitosamente.",
+	"INVALID_ACCOUNT_UNLOCK_TOKEN":           "Token de desbloqueo de cuenta inválido o expirado.",
+	"LOGOUT_SUCCESS":                         "Sesión cerrada exitosamente.",
+	"LOGOUT_ALL_SUCCESS":                     "Todas las sesiones fueron cerradas exitosamente.",
+	"ROLE_WAS_CREATED":                       "El rol fue creado.",
+	"ROLE_LIST_SUCCESS":                      "Lista de roles obtenida con éxito.",
+	"ROLE_UPDATE_SUCCESS":                    "Rol actualizado con éxito.",
+	"ROLE_DELETE_SUCCESS":                    "Rol eliminado con éxito.",
+	"ROLE_PERMISSIONS_SUCCESS":               "Permisos del rol obtenidos con éxito.",
+	"ROLE_PERMISSIONS_UPDATED":               "Permisos del rol actualizados con éxito.",
+	"BUILT_IN_ROLE_PROTECTED":                "Los roles predefinidos no pueden ser eliminados ni renombrados, y los permisos del rol admin no pueden ser cambiados.",
+	"ROLE_HAS_USERS":                         "El rol no puede ser eliminado mientras haya usuarios que lo tengan.",
+	"UNKNOWN_PERMISSION":                     "Permiso desconocido.",
+	"ORGANIZATION_WAS_CREATED":               "Organización creada con éxito.",
+	"ORGANIZATION_LIST_SUCCESS":              "Organizaciones obtenidas con éxito.",
+	"ORGANIZATION_MEMBER_ADDED":              "Miembro agregado a la organización con éxito.",
+	"ORGANIZATION_MEMBER_REMOVED":            "Miembro eliminado de la organización con éxito.",
+	"TENANT_SWITCHED":                        "Organización cambiada con éxito.",
+	"CROSS_TENANT_ACCESS":                    "El recurso pertenece a otra organización.",
+	"NOT_ORGANIZATION_MEMBER":                "El usuario no es miembro de la organización.",
+	"ORGANIZATION_INACTIVE":                  "La organización no está activa.",
+	"IMPERSONATION_STARTED":                  "Suplantación iniciada exitosamente.",
+	"IMPERSONATION_ENDED":                    "Suplantación finalizada exitosamente.",
+	"IMPERSONATION_FORBIDDEN":                "Esta acción no está permitida mientras se suplanta a un usuario.",
+	"CANNOT_IMPERSONATE":                     "El usuario no puede ser suplantado.",
+	"NOT_IMPERSONATING":                      "La sesión no es una suplantación.",
+
+	"IDEMPOTENCY_KEY_CONFLICT":    "La clave de idempotencia ya fue usada con una solicitud diferente.",
+	"IDEMPOTENCY_KEY_IN_PROGRESS": "Una solicitud con la misma clave de idempotencia aún se está procesando.",
 
 	"INVALID_EMAIL":    "El correo electrónico es inválido.",
 	"INVALID_PASSWORD": "La contraseña es inválida.",
@@ -27,6 +27,7 @@ type MessageKeys struct {
 	USER_DELETE_SUCCESS            MessageKeysEnum
 	INVALID_USER_ID                MessageKeysEnum
 	INVALID_USER_ACTIVATION_TOKEN  MessageKeysEnum
+	USER_NOT_ACTIVE                MessageKeysEnum
 	WelcomeEmailResent             MessageKeysEnum
 	UserAlreadyVerified            MessageKeysEnum
 
@@ -40,15 +41,84 @@ type MessageKeys struct {
 	INVALID_PASSWORD_RESET_TOKEN           MessageKeysEnum
 	RESET_PASSWORD_TOKEN_VALID             MessageKeysEnum
 
-	AUTHORIZATION_HEADER_MISSING MessageKeysEnum
-	AUTHORIZATION_HEADER_INVALID MessageKeysEnum
-	AUTHORIZATION_TOKEN_EXPIRED  MessageKeysEnum
-	AUTHORIZATION_GENERATED      MessageKeysEnum
-	INVALID_JWT_TOKEN            MessageKeysEnum
-	JWT_TOKEN_VIOLATED           MessageKeysEnum
-	OTP_LOGIN_ENABLED            MessageKeysEnum
-	INVALID_OTP                  MessageKeysEnum
-	LoginMaxAttemptsExceeded     MessageKeysEnum
+	AUTHORIZATION_HEADER_MISSING           MessageKeysEnum
+	AUTHORIZATION_HEADER_INVALID           MessageKeysEnum
+	AUTHORIZATION_TOKEN_EXPIRED            MessageKeysEnum
+	AUTHORIZATION_TOKEN_REVOKED            MessageKeysEnum
+	JWKS_RETRIEVED                         MessageKeysEnum
+	AUTHORIZATION_GENERATED                MessageKeysEnum
+	INVALID_JWT_TOKEN                      MessageKeysEnum
+	JWT_TOKEN_VIOLATED                     MessageKeysEnum
+	OTP_LOGIN_ENABLED                      MessageKeysEnum
+	INVALID_OTP                            MessageKeysEnum
+	OTP_MAX_ATTEMPTS_EXCEEDED              MessageKeysEnum
+	MFA_CODE_REQUIRED                      MessageKeysEnum
+	INVALID_MFA_CODE                       MessageKeysEnum
+	MFA_ENROLLMENT_STARTED                 MessageKeysEnum
+	MFA_ENABLED                            MessageKeysEnum
+	MFA_ALREADY_ENABLED                    MessageKeysEnum
+	MFA_NOT_ENROLLED                       MessageKeysEnum
+	INVALID_WEBAUTHN_CREDENTIAL            MessageKeysEnum
+	WEBAUTHN_SESSION_EXPIRED               MessageKeysEnum
+	WEBAUTHN_CREDENTIAL_REGISTERED         MessageKeysEnum
+	WEBAUTHN_CREDENTIAL_ALREADY_REGISTERED MessageKeysEnum
+	WEBAUTHN_CHALLENGE_CREATED             MessageKeysEnum
+	OIDC_PROVIDER_NOT_FOUND                MessageKeysEnum
+	OIDC_INVALID_STATE                     MessageKeysEnum
+	OIDC_INVALID_TOKEN                     MessageKeysEnum
+	OIDC_EMAIL_NOT_VERIFIED                MessageKeysEnum
+	OIDC_ACCOUNT_EXISTS                    MessageKeysEnum
+	OIDC_IDENTITY_ALREADY_LINKED           MessageKeysEnum
+	OIDC_IDENTITY_LINKED                   MessageKeysEnum
+	OIDC_AUTHORIZATION_CREATED             MessageKeysEnum
+	INSUFFICIENT_SCOPE                     MessageKeysEnum
+	OAUTH_INVALID_CLIENT                   MessageKeysEnum
+	OAUTH_INVALID_REDIRECT_URI             MessageKeysEnum
+	OAUTH_INVALID_SCOPE                    MessageKeysEnum
+	OAUTH_UNAUTHORIZED_GRANT_TYPE          MessageKeysEnum
+	OAUTH_INVALID_GRANT                    MessageKeysEnum
+	OAUTH_CONSENT_REQUIRED                 MessageKeysEnum
+	OAUTH_CLIENT_REGISTERED                MessageKeysEnum
+	OAUTH_AUTHORIZATION_GRANTED            MessageKeysEnum
+	OAUTH_TOKEN_ISSUED                     MessageKeysEnum
+	OAUTH_TOKEN_INTROSPECTED               MessageKeysEnum
+	OAUTH_TOKEN_REVOKED                    MessageKeysEnum
+	INVALID_API_KEY                        MessageKeysEnum
+	API_KEY_CREATED                        MessageKeysEnum
+	API_KEY_LIST_SUCCESS                   MessageKeysEnum
+	API_KEY_REVOKED                        MessageKeysEnum
+	LoginMaxAttemptsExceeded               MessageKeysEnum
+	LOGIN_DELAY_REQUIRED                   MessageKeysEnum
+	LOGIN_ACCOUNT_LOCKED                   MessageKeysEnum
+	ACCOUNT_UNLOCKED                       MessageKeysEnum
+	INVALID_ACCOUNT_UNLOCK_TOKEN           MessageKeysEnum
+	LOGOUT_SUCCESS                         MessageKeysEnum
+	LOGOUT_ALL_SUCCESS                     MessageKeysEnum
+	ROLE_WAS_CREATED                       MessageKeysEnum
+	ROLE_LIST_SUCCESS                      MessageKeysEnum
+	ROLE_UPDATE_SUCCESS                    MessageKeysEnum
+	ROLE_DELETE_SUCCESS                    MessageKeysEnum
+	ROLE_PERMISSIONS_SUCCESS               MessageKeysEnum
+	ROLE_PERMISSIONS_UPDATED               MessageKeysEnum
+	BUILT_IN_ROLE_PROTECTED                MessageKeysEnum
+	ROLE_HAS_USERS                         MessageKeysEnum
+	UNKNOWN_PERMISSION                     MessageKeysEnum
+	ORGANIZATION_WAS_CREATED               MessageKeysEnum
+	ORGANIZATION_LIST_SUCCESS              MessageKeysEnum
+	ORGANIZATION_MEMBER_ADDED              MessageKeysEnum
+	ORGANIZATION_MEMBER_REMOVED            MessageKeysEnum
+	TENANT_SWITCHED                        MessageKeysEnum
+	CROSS_TENANT_ACCESS                    MessageKeysEnum
+	NOT_ORGANIZATION_MEMBER                MessageKeysEnum
+	ORGANIZATION_INACTIVE                  MessageKeysEnum
+	IMPERSONATION_STARTED                  MessageKeysEnum
+	IMPERSONATION_ENDED                    MessageKeysEnum
+	IMPERSONATION_FORBIDDEN                MessageKeysEnum
+	CANNOT_IMPERSONATE                     MessageKeysEnum
+	NOT_IMPERSONATING                      MessageKeysEnum
+
+	IDEMPOTENCY_KEY_CONFLICT    MessageKeysEnum
+	IDEMPOTENCY_KEY_IN_PROGRESS MessageKeysEnum
 
 	INVALID_EMAIL         MessageKeysEnum
 	INVALID_PASSWORD      MessageKeysEnum
@@ -80,9 +150,14 @@ var MessageKeysInstance = MessageKeys{
 	USER_DELETE_SUCCESS:            "USER_DELETE_SUCCESS",
 	INVALID_USER_ID:                "INVALID_USER_ID",
 	INVALID_USER_ACTIVATION_TOKEN:  "INVALID_USER_ACTIVATION_TOKEN",
+	USER_NOT_ACTIVE:                "USER_NOT_ACTIVE",
 	WelcomeEmailResent:             "WELCOME_EMAIL_RESENT",
 	UserAlreadyVerified:            "USER_ALREADY_VERIFIED",
 
+	// Idempotency related messages
+	IDEMPOTENCY_KEY_CONFLICT:    "IDEMPOTENCY_KEY_CONFLICT",
+	IDEMPOTENCY_KEY_IN_PROGRESS: "IDEMPOTENCY_KEY_IN_PROGRESS",
+
 	INVALID_EMAIL:    "INVALID_EMAIL",
 	INVALID_PASSWORD: "INVALID_PASSWORD",
 	INVALID_SESSION:  "INVALID_SESSION",
@@ -99,15 +174,81 @@ var MessageKeysInstance = MessageKeys{
 	RESET_PASSWORD_TOKEN_VALID:             "RESET_PASSWORD_TOKEN_VALID",
 
 	// Authorization related messages
-	AUTHORIZATION_HEADER_MISSING: "AUTHORIZATION_HEADER_MISSING",
-	AUTHORIZATION_HEADER_INVALID: "AUTHORIZATION_HEADER_INVALID",
-	AUTHORIZATION_TOKEN_EXPIRED:  "AUTHORIZATION_TOKEN_EXPIRED",
-	AUTHORIZATION_GENERATED:      "AUTHORIZATION_GENERATED",
-	INVALID_JWT_TOKEN:            "INVALID_JWT_TOKEN",
-	JWT_TOKEN_VIOLATED:           "JWT_TOKEN_VIOLATED",
-	OTP_LOGIN_ENABLED:            "OTP_LOGIN_ENABLED",
-	INVALID_OTP:                  "INVALID_OTP",
-	LoginMaxAttemptsExceeded:     "LOGIN_MAX_ATTEMPTS_EXCEEDED",
+	AUTHORIZATION_HEADER_MISSING:           "AUTHORIZATION_HEADER_MISSING",
+	AUTHORIZATION_HEADER_INVALID:           "AUTHORIZATION_HEADER_INVALID",
+	AUTHORIZATION_TOKEN_EXPIRED:            "AUTHORIZATION_TOKEN_EXPIRED",
+	AUTHORIZATION_TOKEN_REVOKED:            "AUTHORIZATION_TOKEN_REVOKED",
+	JWKS_RETRIEVED:                         "JWKS_RETRIEVED",
+	AUTHORIZATION_GENERATED:                "AUTHORIZATION_GENERATED",
+	INVALID_JWT_TOKEN:                      "INVALID_JWT_TOKEN",
+	JWT_TOKEN_VIOLATED:                     "JWT_TOKEN_VIOLATED",
+	OTP_LOGIN_ENABLED:                      "OTP_LOGIN_ENABLED",
+	INVALID_OTP:                            "INVALID_OTP",
+	OTP_MAX_ATTEMPTS_EXCEEDED:              "OTP_MAX_ATTEMPTS_EXCEEDED",
+	MFA_CODE_REQUIRED:                      "MFA_CODE_REQUIRED",
+	INVALID_MFA_CODE:                       "INVALID_MFA_CODE",
+	MFA_ENROLLMENT_STARTED:                 "MFA_ENROLLMENT_STARTED",
+	MFA_ENABLED:                            "MFA_ENABLED",
+	MFA_ALREADY_ENABLED:                    "MFA_ALREADY_ENABLED",
+	MFA_NOT_ENROLLED:                       "MFA_NOT_ENROLLED",
+	INVALID_WEBAUTHN_CREDENTIAL:            "INVALID_WEBAUTHN_CREDENTIAL",
+	WEBAUTHN_SESSION_EXPIRED:               "WEBAUTHN_SESSION_EXPIRED",
+	WEBAUTHN_CREDENTIAL_REGISTERED:         "WEBAUTHN_CREDENTIAL_REGISTERED",
+	WEBAUTHN_CREDENTIAL_ALREADY_REGISTERED: "WEBAUTHN_CREDENTIAL_ALREADY_REGISTERED",
+	WEBAUTHN_CHALLENGE_CREATED:             "WEBAUTHN_CHALLENGE_CREATED",
+	OIDC_PROVIDER_NOT_FOUND:                "OIDC_PROVIDER_NOT_FOUND",
+	OIDC_INVALID_STATE:                     "OIDC_INVALID_STATE",
+	OIDC_INVALID_TOKEN:                     "OIDC_INVALID_TOKEN",
+	OIDC_EMAIL_NOT_VERIFIED:                "OIDC_EMAIL_NOT_VERIFIED",
+	OIDC_ACCOUNT_EXISTS:                    "OIDC_ACCOUNT_EXISTS",
+	OIDC_IDENTITY_ALREADY_LINKED:           "OIDC_IDENTITY_ALREADY_LINKED",
+	OIDC_IDENTITY_LINKED:                   "OIDC_IDENTITY_LINKED",
+	OIDC_AUTHORIZATION_CREATED:             "OIDC_AUTHORIZATION_CREATED",
+	INSUFFICIENT_SCOPE:                     "INSUFFICIENT_SCOPE",
+	OAUTH_INVALID_CLIENT:                   "OAUTH_INVALID_CLIENT",
+	OAUTH_INVALID_REDIRECT_URI:             "OAUTH_INVALID_REDIRECT_URI",
+	OAUTH_INVALID_SCOPE:                    "OAUTH_INVALID_SCOPE",
+	OAUTH_UNAUTHORIZED_GRANT_TYPE:          "OAUTH_UNAUTHORIZED_GRANT_TYPE",
+	OAUTH_INVALID_GRANT:                    "OAUTH_INVALID_GRANT",
+	OAUTH_CONSENT_REQUIRED:                 "OAUTH_CONSENT_REQUIRED",
+	OAUTH_CLIENT_REGISTERED:                "OAUTH_CLIENT_REGISTERED",
+	OAUTH_AUTHORIZATION_GRANTED:            "OAUTH_AUTHORIZATION_GRANTED",
+	OAUTH_TOKEN_ISSUED:                     "OAUTH_TOKEN_ISSUED",
+	OAUTH_TOKEN_INTROSPECTED:               "OAUTH_TOKEN_INTROSPECTED",
+	OAUTH_TOKEN_REVOKED:                    "OAUTH_TOKEN_REVOKED",
+	INVALID_API_KEY:                        "INVALID_API_KEY",
+	API_KEY_CREATED:                        "API_KEY_CREATED",
+	API_KEY_LIST_SUCCESS:                   "API_KEY_LIST_SUCCESS",
+	API_KEY_REVOKED:                        "API_KEY_REVOKED",
+	LoginMaxAttemptsExceeded:               "LOGIN_MAX_ATTEMPTS_EXCEEDED",
+	LOGIN_DELAY_REQUIRED:                   "LOGIN_DELAY_REQUIRED",
+	LOGIN_ACCOUNT_LOCKED:                   "LOGIN_ACCOUNT_LOCKED",
+	ACCOUNT_UNLOCKED:                       "ACCOUNT_UNLOCKED",
+	INVALID_ACCOUNT_UNLOCK_TOKEN:           "INVALID_ACCOUNT_UNLOCK_TOKEN",
+	LOGOUT_SUCCESS:                         "LOGOUT_SUCCESS",
+	LOGOUT_ALL_SUCCESS:                     "LOGOUT_ALL_SUCCESS",
+	ROLE_WAS_CREATED:                       "ROLE_WAS_CREATED",
+	ROLE_LIST_SUCCESS:                      "ROLE_LIST_SUCCESS",
+	ROLE_UPDATE_SUCCESS:                    "ROLE_UPDATE_SUCCESS",
+	ROLE_DELETE_SUCCESS:                    "ROLE_DELETE_SUCCESS",
+	ROLE_PERMISSIONS_SUCCESS:               "ROLE_PERMISSIONS_SUCCESS",
+	ROLE_PERMISSIONS_UPDATED:               "ROLE_PERMISSIONS_UPDATED",
+	BUILT_IN_ROLE_PROTECTED:                "BUILT_IN_ROLE_PROTECTED",
+	ROLE_HAS_USERS:                         "ROLE_HAS_USERS",
+	UNKNOWN_PERMISSION:                     "UNKNOWN_PERMISSION",
+	ORGANIZATION_WAS_CREATED:               "ORGANIZATION_WAS_CREATED",
+	ORGANIZATION_LIST_SUCCESS:              "ORGANIZATION_LIST_SUCCESS",
+	ORGANIZATION_MEMBER_ADDED:              "ORGANIZATION_MEMBER_ADDED",
+	ORGANIZATION_MEMBER_REMOVED:            "ORGANIZATION_MEMBER_REMOVED",
+	TENANT_SWITCHED:                        "TENANT_SWITCHED",
+	CROSS_TENANT_ACCESS:                    "CROSS_TENANT_ACCESS",
+	NOT_ORGANIZATION_MEMBER:                "NOT_ORGANIZATION_MEMBER",
+	ORGANIZATION_INACTIVE:                  "ORGANIZATION_INACTIVE",
+	IMPERSONATION_STARTED:                  "IMPERSONATION_STARTED",
+	IMPERSONATION_ENDED:                    "IMPERSONATION_ENDED",
+	IMPERSONATION_FORBIDDEN:                "IMPERSONATION_FORBIDDEN",
+	CANNOT_IMPERSONATE:                     "CANNOT_IMPERSONATE",
+	NOT_IMPERSONATING:                      "NOT_IMPERSONATING",
 
 	APPLICATION_STATUS_OK: "APPLICATION_STATUS_OK",
 }
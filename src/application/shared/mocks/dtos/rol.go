@@ -39,3 +39,32 @@ var AdminRole = usermodels.Role{
 	RoleBase: AdminRoleBase,
 	ID:       1,
 }
+
+// UserRolePermissions is a mock of the permissions of the user role
+var UserRolePermissions = []string{
+	usermodels.PermissionUserRead,
+	usermodels.PermissionUserUpdate,
+	usermodels.PermissionUserDelete,
+	usermodels.PermissionPasswordCreate,
+	usermodels.PermissionAPIKeyRevoke,
+}
+
+// AdminRolePermissions is a mock of the permissions of the admin role
+var AdminRolePermissions = []string{
+	usermodels.PermissionUserRead,
+	usermodels.PermissionUserList,
+	usermodels.PermissionUserUpdate,
+	usermodels.PermissionUserDelete,
+	usermodels.PermissionPasswordCreate,
+	usermodels.PermissionAPIKeyRevoke,
+	usermodels.PermissionAPIKeyRevokeAny,
+	usermodels.PermissionOAuthClientCreate,
+	usermodels.PermissionStatusPipes,
+	usermodels.PermissionRoleRead,
+	usermodels.PermissionRoleWrite,
+	usermodels.PermissionOrganizationRead,
+	usermodels.PermissionOrganizationWrite,
+	usermodels.PermissionTenantCross,
+	usermodels.PermissionUserImpersonate,
+	usermodels.PermissionUserAssignRole,
+}
@@ -34,6 +34,21 @@ var UserWithRole = usermodels.UserWithRole{
 	ID:       1,
 }
 
+// AdminUserWithRole is the model for a user with the admin role
+var AdminUserWithRole = usermodels.UserWithRole{
+	UserBase: usermodels.UserBase{
+		Name:   "Admin User",
+		Email:  "admin@example.com",
+		Phone:  "456",
+		Status: &userStatusActive,
+		RoleID: 1,
+	},
+	ID: 2,
+}
+
 func init() {
 	UserWithRole.SetRole(UserRole)
+	UserWithRole.SetPermissions(UserRolePermissions)
+	AdminUserWithRole.SetRole(AdminRole)
+	AdminUserWithRole.SetPermissions(AdminRolePermissions)
 }
@@ -0,0 +1,158 @@
+package providersmocks
+
+import (
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	contractsProviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+)
+
+// MemoryCacheProvider is an in-memory implementation of the ICacheProvider
+// interface that encodes values as JSON, like the Redis provider.
+type MemoryCacheProvider struct {
+	mu      sync.Mutex
+	entries map[string]memoryCacheEntry
+}
+
+type memoryCacheEntry struct {
+	data      []byte
+	expiresAt time.Time
+}
+
+var _ contractsProviders.ICacheProvider = (*MemoryCacheProvider)(nil)
+
+// NewMemoryCacheProvider creates an empty in-memory cache provider
+func NewMemoryCacheProvider() *MemoryCacheProvider {
+	return &MemoryCacheProvider{entries: make(map[string]memoryCacheEntry)}
+}
+
+// Keys returns the keys stored in the cache
+func (m *MemoryCacheProvider) Keys() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	keys := make([]string, 0, len(m.entries))
+	for key := range m.entries {
+		if _, ok := m.lookup(key); ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// lookup returns the data of a key that has not expired, the lock must be held
+func (m *MemoryCacheProvider) lookup(key string) ([]byte, bool) {
+	entry, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		delete(m.entries, key)
+		return nil, false
+	}
+	return entry.data, true
+}
+
+// store saves the data of a key, the lock must be held
+func (m *MemoryCacheProvider) store(key string, data []byte, ttl time.Duration) {
+	entry := memoryCacheEntry{data: data}
+	if ttl > 0 {
+		entry.expiresAt = time.Now().Add(ttl)
+	}
+	m.entries[key] = entry
+}
+
+// Get get the value for a given key from the cache
+func (m *MemoryCacheProvider) Get(key string, dest any) (bool, *application_errors.ApplicationError) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.lookup(key)
+	if !ok {
+		return false, nil
+	}
+	if err := json.Unmarshal(data, dest); err != nil {
+		return false, memoryCacheError(err)
+	}
+	return true, nil
+}
+
+// Set set the value for a given key in the cache
+func (m *MemoryCacheProvider) Set(key string, value any, ttl time.Duration) *application_errors.ApplicationError {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return memoryCacheError(err)
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.store(key, data, ttl)
+	return nil
+}
+
+// Delete delete the value for a given key from the cache
+func (m *MemoryCacheProvider) Delete(key string) *application_errors.ApplicationError {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.entries, key)
+	return nil
+}
+
+// Flush flush all the values from the cache
+func (m *MemoryCacheProvider) Flush() *application_errors.ApplicationError {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.entries = make(map[string]memoryCacheEntry)
+	return nil
+}
+
+// Increment increment the value for a given key in the cache
+func (m *MemoryCacheProvider) Increment(key string, ttl time.Duration) (int64, *application_errors.ApplicationError) {
+	return m.IncrementBy(key, 1, ttl)
+}
+
+// IncrementBy increment the value for a given key in the cache by a given amount
+// the TTL is only set when the key is created
+func (m *MemoryCacheProvider) IncrementBy(key string, increment int64, ttl time.Duration) (int64, *application_errors.ApplicationError) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.lookup(key)
+	if !ok {
+		m.store(key, []byte(strconv.FormatInt(increment, 10)), ttl)
+		return increment, nil
+	}
+	value, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return 0, memoryCacheError(err)
+	}
+	value += increment
+	entry := m.entries[key]
+	entry.data = []byte(strconv.FormatInt(value, 10))
+	m.entries[key] = entry
+	return value, nil
+}
+
+// GetInt64 get the value for a given key from the cache as an int64
+func (m *MemoryCacheProvider) GetInt64(key string) (int64, *application_errors.ApplicationError) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.lookup(key)
+	if !ok {
+		return 0, nil
+	}
+	value, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil {
+		return 0, memoryCacheError(err)
+	}
+	return value, nil
+}
+
+func memoryCacheError(err error) *application_errors.ApplicationError {
+	return application_errors.NewApplicationError(
+		status.ProviderError,
+		messages.MessageKeysInstance.SOMETHING_WENT_WRONG,
+		err.Error(),
+	)
+}
@@ -0,0 +1,32 @@
+package providersmocks
+
+import (
+	contractsProviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockEncryptionProvider struct {
+	mock.Mock
+}
+
+var _ contractsProviders.IEncryptionProvider = (*MockEncryptionProvider)(nil)
+
+func (m *MockEncryptionProvider) Encrypt(plaintext []byte) ([]byte, *application_errors.ApplicationError) {
+	args := m.Called(plaintext)
+	errorArg := args.Get(1)
+	if errorArg != nil {
+		return nil, errorArg.(*application_errors.ApplicationError)
+	}
+	return args.Get(0).([]byte), nil
+}
+
+func (m *MockEncryptionProvider) Decrypt(ciphertext []byte) ([]byte, *application_errors.ApplicationError) {
+	args := m.Called(ciphertext)
+	errorArg := args.Get(1)
+	if errorArg != nil {
+		return nil, errorArg.(*application_errors.ApplicationError)
+	}
+	return args.Get(0).([]byte), nil
+}
@@ -0,0 +1,52 @@
+package providersmocks
+
+import (
+	"time"
+
+	contractsProviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTokenRevocationProvider struct {
+	mock.Mock
+}
+
+var _ contractsProviders.ITokenRevocationProvider = (*MockTokenRevocationProvider)(nil)
+
+func (m *MockTokenRevocationProvider) RevokeToken(tokenID string, expiresAt time.Time) *application_errors.ApplicationError {
+	args := m.Called(tokenID, expiresAt)
+	errorArg := args.Get(0)
+	if errorArg != nil {
+		return errorArg.(*application_errors.ApplicationError)
+	}
+	return nil
+}
+
+func (m *MockTokenRevocationProvider) IsTokenRevoked(tokenID string) (bool, *application_errors.ApplicationError) {
+	args := m.Called(tokenID)
+	errorArg := args.Get(1)
+	if errorArg != nil {
+		return args.Bool(0), errorArg.(*application_errors.ApplicationError)
+	}
+	return args.Bool(0), nil
+}
+
+func (m *MockTokenRevocationProvider) RevokeUserTokens(userID uint) *application_errors.ApplicationError {
+	args := m.Called(userID)
+	errorArg := args.Get(0)
+	if errorArg != nil {
+		return errorArg.(*application_errors.ApplicationError)
+	}
+	return nil
+}
+
+func (m *MockTokenRevocationProvider) UserTokensRevokedBefore(userID uint) (time.Time, *application_errors.ApplicationError) {
+	args := m.Called(userID)
+	errorArg := args.Get(1)
+	if errorArg != nil {
+		return args.Get(0).(time.Time), errorArg.(*application_errors.ApplicationError)
+	}
+	return args.Get(0).(time.Time), nil
+}
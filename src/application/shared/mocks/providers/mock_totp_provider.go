@@ -0,0 +1,44 @@
+package providersmocks
+
+import (
+	"time"
+
+	contractsProviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MockTOTPProvider struct {
+	mock.Mock
+}
+
+var _ contractsProviders.ITOTPProvider = (*MockTOTPProvider)(nil)
+
+func (m *MockTOTPProvider) GenerateSecret() (string, *application_errors.ApplicationError) {
+	args := m.Called()
+	errorArg := args.Get(1)
+	if errorArg != nil {
+		return "", errorArg.(*application_errors.ApplicationError)
+	}
+	return args.String(0), nil
+}
+
+func (m *MockTOTPProvider) ProvisioningURI(secret string, accountName string) string {
+	args := m.Called(secret, accountName)
+	return args.String(0)
+}
+
+func (m *MockTOTPProvider) ValidateCode(secret string, code string, at time.Time) (int64, bool) {
+	args := m.Called(secret, code, at)
+	return args.Get(0).(int64), args.Bool(1)
+}
+
+func (m *MockTOTPProvider) GenerateRecoveryCodes(count int) ([]string, *application_errors.ApplicationError) {
+	args := m.Called(count)
+	errorArg := args.Get(1)
+	if errorArg != nil {
+		return nil, errorArg.(*application_errors.ApplicationError)
+	}
+	return args.Get(0).([]string), nil
+}
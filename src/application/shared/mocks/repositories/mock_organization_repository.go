@@ -0,0 +1,33 @@
+package repositoriesmocks
+
+import (
+	contracts_repositories "github.com/simon3640/goprojectskeleton/src/application/contracts/repositories"
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
+
+type MockOrganizationRepository struct {
+	MockRepositoryBase[usermodels.OrganizationCreate, usermodels.OrganizationUpdate, usermodels.Organization, usermodels.Organization]
+}
+
+// AddMember makes the user a member of the organization
+func (m *MockOrganizationRepository) AddMember(organizationID uint, userID uint) *application_errors.ApplicationError {
+	args := m.Called(organizationID, userID)
+	errorArg := args.Get(0)
+	if errorArg != nil {
+		return errorArg.(*application_errors.ApplicationError)
+	}
+	return nil
+}
+
+// RemoveMember removes the user from the organization
+func (m *MockOrganizationRepository) RemoveMember(organizationID uint, userID uint) *application_errors.ApplicationError {
+	args := m.Called(organizationID, userID)
+	errorArg := args.Get(0)
+	if errorArg != nil {
+		return errorArg.(*application_errors.ApplicationError)
+	}
+	return nil
+}
+
+var _ contracts_repositories.IOrganizationRepository = (*MockOrganizationRepository)(nil)
@@ -0,0 +1,45 @@
+package repositoriesmocks
+
+import (
+	"time"
+
+	contracts_repositories "github.com/simon3640/goprojectskeleton/src/application/contracts/repositories"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/shared/DTOs"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+type MockOutboxTaskRepository struct {
+	MockRepositoryBase[dtos.OutboxTaskCreate, dtos.OutboxTaskUpdate, sharedmodels.OutboxTask, sharedmodels.OutboxTask]
+}
+
+// GetByTaskID retrieves an outbox task by its task ID
+func (m *MockOutboxTaskRepository) GetByTaskID(taskID string) (*sharedmodels.OutboxTask, *application_errors.ApplicationError) {
+	args := m.Called(taskID)
+	errorArg := args.Get(1)
+	if errorArg != nil {
+		return nil, errorArg.(*application_errors.ApplicationError)
+	}
+	if args.Get(0) == nil {
+		return nil, nil
+	}
+	return args.Get(0).(*sharedmodels.OutboxTask), nil
+}
+
+// ClaimDue claims the due outbox tasks
+func (m *MockOutboxTaskRepository) ClaimDue(now time.Time, limit int, lease time.Duration) ([]sharedmodels.OutboxTask, *application_errors.ApplicationError) {
+	args := m.Called(now, limit, lease)
+	errorArg := args.Get(1)
+	if errorArg != nil {
+		return nil, errorArg.(*application_errors.ApplicationError)
+	}
+	return args.Get(0).([]sharedmodels.OutboxTask), nil
+}
+
+// WithContext returns the repository, the mock is not bound to contexts
+func (m *MockOutboxTaskRepository) WithContext(ctx *app_context.AppContext) contracts_repositories.IOutboxTaskRepository {
+	return m
+}
+
+var _ contracts_repositories.IOutboxTaskRepository = (*MockOutboxTaskRepository)(nil)
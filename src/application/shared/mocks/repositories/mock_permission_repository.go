@@ -0,0 +1,43 @@
+package repositoriesmocks
+
+import (
+	contracts_repositories "github.com/simon3640/goprojectskeleton/src/application/contracts/repositories"
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
+
+type MockPermissionRepository struct {
+	MockRepositoryBase[usermodels.PermissionCreate, usermodels.PermissionUpdate, usermodels.Permission, usermodels.Permission]
+}
+
+// GetRolePermissions gets the keys of the permissions granted to a role
+func (m *MockPermissionRepository) GetRolePermissions(roleID uint) ([]string, *application_errors.ApplicationError) {
+	args := m.Called(roleID)
+	errorArg := args.Get(1)
+	if errorArg != nil {
+		return nil, errorArg.(*application_errors.ApplicationError)
+	}
+	return args.Get(0).([]string), nil
+}
+
+// SetRolePermissions replaces the permissions granted to a role
+func (m *MockPermissionRepository) SetRolePermissions(roleID uint, keys []string) *application_errors.ApplicationError {
+	args := m.Called(roleID, keys)
+	errorArg := args.Get(0)
+	if errorArg != nil {
+		return errorArg.(*application_errors.ApplicationError)
+	}
+	return nil
+}
+
+// GetUserPermissions gets the permissions granted or revoked to a user
+func (m *MockPermissionRepository) GetUserPermissions(userID uint) ([]usermodels.UserPermission, *application_errors.ApplicationError) {
+	args := m.Called(userID)
+	errorArg := args.Get(1)
+	if errorArg != nil {
+		return nil, errorArg.(*application_errors.ApplicationError)
+	}
+	return args.Get(0).([]usermodels.UserPermission), nil
+}
+
+var _ contracts_repositories.IPermissionRepository = (*MockPermissionRepository)(nil)
@@ -0,0 +1,30 @@
+package repositoriesmocks
+
+import (
+	contracts_repositories "github.com/simon3640/goprojectskeleton/src/application/contracts/repositories"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockUnitOfWork runs the functions without transaction. It is called with
+// whether the transaction is committed, and returns the error of the commit.
+type MockUnitOfWork struct {
+	mock.Mock
+}
+
+// Do runs fn and commits when it succeeds
+func (m *MockUnitOfWork) Do(ctx *app_context.AppContext, fn func() *application_errors.ApplicationError) *application_errors.ApplicationError {
+	appErr := fn()
+	args := m.Called(appErr == nil)
+	if appErr != nil {
+		return appErr
+	}
+	if errorArg := args.Get(0); errorArg != nil {
+		return errorArg.(*application_errors.ApplicationError)
+	}
+	return nil
+}
+
+var _ contracts_repositories.IUnitOfWork = (*MockUnitOfWork)(nil)
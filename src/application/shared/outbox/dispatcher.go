@@ -0,0 +1,157 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	contractsobservability "github.com/simon3640/goprojectskeleton/src/application/contracts/observability"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/shared/DTOs"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// Deliver executes the registered handler of a task and records the outcome.
+// See DeliverWith.
+func (o *Outbox) Deliver(appCtx *app_context.AppContext, task sharedmodels.OutboxTask) sharedmodels.OutboxTaskStatus {
+	handler, ok := getHandler(task.Name)
+	if !ok {
+		handler = func(_ *app_context.AppContext, _ locales.LocaleTypeEnum, _ []byte) (string, error) {
+			return "", errors.New("no handler registered for outbox task " + task.Name)
+		}
+	}
+	return o.DeliverWith(appCtx, task, handler)
+}
+
+// DeliverWith executes the given handler for a task and records the outcome.
+//
+// Delivery semantics:
+//   - On success the task is marked as succeeded and the handler details are
+//     stored as its result.
+//   - On failure the task is scheduled again with exponential backoff, or
+//     dead-lettered once MaxAttempts deliveries failed.
+//   - Panics in the handler are recovered and treated as failures.
+//
+// It returns the status of the task after the delivery.
+func (o *Outbox) DeliverWith(appCtx *app_context.AppContext, task sharedmodels.OutboxTask, handler Handler) sharedmodels.OutboxTaskStatus {
+	tracer := observability.GetObservabilityComponents().Tracer
+	clock := observability.GetObservabilityComponents().Clock
+	metrics := observability.GetObservabilityComponents().Metrics
+
+	span := tracer.StartSpan(appCtx, "outbox.deliver."+task.Name)
+	defer span.End()
+	span.UpdateAppContext(appCtx)
+	span.SetAttribute("outbox_task_id", task.TaskID)
+
+	attempts := task.Attempts + 1
+	start := clock.Now()
+	result, err := runHandler(appCtx, task, handler)
+	duration := clock.Now().Sub(start)
+
+	tags := map[string]string{
+		"outbox_task": task.Name,
+	}
+	metrics.RecordLatency("outbox.deliver."+task.Name, duration, tags)
+
+	update := dtos.OutboxTaskUpdate{ID: task.ID, Attempts: attempts}
+	switch {
+	case err == nil:
+		span.SetStatus(contractsobservability.SpanStatusOK, "")
+		tags["status"] = "success"
+		metrics.IncrementCounter("outbox.success."+task.Name, tags)
+		update.Status = sharedmodels.OutboxTaskStatusSucceeded
+		update.Result = result
+	case attempts >= task.MaxAttempts:
+		span.SetStatus(contractsobservability.SpanStatusError, err.Error())
+		tags["status"] = "dead_letter"
+		metrics.IncrementCounter("outbox.dead_letter."+task.Name, tags)
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("outbox task "+task.TaskID+" dead-lettered", err, appCtx)
+		update.Status = sharedmodels.OutboxTaskStatusDeadLetter
+		update.LastError = err.Error()
+	default:
+		span.SetStatus(contractsobservability.SpanStatusError, err.Error())
+		tags["status"] = "retry"
+		metrics.IncrementCounter("outbox.retry."+task.Name, tags)
+		update.Status = sharedmodels.OutboxTaskStatusPending
+		update.LastError = err.Error()
+		update.NextAttemptAt = o.now().Add(o.backoff(attempts))
+	}
+
+	if _, appErr := o.repo.Update(task.ID, update); appErr != nil {
+		// The lease expires and the task is delivered again
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("error updating outbox task "+task.TaskID, appErr.ToError(), appCtx)
+		return task.Status
+	}
+	return update.Status
+}
+
+// runHandler executes the handler of the task recovering panics.
+func runHandler(appCtx *app_context.AppContext, task sharedmodels.OutboxTask, handler Handler) (result string, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic in outbox task %s: %v", task.Name, r)
+		}
+	}()
+	return handler(appCtx, locales.LocaleTypeEnum(task.Locale), task.Payload)
+}
+
+// backoff returns the delay after the given failed attempt.
+func (o *Outbox) backoff(attempts int) time.Duration {
+	delay := o.options.Backoff
+	for i := 1; i < attempts && delay < o.options.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > o.options.MaxBackoff {
+		return o.options.MaxBackoff
+	}
+	return delay
+}
+
+// DispatchDue claims the tasks that are due and delivers them.
+// It returns the number of delivered tasks.
+func (o *Outbox) DispatchDue(appCtx *app_context.AppContext) int {
+	tasks, appErr := o.repo.ClaimDue(o.now(), o.options.BatchSize, o.options.Lease)
+	if appErr != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("error claiming outbox tasks", appErr.ToError(), appCtx)
+		return 0
+	}
+	for _, task := range tasks {
+		if appCtx.Err() != nil {
+			break
+		}
+		o.Deliver(appCtx, task)
+	}
+	return len(tasks)
+}
+
+// Run dispatches the due tasks every interval until the context is done.
+// A full batch is followed by another dispatch without waiting.
+// A non positive interval defaults to 10 seconds.
+func (o *Outbox) Run(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		interval = 10 * time.Second
+	}
+	appCtx := &app_context.AppContext{Context: ctx}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		o.drain(appCtx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// drain dispatches batches until a batch is not full or the context is done.
+func (o *Outbox) drain(appCtx *app_context.AppContext) {
+	for appCtx.Err() == nil {
+		if o.DispatchDue(appCtx) < o.options.BatchSize {
+			return
+		}
+	}
+}
@@ -0,0 +1,210 @@
+// Package outbox provides durable background tasks backed by a persistent outbox.
+//
+// Tasks are persisted before being executed and are delivered at least once
+// by the dispatcher, with retries and dead-lettering. Handlers must therefore
+// be idempotent. Tasks enqueued in a transaction of the unit of work of the
+// outbox are persisted together with the writes of the transaction.
+package outbox
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"sync"
+	"time"
+
+	contractsrepositories "github.com/simon3640/goprojectskeleton/src/application/contracts/repositories"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/shared/DTOs"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// Handler delivers a persisted task.
+// It receives the JSON encoded payload of the task and returns the details
+// stored as the result of the task, or an error to retry it.
+type Handler func(ctx *app_context.AppContext, locale locales.LocaleTypeEnum, payload []byte) (string, error)
+
+var (
+	handlers   = make(map[string]Handler)
+	handlersMu sync.RWMutex
+)
+
+// Register registers the handler of the tasks with the given name.
+// Registering a name again replaces the previous handler.
+// Handlers must be registered at startup so tasks persisted before a
+// restart can be delivered.
+func Register(name string, handler Handler) {
+	handlersMu.Lock()
+	defer handlersMu.Unlock()
+	handlers[name] = handler
+}
+
+// IsRegistered reports whether a handler is registered for the given name.
+func IsRegistered(name string) bool {
+	_, ok := getHandler(name)
+	return ok
+}
+
+func getHandler(name string) (Handler, bool) {
+	handlersMu.RLock()
+	defer handlersMu.RUnlock()
+	handler, ok := handlers[name]
+	return handler, ok
+}
+
+// Options configures the delivery of the outbox tasks.
+type Options struct {
+	// MaxAttempts is the number of deliveries before a task is dead-lettered.
+	MaxAttempts int
+	// Backoff is the delay before the first retry. It doubles after every retry.
+	Backoff time.Duration
+	// MaxBackoff caps the delay between retries.
+	MaxBackoff time.Duration
+	// Lease is the time a claimed task is hidden from other dispatchers.
+	// It must be longer than the slowest handler.
+	Lease time.Duration
+	// BatchSize is the number of tasks claimed on every dispatch.
+	BatchSize int
+}
+
+// DefaultOptions returns the default outbox options.
+func DefaultOptions() Options {
+	return Options{
+		MaxAttempts: 5,
+		Backoff:     5 * time.Second,
+		MaxBackoff:  5 * time.Minute,
+		Lease:       time.Minute,
+		BatchSize:   20,
+	}
+}
+
+// Outbox persists background tasks and delivers them at least once.
+type Outbox struct {
+	repo       contractsrepositories.IOutboxTaskRepository
+	unitOfWork contractsrepositories.IUnitOfWork
+	options    Options
+	now        func() time.Time
+}
+
+// NewOutbox creates a new outbox backed by the given repository, and the
+// unit of work of its database.
+// Zero options take their default value.
+func NewOutbox(repo contractsrepositories.IOutboxTaskRepository, unitOfWork contractsrepositories.IUnitOfWork, options Options) *Outbox {
+	defaults := DefaultOptions()
+	if options.MaxAttempts <= 0 {
+		options.MaxAttempts = defaults.MaxAttempts
+	}
+	if options.Backoff <= 0 {
+		options.Backoff = defaults.Backoff
+	}
+	if options.MaxBackoff <= 0 {
+		options.MaxBackoff = defaults.MaxBackoff
+	}
+	if options.Lease <= 0 {
+		options.Lease = defaults.Lease
+	}
+	if options.BatchSize <= 0 {
+		options.BatchSize = defaults.BatchSize
+	}
+	return &Outbox{
+		repo:       repo,
+		unitOfWork: unitOfWork,
+		options:    options,
+		now:        time.Now,
+	}
+}
+
+// Enqueue persists a new task with the JSON encoded input and returns it.
+// The task belongs to the user of the AppContext, if any, and is persisted
+// in the transaction the AppContext runs in (see Transaction).
+//
+// The task is leased on creation so the caller can deliver it right away
+// with Deliver; if that delivery never happens (e.g. the process dies),
+// the dispatcher delivers it once the lease expires.
+func (o *Outbox) Enqueue(appCtx *app_context.AppContext, locale locales.LocaleTypeEnum, name string, input any) (*sharedmodels.OutboxTask, *application_errors.ApplicationError) {
+	payload, err := json.Marshal(input)
+	if err != nil {
+		return nil, application_errors.NewApplicationError(
+			status.InternalError,
+			messages.MessageKeysInstance.SOMETHING_WENT_WRONG,
+			"error encoding outbox payload: "+err.Error(),
+		)
+	}
+	taskID, appErr := newTaskID()
+	if appErr != nil {
+		return nil, appErr
+	}
+	taskCreate := dtos.NewOutboxTaskCreate(
+		taskID,
+		name,
+		payload,
+		string(locale),
+		o.options.MaxAttempts,
+		o.now().Add(o.options.Lease),
+	)
+	if appCtx == nil {
+		return o.repo.Create(*taskCreate)
+	}
+	if appCtx.User != nil {
+		taskCreate.UserID = appCtx.User.ID
+	}
+	return o.repo.WithContext(appCtx).Create(*taskCreate)
+}
+
+// GetTask returns the task with the given task ID.
+func (o *Outbox) GetTask(taskID string) (*sharedmodels.OutboxTask, *application_errors.ApplicationError) {
+	return o.repo.GetByTaskID(taskID)
+}
+
+// Transaction runs fn in a transaction of the database of the outbox. The
+// tasks enqueued by fn with the AppContext are persisted together with the
+// writes of the repositories bound to it, or not at all.
+func (o *Outbox) Transaction(appCtx *app_context.AppContext, fn func() *application_errors.ApplicationError) *application_errors.ApplicationError {
+	return o.unitOfWork.Do(appCtx, fn)
+}
+
+// newTaskID returns a random task ID.
+func newTaskID() (string, *application_errors.ApplicationError) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", application_errors.NewApplicationError(
+			status.InternalError,
+			messages.MessageKeysInstance.SOMETHING_WENT_WRONG,
+			"error generating task id: "+err.Error(),
+		)
+	}
+	return hex.EncodeToString(b), nil
+}
+
+var (
+	outboxSingleton *Outbox
+	singletonMu     sync.RWMutex
+)
+
+// InitializeOutbox initializes the singleton Outbox instance.
+// This should be called during application startup in the infrastructure layer.
+func InitializeOutbox(repo contractsrepositories.IOutboxTaskRepository, unitOfWork contractsrepositories.IUnitOfWork, options Options) *Outbox {
+	singletonMu.Lock()
+	defer singletonMu.Unlock()
+	outboxSingleton = NewOutbox(repo, unitOfWork, options)
+	return outboxSingleton
+}
+
+// GetOutbox returns the singleton Outbox instance, or nil if it was not initialized.
+func GetOutbox() *Outbox {
+	singletonMu.RLock()
+	defer singletonMu.RUnlock()
+	return outboxSingleton
+}
+
+// ResetOutboxSingleton resets the singleton instance.
+// This is primarily useful for testing purposes.
+func ResetOutboxSingleton() {
+	singletonMu.Lock()
+	defer singletonMu.Unlock()
+	outboxSingleton = nil
+}
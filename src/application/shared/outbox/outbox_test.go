@@ -0,0 +1,139 @@
+package outbox
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	dtos "github.com/simon3640/goprojectskeleton/src/application/shared/DTOs"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	repositoriesmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/repositories"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newTestOutbox(repo *repositoriesmocks.MockOutboxTaskRepository, now time.Time) *Outbox {
+	ob := NewOutbox(repo, new(repositoriesmocks.MockUnitOfWork), Options{MaxAttempts: 3, Backoff: time.Second, MaxBackoff: 3 * time.Second, Lease: time.Minute, BatchSize: 10})
+	ob.now = func() time.Time { return now }
+	return ob
+}
+
+func TestOutboxEnqueue(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Now()
+	repo := new(repositoriesmocks.MockOutboxTaskRepository)
+	repo.On("Create", mock.MatchedBy(func(c dtos.OutboxTaskCreate) bool {
+		return c.Name == "send" &&
+			string(c.Payload) == `{"id":1}` &&
+			c.Status == sharedmodels.OutboxTaskStatusPending &&
+			c.MaxAttempts == 3 &&
+			c.NextAttemptAt.Equal(now.Add(time.Minute)) &&
+			len(c.TaskID) == 32 &&
+			c.UserID == 1
+	})).Return(&sharedmodels.OutboxTask{OutboxTaskBase: sharedmodels.OutboxTaskBase{TaskID: "task-1"}}, nil)
+
+	ob := newTestOutbox(repo, now)
+	user := dtomocks.UserWithRole
+	task, err := ob.Enqueue(app_context.NewContextWithUser(&user), locales.EN_US, "send", map[string]int{"id": 1})
+	assert.Nil(err)
+	assert.Equal("task-1", task.TaskID)
+	repo.AssertExpectations(t)
+}
+
+func TestOutboxDeliver(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Now()
+	appCtx := app_context.NewVoidAppContext()
+	task := sharedmodels.OutboxTask{
+		OutboxTaskBase: sharedmodels.OutboxTaskBase{TaskID: "task-1", Name: "outbox-test", Payload: []byte(`"x"`), MaxAttempts: 3},
+		DBBaseModel:    sharedmodels.DBBaseModel{ID: 7},
+	}
+
+	calls := 0
+	var handlerErr error
+	Register("outbox-test", func(_ *app_context.AppContext, _ locales.LocaleTypeEnum, payload []byte) (string, error) {
+		calls++
+		assert.Equal(`"x"`, string(payload))
+		return "done", handlerErr
+	})
+
+	// success
+	repo := new(repositoriesmocks.MockOutboxTaskRepository)
+	repo.On("Update", uint(7), dtos.OutboxTaskUpdate{ID: 7, Attempts: 1, Status: sharedmodels.OutboxTaskStatusSucceeded, Result: "done"}).Return(&task, nil)
+	assert.Equal(sharedmodels.OutboxTaskStatusSucceeded, newTestOutbox(repo, now).Deliver(appCtx, task))
+	repo.AssertExpectations(t)
+
+	// retry with backoff
+	handlerErr = errors.New("smtp down")
+	task.Attempts = 1
+	repo = new(repositoriesmocks.MockOutboxTaskRepository)
+	repo.On("Update", uint(7), dtos.OutboxTaskUpdate{
+		ID:            7,
+		Attempts:      2,
+		Status:        sharedmodels.OutboxTaskStatusPending,
+		LastError:     "smtp down",
+		NextAttemptAt: now.Add(2 * time.Second),
+	}).Return(&task, nil)
+	assert.Equal(sharedmodels.OutboxTaskStatusPending, newTestOutbox(repo, now).Deliver(appCtx, task))
+	repo.AssertExpectations(t)
+
+	// dead letter after MaxAttempts
+	task.Attempts = 2
+	repo = new(repositoriesmocks.MockOutboxTaskRepository)
+	repo.On("Update", uint(7), dtos.OutboxTaskUpdate{ID: 7, Attempts: 3, Status: sharedmodels.OutboxTaskStatusDeadLetter, LastError: "smtp down"}).Return(&task, nil)
+	assert.Equal(sharedmodels.OutboxTaskStatusDeadLetter, newTestOutbox(repo, now).Deliver(appCtx, task))
+	repo.AssertExpectations(t)
+	assert.Equal(3, calls)
+}
+
+func TestOutboxDeliverPanicAndMissingHandler(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Now()
+	appCtx := app_context.NewVoidAppContext()
+	Register("outbox-panic", func(_ *app_context.AppContext, _ locales.LocaleTypeEnum, _ []byte) (string, error) {
+		panic("boom")
+	})
+
+	for _, name := range []string{"outbox-panic", "outbox-missing"} {
+		task := sharedmodels.OutboxTask{
+			OutboxTaskBase: sharedmodels.OutboxTaskBase{TaskID: "task-1", Name: name, MaxAttempts: 1},
+			DBBaseModel:    sharedmodels.DBBaseModel{ID: 1},
+		}
+		repo := new(repositoriesmocks.MockOutboxTaskRepository)
+		repo.On("Update", uint(1), mock.MatchedBy(func(u dtos.OutboxTaskUpdate) bool {
+			return u.Status == sharedmodels.OutboxTaskStatusDeadLetter && u.LastError != ""
+		})).Return(&task, nil)
+		assert.Equal(sharedmodels.OutboxTaskStatusDeadLetter, newTestOutbox(repo, now).Deliver(appCtx, task))
+		repo.AssertExpectations(t)
+	}
+}
+
+func TestOutboxDispatchDue(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Now()
+	delivered := make([]string, 0)
+	Register("outbox-dispatch", func(_ *app_context.AppContext, _ locales.LocaleTypeEnum, payload []byte) (string, error) {
+		delivered = append(delivered, string(payload))
+		return "", nil
+	})
+
+	tasks := []sharedmodels.OutboxTask{
+		{OutboxTaskBase: sharedmodels.OutboxTaskBase{TaskID: "a", Name: "outbox-dispatch", Payload: []byte("1"), MaxAttempts: 3}, DBBaseModel: sharedmodels.DBBaseModel{ID: 1}},
+		{OutboxTaskBase: sharedmodels.OutboxTaskBase{TaskID: "b", Name: "outbox-dispatch", Payload: []byte("2"), MaxAttempts: 3}, DBBaseModel: sharedmodels.DBBaseModel{ID: 2}},
+	}
+	repo := new(repositoriesmocks.MockOutboxTaskRepository)
+	repo.On("ClaimDue", now, 10, time.Minute).Return(tasks, nil)
+	repo.On("Update", mock.Anything, mock.Anything).Return(&tasks[0], nil)
+
+	assert.Equal(2, newTestOutbox(repo, now).DispatchDue(app_context.NewVoidAppContext()))
+	assert.Equal([]string{"1", "2"}, delivered)
+	repo.AssertNumberOfCalls(t, "Update", 2)
+}
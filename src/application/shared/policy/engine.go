@@ -0,0 +1,359 @@
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
+
+// Request is the request to authorize, with the attributes the conditions of
+// the rules are evaluated on
+type Request struct {
+	Action      string
+	Actor       map[string]any
+	Resource    map[string]any
+	Environment map[string]any
+}
+
+// RuleTrace is the evaluation of a rule on a request, shown in explain mode
+type RuleTrace struct {
+	RuleID  string `json:"rule_id"`
+	Effect  Effect `json:"effect"`
+	Matched bool   `json:"matched"`
+	Reason  string `json:"reason"`
+}
+
+// Decision is the outcome of the evaluation of a request.
+// RuleID is the rule that decided, empty when the default effect applied.
+// Trace holds the evaluation of every rule in explain mode.
+type Decision struct {
+	Effect Effect      `json:"effect"`
+	RuleID string      `json:"rule_id,omitempty"`
+	Reason string      `json:"reason"`
+	Trace  []RuleTrace `json:"trace,omitempty"`
+}
+
+// Allowed reports whether the request is granted
+func (d Decision) Allowed() bool {
+	return d.Effect == EffectAllow
+}
+
+// Engine evaluates requests against a policy. Every decision is logged, in
+// explain mode along with the evaluation of every rule.
+type Engine struct {
+	policy  Policy
+	explain bool
+	now     func() time.Time
+}
+
+// NewEngine creates a new Engine evaluating the policy
+func NewEngine(policy Policy, explain bool) *Engine {
+	return &Engine{
+		policy:  policy,
+		explain: explain,
+		now:     time.Now,
+	}
+}
+
+// SetClock replaces the clock the environment attributes are read from
+func (e *Engine) SetClock(now func() time.Time) {
+	e.now = now
+}
+
+// Authorize evaluates the action of the actor on the resource, the resource
+// may be nil for actions without one
+func (e *Engine) Authorize(action string, actor usermodels.UserWithRole, resource any) Decision {
+	return e.Evaluate(Request{
+		Action:      action,
+		Actor:       ActorAttributes(actor),
+		Resource:    ResourceAttributes(resource),
+		Environment: EnvironmentAttributes(e.now()),
+	})
+}
+
+// Evaluate evaluates the request and logs the decision
+func (e *Engine) Evaluate(request Request) Decision {
+	decision := e.evaluate(request, e.explain)
+	logger := observability.GetObservabilityComponents().Logger
+	logger.Info(fmt.Sprintf(
+		"Policy decision %s for action %s of actor %v: %s",
+		decision.Effect, request.Action, request.Actor["id"], decision.Reason,
+	))
+	if e.explain {
+		logger.Debug("Policy decision explained", decision)
+	}
+	return decision
+}
+
+// Explain evaluates the request and returns the decision with the
+// evaluation of every rule, whether the explain mode is on or not
+func (e *Engine) Explain(request Request) Decision {
+	return e.evaluate(request, true)
+}
+
+func (e *Engine) evaluate(request Request, explain bool) Decision {
+	var allowedBy *Rule
+	var trace []RuleTrace
+	for i := range e.policy.Rules {
+		rule := &e.policy.Rules[i]
+		matched, reason := matchRule(*rule, request)
+		if explain {
+			trace = append(trace, RuleTrace{RuleID: rule.ID, Effect: rule.Effect, Matched: matched, Reason: reason})
+		}
+		if !matched {
+			continue
+		}
+		if rule.Effect == EffectDeny {
+			return Decision{
+				Effect: EffectDeny,
+				RuleID: rule.ID,
+				Reason: fmt.Sprintf("denied by rule %s", rule.ID),
+				Trace:  trace,
+			}
+		}
+		if allowedBy == nil {
+			allowedBy = rule
+		}
+	}
+	if allowedBy != nil {
+		return Decision{
+			Effect: EffectAllow,
+			RuleID: allowedBy.ID,
+			Reason: fmt.Sprintf("allowed by rule %s", allowedBy.ID),
+			Trace:  trace,
+		}
+	}
+	return Decision{
+		Effect: e.policy.Default,
+		Reason: "no rule applies, default effect",
+		Trace:  trace,
+	}
+}
+
+// matchRule reports whether the rule applies to the request, with the reason
+// it does not
+func matchRule(rule Rule, request Request) (bool, string) {
+	if !matchAction(rule.Actions, request.Action) {
+		return false, fmt.Sprintf("action %s is not covered", request.Action)
+	}
+	for _, condition := range rule.Conditions {
+		if !matchCondition(condition, request) {
+			actual, _ := attribute(request, condition.Attribute)
+			return false, fmt.Sprintf("condition %s not met, got %v", condition, actual)
+		}
+	}
+	return true, "all conditions met"
+}
+
+func matchAction(actions []string, action string) bool {
+	for _, candidate := range actions {
+		if candidate == actionWildcard || candidate == action {
+			return true
+		}
+		if group, ok := strings.CutSuffix(candidate, actionWildcardGroup); ok &&
+			strings.HasPrefix(action, group+":") {
+			return true
+		}
+	}
+	return false
+}
+
+func matchCondition(condition Condition, request Request) bool {
+	actual, found := attribute(request, condition.Attribute)
+	if condition.Operator == OperatorExists {
+		return found
+	}
+	if !found {
+		return false
+	}
+	expected := condition.Value
+	if condition.ValueFrom != "" {
+		var ok bool
+		if expected, ok = attribute(request, condition.ValueFrom); !ok {
+			return false
+		}
+	}
+
+	switch condition.Operator {
+	case OperatorEq:
+		return equal(actual, expected)
+	case OperatorNe:
+		return !equal(actual, expected)
+	case OperatorIn:
+		return contains(expected, actual)
+	case OperatorNotIn:
+		return !contains(expected, actual)
+	case OperatorContains:
+		return contains(actual, expected)
+	case OperatorGt, OperatorGte, OperatorLt, OperatorLte:
+		return compare(condition.Operator, actual, expected)
+	}
+	return false
+}
+
+// attribute returns the attribute of the request at path
+func attribute(request Request, path string) (any, bool) {
+	if path == actionAttribute {
+		return request.Action, true
+	}
+	root, rest, _ := strings.Cut(path, attributeSeparator)
+	var value any
+	switch root {
+	case "actor":
+		value = request.Actor
+	case "resource":
+		value = request.Resource
+	case "environment":
+		value = request.Environment
+	}
+	for _, key := range strings.Split(rest, attributeSeparator) {
+		attributes, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = attributes[key]; !ok || value == nil {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+func equal(a any, b any) bool {
+	if x, ok := toFloat(a); ok {
+		if y, ok := toFloat(b); ok {
+			return x == y
+		}
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// contains reports whether the collection holds the value, or for strings
+// whether the value is a substring
+func contains(collection any, value any) bool {
+	if text, ok := collection.(string); ok {
+		substring, ok := value.(string)
+		return ok && strings.Contains(text, substring)
+	}
+	items, ok := collection.([]any)
+	if !ok {
+		return false
+	}
+	for _, item := range items {
+		if equal(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+func compare(operator string, a any, b any) bool {
+	x, okX := toFloat(a)
+	y, okY := toFloat(b)
+	if !okX || !okY {
+		return false
+	}
+	switch operator {
+	case OperatorGt:
+		return x > y
+	case OperatorGte:
+		return x >= y
+	case OperatorLt:
+		return x < y
+	case OperatorLte:
+		return x <= y
+	}
+	return false
+}
+
+func toFloat(value any) (float64, bool) {
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return 0, false
+}
+
+// ActorAttributes returns the attributes of the actor: the JSON fields of the
+// user along with its role and permissions
+func ActorAttributes(actor usermodels.UserWithRole) map[string]any {
+	attributes := ResourceAttributes(actor)
+	if attributes == nil {
+		attributes = map[string]any{}
+	}
+	attributes["role"] = actor.GetRoleKey()
+	attributes["permissions"] = toAttribute(actor.GetPermissions())
+	return attributes
+}
+
+// ResourceAttributes returns the JSON fields of the resource as attributes,
+// nil when there is no resource
+func ResourceAttributes(resource any) map[string]any {
+	attributes, _ := toAttribute(resource).(map[string]any)
+	return attributes
+}
+
+// EnvironmentAttributes returns the attributes of the moment of the request:
+// time, date, hour, minute and weekday, 0 for Sunday
+func EnvironmentAttributes(now time.Time) map[string]any {
+	return map[string]any{
+		"time":    now.Format(time.RFC3339),
+		"date":    now.Format(time.DateOnly),
+		"hour":    float64(now.Hour()),
+		"minute":  float64(now.Minute()),
+		"weekday": float64(now.Weekday()),
+	}
+}
+
+// toAttribute converts a value to its JSON representation, so the numbers of
+// the attributes and of the policy compare alike
+func toAttribute(value any) any {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	var attribute any
+	if err := json.Unmarshal(raw, &attribute); err != nil {
+		return nil
+	}
+	return attribute
+}
+
+var (
+	engineSingleton *Engine
+	singletonMu     sync.RWMutex
+)
+
+// InitializeEngine initializes the singleton Engine instance.
+// This should be called during application startup in the infrastructure layer.
+func InitializeEngine(policy Policy, explain bool) *Engine {
+	singletonMu.Lock()
+	defer singletonMu.Unlock()
+	engineSingleton = NewEngine(policy, explain)
+	return engineSingleton
+}
+
+// GetEngine returns the singleton Engine instance, or nil if it was not initialized.
+func GetEngine() *Engine {
+	singletonMu.RLock()
+	defer singletonMu.RUnlock()
+	return engineSingleton
+}
+
+// ResetEngineSingleton resets the singleton instance.
+// This is primarily useful for testing purposes.
+func ResetEngineSingleton() {
+	singletonMu.Lock()
+	defer singletonMu.Unlock()
+	engineSingleton = nil
+}
@@ -0,0 +1,157 @@
+package policy
+
+import (
+	"testing"
+	"time"
+
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testPolicy = `{
+	"rules": [
+		{
+			"id": "managers-update-department",
+			"effect": "allow",
+			"actions": ["user:update"],
+			"conditions": [
+				{"attribute": "actor.role", "operator": "eq", "value": "manager"},
+				{"attribute": "resource.department", "operator": "eq", "valueFrom": "actor.department"}
+			]
+		},
+		{
+			"id": "owners-read-business-hours",
+			"effect": "allow",
+			"actions": ["document:*"],
+			"conditions": [
+				{"attribute": "resource.user_id", "operator": "eq", "valueFrom": "actor.id"},
+				{"attribute": "environment.hour", "operator": "gte", "value": 9},
+				{"attribute": "environment.hour", "operator": "lt", "value": 18},
+				{"attribute": "environment.weekday", "operator": "in", "value": [1, 2, 3, 4, 5]}
+			]
+		},
+		{
+			"id": "suspended-never",
+			"effect": "deny",
+			"actions": ["*"],
+			"conditions": [
+				{"attribute": "actor.status", "operator": "eq", "value": "suspended"}
+			]
+		}
+	]
+}`
+
+func newTestEngine(t *testing.T, explain bool) *Engine {
+	policy, err := ParsePolicy([]byte(testPolicy))
+	assert.NoError(t, err)
+	return NewEngine(*policy, explain)
+}
+
+func TestEngineDepartmentRule(t *testing.T) {
+	assert := assert.New(t)
+	engine := newTestEngine(t, false)
+
+	request := Request{
+		Action:   "user:update",
+		Actor:    map[string]any{"id": float64(1), "role": "manager", "department": "sales"},
+		Resource: map[string]any{"id": float64(2), "department": "sales"},
+	}
+	decision := engine.Evaluate(request)
+	assert.True(decision.Allowed())
+	assert.Equal("managers-update-department", decision.RuleID)
+	assert.Empty(decision.Trace)
+
+	request.Resource["department"] = "support"
+	decision = engine.Evaluate(request)
+	assert.False(decision.Allowed())
+	assert.Empty(decision.RuleID)
+}
+
+func TestEngineEnvironment(t *testing.T) {
+	assert := assert.New(t)
+	engine := newTestEngine(t, false)
+	actor := usermodels.UserWithRole{ID: 3}
+	document := struct {
+		UserID uint `json:"user_id"`
+	}{UserID: 3}
+
+	// Wednesday
+	engine.SetClock(func() time.Time { return time.Date(2026, 10, 14, 10, 0, 0, 0, time.UTC) })
+	assert.True(engine.Authorize("document:read", actor, document).Allowed())
+
+	engine.SetClock(func() time.Time { return time.Date(2026, 10, 14, 20, 0, 0, 0, time.UTC) })
+	assert.False(engine.Authorize("document:read", actor, document).Allowed())
+
+	// Sunday
+	engine.SetClock(func() time.Time { return time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC) })
+	assert.False(engine.Authorize("document:read", actor, document).Allowed())
+}
+
+func TestEngineDenyOverrides(t *testing.T) {
+	assert := assert.New(t)
+	engine := newTestEngine(t, false)
+
+	decision := engine.Evaluate(Request{
+		Action:   "user:update",
+		Actor:    map[string]any{"role": "manager", "department": "sales", "status": "suspended"},
+		Resource: map[string]any{"department": "sales"},
+	})
+	assert.False(decision.Allowed())
+	assert.Equal("suspended-never", decision.RuleID)
+}
+
+func TestEngineExplain(t *testing.T) {
+	assert := assert.New(t)
+	engine := newTestEngine(t, false)
+
+	decision := engine.Explain(Request{
+		Action:   "user:update",
+		Actor:    map[string]any{"role": "user"},
+		Resource: map[string]any{},
+	})
+	assert.False(decision.Allowed())
+	assert.Len(decision.Trace, 3)
+	assert.False(decision.Trace[0].Matched)
+	assert.Contains(decision.Trace[0].Reason, "actor.role eq manager")
+	assert.Contains(decision.Trace[1].Reason, "not covered")
+}
+
+func TestParsePolicyErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	invalid := []string{
+		`{"default": "maybe"}`,
+		`{"rules": [{"effect": "allow", "actions": ["*"]}]}`,
+		`{"rules": [{"id": "a", "effect": "allow", "actions": ["*"]}, {"id": "a", "effect": "deny", "actions": ["*"]}]}`,
+		`{"rules": [{"id": "a", "effect": "grant", "actions": ["*"]}]}`,
+		`{"rules": [{"id": "a", "effect": "allow"}]}`,
+		`{"rules": [{"id": "a", "effect": "allow", "actions": ["*"], "conditions": [{"attribute": "user.id", "operator": "eq", "value": 1}]}]}`,
+		`{"rules": [{"id": "a", "effect": "allow", "actions": ["*"], "conditions": [{"attribute": "actor.id", "operator": "like", "value": 1}]}]}`,
+		`{"rules": [{"id": "a", "effect": "allow", "actions": ["*"], "conditions": [{"attribute": "actor.id", "operator": "eq", "value": 1, "valueFrom": "resource.id"}]}]}`,
+	}
+	for _, raw := range invalid {
+		_, err := ParsePolicy([]byte(raw))
+		assert.Error(err, raw)
+	}
+
+	policy, err := ParsePolicy([]byte(`{}`))
+	assert.NoError(err)
+	assert.Equal(EffectDeny, policy.Default)
+	assert.False(NewEngine(*policy, false).Evaluate(Request{Action: "user:read"}).Allowed())
+}
+
+func TestLoadPolicyFile(t *testing.T) {
+	assert := assert.New(t)
+
+	policy, err := LoadPolicyFile("policy.example.json")
+	assert.Nil(err)
+	assert.Len(policy.Rules, 3)
+
+	_, err = LoadPolicyFile("missing.json")
+	assert.NotNil(err)
+
+	policy, err = LoadPolicyFile("")
+	assert.Nil(err)
+	assert.Empty(policy.Rules)
+}
@@ -0,0 +1,27 @@
+package policy
+
+import (
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+)
+
+// ResourceLoader loads the resource the input of a use case refers to, so the
+// rules can see its attributes
+type ResourceLoader func(input any) (any, *application_errors.ApplicationError)
+
+// Loader adapts a typed lookup, usually through a repository, to a
+// ResourceLoader. Inputs of another type are refused.
+func Loader[I any, R any](load func(input I) (R, *application_errors.ApplicationError)) ResourceLoader {
+	return func(input any) (any, *application_errors.ApplicationError) {
+		typed, ok := input.(I)
+		if !ok {
+			return nil, application_errors.NewApplicationError(
+				status.InvalidInput,
+				messages.MessageKeysInstance.INVALID_DATA,
+				"unexpected input for the resource loader",
+			)
+		}
+		return load(typed)
+	}
+}
@@ -0,0 +1,179 @@
+// Package policy provides an attribute based access control engine.
+//
+// Policies are declarative rules loaded from a JSON file. Each rule grants or
+// denies actions when all its conditions hold on the attributes of the actor,
+// the resource, the action and the environment of the request.
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+)
+
+// Effect is the effect of a rule, or of a decision, on a request
+type Effect string
+
+const (
+	// EffectAllow grants the request
+	EffectAllow Effect = "allow"
+	// EffectDeny refuses the request
+	EffectDeny Effect = "deny"
+)
+
+// Operators of the conditions
+const (
+	OperatorEq       = "eq"
+	OperatorNe       = "ne"
+	OperatorIn       = "in"
+	OperatorNotIn    = "not_in"
+	OperatorContains = "contains"
+	OperatorGt       = "gt"
+	OperatorGte      = "gte"
+	OperatorLt       = "lt"
+	OperatorLte      = "lte"
+	OperatorExists   = "exists"
+)
+
+const (
+	attributeSeparator  = "."
+	actionAttribute     = "action"
+	actionWildcard      = "*"
+	actionWildcardGroup = ":*"
+)
+
+var operators = []string{
+	OperatorEq, OperatorNe, OperatorIn, OperatorNotIn, OperatorContains,
+	OperatorGt, OperatorGte, OperatorLt, OperatorLte, OperatorExists,
+}
+
+var attributeRoots = []string{"actor", "resource", "environment"}
+
+// Condition compares an attribute of the request with a literal value or with
+// another attribute of the request.
+// Attributes are paths like "actor.role", "resource.id" or "environment.hour".
+type Condition struct {
+	Attribute string `json:"attribute"`
+	Operator  string `json:"operator"`
+	Value     any    `json:"value,omitempty"`
+	ValueFrom string `json:"valueFrom,omitempty"`
+}
+
+// String returns the condition as written in the policy
+func (c Condition) String() string {
+	if c.ValueFrom != "" {
+		return fmt.Sprintf("%s %s %s", c.Attribute, c.Operator, c.ValueFrom)
+	}
+	if c.Operator == OperatorExists {
+		return fmt.Sprintf("%s %s", c.Attribute, c.Operator)
+	}
+	return fmt.Sprintf("%s %s %v", c.Attribute, c.Operator, c.Value)
+}
+
+// Rule grants or denies the actions when all its conditions hold.
+// Actions are exact, "*" for every action or "user:*" for every action of a
+// group.
+type Rule struct {
+	ID          string      `json:"id"`
+	Description string      `json:"description"`
+	Effect      Effect      `json:"effect"`
+	Actions     []string    `json:"actions"`
+	Conditions  []Condition `json:"conditions"`
+}
+
+// Policy is a set of rules. A rule denying a request overrides the rules
+// allowing it, requests no rule applies to get the default effect, deny when
+// it is not set.
+type Policy struct {
+	Default Effect `json:"default"`
+	Rules   []Rule `json:"rules"`
+}
+
+// ParsePolicy parses and validates a policy written in JSON
+func ParsePolicy(raw []byte) (*Policy, error) {
+	var policy Policy
+	if err := json.Unmarshal(raw, &policy); err != nil {
+		return nil, fmt.Errorf("invalid policy: %w", err)
+	}
+	if policy.Default == "" {
+		policy.Default = EffectDeny
+	}
+	if policy.Default != EffectAllow && policy.Default != EffectDeny {
+		return nil, fmt.Errorf("invalid policy default effect %q", policy.Default)
+	}
+
+	seen := make(map[string]bool, len(policy.Rules))
+	for _, rule := range policy.Rules {
+		if rule.ID == "" || seen[rule.ID] {
+			return nil, fmt.Errorf("policy rules need a unique id, got %q", rule.ID)
+		}
+		seen[rule.ID] = true
+		if err := validateRule(rule); err != nil {
+			return nil, fmt.Errorf("policy rule %q: %w", rule.ID, err)
+		}
+	}
+	return &policy, nil
+}
+
+func validateRule(rule Rule) error {
+	if rule.Effect != EffectAllow && rule.Effect != EffectDeny {
+		return fmt.Errorf("invalid effect %q", rule.Effect)
+	}
+	if len(rule.Actions) == 0 {
+		return fmt.Errorf("at least one action is required")
+	}
+	for _, condition := range rule.Conditions {
+		if !isAttribute(condition.Attribute) {
+			return fmt.Errorf("invalid attribute %q", condition.Attribute)
+		}
+		if !slices.Contains(operators, condition.Operator) {
+			return fmt.Errorf("invalid operator %q", condition.Operator)
+		}
+		if condition.ValueFrom != "" && !isAttribute(condition.ValueFrom) {
+			return fmt.Errorf("invalid attribute %q", condition.ValueFrom)
+		}
+		if condition.ValueFrom != "" && condition.Value != nil {
+			return fmt.Errorf("condition %q has both a value and a valueFrom", condition.Attribute)
+		}
+	}
+	return nil
+}
+
+func isAttribute(path string) bool {
+	if path == actionAttribute {
+		return true
+	}
+	root, rest, found := strings.Cut(path, attributeSeparator)
+	return found && rest != "" && slices.Contains(attributeRoots, root)
+}
+
+// LoadPolicyFile loads the policy of the file at path, see ParsePolicy.
+// An empty path returns a policy without rules, which denies every request.
+func LoadPolicyFile(path string) (*Policy, *application_errors.ApplicationError) {
+	if path == "" {
+		return &Policy{Default: EffectDeny}, nil
+	}
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, application_errors.NewApplicationError(
+			status.ApplicationInitializationError,
+			messages.MessageKeysInstance.SOMETHING_WENT_WRONG,
+			err.Error(),
+		)
+	}
+	policy, err := ParsePolicy(raw)
+	if err != nil {
+		return nil, application_errors.NewApplicationError(
+			status.ApplicationInitializationError,
+			messages.MessageKeysInstance.SOMETHING_WENT_WRONG,
+			err.Error(),
+		)
+	}
+	return policy, nil
+}
@@ -0,0 +1,15 @@
+package email_service
+
+import (
+	email_models "github.com/simon3640/goprojectskeleton/src/application/shared/services/emails/models"
+)
+
+type AccountLockedEmailService struct {
+	EmailServiceBase[email_models.AccountLockedEmailData]
+}
+
+var AccountLockedEmailServiceInstance *AccountLockedEmailService
+
+func init() {
+	AccountLockedEmailServiceInstance = &AccountLockedEmailService{}
+}
@@ -0,0 +1,9 @@
+package email_models
+
+type AccountLockedEmailData struct {
+	Name              string
+	UnlockLink        string
+	ExpirationMinutes int64
+	AppName           string
+	SupportEmail      string
+}
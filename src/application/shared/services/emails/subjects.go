@@ -8,24 +8,28 @@ type SubjectKeys struct {
 	WelcomeEmail       SubjectKeysEnum
 	PasswordResetEmail SubjectKeysEnum
 	OTPEmail           SubjectKeysEnum
+	AccountLockedEmail SubjectKeysEnum
 }
 
 var SubjectKeysInstance = SubjectKeys{
 	WelcomeEmail:       "WELCOME_EMAIL",
 	PasswordResetEmail: "PASSWORD_RESET_EMAIL",
 	OTPEmail:           "OTP_EMAIL",
+	AccountLockedEmail: "ACCOUNT_LOCKED_EMAIL",
 }
 
 var EnSubjects = map[SubjectKeysEnum]string{
 	SubjectKeysInstance.WelcomeEmail:       "Welcome to our App!",
 	SubjectKeysInstance.PasswordResetEmail: "Password Reset Instructions",
 	SubjectKeysInstance.OTPEmail:           "Your One-Time Password (OTP)",
+	SubjectKeysInstance.AccountLockedEmail: "Your account was temporarily locked",
 }
 
 var EsSubjects = map[SubjectKeysEnum]string{
 	SubjectKeysInstance.WelcomeEmail:       "¡Bienvenido a nuestra aplicación!",
 	SubjectKeysInstance.PasswordResetEmail: "Instrucciones para restablecer la contraseña",
 	SubjectKeysInstance.OTPEmail:           "Su contraseña de un solo uso (OTP)",
+	SubjectKeysInstance.AccountLockedEmail: "Su cuenta fue bloqueada temporalmente",
 }
 
 type Subjects struct {
@@ -0,0 +1,84 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	contractsProviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	contracts_repositories "github.com/simon3640/goprojectskeleton/src/application/contracts/repositories"
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/settings"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+)
+
+// PermissionService resolves the effective permissions of the users. The
+// permissions of the roles are read on every authenticated request, so they
+// are cached per role, the overrides of the users are not.
+type PermissionService struct {
+	permissionRepository contracts_repositories.IPermissionRepository
+	cacheProvider        contractsProviders.ICacheProvider
+}
+
+// RolePermissionsKey is the cache key of the permissions of a role
+func RolePermissionsKey(roleID uint) string {
+	return fmt.Sprintf("role_permissions:%d", roleID)
+}
+
+// SetUserPermissions sets the permissions of the role of the user, with the
+// permissions granted or revoked to the user applied
+func (s *PermissionService) SetUserPermissions(user *usermodels.UserWithRole) *application_errors.ApplicationError {
+	rolePermissions, err := s.GetRolePermissions(user.RoleID)
+	if err != nil {
+		return err
+	}
+	overrides, err := s.permissionRepository.GetUserPermissions(user.ID)
+	if err != nil {
+		return err
+	}
+	user.SetPermissions(usermodels.EffectivePermissions(rolePermissions, overrides))
+	return nil
+}
+
+// GetRolePermissions returns the permissions of a role from the cache, or
+// from the repository when they are not cached. Cache errors fall back to
+// the repository.
+func (s *PermissionService) GetRolePermissions(roleID uint) ([]string, *application_errors.ApplicationError) {
+	key := RolePermissionsKey(roleID)
+	if s.cacheProvider != nil {
+		var permissions []string
+		if found, err := s.cacheProvider.Get(key, &permissions); err == nil && found {
+			return permissions, nil
+		}
+	}
+
+	permissions, err := s.permissionRepository.GetRolePermissions(roleID)
+	if err != nil {
+		return nil, err
+	}
+	if s.cacheProvider != nil {
+		ttl := time.Duration(settings.AppSettingsInstance.RedisTTL) * time.Second
+		_ = s.cacheProvider.Set(key, permissions, ttl)
+	}
+	return permissions, nil
+}
+
+// InvalidateRole removes the cached permissions of a role, it must be called
+// when the permissions of the role change
+func (s *PermissionService) InvalidateRole(roleID uint) *application_errors.ApplicationError {
+	if s.cacheProvider == nil {
+		return nil
+	}
+	return s.cacheProvider.Delete(RolePermissionsKey(roleID))
+}
+
+// NewPermissionService creates a new permission service, the cache provider
+// is optional
+func NewPermissionService(
+	permissionRepository contracts_repositories.IPermissionRepository,
+	cacheProvider contractsProviders.ICacheProvider,
+) *PermissionService {
+	return &PermissionService{
+		permissionRepository: permissionRepository,
+		cacheProvider:        cacheProvider,
+	}
+}
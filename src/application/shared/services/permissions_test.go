@@ -0,0 +1,73 @@
+package services
+
+import (
+	"testing"
+
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
+	repositoriesmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/repositories"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPermissionService_SetUserPermissions(t *testing.T) {
+	assert := assert.New(t)
+
+	permissionRepository := new(repositoriesmocks.MockPermissionRepository)
+	service := NewPermissionService(permissionRepository, providersmocks.NewMemoryCacheProvider())
+
+	permissionRepository.On("GetRolePermissions", uint(2)).Return([]string{usermodels.PermissionUserRead, usermodels.PermissionUserDelete}, nil)
+	permissionRepository.On("GetUserPermissions", uint(1)).Return([]usermodels.UserPermission{
+		{UserID: 1, PermissionKey: usermodels.PermissionUserList, Granted: true},
+		{UserID: 1, PermissionKey: usermodels.PermissionUserDelete, Granted: false},
+	}, nil)
+
+	user := usermodels.UserWithRole{UserBase: usermodels.UserBase{RoleID: 2}, ID: 1}
+	err := service.SetUserPermissions(&user)
+
+	assert.Nil(err)
+	assert.ElementsMatch([]string{usermodels.PermissionUserRead, usermodels.PermissionUserList}, user.GetPermissions())
+	assert.True(user.HasPermission(usermodels.PermissionUserList))
+	assert.False(user.HasPermission(usermodels.PermissionUserDelete))
+}
+
+func TestPermissionService_CachesRolePermissions(t *testing.T) {
+	assert := assert.New(t)
+
+	permissionRepository := new(repositoriesmocks.MockPermissionRepository)
+	cacheProvider := providersmocks.NewMemoryCacheProvider()
+	service := NewPermissionService(permissionRepository, cacheProvider)
+
+	permissionRepository.On("GetRolePermissions", uint(2)).Return([]string{usermodels.PermissionUserRead}, nil)
+
+	for range 3 {
+		permissions, err := service.GetRolePermissions(2)
+		assert.Nil(err)
+		assert.Equal([]string{usermodels.PermissionUserRead}, permissions)
+	}
+	permissionRepository.AssertNumberOfCalls(t, "GetRolePermissions", 1)
+
+	// Changed permissions are read again once the role is invalidated
+	assert.Nil(service.InvalidateRole(2))
+	_, _ = service.GetRolePermissions(2)
+	permissionRepository.AssertNumberOfCalls(t, "GetRolePermissions", 2)
+}
+
+func TestPermissionService_RepositoryError(t *testing.T) {
+	assert := assert.New(t)
+
+	permissionRepository := new(repositoriesmocks.MockPermissionRepository)
+	service := NewPermissionService(permissionRepository, nil)
+
+	appErr := application_errors.NewApplicationError(status.InternalError, messages.MessageKeysInstance.SOMETHING_WENT_WRONG, "database error")
+	permissionRepository.On("GetRolePermissions", uint(2)).Return(nil, appErr)
+
+	user := usermodels.UserWithRole{UserBase: usermodels.UserBase{RoleID: 2}, ID: 1}
+	err := service.SetUserPermissions(&user)
+
+	assert.Equal(appErr, err)
+	assert.Empty(user.GetPermissions())
+}
@@ -0,0 +1,22 @@
+package services
+
+import (
+	contractsProviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+)
+
+// RevokeUserTokensService revokes every token issued to the user until now.
+// The change that requires it already took effect, so errors are only logged.
+func RevokeUserTokensService(
+	ctx *app_context.AppContext,
+	userID uint,
+	revocationProvider contractsProviders.ITokenRevocationProvider,
+) {
+	if revocationProvider == nil {
+		return
+	}
+	if err := revocationProvider.RevokeUserTokens(userID); err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error revoking user tokens", err.ToError(), ctx)
+	}
+}
@@ -39,6 +39,9 @@ type AppSettings struct {
 	RedisDB       int
 	RedisTTL      int // in seconds
 
+	// Idempotency
+	IdempotencyTTL int64 // in seconds
+
 	// Security
 	JWTSecretKey               string
 	JWTIssuer                  string
@@ -46,14 +49,38 @@ type AppSettings struct {
 	JWTAccessTTL               int64 // in seconds
 	JWTRefreshTTL              int64 // in seconds
 	JWTClockSkew               int64 // in seconds
-	OneTimeTokenPasswordTTL    int64 // in minutes
-	OneTimeTokenEmailVerifyTTL int64 // in minutes
+	JWTAlgorithm               string
+	JWTSigningKeys             string // JSON array of signing keys, see providers.ParseJWTKeys
+	JWTKeyGracePeriod          int64  // in seconds
+	OneTimeTokenPasswordTTL    int64  // in minutes
+	OneTimeTokenEmailVerifyTTL int64  // in minutes
 	FrontendResetPasswordURL   string
 	FrontendActivateAccountURL string
-	OneTimePasswordTTL         int64 // in minutes
-	OneTimePasswordLength      int   // length of the generated one-time password
-	LoginMaxAttempts           int   // maximum number of failed login attempts
-	LoginAttemptsWindowMinutes int64 // time window in minutes for counting failed attempts
+	FrontendUnlockAccountURL   string
+	OneTimePasswordTTL         int64    // in minutes
+	OneTimePasswordLength      int      // length of the generated one-time password
+	OneTimePasswordMaxAttempts int      // maximum number of invalid codes per OTP login transaction
+	LoginMaxAttempts           int      // maximum number of failed login attempts of an IP on an account
+	LoginAttemptsWindowMinutes int64    // time window in minutes for counting failed attempts
+	LoginDelayAfterAttempts    int      // failed attempts of an IP on an account after which the next attempt must wait
+	LoginDelaySeconds          int64    // in seconds, wait after the first delayed attempt, doubled on every failure
+	LoginMaxAttemptsPerIP      int      // maximum number of failed login attempts of an IP on any account
+	LoginMaxAttemptsPerAccount int      // maximum number of failed login attempts on an account before it is locked
+	LoginGlobalFailureLimit    int      // failed logins per minute above which credential stuffing is assumed
+	TrustedProxies             []string // addresses or CIDR ranges of the proxies whose X-Forwarded-For is trusted
+	EncryptionKey              string   // secret the key encrypting the secrets at rest is derived from
+	TOTPIssuer                 string   // name of the application in authenticator apps
+	WebAuthnRPID               string   // domain the passkeys are scoped to
+	WebAuthnRPName             string   // name of the application shown by authenticators
+	WebAuthnRPOrigins          []string // origins allowed to perform passkey ceremonies
+	WebAuthnChallengeTTL       int64    // in seconds
+	OIDCProviders              string   // JSON array of identity providers, see providers.ParseOIDCProviders
+	OIDCStateTTL               int64    // in seconds
+	OIDCDefaultRoleID          int      // role of the users created on their first login with an identity provider
+	OAuthCodeTTL               int64    // in seconds, lifetime of the authorization codes issued to OAuth clients
+	ImpersonationTTL           int64    // in seconds, lifetime of the access tokens of the impersonations
+	PolicyFile                 string   // path of the JSON policy of the policy guards, see policy.ParsePolicy
+	PolicyExplain              bool     // log the evaluation of every policy rule along with the decisions
 
 	// Mail
 	MailHost         string
@@ -66,6 +93,13 @@ type AppSettings struct {
 	BackgroundWorkers   int
 	BackgroundQueueSize int
 
+	// Outbox
+	OutboxMaxAttempts    int
+	OutboxPollInterval   int64 // in seconds
+	OutboxBatchSize      int
+	OutboxLeaseSeconds   int64 // in seconds
+	OutboxBackoffSeconds int64 // in seconds
+
 	// Observability
 	ObservabilityEnabled      bool
 	ObservabilityBackend      string
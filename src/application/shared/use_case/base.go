@@ -6,6 +6,7 @@ import (
 
 	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
 )
 
@@ -59,7 +60,8 @@ func (v *BaseUseCaseValidation[Input, Output]) SetLocale(locale locales.LocaleTy
 
 // Validate validates the input for the use case
 // - If the input has the method Validate then call it
-// - If the guards are empty then return
+// - If the guards are empty then return, OAuth clients acting for themselves are rejected
+// - If the guards are not empty then reject the requests without user
 // - If the guards are not empty then set the actor and validate the input
 // - If the input is invalid then set the error and return
 // - If the input is valid then return
@@ -78,7 +80,26 @@ func (v *BaseUseCaseValidation[Input, Output]) Validate(
 			return
 		}
 	}
-	if len(v.Guards.list) == 0 {
+	if v.Guards.IsEmpty() {
+		if v.AppContext != nil && v.AppContext.User != nil && v.AppContext.User.IsClient() {
+			result.SetError(
+				status.Unauthorized,
+				v.AppMessages.Get(
+					v.Locale,
+					messages.MessageKeysInstance.INSUFFICIENT_SCOPE,
+				),
+			)
+		}
+		return
+	}
+	if v.AppContext == nil || v.AppContext.User == nil {
+		result.SetError(
+			status.Unauthorized,
+			v.AppMessages.Get(
+				v.Locale,
+				messages.MessageKeysInstance.UNAUTHORIZED_RESOURCE,
+			),
+		)
 		return
 	}
 	v.Guards.SetActor(*v.AppContext.User)
@@ -93,3 +114,22 @@ func (v *BaseUseCaseValidation[Input, Output]) Validate(
 		return
 	}
 }
+
+var _ Authorizer = (*BaseUseCaseValidation[any, any])(nil)
+
+// Authorize validates the input of the invocation against the use case, its
+// guards included, see Authorization
+func (v *BaseUseCaseValidation[Input, Output]) Authorize(inv *Invocation) Result {
+	input, ok := inv.Input.(Input)
+	if !ok {
+		return nil
+	}
+	result := NewUseCaseResult[Output]()
+	v.SetLocale(inv.Locale)
+	v.SetAppContext(inv.Context)
+	v.Validate(input, result)
+	if result.HasError() {
+		return result
+	}
+	return nil
+}
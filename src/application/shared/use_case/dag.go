@@ -11,15 +11,21 @@ import (
 	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/outbox"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/workers"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
 )
 
 // DagStep represents a single executable step in a DAG.
 // It is a thin wrapper around a BaseUseCase and defines the
 // input and output types for that step.
+// A step may carry an optional compensation (see WithCompensation)
+// and an execution policy (see WithPolicy).
 type DagStep[I any, O any] struct {
-	uc BaseUseCase[I, O]
+	uc           BaseUseCase[I, O]
+	compensation *compensationEntry[O]
+	policy       *StepPolicy
 }
 
 // NewStep creates a DagStep from the given use case.
@@ -27,6 +33,22 @@ type DagStep[I any, O any] struct {
 // across multiple DAGs.
 func NewStep[I any, O any](uc BaseUseCase[I, O]) DagStep[I, O] { return DagStep[I, O]{uc: uc} }
 
+// execute runs the step applying its policy and, on success, registers its
+// compensation in the compensation stack of the current DAG execution.
+func (s DagStep[I, O]) execute(
+	ctx *app_context.AppContext,
+	stack *compensationStack,
+	name string,
+	locale locales.LocaleTypeEnum,
+	input I,
+) *UseCaseResult[O] {
+	res := executeWithPolicy(ctx, s, name, locale, input)
+	if res != nil && !res.HasError() && res.Data != nil {
+		registerCompensation(stack, s.compensation, locale, *res.Data)
+	}
+	return res
+}
+
 // backgroundEntry represents a background task attached to a DAG node.
 // Each entry captures a function to execute and an optional name
 // used for logging or tracing purposes.
@@ -37,18 +59,23 @@ type backgroundEntry[O any] struct {
 
 // DAG represents an immutable, typed directed acyclic graph of use cases.
 //
-// A DAG is built by chaining steps using Then and ThenBackground.
+// A DAG is built by chaining steps using Then and ThenBackground,
+// can fan out into concurrent branches using Parallel and Join, and can
+// be routed on the output of a step using When and Switch.
 // Each builder function returns a NEW DAG instance, making DAGs
 // safe to reuse and share across goroutines.
 //
 // The DAG executes synchronously by default, with optional background
 // tasks that run asynchronously after successful execution.
+// Its structure can be inspected without executing it (see Describe).
 type DAG[I any, O any] struct {
-	run         func(appContext *app_context.AppContext, input I) *UseCaseResult[O]
+	run         func(appContext *app_context.AppContext, stack *compensationStack, input I) *UseCaseResult[O]
 	ctx         *app_context.AppContext
 	locale      locales.LocaleTypeEnum
 	_background []backgroundEntry[O] // internal; treat as immutable — copy on write
+	_durable    []durableEntry[O]    // internal; treat as immutable — copy on write
 	executor    *workers.BackgroundExecutor
+	graph       *dagGraph
 }
 
 // NewDag creates a new DAG starting with the provided initial step.
@@ -64,8 +91,8 @@ type DAG[I any, O any] struct {
 //   - locale: Locale passed to all use cases during execution.
 //   - executor: Optional executor used to run background tasks asynchronously.
 func NewDag[I any, O any](ctx *app_context.AppContext, first DagStep[I, O], locale locales.LocaleTypeEnum, executor *workers.BackgroundExecutor) *DAG[I, O] {
-	run := func(appContext *app_context.AppContext, input I) *UseCaseResult[O] {
-		return first.uc.Execute(appContext, locale, input)
+	run := func(appContext *app_context.AppContext, stack *compensationStack, input I) *UseCaseResult[O] {
+		return first.execute(appContext, stack, "", locale, input)
 	}
 	return &DAG[I, O]{
 		run:         run,
@@ -73,6 +100,7 @@ func NewDag[I any, O any](ctx *app_context.AppContext, first DagStep[I, O], loca
 		locale:      locale,
 		_background: nil,
 		executor:    executor,
+		graph:       newDagGraph(first.describe("", DagNodeStep)),
 	}
 }
 
@@ -85,6 +113,10 @@ func NewDag[I any, O any](ctx *app_context.AppContext, first DagStep[I, O], loca
 //     step is not executed.
 //   - On success, the output of the previous step becomes the input
 //     of the next step.
+//   - The next step is retried, bounded by a timeout or short-circuited
+//     according to its policy (see WithPolicy).
+//   - If a step fails, the compensations of the steps that already
+//     succeeded are executed in reverse order (see WithCompensation).
 //
 // Note:
 //   - Background steps are not carried over when the output type changes,
@@ -94,7 +126,7 @@ func Then[I any, O any, P any](d *DAG[I, O], next DagStep[O, P], name string) *D
 	prevBackground := make([]backgroundEntry[P], 0)
 	// intentionally empty — background entries from previous output type cannot be reused
 
-	run := func(ctx *app_context.AppContext, input I) *UseCaseResult[P] {
+	run := func(ctx *app_context.AppContext, stack *compensationStack, input I) *UseCaseResult[P] {
 		tracer := observability.GetObservabilityComponents().Tracer
 		clock := observability.GetObservabilityComponents().Clock
 		metrics := observability.GetObservabilityComponents().Metrics
@@ -104,7 +136,7 @@ func Then[I any, O any, P any](d *DAG[I, O], next DagStep[O, P], name string) *D
 		span.UpdateAppContext(ctx)
 
 		start := clock.Now()
-		r1 := d.run(ctx, input)
+		r1 := d.run(ctx, stack, input)
 		duration := clock.Now().Sub(start)
 
 		tags := map[string]string{
@@ -123,7 +155,7 @@ func Then[I any, O any, P any](d *DAG[I, O], next DagStep[O, P], name string) *D
 				Details:    r1.Details,
 			}
 		}
-		return next.uc.Execute(ctx, d.locale, *r1.Data)
+		return next.execute(ctx, stack, name, d.locale, *r1.Data)
 	}
 	return &DAG[I, P]{
 		run:         run,
@@ -131,6 +163,7 @@ func Then[I any, O any, P any](d *DAG[I, O], next DagStep[O, P], name string) *D
 		locale:      d.locale,
 		_background: prevBackground,
 		executor:    d.executor,
+		graph:       d.graph.then(next.describe(name, DagNodeStep)),
 	}
 }
 
@@ -142,6 +175,7 @@ func Then[I any, O any, P any](d *DAG[I, O], next DagStep[O, P], name string) *D
 //   - Do not affect the main DAG result.
 //   - Are executed asynchronously using the DAG's BackgroundExecutor,
 //     or synchronously if no executor is configured.
+//   - Are executed with the policy of the step (see WithPolicy).
 //
 // The returned DAG:
 //   - Preserves immutability by copying existing background steps.
@@ -153,6 +187,28 @@ func Then[I any, O any, P any](d *DAG[I, O], next DagStep[O, P], name string) *D
 //   - Use background steps only for short, non-critical tasks.
 //     For long-running or critical work, prefer queues or message brokers.
 func ThenBackground[I any, O any, P any](d *DAG[I, O], next DagStep[O, P], name string) *DAG[I, O] {
+	entr := newBackgroundEntry(d, next, name)
+
+	// copy existing background slice to keep immutability
+	newBg := make([]backgroundEntry[O], len(d._background), len(d._background)+1)
+	copy(newBg, d._background)
+	newBg = append(newBg, entr)
+
+	// return new DAG with copied background list
+	return &DAG[I, O]{
+		run:         d.run,
+		ctx:         d.ctx,
+		locale:      d.locale,
+		_background: newBg,
+		_durable:    d._durable,
+		executor:    d.executor,
+		graph:       d.graph.attach(next.describe(name, DagNodeBackground)),
+	}
+}
+
+// newBackgroundEntry creates the instrumented background entry that executes
+// next with the output of the DAG, applying its policy, and ignores its result.
+func newBackgroundEntry[I any, O any, P any](d *DAG[I, O], next DagStep[O, P], name string) backgroundEntry[O] {
 	// We create a wrapper that executes 'next' but ignores its output/result. Any errors are logged.
 	// Capture observability components for background task
 	tracer := observability.GetObservabilityComponents().Tracer
@@ -160,7 +216,7 @@ func ThenBackground[I any, O any, P any](d *DAG[I, O], next DagStep[O, P], name
 	clock := observability.GetObservabilityComponents().Clock
 	parentTraceCtx := d.ctx.TraceContext()
 
-	entr := backgroundEntry[O]{
+	return backgroundEntry[O]{
 		name: name,
 		fn: func(ctx *app_context.AppContext, out O) {
 			// Always instrument background task (observability is always enabled)
@@ -175,7 +231,7 @@ func ThenBackground[I any, O any, P any](d *DAG[I, O], next DagStep[O, P], name
 			span.UpdateAppContext(ctx)
 
 			start := clock.Now()
-			res := next.uc.Execute(ctx, d.locale, out)
+			res := executeWithPolicy(ctx, next, name, d.locale, out)
 			duration := clock.Now().Sub(start)
 
 			tags := map[string]string{
@@ -200,20 +256,6 @@ func ThenBackground[I any, O any, P any](d *DAG[I, O], next DagStep[O, P], name
 			}
 		},
 	}
-
-	// copy existing background slice to keep immutability
-	newBg := make([]backgroundEntry[O], len(d._background), len(d._background)+1)
-	copy(newBg, d._background)
-	newBg = append(newBg, entr)
-
-	// return new DAG with copied background list
-	return &DAG[I, O]{
-		run:         d.run,
-		ctx:         d.ctx,
-		locale:      d.locale,
-		_background: newBg,
-		executor:    d.executor,
-	}
 }
 
 // Execute runs the DAG synchronously and schedules any attached background
@@ -221,8 +263,14 @@ func ThenBackground[I any, O any, P any](d *DAG[I, O], next DagStep[O, P], name
 //
 // Execution flow:
 //   - The DAG is executed synchronously via the configured run function.
+//   - If execution returns nil or an error, the compensations of the steps
+//     that succeeded are executed in reverse order and reported in the
+//     result details.
 //   - If execution returns nil, an error, or no data, background tasks are
 //     not scheduled.
+//   - If execution succeeds and durable steps are present, each durable task
+//     is persisted in the outbox before being scheduled (see ThenDurable).
+//     If a task cannot be persisted the execution fails and is compensated.
 //   - If execution succeeds and background steps are present, each background
 //     task is scheduled for asynchronous execution.
 //
@@ -260,8 +308,16 @@ func (d *DAG[I, O]) Execute(input I) *UseCaseResult[O] {
 	defer span.End()
 	span.UpdateAppContext(d.ctx)
 
+	stack := newCompensationStack()
 	start := clock.Now()
-	res := d.run(d.ctx, input)
+	var res *UseCaseResult[O]
+	var tasks []*sharedmodels.OutboxTask
+	if ob := outbox.GetOutbox(); ob != nil && len(d._durable) > 0 {
+		// the durable tasks are persisted with the writes of the steps
+		res, tasks = d.runDurable(ob, stack, input)
+	} else {
+		res = d.run(d.ctx, stack, input)
+	}
 	duration := clock.Now().Sub(start)
 
 	tags := map[string]string{}
@@ -282,21 +338,35 @@ func (d *DAG[I, O]) Execute(input I) *UseCaseResult[O] {
 		tags["status"] = "error"
 		metrics.IncrementCounter("dag.error", tags)
 	}
+	if res == nil || res.HasError() {
+		// roll back the side effects of the steps that already succeeded
+		if reports := stack.unwind(d.ctx); res != nil && len(reports) > 0 {
+			res.SetDetails(formatCompensationDetails(res.Details, reports))
+		}
+	}
 	if res == nil {
 		return nil
 	}
 	if res.HasError() || res.Data == nil {
 		return res
 	}
-	// schedule background tasks
-	if len(d._background) == 0 {
-		return res
+	// schedule durable and background tasks
+	if len(d._durable) > 0 {
+		d.scheduleDurable(res, tasks)
 	}
+	d.scheduleBackground(d._background, *res.Data)
+	return res
+}
 
-	out := *res.Data
+// scheduleBackground schedules the given background entries with the
+// output of a successful execution.
+func (d *DAG[I, O]) scheduleBackground(entries []backgroundEntry[O], out O) {
+	if len(entries) == 0 {
+		return
+	}
 	// submit tasks to executor if present
 	if d.executor != nil {
-		for _, be := range d._background {
+		for _, be := range entries {
 			// capture be locally and the AppContext
 			entry := be
 			appCtx := d.ctx // Capture the AppContext to use in background task
@@ -325,11 +395,11 @@ func (d *DAG[I, O]) Execute(input I) *UseCaseResult[O] {
 				}(be, out)
 			}
 		}
-		return res
+		return
 	}
 
 	// no executor — spawn goroutines per task but respecting ctx
-	for _, be := range d._background {
+	for _, be := range entries {
 		entry := be
 		go func(entry backgroundEntry[O], o O, ctx *app_context.AppContext) {
 			defer func() {
@@ -347,7 +417,6 @@ func (d *DAG[I, O]) Execute(input I) *UseCaseResult[O] {
 			entry.fn(ctx, o)
 		}(entry, out, d.ctx)
 	}
-	return res
 }
 
 // ExecuteWithBackground executes the DAG synchronously and then runs any
@@ -390,7 +459,7 @@ func (d *DAG[I, O]) Execute(input I) *UseCaseResult[O] {
 //     task execution does not modify the returned result.
 func (d *DAG[I, O]) ExecuteWithBackground(input I, waitTimeout time.Duration) *UseCaseResult[O] {
 	res := d.Execute(input)
-	if res == nil || res.HasError() || res.Data == nil || len(d._background) == 0 {
+	if res == nil || res.HasError() || res.Data == nil || len(d._background)+len(d._durable) == 0 {
 		return res
 	}
 	// if executor present, wait for its queued items to drain. Caller can pass a timeout.
@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	contractsobservability "github.com/simon3640/goprojectskeleton/src/application/contracts/observability"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+)
+
+// compensationEntry is the compensation attached to a DagStep.
+// It receives the output of the step it compensates.
+type compensationEntry[O any] struct {
+	name string
+	fn   func(ctx *app_context.AppContext, locale locales.LocaleTypeEnum, out O) (bool, string)
+}
+
+// WithCompensation returns a copy of the step with a compensation use case
+// attached to it.
+//
+// The compensation receives the output of the step and is executed only if
+// the step succeeded and a later step of the same DAG failed. Compensations
+// run in reverse order of completion (saga rollback).
+//
+// Parameters:
+//   - step: The step to compensate.
+//   - compensation: Use case that undoes the side effects of the step.
+//   - name: Name of the compensation, used for tracing, metrics and reporting.
+func WithCompensation[I any, O any, C any](step DagStep[I, O], compensation BaseUseCase[O, C], name string) DagStep[I, O] {
+	step.compensation = &compensationEntry[O]{
+		name: name,
+		fn: func(ctx *app_context.AppContext, locale locales.LocaleTypeEnum, out O) (bool, string) {
+			res := compensation.Execute(ctx, locale, out)
+			if res == nil {
+				return false, "nil result"
+			}
+			if res.HasError() {
+				return false, *res.Error
+			}
+			return true, ""
+		},
+	}
+	return step
+}
+
+// CompensationReport describes the outcome of a compensation executed
+// after a failed DAG execution.
+type CompensationReport struct {
+	Name    string
+	Success bool
+	Error   string
+}
+
+// pendingCompensation is a compensation bound to the output of a completed step.
+type pendingCompensation struct {
+	name string
+	fn   func(ctx *app_context.AppContext) (bool, string)
+}
+
+// compensationStack records the compensations of the completed steps of a
+// single DAG execution. It is safe for concurrent use by parallel branches.
+type compensationStack struct {
+	mu      sync.Mutex
+	entries []pendingCompensation
+}
+
+func newCompensationStack() *compensationStack {
+	return &compensationStack{}
+}
+
+// register binds the compensation of a completed step to its output.
+func registerCompensation[O any](stack *compensationStack, entry *compensationEntry[O], locale locales.LocaleTypeEnum, out O) {
+	if stack == nil || entry == nil {
+		return
+	}
+	stack.mu.Lock()
+	defer stack.mu.Unlock()
+	stack.entries = append(stack.entries, pendingCompensation{
+		name: entry.name,
+		fn: func(ctx *app_context.AppContext) (bool, string) {
+			return entry.fn(ctx, locale, out)
+		},
+	})
+}
+
+// unwind executes every registered compensation in reverse order and
+// returns a report for each of them. A failed compensation does not stop
+// the remaining ones.
+func (s *compensationStack) unwind(ctx *app_context.AppContext) []CompensationReport {
+	s.mu.Lock()
+	entries := s.entries
+	s.entries = nil
+	s.mu.Unlock()
+
+	reports := make([]CompensationReport, 0, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		reports = append(reports, executeCompensation(ctx, entries[i]))
+	}
+	return reports
+}
+
+// executeCompensation executes a single compensation with instrumentation.
+// Panics are recovered and reported as a failed compensation.
+func executeCompensation(ctx *app_context.AppContext, entry pendingCompensation) (report CompensationReport) {
+	tracer := observability.GetObservabilityComponents().Tracer
+	clock := observability.GetObservabilityComponents().Clock
+	metrics := observability.GetObservabilityComponents().Metrics
+
+	span := tracer.StartSpan(ctx, "dag.compensation.execute."+entry.name)
+	defer span.End()
+	span.UpdateAppContext(ctx)
+
+	tags := map[string]string{
+		"dag_compensation": entry.name,
+	}
+	start := clock.Now()
+	defer func() {
+		if r := recover(); r != nil {
+			report = CompensationReport{Name: entry.name, Success: false, Error: fmt.Sprintf("panic: %v", r)}
+		}
+		metrics.RecordLatency("dag.compensation.execute."+entry.name, clock.Now().Sub(start), tags)
+		if report.Success {
+			span.SetStatus(contractsobservability.SpanStatusOK, "")
+			tags["status"] = "success"
+			metrics.IncrementCounter("dag.compensation.success."+entry.name, tags)
+		} else {
+			span.SetStatus(contractsobservability.SpanStatusError, report.Error)
+			tags["status"] = "error"
+			metrics.IncrementCounter("dag.compensation.error."+entry.name, tags)
+		}
+	}()
+
+	ok, errMsg := entry.fn(ctx)
+	return CompensationReport{Name: entry.name, Success: ok, Error: errMsg}
+}
+
+// formatCompensationDetails appends the compensation reports to the details
+// of a failed UseCaseResult.
+func formatCompensationDetails(details string, reports []CompensationReport) string {
+	if len(reports) == 0 {
+		return details
+	}
+	parts := make([]string, 0, len(reports))
+	for _, r := range reports {
+		if r.Success {
+			parts = append(parts, r.Name+": success")
+		} else {
+			parts = append(parts, r.Name+": failed ("+r.Error+")")
+		}
+	}
+	summary := "compensations: " + strings.Join(parts, ", ")
+	if details == "" {
+		return summary
+	}
+	return details + "\n" + summary
+}
@@ -0,0 +1,312 @@
+package usecase
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// DagNodeKind identifies the role of a node in a DagGraph.
+type DagNodeKind string
+
+// DagNodeKind values
+const (
+	DagNodeStep       DagNodeKind = "step"
+	DagNodeBranch     DagNodeKind = "branch"
+	DagNodeJoin       DagNodeKind = "join"
+	DagNodeSwitch     DagNodeKind = "switch"
+	DagNodeCase       DagNodeKind = "case"
+	DagNodeBackground DagNodeKind = "background"
+	DagNodeDurable    DagNodeKind = "durable"
+)
+
+// DagNode describes a step of a DAG.
+type DagNode struct {
+	ID           string      `json:"id"`
+	Name         string      `json:"name"`
+	Kind         DagNodeKind `json:"kind"`
+	UseCase      string      `json:"useCase,omitempty"`
+	Input        string      `json:"input"`
+	Output       string      `json:"output"`
+	Compensation string      `json:"compensation,omitempty"`
+	Policy       string      `json:"policy,omitempty"`
+}
+
+// DagEdge connects the node producing a value with the node consuming it.
+type DagEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// DagGraph is the structural description of a DAG: its steps, branches
+// and background tasks, with the type names flowing between them.
+// It is built together with the DAG and never executes anything.
+type DagGraph struct {
+	Name  string    `json:"name"`
+	Nodes []DagNode `json:"nodes"`
+	Edges []DagEdge `json:"edges"`
+}
+
+// Node returns the node with the given ID.
+func (g DagGraph) Node(id string) (DagNode, bool) {
+	for _, node := range g.Nodes {
+		if node.ID == id {
+			return node, true
+		}
+	}
+	return DagNode{}, false
+}
+
+// ToJSON exports the graph as JSON.
+func (g DagGraph) ToJSON() ([]byte, error) {
+	return json.Marshal(g)
+}
+
+// ToDOT exports the graph in the Graphviz DOT language.
+// Background tasks are drawn with dashed edges.
+func (g DagGraph) ToDOT() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "digraph %q {\n", g.Name)
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+	for _, node := range g.Nodes {
+		attrs := ""
+		switch node.Kind {
+		case DagNodeJoin, DagNodeSwitch:
+			attrs = ", shape=diamond"
+		case DagNodeBackground, DagNodeDurable:
+			attrs = ", style=dashed"
+		}
+		fmt.Fprintf(&b, "  %s [label=%q%s];\n", node.ID, nodeLabel(node, "\n"), attrs)
+	}
+	for _, edge := range g.Edges {
+		attrs := ""
+		if to, ok := g.Node(edge.To); ok && (to.Kind == DagNodeBackground || to.Kind == DagNodeDurable) {
+			attrs = " [style=dashed]"
+		}
+		fmt.Fprintf(&b, "  %s -> %s%s;\n", edge.From, edge.To, attrs)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// ToMermaid exports the graph as a Mermaid flowchart.
+// Background tasks are drawn with dotted edges.
+func (g DagGraph) ToMermaid() string {
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for _, node := range g.Nodes {
+		label := strings.ReplaceAll(nodeLabel(node, "<br/>"), `"`, "#quot;")
+		if node.Kind == DagNodeJoin || node.Kind == DagNodeSwitch {
+			fmt.Fprintf(&b, "  %s{\"%s\"}\n", node.ID, label)
+			continue
+		}
+		fmt.Fprintf(&b, "  %s[\"%s\"]\n", node.ID, label)
+	}
+	for _, edge := range g.Edges {
+		arrow := "-->"
+		if to, ok := g.Node(edge.To); ok && (to.Kind == DagNodeBackground || to.Kind == DagNodeDurable) {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(&b, "  %s %s %s\n", edge.From, arrow, edge.To)
+	}
+	return b.String()
+}
+
+// nodeLabel builds the label of a node with its name, kind and types.
+func nodeLabel(node DagNode, newline string) string {
+	label := node.Name
+	if node.Kind != DagNodeStep {
+		label += " (" + string(node.Kind) + ")"
+	}
+	label += newline + node.Input + " → " + node.Output
+	if node.Compensation != "" {
+		label += newline + "compensation: " + node.Compensation
+	}
+	if node.Policy != "" {
+		label += newline + node.Policy
+	}
+	return label
+}
+
+// dagGraph is the immutable graph carried by a DAG while it is built.
+// Background and durable nodes are kept apart because they are bound to
+// the current output type and are dropped when the output type changes.
+type dagGraph struct {
+	nodes    []DagNode
+	edges    []DagEdge
+	tails    []string
+	attached []DagNode
+}
+
+// newDagGraph creates a graph with a single node.
+func newDagGraph(first DagNode) *dagGraph {
+	first.ID = "n0"
+	return &dagGraph{nodes: []DagNode{first}, tails: []string{first.ID}}
+}
+
+// then returns a new graph with the node fed by the given nodes, or by the
+// tails if none is given. The node becomes the new tail.
+func (g *dagGraph) then(node DagNode, from ...string) *dagGraph {
+	node.ID = fmt.Sprintf("n%d", len(g.nodes))
+	if len(from) == 0 {
+		from = g.tails
+	}
+	edges := slices.Clone(g.edges)
+	for _, id := range from {
+		edges = append(edges, DagEdge{From: id, To: node.ID})
+	}
+	return &dagGraph{
+		nodes: append(slices.Clone(g.nodes), node),
+		edges: edges,
+		tails: []string{node.ID},
+	}
+}
+
+// fanOut returns a new graph with the given branches fed by the tails.
+// The branches become the new tails.
+func (g *dagGraph) fanOut(branches []DagNode) *dagGraph {
+	next := &dagGraph{nodes: slices.Clone(g.nodes), edges: slices.Clone(g.edges)}
+	for _, branch := range branches {
+		branch.ID = fmt.Sprintf("n%d", len(next.nodes))
+		next.nodes = append(next.nodes, branch)
+		for _, tail := range g.tails {
+			next.edges = append(next.edges, DagEdge{From: tail, To: branch.ID})
+		}
+		next.tails = append(next.tails, branch.ID)
+	}
+	return next
+}
+
+// attach returns a new graph with a background node fed by the tails.
+func (g *dagGraph) attach(node DagNode) *dagGraph {
+	return &dagGraph{
+		nodes:    g.nodes,
+		edges:    g.edges,
+		tails:    g.tails,
+		attached: append(slices.Clone(g.attached), node),
+	}
+}
+
+// describe builds the exported description of the graph.
+func (g *dagGraph) describe() DagGraph {
+	graph := DagGraph{
+		Nodes: slices.Clone(g.nodes),
+		Edges: slices.Clone(g.edges),
+	}
+	for _, node := range g.attached {
+		node.ID = fmt.Sprintf("n%d", len(graph.Nodes))
+		graph.Nodes = append(graph.Nodes, node)
+		for _, tail := range g.tails {
+			graph.Edges = append(graph.Edges, DagEdge{From: tail, To: node.ID})
+		}
+	}
+	return graph
+}
+
+// Describe returns the structural description of the DAG.
+func (d *DAG[I, O]) Describe() DagGraph {
+	return d.graph.describe()
+}
+
+// describe builds the node of the step with the given name and kind.
+// Unnamed steps are named after the type of their use case.
+func (s DagStep[I, O]) describe(name string, kind DagNodeKind) DagNode {
+	useCase := fmt.Sprintf("%T", s.uc)
+	if name == "" {
+		name = strings.TrimPrefix(useCase, "*")
+	}
+	node := DagNode{
+		Name:    name,
+		Kind:    kind,
+		UseCase: useCase,
+		Input:   typeName[I](),
+		Output:  typeName[O](),
+		Policy:  s.policy.describe(),
+	}
+	if s.compensation != nil {
+		node.Compensation = s.compensation.name
+	}
+	return node
+}
+
+// describe summarizes the policy, e.g. "attempts=3 timeout=2s breaker=5/30s".
+func (p *StepPolicy) describe() string {
+	if p == nil {
+		return ""
+	}
+	parts := make([]string, 0, 3)
+	if p.MaxAttempts > 1 {
+		parts = append(parts, fmt.Sprintf("attempts=%d", p.MaxAttempts))
+	}
+	if p.Timeout > 0 {
+		parts = append(parts, "timeout="+p.Timeout.String())
+	}
+	if p.CircuitBreaker != nil {
+		parts = append(parts, fmt.Sprintf("breaker=%d/%s", p.CircuitBreaker.FailureThreshold, p.CircuitBreaker.OpenDuration))
+	}
+	return strings.Join(parts, " ")
+}
+
+// typeName returns the name of the type T, including interfaces.
+func typeName[T any]() string {
+	return reflect.TypeFor[T]().String()
+}
+
+var (
+	pipes   = make(map[string]func() DagGraph)
+	pipesMu sync.RWMutex
+)
+
+// RegisterPipe registers a pipe under the given name so it can be listed
+// with GetPipes. The describe function builds the pipe and returns its
+// Describe(); it is called lazily on every listing, so it must not execute
+// the pipe. Registering a name again replaces the previous pipe.
+func RegisterPipe(name string, describe func() DagGraph) {
+	pipesMu.Lock()
+	defer pipesMu.Unlock()
+	pipes[name] = describe
+}
+
+// GetPipe returns the description of the pipe registered with the given name.
+func GetPipe(name string) (DagGraph, bool) {
+	pipesMu.RLock()
+	describe, ok := pipes[name]
+	pipesMu.RUnlock()
+	if !ok {
+		return DagGraph{}, false
+	}
+	graph := describe()
+	graph.Name = name
+	return graph, true
+}
+
+// GetPipes returns the description of every registered pipe sorted by name.
+func GetPipes() []DagGraph {
+	pipesMu.RLock()
+	names := make([]string, 0, len(pipes))
+	for name := range pipes {
+		names = append(names, name)
+	}
+	pipesMu.RUnlock()
+	slices.Sort(names)
+
+	graphs := make([]DagGraph, 0, len(names))
+	for _, name := range names {
+		if graph, ok := GetPipe(name); ok {
+			graphs = append(graphs, graph)
+		}
+	}
+	return graphs
+}
+
+// ResetPipes forgets every registered pipe.
+// It is intended for tests.
+func ResetPipes() {
+	pipesMu.Lock()
+	defer pipesMu.Unlock()
+	pipes = make(map[string]func() DagGraph)
+}
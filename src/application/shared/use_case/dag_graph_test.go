@@ -0,0 +1,121 @@
+package usecase
+
+import (
+	"encoding/json"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newGraphTestDag() *DAG[string, string] {
+	var mu sync.Mutex
+	calls := make([]string, 0)
+
+	square := WithPolicy(
+		WithCompensation(NewStep(&UCIntExponent{}), &UCRecordCompensation{mu: &mu, calls: &calls, name: "undo-square"}, "undo-square"),
+		StepPolicy{MaxAttempts: 3, Timeout: time.Second},
+	)
+	squareBranch := NewBranch(NewStep(&UCIntExponent{}), "branch-square")
+	stringBranch := NewBranch(NewStep(&UCIntToString{}), "branch-string")
+
+	dag := Then(NewDag(app_context.NewVoidAppContext(), NewStep(&UCStringToInt{}), locales.EN_US, nil), square, "square")
+	// dropped by Then, since it is bound to the int output
+	dag = ThenBackground(dag, NewStep(&UCLogBackgroundInt{}), "dropped")
+	joined := Join(Parallel(dag, squareBranch, stringBranch), func(input int, results BranchResults) string {
+		return strconv.Itoa(input) + stringBranch.Output(results)
+	})
+	joined = ThenBackground(joined, NewStep(&UCLogBackgroundString{}), "log")
+	return ThenDurable(joined, NewStep(&UCLogBackgroundString{}), "durable-log")
+}
+
+func TestDagDescribe(t *testing.T) {
+	assert := assert.New(t)
+
+	graph := newGraphTestDag().Describe()
+
+	names := make([]string, 0, len(graph.Nodes))
+	for _, node := range graph.Nodes {
+		names = append(names, node.Name)
+	}
+	assert.Equal([]string{"usecase.UCStringToInt", "square", "branch-square", "branch-string", "join", "log", "durable-log"}, names)
+	assert.Equal([]DagEdge{
+		{From: "n0", To: "n1"},
+		{From: "n1", To: "n2"},
+		{From: "n1", To: "n3"},
+		{From: "n2", To: "n4"},
+		{From: "n3", To: "n4"},
+		{From: "n4", To: "n5"},
+		{From: "n4", To: "n6"},
+	}, graph.Edges)
+
+	first := graph.Nodes[0]
+	assert.Equal(DagNodeStep, first.Kind)
+	assert.Equal("*usecase.UCStringToInt", first.UseCase)
+	assert.Equal("string", first.Input)
+	assert.Equal("int", first.Output)
+
+	square := graph.Nodes[1]
+	assert.Equal("undo-square", square.Compensation)
+	assert.Equal("attempts=3 timeout=1s", square.Policy)
+
+	assert.Equal(DagNodeBranch, graph.Nodes[3].Kind)
+	assert.Equal("string", graph.Nodes[3].Output)
+	assert.Equal(DagNodeJoin, graph.Nodes[4].Kind)
+	assert.Equal(DagNodeBackground, graph.Nodes[5].Kind)
+	assert.Equal(DagNodeDurable, graph.Nodes[6].Kind)
+}
+
+func TestDagGraphExport(t *testing.T) {
+	assert := assert.New(t)
+
+	graph := newGraphTestDag().Describe()
+	graph.Name = "test-pipe"
+
+	dot := graph.ToDOT()
+	assert.Contains(dot, `digraph "test-pipe" {`)
+	assert.Contains(dot, `n1 [label="square\nint → int\ncompensation: undo-square\nattempts=3 timeout=1s"];`)
+	assert.Contains(dot, "n4 [label=\"join (join)\\nint → string\", shape=diamond];")
+	assert.Contains(dot, "n1 -> n2;")
+	assert.Contains(dot, "n4 -> n6 [style=dashed];")
+
+	mermaid := graph.ToMermaid()
+	assert.Contains(mermaid, "flowchart LR\n")
+	assert.Contains(mermaid, `n4{"join (join)<br/>int → string"}`)
+	assert.Contains(mermaid, "n0 --> n1")
+	assert.Contains(mermaid, "n4 -.-> n5")
+
+	data, err := graph.ToJSON()
+	assert.Nil(err)
+	var decoded DagGraph
+	assert.Nil(json.Unmarshal(data, &decoded))
+	assert.Equal(graph, decoded)
+}
+
+func TestDagPipeRegistry(t *testing.T) {
+	assert := assert.New(t)
+	ResetPipes()
+	defer ResetPipes()
+
+	RegisterPipe("b-pipe", func() DagGraph { return newGraphTestDag().Describe() })
+	RegisterPipe("a-pipe", func() DagGraph {
+		return NewDag(app_context.NewVoidAppContext(), NewStep(&UCStringToInt{}), locales.EN_US, nil).Describe()
+	})
+
+	graphs := GetPipes()
+	assert.Len(graphs, 2)
+	assert.Equal("a-pipe", graphs[0].Name)
+	assert.Len(graphs[0].Nodes, 1)
+	assert.Equal("b-pipe", graphs[1].Name)
+
+	graph, ok := GetPipe("b-pipe")
+	assert.True(ok)
+	assert.Len(graph.Nodes, 7)
+	_, ok = GetPipe("missing")
+	assert.False(ok)
+}
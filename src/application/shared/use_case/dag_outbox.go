@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"runtime/debug"
+
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/outbox"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+)
+
+// durableEntry represents a durable background task attached to a DAG node.
+type durableEntry[O any] struct {
+	name    string
+	handler outbox.Handler
+	// fallback runs the task in memory when no outbox is configured
+	fallback backgroundEntry[O]
+}
+
+// ThenDurable attaches a durable background step that consumes the current
+// output type of the DAG.
+//
+// Durable steps behave like background steps (see ThenBackground), but the
+// output of the DAG is persisted in the outbox before the step is executed:
+//   - The synchronous steps run in a transaction of the outbox database (see
+//     outbox.Outbox.Transaction), and the task is persisted in it once they
+//     succeeded: the writes of the repositories bound to the AppContext of
+//     the DAG are committed together with the task, or not at all. If the
+//     transaction is rolled back the execution fails and the compensations
+//     of the steps are executed (see WithCompensation).
+//   - The task is delivered right away through the DAG's BackgroundExecutor.
+//   - If that delivery fails or never happens (full queue, restart, frozen
+//     Lambda), the outbox dispatcher delivers it again at least once, with
+//     retries and dead-lettering.
+//   - The task ID is attached to the UseCaseResult (see TaskIDs) and can be
+//     used to query the outcome of the task. Tasks enqueued by anonymous
+//     requests, e.g. a sign-up, cannot be queried, so their ID is not
+//     attached.
+//
+// The output type must be JSON serializable, and the step must be idempotent.
+// The policy of the step (see WithPolicy) applies to every delivery.
+// If no outbox is configured (see outbox.InitializeOutbox), the step runs as
+// a regular background step.
+//
+// On execution, the step is registered as the outbox handler for name unless
+// a handler was already registered (see RegisterDurableStep).
+func ThenDurable[I any, O any, P any](d *DAG[I, O], next DagStep[O, P], name string) *DAG[I, O] {
+	entry := durableEntry[O]{
+		name:     name,
+		handler:  durableHandler(name, next),
+		fallback: newBackgroundEntry(d, next, name),
+	}
+
+	// copy existing durable slice to keep immutability
+	newDurable := make([]durableEntry[O], len(d._durable), len(d._durable)+1)
+	copy(newDurable, d._durable)
+	newDurable = append(newDurable, entry)
+
+	return &DAG[I, O]{
+		run:         d.run,
+		ctx:         d.ctx,
+		locale:      d.locale,
+		_background: d._background,
+		_durable:    newDurable,
+		executor:    d.executor,
+		graph:       d.graph.attach(next.describe(name, DagNodeDurable)),
+	}
+}
+
+// RegisterDurableStep registers the step as the outbox handler of the
+// durable tasks with the given name.
+//
+// It must be called at startup with a dedicated step instance, so tasks
+// persisted before a restart can be delivered by the dispatcher.
+func RegisterDurableStep[O any, P any](name string, step DagStep[O, P]) {
+	outbox.Register(name, durableHandler(name, step))
+}
+
+// durableHandler adapts a step to an outbox handler that decodes the
+// JSON payload into the step input and executes it with its policy.
+func durableHandler[O any, P any](name string, step DagStep[O, P]) outbox.Handler {
+	return func(ctx *app_context.AppContext, locale locales.LocaleTypeEnum, payload []byte) (string, error) {
+		var input O
+		if err := json.Unmarshal(payload, &input); err != nil {
+			return "", err
+		}
+		res := executeWithPolicy(ctx, step, name, locale, input)
+		if res == nil {
+			return "", errors.New("nil result")
+		}
+		if res.HasError() {
+			return "", res.GetError()
+		}
+		return res.Details, nil
+	}
+}
+
+// runDurable runs the synchronous steps and persists the durable tasks of a
+// successful execution in a single transaction of the outbox database. The
+// transaction is rolled back if the steps fail or a task is not persisted.
+func (d *DAG[I, O]) runDurable(ob *outbox.Outbox, stack *compensationStack, input I) (*UseCaseResult[O], []*sharedmodels.OutboxTask) {
+	var res *UseCaseResult[O]
+	var tasks []*sharedmodels.OutboxTask
+	appErr := ob.Transaction(d.ctx, func() *application_errors.ApplicationError {
+		res = d.run(d.ctx, stack, input)
+		if res == nil || res.HasError() {
+			return errRolledBack
+		}
+		if res.Data == nil {
+			return nil
+		}
+		var appErr *application_errors.ApplicationError
+		tasks, appErr = d.enqueueDurable(res)
+		return appErr
+	})
+	if appErr == nil || res == nil || res.HasError() {
+		return res, tasks
+	}
+	// the steps succeeded but their writes were rolled back
+	observability.GetObservabilityComponents().Logger.ErrorWithContext("error persisting durable tasks", appErr.ToError(), d.ctx)
+	res.SetError(appErr.Code, appErr.ErrMsg)
+	return res, nil
+}
+
+// errRolledBack rolls back the transaction of a failed execution, whose
+// result carries the error
+var errRolledBack = application_errors.NewApplicationError(status.InternalError, messages.MessageKeysInstance.SOMETHING_WENT_WRONG, "execution rolled back")
+
+// enqueueDurable persists the durable tasks of a successful execution, in
+// the order of the durable steps, and stops at the first task that cannot
+// be persisted.
+func (d *DAG[I, O]) enqueueDurable(res *UseCaseResult[O]) ([]*sharedmodels.OutboxTask, *application_errors.ApplicationError) {
+	ob := outbox.GetOutbox()
+	tasks := make([]*sharedmodels.OutboxTask, 0, len(d._durable))
+	for _, entry := range d._durable {
+		task, appErr := ob.Enqueue(d.ctx, d.locale, entry.name, *res.Data)
+		if appErr != nil {
+			return nil, application_errors.NewApplicationError(appErr.Code, appErr.Context, "error persisting durable task "+entry.name)
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+// scheduleDurable attaches the task IDs of the persisted durable tasks of a
+// user to the result and delivers them right away. Without outbox the durable steps run
+// as background steps.
+func (d *DAG[I, O]) scheduleDurable(res *UseCaseResult[O], tasks []*sharedmodels.OutboxTask) {
+	ob := outbox.GetOutbox()
+	if ob == nil || tasks == nil {
+		for _, entry := range d._durable {
+			d.scheduleBackground([]backgroundEntry[O]{entry.fallback}, *res.Data)
+		}
+		return
+	}
+	for i, entry := range d._durable {
+		task := tasks[i]
+		if task.UserID != 0 {
+			res.AddTaskID(entry.name, task.TaskID)
+		}
+		if !outbox.IsRegistered(entry.name) {
+			outbox.Register(entry.name, entry.handler)
+		}
+
+		handler := entry.handler
+		appCtx := d.ctx
+		deliver := func() {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Printf("panic recovered in durable task %s: %v\n%s", entry.name, r, debug.Stack())
+				}
+			}()
+			ob.DeliverWith(appCtx, *task, handler)
+		}
+		if d.executor == nil {
+			go deliver()
+			continue
+		}
+		if err := d.executor.Submit(func(_ context.Context) { deliver() }); err != nil {
+			// the task is already persisted, the dispatcher delivers it once the lease expires
+			log.Printf("executor submit failed for durable task %s: %v — left to the outbox dispatcher", entry.name, err)
+		}
+	}
+}
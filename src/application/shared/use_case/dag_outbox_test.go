@@ -0,0 +1,219 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	dtos "github.com/simon3640/goprojectskeleton/src/application/shared/DTOs"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	repositoriesmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/repositories"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/outbox"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDagDurableWithoutOutbox(t *testing.T) {
+	assert := assert.New(t)
+	outbox.ResetOutboxSingleton()
+
+	appCtx := &app_context.AppContext{Context: context.Background()}
+	durableUC := &UCLogBackgroundInt{}
+	dag := ThenDurable(NewDag(appCtx, NewStep(&UCStringToInt{}), locales.EN_US, nil), NewStep(durableUC), "durable-no-outbox")
+
+	result := dag.Execute("5")
+	assert.True(result.IsSuccess())
+	assert.Empty(result.TaskIDs)
+
+	// Without outbox the step runs as a regular background step
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal([]int{5}, durableUC.GetLogged())
+}
+
+func TestDagDurableWithOutbox(t *testing.T) {
+	assert := assert.New(t)
+	defer outbox.ResetOutboxSingleton()
+
+	task := &sharedmodels.OutboxTask{
+		OutboxTaskBase: sharedmodels.OutboxTaskBase{TaskID: "task-1", Name: "durable-outbox", Payload: []byte("5"), MaxAttempts: 3, UserID: 1},
+		DBBaseModel:    sharedmodels.DBBaseModel{ID: 1},
+	}
+	updated := make(chan dtos.OutboxTaskUpdate, 1)
+	repo := new(repositoriesmocks.MockOutboxTaskRepository)
+	repo.On("Create", mock.MatchedBy(func(c dtos.OutboxTaskCreate) bool {
+		return c.Name == "durable-outbox" && string(c.Payload) == "5" && c.UserID == 1
+	})).Return(task, nil)
+	repo.On("Update", uint(1), mock.Anything).
+		Run(func(args mock.Arguments) { updated <- args.Get(1).(dtos.OutboxTaskUpdate) }).
+		Return(task, nil)
+	uow := new(repositoriesmocks.MockUnitOfWork)
+	uow.On("Do", true).Return(nil).Once()
+	outbox.InitializeOutbox(repo, uow, outbox.Options{})
+
+	appCtx := app_context.NewContextWithUser(&usermodels.UserWithRole{ID: 1})
+	durableUC := &UCLogBackgroundInt{}
+	dag := ThenDurable(NewDag(appCtx, NewStep(&UCStringToInt{}), locales.EN_US, nil), NewStep(durableUC), "durable-outbox")
+
+	result := dag.Execute("5")
+	assert.True(result.IsSuccess())
+	assert.Equal(map[string]string{"durable-outbox": "task-1"}, result.TaskIDs)
+
+	select {
+	case update := <-updated:
+		assert.Equal(sharedmodels.OutboxTaskStatusSucceeded, update.Status)
+		assert.Equal(1, update.Attempts)
+	case <-time.After(time.Second):
+		t.Fatal("durable task was not delivered")
+	}
+	assert.Equal([]int{5}, durableUC.GetLogged())
+	repo.AssertExpectations(t)
+	uow.AssertExpectations(t)
+}
+
+func TestDagDurableAnonymous(t *testing.T) {
+	assert := assert.New(t)
+	defer outbox.ResetOutboxSingleton()
+
+	task := &sharedmodels.OutboxTask{
+		OutboxTaskBase: sharedmodels.OutboxTaskBase{TaskID: "task-1", Name: "durable-anonymous", Payload: []byte("5"), MaxAttempts: 3},
+		DBBaseModel:    sharedmodels.DBBaseModel{ID: 1},
+	}
+	updated := make(chan dtos.OutboxTaskUpdate, 1)
+	repo := new(repositoriesmocks.MockOutboxTaskRepository)
+	repo.On("Create", mock.Anything).Return(task, nil)
+	repo.On("Update", uint(1), mock.Anything).
+		Run(func(args mock.Arguments) { updated <- args.Get(1).(dtos.OutboxTaskUpdate) }).
+		Return(task, nil)
+	uow := new(repositoriesmocks.MockUnitOfWork)
+	uow.On("Do", true).Return(nil).Once()
+	outbox.InitializeOutbox(repo, uow, outbox.Options{})
+
+	appCtx := &app_context.AppContext{Context: context.Background()}
+	durableUC := &UCLogBackgroundInt{}
+	dag := ThenDurable(NewDag(appCtx, NewStep(&UCStringToInt{}), locales.EN_US, nil), NewStep(durableUC), "durable-anonymous")
+
+	// The task of an anonymous request cannot be read, so its ID is not returned
+	result := dag.Execute("5")
+	assert.True(result.IsSuccess())
+	assert.Empty(result.TaskIDs)
+
+	select {
+	case update := <-updated:
+		assert.Equal(sharedmodels.OutboxTaskStatusSucceeded, update.Status)
+	case <-time.After(time.Second):
+		t.Fatal("durable task was not delivered")
+	}
+}
+
+func TestDagDurableNotPersisted(t *testing.T) {
+	assert := assert.New(t)
+	defer outbox.ResetOutboxSingleton()
+
+	first := &sharedmodels.OutboxTask{
+		OutboxTaskBase: sharedmodels.OutboxTaskBase{TaskID: "task-1", Name: "durable-first", Payload: []byte("5"), MaxAttempts: 3},
+		DBBaseModel:    sharedmodels.DBBaseModel{ID: 1},
+	}
+	repo := new(repositoriesmocks.MockOutboxTaskRepository)
+	repo.On("Create", mock.MatchedBy(func(c dtos.OutboxTaskCreate) bool { return c.Name == "durable-first" })).Return(first, nil)
+	repo.On("Create", mock.MatchedBy(func(c dtos.OutboxTaskCreate) bool { return c.Name == "durable-second" })).
+		Return((*sharedmodels.OutboxTask)(nil), application_errors.NewApplicationError(status.ProviderError, "", "database unavailable"))
+	uow := new(repositoriesmocks.MockUnitOfWork)
+	uow.On("Do", false).Return(nil).Once()
+	outbox.InitializeOutbox(repo, uow, outbox.Options{})
+
+	appCtx := &app_context.AppContext{Context: context.Background()}
+	var mu sync.Mutex
+	calls := make([]string, 0)
+	parse := WithCompensation(NewStep(&UCStringToInt{}), &UCRecordCompensation{mu: &mu, calls: &calls, name: "undo-parse"}, "undo-parse")
+	firstUC := &UCLogBackgroundInt{}
+	secondUC := &UCLogBackgroundInt{}
+	dag := ThenDurable(NewDag(appCtx, parse, locales.EN_US, nil), NewStep(firstUC), "durable-first")
+	dag = ThenDurable(dag, NewStep(secondUC), "durable-second")
+
+	// The transaction is rolled back with the first task, the steps are
+	// compensated and no task is delivered
+	result := dag.Execute("5")
+	assert.True(result.HasError())
+	assert.Equal(status.ProviderError, result.StatusCode)
+	assert.Empty(result.TaskIDs)
+	assert.Equal([]string{"undo-parse:5"}, calls)
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(firstUC.GetLogged())
+	assert.Empty(secondUC.GetLogged())
+	repo.AssertExpectations(t)
+	uow.AssertExpectations(t)
+}
+
+func TestDagDurableNotCommitted(t *testing.T) {
+	assert := assert.New(t)
+	defer outbox.ResetOutboxSingleton()
+
+	task := &sharedmodels.OutboxTask{
+		OutboxTaskBase: sharedmodels.OutboxTaskBase{TaskID: "task-1", Name: "durable-commit", Payload: []byte("5"), MaxAttempts: 3},
+		DBBaseModel:    sharedmodels.DBBaseModel{ID: 1},
+	}
+	repo := new(repositoriesmocks.MockOutboxTaskRepository)
+	repo.On("Create", mock.Anything).Return(task, nil)
+	uow := new(repositoriesmocks.MockUnitOfWork)
+	uow.On("Do", true).Return(application_errors.NewApplicationError(status.ProviderError, "", "database unavailable")).Once()
+	outbox.InitializeOutbox(repo, uow, outbox.Options{})
+
+	appCtx := &app_context.AppContext{Context: context.Background()}
+	var mu sync.Mutex
+	calls := make([]string, 0)
+	parse := WithCompensation(NewStep(&UCStringToInt{}), &UCRecordCompensation{mu: &mu, calls: &calls, name: "undo-parse"}, "undo-parse")
+	durableUC := &UCLogBackgroundInt{}
+	dag := ThenDurable(NewDag(appCtx, parse, locales.EN_US, nil), NewStep(durableUC), "durable-commit")
+
+	// The task is not committed with the writes of the steps: it is not
+	// delivered and the steps are compensated
+	result := dag.Execute("5")
+	assert.True(result.HasError())
+	assert.Equal(status.ProviderError, result.StatusCode)
+	assert.Empty(result.TaskIDs)
+	assert.Equal([]string{"undo-parse:5"}, calls)
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(durableUC.GetLogged())
+	uow.AssertExpectations(t)
+}
+
+func TestDagDurableHandlerPolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	flaky := &UCFlakyInt{failures: 1, code: status.ProviderError}
+	handler := durableHandler("durable-policy", WithPolicy(NewStep(flaky), StepPolicy{
+		MaxAttempts: 2,
+		RetryOn:     []status.ApplicationStatusEnum{status.ProviderError},
+	}))
+
+	_, err := handler(&app_context.AppContext{Context: context.Background()}, locales.EN_US, []byte("5"))
+	assert.NoError(err)
+	assert.Equal(2, flaky.Calls())
+}
+
+func TestDagDurableStepFailed(t *testing.T) {
+	assert := assert.New(t)
+	defer outbox.ResetOutboxSingleton()
+
+	repo := new(repositoriesmocks.MockOutboxTaskRepository)
+	uow := new(repositoriesmocks.MockUnitOfWork)
+	uow.On("Do", false).Return(nil).Once()
+	outbox.InitializeOutbox(repo, uow, outbox.Options{})
+
+	appCtx := &app_context.AppContext{Context: context.Background()}
+	dag := ThenDurable(NewDag(appCtx, NewStep(&UCFail[string]{}), locales.EN_US, nil), NewStep(&UCStringToInt{}), "durable-failed")
+
+	// The writes of the failed steps are rolled back and no task is persisted
+	result := dag.Execute("5")
+	assert.True(result.HasError())
+	assert.Empty(result.TaskIDs)
+	repo.AssertNotCalled(t, "Create", mock.Anything)
+	uow.AssertExpectations(t)
+}
@@ -0,0 +1,246 @@
+package usecase
+
+import (
+	"sync"
+
+	contractsobservability "github.com/simon3640/goprojectskeleton/src/application/contracts/observability"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+)
+
+// ParallelBranch is a branch of a fan-out that consumes the output O of
+// the previous DAG step. It is implemented by Branch and can only be
+// created through NewBranch.
+type ParallelBranch[O any] interface {
+	branchName() string
+	describe() DagNode
+	execute(ctx *app_context.AppContext, stack *compensationStack, locale locales.LocaleTypeEnum, input O) *UseCaseResult[any]
+}
+
+// Branch is a typed branch of a parallel fan-out.
+// It wraps a DagStep that receives the output of the previous step
+// and produces its own output type B.
+type Branch[O any, B any] struct {
+	name string
+	step DagStep[O, B]
+}
+
+var _ ParallelBranch[any] = Branch[any, any]{}
+
+// NewBranch creates a named branch from the given step.
+// The name identifies the branch in spans, metrics and BranchResults,
+// so it must be unique inside the same Parallel call.
+func NewBranch[O any, B any](step DagStep[O, B], name string) Branch[O, B] {
+	return Branch[O, B]{name: name, step: step}
+}
+
+// Output returns the typed output of this branch from the join results.
+// It returns the zero value of B if the branch produced no data.
+func (b Branch[O, B]) Output(results BranchResults) B {
+	var zero B
+	value, ok := results[b.name]
+	if !ok {
+		return zero
+	}
+	out, ok := value.(B)
+	if !ok {
+		return zero
+	}
+	return out
+}
+
+func (b Branch[O, B]) branchName() string { return b.name }
+
+func (b Branch[O, B]) describe() DagNode { return b.step.describe(b.name, DagNodeBranch) }
+
+func (b Branch[O, B]) execute(ctx *app_context.AppContext, stack *compensationStack, locale locales.LocaleTypeEnum, input O) *UseCaseResult[any] {
+	res := b.step.execute(ctx, stack, b.name, locale, input)
+	if res == nil {
+		return nil
+	}
+	erased := &UseCaseResult[any]{
+		StatusCode: res.StatusCode,
+		Success:    res.Success,
+		Error:      res.Error,
+		Headers:    res.Headers,
+		Details:    res.Details,
+	}
+	if res.Data != nil {
+		var data any = *res.Data
+		erased.Data = &data
+	}
+	return erased
+}
+
+// BranchResults holds the outputs of every branch of a fan-out indexed by
+// branch name. Use Branch.Output to read a typed value from it.
+type BranchResults map[string]any
+
+// ParallelDAG is an intermediate builder returned by Parallel.
+// It must be closed with Join to obtain a DAG again.
+type ParallelDAG[I any, O any] struct {
+	dag      *DAG[I, O]
+	branches []ParallelBranch[O]
+}
+
+// Parallel fans out the output of the DAG into several branches that are
+// executed concurrently once the previous steps succeed.
+//
+// Each branch receives the same input (the output of the DAG) and runs in
+// its own goroutine with its own `dag.step.execute.<branch>` span and metrics.
+// The fan-out must be closed with Join.
+func Parallel[I any, O any](d *DAG[I, O], branches ...ParallelBranch[O]) *ParallelDAG[I, O] {
+	copied := make([]ParallelBranch[O], len(branches))
+	copy(copied, branches)
+	return &ParallelDAG[I, O]{
+		dag:      d,
+		branches: copied,
+	}
+}
+
+// Join merges the branches of a fan-out into a single output using the
+// given combiner and returns a NEW DAG with the combined output type.
+//
+// Execution semantics:
+//   - The previous DAG is executed first; if it fails, no branch is executed.
+//   - All branches are executed concurrently and Join waits for all of them.
+//     Inside a database transaction the branches are executed one after
+//     another, since a transaction must not be used concurrently.
+//   - If any branch fails (or returns nil), the first error is propagated
+//     and the combiner is not called. Compensations of the branches that
+//     succeeded are executed together with the rest of the DAG.
+//   - On success, the combiner receives the output of the previous step
+//     and the results of every branch.
+//
+// Note:
+//   - Background steps are not carried over, since they are bound to the
+//     previous output type.
+func Join[I any, O any, P any](p *ParallelDAG[I, O], combine func(input O, results BranchResults) P) *DAG[I, P] {
+	d := p.dag
+	branches := p.branches
+
+	run := func(ctx *app_context.AppContext, stack *compensationStack, input I) *UseCaseResult[P] {
+		r1 := d.run(ctx, stack, input)
+		if r1 == nil {
+			return nil
+		}
+		if r1.HasError() {
+			return &UseCaseResult[P]{
+				StatusCode: r1.StatusCode,
+				Success:    false,
+				Error:      r1.Error,
+				Details:    r1.Details,
+			}
+		}
+
+		out := *r1.Data
+		result := NewUseCaseResult[P]()
+		results := make(BranchResults, len(branches))
+
+		var wg sync.WaitGroup
+		var mu sync.Mutex
+
+		runBranch := func(branch ParallelBranch[O]) {
+			res := executeBranch(ctx.Clone(), stack, d.locale, branch, out)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if res == nil {
+				if !result.HasError() {
+					result.SetError(status.InternalError, "nil result from branch "+branch.branchName())
+				}
+				return
+			}
+			if res.HasError() {
+				if !result.HasError() {
+					result.SetError(res.StatusCode, *res.Error)
+					result.SetDetails(res.Details)
+				}
+				return
+			}
+			if res.Data != nil {
+				results[branch.branchName()] = *res.Data
+			}
+		}
+
+		for _, b := range branches {
+			if ctx.Transaction() != nil {
+				runBranch(b)
+				continue
+			}
+			wg.Add(1)
+			go func(branch ParallelBranch[O]) {
+				defer wg.Done()
+				runBranch(branch)
+			}(b)
+		}
+		wg.Wait()
+
+		if result.HasError() {
+			return result
+		}
+		result.SetData(status.Success, combine(out, results), r1.Details)
+		return result
+	}
+
+	nodes := make([]DagNode, 0, len(branches))
+	for _, b := range branches {
+		nodes = append(nodes, b.describe())
+	}
+	join := DagNode{Name: "join", Kind: DagNodeJoin, Input: typeName[O](), Output: typeName[P]()}
+
+	return &DAG[I, P]{
+		run:         run,
+		ctx:         d.ctx,
+		locale:      d.locale,
+		_background: make([]backgroundEntry[P], 0),
+		executor:    d.executor,
+		graph:       d.graph.fanOut(nodes).then(join),
+	}
+}
+
+// executeBranch executes a single branch of a fan-out with the same
+// instrumentation as the steps chained with Then.
+func executeBranch[O any](
+	ctx *app_context.AppContext,
+	stack *compensationStack,
+	locale locales.LocaleTypeEnum,
+	branch ParallelBranch[O],
+	input O,
+) *UseCaseResult[any] {
+	tracer := observability.GetObservabilityComponents().Tracer
+	clock := observability.GetObservabilityComponents().Clock
+	metrics := observability.GetObservabilityComponents().Metrics
+
+	name := branch.branchName()
+	span := tracer.StartSpan(ctx, "dag.step.execute."+name)
+	defer span.End()
+	span.UpdateAppContext(ctx)
+	span.SetAttribute("dag_branch", name)
+
+	start := clock.Now()
+	res := branch.execute(ctx, stack, locale, input)
+	duration := clock.Now().Sub(start)
+
+	tags := map[string]string{
+		"dag_step": name,
+	}
+	metrics.RecordLatency("dag.step.execute."+name, duration, tags)
+
+	if res == nil {
+		span.SetStatus(contractsobservability.SpanStatusError, "nil result")
+		tags["status"] = "error"
+		metrics.IncrementCounter("dag.step.error."+name, tags)
+		return nil
+	}
+	if res.HasError() {
+		span.SetStatus(contractsobservability.SpanStatusError, res.GetError().Error())
+		tags["status"] = "error"
+		metrics.IncrementCounter("dag.step.error."+name, tags)
+		return res
+	}
+	span.SetStatus(contractsobservability.SpanStatusOK, "")
+	return res
+}
@@ -0,0 +1,282 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+)
+
+// StepPolicy declares how a DagStep is executed.
+//
+// A zero StepPolicy executes the step exactly once, without timeout
+// and without circuit breaker, which is the default behavior of a step.
+type StepPolicy struct {
+	// MaxAttempts is the maximum number of executions of the step,
+	// including the first one. Values lower than 1 are treated as 1.
+	MaxAttempts int
+	// Backoff is the delay before the first retry.
+	Backoff time.Duration
+	// BackoffMultiplier multiplies the delay after every retry.
+	// Values lower than or equal to 1 keep a constant delay.
+	BackoffMultiplier float64
+	// MaxBackoff caps the delay between retries. Zero means no cap.
+	MaxBackoff time.Duration
+	// RetryOn lists the status codes that are considered transient.
+	// Errors with any other status are returned without retrying.
+	RetryOn []status.ApplicationStatusEnum
+	// Timeout is the deadline of every attempt. The use case receives an
+	// AppContext whose context is cancelled when the deadline is reached.
+	// Zero means no timeout other than the deadline of the AppContext.
+	Timeout time.Duration
+	// CircuitBreaker optionally protects the step with a circuit breaker
+	// shared by every step with the same name.
+	CircuitBreaker *CircuitBreakerPolicy
+}
+
+// CircuitBreakerPolicy configures the circuit breaker of a step.
+type CircuitBreakerPolicy struct {
+	// FailureThreshold is the number of consecutive failed executions
+	// that opens the circuit. Executions fail with the retryable errors of
+	// the step, provider errors and internal errors; other errors, e.g.
+	// invalid input or not found, are answers of the dependency.
+	FailureThreshold int
+	// OpenDuration is the time the circuit stays open before letting
+	// a single trial execution through (half-open).
+	OpenDuration time.Duration
+}
+
+// WithPolicy returns a copy of the step executed with the given policy.
+//
+// Retries, timeouts and circuit breaker decisions are recorded with the
+// `dag.step.retry.<name>`, `dag.step.timeout.<name>` and
+// `dag.step.circuit_open.<name>` counters, next to `dag.step.error.<name>`.
+// The name is the one given to Then or NewBranch; the first step of a DAG
+// uses the type of its use case.
+func WithPolicy[I any, O any](step DagStep[I, O], policy StepPolicy) DagStep[I, O] {
+	step.policy = &policy
+	return step
+}
+
+func (p *StepPolicy) attempts() int {
+	if p == nil || p.MaxAttempts < 1 {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+func (p *StepPolicy) isRetryable(code status.ApplicationStatusEnum) bool {
+	return p != nil && slices.Contains(p.RetryOn, code)
+}
+
+// isFailure reports whether an error counts as a failure of the dependency
+// for the circuit breaker.
+func (p *StepPolicy) isFailure(code status.ApplicationStatusEnum) bool {
+	return code == status.ProviderError || code == status.InternalError || p.isRetryable(code)
+}
+
+// delay returns the backoff before the given retry (1 based).
+func (p *StepPolicy) delay(retry int) time.Duration {
+	delay := p.Backoff
+	for i := 1; i < retry && p.BackoffMultiplier > 1; i++ {
+		delay = time.Duration(float64(delay) * p.BackoffMultiplier)
+		if p.MaxBackoff > 0 && delay >= p.MaxBackoff {
+			break
+		}
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return delay
+}
+
+// executeWithPolicy executes the use case of the step applying its policy.
+func executeWithPolicy[I any, O any](
+	ctx *app_context.AppContext,
+	s DagStep[I, O],
+	name string,
+	locale locales.LocaleTypeEnum,
+	input I,
+) *UseCaseResult[O] {
+	if s.policy == nil {
+		return s.uc.Execute(ctx, locale, input)
+	}
+	if name == "" {
+		name = fmt.Sprintf("%T", s.uc)
+	}
+	metrics := observability.GetObservabilityComponents().Metrics
+	tags := map[string]string{
+		"dag_step": name,
+	}
+
+	var breaker *circuitBreaker
+	if s.policy.CircuitBreaker != nil {
+		breaker = circuitBreakers.get(name, *s.policy.CircuitBreaker)
+	}
+
+	var res *UseCaseResult[O]
+	maxAttempts := s.policy.attempts()
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		if attempt > 1 {
+			metrics.IncrementCounter("dag.step.retry."+name, tags)
+			if !sleepContext(ctx, s.policy.delay(attempt-1)) {
+				return stepContextError[O](ctx, name)
+			}
+		}
+		if ctx.Err() != nil {
+			return stepContextError[O](ctx, name)
+		}
+		if breaker != nil && !breaker.allow() {
+			metrics.IncrementCounter("dag.step.circuit_open."+name, tags)
+			res = NewUseCaseResult[O]()
+			res.SetError(status.ProviderError, "circuit open for step "+name)
+			return res
+		}
+
+		res = executeAttempt(ctx, s, name, locale, input)
+		failed := res == nil || res.HasError()
+		if breaker != nil {
+			breaker.record(!failed || (res != nil && !s.policy.isFailure(res.StatusCode)))
+		}
+		if !failed || res == nil || !s.policy.isRetryable(res.StatusCode) {
+			return res
+		}
+	}
+	return res
+}
+
+// executeAttempt executes the use case once, bounded by the step timeout.
+// When the timeout is reached the use case context is cancelled and a
+// timeout error is returned without waiting for the use case to finish.
+func executeAttempt[I any, O any](
+	ctx *app_context.AppContext,
+	s DagStep[I, O],
+	name string,
+	locale locales.LocaleTypeEnum,
+	input I,
+) *UseCaseResult[O] {
+	if s.policy.Timeout <= 0 {
+		return s.uc.Execute(ctx, locale, input)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, s.policy.Timeout)
+	defer cancel()
+	attemptCtx := ctx.Clone()
+	attemptCtx.Context = timeoutCtx
+
+	done := make(chan *UseCaseResult[O], 1)
+	go func() {
+		done <- s.uc.Execute(attemptCtx, locale, input)
+	}()
+
+	select {
+	case res := <-done:
+		return res
+	case <-timeoutCtx.Done():
+		metrics := observability.GetObservabilityComponents().Metrics
+		metrics.IncrementCounter("dag.step.timeout."+name, map[string]string{
+			"dag_step": name,
+		})
+		res := NewUseCaseResult[O]()
+		res.SetError(status.InternalError, "step "+name+" timed out after "+s.policy.Timeout.String())
+		return res
+	}
+}
+
+// stepContextError builds the result of a step whose AppContext is done.
+func stepContextError[O any](ctx *app_context.AppContext, name string) *UseCaseResult[O] {
+	res := NewUseCaseResult[O]()
+	res.SetError(status.InternalError, "step "+name+" cancelled: "+ctx.Err().Error())
+	return res
+}
+
+// sleepContext waits for the given duration or until the context is done.
+// It returns false if the context was done before the duration elapsed.
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// circuitBreaker is a consecutive-failures circuit breaker.
+type circuitBreaker struct {
+	mu       sync.Mutex
+	policy   CircuitBreakerPolicy
+	failures int
+	openedAt time.Time
+	trial    bool
+}
+
+// allow reports whether an execution may go through.
+// Once OpenDuration elapses a single trial execution is allowed (half-open).
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.openedAt.IsZero() {
+		return true
+	}
+	if b.trial || time.Since(b.openedAt) < b.policy.OpenDuration {
+		return false
+	}
+	b.trial = true
+	return true
+}
+
+// record updates the breaker with the outcome of an execution.
+func (b *circuitBreaker) record(success bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+	if success {
+		b.failures = 0
+		b.openedAt = time.Time{}
+		return
+	}
+	b.failures++
+	if !b.openedAt.IsZero() || (b.policy.FailureThreshold > 0 && b.failures >= b.policy.FailureThreshold) {
+		b.openedAt = time.Now()
+	}
+}
+
+// circuitBreakerRegistry holds the circuit breakers keyed by step name.
+type circuitBreakerRegistry struct {
+	mu       sync.Mutex
+	breakers map[string]*circuitBreaker
+}
+
+var circuitBreakers = &circuitBreakerRegistry{breakers: make(map[string]*circuitBreaker)}
+
+// get returns the breaker of the step, creating it with the policy
+// of the first step registered under that name.
+func (r *circuitBreakerRegistry) get(name string, policy CircuitBreakerPolicy) *circuitBreaker {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	breaker, ok := r.breakers[name]
+	if !ok {
+		breaker = &circuitBreaker{policy: policy}
+		r.breakers[name] = breaker
+	}
+	return breaker
+}
+
+// ResetCircuitBreakers closes and forgets every step circuit breaker.
+// It is intended for tests.
+func ResetCircuitBreakers() {
+	circuitBreakers.mu.Lock()
+	defer circuitBreakers.mu.Unlock()
+	circuitBreakers.breakers = make(map[string]*circuitBreaker)
+}
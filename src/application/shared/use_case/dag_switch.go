@@ -0,0 +1,139 @@
+package usecase
+
+import (
+	contractsobservability "github.com/simon3640/goprojectskeleton/src/application/contracts/observability"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+)
+
+// Case is a conditional branch of a Switch.
+// It wraps a DagStep that is executed when the predicate matches the
+// output of the previous step.
+type Case[O any, P any] struct {
+	name  string
+	match func(O) bool
+	step  DagStep[O, P]
+}
+
+// NewCase creates a named case executed when match returns true.
+// The name identifies the case in spans, metrics and graphs,
+// so it must be unique inside the same Switch call.
+func NewCase[O any, P any](name string, match func(O) bool, step DagStep[O, P]) Case[O, P] {
+	return Case[O, P]{name: name, match: match, step: step}
+}
+
+// DefaultCase creates a named case that always matches.
+// It must be the last case of a Switch.
+func DefaultCase[O any, P any](name string, step DagStep[O, P]) Case[O, P] {
+	return NewCase(name, func(O) bool { return true }, step)
+}
+
+// When routes the output of the DAG to thenStep if the predicate returns
+// true and to elseStep otherwise, and returns a NEW DAG with the output
+// type of both steps.
+//
+// It is a Switch with a "then" and an "else" case.
+func When[I any, O any, P any](d *DAG[I, O], name string, predicate func(O) bool, thenStep DagStep[O, P], elseStep DagStep[O, P]) *DAG[I, P] {
+	return Switch(d, name,
+		NewCase("then", predicate, thenStep),
+		DefaultCase("else", elseStep),
+	)
+}
+
+// Switch routes the output of the DAG to the first case whose predicate
+// matches and returns a NEW DAG with the output type of the cases.
+//
+// Execution semantics:
+//   - The previous DAG is executed first; if it fails, no case is evaluated.
+//   - Cases are evaluated in order and only the first matching case is
+//     executed, with its policy and compensation like a step chained with Then.
+//   - The chosen case is recorded in the `dag_case` attribute of the
+//     `dag.step.execute.<name>` span and in the `dag.switch.<name>` counter.
+//   - If no case matches, an internal error is returned.
+//
+// The step of a case is named `<name>.<case>` in its own spans, metrics and
+// circuit breaker.
+//
+// Note:
+//   - Background steps are not carried over, since they are bound to the
+//     previous output type.
+func Switch[I any, O any, P any](d *DAG[I, O], name string, cases ...Case[O, P]) *DAG[I, P] {
+	copied := make([]Case[O, P], len(cases))
+	copy(copied, cases)
+
+	run := func(ctx *app_context.AppContext, stack *compensationStack, input I) *UseCaseResult[P] {
+		r1 := d.run(ctx, stack, input)
+		if r1 == nil {
+			return nil
+		}
+		if r1.HasError() {
+			return &UseCaseResult[P]{
+				StatusCode: r1.StatusCode,
+				Success:    false,
+				Error:      r1.Error,
+				Details:    r1.Details,
+			}
+		}
+
+		tracer := observability.GetObservabilityComponents().Tracer
+		clock := observability.GetObservabilityComponents().Clock
+		metrics := observability.GetObservabilityComponents().Metrics
+
+		span := tracer.StartSpan(ctx, "dag.step.execute."+name)
+		defer span.End()
+		span.UpdateAppContext(ctx)
+
+		out := *r1.Data
+		for _, c := range copied {
+			if !c.match(out) {
+				continue
+			}
+			span.SetAttribute("dag_case", c.name)
+			tags := map[string]string{
+				"dag_step": name,
+				"dag_case": c.name,
+			}
+			metrics.IncrementCounter("dag.switch."+name, tags)
+
+			start := clock.Now()
+			res := c.step.execute(ctx, stack, name+"."+c.name, d.locale, out)
+			metrics.RecordLatency("dag.step.execute."+name, clock.Now().Sub(start), tags)
+
+			if res == nil {
+				span.SetStatus(contractsobservability.SpanStatusError, "nil result")
+				tags["status"] = "error"
+				metrics.IncrementCounter("dag.step.error."+name, tags)
+				return nil
+			}
+			if res.HasError() {
+				span.SetStatus(contractsobservability.SpanStatusError, res.GetError().Error())
+				tags["status"] = "error"
+				metrics.IncrementCounter("dag.step.error."+name, tags)
+				return res
+			}
+			span.SetStatus(contractsobservability.SpanStatusOK, "")
+			return res
+		}
+
+		span.SetStatus(contractsobservability.SpanStatusError, "no case matched")
+		result := NewUseCaseResult[P]()
+		result.SetError(status.InternalError, "no case matched in switch "+name)
+		return result
+	}
+
+	nodes := make([]DagNode, 0, len(copied))
+	for _, c := range copied {
+		nodes = append(nodes, c.step.describe(name+"."+c.name, DagNodeCase))
+	}
+	node := DagNode{Name: name, Kind: DagNodeSwitch, Input: typeName[O](), Output: typeName[O]()}
+
+	return &DAG[I, P]{
+		run:         run,
+		ctx:         d.ctx,
+		locale:      d.locale,
+		_background: make([]backgroundEntry[P], 0),
+		executor:    d.executor,
+		graph:       d.graph.then(node).fanOut(nodes),
+	}
+}
@@ -0,0 +1,134 @@
+package usecase
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	contractsobservability "github.com/simon3640/goprojectskeleton/src/application/contracts/observability"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingTracer records the attributes of the spans by span name
+type recordingTracer struct {
+	contractsobservability.Tracer
+	mu    sync.Mutex
+	attrs map[string]map[string]interface{}
+}
+
+type recordingSpan struct {
+	contractsobservability.Span
+	tracer *recordingTracer
+	name   string
+}
+
+func (t *recordingTracer) StartSpan(carrier contractsobservability.TraceContextCarrier, name string, opts ...contractsobservability.SpanOption) contractsobservability.Span {
+	return &recordingSpan{Span: t.Tracer.StartSpan(carrier, name, opts...), tracer: t, name: name}
+}
+
+func (s *recordingSpan) SetAttribute(key string, value interface{}) {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	if s.tracer.attrs[s.name] == nil {
+		s.tracer.attrs[s.name] = make(map[string]interface{})
+	}
+	s.tracer.attrs[s.name][key] = value
+	s.Span.SetAttribute(key, value)
+}
+
+func (t *recordingTracer) attribute(span string, key string) interface{} {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.attrs[span][key]
+}
+
+func TestDagWhen(t *testing.T) {
+	assert := assert.New(t)
+
+	components := observability.GetObservabilityComponents()
+	tracer := &recordingTracer{Tracer: components.Tracer, attrs: make(map[string]map[string]interface{})}
+	components.Tracer = tracer
+	defer func() { components.Tracer = tracer.Tracer }()
+
+	appCtx := &app_context.AppContext{Context: context.Background()}
+	isEven := func(n int) bool { return n%2 == 0 }
+	dag := When(NewDag(appCtx, NewStep(&UCStringToInt{}), locales.EN_US, nil), "parity",
+		isEven, NewStep(&UCIntExponent{}), NewStep(&UCFlakyInt{}))
+	dagString := Then(dag, NewStep(&UCIntToString{}), "to-string")
+
+	result := dagString.Execute("4")
+	assert.True(result.IsSuccess())
+	assert.Equal("16", *result.Data)
+	assert.Equal("then", tracer.attribute("dag.step.execute.parity", "dag_case"))
+
+	result = dagString.Execute("5")
+	assert.True(result.IsSuccess())
+	assert.Equal("6", *result.Data)
+	assert.Equal("else", tracer.attribute("dag.step.execute.parity", "dag_case"))
+
+	// The previous error is propagated without evaluating the cases
+	result = dagString.Execute("x")
+	assert.True(result.HasError())
+}
+
+func TestDagSwitch(t *testing.T) {
+	assert := assert.New(t)
+
+	appCtx := &app_context.AppContext{Context: context.Background()}
+	failing := &UCFlakyInt{failures: 1, code: status.ProviderError}
+	dag := Switch(NewDag(appCtx, NewStep(&UCStringToInt{}), locales.EN_US, nil), "size",
+		NewCase("small", func(n int) bool { return n < 10 }, NewStep(&UCIntExponent{})),
+		NewCase("medium", func(n int) bool { return n < 100 }, NewStep(&UCFail[int]{})),
+		NewCase("large", func(n int) bool { return n < 1000 }, NewStep(&UCFlakyInt{})),
+	)
+
+	result := dag.Execute("5")
+	assert.True(result.IsSuccess())
+	assert.Equal(25, *result.Data)
+
+	// Only the first matching case is executed
+	result = dag.Execute("50")
+	assert.True(result.HasError())
+
+	result = dag.Execute("500")
+	assert.True(result.IsSuccess())
+	assert.Equal(501, *result.Data)
+
+	result = dag.Execute("5000")
+	assert.True(result.HasError())
+	assert.Equal(status.InternalError, result.StatusCode)
+	assert.Contains(*result.Error, "no case matched in switch size")
+
+	// Case steps keep their policy
+	withPolicy := Switch(NewDag(appCtx, NewStep(&UCStringToInt{}), locales.EN_US, nil), "retry",
+		DefaultCase("default", WithPolicy(NewStep(failing), StepPolicy{MaxAttempts: 2, RetryOn: []status.ApplicationStatusEnum{status.ProviderError}})),
+	)
+	result = withPolicy.Execute("7")
+	assert.True(result.IsSuccess())
+	assert.Equal(2, failing.Calls())
+}
+
+func TestDagSwitchDescribe(t *testing.T) {
+	assert := assert.New(t)
+
+	appCtx := &app_context.AppContext{Context: context.Background()}
+	dag := When(NewDag(appCtx, NewStep(&UCStringToInt{}), locales.EN_US, nil), "parity",
+		func(n int) bool { return n%2 == 0 }, NewStep(&UCIntExponent{}), NewStep(&UCIntExponent{}))
+	graph := Then(dag, NewStep(&UCIntToString{}), "to-string").Describe()
+	assert.Equal(DagNodeSwitch, graph.Nodes[1].Kind)
+	assert.Equal("parity.then", graph.Nodes[2].Name)
+	assert.Equal(DagNodeCase, graph.Nodes[3].Kind)
+	assert.Equal("parity.else", graph.Nodes[3].Name)
+	assert.Equal([]DagEdge{
+		{From: "n0", To: "n1"},
+		{From: "n1", To: "n2"},
+		{From: "n1", To: "n3"},
+		{From: "n2", To: "n4"},
+		{From: "n3", To: "n4"},
+	}, graph.Edges)
+}
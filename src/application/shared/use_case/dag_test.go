@@ -388,3 +388,400 @@ func TestDagWithBackgroundChain(t *testing.T) {
 	assert.Equal(1, len(logged))
 	assert.Equal("25", logged[0])
 }
+
+func TestDagParallelJoin(t *testing.T) {
+	assert := assert.New(t)
+
+	appCtx := &app_context.AppContext{Context: context.Background()}
+	executor := workers.NewBackgroundExecutor(appCtx, 4, 100)
+	executor.Start()
+	defer executor.Stop()
+
+	UC1 := &UCStringToInt{}
+	UC2 := &UCIntExponent{}
+	UC3 := &UCIntToString{}
+
+	squareBranch := NewBranch(NewStep(UC2), "square")
+	stringBranch := NewBranch(NewStep(UC3), "to-string")
+
+	dag := NewDag(appCtx, NewStep(UC1), locales.EN_US, executor)
+	joined := Join(Parallel(dag, squareBranch, stringBranch), func(input int, results BranchResults) string {
+		return strconv.Itoa(input) + ":" + strconv.Itoa(squareBranch.Output(results)) + ":" + stringBranch.Output(results)
+	})
+	dagFinal := Then(joined, NewStep(&UCStringToInt{}), "back-to-int")
+
+	result := joined.Execute("5")
+	assert.NotNil(result)
+	assert.True(result.IsSuccess())
+	assert.Equal("5:25:5", *result.Data)
+
+	// The joined output feeds the next step; "5:25:5" is not an int
+	result2 := dagFinal.Execute("5")
+	assert.NotNil(result2)
+	assert.True(result2.HasError())
+}
+
+func TestDagParallelJoinBranchError(t *testing.T) {
+	assert := assert.New(t)
+
+	appCtx := &app_context.AppContext{Context: context.Background()}
+
+	combinerCalled := false
+
+	dag := NewDag(appCtx, NewStep(&UCIntToString{}), locales.EN_US, nil)
+	joined := Join(
+		Parallel(dag,
+			NewBranch(NewStep(&UCStringToInt{}), "parse"),
+			NewBranch(NewStep(&UCLogBackgroundString{}), "log"),
+		),
+		func(_ string, _ BranchResults) int {
+			combinerCalled = true
+			return 0
+		},
+	)
+
+	result := joined.Execute(7)
+	assert.NotNil(result)
+	assert.True(result.IsSuccess())
+	assert.True(combinerCalled)
+
+	combinerCalled = false
+	dagFail := NewDag(appCtx, NewStep(&UCLogBackgroundString{}), locales.EN_US, nil)
+	joinedFail := Join(
+		Parallel(dagFail,
+			NewBranch(NewStep(&UCStringToInt{}), "parse"),
+		),
+		func(_ string, _ BranchResults) int {
+			combinerCalled = true
+			return 0
+		},
+	)
+	resultFail := joinedFail.Execute("x")
+	assert.NotNil(resultFail)
+	assert.True(resultFail.HasError())
+	assert.Equal(status.InternalError, resultFail.StatusCode)
+	assert.False(combinerCalled)
+}
+
+// Use case que registra el maximo de ejecuciones concurrentes
+type UCTrackConcurrency struct {
+	mu        sync.Mutex
+	active    int
+	maxActive int
+}
+
+func (uc *UCTrackConcurrency) SetLocale(_ locales.LocaleTypeEnum) {}
+func (uc *UCTrackConcurrency) Execute(_ *app_context.AppContext, _ locales.LocaleTypeEnum, input int) *UseCaseResult[int] {
+	uc.mu.Lock()
+	uc.active++
+	uc.maxActive = max(uc.maxActive, uc.active)
+	uc.mu.Unlock()
+
+	time.Sleep(20 * time.Millisecond)
+
+	uc.mu.Lock()
+	uc.active--
+	uc.mu.Unlock()
+	result := NewUseCaseResult[int]()
+	result.SetData(status.Success, input, "Tracked")
+	return result
+}
+
+func (uc *UCTrackConcurrency) SetAppContext(_ *app_context.AppContext) {}
+
+func (uc *UCTrackConcurrency) MaxActive() int {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	return uc.maxActive
+}
+
+func TestDagParallelJoinInTransaction(t *testing.T) {
+	assert := assert.New(t)
+
+	newJoined := func(appCtx *app_context.AppContext, tracker *UCTrackConcurrency) *DAG[string, int] {
+		dag := NewDag(appCtx, NewStep(&UCStringToInt{}), locales.EN_US, nil)
+		return Join(
+			Parallel(dag,
+				NewBranch(NewStep(tracker), "first"),
+				NewBranch(NewStep(tracker), "second"),
+			),
+			func(input int, _ BranchResults) int { return input },
+		)
+	}
+
+	concurrent := &UCTrackConcurrency{}
+	result := newJoined(&app_context.AppContext{Context: context.Background()}, concurrent).Execute("5")
+	assert.True(result.IsSuccess())
+	assert.Equal(2, concurrent.MaxActive())
+
+	// The branches do not share the transaction concurrently
+	txCtx := &app_context.AppContext{Context: context.Background()}
+	txCtx.SetTransaction("tx")
+	sequential := &UCTrackConcurrency{}
+	result = newJoined(txCtx, sequential).Execute("5")
+	assert.True(result.IsSuccess())
+	assert.Equal(5, *result.Data)
+	assert.Equal(1, sequential.MaxActive())
+}
+
+// Use case para pruebas de compensaciones
+type UCRecordCompensation struct {
+	mu    *sync.Mutex
+	calls *[]string
+	name  string
+	fail  bool
+}
+
+func (uc *UCRecordCompensation) SetLocale(_ locales.LocaleTypeEnum) {}
+func (uc *UCRecordCompensation) Execute(_ *app_context.AppContext, _ locales.LocaleTypeEnum, input int) *UseCaseResult[bool] {
+	uc.mu.Lock()
+	*uc.calls = append(*uc.calls, uc.name+":"+strconv.Itoa(input))
+	uc.mu.Unlock()
+	result := NewUseCaseResult[bool]()
+	if uc.fail {
+		result.SetError(status.InternalError, "compensation failed")
+		return result
+	}
+	result.SetData(status.Success, true, "Compensated")
+	return result
+}
+
+func (uc *UCRecordCompensation) SetAppContext(_ *app_context.AppContext) {}
+
+var _ BaseUseCase[int, bool] = (*UCRecordCompensation)(nil)
+
+// Use case que siempre falla
+type UCFail[I any] struct{}
+
+func (uc *UCFail[I]) SetLocale(_ locales.LocaleTypeEnum) {}
+func (uc *UCFail[I]) Execute(_ *app_context.AppContext, _ locales.LocaleTypeEnum, _ I) *UseCaseResult[I] {
+	result := NewUseCaseResult[I]()
+	result.SetError(status.Conflict, "step failed")
+	return result
+}
+
+func (uc *UCFail[I]) SetAppContext(_ *app_context.AppContext) {}
+
+func TestDagCompensationOnFailure(t *testing.T) {
+	assert := assert.New(t)
+
+	appCtx := &app_context.AppContext{Context: context.Background()}
+	var mu sync.Mutex
+	calls := make([]string, 0)
+
+	parse := WithCompensation(NewStep(&UCStringToInt{}), &UCRecordCompensation{mu: &mu, calls: &calls, name: "undo-parse"}, "undo-parse")
+	square := WithCompensation(NewStep(&UCIntExponent{}), &UCRecordCompensation{mu: &mu, calls: &calls, name: "undo-square", fail: true}, "undo-square")
+
+	dag := Then(NewDag(appCtx, parse, locales.EN_US, nil), square, "square")
+	dagOK := Then(dag, NewStep(&UCIntToString{}), "to-string")
+	dagFail := Then(dagOK, NewStep(&UCFail[string]{}), "fail")
+
+	// No compensation runs when the DAG succeeds
+	result := dagOK.Execute("5")
+	assert.NotNil(result)
+	assert.True(result.IsSuccess())
+	assert.Empty(calls)
+
+	// No compensation runs when the first step fails
+	result = dagOK.Execute("x")
+	assert.True(result.HasError())
+	assert.Empty(calls)
+
+	// Completed steps are compensated in reverse order
+	result = dagFail.Execute("5")
+	assert.NotNil(result)
+	assert.True(result.HasError())
+	assert.Equal(status.Conflict, result.StatusCode)
+	assert.Equal([]string{"undo-square:25", "undo-parse:5"}, calls)
+	assert.Contains(result.Details, "compensations: undo-square: failed (compensation failed), undo-parse: success")
+}
+
+func TestDagCompensationParallelBranches(t *testing.T) {
+	assert := assert.New(t)
+
+	appCtx := &app_context.AppContext{Context: context.Background()}
+	var mu sync.Mutex
+	calls := make([]string, 0)
+
+	parse := WithCompensation(NewStep(&UCStringToInt{}), &UCRecordCompensation{mu: &mu, calls: &calls, name: "undo-parse"}, "undo-parse")
+	square := WithCompensation(NewStep(&UCIntExponent{}), &UCRecordCompensation{mu: &mu, calls: &calls, name: "undo-square"}, "undo-square")
+
+	dag := Then(NewDag(appCtx, parse, locales.EN_US, nil), NewStep(&UCIntToString{}), "to-string")
+	joined := Join(
+		Parallel(Then(dag, NewStep(&UCStringToInt{}), "parse-again"),
+			NewBranch(square, "square"),
+			NewBranch(NewStep(&UCFail[int]{}), "fail"),
+		),
+		func(input int, _ BranchResults) int { return input },
+	)
+
+	result := joined.Execute("3")
+	assert.NotNil(result)
+	assert.True(result.HasError())
+	assert.Equal([]string{"undo-square:9", "undo-parse:3"}, calls)
+}
+
+// Use case que falla las primeras veces con el status indicado
+type UCFlakyInt struct {
+	mu       sync.Mutex
+	failures int
+	code     status.ApplicationStatusEnum
+	calls    int
+	delay    time.Duration
+}
+
+func (uc *UCFlakyInt) SetLocale(_ locales.LocaleTypeEnum) {}
+func (uc *UCFlakyInt) Execute(ctx *app_context.AppContext, _ locales.LocaleTypeEnum, input int) *UseCaseResult[int] {
+	uc.mu.Lock()
+	uc.calls++
+	calls := uc.calls
+	uc.mu.Unlock()
+	result := NewUseCaseResult[int]()
+	if uc.delay > 0 {
+		select {
+		case <-time.After(uc.delay):
+		case <-ctx.Done():
+			result.SetError(status.InternalError, ctx.Err().Error())
+			return result
+		}
+	}
+	if calls <= uc.failures {
+		result.SetError(uc.code, "transient failure")
+		return result
+	}
+	result.SetData(status.Success, input+1, "Incremented")
+	return result
+}
+
+func (uc *UCFlakyInt) SetAppContext(_ *app_context.AppContext) {}
+
+func (uc *UCFlakyInt) Calls() int {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+	return uc.calls
+}
+
+func TestDagStepPolicyRetry(t *testing.T) {
+	assert := assert.New(t)
+
+	appCtx := &app_context.AppContext{Context: context.Background()}
+	policy := StepPolicy{
+		MaxAttempts: 3,
+		Backoff:     time.Millisecond,
+		RetryOn:     []status.ApplicationStatusEnum{status.ProviderError},
+	}
+
+	flaky := &UCFlakyInt{failures: 2, code: status.ProviderError}
+	dag := Then(NewDag(appCtx, NewStep(&UCStringToInt{}), locales.EN_US, nil), WithPolicy(NewStep(flaky), policy), "flaky")
+	result := dag.Execute("1")
+	assert.True(result.IsSuccess())
+	assert.Equal(2, *result.Data)
+	assert.Equal(3, flaky.Calls())
+
+	// Exhausted attempts return the last error
+	exhausted := &UCFlakyInt{failures: 5, code: status.ProviderError}
+	dag = Then(NewDag(appCtx, NewStep(&UCStringToInt{}), locales.EN_US, nil), WithPolicy(NewStep(exhausted), policy), "exhausted")
+	result = dag.Execute("1")
+	assert.True(result.HasError())
+	assert.Equal(status.ProviderError, result.StatusCode)
+	assert.Equal(3, exhausted.Calls())
+
+	// Non retryable status is not retried
+	conflict := &UCFlakyInt{failures: 1, code: status.Conflict}
+	dag = Then(NewDag(appCtx, NewStep(&UCStringToInt{}), locales.EN_US, nil), WithPolicy(NewStep(conflict), policy), "conflict")
+	result = dag.Execute("1")
+	assert.True(result.HasError())
+	assert.Equal(status.Conflict, result.StatusCode)
+	assert.Equal(1, conflict.Calls())
+}
+
+func TestDagStepPolicyBackground(t *testing.T) {
+	assert := assert.New(t)
+
+	appCtx := &app_context.AppContext{Context: context.Background()}
+	flaky := &UCFlakyInt{failures: 2, code: status.ProviderError}
+	step := WithPolicy(NewStep(flaky), StepPolicy{
+		MaxAttempts: 3,
+		Backoff:     time.Millisecond,
+		RetryOn:     []status.ApplicationStatusEnum{status.ProviderError},
+	})
+
+	dag := ThenBackground(NewDag(appCtx, NewStep(&UCStringToInt{}), locales.EN_US, nil), step, "flaky-background")
+	result := dag.Execute("1")
+	assert.True(result.IsSuccess())
+	assert.Eventually(func() bool { return flaky.Calls() == 3 }, time.Second, 10*time.Millisecond)
+}
+
+func TestDagStepPolicyTimeout(t *testing.T) {
+	assert := assert.New(t)
+
+	appCtx := &app_context.AppContext{Context: context.Background()}
+	slow := &UCFlakyInt{delay: time.Second}
+	step := WithPolicy(NewStep(slow), StepPolicy{Timeout: 20 * time.Millisecond})
+	dag := Then(NewDag(appCtx, NewStep(&UCStringToInt{}), locales.EN_US, nil), step, "slow")
+
+	start := time.Now()
+	result := dag.Execute("1")
+	assert.True(result.HasError())
+	assert.Equal(status.InternalError, result.StatusCode)
+	assert.Contains(*result.Error, "timed out")
+	assert.Less(time.Since(start), 500*time.Millisecond)
+
+	// A cancelled AppContext stops the retries
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	flaky := &UCFlakyInt{failures: 1, code: status.ProviderError}
+	step = WithPolicy(NewStep(flaky), StepPolicy{MaxAttempts: 3, RetryOn: []status.ApplicationStatusEnum{status.ProviderError}})
+	dag = Then(NewDag(&app_context.AppContext{Context: cancelled}, NewStep(&UCStringToInt{}), locales.EN_US, nil), step, "cancelled")
+	result = dag.Execute("1")
+	assert.True(result.HasError())
+	assert.Equal(0, flaky.Calls())
+}
+
+func TestDagStepPolicyCircuitBreaker(t *testing.T) {
+	assert := assert.New(t)
+	ResetCircuitBreakers()
+	defer ResetCircuitBreakers()
+
+	appCtx := &app_context.AppContext{Context: context.Background()}
+	failing := &UCFlakyInt{failures: 2, code: status.ProviderError}
+	step := WithPolicy(NewStep(failing), StepPolicy{
+		CircuitBreaker: &CircuitBreakerPolicy{FailureThreshold: 2, OpenDuration: 30 * time.Millisecond},
+	})
+	dag := Then(NewDag(appCtx, NewStep(&UCStringToInt{}), locales.EN_US, nil), step, "breaker")
+
+	assert.Equal(status.ProviderError, dag.Execute("1").StatusCode)
+	assert.Equal(status.ProviderError, dag.Execute("1").StatusCode)
+
+	// The circuit is open: the use case is not executed
+	result := dag.Execute("1")
+	assert.True(result.HasError())
+	assert.Contains(*result.Error, "circuit open")
+	assert.Equal(2, failing.Calls())
+
+	// After OpenDuration a trial execution closes the circuit again
+	time.Sleep(40 * time.Millisecond)
+	result = dag.Execute("1")
+	assert.True(result.IsSuccess())
+	assert.Equal(3, failing.Calls())
+}
+
+func TestDagStepPolicyCircuitBreakerClientErrors(t *testing.T) {
+	assert := assert.New(t)
+	ResetCircuitBreakers()
+	defer ResetCircuitBreakers()
+
+	appCtx := &app_context.AppContext{Context: context.Background()}
+	notFound := &UCFlakyInt{failures: 3, code: status.NotFound}
+	step := WithPolicy(NewStep(notFound), StepPolicy{
+		CircuitBreaker: &CircuitBreakerPolicy{FailureThreshold: 2, OpenDuration: time.Minute},
+	})
+	dag := Then(NewDag(appCtx, NewStep(&UCStringToInt{}), locales.EN_US, nil), step, "breaker_client_errors")
+
+	// Errors answered by the dependency do not open the circuit
+	for i := 0; i < 3; i++ {
+		assert.Equal(status.NotFound, dag.Execute("1").StatusCode)
+	}
+	assert.True(dag.Execute("1").IsSuccess())
+	assert.Equal(4, notFound.Calls())
+}
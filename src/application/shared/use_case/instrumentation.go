@@ -8,6 +8,9 @@ import (
 )
 
 // InstrumentUseCase executes a UseCase with automatic instrumentation
+//
+// Deprecated: use Intercept, which reads the observability components
+// itself and runs the global middlewares (see Use).
 func InstrumentUseCase[Input any, Output any](
 	uc BaseUseCase[Input, Output],
 	appCtx *app_context.AppContext,
@@ -0,0 +1,325 @@
+package usecase
+
+import (
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"sync"
+
+	contractsobservability "github.com/simon3640/goprojectskeleton/src/application/contracts/observability"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+)
+
+// Result is the type-erased view of a UseCaseResult seen by middlewares.
+// It is implemented by *UseCaseResult.
+type Result interface {
+	HasError() bool
+	GetError() error
+	GetStatusCode() status.ApplicationStatusEnum
+}
+
+// Invocation describes an execution of a use case going through its
+// middleware chain. Middlewares may replace the Context and the Locale
+// before calling the next middleware; the Input must keep its type.
+// UseCase is the intercepted use case.
+type Invocation struct {
+	Name    string
+	Context *app_context.AppContext
+	Locale  locales.LocaleTypeEnum
+	Input   any
+	UseCase any
+	fail    func(code status.ApplicationStatusEnum, err string) Result
+	empty   func() Result
+}
+
+// Fail builds a failed result of the output type of the use case.
+// Middlewares use it to short-circuit the chain.
+func (inv *Invocation) Fail(code status.ApplicationStatusEnum, err string) Result {
+	return inv.fail(code, err)
+}
+
+// Next calls the next middleware of the chain, or the use case itself.
+type Next func(inv *Invocation) Result
+
+// Middleware intercepts the execution of a use case.
+// It must call next to continue the chain, or return its own result
+// (see Invocation.Fail) to short-circuit it.
+type Middleware func(inv *Invocation, next Next) Result
+
+var (
+	globalMiddlewares   = []Middleware{Recovery(), Tracing(), Metrics(), Validation(), Authorization()}
+	useCaseMiddlewares  = make(map[string][]Middleware)
+	globalMiddlewaresMu sync.RWMutex
+)
+
+// Use replaces the global middlewares, executed in order before the
+// middlewares of every intercepted use case.
+// It must be called at startup. The defaults are Recovery, Tracing, Metrics,
+// Validation and Authorization.
+func Use(middlewares ...Middleware) {
+	globalMiddlewaresMu.Lock()
+	defer globalMiddlewaresMu.Unlock()
+	globalMiddlewares = append([]Middleware(nil), middlewares...)
+}
+
+// Register registers the middlewares of the use case with the given name,
+// executed in order after the global middlewares every time it is
+// intercepted. Registering a name again replaces its middlewares.
+// It must be called at startup.
+func Register(name string, middlewares ...Middleware) {
+	globalMiddlewaresMu.Lock()
+	defer globalMiddlewaresMu.Unlock()
+	useCaseMiddlewares[name] = append([]Middleware(nil), middlewares...)
+}
+
+// registeredMiddlewares returns a copy of the middlewares registered for the
+// use case with the given name.
+func registeredMiddlewares(name string) []Middleware {
+	globalMiddlewaresMu.RLock()
+	defer globalMiddlewaresMu.RUnlock()
+	return append([]Middleware(nil), useCaseMiddlewares[name]...)
+}
+
+// GlobalMiddlewares returns a copy of the global middlewares.
+func GlobalMiddlewares() []Middleware {
+	globalMiddlewaresMu.RLock()
+	defer globalMiddlewaresMu.RUnlock()
+	return append([]Middleware(nil), globalMiddlewares...)
+}
+
+// InterceptedUseCase is a use case wrapped by an ordered middleware chain.
+// It implements BaseUseCase, so it can be used wherever the use case is,
+// including DAG steps.
+type InterceptedUseCase[I any, O any] struct {
+	uc          BaseUseCase[I, O]
+	name        string
+	middlewares []Middleware
+}
+
+var _ BaseUseCase[any, any] = (*InterceptedUseCase[any, any])(nil)
+
+// Intercept wraps the use case with the global middlewares (see Use), the
+// middlewares registered for its name (see Register) and the given
+// middlewares, which depend on the request. The first middleware is the
+// outermost. The name identifies the use case in spans, metrics and logs.
+func Intercept[I any, O any](uc BaseUseCase[I, O], name string, middlewares ...Middleware) *InterceptedUseCase[I, O] {
+	chain := GlobalMiddlewares()
+	chain = append(chain, registeredMiddlewares(name)...)
+	chain = append(chain, middlewares...)
+	return &InterceptedUseCase[I, O]{uc: uc, name: name, middlewares: chain}
+}
+
+// Execute runs the middleware chain around the use case.
+func (i *InterceptedUseCase[I, O]) Execute(
+	ctx *app_context.AppContext,
+	locale locales.LocaleTypeEnum,
+	input I,
+) *UseCaseResult[O] {
+	inv := &Invocation{
+		Name:    i.name,
+		Context: ctx,
+		Locale:  locale,
+		Input:   input,
+		UseCase: i.uc,
+		fail: func(code status.ApplicationStatusEnum, err string) Result {
+			return NewUseCaseResult[O]().SetError(code, err)
+		},
+		empty: func() Result {
+			return NewUseCaseResult[O]()
+		},
+	}
+
+	next := func(inv *Invocation) Result {
+		in, ok := inv.Input.(I)
+		if !ok {
+			return inv.Fail(status.InternalError, fmt.Sprintf("invalid input %T for use case %s", inv.Input, i.name))
+		}
+		res := i.uc.Execute(inv.Context, inv.Locale, in)
+		if res == nil {
+			return nil
+		}
+		return res
+	}
+	for idx := len(i.middlewares) - 1; idx >= 0; idx-- {
+		middleware, inner := i.middlewares[idx], next
+		next = func(inv *Invocation) Result { return middleware(inv, inner) }
+	}
+
+	res := next(inv)
+	if res == nil {
+		return nil
+	}
+	typed, ok := res.(*UseCaseResult[O])
+	if !ok {
+		return NewUseCaseResult[O]().SetError(status.InternalError, fmt.Sprintf("invalid result %T for use case %s", res, i.name))
+	}
+	return typed
+}
+
+// SetLocale sets the locale of the wrapped use case.
+func (i *InterceptedUseCase[I, O]) SetLocale(locale locales.LocaleTypeEnum) {
+	i.uc.SetLocale(locale)
+}
+
+// SetAppContext sets the app context of the wrapped use case.
+func (i *InterceptedUseCase[I, O]) SetAppContext(appContext *app_context.AppContext) {
+	i.uc.SetAppContext(appContext)
+}
+
+// Recovery recovers panics of the use case and returns an internal error.
+func Recovery() Middleware {
+	return func(inv *Invocation, next Next) (res Result) {
+		defer func() {
+			if r := recover(); r != nil {
+				observability.GetObservabilityComponents().Logger.ErrorWithContext(
+					"panic recovered in use case "+inv.Name,
+					fmt.Errorf("%v\n%s", r, debug.Stack()),
+					inv.Context,
+				)
+				res = inv.Fail(
+					status.InternalError,
+					locales.NewLocale(locales.EN_US).Get(inv.Locale, messages.MessageKeysInstance.SOMETHING_WENT_WRONG),
+				)
+			}
+		}()
+		return next(inv)
+	}
+}
+
+// Tracing executes the use case inside a `usecase.<name>` span.
+func Tracing() Middleware {
+	return func(inv *Invocation, next Next) Result {
+		span := observability.GetObservabilityComponents().Tracer.StartSpan(inv.Context, "usecase."+inv.Name)
+		defer span.End()
+		span.UpdateAppContext(inv.Context)
+
+		res := next(inv)
+		switch {
+		case res == nil:
+			span.SetStatus(contractsobservability.SpanStatusError, "nil result")
+		case res.HasError():
+			span.SetStatus(contractsobservability.SpanStatusError, res.GetError().Error())
+		default:
+			span.SetStatus(contractsobservability.SpanStatusOK, "")
+		}
+		return res
+	}
+}
+
+// Metrics records the `usecase.execute` latency and the `usecase.success`
+// and `usecase.error` counters, tagged with the use case name.
+func Metrics() Middleware {
+	return func(inv *Invocation, next Next) Result {
+		clock := observability.GetObservabilityComponents().Clock
+		metrics := observability.GetObservabilityComponents().Metrics
+
+		start := clock.Now()
+		res := next(inv)
+		tags := map[string]string{
+			"usecase": inv.Name,
+		}
+		metrics.RecordLatency("usecase.execute", clock.Now().Sub(start), tags)
+
+		switch {
+		case res == nil:
+			tags["status"] = "error"
+			tags["status_code"] = string(status.InternalError)
+			metrics.IncrementCounter("usecase.error", tags)
+		case res.HasError():
+			tags["status"] = "error"
+			tags["status_code"] = string(res.GetStatusCode())
+			metrics.IncrementCounter("usecase.error", tags)
+		default:
+			tags["status"] = "success"
+			metrics.IncrementCounter("usecase.success", tags)
+		}
+		return res
+	}
+}
+
+// Validation rejects inputs whose Validate method returns errors,
+// like BaseUseCaseValidation.Validate.
+func Validation() Middleware {
+	return func(inv *Invocation, next Next) Result {
+		if validator, ok := inv.Input.(interface{ Validate() []string }); ok {
+			if errs := validator.Validate(); len(errs) > 0 {
+				return inv.Fail(status.InvalidInput, strings.Join(errs, "\n"))
+			}
+		}
+		return next(inv)
+	}
+}
+
+// Authorizer is implemented by the use cases authorizing their invocations,
+// like the use cases embedding BaseUseCaseValidation. Authorize returns the
+// failed result of a rejected invocation, or nil.
+type Authorizer interface {
+	Authorize(inv *Invocation) Result
+}
+
+// Authorization authorizes the invocations of the use cases implementing
+// Authorizer, with their guards, before the rest of the chain. It must
+// precede the middlewares that may skip the use case, like Cache, so they
+// only serve the actors allowed to execute it.
+func Authorization() Middleware {
+	return func(inv *Invocation, next Next) Result {
+		if authorizer, ok := inv.UseCase.(Authorizer); ok {
+			if res := authorizer.Authorize(inv); res != nil {
+				return res
+			}
+		}
+		return next(inv)
+	}
+}
+
+// WithGuards checks the guards against the user of the AppContext, for the
+// use cases that do not authorize their invocations themselves. Requests
+// without user are rejected.
+func WithGuards(guards ...Guard) Middleware {
+	list := NewGuards(guards...)
+	return func(inv *Invocation, next Next) Result {
+		if inv.Context == nil || inv.Context.User == nil {
+			return inv.Fail(
+				status.Unauthorized,
+				locales.NewLocale(locales.EN_US).Get(inv.Locale, messages.MessageKeysInstance.UNAUTHORIZED_RESOURCE),
+			)
+		}
+		guards := list
+		guards.SetActor(*inv.Context.User)
+		if err := guards.Validate(inv.Input); err != nil {
+			return inv.Fail(status.Unauthorized, locales.NewLocale(locales.EN_US).Get(inv.Locale, *err))
+		}
+		return next(inv)
+	}
+}
+
+// AuditLog logs every execution of the use case with its actor and status,
+// and the admin acting as the actor during impersonations.
+func AuditLog() Middleware {
+	return func(inv *Invocation, next Next) Result {
+		res := next(inv)
+		actor := "anonymous"
+		if inv.Context != nil && inv.Context.User != nil {
+			actor = fmt.Sprintf("user:%d", inv.Context.User.ID)
+			if inv.Context.User.IsClient() {
+				actor = "client:" + inv.Context.User.GetClientID()
+			}
+		}
+		if adminID, ok := inv.Context.Impersonator(); ok {
+			actor += fmt.Sprintf(" impersonator=user:%d", adminID)
+		}
+		outcome := string(status.InternalError)
+		if res != nil {
+			outcome = string(res.GetStatusCode())
+		}
+		observability.GetObservabilityComponents().Logger.InfoWithContext(
+			fmt.Sprintf("audit usecase=%s actor=%s status=%s", inv.Name, actor, outcome),
+			inv.Context,
+		)
+		return res
+	}
+}
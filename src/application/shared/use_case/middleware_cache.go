@@ -0,0 +1,159 @@
+package usecase
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	contractsproviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+)
+
+// Cacheable is implemented by inputs identifying a cacheable query,
+// like domainutils.QueryPayloadBuilder.
+type Cacheable interface {
+	CacheKey() string
+}
+
+// CachedMarker is implemented by outputs reporting whether they were served
+// from the cache, like shareddtos.MultipleResponse.
+type CachedMarker interface {
+	MarkCached()
+}
+
+// CacheTagTTL is the time an invalidation of a tag is remembered.
+// It must be greater than the TTL of every cached entry.
+var CacheTagTTL = 24 * time.Hour
+
+// CacheOptions configures the Cache middleware.
+type CacheOptions struct {
+	// Provider stores the cached results and the tag invalidations.
+	Provider contractsproviders.ICacheProvider
+	// TTL is the time a result is cached.
+	TTL time.Duration
+	// Key returns the cache key of the input. Defaults to Cacheable.CacheKey;
+	// inputs without key are never cached.
+	Key func(input any) string
+	// Tags returns the tags of the cached entry, e.g. "user:*" and "user:42".
+	Tags func(input any) []string
+}
+
+// cacheEntry is the cached result of a use case with its tags.
+type cacheEntry struct {
+	StoredAt int64           `json:"storedAt"`
+	Tags     []string        `json:"tags"`
+	Result   json.RawMessage `json:"result"`
+}
+
+// Cache is a read-through cache of the successful results of the use case,
+// keyed by the use case name, the input cache key, the locale and the tenant
+// of the request.
+//
+// An entry is discarded when one of its tags has been invalidated after the
+// use case started computing it (see InvalidateCache and InvalidateTags).
+// Cache errors are logged and never fail the use case.
+//
+// A hit skips the use case and its guards, so Cache must be placed after
+// the middlewares authorizing the request (see
+// BaseUseCaseValidation.Authorization).
+func Cache(opts CacheOptions) Middleware {
+	key := opts.Key
+	if key == nil {
+		key = func(input any) string {
+			if cacheable, ok := input.(Cacheable); ok {
+				return cacheable.CacheKey()
+			}
+			return ""
+		}
+	}
+	return func(inv *Invocation, next Next) Result {
+		inputKey := key(inv.Input)
+		if inputKey == "" || opts.Provider == nil {
+			return next(inv)
+		}
+		logger := observability.GetObservabilityComponents().Logger
+		cacheKey := "usecase:" + inv.Name + ":" + inputKey + ":" + string(inv.Locale)
+		if tenantID, ok := inv.Context.TenantID(); ok {
+			cacheKey += ":tenant:" + strconv.FormatUint(uint64(tenantID), 10)
+		}
+
+		var entry cacheEntry
+		found, err := opts.Provider.Get(cacheKey, &entry)
+		if err != nil {
+			logger.ErrorWithContext("Error getting cache for use case "+inv.Name, err.ToError(), inv.Context)
+		}
+		if found && cacheEntryValid(opts.Provider, entry) {
+			res := inv.empty()
+			if err := json.Unmarshal(entry.Result, res); err == nil {
+				if marker, ok := res.(interface{ markCached() }); ok {
+					marker.markCached()
+				}
+				logger.DebugWithContext("Cache hit for use case "+inv.Name, map[string]any{"cacheKey": cacheKey}, inv.Context)
+				return res
+			}
+		}
+
+		storedAt := observability.GetObservabilityComponents().Clock.Now().UnixNano()
+		res := next(inv)
+		if res == nil || res.HasError() {
+			return res
+		}
+		data, marshalErr := json.Marshal(res)
+		if marshalErr != nil {
+			logger.ErrorWithContext("Error encoding cache for use case "+inv.Name, marshalErr, inv.Context)
+			return res
+		}
+		entry = cacheEntry{StoredAt: storedAt, Result: data}
+		if opts.Tags != nil {
+			entry.Tags = opts.Tags(inv.Input)
+		}
+		if err := opts.Provider.Set(cacheKey, entry, opts.TTL); err != nil {
+			logger.ErrorWithContext("Error setting cache for use case "+inv.Name, err.ToError(), inv.Context)
+		}
+		return res
+	}
+}
+
+// cacheEntryValid checks that no tag of the entry was invalidated after it
+// started being computed.
+func cacheEntryValid(provider contractsproviders.ICacheProvider, entry cacheEntry) bool {
+	for _, tag := range entry.Tags {
+		invalidatedAt, err := provider.GetInt64(cacheTagKey(tag))
+		if err != nil || invalidatedAt >= entry.StoredAt {
+			return false
+		}
+	}
+	return true
+}
+
+// cacheTagKey builds the key storing the last invalidation of the tag.
+func cacheTagKey(tag string) string {
+	return "cache:tag:" + tag
+}
+
+// InvalidateCache invalidates the tags returned for the input once the use
+// case succeeds. Write use cases declare with it the entries they change.
+func InvalidateCache(provider contractsproviders.ICacheProvider, tags func(input any) []string) Middleware {
+	return func(inv *Invocation, next Next) Result {
+		res := next(inv)
+		if res == nil || res.HasError() || provider == nil {
+			return res
+		}
+		if err := InvalidateTags(provider, tags(inv.Input)...); err != nil {
+			observability.GetObservabilityComponents().Logger.ErrorWithContext("Error invalidating cache for use case "+inv.Name, err.ToError(), inv.Context)
+		}
+		return res
+	}
+}
+
+// InvalidateTags discards every cached entry carrying one of the tags.
+func InvalidateTags(provider contractsproviders.ICacheProvider, tags ...string) *applicationerrors.ApplicationError {
+	now := observability.GetObservabilityComponents().Clock.Now().UnixNano()
+	for _, tag := range tags {
+		if err := provider.Set(cacheTagKey(tag), now, CacheTagTTL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
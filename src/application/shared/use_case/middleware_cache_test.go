@@ -0,0 +1,178 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func intCacheOptions(cache *providersmocks.MemoryCacheProvider) CacheOptions {
+	return CacheOptions{
+		Provider: cache,
+		TTL:      time.Minute,
+		Key:      func(input any) string { return fmt.Sprint(input) },
+		Tags: func(input any) []string {
+			return []string{"number:*", fmt.Sprintf("number:%v", input)}
+		},
+	}
+}
+
+func TestCacheMiddleware(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := &app_context.AppContext{Context: context.Background()}
+	cache := providersmocks.NewMemoryCacheProvider()
+	uc := &UCFlakyInt{}
+	intercepted := Intercept(uc, "flaky", Cache(intCacheOptions(cache)))
+
+	result := intercepted.Execute(ctx, locales.EN_US, 1)
+	assert.True(result.IsSuccess())
+	assert.Equal(2, *result.Data)
+
+	result = intercepted.Execute(ctx, locales.EN_US, 1)
+	assert.True(result.IsSuccess())
+	assert.Equal(2, *result.Data)
+	assert.Equal(1, uc.Calls())
+
+	// The input and the locale are part of the key
+	intercepted.Execute(ctx, locales.EN_US, 2)
+	intercepted.Execute(ctx, locales.ES_ES, 1)
+	assert.Equal(3, uc.Calls())
+	assert.ElementsMatch([]string{
+		"usecase:flaky:1:" + string(locales.EN_US),
+		"usecase:flaky:2:" + string(locales.EN_US),
+		"usecase:flaky:1:" + string(locales.ES_ES),
+	}, cache.Keys())
+}
+
+func TestCacheMiddlewareTenant(t *testing.T) {
+	assert := assert.New(t)
+
+	user := usermodels.UserWithRole{ID: 1}
+	user.SetOrganizations([]uint{3})
+	user.SetTenant(3)
+	ctx := app_context.NewContextWithUser(&user)
+	cache := providersmocks.NewMemoryCacheProvider()
+	uc := &UCFlakyInt{}
+	intercepted := Intercept(uc, "flaky", Cache(intCacheOptions(cache)))
+
+	// Results of an organization are not served to the others
+	intercepted.Execute(ctx, locales.EN_US, 1)
+	intercepted.Execute(&app_context.AppContext{Context: context.Background()}, locales.EN_US, 1)
+	assert.Equal(2, uc.Calls())
+	assert.ElementsMatch([]string{
+		"usecase:flaky:1:" + string(locales.EN_US) + ":tenant:3",
+		"usecase:flaky:1:" + string(locales.EN_US),
+	}, cache.Keys())
+}
+
+func TestCacheMiddlewareInvalidation(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := &app_context.AppContext{Context: context.Background()}
+	cache := providersmocks.NewMemoryCacheProvider()
+	uc := &UCFlakyInt{}
+	intercepted := Intercept(uc, "flaky", Cache(intCacheOptions(cache)))
+
+	intercepted.Execute(ctx, locales.EN_US, 1)
+	intercepted.Execute(ctx, locales.EN_US, 2)
+	assert.Equal(2, uc.Calls())
+
+	// Only the entries carrying the tag are discarded
+	assert.Nil(InvalidateTags(cache, "number:1"))
+	intercepted.Execute(ctx, locales.EN_US, 1)
+	intercepted.Execute(ctx, locales.EN_US, 2)
+	assert.Equal(3, uc.Calls())
+
+	// The recomputed entry is valid again
+	intercepted.Execute(ctx, locales.EN_US, 1)
+	assert.Equal(3, uc.Calls())
+
+	assert.Nil(InvalidateTags(cache, "number:*"))
+	intercepted.Execute(ctx, locales.EN_US, 1)
+	intercepted.Execute(ctx, locales.EN_US, 2)
+	assert.Equal(5, uc.Calls())
+}
+
+func TestInvalidateCacheMiddleware(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := &app_context.AppContext{Context: context.Background()}
+	cache := providersmocks.NewMemoryCacheProvider()
+	reader := &UCFlakyInt{}
+	read := Intercept(reader, "read", Cache(intCacheOptions(cache)))
+	tags := func(input any) []string { return []string{fmt.Sprintf("number:%v", input)} }
+
+	read.Execute(ctx, locales.EN_US, 1)
+
+	// Failed writes do not invalidate anything
+	failedWrite := Intercept(&UCFail[int]{}, "write", InvalidateCache(cache, tags))
+	assert.True(failedWrite.Execute(ctx, locales.EN_US, 1).HasError())
+	read.Execute(ctx, locales.EN_US, 1)
+	assert.Equal(1, reader.Calls())
+
+	write := Intercept(&UCIntExponent{}, "write", InvalidateCache(cache, tags))
+	assert.True(write.Execute(ctx, locales.EN_US, 1).IsSuccess())
+	read.Execute(ctx, locales.EN_US, 1)
+	assert.Equal(2, reader.Calls())
+}
+
+func TestCacheMiddlewareSkipsErrorsAndKeylessInputs(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := &app_context.AppContext{Context: context.Background()}
+	cache := providersmocks.NewMemoryCacheProvider()
+	uc := &UCFlakyInt{failures: 1, code: status.ProviderError}
+	intercepted := Intercept(uc, "flaky", Cache(intCacheOptions(cache)))
+
+	assert.True(intercepted.Execute(ctx, locales.EN_US, 1).HasError())
+	assert.True(intercepted.Execute(ctx, locales.EN_US, 1).IsSuccess())
+	assert.True(intercepted.Execute(ctx, locales.EN_US, 1).IsSuccess())
+	assert.Equal(2, uc.Calls())
+
+	// Without Key, inputs that are not Cacheable are never cached
+	keyless := &UCFlakyInt{}
+	intercepted = Intercept(keyless, "keyless", Cache(CacheOptions{Provider: cache, TTL: time.Minute}))
+	intercepted.Execute(ctx, locales.EN_US, 1)
+	intercepted.Execute(ctx, locales.EN_US, 1)
+	assert.Equal(2, keyless.Calls())
+}
+
+func TestCacheMiddlewareProviderErrors(t *testing.T) {
+	assert := assert.New(t)
+
+	ctx := &app_context.AppContext{Context: context.Background()}
+	appErr := applicationerrors.NewApplicationError(
+		status.ProviderError,
+		messages.MessageKeysInstance.SOMETHING_WENT_WRONG,
+		"cache error",
+	)
+	cache := new(providersmocks.MockCacheProvider)
+	cache.On("Get", mock.Anything, mock.Anything).Return(false, appErr)
+	cache.On("Set", mock.Anything, mock.Anything, time.Minute).Return(appErr)
+
+	uc := &UCFlakyInt{}
+	intercepted := Intercept(uc, "flaky", Cache(CacheOptions{
+		Provider: cache,
+		TTL:      time.Minute,
+		Key:      func(input any) string { return fmt.Sprint(input) },
+	}))
+
+	// Cache errors never fail the use case
+	result := intercepted.Execute(ctx, locales.EN_US, 1)
+	assert.True(result.IsSuccess())
+	assert.Equal(2, *result.Data)
+	cache.AssertNumberOfCalls(t, "Set", 1)
+}
@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"testing"
+
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type UCPanic struct{}
+
+func (uc *UCPanic) SetLocale(_ locales.LocaleTypeEnum) {}
+func (uc *UCPanic) Execute(_ *app_context.AppContext, _ locales.LocaleTypeEnum, _ int) *UseCaseResult[int] {
+	panic("boom")
+}
+func (uc *UCPanic) SetAppContext(_ *app_context.AppContext) {}
+
+type validatedInput struct{ value string }
+
+func (v validatedInput) Validate() []string {
+	if v.value == "" {
+		return []string{"value is required"}
+	}
+	return nil
+}
+
+func recordMiddleware(calls *[]string, name string) Middleware {
+	return func(inv *Invocation, next Next) Result {
+		*calls = append(*calls, name+":before")
+		res := next(inv)
+		*calls = append(*calls, name+":after")
+		return res
+	}
+}
+
+func TestInterceptOrder(t *testing.T) {
+	assert := assert.New(t)
+	defer Use(GlobalMiddlewares()...)
+
+	calls := make([]string, 0)
+	Use(recordMiddleware(&calls, "global"))
+
+	uc := Intercept(&UCStringToInt{}, "string_to_int", recordMiddleware(&calls, "first"), recordMiddleware(&calls, "second"))
+	result := uc.Execute(app_context.NewVoidAppContext(), locales.EN_US, "5")
+
+	assert.True(result.IsSuccess())
+	assert.Equal(5, *result.Data)
+	assert.Equal([]string{
+		"global:before", "first:before", "second:before",
+		"second:after", "first:after", "global:after",
+	}, calls)
+}
+
+func TestInterceptShortCircuit(t *testing.T) {
+	assert := assert.New(t)
+
+	echo := &UCGuardedEcho{BaseUseCaseValidation[validatedInput, string]{
+		AppMessages: locales.NewLocale(locales.EN_US),
+	}}
+	calls := make([]string, 0)
+	uc := Intercept(echo, "echo", recordMiddleware(&calls, "inner"))
+
+	result := uc.Execute(app_context.NewVoidAppContext(), locales.EN_US, validatedInput{})
+	assert.True(result.HasError())
+	assert.Equal(status.InvalidInput, result.StatusCode)
+	assert.Equal("value is required", *result.Error)
+	assert.Empty(calls)
+
+	result = uc.Execute(app_context.NewVoidAppContext(), locales.EN_US, validatedInput{value: "ok"})
+	assert.True(result.IsSuccess())
+	assert.Equal("ok", *result.Data)
+}
+
+type UCGuardedEcho struct {
+	BaseUseCaseValidation[validatedInput, string]
+}
+
+func (uc *UCGuardedEcho) Execute(_ *app_context.AppContext, _ locales.LocaleTypeEnum, input validatedInput) *UseCaseResult[string] {
+	return NewUseCaseResult[string]().SetData(status.Success, input.value, "")
+}
+
+func TestInterceptAuthorization(t *testing.T) {
+	assert := assert.New(t)
+
+	onlyFirstUser := func(user usermodels.UserWithRole, _ any) *messages.MessageKeysEnum {
+		if user.ID != 1 {
+			return &messages.MessageKeysInstance.UNAUTHORIZED_RESOURCE
+		}
+		return nil
+	}
+	echo := &UCGuardedEcho{BaseUseCaseValidation[validatedInput, string]{
+		AppMessages: locales.NewLocale(locales.EN_US),
+		Guards:      NewGuards(onlyFirstUser).WithScopes("profile"),
+	}}
+	calls := make([]string, 0)
+	uc := Intercept(echo, "guarded_echo", recordMiddleware(&calls, "inner"))
+
+	result := uc.Execute(app_context.NewVoidAppContext(), locales.EN_US, validatedInput{value: "ok"})
+	assert.Equal(status.Unauthorized, result.StatusCode)
+
+	user := &usermodels.UserWithRole{ID: 1}
+	result = uc.Execute(app_context.NewContextWithUser(user), locales.EN_US, validatedInput{})
+	assert.Equal(status.InvalidInput, result.StatusCode)
+
+	result = uc.Execute(app_context.NewContextWithUser(&usermodels.UserWithRole{ID: 2}), locales.EN_US, validatedInput{value: "ok"})
+	assert.Equal(status.Unauthorized, result.StatusCode)
+
+	// Delegated actors need one of the scopes of the use case
+	user.SetScopes([]string{"users:read"})
+	result = uc.Execute(app_context.NewContextWithUser(user), locales.EN_US, validatedInput{value: "ok"})
+	assert.Equal(status.Unauthorized, result.StatusCode)
+	assert.Equal(locales.NewLocale(locales.EN_US).Get(locales.EN_US, messages.MessageKeysInstance.INSUFFICIENT_SCOPE), *result.Error)
+	assert.Empty(calls)
+
+	user.SetScopes([]string{"profile"})
+	result = uc.Execute(app_context.NewContextWithUser(user), locales.EN_US, validatedInput{value: "ok"})
+	assert.True(result.IsSuccess())
+	assert.Equal("ok", *result.Data)
+	assert.Equal([]string{"inner:before", "inner:after"}, calls)
+}
+
+func TestInterceptAuthorizationClient(t *testing.T) {
+	assert := assert.New(t)
+
+	client := &usermodels.UserWithRole{}
+	client.SetClient("client", []string{"users:read"})
+	for _, guards := range []Guards{NewGuards(), NewGuards().WithScopes("users:read")} {
+		echo := &UCGuardedEcho{BaseUseCaseValidation[validatedInput, string]{
+			AppMessages: locales.NewLocale(locales.EN_US),
+			Guards:      guards,
+		}}
+		uc := Intercept(echo, "guarded_echo")
+
+		// Clients acting for themselves only reach the use cases granting them a client scope
+		result := uc.Execute(app_context.NewContextWithUser(client), locales.EN_US, validatedInput{value: "ok"})
+		assert.Equal(status.Unauthorized, result.StatusCode)
+		assert.Equal(locales.NewLocale(locales.EN_US).Get(locales.EN_US, messages.MessageKeysInstance.INSUFFICIENT_SCOPE), *result.Error)
+	}
+
+	echo := &UCGuardedEcho{BaseUseCaseValidation[validatedInput, string]{
+		AppMessages: locales.NewLocale(locales.EN_US),
+		Guards: NewGuards(func(_ usermodels.UserWithRole, _ any) *messages.MessageKeysEnum {
+			return &messages.MessageKeysInstance.UNAUTHORIZED_RESOURCE
+		}).WithClientScopes("users:read"),
+	}}
+	uc := Intercept(echo, "guarded_echo")
+	result := uc.Execute(app_context.NewContextWithUser(client), locales.EN_US, validatedInput{value: "ok"})
+	assert.True(result.IsSuccess())
+}
+
+type UCEcho struct{}
+
+func (uc *UCEcho) SetLocale(_ locales.LocaleTypeEnum) {}
+func (uc *UCEcho) Execute(_ *app_context.AppContext, _ locales.LocaleTypeEnum, input validatedInput) *UseCaseResult[string] {
+	return NewUseCaseResult[string]().SetData(status.Success, input.value, "")
+}
+func (uc *UCEcho) SetAppContext(_ *app_context.AppContext) {}
+
+func TestInterceptGuards(t *testing.T) {
+	assert := assert.New(t)
+
+	onlyFirstUser := func(user usermodels.UserWithRole, _ any) *messages.MessageKeysEnum {
+		if user.ID != 1 {
+			return &messages.MessageKeysInstance.UNAUTHORIZED_RESOURCE
+		}
+		return nil
+	}
+	// Use cases that do not authorize themselves get their guards from the chain
+	uc := Intercept(&UCEcho{}, "echo", WithGuards(onlyFirstUser))
+
+	result := uc.Execute(app_context.NewVoidAppContext(), locales.EN_US, validatedInput{value: "ok"})
+	assert.Equal(status.Unauthorized, result.StatusCode)
+
+	result = uc.Execute(app_context.NewContextWithUser(&usermodels.UserWithRole{ID: 2}), locales.EN_US, validatedInput{value: "ok"})
+	assert.Equal(status.Unauthorized, result.StatusCode)
+
+	result = uc.Execute(app_context.NewContextWithUser(&usermodels.UserWithRole{ID: 1}), locales.EN_US, validatedInput{})
+	assert.Equal(status.InvalidInput, result.StatusCode)
+
+	result = uc.Execute(app_context.NewContextWithUser(&usermodels.UserWithRole{ID: 1}), locales.EN_US, validatedInput{value: "ok"})
+	assert.True(result.IsSuccess())
+}
+
+func TestInterceptRegistered(t *testing.T) {
+	assert := assert.New(t)
+	defer Use(GlobalMiddlewares()...)
+	defer Register("registered_echo")
+
+	calls := make([]string, 0)
+	Use(recordMiddleware(&calls, "global"))
+	Register("registered_echo", recordMiddleware(&calls, "registered"))
+
+	// The middlewares registered for the name run between the global and the given ones
+	uc := Intercept(&UCEcho{}, "registered_echo", recordMiddleware(&calls, "request"))
+	result := uc.Execute(app_context.NewVoidAppContext(), locales.EN_US, validatedInput{value: "ok"})
+
+	assert.True(result.IsSuccess())
+	assert.Equal([]string{
+		"global:before", "registered:before", "request:before",
+		"request:after", "registered:after", "global:after",
+	}, calls)
+}
+
+func TestInterceptRecovery(t *testing.T) {
+	assert := assert.New(t)
+
+	uc := Intercept(&UCPanic{}, "panic", AuditLog())
+	result := uc.Execute(app_context.NewVoidAppContext(), locales.EN_US, 1)
+
+	assert.NotNil(result)
+	assert.True(result.HasError())
+	assert.Equal(status.InternalError, result.StatusCode)
+
+	// Intercepted use cases can be used as DAG steps
+	dag := Then(NewDag(app_context.NewVoidAppContext(), NewStep(&UCStringToInt{}), locales.EN_US, nil), NewStep[int, int](uc), "panic")
+	result = dag.Execute("1")
+	assert.True(result.HasError())
+	assert.Equal(status.InternalError, result.StatusCode)
+}
@@ -8,13 +8,29 @@ import (
 // Guard is a function that validates user access to a resource
 type Guard func(user usermodels.UserWithRole, input any) *messages.MessageKeysEnum
 
+// Guards are the guards of a use case. Users authenticated by a token
+// delegated to an OAuth client must also hold one of the scopes, a use case
+// without scopes can not be used with delegated tokens. OAuth clients acting
+// for themselves are no user, the guards of the user do not apply to them:
+// they are only granted the client scopes of the use case.
 type Guards struct {
-	list  []Guard
-	actor usermodels.UserWithRole
+	list         []Guard
+	scopes       []string
+	clientScopes []string
+	actor        usermodels.UserWithRole
 }
 
 // Validate validates the input against the guards
 func (g Guards) Validate(input any) *messages.MessageKeysEnum {
+	if g.actor.IsClient() {
+		if !g.actor.HasAnyScope(g.clientScopes...) {
+			return &messages.MessageKeysInstance.INSUFFICIENT_SCOPE
+		}
+		return nil
+	}
+	if g.actor.IsDelegated() && !g.actor.HasAnyScope(g.scopes...) {
+		return &messages.MessageKeysInstance.INSUFFICIENT_SCOPE
+	}
 	for _, guard := range g.list {
 		if err := guard(g.actor, input); err != nil {
 			return err
@@ -30,6 +46,25 @@ func NewGuards(guards ...Guard) Guards {
 	}
 }
 
+// WithScopes returns the guards granting access to delegated tokens with one
+// of the OAuth scopes
+func (g Guards) WithScopes(scopes ...string) Guards {
+	g.scopes = scopes
+	return g
+}
+
+// WithClientScopes returns the guards granting access to OAuth clients acting
+// for themselves with one of the scopes, see client_credentials
+func (g Guards) WithClientScopes(scopes ...string) Guards {
+	g.clientScopes = scopes
+	return g
+}
+
+// IsEmpty reports whether the guards do not restrict the use case
+func (g Guards) IsEmpty() bool {
+	return len(g.list) == 0 && len(g.scopes) == 0 && len(g.clientScopes) == 0
+}
+
 // SetActor sets the actor for the guards
 func (g *Guards) SetActor(actor usermodels.UserWithRole) {
 	g.actor = actor
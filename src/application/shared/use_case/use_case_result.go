@@ -11,6 +11,7 @@ type UseCaseResult[T any] struct {
 	Data       *T
 	Error      *string
 	Headers    map[string]string
+	TaskIDs    map[string]string
 	Details    string
 	StatusCode status.ApplicationStatusEnum
 	Success    bool
@@ -56,6 +57,15 @@ func (ucr *UseCaseResult[T]) AddHeader(key string, value string) *UseCaseResult[
 	return ucr
 }
 
+// AddTaskID attaches the ID of a durable background task to the result.
+func (ucr *UseCaseResult[T]) AddTaskID(name string, taskID string) *UseCaseResult[T] {
+	if ucr.TaskIDs == nil {
+		ucr.TaskIDs = make(map[string]string)
+	}
+	ucr.TaskIDs[name] = taskID
+	return ucr
+}
+
 func (ucr *UseCaseResult[T]) SetData(
 	statusCode status.ApplicationStatusEnum,
 	data T,
@@ -112,3 +122,13 @@ func (ucr *UseCaseResult[T]) HasError() bool {
 func (ucr *UseCaseResult[T]) GetError() error {
 	return fmt.Errorf("error: %s", *ucr.Error)
 }
+
+// markCached marks the data as served from the cache if it implements CachedMarker.
+func (ucr *UseCaseResult[T]) markCached() {
+	if ucr.Data == nil {
+		return
+	}
+	if marker, ok := any(ucr.Data).(CachedMarker); ok {
+		marker.MarkCached()
+	}
+}
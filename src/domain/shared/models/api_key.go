@@ -0,0 +1,76 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// APIKeyPrefix starts every API key, it tells the keys apart from the JWT
+// access tokens when they are presented
+const APIKeyPrefix = "gpk_"
+
+// APIKeyDisplayLength is the length of the start of a key that is stored in
+// clear, the keys are listed by it
+const APIKeyDisplayLength = len(APIKeyPrefix) + 8
+
+// APIKeyBase is a personal access token of a user for machine clients.
+// Only the hash of the key is stored, the key is shown once when it is
+// created. A key acts as its user restricted to its scopes until it expires
+// or is revoked.
+type APIKeyBase struct {
+	UserID     uint       `json:"user_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Hash       []byte     `json:"-"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  time.Time  `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+}
+
+func (a *APIKeyBase) Validate() []string {
+	var errs []string
+
+	if a.UserID == 0 {
+		errs = append(errs, "user_id is required")
+	}
+	if a.Name == "" {
+		errs = append(errs, "name is required")
+	}
+	if a.Prefix == "" {
+		errs = append(errs, "prefix is required")
+	}
+	if len(a.Hash) == 0 {
+		errs = append(errs, "hash is required")
+	}
+	errs = append(errs, ValidateAPIKeyScopes(a.Scopes)...)
+	if a.ExpiresAt.IsZero() {
+		errs = append(errs, "expires_at is required")
+	}
+
+	return errs
+}
+
+// IsActive reports whether the key can still authenticate its user
+func (a *APIKeyBase) IsActive(now time.Time) bool {
+	return a.RevokedAt == nil && a.ExpiresAt.After(now)
+}
+
+// ValidateAPIKeyScopes checks the scopes of a key, a key must be granted at
+// least one of the OAuth scopes
+func ValidateAPIKeyScopes(scopes []string) []string {
+	if len(scopes) == 0 {
+		return []string{"scopes are required"}
+	}
+	for _, scope := range scopes {
+		if !slices.Contains(OAuthScopes, scope) {
+			return []string{"scope " + scope + " is not supported"}
+		}
+	}
+	return nil
+}
+
+type APIKey struct {
+	APIKeyBase
+	DBBaseModel
+}
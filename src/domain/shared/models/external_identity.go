@@ -0,0 +1,32 @@
+package models
+
+// ExternalIdentityBase links the account of a user at an OpenID Connect
+// provider to the user. The subject is the ID of the account at the
+// provider, the email is the one the provider reported on the last login.
+type ExternalIdentityBase struct {
+	UserID   uint   `json:"user_id"`
+	Provider string `json:"provider"`
+	Subject  string `json:"subject"`
+	Email    string `json:"email"`
+}
+
+func (e *ExternalIdentityBase) Validate() []string {
+	var errs []string
+
+	if e.UserID == 0 {
+		errs = append(errs, "user_id is required")
+	}
+	if e.Provider == "" {
+		errs = append(errs, "provider is required")
+	}
+	if e.Subject == "" {
+		errs = append(errs, "subject is required")
+	}
+
+	return errs
+}
+
+type ExternalIdentity struct {
+	ExternalIdentityBase
+	DBBaseModel
+}
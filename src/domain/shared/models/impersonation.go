@@ -0,0 +1,44 @@
+package models
+
+import "time"
+
+// ImpersonationBase is the record of an admin acting as another user. The
+// access token of the impersonation is identified by TokenID, its jti claim,
+// so it can be revoked when the impersonation ends.
+type ImpersonationBase struct {
+	AdminID   uint       `json:"admin_id"`
+	UserID    uint       `json:"user_id"`
+	TokenID   string     `json:"-"`
+	Reason    string     `json:"reason"`
+	ExpiresAt time.Time  `json:"expires_at"`
+	EndedAt   *time.Time `json:"ended_at"`
+}
+
+func (i *ImpersonationBase) Validate() []string {
+	var errs []string
+
+	if i.AdminID == 0 {
+		errs = append(errs, "admin_id is required")
+	}
+	if i.UserID == 0 {
+		errs = append(errs, "user_id is required")
+	}
+	if i.TokenID == "" {
+		errs = append(errs, "token_id is required")
+	}
+	if i.ExpiresAt.IsZero() {
+		errs = append(errs, "expires_at is required")
+	}
+
+	return errs
+}
+
+// IsActive reports whether the impersonation token can still be used
+func (i *ImpersonationBase) IsActive(now time.Time) bool {
+	return i.EndedAt == nil && i.ExpiresAt.After(now)
+}
+
+type Impersonation struct {
+	ImpersonationBase
+	DBBaseModel
+}
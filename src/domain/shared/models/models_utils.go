@@ -39,3 +39,14 @@ type HasValidation interface {
 type HasUserID interface {
 	GetUserID() uint
 }
+
+// HasRoleAssignment is implemented by the inputs that may set the role of a
+// user
+type HasRoleAssignment interface {
+	AssignsRole() bool
+}
+
+// HasOrganizationID is implemented by the inputs belonging to an organization
+type HasOrganizationID interface {
+	GetOrganizationID() uint
+}
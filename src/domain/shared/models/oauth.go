@@ -0,0 +1,158 @@
+package models
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// OAuth grant types a client can be registered for
+const (
+	OAuthGrantAuthorizationCode = "authorization_code"
+	OAuthGrantClientCredentials = "client_credentials"
+	OAuthGrantRefreshToken      = "refresh_token"
+)
+
+// OAuth scopes delegated tokens can be granted. The use cases map them onto
+// their guards, see usecase.Guards.WithScopes.
+const (
+	OAuthScopeProfile      = "profile"
+	OAuthScopeProfileWrite = "profile:write"
+	OAuthScopeUsersRead    = "users:read"
+)
+
+// OAuthScopes are the scopes known by the authorization server
+var OAuthScopes = []string{OAuthScopeProfile, OAuthScopeProfileWrite, OAuthScopeUsersRead}
+
+// ParseOAuthScope splits a space-delimited scope parameter
+func ParseOAuthScope(scope string) []string {
+	return strings.Fields(scope)
+}
+
+// JoinOAuthScope joins scopes into a space-delimited scope parameter
+func JoinOAuthScope(scopes []string) string {
+	return strings.Join(scopes, " ")
+}
+
+// OAuthClientBase is an application that delegates the login of its users to
+// this service. Confidential clients authenticate with a secret, only its
+// hash is stored. First-party clients are trusted applications of the
+// organization, their users are not asked for consent.
+type OAuthClientBase struct {
+	Name         string   `json:"name"`
+	ClientID     string   `json:"client_id"`
+	SecretHash   []byte   `json:"-"`
+	RedirectURIs []string `json:"redirect_uris"`
+	Scopes       []string `json:"scopes"`
+	GrantTypes   []string `json:"grant_types"`
+	FirstParty   bool     `json:"first_party"`
+	OwnerID      uint     `json:"owner_id"`
+}
+
+func (c *OAuthClientBase) Validate() []string {
+	var errs []string
+
+	if c.Name == "" {
+		errs = append(errs, "name is required")
+	}
+	if c.ClientID == "" {
+		errs = append(errs, "client_id is required")
+	}
+	if len(c.GrantTypes) == 0 {
+		errs = append(errs, "grant_types is required")
+	}
+	for _, grantType := range c.GrantTypes {
+		switch grantType {
+		case OAuthGrantAuthorizationCode, OAuthGrantRefreshToken:
+		case OAuthGrantClientCredentials:
+			if !c.IsConfidential() {
+				errs = append(errs, "client_credentials requires a confidential client")
+			}
+		default:
+			errs = append(errs, "unsupported grant type: "+grantType)
+		}
+	}
+	if c.AllowsGrantType(OAuthGrantAuthorizationCode) && len(c.RedirectURIs) == 0 {
+		errs = append(errs, "redirect_uris is required for authorization_code")
+	}
+	for _, redirectURI := range c.RedirectURIs {
+		parsed, err := url.Parse(redirectURI)
+		if err != nil || !parsed.IsAbs() || parsed.Fragment != "" {
+			errs = append(errs, "invalid redirect uri: "+redirectURI)
+		}
+	}
+	for _, scope := range c.Scopes {
+		if !slices.Contains(OAuthScopes, scope) {
+			errs = append(errs, "unknown scope: "+scope)
+		}
+	}
+
+	return errs
+}
+
+// IsConfidential reports whether the client authenticates with a secret
+func (c *OAuthClientBase) IsConfidential() bool {
+	return len(c.SecretHash) > 0
+}
+
+// AllowsGrantType reports whether the client was registered for the grant type
+func (c *OAuthClientBase) AllowsGrantType(grantType string) bool {
+	return slices.Contains(c.GrantTypes, grantType)
+}
+
+// AllowsRedirectURI reports whether the redirect URI was registered, URIs are
+// compared as exact strings
+func (c *OAuthClientBase) AllowsRedirectURI(redirectURI string) bool {
+	return slices.Contains(c.RedirectURIs, redirectURI)
+}
+
+// AllowsScopes reports whether every scope was registered for the client
+func (c *OAuthClientBase) AllowsScopes(scopes []string) bool {
+	for _, scope := range scopes {
+		if !slices.Contains(c.Scopes, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+type OAuthClient struct {
+	OAuthClientBase
+	DBBaseModel
+}
+
+// OAuthConsentBase records the scopes a user granted to a client, so the
+// user is only asked again when the client requests more scopes
+type OAuthConsentBase struct {
+	UserID   uint     `json:"user_id"`
+	ClientID string   `json:"client_id"`
+	Scopes   []string `json:"scopes"`
+}
+
+func (c *OAuthConsentBase) Validate() []string {
+	var errs []string
+
+	if c.UserID == 0 {
+		errs = append(errs, "user_id is required")
+	}
+	if c.ClientID == "" {
+		errs = append(errs, "client_id is required")
+	}
+
+	return errs
+}
+
+// Covers reports whether the user already granted every scope
+func (c *OAuthConsentBase) Covers(scopes []string) bool {
+	for _, scope := range scopes {
+		if !slices.Contains(c.Scopes, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+type OAuthConsent struct {
+	OAuthConsentBase
+	DBBaseModel
+}
@@ -10,12 +10,17 @@ const (
 	OneTimePasswordLogin OneTimePasswordPurpose = "login"
 )
 
+// OneTimePasswordBase is a one time password sent to a user.
+// The password is bound to the login transaction it was sent for, it is only
+// accepted with the transaction ID and a limited number of attempts.
 type OneTimePasswordBase struct {
-	UserID  uint                   `json:"user_id"`
-	Purpose OneTimePasswordPurpose `json:"purpose"`
-	Hash    []byte                 `json:"hash"`
-	IsUsed  bool                   `json:"isUsed"`
-	Expires time.Time              `json:"expires"`
+	UserID          uint                   `json:"user_id"`
+	Purpose         OneTimePasswordPurpose `json:"purpose"`
+	Hash            []byte                 `json:"hash"`
+	TransactionHash []byte                 `json:"-"`
+	Attempts        int                    `json:"attempts"`
+	IsUsed          bool                   `json:"isUsed"`
+	Expires         time.Time              `json:"expires"`
 }
 
 func (o *OneTimePasswordBase) Validate() []string {
@@ -30,6 +35,9 @@ func (o *OneTimePasswordBase) Validate() []string {
 	if len(o.Hash) == 0 {
 		errs = append(errs, "hash is required")
 	}
+	if len(o.TransactionHash) == 0 {
+		errs = append(errs, "transaction_hash is required")
+	}
 	if o.Expires.IsZero() {
 		errs = append(errs, "expires is required")
 	}
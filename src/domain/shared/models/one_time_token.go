@@ -10,6 +10,7 @@ type OneTimeTokenPurpose string
 const (
 	OneTimeTokenPurposePasswordReset OneTimeTokenPurpose = "password_reset"
 	OneTimeTokenPurposeEmailVerify   OneTimeTokenPurpose = "email_verify"
+	OneTimeTokenPurposeAccountUnlock OneTimeTokenPurpose = "account_unlock"
 )
 
 type OneTimeTokenBase struct {
@@ -36,7 +37,8 @@ func (o *OneTimeTokenBase) Validate() []string {
 		errs = append(errs, "expires is required")
 	}
 
-	if o.Purpose != OneTimeTokenPurposePasswordReset && o.Purpose != OneTimeTokenPurposeEmailVerify {
+	if o.Purpose != OneTimeTokenPurposePasswordReset && o.Purpose != OneTimeTokenPurposeEmailVerify &&
+		o.Purpose != OneTimeTokenPurposeAccountUnlock {
 		errs = append(errs, "purpose is invalid")
 	}
 
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"time"
+)
+
+type OutboxTaskStatus string
+
+const (
+	OutboxTaskStatusPending    OutboxTaskStatus = "pending"
+	OutboxTaskStatusSucceeded  OutboxTaskStatus = "succeeded"
+	OutboxTaskStatusDeadLetter OutboxTaskStatus = "dead_letter"
+)
+
+// OutboxTaskBase is a background task persisted before being dispatched.
+// The payload is the JSON encoded input of the task.
+type OutboxTaskBase struct {
+	TaskID        string           `json:"taskId"`
+	Name          string           `json:"name"`
+	Payload       []byte           `json:"-"`
+	Locale        string           `json:"locale"`
+	Status        OutboxTaskStatus `json:"status"`
+	Attempts      int              `json:"attempts"`
+	MaxAttempts   int              `json:"maxAttempts"`
+	NextAttemptAt time.Time        `json:"nextAttemptAt"`
+	LastError     string           `json:"lastError,omitempty"`
+	Result        string           `json:"result,omitempty"`
+	// UserID is the user whose request enqueued the task, 0 for anonymous
+	// requests. Only that user can read the task.
+	UserID uint `json:"userId,omitempty"`
+}
+
+func (o *OutboxTaskBase) Validate() []string {
+	var errs []string
+
+	if o.TaskID == "" {
+		errs = append(errs, "task_id is required")
+	}
+	if o.Name == "" {
+		errs = append(errs, "name is required")
+	}
+	if o.MaxAttempts < 1 {
+		errs = append(errs, "max_attempts must be greater than 0")
+	}
+
+	return errs
+}
+
+// IsDone reports whether the task reached a final status
+func (o *OutboxTaskBase) IsDone() bool {
+	return o.Status == OutboxTaskStatusSucceeded || o.Status == OutboxTaskStatusDeadLetter
+}
+
+type OutboxTask struct {
+	OutboxTaskBase
+	DBBaseModel
+}
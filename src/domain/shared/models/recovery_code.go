@@ -0,0 +1,27 @@
+package models
+
+// RecoveryCodeBase is a one-time code that replaces the authenticator app
+// code when the user lost access to their device
+type RecoveryCodeBase struct {
+	UserID uint   `json:"user_id"`
+	Hash   []byte `json:"hash"`
+	IsUsed bool   `json:"isUsed"`
+}
+
+func (r *RecoveryCodeBase) Validate() []string {
+	var errs []string
+
+	if r.UserID == 0 {
+		errs = append(errs, "user_id is required")
+	}
+	if len(r.Hash) == 0 {
+		errs = append(errs, "hash is required")
+	}
+
+	return errs
+}
+
+type RecoveryCode struct {
+	RecoveryCodeBase
+	DBBaseModel
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"time"
+)
+
+// RefreshTokenBase is a refresh token issued to a user.
+// Tokens issued by a login share a family, every refresh rotates the token
+// inside the family, so the family is the server-side session.
+// Tokens issued to an OAuth client carry its ID and the granted scope, they
+// can only be refreshed by that client.
+type RefreshTokenBase struct {
+	UserID    uint      `json:"user_id"`
+	FamilyID  string    `json:"family_id"`
+	Hash      []byte    `json:"hash"`
+	IsRotated bool      `json:"isRotated"`
+	IsRevoked bool      `json:"isRevoked"`
+	Expires   time.Time `json:"expires"`
+	ClientID  string    `json:"client_id,omitempty"`
+	Scope     string    `json:"scope,omitempty"`
+	// TenantID is the organization the session acts in, chosen with a switch
+	// of tenant, 0 for the default organization of the user
+	TenantID uint `json:"tenant_id,omitempty"`
+}
+
+func (r *RefreshTokenBase) Validate() []string {
+	var errs []string
+
+	if r.UserID == 0 {
+		errs = append(errs, "user_id is required")
+	}
+	if r.FamilyID == "" {
+		errs = append(errs, "family_id is required")
+	}
+	if len(r.Hash) == 0 {
+		errs = append(errs, "hash is required")
+	}
+	if r.Expires.IsZero() {
+		errs = append(errs, "expires is required")
+	}
+
+	return errs
+}
+
+// IsActive reports whether the token can still be exchanged for new tokens
+func (r *RefreshTokenBase) IsActive(now time.Time) bool {
+	return !r.IsRotated && !r.IsRevoked && r.Expires.After(now)
+}
+
+type RefreshToken struct {
+	RefreshTokenBase
+	DBBaseModel
+}
@@ -0,0 +1,29 @@
+package models
+
+// TOTPDeviceBase is the authenticator app a user enrolled for multi-factor
+// authentication. The secret is stored encrypted, the device only protects
+// the logins once the user confirmed it with a first code.
+type TOTPDeviceBase struct {
+	UserID       uint   `json:"user_id"`
+	Secret       []byte `json:"-"`
+	IsConfirmed  bool   `json:"isConfirmed"`
+	LastUsedStep int64  `json:"-"`
+}
+
+func (t *TOTPDeviceBase) Validate() []string {
+	var errs []string
+
+	if t.UserID == 0 {
+		errs = append(errs, "user_id is required")
+	}
+	if len(t.Secret) == 0 {
+		errs = append(errs, "secret is required")
+	}
+
+	return errs
+}
+
+type TOTPDevice struct {
+	TOTPDeviceBase
+	DBBaseModel
+}
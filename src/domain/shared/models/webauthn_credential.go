@@ -0,0 +1,38 @@
+package models
+
+import "time"
+
+// WebAuthnCredentialBase is a passkey a user registered for passwordless
+// logins. The public key is COSE encoded, the sign count is the last counter
+// the authenticator reported, which detects cloned authenticators.
+type WebAuthnCredentialBase struct {
+	UserID       uint       `json:"user_id"`
+	Name         string     `json:"name"`
+	CredentialID []byte     `json:"credentialId"`
+	PublicKey    []byte     `json:"-"`
+	SignCount    uint32     `json:"-"`
+	Transports   []string   `json:"transports"`
+	AAGUID       []byte     `json:"aaguid"`
+	LastUsedAt   *time.Time `json:"lastUsedAt"`
+}
+
+func (w *WebAuthnCredentialBase) Validate() []string {
+	var errs []string
+
+	if w.UserID == 0 {
+		errs = append(errs, "user_id is required")
+	}
+	if len(w.CredentialID) == 0 {
+		errs = append(errs, "credential_id is required")
+	}
+	if len(w.PublicKey) == 0 {
+		errs = append(errs, "public_key is required")
+	}
+
+	return errs
+}
+
+type WebAuthnCredential struct {
+	WebAuthnCredentialBase
+	DBBaseModel
+}
@@ -272,6 +272,11 @@ func (qp *QueryPayloadBuilder[DBModel]) GetQueryKey() string {
 	return sb.String()
 }
 
+// CacheKey identifies the query for the use case cache
+func (qp QueryPayloadBuilder[DBModel]) CacheKey() string {
+	return qp.GetQueryKey()
+}
+
 // BuildQueryParamsUrl constructs the query parameters string for URLs
 func (qp *QueryPayloadBuilder[DBModel]) BuildQueryParamsURL() string {
 	var sb strings.Builder
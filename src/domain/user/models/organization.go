@@ -0,0 +1,68 @@
+package models
+
+import "regexp"
+
+var organizationSlugRegex = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// OrganizationBase is an organization the users are members of. Every
+// organization is a tenant, the data of its members is isolated from the
+// other tenants.
+type OrganizationBase struct {
+	Name     string `json:"name"`
+	Slug     string `json:"slug"`
+	IsActive bool   `json:"is_active"`
+}
+
+// Validate validates the organization base
+func (o OrganizationBase) Validate() []string {
+	var errs []string
+	if o.Name == "" {
+		errs = append(errs, "name is required")
+	}
+	if len(o.Name) > 100 {
+		errs = append(errs, "name is too long")
+	}
+	if !organizationSlugRegex.MatchString(o.Slug) {
+		errs = append(errs, "slug must be lowercase letters and numbers separated by dashes")
+	}
+	if len(o.Slug) > 100 {
+		errs = append(errs, "slug is too long")
+	}
+	return errs
+}
+
+type OrganizationCreate struct {
+	OrganizationBase
+}
+
+type OrganizationUpdateBase struct {
+	Name     *string `json:"name"`
+	IsActive *bool   `json:"is_active"`
+}
+
+type OrganizationUpdate struct {
+	OrganizationUpdateBase
+	ID uint `json:"id"`
+}
+
+type Organization struct {
+	OrganizationBase
+	ID uint `json:"id"`
+}
+
+// MembershipCreate makes a user a member of an organization
+type MembershipCreate struct {
+	OrganizationID uint `json:"organization_id"`
+	UserID         uint `json:"user_id"`
+}
+
+type MembershipUpdate struct {
+	OrganizationID uint `json:"organization_id"`
+	UserID         uint `json:"user_id"`
+}
+
+// Membership is the membership of a user in an organization
+type Membership struct {
+	OrganizationID uint `json:"organization_id"`
+	UserID         uint `json:"user_id"`
+}
@@ -0,0 +1,84 @@
+package models
+
+import "slices"
+
+// Permission keys checked by the permission guards
+const (
+	PermissionUserRead          = "user:read"
+	PermissionUserList          = "user:list"
+	PermissionUserUpdate        = "user:update"
+	PermissionUserDelete        = "user:delete"
+	PermissionPasswordCreate    = "password:create"
+	PermissionAPIKeyRevoke      = "api_key:revoke"
+	PermissionAPIKeyRevokeAny   = "api_key:revoke_any"
+	PermissionOAuthClientCreate = "oauth_client:create"
+	PermissionStatusPipes       = "status:pipes"
+	PermissionRoleRead          = "role:read"
+	PermissionRoleWrite         = "role:write"
+	PermissionOrganizationRead  = "organization:read"
+	PermissionOrganizationWrite = "organization:write"
+	PermissionTenantCross       = "tenant:cross"
+	PermissionUserImpersonate   = "user:impersonate"
+	PermissionUserAssignRole    = "user:assign_role"
+)
+
+type PermissionBase struct {
+	Key         string `json:"key"`
+	Description string `json:"description"`
+}
+
+type PermissionCreate struct {
+	PermissionBase
+}
+
+type PermissionUpdate struct {
+	Description *string `json:"description"`
+	ID          uint    `json:"id"`
+}
+
+type Permission struct {
+	PermissionBase
+	ID uint `json:"id"`
+}
+
+// RolePermission grants a permission to every user of a role
+type RolePermission struct {
+	RoleID       uint `json:"role_id"`
+	PermissionID uint `json:"permission_id"`
+}
+
+// UserPermissionCreate grants, or revokes when Granted is false, a permission
+// to a user over the permissions of their role
+type UserPermissionCreate struct {
+	UserID       uint `json:"user_id"`
+	PermissionID uint `json:"permission_id"`
+	Granted      bool `json:"granted"`
+}
+
+type UserPermissionUpdate struct {
+	Granted *bool `json:"granted"`
+	ID      uint  `json:"id"`
+}
+
+// UserPermission is a permission granted or revoked to a user
+type UserPermission struct {
+	UserID        uint   `json:"user_id"`
+	PermissionKey string `json:"permission_key"`
+	Granted       bool   `json:"granted"`
+}
+
+// EffectivePermissions returns the permissions of the role with the
+// permissions granted to the user added and those revoked removed
+func EffectivePermissions(rolePermissions []string, overrides []UserPermission) []string {
+	permissions := slices.Clone(rolePermissions)
+	for _, override := range overrides {
+		index := slices.Index(permissions, override.PermissionKey)
+		switch {
+		case override.Granted && index < 0:
+			permissions = append(permissions, override.PermissionKey)
+		case !override.Granted && index >= 0:
+			permissions = slices.Delete(permissions, index, index+1)
+		}
+	}
+	return permissions
+}
@@ -2,12 +2,38 @@ package models
 
 import sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
 
+// Keys of the default roles
+const (
+	RoleKeyAdmin = "admin"
+	RoleKeyUser  = "user"
+)
+
+// AdminRoleID is the ID of the admin role, the first default role created.
+// Users can not be created nor updated with it.
+const AdminRoleID uint = 1
+
+// UserRoleID is the ID of the user role, the second default role created.
+// Users registering themselves get it.
+const UserRoleID uint = 2
+
 type RoleBase struct {
 	Key      string `json:"key"`
 	IsActive bool   `json:"status"`
 	Priority int    `json:"priority"`
 }
 
+// Validate validates the role base
+func (r RoleBase) Validate() []string {
+	var errs []string
+	if r.Key == "" {
+		errs = append(errs, "key is required")
+	}
+	if len(r.Key) > 100 {
+		errs = append(errs, "key is too long")
+	}
+	return errs
+}
+
 type RoleCreate struct {
 	RoleBase
 }
@@ -18,6 +44,18 @@ type RoleUpdateBase struct {
 	Priority *int    `json:"priority"`
 }
 
+// Validate validates the role update base
+func (r RoleUpdateBase) Validate() []string {
+	var errs []string
+	if r.Key != nil && *r.Key == "" {
+		errs = append(errs, "key is required")
+	}
+	if r.Key != nil && len(*r.Key) > 100 {
+		errs = append(errs, "key is too long")
+	}
+	return errs
+}
+
 type RoleUpdate struct {
 	RoleUpdateBase
 	ID uint `json:"id"`
@@ -28,6 +66,12 @@ type Role struct {
 	ID uint `json:"id"`
 }
 
+// IsBuiltIn reports whether the role is one of the default roles the
+// application relies on, they can not be deleted nor renamed
+func (r Role) IsBuiltIn() bool {
+	return r.Key == RoleKeyAdmin || r.Key == RoleKeyUser
+}
+
 type RoleInDB struct {
 	RoleBase
 	sharedmodels.DBBaseModel
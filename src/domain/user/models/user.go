@@ -2,6 +2,7 @@
 package models
 
 import (
+	"slices"
 	"strconv"
 
 	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
@@ -50,6 +51,10 @@ type UserBase struct {
 
 // Validate validates the user base
 func (u UserBase) Validate() []string {
+	return u.validate(true)
+}
+
+func (u UserBase) validate(phoneRequired bool) []string {
 	var errs []string
 
 	if u.Name == "" {
@@ -58,7 +63,7 @@ func (u UserBase) Validate() []string {
 	if u.Email == "" {
 		errs = append(errs, "email is required")
 	}
-	if u.Phone == "" {
+	if phoneRequired && u.Phone == "" {
 		errs = append(errs, "phone is required")
 	}
 	if u.RoleID == 0 {
@@ -77,7 +82,20 @@ func (u *UserBase) ValidateCreate() []string {
 	errs := u.Validate()
 	status := UserStatusPending
 	u.Status = &status
-	if u.RoleID == 1 { // TODO: replace with constant
+	if u.RoleID == AdminRoleID {
+		errs = append(errs, "admin role is not allowed")
+	}
+	return errs
+}
+
+// ValidateCreateExternal validates the user base for a creation on the first
+// login with an identity provider. The provider verified the email, so the
+// user is active, and it may not share the phone of the user.
+func (u *UserBase) ValidateCreateExternal() []string {
+	errs := u.validate(false)
+	status := UserStatusActive
+	u.Status = &status
+	if u.RoleID == AdminRoleID {
 		errs = append(errs, "admin role is not allowed")
 	}
 	return errs
@@ -99,12 +117,17 @@ func (u *UserUpdateBase) Validate() []string {
 	if u.Email != nil && !sharedmodels.IsValidEmail(*u.Email) {
 		errs = append(errs, "email is invalid")
 	}
-	if u.RoleID != nil && *u.RoleID == 1 { // TODO: replace with constant
+	if u.RoleID != nil && *u.RoleID == AdminRoleID {
 		errs = append(errs, "admin role is not allowed")
 	}
 	return errs
 }
 
+// AssignsRole reports whether the update changes the role of the user
+func (u UserUpdateBase) AssignsRole() bool {
+	return u.RoleID != nil
+}
+
 // UserUpdate is the update structure for a user
 type UserUpdate struct {
 	UserUpdateBase
@@ -113,8 +136,20 @@ type UserUpdate struct {
 
 type UserWithRole struct {
 	UserBase
-	role Role
-	ID   uint `json:"id"`
+	role        Role
+	permissions []string
+	scopes      []string
+	delegated   bool
+	// clientID is the OAuth client authenticated by a client_credentials
+	// token, such actors are no user and have ID 0
+	clientID      string
+	organizations []uint
+	tenantID      uint
+	// impersonatorID is the admin acting as the user, 0 when the user acts
+	// by themselves
+	impersonatorID  uint
+	impersonationID string
+	ID              uint `json:"id"`
 }
 
 func (u *UserWithRole) SetRole(role Role) {
@@ -122,7 +157,23 @@ func (u *UserWithRole) SetRole(role Role) {
 }
 
 func (u *UserWithRole) UserIsAdmin() bool {
-	return u.role.Key == "admin"
+	return u.role.Key == RoleKeyAdmin
+}
+
+// SetPermissions sets the effective permissions of the user, those of their
+// role with the overrides of the user applied
+func (u *UserWithRole) SetPermissions(permissions []string) {
+	u.permissions = permissions
+}
+
+// GetPermissions returns the effective permissions of the user
+func (u *UserWithRole) GetPermissions() []string {
+	return u.permissions
+}
+
+// HasPermission reports whether the user holds the permission
+func (u *UserWithRole) HasPermission(permission string) bool {
+	return slices.Contains(u.permissions, permission)
 }
 
 func (u *UserWithRole) GetRoleKey() string {
@@ -133,6 +184,111 @@ func (u *UserWithRole) GetUserID() uint {
 	return u.ID
 }
 
+// SetScopes marks the user as authenticated by a token delegated to an OAuth
+// client, which only grants the given scopes
+func (u *UserWithRole) SetScopes(scopes []string) {
+	u.scopes = scopes
+	u.delegated = true
+}
+
+// IsDelegated reports whether the user is authenticated by a token delegated
+// to an OAuth client, tokens of the login are not limited by scopes
+func (u *UserWithRole) IsDelegated() bool {
+	return u.delegated
+}
+
+// SetClient marks the actor as the OAuth client itself, authenticated by a
+// client_credentials token which only grants the given scopes
+func (u *UserWithRole) SetClient(clientID string, scopes []string) {
+	u.clientID = clientID
+	u.SetScopes(scopes)
+}
+
+// IsClient reports whether the actor is an OAuth client acting for itself
+// and not for a user
+func (u *UserWithRole) IsClient() bool {
+	return u.clientID != ""
+}
+
+// GetClientID returns the OAuth client acting for itself
+func (u *UserWithRole) GetClientID() string {
+	return u.clientID
+}
+
+// HasAnyScope reports whether the delegated token grants one of the scopes
+func (u *UserWithRole) HasAnyScope(scopes ...string) bool {
+	for _, scope := range scopes {
+		if slices.Contains(u.scopes, scope) {
+			return true
+		}
+	}
+	return false
+}
+
+// SetOrganizations sets the organizations the user is a member of
+func (u *UserWithRole) SetOrganizations(organizations []uint) {
+	u.organizations = organizations
+}
+
+// GetOrganizations returns the organizations the user is a member of
+func (u *UserWithRole) GetOrganizations() []uint {
+	return u.organizations
+}
+
+// IsMemberOf reports whether the user is a member of the organization
+func (u *UserWithRole) IsMemberOf(organizationID uint) bool {
+	return slices.Contains(u.organizations, organizationID)
+}
+
+// CanAccessTenant reports whether the user may act in the organization, as a
+// member or as a super-admin crossing tenants. Organization 0 is no tenant,
+// only users of no organization and super-admins act without tenant.
+func (u *UserWithRole) CanAccessTenant(organizationID uint) bool {
+	if u.HasPermission(PermissionTenantCross) {
+		return true
+	}
+	if organizationID == 0 {
+		return len(u.organizations) == 0
+	}
+	return u.IsMemberOf(organizationID)
+}
+
+// SetTenant sets the organization the requests of the user are scoped to,
+// 0 for no tenant
+func (u *UserWithRole) SetTenant(organizationID uint) {
+	u.tenantID = organizationID
+}
+
+// GetTenantID returns the organization the requests of the user are scoped
+// to, and whether they are scoped
+func (u *UserWithRole) GetTenantID() (uint, bool) {
+	return u.tenantID, u.tenantID != 0
+}
+
+// SetImpersonator marks the user as impersonated by the admin, the
+// impersonation is identified by the ID of its access token
+func (u *UserWithRole) SetImpersonator(adminID uint, impersonationID string) {
+	u.impersonatorID = adminID
+	u.impersonationID = impersonationID
+}
+
+// GetImpersonatorID returns the admin acting as the user, and whether the
+// user is impersonated
+func (u *UserWithRole) GetImpersonatorID() (uint, bool) {
+	return u.impersonatorID, u.impersonatorID != 0
+}
+
+// GetImpersonationID returns the ID of the access token of the
+// impersonation, empty when the user is not impersonated
+func (u *UserWithRole) GetImpersonationID() string {
+	return u.impersonationID
+}
+
+// IsImpersonated reports whether an admin is acting as the user
+func (u *UserWithRole) IsImpersonated() bool {
+	return u.impersonatorID != 0
+}
+
 func (u *UserWithRole) GetUserIDString() string {
 	return strconv.FormatUint(uint64(u.ID), 10)
 }
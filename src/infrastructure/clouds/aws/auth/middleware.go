@@ -11,22 +11,26 @@ import (
 	authusecases "github.com/simon3640/goprojectskeleton/src/application/modules/auth/use_cases"
 	appcontext "github.com/simon3640/goprojectskeleton/src/application/shared/context"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
-	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
 	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/workers"
 	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
 	database "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton"
+	authrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/auth"
 	userrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/user"
 	handlers "github.com/simon3640/goprojectskeleton/src/infrastructure/handlers/shared"
 	"github.com/simon3640/goprojectskeleton/src/infrastructure/providers"
 )
 
-// AuthMiddleware validates JWT tokens from the Authorization header and injects user context.
+// AuthMiddleware validates JWT tokens from the Authorization header, or API keys from the
+// X-API-Key header, and injects user context.
 // This is for traditional HTTP handlers.
 func AuthMiddleware(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		token := r.Header.Get("Authorization")
+		if token == "" {
+			token = r.Header.Get("X-API-Key")
+		}
 
 		locale := r.Header.Get("Accept-Language")
 		if locale == "" {
@@ -34,18 +38,18 @@ func AuthMiddleware(next http.HandlerFunc) http.HandlerFunc {
 		}
 
 		uc := authusecases.NewAuthUserUseCase(
-			userrepositories.NewUserRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+			userrepositories.NewUserRepository(r.Context(), database.GoProjectSkeletondb.DB, providers.Logger),
 			providers.JWTProviderInstance,
+			providers.NewTokenRevocationProvider(providers.CacheProviderInstance),
+			authrepositories.NewAPIKeyRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+			providers.HashProviderInstance,
+			userrepositories.NewPermissionRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+			providers.CacheProviderInstance,
 		)
-		ucResult := usecase.InstrumentUseCase(
-			uc,
+		ucResult := usecase.Intercept(uc, "auth_user_use_case").Execute(
 			&appcontext.AppContext{Context: r.Context()},
 			locales.LocaleTypeEnum(locale),
 			token,
-			observability.GetObservabilityComponents().Tracer,
-			observability.GetObservabilityComponents().Metrics,
-			observability.GetObservabilityComponents().Clock,
-			"auth_user_use_case",
 		)
 
 		if ucResult.HasError() {
@@ -72,8 +76,8 @@ func AuthMiddleware(next http.HandlerFunc) http.HandlerFunc {
 	}
 }
 
-// extractTokenFromLambdaEvent extracts the Authorization token from a Lambda event.
-// It handles both API Gateway v1 and v2 event formats.
+// extractTokenFromLambdaEvent extracts the Authorization token from a Lambda event, falling
+// back to the X-API-Key header. It handles both API Gateway v1 and v2 event formats.
 func extractTokenFromLambdaEvent(event interface{}) string {
 	switch e := event.(type) {
 	case *aws.APIGatewayV2HTTPRequest:
@@ -112,6 +116,33 @@ func extractTokenFromLambdaEvent(event interface{}) string {
 			}
 		}
 	}
+	return extractAPIKeyFromLambdaEvent(event)
+}
+
+// extractAPIKeyFromLambdaEvent extracts the X-API-Key header from a Lambda event.
+func extractAPIKeyFromLambdaEvent(event interface{}) string {
+	switch e := event.(type) {
+	case *aws.APIGatewayV2HTTPRequest:
+		if key, ok := e.Headers["x-api-key"]; ok {
+			return key
+		}
+	case *aws.APIGatewayV1ProxyRequest:
+		if key, ok := e.Headers["X-API-Key"]; ok {
+			return key
+		}
+		if key, ok := e.Headers["x-api-key"]; ok {
+			return key
+		}
+	case map[string]interface{}:
+		if headers, ok := e["headers"].(map[string]interface{}); ok {
+			if key, ok := headers["X-API-Key"].(string); ok {
+				return key
+			}
+			if key, ok := headers["x-api-key"].(string); ok {
+				return key
+			}
+		}
+	}
 	return ""
 }
 
@@ -173,18 +204,18 @@ func LambdaAuthMiddleware(event interface{}) (context.Context, *aws.LambdaRespon
 	ctx := context.Background()
 
 	uc := authusecases.NewAuthUserUseCase(
-		userrepositories.NewUserRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		userrepositories.NewUserRepository(ctx, database.GoProjectSkeletondb.DB, providers.Logger),
 		providers.JWTProviderInstance,
+		providers.NewTokenRevocationProvider(providers.CacheProviderInstance),
+		authrepositories.NewAPIKeyRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		providers.HashProviderInstance,
+		userrepositories.NewPermissionRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		providers.CacheProviderInstance,
 	)
-	ucResult := usecase.InstrumentUseCase(
-		uc,
+	ucResult := usecase.Intercept(uc, "auth_user_use_case").Execute(
 		&appcontext.AppContext{Context: ctx},
 		locales.LocaleTypeEnum(locale),
 		token,
-		observability.GetObservabilityComponents().Tracer,
-		observability.GetObservabilityComponents().Metrics,
-		observability.GetObservabilityComponents().Clock,
-		"auth_user_use_case",
 	)
 
 	if ucResult.HasError() {
@@ -251,9 +282,11 @@ func HandleLambdaEventWithAuth(
 	if user, ok := user.(usermodels.UserWithRole); ok {
 		appContext.AddUserToContext(&user)
 	}
+	appContext.AddClientIPToContext(handlerCtx.Context.ClientIP)
 
 	// Replace context with authenticated context
 	localeStr := string(handlerCtx.Locale)
+	idempotencyKey := handlerCtx.IdempotencyKey
 	handlerCtx = handlers.NewHandlerContext(
 		&appContext,
 		&localeStr,
@@ -262,6 +295,7 @@ func HandleLambdaEventWithAuth(
 		handlerCtx.Query,
 		handlerCtx.ResponseWriter,
 	)
+	handlerCtx.IdempotencyKey = idempotencyKey
 
 	// Execute the handler
 	handlerFunc(handlerCtx)
@@ -291,18 +325,18 @@ func HandleLambdaEventWithOptionalAuth(
 	// Only validate if token is present
 	if token != "" && strings.TrimSpace(token) != "" {
 		uc := authusecases.NewAuthUserUseCase(
-			userrepositories.NewUserRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+			userrepositories.NewUserRepository(ctx, database.GoProjectSkeletondb.DB, providers.Logger),
 			providers.JWTProviderInstance,
+			providers.NewTokenRevocationProvider(providers.CacheProviderInstance),
+			authrepositories.NewAPIKeyRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+			providers.HashProviderInstance,
+			userrepositories.NewPermissionRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+			providers.CacheProviderInstance,
 		)
-		ucResult := usecase.InstrumentUseCase(
-			uc,
+		ucResult := usecase.Intercept(uc, "auth_user_use_case").Execute(
 			&appcontext.AppContext{Context: ctx},
 			locales.LocaleTypeEnum(locale),
 			token,
-			observability.GetObservabilityComponents().Tracer,
-			observability.GetObservabilityComponents().Metrics,
-			observability.GetObservabilityComponents().Clock,
-			"auth_user_use_case",
 		)
 
 		if !ucResult.HasError() {
@@ -328,14 +362,16 @@ func HandleLambdaEventWithOptionalAuth(
 
 	// Replace context with (possibly authenticated) context
 	localeStr := string(handlerCtx.Locale)
+	idempotencyKey := handlerCtx.IdempotencyKey
 	handlerCtx = handlers.NewHandlerContext(
-		&appcontext.AppContext{Context: ctx},
+		&appcontext.AppContext{Context: ctx, ClientIP: handlerCtx.Context.ClientIP},
 		&localeStr,
 		handlerCtx.Params,
 		handlerCtx.Body,
 		handlerCtx.Query,
 		handlerCtx.ResponseWriter,
 	)
+	handlerCtx.IdempotencyKey = idempotencyKey
 
 	// Execute the handler
 	handlerFunc(handlerCtx)
@@ -21,10 +21,35 @@ const FunctionsJSONPath = "../functions.json"
 func GetHandlerPackage(handlerName string) string {
 	handlerPackages := map[string]string{
 		// Auth handlers
-		"Login":                "authhandlers",
-		"RefreshAccessToken":   "authhandlers",
-		"RequestPasswordReset": "authhandlers",
-		"LoginOTP":             "authhandlers",
+		"Login":                      "authhandlers",
+		"RefreshAccessToken":         "authhandlers",
+		"RequestPasswordReset":       "authhandlers",
+		"LoginOTP":                   "authhandlers",
+		"UnlockAccount":              "authhandlers",
+		"Logout":                     "authhandlers",
+		"LogoutAll":                  "authhandlers",
+		"EnrollTOTP":                 "authhandlers",
+		"ConfirmTOTP":                "authhandlers",
+		"BeginWebAuthnRegistration":  "authhandlers",
+		"FinishWebAuthnRegistration": "authhandlers",
+		"BeginWebAuthnLogin":         "authhandlers",
+		"FinishWebAuthnLogin":        "authhandlers",
+		"StartOIDCLogin":             "authhandlers",
+		"OIDCLogin":                  "authhandlers",
+		"StartOIDCLink":              "authhandlers",
+		"LinkOIDCIdentity":           "authhandlers",
+		"CreateAPIKey":               "authhandlers",
+		"GetAPIKeys":                 "authhandlers",
+		"RevokeAPIKey":               "authhandlers",
+		"SwitchTenant":               "authhandlers",
+		"ImpersonateUser":            "authhandlers",
+		"EndImpersonation":           "authhandlers",
+		"RegisterOAuthClient":        "authhandlers",
+		"AuthorizeOAuthClient":       "authhandlers",
+		"OAuthToken":                 "authhandlers",
+		"IntrospectOAuthToken":       "authhandlers",
+		"RevokeOAuthToken":           "authhandlers",
+		"GetJWKS":                    "authhandlers",
 		// User handlers
 		"CreateUser":            "userhandlers",
 		"GetUser":               "userhandlers",
@@ -34,11 +59,27 @@ func GetHandlerPackage(handlerName string) string {
 		"CreateUserAndPassword": "userhandlers",
 		"ActivateUser":          "userhandlers",
 		"ResendWelcomeEmail":    "userhandlers",
+		// Role handlers
+		"CreateRole":         "userhandlers",
+		"GetRole":            "userhandlers",
+		"GetAllRole":         "userhandlers",
+		"UpdateRole":         "userhandlers",
+		"DeleteRole":         "userhandlers",
+		"GetRolePermissions": "userhandlers",
+		"SetRolePermissions": "userhandlers",
+		// Organization handlers
+		"CreateOrganization":       "userhandlers",
+		"GetAllOrganization":       "userhandlers",
+		"AddOrganizationMember":    "userhandlers",
+		"RemoveOrganizationMember": "userhandlers",
 		// Password handlers
 		"CreatePassword":      "passwordhandlers",
 		"CreatePasswordToken": "passwordhandlers",
 		// Status handlers
 		"GetHealthCheck": "statushandlers",
+		"GetTask":        "statushandlers",
+		"GetPipes":       "statushandlers",
+		"GetPipe":        "statushandlers",
 	}
 
 	if pkg, ok := handlerPackages[handlerName]; ok {
@@ -68,11 +109,39 @@ func GetInitFunction(handlerName string) string {
 	initFunctions := map[string]string{
 		// Status handlers
 		"GetHealthCheck": "InitializeForStatus",
+		"GetTask":        "InitializeForStatusWithAuth",
+		"GetPipes":       "InitializeForStatusWithAuth",
+		"GetPipe":        "InitializeForStatusWithAuth",
 		// Auth handlers
-		"Login":                "InitializeForAuthLogin",
-		"RefreshAccessToken":   "InitializeForAuthRefresh",
-		"LoginOTP":             "InitializeForAuthLoginOTP",
-		"RequestPasswordReset": "InitializeForAuthPasswordReset",
+		"Login":                      "InitializeForAuthLogin",
+		"RefreshAccessToken":         "InitializeForAuthRefresh",
+		"LoginOTP":                   "InitializeForAuthLoginOTP",
+		"UnlockAccount":              "InitializeForAuthUnlock",
+		"RequestPasswordReset":       "InitializeForAuthPasswordReset",
+		"Logout":                     "InitializeForAuthLogout",
+		"LogoutAll":                  "InitializeForAuthLogout",
+		"EnrollTOTP":                 "InitializeForAuthMFA",
+		"ConfirmTOTP":                "InitializeForAuthMFA",
+		"BeginWebAuthnRegistration":  "InitializeForAuthWebAuthn",
+		"FinishWebAuthnRegistration": "InitializeForAuthWebAuthn",
+		"BeginWebAuthnLogin":         "InitializeForAuthWebAuthn",
+		"FinishWebAuthnLogin":        "InitializeForAuthWebAuthn",
+		"StartOIDCLogin":             "InitializeForAuthOIDC",
+		"OIDCLogin":                  "InitializeForAuthOIDC",
+		"StartOIDCLink":              "InitializeForAuthOIDC",
+		"LinkOIDCIdentity":           "InitializeForAuthOIDC",
+		"CreateAPIKey":               "InitializeForAuthAPIKey",
+		"GetAPIKeys":                 "InitializeForAuthAPIKey",
+		"RevokeAPIKey":               "InitializeForAuthAPIKey",
+		"SwitchTenant":               "InitializeForOrganization",
+		"ImpersonateUser":            "InitializeForAuthLogout",
+		"EndImpersonation":           "InitializeForAuthLogout",
+		"RegisterOAuthClient":        "InitializeForOAuth",
+		"AuthorizeOAuthClient":       "InitializeForOAuth",
+		"OAuthToken":                 "InitializeForOAuth",
+		"IntrospectOAuthToken":       "InitializeForOAuth",
+		"RevokeOAuthToken":           "InitializeForOAuth",
+		"GetJWKS":                    "InitializeForAuthJWKS",
 		// User handlers
 		"CreateUser":            "InitializeForUser",
 		"GetUser":               "InitializeForUser",
@@ -82,6 +151,19 @@ func GetInitFunction(handlerName string) string {
 		"GetAllUser":            "InitializeForUserWithCache",
 		"CreateUserAndPassword": "InitializeForUserWithEmail",
 		"ResendWelcomeEmail":    "InitializeForUserWithEmail",
+		// Role handlers
+		"CreateRole":         "InitializeForRole",
+		"GetRole":            "InitializeForRole",
+		"GetAllRole":         "InitializeForRole",
+		"UpdateRole":         "InitializeForRole",
+		"DeleteRole":         "InitializeForRole",
+		"GetRolePermissions": "InitializeForRole",
+		"SetRolePermissions": "InitializeForRole",
+		// Organization handlers
+		"CreateOrganization":       "InitializeForOrganization",
+		"GetAllOrganization":       "InitializeForOrganization",
+		"AddOrganizationMember":    "InitializeForOrganization",
+		"RemoveOrganizationMember": "InitializeForOrganization",
 		// Password handlers
 		"CreatePassword":      "InitializeForPassword",
 		"CreatePasswordToken": "InitializeForPasswordWithEmail",
@@ -7,19 +7,28 @@ import (
 	"log"
 	"strings"
 	"sync"
+	"time"
 
 	contractsProviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
 	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/outbox"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/policy"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/services"
 	email_service "github.com/simon3640/goprojectskeleton/src/application/shared/services/emails"
 	email_models "github.com/simon3640/goprojectskeleton/src/application/shared/services/emails/models"
 	settings "github.com/simon3640/goprojectskeleton/src/application/shared/settings"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/workers"
 	"github.com/simon3640/goprojectskeleton/src/infrastructure/config"
 	database "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton"
+	outboxrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/outbox"
+	reposhared "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/shared"
+	authhandlers "github.com/simon3640/goprojectskeleton/src/infrastructure/handlers/auth"
+	userhandlers "github.com/simon3640/goprojectskeleton/src/infrastructure/handlers/user"
 	"github.com/simon3640/goprojectskeleton/src/infrastructure/otel"
 	"github.com/simon3640/goprojectskeleton/src/infrastructure/providers"
 )
@@ -30,6 +39,8 @@ var (
 	initializedJWT      bool
 	initializedCache    bool
 	initializedEmail    bool
+	initializedOIDC     bool
+	initializedPolicy   bool
 	initMutex           sync.Mutex
 )
 
@@ -72,11 +83,26 @@ func InitializeBase() *application_errors.ApplicationError {
 		})
 	}
 
+	InitializeUseCaseMiddlewares()
+
 	initializedBase = true
 	log.Println("Base infrastructure initialized successfully")
 	return nil
 }
 
+// InitializeUseCaseMiddlewares installs the middleware chain of every
+// intercepted use case, and registers the middlewares of each use case.
+func InitializeUseCaseMiddlewares() {
+	usecase.Use(
+		usecase.Recovery(),
+		usecase.Tracing(),
+		usecase.Metrics(),
+		usecase.Validation(),
+		usecase.Authorization(),
+	)
+	authhandlers.RegisterUseCaseMiddlewares()
+}
+
 // InitializeDatabase initializes the database connection.
 func InitializeDatabase() *application_errors.ApplicationError {
 	initMutex.Lock()
@@ -132,6 +158,13 @@ func InitializeJWT() *application_errors.ApplicationError {
 		settings.AppSettingsInstance.JWTRefreshTTL,
 		settings.AppSettingsInstance.JWTClockSkew,
 	)
+	if err := providers.JWTProviderInstance.SetupKeys(
+		settings.AppSettingsInstance.JWTSigningKeys,
+		settings.AppSettingsInstance.JWTAlgorithm,
+		settings.AppSettingsInstance.JWTKeyGracePeriod,
+	); err != nil {
+		return err
+	}
 
 	initializedJWT = true
 	log.Println("JWT initialized successfully")
@@ -164,6 +197,56 @@ func InitializeCache() *application_errors.ApplicationError {
 	return nil
 }
 
+// InitializeOIDC initializes the identity providers of the OIDC login.
+func InitializeOIDC() *application_errors.ApplicationError {
+	initMutex.Lock()
+	defer initMutex.Unlock()
+
+	if initializedOIDC {
+		return nil
+	}
+
+	if !initializedBase {
+		if err := InitializeBase(); err != nil {
+			return err
+		}
+	}
+
+	if err := providers.OIDCProviderInstance.Setup(settings.AppSettingsInstance.OIDCProviders); err != nil {
+		return err
+	}
+
+	initializedOIDC = true
+	log.Println("OIDC initialized successfully")
+	return nil
+}
+
+// InitializePolicy initializes the policy engine of the policy guards.
+func InitializePolicy() *application_errors.ApplicationError {
+	initMutex.Lock()
+	defer initMutex.Unlock()
+
+	if initializedPolicy {
+		return nil
+	}
+
+	if !initializedBase {
+		if err := InitializeBase(); err != nil {
+			return err
+		}
+	}
+
+	loaded, err := policy.LoadPolicyFile(settings.AppSettingsInstance.PolicyFile)
+	if err != nil {
+		return err
+	}
+	policy.InitializeEngine(*loaded, settings.AppSettingsInstance.PolicyExplain)
+
+	initializedPolicy = true
+	log.Println("Policy initialized successfully")
+	return nil
+}
+
 // InitializeEmail initializes email provider, render providers, and email services.
 func InitializeEmail() *application_errors.ApplicationError {
 	initMutex.Lock()
@@ -191,6 +274,7 @@ func InitializeEmail() *application_errors.ApplicationError {
 	var renderNewUser contractsProviders.IRendererProvider[email_models.NewUserEmailData]
 	var renderResetPassword contractsProviders.IRendererProvider[email_models.ResetPasswordEmailData]
 	var renderOTP contractsProviders.IRendererProvider[email_models.OneTimePasswordEmailData]
+	var renderAccountLocked contractsProviders.IRendererProvider[email_models.AccountLockedEmailData]
 
 	// Check if templates are stored in S3
 	templatesPath := settings.AppSettingsInstance.TemplatesPath
@@ -207,7 +291,7 @@ func InitializeEmail() *application_errors.ApplicationError {
 		}
 		bucket := parts[0]
 
-		s3RenderNewUser, s3RenderResetPassword, s3RenderOTP, err := NewS3RenderProviders(bucket)
+		s3RenderNewUser, s3RenderResetPassword, s3RenderOTP, s3RenderAccountLocked, err := NewS3RenderProviders(bucket)
 		if err != nil {
 			return application_errors.NewApplicationError(
 				status.ProviderInitializationError,
@@ -219,6 +303,7 @@ func InitializeEmail() *application_errors.ApplicationError {
 		renderNewUser = s3RenderNewUser
 		renderResetPassword = s3RenderResetPassword
 		renderOTP = s3RenderOTP
+		renderAccountLocked = s3RenderAccountLocked
 
 		providers.Logger.Info(fmt.Sprintf("Using S3 render providers with bucket: %s", bucket))
 	} else {
@@ -245,6 +330,11 @@ func InitializeEmail() *application_errors.ApplicationError {
 		providers.EmailProviderInstance,
 	)
 
+	email_service.AccountLockedEmailServiceInstance.SetUp(
+		renderAccountLocked,
+		providers.EmailProviderInstance,
+	)
+
 	initializedEmail = true
 	log.Println("Email initialized successfully")
 	return nil
@@ -261,6 +351,30 @@ func InitializeBackGroundExecutor() *application_errors.ApplicationError {
 	return nil
 }
 
+// InitializeOutbox initializes the outbox backed by the database and registers
+// the durable tasks handlers.
+// Lambda functions do not run the dispatcher loop; the due tasks are
+// dispatched once in background on every cold start.
+func InitializeOutbox() *application_errors.ApplicationError {
+	ob := outbox.InitializeOutbox(
+		outboxrepositories.NewOutboxTaskRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		reposhared.NewUnitOfWork(database.GoProjectSkeletondb.DB, providers.Logger),
+		outbox.Options{
+			MaxAttempts: settings.AppSettingsInstance.OutboxMaxAttempts,
+			BatchSize:   settings.AppSettingsInstance.OutboxBatchSize,
+			Lease:       time.Duration(settings.AppSettingsInstance.OutboxLeaseSeconds) * time.Second,
+			Backoff:     time.Duration(settings.AppSettingsInstance.OutboxBackoffSeconds) * time.Second,
+		},
+	)
+	userhandlers.RegisterOutboxHandlers()
+	if executor := workers.GetBackgroundExecutor(); executor != nil {
+		_ = executor.Submit(func(ctx context.Context) {
+			ob.DispatchDue(&app_context.AppContext{Context: ctx})
+		})
+	}
+	return nil
+}
+
 // InitializeInfrastructure initializes all infrastructure components.
 // This is kept for backward compatibility but should be replaced with
 // specific initialization functions for better tree-shaking.
@@ -289,6 +403,22 @@ func InitializeForStatus() *application_errors.ApplicationError {
 	return InitializeBase()
 }
 
+// InitializeForStatusWithAuth initializes infrastructure for the status
+// handlers that require an authenticated user (pipes, background tasks).
+// Requires: Base, Database, JWT, Cache.
+func InitializeForStatusWithAuth() *application_errors.ApplicationError {
+	if err := InitializeBase(); err != nil {
+		return err
+	}
+	if err := InitializeDatabase(); err != nil {
+		return err
+	}
+	if err := InitializeJWT(); err != nil {
+		return err
+	}
+	return InitializeCache()
+}
+
 // InitializeForAuthLogin initializes infrastructure for auth login handler.
 // Requires: Base, Database, JWT, Cache.
 func InitializeForAuthLogin() *application_errors.ApplicationError {
@@ -314,17 +444,140 @@ func InitializeForAuthLogin() *application_errors.ApplicationError {
 }
 
 // InitializeForAuthRefresh initializes infrastructure for auth refresh handler.
-// Requires: Base, JWT.
+// Requires: Base, Database, JWT, Cache.
 func InitializeForAuthRefresh() *application_errors.ApplicationError {
 	if err := InitializeBase(); err != nil {
 		return err
 	}
+	if err := InitializeDatabase(); err != nil {
+		return err
+	}
+	if err := InitializeJWT(); err != nil {
+		return err
+	}
+	if err := InitializeCache(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// InitializeForAuthLogout initializes infrastructure for auth logout handlers.
+// Requires: Base, Database, JWT, Cache.
+func InitializeForAuthLogout() *application_errors.ApplicationError {
+	if err := InitializeBase(); err != nil {
+		return err
+	}
+	if err := InitializeDatabase(); err != nil {
+		return err
+	}
+	if err := InitializeJWT(); err != nil {
+		return err
+	}
+	if err := InitializeCache(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// InitializeForAuthMFA initializes infrastructure for the MFA enrollment handlers.
+// Requires: Base, Database, JWT, Cache.
+func InitializeForAuthMFA() *application_errors.ApplicationError {
+	if err := InitializeBase(); err != nil {
+		return err
+	}
+	if err := InitializeDatabase(); err != nil {
+		return err
+	}
+	if err := InitializeJWT(); err != nil {
+		return err
+	}
+	if err := InitializeCache(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// InitializeForAuthWebAuthn initializes infrastructure for the passkey handlers.
+// Requires: Base, Database, JWT, Cache.
+func InitializeForAuthWebAuthn() *application_errors.ApplicationError {
+	if err := InitializeBase(); err != nil {
+		return err
+	}
+	if err := InitializeDatabase(); err != nil {
+		return err
+	}
+	if err := InitializeJWT(); err != nil {
+		return err
+	}
+	if err := InitializeCache(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// InitializeForAuthOIDC initializes infrastructure for the identity provider login and linking handlers.
+// Requires: Base, Database, JWT, Cache, OIDC.
+func InitializeForAuthOIDC() *application_errors.ApplicationError {
+	if err := InitializeBase(); err != nil {
+		return err
+	}
+	if err := InitializeDatabase(); err != nil {
+		return err
+	}
+	if err := InitializeJWT(); err != nil {
+		return err
+	}
+	if err := InitializeCache(); err != nil {
+		return err
+	}
+	return InitializeOIDC()
+}
+
+// InitializeForAuthAPIKey initializes infrastructure for the API key handlers.
+// Requires: Base, Database, JWT, Cache.
+func InitializeForAuthAPIKey() *application_errors.ApplicationError {
+	if err := InitializeBase(); err != nil {
+		return err
+	}
+	if err := InitializeDatabase(); err != nil {
+		return err
+	}
 	if err := InitializeJWT(); err != nil {
 		return err
 	}
+	if err := InitializeCache(); err != nil {
+		return err
+	}
 	return nil
 }
 
+// InitializeForOAuth initializes infrastructure for the OAuth2 authorization server handlers.
+// Requires: Base, Database, JWT, Cache.
+func InitializeForOAuth() *application_errors.ApplicationError {
+	if err := InitializeBase(); err != nil {
+		return err
+	}
+	if err := InitializeDatabase(); err != nil {
+		return err
+	}
+	if err := InitializeJWT(); err != nil {
+		return err
+	}
+	if err := InitializeCache(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// InitializeForAuthJWKS initializes infrastructure for the JWKS handler.
+// Requires: Base, JWT.
+func InitializeForAuthJWKS() *application_errors.ApplicationError {
+	if err := InitializeBase(); err != nil {
+		return err
+	}
+	return InitializeJWT()
+}
+
 // InitializeForAuthLoginOTP initializes infrastructure for auth login OTP handler.
 // Requires: Base, Database, JWT.
 func InitializeForAuthLoginOTP() *application_errors.ApplicationError {
@@ -340,6 +593,18 @@ func InitializeForAuthLoginOTP() *application_errors.ApplicationError {
 	return nil
 }
 
+// InitializeForAuthUnlock initializes infrastructure for the account unlock handler.
+// Requires: Base, Database, Cache.
+func InitializeForAuthUnlock() *application_errors.ApplicationError {
+	if err := InitializeBase(); err != nil {
+		return err
+	}
+	if err := InitializeDatabase(); err != nil {
+		return err
+	}
+	return InitializeCache()
+}
+
 // InitializeForAuthPasswordReset initializes infrastructure for auth password reset handler.
 // Requires: Base, Database, Email.
 func InitializeForAuthPasswordReset() *application_errors.ApplicationError {
@@ -359,7 +624,7 @@ func InitializeForAuthPasswordReset() *application_errors.ApplicationError {
 }
 
 // InitializeForUser initializes infrastructure for basic user handlers.
-// Requires: Base, Database.
+// Requires: Base, Database, JWT, Cache, Policy.
 func InitializeForUser() *application_errors.ApplicationError {
 	if err := InitializeBase(); err != nil {
 		return err
@@ -370,6 +635,12 @@ func InitializeForUser() *application_errors.ApplicationError {
 	if err := InitializeJWT(); err != nil {
 		return err
 	}
+	if err := InitializeCache(); err != nil {
+		return err
+	}
+	if err := InitializePolicy(); err != nil {
+		return err
+	}
 	if err := InitializeBackGroundExecutor(); err != nil {
 		return err
 	}
@@ -400,9 +671,43 @@ func InitializeForUserWithEmail() *application_errors.ApplicationError {
 	if err := InitializeBackGroundExecutor(); err != nil {
 		return err
 	}
+	if err := InitializeOutbox(); err != nil {
+		return err
+	}
 	return nil
 }
 
+// InitializeForRole initializes infrastructure for the role handlers.
+// Requires: Base, Database, JWT, Cache.
+func InitializeForRole() *application_errors.ApplicationError {
+	if err := InitializeBase(); err != nil {
+		return err
+	}
+	if err := InitializeDatabase(); err != nil {
+		return err
+	}
+	if err := InitializeJWT(); err != nil {
+		return err
+	}
+	return InitializeCache()
+}
+
+// InitializeForOrganization initializes infrastructure for the organization
+// handlers and the tenant switch.
+// Requires: Base, Database, JWT, Cache.
+func InitializeForOrganization() *application_errors.ApplicationError {
+	if err := InitializeBase(); err != nil {
+		return err
+	}
+	if err := InitializeDatabase(); err != nil {
+		return err
+	}
+	if err := InitializeJWT(); err != nil {
+		return err
+	}
+	return InitializeCache()
+}
+
 // InitializeForPassword initializes infrastructure for password handlers.
 // Requires: Base, Database.
 func InitializeForPassword() *application_errors.ApplicationError {
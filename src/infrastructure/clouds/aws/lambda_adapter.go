@@ -10,6 +10,7 @@ import (
 	"strings"
 
 	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/settings"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/workers"
 	handlers "github.com/simon3640/goprojectskeleton/src/infrastructure/handlers/shared"
 )
@@ -84,6 +85,8 @@ func (a *LambdaAdapter) ToHandlerContext(
 ) handlers.HandlerContext {
 	ctx := context.Background()
 	locale := "en-US"
+	idempotencyKey := ""
+	clientIP := ""
 	var body io.ReadCloser
 	var query *handlers.Query
 
@@ -96,6 +99,8 @@ func (a *LambdaAdapter) ToHandlerContext(
 		} else if acceptLang, ok := e.Headers["Accept-Language"]; ok {
 			locale = acceptLang
 		}
+		idempotencyKey = headerValue(e.Headers, "Idempotency-Key")
+		clientIP = handlers.ClientIP(headerValue(e.Headers, handlers.FORWARDED_FOR.String()), e.RequestContext.HTTP.SourceIP, settings.AppSettingsInstance.TrustedProxies)
 
 		// Parse query parameters
 		query = a.parseQueryParamsV2(e.QueryStringParameters, e.RawQueryString)
@@ -122,6 +127,8 @@ func (a *LambdaAdapter) ToHandlerContext(
 		} else if acceptLang, ok := e.Headers["accept-language"]; ok {
 			locale = acceptLang
 		}
+		idempotencyKey = headerValue(e.Headers, "Idempotency-Key")
+		clientIP = handlers.ClientIP(headerValue(e.Headers, handlers.FORWARDED_FOR.String()), e.RequestContext.Identity.SourceIP, settings.AppSettingsInstance.TrustedProxies)
 
 		// Parse query parameters
 		query = a.parseQueryParamsV1(e.QueryStringParameters, e.MultiValueQueryStringParameters)
@@ -167,14 +174,26 @@ func (a *LambdaAdapter) ToHandlerContext(
 		responseWriter = NewLambdaResponseWriter()
 	}
 
-	return handlers.NewHandlerContext(
-		&app_context.AppContext{Context: ctx},
+	handlerCtx := handlers.NewHandlerContext(
+		&app_context.AppContext{Context: ctx, ClientIP: clientIP},
 		&locale,
 		params,
 		&body,
 		query,
 		responseWriter,
 	)
+	handlerCtx.IdempotencyKey = idempotencyKey
+	return handlerCtx
+}
+
+// headerValue returns the value of a header regardless of its case.
+func headerValue(headers map[string]string, name string) string {
+	for key, value := range headers {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return ""
 }
 
 // ParsePathParams extracts path parameters from a route pattern and path.
@@ -159,25 +159,36 @@ type S3RenderOTPEmail struct {
 	*S3RendererBase[email_models.OneTimePasswordEmailData]
 }
 
+// S3RenderAccountLockedEmail renders account locked emails from S3
+type S3RenderAccountLockedEmail struct {
+	*S3RendererBase[email_models.AccountLockedEmailData]
+}
+
 // NewS3RenderProviders creates all S3 render providers
-func NewS3RenderProviders(bucket string) (*S3RenderNewUserEmail, *S3RenderResetPasswordEmail, *S3RenderOTPEmail, error) {
+func NewS3RenderProviders(bucket string) (*S3RenderNewUserEmail, *S3RenderResetPasswordEmail, *S3RenderOTPEmail, *S3RenderAccountLockedEmail, error) {
 	baseNewUser, err := NewS3RendererBase[email_models.NewUserEmailData](bucket)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 
 	baseResetPassword, err := NewS3RendererBase[email_models.ResetPasswordEmailData](bucket)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
 	}
 
 	baseOTP, err := NewS3RendererBase[email_models.OneTimePasswordEmailData](bucket)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, nil, err
+	}
+
+	baseAccountLocked, err := NewS3RendererBase[email_models.AccountLockedEmailData](bucket)
+	if err != nil {
+		return nil, nil, nil, nil, err
 	}
 
 	return &S3RenderNewUserEmail{baseNewUser},
 		&S3RenderResetPasswordEmail{baseResetPassword},
 		&S3RenderOTPEmail{baseOTP},
+		&S3RenderAccountLockedEmail{baseAccountLocked},
 		nil
 }
@@ -25,6 +25,36 @@ func GetAll() []Config {
 			Method:      "get",
 			AuthLevel:   "anonymous",
 		},
+		{
+			Path:           "status/get_task",
+			HandlerName:    "GetTask",
+			Route:          "task/{id}",
+			Method:         "get",
+			AuthLevel:      "function",
+			NeedsAuth:      true,
+			HasPathParams:  true,
+			PathParamName:  "id",
+			PathParamRoute: "/api/task/:id",
+		},
+		{
+			Path:        "status/get_pipes",
+			HandlerName: "GetPipes",
+			Route:       "admin/pipe",
+			Method:      "get",
+			AuthLevel:   "function",
+			NeedsAuth:   true,
+		},
+		{
+			Path:           "status/get_pipe",
+			HandlerName:    "GetPipe",
+			Route:          "admin/pipe/{name}",
+			Method:         "get",
+			AuthLevel:      "function",
+			NeedsAuth:      true,
+			HasPathParams:  true,
+			PathParamName:  "name",
+			PathParamRoute: "/api/admin/pipe/:name",
+		},
 		// Auth routes
 		{
 			Path:        "auth/login",
@@ -40,6 +70,188 @@ func GetAll() []Config {
 			Method:      "post",
 			AuthLevel:   "anonymous",
 		},
+		{
+			Path:        "auth/logout",
+			HandlerName: "Logout",
+			Route:       "auth/logout",
+			Method:      "post",
+			AuthLevel:   "anonymous",
+		},
+		{
+			Path:        "auth/logout_all",
+			HandlerName: "LogoutAll",
+			Route:       "auth/logout-all",
+			Method:      "post",
+			AuthLevel:   "function",
+			NeedsAuth:   true,
+		},
+		{
+			Path:        "auth/mfa_totp_enroll",
+			HandlerName: "EnrollTOTP",
+			Route:       "auth/mfa/totp/enroll",
+			Method:      "post",
+			AuthLevel:   "function",
+			NeedsAuth:   true,
+		},
+		{
+			Path:        "auth/mfa_totp_confirm",
+			HandlerName: "ConfirmTOTP",
+			Route:       "auth/mfa/totp/confirm",
+			Method:      "post",
+			AuthLevel:   "function",
+			NeedsAuth:   true,
+		},
+		{
+			Path:        "auth/webauthn_register_begin",
+			HandlerName: "BeginWebAuthnRegistration",
+			Route:       "auth/webauthn/register/begin",
+			Method:      "post",
+			AuthLevel:   "function",
+			NeedsAuth:   true,
+		},
+		{
+			Path:        "auth/webauthn_register_finish",
+			HandlerName: "FinishWebAuthnRegistration",
+			Route:       "auth/webauthn/register/finish",
+			Method:      "post",
+			AuthLevel:   "function",
+			NeedsAuth:   true,
+		},
+		{
+			Path:        "auth/webauthn_login_begin",
+			HandlerName: "BeginWebAuthnLogin",
+			Route:       "auth/webauthn/login/begin",
+			Method:      "post",
+			AuthLevel:   "anonymous",
+		},
+		{
+			Path:        "auth/webauthn_login_finish",
+			HandlerName: "FinishWebAuthnLogin",
+			Route:       "auth/webauthn/login/finish",
+			Method:      "post",
+			AuthLevel:   "anonymous",
+		},
+		{
+			Path:        "auth/oidc_authorize",
+			HandlerName: "StartOIDCLogin",
+			Route:       "auth/oidc/authorize",
+			Method:      "post",
+			AuthLevel:   "anonymous",
+		},
+		{
+			Path:        "auth/oidc_callback",
+			HandlerName: "OIDCLogin",
+			Route:       "auth/oidc/callback",
+			Method:      "post",
+			AuthLevel:   "anonymous",
+		},
+		{
+			Path:        "auth/oidc_link_authorize",
+			HandlerName: "StartOIDCLink",
+			Route:       "auth/oidc/link/authorize",
+			Method:      "post",
+			AuthLevel:   "function",
+			NeedsAuth:   true,
+		},
+		{
+			Path:        "auth/oidc_link_callback",
+			HandlerName: "LinkOIDCIdentity",
+			Route:       "auth/oidc/link/callback",
+			Method:      "post",
+			AuthLevel:   "function",
+			NeedsAuth:   true,
+		},
+		{
+			Path:        "auth/api_key_create",
+			HandlerName: "CreateAPIKey",
+			Route:       "auth/api-key",
+			Method:      "post",
+			AuthLevel:   "function",
+			NeedsAuth:   true,
+		},
+		{
+			Path:        "auth/api_key_list",
+			HandlerName: "GetAPIKeys",
+			Route:       "auth/api-key",
+			Method:      "get",
+			AuthLevel:   "function",
+			NeedsAuth:   true,
+		},
+		{
+			Path:           "auth/api_key_revoke",
+			HandlerName:    "RevokeAPIKey",
+			Route:          "auth/api-key/{id}",
+			Method:         "delete",
+			AuthLevel:      "function",
+			NeedsAuth:      true,
+			HasPathParams:  true,
+			PathParamName:  "id",
+			PathParamRoute: "/api/auth/api-key/:id",
+		},
+		{
+			Path:        "auth/tenant_switch",
+			HandlerName: "SwitchTenant",
+			Route:       "auth/tenant",
+			Method:      "post",
+			AuthLevel:   "function",
+			NeedsAuth:   true,
+		},
+		{
+			Path:           "auth/impersonate",
+			HandlerName:    "ImpersonateUser",
+			Route:          "auth/impersonate/{id}",
+			Method:         "post",
+			AuthLevel:      "function",
+			NeedsAuth:      true,
+			HasPathParams:  true,
+			PathParamName:  "id",
+			PathParamRoute: "/api/auth/impersonate/:id",
+		},
+		{
+			Path:        "auth/impersonate_end",
+			HandlerName: "EndImpersonation",
+			Route:       "auth/impersonate",
+			Method:      "delete",
+			AuthLevel:   "function",
+			NeedsAuth:   true,
+		},
+		{
+			Path:        "oauth/clients",
+			HandlerName: "RegisterOAuthClient",
+			Route:       "oauth/clients",
+			Method:      "post",
+			AuthLevel:   "function",
+			NeedsAuth:   true,
+		},
+		{
+			Path:        "oauth/authorize",
+			HandlerName: "AuthorizeOAuthClient",
+			Route:       "oauth/authorize",
+			Method:      "post",
+			AuthLevel:   "function",
+			NeedsAuth:   true,
+		},
+		{
+			Path:        "oauth/token",
+			HandlerName: "OAuthToken",
+			Route:       "oauth/token",
+			Method:      "post",
+			AuthLevel:   "anonymous",
+		},
+		{
+			Path:        "oauth/introspect",
+			HandlerName: "IntrospectOAuthToken",
+			Route:       "oauth/introspect",
+			Method:      "post",
+			AuthLevel:   "anonymous",
+		},
+		{
+			Path:        "oauth/revoke",
+			HandlerName: "RevokeOAuthToken",
+			Route:       "oauth/revoke",
+			Method:      "post",
+			AuthLevel:   "anonymous",
+		},
 		{
 			Path:           "auth/password_reset",
 			HandlerName:    "RequestPasswordReset",
@@ -51,14 +263,25 @@ func GetAll() []Config {
 			PathParamRoute: "/api/auth/password-reset/:identifier",
 		},
 		{
-			Path:           "auth/login_otp",
-			HandlerName:    "LoginOTP",
-			Route:          "auth/login-otp/{otp}",
-			Method:         "get",
-			AuthLevel:      "anonymous",
-			HasPathParams:  true,
-			PathParamName:  "otp",
-			PathParamRoute: "/api/auth/login-otp/:otp",
+			Path:        "auth/login_otp",
+			HandlerName: "LoginOTP",
+			Route:       "auth/login-otp",
+			Method:      "post",
+			AuthLevel:   "anonymous",
+		},
+		{
+			Path:        "auth/unlock",
+			HandlerName: "UnlockAccount",
+			Route:       "auth/unlock",
+			Method:      "post",
+			AuthLevel:   "anonymous",
+		},
+		{
+			Path:        "auth/jwks",
+			HandlerName: "GetJWKS",
+			Route:       ".well-known/jwks.json",
+			Method:      "get",
+			AuthLevel:   "anonymous",
 		},
 		// User routes - public
 		{
@@ -125,6 +348,119 @@ func GetAll() []Config {
 			PathParamName:  "id",
 			PathParamRoute: "/api/user/:id",
 		},
+		// Role routes
+		{
+			Path:        "role/create",
+			HandlerName: "CreateRole",
+			Route:       "role",
+			Method:      "post",
+			AuthLevel:   "function",
+			NeedsAuth:   true,
+		},
+		{
+			Path:        "role/get_all",
+			HandlerName: "GetAllRole",
+			Route:       "role",
+			Method:      "get",
+			AuthLevel:   "function",
+			NeedsAuth:   true,
+			NeedsQuery:  true,
+		},
+		{
+			Path:           "role/get",
+			HandlerName:    "GetRole",
+			Route:          "role/{id}",
+			Method:         "get",
+			AuthLevel:      "function",
+			NeedsAuth:      true,
+			HasPathParams:  true,
+			PathParamName:  "id",
+			PathParamRoute: "/api/role/:id",
+		},
+		{
+			Path:           "role/update",
+			HandlerName:    "UpdateRole",
+			Route:          "role/{id}",
+			Method:         "patch",
+			AuthLevel:      "function",
+			NeedsAuth:      true,
+			HasPathParams:  true,
+			PathParamName:  "id",
+			PathParamRoute: "/api/role/:id",
+		},
+		{
+			Path:           "role/delete",
+			HandlerName:    "DeleteRole",
+			Route:          "role/{id}",
+			Method:         "delete",
+			AuthLevel:      "function",
+			NeedsAuth:      true,
+			HasPathParams:  true,
+			PathParamName:  "id",
+			PathParamRoute: "/api/role/:id",
+		},
+		{
+			Path:           "role/permissions_get",
+			HandlerName:    "GetRolePermissions",
+			Route:          "role/{id}/permission",
+			Method:         "get",
+			AuthLevel:      "function",
+			NeedsAuth:      true,
+			HasPathParams:  true,
+			PathParamName:  "id",
+			PathParamRoute: "/api/role/:id/permission",
+		},
+		{
+			Path:           "role/permissions_set",
+			HandlerName:    "SetRolePermissions",
+			Route:          "role/{id}/permission",
+			Method:         "put",
+			AuthLevel:      "function",
+			NeedsAuth:      true,
+			HasPathParams:  true,
+			PathParamName:  "id",
+			PathParamRoute: "/api/role/:id/permission",
+		},
+		// Organization routes
+		{
+			Path:        "organization/create",
+			HandlerName: "CreateOrganization",
+			Route:       "organization",
+			Method:      "post",
+			AuthLevel:   "function",
+			NeedsAuth:   true,
+		},
+		{
+			Path:        "organization/get_all",
+			HandlerName: "GetAllOrganization",
+			Route:       "organization",
+			Method:      "get",
+			AuthLevel:   "function",
+			NeedsAuth:   true,
+			NeedsQuery:  true,
+		},
+		{
+			Path:           "organization/member_add",
+			HandlerName:    "AddOrganizationMember",
+			Route:          "organization/{id}/member",
+			Method:         "post",
+			AuthLevel:      "function",
+			NeedsAuth:      true,
+			HasPathParams:  true,
+			PathParamName:  "id",
+			PathParamRoute: "/api/organization/:id/member",
+		},
+		{
+			Path:           "organization/member_remove",
+			HandlerName:    "RemoveOrganizationMember",
+			Route:          "organization/{id}/member/{user_id}",
+			Method:         "delete",
+			AuthLevel:      "function",
+			NeedsAuth:      true,
+			HasPathParams:  true,
+			PathParamName:  "id",
+			PathParamRoute: "/api/organization/:id/member/:user_id",
+		},
 		// Password routes
 		{
 			Path:        "password/create",
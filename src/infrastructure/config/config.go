@@ -42,6 +42,9 @@ type Config struct {
 	RedisDB       string `env:"REDIS_DB" envDefault:"0"`
 	RedisTTL      string `env:"REDIS_TTL" envDefault:"300"`
 
+	// Idempotency
+	IdempotencyTTL string `env:"IDEMPOTENCY_TTL" envDefault:"86400"`
+
 	// Security
 	JWTSecretKey               string `env:"JWT_SECRET_KEY" envDefault:"secret"`
 	JWTIssuer                  string `env:"JWT_ISSUER" envDefault:"test-issuer"`
@@ -49,14 +52,38 @@ type Config struct {
 	JWTAccessTTL               string `env:"JWT_ACCESS_TTL" envDefault:"3600"`
 	JWTRefreshTTL              string `env:"JWT_REFRESH_TTL" envDefault:"86400"`
 	JWTClockSkew               string `env:"JWT_CLOCK_SKEW" envDefault:"60"`
+	JWTAlgorithm               string `env:"JWT_ALGORITHM" envDefault:"HS256"`
+	JWTSigningKeys             string `env:"JWT_SIGNING_KEYS" envDefault:""`
+	JWTKeyGracePeriod          string `env:"JWT_KEY_GRACE_PERIOD" envDefault:"86400"`
 	OneTimeTokenPasswordTTL    string `env:"ONE_TIME_TOKEN_TTL" envDefault:"15"`
 	OneTimeTokenEmailVerifyTTL string `env:"ONE_TIME_TOKEN_EMAIL_VERIFY_TTL" envDefault:"60"`
 	FrontendResetPasswordURL   string `env:"FRONTEND_RESET_PASSWORD_URL" envDefault:"http://localhost:3000/reset-password"`
 	FrontendActivateAccountURL string `env:"FRONTEND_ACTIVATE_ACCOUNT_URL" envDefault:"http://localhost:3000/activate-account"`
+	FrontendUnlockAccountURL   string `env:"FRONTEND_UNLOCK_ACCOUNT_URL" envDefault:"http://localhost:3000/unlock-account"`
 	OneTimePasswordLength      string `env:"ONE_TIME_PASSWORD_LENGTH" envDefault:"6"`
 	OneTimePasswordTTL         string `env:"ONE_TIME_PASSWORD_TTL" envDefault:"10"`
+	OneTimePasswordMaxAttempts string `env:"ONE_TIME_PASSWORD_MAX_ATTEMPTS" envDefault:"5"`
 	LoginMaxAttempts           string `env:"LOGIN_MAX_ATTEMPTS" envDefault:"5"`
 	LoginAttemptsWindowMinutes string `env:"LOGIN_ATTEMPTS_WINDOW_MINUTES" envDefault:"15"`
+	LoginDelayAfterAttempts    string `env:"LOGIN_DELAY_AFTER_ATTEMPTS" envDefault:"3"`
+	LoginDelaySeconds          string `env:"LOGIN_DELAY_SECONDS" envDefault:"2"`
+	LoginMaxAttemptsPerIP      string `env:"LOGIN_MAX_ATTEMPTS_PER_IP" envDefault:"50"`
+	LoginMaxAttemptsPerAccount string `env:"LOGIN_MAX_ATTEMPTS_PER_ACCOUNT" envDefault:"20"`
+	LoginGlobalFailureLimit    string `env:"LOGIN_GLOBAL_FAILURE_LIMIT" envDefault:"200"`
+	TrustedProxies             string `env:"TRUSTED_PROXIES" envDefault:""`
+	EncryptionKey              string `env:"ENCRYPTION_KEY" envDefault:"secret"`
+	TOTPIssuer                 string `env:"TOTP_ISSUER" envDefault:"GoProjectSkeleton"`
+	WebAuthnRPID               string `env:"WEBAUTHN_RP_ID" envDefault:"localhost"`
+	WebAuthnRPName             string `env:"WEBAUTHN_RP_NAME" envDefault:"GoProjectSkeleton"`
+	WebAuthnRPOrigins          string `env:"WEBAUTHN_RP_ORIGINS" envDefault:"http://localhost:3000"`
+	WebAuthnChallengeTTL       string `env:"WEBAUTHN_CHALLENGE_TTL" envDefault:"300"`
+	OIDCProviders              string `env:"OIDC_PROVIDERS" envDefault:""`
+	OIDCStateTTL               string `env:"OIDC_STATE_TTL" envDefault:"600"`
+	OIDCDefaultRoleID          string `env:"OIDC_DEFAULT_ROLE_ID" envDefault:"2"`
+	OAuthCodeTTL               string `env:"OAUTH_CODE_TTL" envDefault:"60"`
+	ImpersonationTTL           string `env:"IMPERSONATION_TTL" envDefault:"900"`
+	PolicyFile                 string `env:"POLICY_FILE" envDefault:""`
+	PolicyExplain              string `env:"POLICY_EXPLAIN" envDefault:"false"`
 
 	// Mail
 	MailHost         string `env:"MAIL_HOST" envDefault:"localhost"`
@@ -69,6 +96,13 @@ type Config struct {
 	BackgroundWorkers  string `env:"BACKGROUND_WORKERS" envDefault:"4"`
 	BackgroundQueueSize string `env:"BACKGROUND_QUEUE_SIZE" envDefault:"100"`
 
+	// Outbox
+	OutboxMaxAttempts    string `env:"OUTBOX_MAX_ATTEMPTS" envDefault:"5"`
+	OutboxPollInterval   string `env:"OUTBOX_POLL_INTERVAL" envDefault:"10"`
+	OutboxBatchSize      string `env:"OUTBOX_BATCH_SIZE" envDefault:"20"`
+	OutboxLeaseSeconds   string `env:"OUTBOX_LEASE_SECONDS" envDefault:"60"`
+	OutboxBackoffSeconds string `env:"OUTBOX_BACKOFF_SECONDS" envDefault:"5"`
+
 	// Observability
 	ObservabilityEnabled   string `env:"OBSERVABILITY_ENABLED" envDefault:"false"`
 	ObservabilityBackend   string `env:"OBSERVABILITY_BACKEND" envDefault:"noop"`
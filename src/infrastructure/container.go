@@ -2,16 +2,24 @@ package infrastructure
 
 import (
 	"context"
+	"time"
 
 	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/observability/noop"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/outbox"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/policy"
 	services "github.com/simon3640/goprojectskeleton/src/application/shared/services"
 	email_service "github.com/simon3640/goprojectskeleton/src/application/shared/services/emails"
 	settings "github.com/simon3640/goprojectskeleton/src/application/shared/settings"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/workers"
 	config "github.com/simon3640/goprojectskeleton/src/infrastructure/config"
 	database "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton"
 	initdb "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/init_db"
+	outboxrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/outbox"
+	reposhared "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/shared"
+	authhandlers "github.com/simon3640/goprojectskeleton/src/infrastructure/handlers/auth"
+	userhandlers "github.com/simon3640/goprojectskeleton/src/infrastructure/handlers/user"
 	providers "github.com/simon3640/goprojectskeleton/src/infrastructure/providers"
 )
 
@@ -58,6 +66,26 @@ func Initialize() *application_errors.ApplicationError {
 		settings.AppSettingsInstance.JWTRefreshTTL,
 		settings.AppSettingsInstance.JWTClockSkew,
 	)
+	if err := providers.JWTProviderInstance.SetupKeys(
+		settings.AppSettingsInstance.JWTSigningKeys,
+		settings.AppSettingsInstance.JWTAlgorithm,
+		settings.AppSettingsInstance.JWTKeyGracePeriod,
+	); err != nil {
+		return err
+	}
+
+	// Initialize OIDC Provider
+	if err := providers.OIDCProviderInstance.Setup(settings.AppSettingsInstance.OIDCProviders); err != nil {
+		return err
+	}
+
+	// Initialize Policy Engine
+	if err := InitializePolicy(); err != nil {
+		return err
+	}
+
+	// Initialize the middlewares of the use cases
+	InitializeUseCaseMiddlewares()
 
 	// Initialize Email Provider
 	providers.EmailProviderInstance.Setup(
@@ -90,6 +118,11 @@ func Initialize() *application_errors.ApplicationError {
 		providers.EmailProviderInstance,
 	)
 
+	email_service.AccountLockedEmailServiceInstance.SetUp(
+		providers.RenderAccountLockedEmailInstance,
+		providers.EmailProviderInstance,
+	)
+
 	// Initialize Background Executor
 	ctx := context.Background()
 	workers.InitializeBackgroundExecutor(
@@ -99,5 +132,48 @@ func Initialize() *application_errors.ApplicationError {
 	)
 
 	services.InitializeBackgroundServiceFactory()
+
+	// Initialize Outbox and its dispatcher
+	InitializeOutbox()
+	go outbox.GetOutbox().Run(ctx, time.Duration(settings.AppSettingsInstance.OutboxPollInterval)*time.Second)
+	return nil
+}
+
+// InitializePolicy initializes the policy engine with the policy file
+func InitializePolicy() *application_errors.ApplicationError {
+	loaded, err := policy.LoadPolicyFile(settings.AppSettingsInstance.PolicyFile)
+	if err != nil {
+		return err
+	}
+	policy.InitializeEngine(*loaded, settings.AppSettingsInstance.PolicyExplain)
 	return nil
 }
+
+// InitializeUseCaseMiddlewares installs the middleware chain of every
+// intercepted use case, and registers the middlewares of each use case
+func InitializeUseCaseMiddlewares() {
+	usecase.Use(
+		usecase.Recovery(),
+		usecase.Tracing(),
+		usecase.Metrics(),
+		usecase.Validation(),
+		usecase.Authorization(),
+	)
+	authhandlers.RegisterUseCaseMiddlewares()
+}
+
+// InitializeOutbox initializes the outbox backed by the database and
+// registers the durable tasks handlers
+func InitializeOutbox() {
+	outbox.InitializeOutbox(
+		outboxrepositories.NewOutboxTaskRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		reposhared.NewUnitOfWork(database.GoProjectSkeletondb.DB, providers.Logger),
+		outbox.Options{
+			MaxAttempts: settings.AppSettingsInstance.OutboxMaxAttempts,
+			BatchSize:   settings.AppSettingsInstance.OutboxBatchSize,
+			Lease:       time.Duration(settings.AppSettingsInstance.OutboxLeaseSeconds) * time.Second,
+			Backoff:     time.Duration(settings.AppSettingsInstance.OutboxBackoffSeconds) * time.Second,
+		},
+	)
+	userhandlers.RegisterOutboxHandlers()
+}
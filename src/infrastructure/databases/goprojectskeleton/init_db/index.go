@@ -22,12 +22,42 @@ func InitMigrate(db *gorm.DB, logger contractsproviders.ILoggerProvider) *applic
 	}
 	logger.Info("Role model migrated")
 
+	logger.Info("Auto migrating Permission model")
+	if err := setups.NewSetupPermission().Setup(db, dbmodels.Permission{}, &defaults.DefaultPermissions, logger); err != nil {
+		return applicationerrors.NewApplicationError(status.DatabaseInitializationError, messages.MessageKeysInstance.SOMETHING_WENT_WRONG, err.Error())
+	}
+	logger.Info("Permission model migrated")
+
+	logger.Info("Auto migrating RolePermission model")
+	if err := setups.NewSetupRolePermission().Setup(db, dbmodels.RolePermission{}, &defaults.DefaultRolePermissions, logger); err != nil {
+		return applicationerrors.NewApplicationError(status.DatabaseInitializationError, messages.MessageKeysInstance.SOMETHING_WENT_WRONG, err.Error())
+	}
+	logger.Info("RolePermission model migrated")
+
 	logger.Info("Auto migrating User model")
 	if err := setups.NewSetupUser().Setup(db, dbmodels.User{}, &defaults.DefaultUsers, logger); err != nil {
 		return applicationerrors.NewApplicationError(status.DatabaseInitializationError, messages.MessageKeysInstance.SOMETHING_WENT_WRONG, err.Error())
 	}
 	logger.Info("User model migrated")
 
+	logger.Info("Auto migrating Organization model")
+	if err := setups.NewSetupOrganization().Setup(db, dbmodels.Organization{}, nil, logger); err != nil {
+		return applicationerrors.NewApplicationError(status.DatabaseInitializationError, messages.MessageKeysInstance.SOMETHING_WENT_WRONG, err.Error())
+	}
+	logger.Info("Organization model migrated")
+
+	logger.Info("Auto migrating Membership model")
+	if err := setups.NewSetupMembership().Setup(db, dbmodels.Membership{}, nil, logger); err != nil {
+		return applicationerrors.NewApplicationError(status.DatabaseInitializationError, messages.MessageKeysInstance.SOMETHING_WENT_WRONG, err.Error())
+	}
+	logger.Info("Membership model migrated")
+
+	logger.Info("Auto migrating UserPermission model")
+	if err := setups.NewSetupUserPermission().Setup(db, dbmodels.UserPermission{}, nil, logger); err != nil {
+		return applicationerrors.NewApplicationError(status.DatabaseInitializationError, messages.MessageKeysInstance.SOMETHING_WENT_WRONG, err.Error())
+	}
+	logger.Info("UserPermission model migrated")
+
 	logger.Info("Auto migrating Password model")
 	if err := setups.NewSetupPassword().Setup(db, dbmodels.Password{}, &defaults.DefaultPasswords, logger); err != nil {
 		return applicationerrors.NewApplicationError(status.DatabaseInitializationError, messages.MessageKeysInstance.SOMETHING_WENT_WRONG, err.Error())
@@ -48,5 +78,65 @@ func InitMigrate(db *gorm.DB, logger contractsproviders.ILoggerProvider) *applic
 	}
 	logger.Info("OneTimePassword model migrated")
 
+	logger.Info("Auto migrating RefreshToken model")
+	if err := setups.NewSetupRefreshToken().Setup(db, dbmodels.RefreshToken{}, nil, logger); err != nil {
+		return applicationerrors.NewApplicationError(status.DatabaseInitializationError, messages.MessageKeysInstance.SOMETHING_WENT_WRONG, err.Error())
+	}
+	logger.Info("RefreshToken model migrated")
+
+	logger.Info("Auto migrating TOTPDevice model")
+	if err := setups.NewSetupTOTPDevice().Setup(db, dbmodels.TOTPDevice{}, nil, logger); err != nil {
+		return applicationerrors.NewApplicationError(status.DatabaseInitializationError, messages.MessageKeysInstance.SOMETHING_WENT_WRONG, err.Error())
+	}
+	logger.Info("TOTPDevice model migrated")
+
+	logger.Info("Auto migrating RecoveryCode model")
+	if err := setups.NewSetupRecoveryCode().Setup(db, dbmodels.RecoveryCode{}, nil, logger); err != nil {
+		return applicationerrors.NewApplicationError(status.DatabaseInitializationError, messages.MessageKeysInstance.SOMETHING_WENT_WRONG, err.Error())
+	}
+	logger.Info("RecoveryCode model migrated")
+
+	logger.Info("Auto migrating WebAuthnCredential model")
+	if err := setups.NewSetupWebAuthnCredential().Setup(db, dbmodels.WebAuthnCredential{}, nil, logger); err != nil {
+		return applicationerrors.NewApplicationError(status.DatabaseInitializationError, messages.MessageKeysInstance.SOMETHING_WENT_WRONG, err.Error())
+	}
+	logger.Info("WebAuthnCredential model migrated")
+
+	logger.Info("Auto migrating ExternalIdentity model")
+	if err := setups.NewSetupExternalIdentity().Setup(db, dbmodels.ExternalIdentity{}, nil, logger); err != nil {
+		return applicationerrors.NewApplicationError(status.DatabaseInitializationError, messages.MessageKeysInstance.SOMETHING_WENT_WRONG, err.Error())
+	}
+	logger.Info("ExternalIdentity model migrated")
+
+	logger.Info("Auto migrating OAuthClient model")
+	if err := setups.NewSetupOAuthClient().Setup(db, dbmodels.OAuthClient{}, nil, logger); err != nil {
+		return applicationerrors.NewApplicationError(status.DatabaseInitializationError, messages.MessageKeysInstance.SOMETHING_WENT_WRONG, err.Error())
+	}
+	logger.Info("OAuthClient model migrated")
+
+	logger.Info("Auto migrating OAuthConsent model")
+	if err := setups.NewSetupOAuthConsent().Setup(db, dbmodels.OAuthConsent{}, nil, logger); err != nil {
+		return applicationerrors.NewApplicationError(status.DatabaseInitializationError, messages.MessageKeysInstance.SOMETHING_WENT_WRONG, err.Error())
+	}
+	logger.Info("OAuthConsent model migrated")
+
+	logger.Info("Auto migrating APIKey model")
+	if err := setups.NewSetupAPIKey().Setup(db, dbmodels.APIKey{}, nil, logger); err != nil {
+		return applicationerrors.NewApplicationError(status.DatabaseInitializationError, messages.MessageKeysInstance.SOMETHING_WENT_WRONG, err.Error())
+	}
+	logger.Info("APIKey model migrated")
+
+	logger.Info("Auto migrating Impersonation model")
+	if err := setups.NewSetupImpersonation().Setup(db, dbmodels.Impersonation{}, nil, logger); err != nil {
+		return applicationerrors.NewApplicationError(status.DatabaseInitializationError, messages.MessageKeysInstance.SOMETHING_WENT_WRONG, err.Error())
+	}
+	logger.Info("Impersonation model migrated")
+
+	logger.Info("Auto migrating OutboxTask model")
+	if err := setups.NewSetupOutboxTask().Setup(db, dbmodels.OutboxTask{}, nil, logger); err != nil {
+		return applicationerrors.NewApplicationError(status.DatabaseInitializationError, messages.MessageKeysInstance.SOMETHING_WENT_WRONG, err.Error())
+	}
+	logger.Info("OutboxTask model migrated")
+
 	return nil
 }
@@ -0,0 +1,24 @@
+package setups
+
+import (
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	dbmodels "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/models"
+	authrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/auth"
+)
+
+// SetupAPIKey is the setup struct for the API key model
+type SetupAPIKey struct {
+	SetupBase[dtos.APIKeyCreate, dtos.APIKeyUpdate, sharedmodels.APIKey, dbmodels.APIKey]
+}
+
+var _ SetupModel[dtos.APIKeyCreate, dtos.APIKeyUpdate, sharedmodels.APIKey, dbmodels.APIKey] = (*SetupAPIKey)(nil)
+
+// NewSetupAPIKey creates a new setup for the API key model
+func NewSetupAPIKey() *SetupAPIKey {
+	return &SetupAPIKey{
+		SetupBase: SetupBase[dtos.APIKeyCreate, dtos.APIKeyUpdate, sharedmodels.APIKey, dbmodels.APIKey]{
+			modelConverter: &authrepositories.APIKeyConverter{},
+		},
+	}
+}
@@ -0,0 +1,24 @@
+package setups
+
+import (
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	dbmodels "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/models"
+	authrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/auth"
+)
+
+// SetupExternalIdentity is the setup struct for the external identity model
+type SetupExternalIdentity struct {
+	SetupBase[dtos.ExternalIdentityCreate, dtos.ExternalIdentityUpdate, sharedmodels.ExternalIdentity, dbmodels.ExternalIdentity]
+}
+
+var _ SetupModel[dtos.ExternalIdentityCreate, dtos.ExternalIdentityUpdate, sharedmodels.ExternalIdentity, dbmodels.ExternalIdentity] = (*SetupExternalIdentity)(nil)
+
+// NewSetupExternalIdentity creates a new setup for the external identity model
+func NewSetupExternalIdentity() *SetupExternalIdentity {
+	return &SetupExternalIdentity{
+		SetupBase: SetupBase[dtos.ExternalIdentityCreate, dtos.ExternalIdentityUpdate, sharedmodels.ExternalIdentity, dbmodels.ExternalIdentity]{
+			modelConverter: &authrepositories.ExternalIdentityConverter{},
+		},
+	}
+}
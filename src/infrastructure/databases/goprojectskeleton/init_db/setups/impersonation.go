@@ -0,0 +1,24 @@
+package setups
+
+import (
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	dbmodels "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/models"
+	authrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/auth"
+)
+
+// SetupImpersonation is the setup struct for the impersonation model
+type SetupImpersonation struct {
+	SetupBase[dtos.ImpersonationCreate, dtos.ImpersonationUpdate, sharedmodels.Impersonation, dbmodels.Impersonation]
+}
+
+var _ SetupModel[dtos.ImpersonationCreate, dtos.ImpersonationUpdate, sharedmodels.Impersonation, dbmodels.Impersonation] = (*SetupImpersonation)(nil)
+
+// NewSetupImpersonation creates a new setup for the impersonation model
+func NewSetupImpersonation() *SetupImpersonation {
+	return &SetupImpersonation{
+		SetupBase: SetupBase[dtos.ImpersonationCreate, dtos.ImpersonationUpdate, sharedmodels.Impersonation, dbmodels.Impersonation]{
+			modelConverter: &authrepositories.ImpersonationConverter{},
+		},
+	}
+}
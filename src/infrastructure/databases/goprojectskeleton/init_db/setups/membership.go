@@ -0,0 +1,23 @@
+package setups
+
+import (
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+	dbmodels "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/models"
+	userrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/user"
+)
+
+// SetupMembership is the setup struct for the membership model
+type SetupMembership struct {
+	SetupBase[usermodels.MembershipCreate, usermodels.MembershipUpdate, usermodels.Membership, dbmodels.Membership]
+}
+
+var _ SetupModel[usermodels.MembershipCreate, usermodels.MembershipUpdate, usermodels.Membership, dbmodels.Membership] = (*SetupMembership)(nil)
+
+// NewSetupMembership creates a new setup for the membership model
+func NewSetupMembership() *SetupMembership {
+	return &SetupMembership{
+		SetupBase: SetupBase[usermodels.MembershipCreate, usermodels.MembershipUpdate, usermodels.Membership, dbmodels.Membership]{
+			modelConverter: &userrepositories.MembershipConverter{},
+		},
+	}
+}
@@ -0,0 +1,24 @@
+package setups
+
+import (
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	dbmodels "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/models"
+	authrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/auth"
+)
+
+// SetupOAuthClient is the setup struct for the OAuth client model
+type SetupOAuthClient struct {
+	SetupBase[dtos.OAuthClientCreate, dtos.OAuthClientUpdate, sharedmodels.OAuthClient, dbmodels.OAuthClient]
+}
+
+var _ SetupModel[dtos.OAuthClientCreate, dtos.OAuthClientUpdate, sharedmodels.OAuthClient, dbmodels.OAuthClient] = (*SetupOAuthClient)(nil)
+
+// NewSetupOAuthClient creates a new setup for the OAuth client model
+func NewSetupOAuthClient() *SetupOAuthClient {
+	return &SetupOAuthClient{
+		SetupBase: SetupBase[dtos.OAuthClientCreate, dtos.OAuthClientUpdate, sharedmodels.OAuthClient, dbmodels.OAuthClient]{
+			modelConverter: &authrepositories.OAuthClientConverter{},
+		},
+	}
+}
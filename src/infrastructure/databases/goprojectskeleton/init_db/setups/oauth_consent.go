@@ -0,0 +1,24 @@
+package setups
+
+import (
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	dbmodels "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/models"
+	authrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/auth"
+)
+
+// SetupOAuthConsent is the setup struct for the OAuth consent model
+type SetupOAuthConsent struct {
+	SetupBase[dtos.OAuthConsentCreate, dtos.OAuthConsentUpdate, sharedmodels.OAuthConsent, dbmodels.OAuthConsent]
+}
+
+var _ SetupModel[dtos.OAuthConsentCreate, dtos.OAuthConsentUpdate, sharedmodels.OAuthConsent, dbmodels.OAuthConsent] = (*SetupOAuthConsent)(nil)
+
+// NewSetupOAuthConsent creates a new setup for the OAuth consent model
+func NewSetupOAuthConsent() *SetupOAuthConsent {
+	return &SetupOAuthConsent{
+		SetupBase: SetupBase[dtos.OAuthConsentCreate, dtos.OAuthConsentUpdate, sharedmodels.OAuthConsent, dbmodels.OAuthConsent]{
+			modelConverter: &authrepositories.OAuthConsentConverter{},
+		},
+	}
+}
@@ -0,0 +1,23 @@
+package setups
+
+import (
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+	dbmodels "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/models"
+	userrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/user"
+)
+
+// SetupOrganization is the setup struct for the organization model
+type SetupOrganization struct {
+	SetupBase[usermodels.OrganizationCreate, usermodels.OrganizationUpdate, usermodels.Organization, dbmodels.Organization]
+}
+
+var _ SetupModel[usermodels.OrganizationCreate, usermodels.OrganizationUpdate, usermodels.Organization, dbmodels.Organization] = (*SetupOrganization)(nil)
+
+// NewSetupOrganization creates a new setup for the organization model
+func NewSetupOrganization() *SetupOrganization {
+	return &SetupOrganization{
+		SetupBase: SetupBase[usermodels.OrganizationCreate, usermodels.OrganizationUpdate, usermodels.Organization, dbmodels.Organization]{
+			modelConverter: &userrepositories.OrganizationConverter{},
+		},
+	}
+}
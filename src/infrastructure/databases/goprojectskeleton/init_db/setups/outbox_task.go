@@ -0,0 +1,24 @@
+package setups
+
+import (
+	dtos "github.com/simon3640/goprojectskeleton/src/application/shared/DTOs"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	dbmodels "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/models"
+	outboxrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/outbox"
+)
+
+// SetupOutboxTask is the setup struct for the outbox task model
+type SetupOutboxTask struct {
+	SetupBase[dtos.OutboxTaskCreate, dtos.OutboxTaskUpdate, sharedmodels.OutboxTask, dbmodels.OutboxTask]
+}
+
+var _ SetupModel[dtos.OutboxTaskCreate, dtos.OutboxTaskUpdate, sharedmodels.OutboxTask, dbmodels.OutboxTask] = (*SetupOutboxTask)(nil)
+
+// NewSetupOutboxTask creates a new setup for the outbox task model
+func NewSetupOutboxTask() *SetupOutboxTask {
+	return &SetupOutboxTask{
+		SetupBase: SetupBase[dtos.OutboxTaskCreate, dtos.OutboxTaskUpdate, sharedmodels.OutboxTask, dbmodels.OutboxTask]{
+			modelConverter: &outboxrepositories.OutboxTaskConverter{},
+		},
+	}
+}
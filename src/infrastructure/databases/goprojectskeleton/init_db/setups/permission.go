@@ -0,0 +1,23 @@
+package setups
+
+import (
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+	dbmodels "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/models"
+	userrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/user"
+)
+
+// SetupPermission is the setup struct for the permission model
+type SetupPermission struct {
+	SetupBase[usermodels.PermissionCreate, usermodels.PermissionUpdate, usermodels.Permission, dbmodels.Permission]
+}
+
+var _ SetupModel[usermodels.PermissionCreate, usermodels.PermissionUpdate, usermodels.Permission, dbmodels.Permission] = (*SetupPermission)(nil)
+
+// NewSetupPermission creates a new setup for the permission model
+func NewSetupPermission() *SetupPermission {
+	return &SetupPermission{
+		SetupBase: SetupBase[usermodels.PermissionCreate, usermodels.PermissionUpdate, usermodels.Permission, dbmodels.Permission]{
+			modelConverter: &userrepositories.PermissionConverter{},
+		},
+	}
+}
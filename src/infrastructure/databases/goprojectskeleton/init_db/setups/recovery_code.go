@@ -0,0 +1,24 @@
+package setups
+
+import (
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	dbmodels "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/models"
+	authrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/auth"
+)
+
+// SetupRecoveryCode is the setup struct for the recovery code model
+type SetupRecoveryCode struct {
+	SetupBase[dtos.RecoveryCodeCreate, dtos.RecoveryCodeUpdate, sharedmodels.RecoveryCode, dbmodels.RecoveryCode]
+}
+
+var _ SetupModel[dtos.RecoveryCodeCreate, dtos.RecoveryCodeUpdate, sharedmodels.RecoveryCode, dbmodels.RecoveryCode] = (*SetupRecoveryCode)(nil)
+
+// NewSetupRecoveryCode creates a new setup for the recovery code model
+func NewSetupRecoveryCode() *SetupRecoveryCode {
+	return &SetupRecoveryCode{
+		SetupBase: SetupBase[dtos.RecoveryCodeCreate, dtos.RecoveryCodeUpdate, sharedmodels.RecoveryCode, dbmodels.RecoveryCode]{
+			modelConverter: &authrepositories.RecoveryCodeConverter{},
+		},
+	}
+}
@@ -0,0 +1,24 @@
+package setups
+
+import (
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	dbmodels "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/models"
+	authrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/auth"
+)
+
+// SetupRefreshToken is the setup struct for the refresh token model
+type SetupRefreshToken struct {
+	SetupBase[dtos.RefreshTokenCreate, dtos.RefreshTokenUpdate, sharedmodels.RefreshToken, dbmodels.RefreshToken]
+}
+
+var _ SetupModel[dtos.RefreshTokenCreate, dtos.RefreshTokenUpdate, sharedmodels.RefreshToken, dbmodels.RefreshToken] = (*SetupRefreshToken)(nil)
+
+// NewSetupRefreshToken creates a new setup for the refresh token model
+func NewSetupRefreshToken() *SetupRefreshToken {
+	return &SetupRefreshToken{
+		SetupBase: SetupBase[dtos.RefreshTokenCreate, dtos.RefreshTokenUpdate, sharedmodels.RefreshToken, dbmodels.RefreshToken]{
+			modelConverter: &authrepositories.RefreshTokenConverter{},
+		},
+	}
+}
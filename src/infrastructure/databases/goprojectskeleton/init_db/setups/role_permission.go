@@ -0,0 +1,23 @@
+package setups
+
+import (
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+	dbmodels "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/models"
+	userrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/user"
+)
+
+// SetupRolePermission is the setup struct for the role permission model
+type SetupRolePermission struct {
+	SetupBase[usermodels.RolePermission, usermodels.RolePermission, usermodels.RolePermission, dbmodels.RolePermission]
+}
+
+var _ SetupModel[usermodels.RolePermission, usermodels.RolePermission, usermodels.RolePermission, dbmodels.RolePermission] = (*SetupRolePermission)(nil)
+
+// NewSetupRolePermission creates a new setup for the role permission model
+func NewSetupRolePermission() *SetupRolePermission {
+	return &SetupRolePermission{
+		SetupBase: SetupBase[usermodels.RolePermission, usermodels.RolePermission, usermodels.RolePermission, dbmodels.RolePermission]{
+			modelConverter: &userrepositories.RolePermissionConverter{},
+		},
+	}
+}
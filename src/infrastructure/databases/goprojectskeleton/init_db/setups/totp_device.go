@@ -0,0 +1,24 @@
+package setups
+
+import (
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	dbmodels "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/models"
+	authrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/auth"
+)
+
+// SetupTOTPDevice is the setup struct for the TOTP device model
+type SetupTOTPDevice struct {
+	SetupBase[dtos.TOTPDeviceCreate, dtos.TOTPDeviceUpdate, sharedmodels.TOTPDevice, dbmodels.TOTPDevice]
+}
+
+var _ SetupModel[dtos.TOTPDeviceCreate, dtos.TOTPDeviceUpdate, sharedmodels.TOTPDevice, dbmodels.TOTPDevice] = (*SetupTOTPDevice)(nil)
+
+// NewSetupTOTPDevice creates a new setup for the TOTP device model
+func NewSetupTOTPDevice() *SetupTOTPDevice {
+	return &SetupTOTPDevice{
+		SetupBase: SetupBase[dtos.TOTPDeviceCreate, dtos.TOTPDeviceUpdate, sharedmodels.TOTPDevice, dbmodels.TOTPDevice]{
+			modelConverter: &authrepositories.TOTPDeviceConverter{},
+		},
+	}
+}
@@ -0,0 +1,23 @@
+package setups
+
+import (
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+	dbmodels "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/models"
+	userrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/user"
+)
+
+// SetupUserPermission is the setup struct for the user permission model
+type SetupUserPermission struct {
+	SetupBase[usermodels.UserPermissionCreate, usermodels.UserPermissionUpdate, usermodels.UserPermission, dbmodels.UserPermission]
+}
+
+var _ SetupModel[usermodels.UserPermissionCreate, usermodels.UserPermissionUpdate, usermodels.UserPermission, dbmodels.UserPermission] = (*SetupUserPermission)(nil)
+
+// NewSetupUserPermission creates a new setup for the user permission model
+func NewSetupUserPermission() *SetupUserPermission {
+	return &SetupUserPermission{
+		SetupBase: SetupBase[usermodels.UserPermissionCreate, usermodels.UserPermissionUpdate, usermodels.UserPermission, dbmodels.UserPermission]{
+			modelConverter: &userrepositories.UserPermissionConverter{},
+		},
+	}
+}
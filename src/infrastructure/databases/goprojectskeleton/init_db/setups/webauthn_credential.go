@@ -0,0 +1,24 @@
+package setups
+
+import (
+	dtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	dbmodels "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/models"
+	authrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/auth"
+)
+
+// SetupWebAuthnCredential is the setup struct for the WebAuthn credential model
+type SetupWebAuthnCredential struct {
+	SetupBase[dtos.WebAuthnCredentialCreate, dtos.WebAuthnCredentialUpdate, sharedmodels.WebAuthnCredential, dbmodels.WebAuthnCredential]
+}
+
+var _ SetupModel[dtos.WebAuthnCredentialCreate, dtos.WebAuthnCredentialUpdate, sharedmodels.WebAuthnCredential, dbmodels.WebAuthnCredential] = (*SetupWebAuthnCredential)(nil)
+
+// NewSetupWebAuthnCredential creates a new setup for the WebAuthn credential model
+func NewSetupWebAuthnCredential() *SetupWebAuthnCredential {
+	return &SetupWebAuthnCredential{
+		SetupBase: SetupBase[dtos.WebAuthnCredentialCreate, dtos.WebAuthnCredentialUpdate, sharedmodels.WebAuthnCredential, dbmodels.WebAuthnCredential]{
+			modelConverter: &authrepositories.WebAuthnCredentialConverter{},
+		},
+	}
+}
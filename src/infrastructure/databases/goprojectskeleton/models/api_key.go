@@ -0,0 +1,26 @@
+package dbmodels
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// APIKey stores the scopes as a space-separated list
+type APIKey struct {
+	gorm.Model
+	UserID     uint       `gorm:"not null;index"`
+	Name       string     `gorm:"type:varchar(100);not null"`
+	Prefix     string     `gorm:"type:varchar(32);not null"`
+	Hash       []byte     `gorm:"not null;uniqueIndex"`
+	Scopes     string     `gorm:"type:varchar(255);not null"`
+	ExpiresAt  time.Time  `gorm:"not null"`
+	LastUsedAt *time.Time `gorm:"default:null"`
+	RevokedAt  *time.Time `gorm:"default:null"`
+}
+
+func (APIKey) TableName() string {
+	return "api_key"
+}
+
+var _ DBModel = (*APIKey)(nil)
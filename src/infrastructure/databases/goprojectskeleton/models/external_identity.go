@@ -0,0 +1,17 @@
+package dbmodels
+
+import "gorm.io/gorm"
+
+type ExternalIdentity struct {
+	gorm.Model
+	UserID   uint   `gorm:"not null;index"`
+	Provider string `gorm:"type:varchar(50);not null;uniqueIndex:idx_external_identity_provider_subject"`
+	Subject  string `gorm:"type:varchar(255);not null;uniqueIndex:idx_external_identity_provider_subject"`
+	Email    string `gorm:"type:varchar(100)"`
+}
+
+func (ExternalIdentity) TableName() string {
+	return "external_identity"
+}
+
+var _ DBModel = (*ExternalIdentity)(nil)
@@ -0,0 +1,24 @@
+package dbmodels
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Impersonation is the audit record of an admin acting as a user
+type Impersonation struct {
+	gorm.Model
+	AdminID   uint       `gorm:"not null;index"`
+	UserID    uint       `gorm:"not null;index"`
+	TokenID   string     `gorm:"type:varchar(64);not null;uniqueIndex"`
+	Reason    string     `gorm:"type:varchar(255)"`
+	ExpiresAt time.Time  `gorm:"not null"`
+	EndedAt   *time.Time `gorm:"default:null"`
+}
+
+func (Impersonation) TableName() string {
+	return "impersonation"
+}
+
+var _ DBModel = (*Impersonation)(nil)
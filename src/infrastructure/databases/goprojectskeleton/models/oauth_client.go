@@ -0,0 +1,23 @@
+package dbmodels
+
+import "gorm.io/gorm"
+
+// OAuthClient stores the redirect URIs, scopes and grant types as
+// space-separated lists, none of them can contain spaces
+type OAuthClient struct {
+	gorm.Model
+	Name         string `gorm:"type:varchar(100);not null"`
+	ClientID     string `gorm:"type:varchar(64);not null;uniqueIndex"`
+	SecretHash   []byte
+	RedirectURIs string `gorm:"type:text"`
+	Scopes       string `gorm:"type:varchar(255)"`
+	GrantTypes   string `gorm:"type:varchar(255);not null"`
+	FirstParty   bool   `gorm:"not null;default:false"`
+	OwnerID      uint   `gorm:"index"`
+}
+
+func (OAuthClient) TableName() string {
+	return "oauth_client"
+}
+
+var _ DBModel = (*OAuthClient)(nil)
@@ -0,0 +1,16 @@
+package dbmodels
+
+import "gorm.io/gorm"
+
+type OAuthConsent struct {
+	gorm.Model
+	UserID   uint   `gorm:"not null;uniqueIndex:idx_oauth_consent_user_client"`
+	ClientID string `gorm:"type:varchar(64);not null;uniqueIndex:idx_oauth_consent_user_client"`
+	Scopes   string `gorm:"type:varchar(255)"`
+}
+
+func (OAuthConsent) TableName() string {
+	return "oauth_consent"
+}
+
+var _ DBModel = (*OAuthConsent)(nil)
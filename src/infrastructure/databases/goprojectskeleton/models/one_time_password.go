@@ -8,11 +8,13 @@ import (
 
 type OneTimePassword struct {
 	gorm.Model
-	UserID  uint      `gorm:"not null;index"`
-	Purpose string    `gorm:"not null:varchar(255)"`
-	Hash    []byte    `gorm:"not null;varchar(255);uniqueIndex"`
-	IsUsed  bool      `gorm:"not null"`
-	Expires time.Time `gorm:"not null"`
+	UserID          uint      `gorm:"not null;index"`
+	Purpose         string    `gorm:"not null:varchar(255)"`
+	Hash            []byte    `gorm:"not null;varchar(255);uniqueIndex"`
+	TransactionHash []byte    `gorm:"uniqueIndex"`
+	Attempts        int       `gorm:"not null;default:0"`
+	IsUsed          bool      `gorm:"not null"`
+	Expires         time.Time `gorm:"not null"`
 }
 
 func (OneTimePassword) TableName() string {
@@ -0,0 +1,50 @@
+package dbmodels
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Organization struct {
+	gorm.Model
+	Name     string `gorm:"type:varchar(100);not null"`
+	Slug     string `gorm:"type:varchar(100);unique;not null"`
+	IsActive bool   `gorm:"not null;type:boolean;default:true"`
+}
+
+func (Organization) TableName() string {
+	return "organization"
+}
+
+// TenantCondition selects the organization itself
+func (Organization) TenantCondition() string {
+	return `"organization"."id" = ?`
+}
+
+var _ DBModel = (*Organization)(nil)
+
+// Membership is the join table of the organizations and their users
+type Membership struct {
+	OrganizationID uint         `gorm:"primaryKey"`
+	UserID         uint         `gorm:"primaryKey;index"`
+	CreatedAt      time.Time    `gorm:"not null"`
+	Organization   Organization `gorm:"foreignKey:OrganizationID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	User           User         `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (Membership) TableName() string {
+	return "membership"
+}
+
+// TenantCondition selects the memberships of the organization
+func (Membership) TenantCondition() string {
+	return `"membership"."organization_id" = ?`
+}
+
+// StampTenant makes the membership one of the organization
+func (m *Membership) StampTenant(tenantID uint) {
+	m.OrganizationID = tenantID
+}
+
+var _ DBModel = (*Membership)(nil)
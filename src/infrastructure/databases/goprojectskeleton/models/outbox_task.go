@@ -0,0 +1,28 @@
+package dbmodels
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type OutboxTask struct {
+	gorm.Model
+	TaskID        string    `gorm:"not null;type:varchar(64);uniqueIndex"`
+	Name          string    `gorm:"not null;type:varchar(255);index"`
+	Payload       []byte    `gorm:"not null"`
+	Locale        string    `gorm:"type:varchar(16)"`
+	Status        string    `gorm:"not null;type:varchar(32);index:idx_outbox_task_due,priority:1"`
+	Attempts      int       `gorm:"not null;default:0"`
+	MaxAttempts   int       `gorm:"not null"`
+	NextAttemptAt time.Time `gorm:"not null;index:idx_outbox_task_due,priority:2"`
+	LastError     string    `gorm:"type:text"`
+	Result        string    `gorm:"type:text"`
+	UserID        uint      `gorm:"not null;default:0;index"`
+}
+
+func (OutboxTask) TableName() string {
+	return "outbox_task"
+}
+
+var _ DBModel = (*OutboxTask)(nil)
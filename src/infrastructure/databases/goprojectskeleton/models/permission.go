@@ -0,0 +1,46 @@
+package dbmodels
+
+import "gorm.io/gorm"
+
+type Permission struct {
+	gorm.Model
+	Key         string `gorm:"type:varchar(100);unique;not null"`
+	Description string `gorm:"type:varchar(255)"`
+}
+
+func (Permission) TableName() string {
+	return "permission"
+}
+
+var _ DBModel = (*Permission)(nil)
+
+// RolePermission is the join table of the roles and their permissions
+type RolePermission struct {
+	RoleID       uint       `gorm:"primaryKey"`
+	PermissionID uint       `gorm:"primaryKey"`
+	Role         Role       `gorm:"foreignKey:RoleID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Permission   Permission `gorm:"foreignKey:PermissionID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (RolePermission) TableName() string {
+	return "role_permission"
+}
+
+var _ DBModel = (*RolePermission)(nil)
+
+// UserPermission grants or revokes a permission to a user over the
+// permissions of their role
+type UserPermission struct {
+	gorm.Model
+	UserID       uint       `gorm:"not null;uniqueIndex:idx_user_permission"`
+	PermissionID uint       `gorm:"not null;uniqueIndex:idx_user_permission"`
+	Granted      bool       `gorm:"not null;default:true"`
+	User         User       `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Permission   Permission `gorm:"foreignKey:PermissionID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+}
+
+func (UserPermission) TableName() string {
+	return "user_permission"
+}
+
+var _ DBModel = (*UserPermission)(nil)
@@ -0,0 +1,16 @@
+package dbmodels
+
+import "gorm.io/gorm"
+
+type RecoveryCode struct {
+	gorm.Model
+	UserID uint   `gorm:"not null;index"`
+	Hash   []byte `gorm:"not null;index"`
+	IsUsed bool   `gorm:"not null;default:false"`
+}
+
+func (RecoveryCode) TableName() string {
+	return "recovery_code"
+}
+
+var _ DBModel = (*RecoveryCode)(nil)
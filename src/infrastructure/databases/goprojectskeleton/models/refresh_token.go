@@ -0,0 +1,26 @@
+package dbmodels
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type RefreshToken struct {
+	gorm.Model
+	UserID    uint      `gorm:"not null;index"`
+	FamilyID  string    `gorm:"not null;type:varchar(64);index"`
+	Hash      []byte    `gorm:"not null;uniqueIndex"`
+	IsRotated bool      `gorm:"not null;default:false"`
+	IsRevoked bool      `gorm:"not null;default:false"`
+	Expires   time.Time `gorm:"not null"`
+	ClientID  string    `gorm:"type:varchar(64);index"`
+	Scope     string    `gorm:"type:varchar(255)"`
+	TenantID  uint      `gorm:"not null;default:0"`
+}
+
+func (RefreshToken) TableName() string {
+	return "refresh_token"
+}
+
+var _ DBModel = (*RefreshToken)(nil)
@@ -0,0 +1,17 @@
+package dbmodels
+
+import "gorm.io/gorm"
+
+type TOTPDevice struct {
+	gorm.Model
+	UserID       uint   `gorm:"not null;uniqueIndex"`
+	Secret       []byte `gorm:"not null"`
+	IsConfirmed  bool   `gorm:"not null;default:false"`
+	LastUsedStep int64  `gorm:"not null;default:0"`
+}
+
+func (TOTPDevice) TableName() string {
+	return "totp_device"
+}
+
+var _ DBModel = (*TOTPDevice)(nil)
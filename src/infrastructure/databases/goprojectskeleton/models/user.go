@@ -6,7 +6,7 @@ type User struct {
 	gorm.Model
 	Name      string     `gorm:"type:varchar(100);not null"`
 	Email     string     `gorm:"type:varchar(100);not null;unique"`
-	Phone     string     `gorm:"type:varchar(20);not null;unique"`
+	Phone     *string    `gorm:"type:varchar(20);unique"`
 	Status    string     `gorm:"type:varchar(20);not null"`
 	RoleID    uint       `gorm:"not null;index"`
 	OTPLogin  bool       `gorm:"not null;default:false"`
@@ -18,4 +18,9 @@ func (User) TableName() string {
 	return "user"
 }
 
+// TenantCondition selects the members of the organization
+func (User) TenantCondition() string {
+	return `"user"."id" IN (SELECT "user_id" FROM "membership" WHERE "organization_id" = ?)`
+}
+
 var _ DBModel = (*User)(nil)
@@ -0,0 +1,25 @@
+package dbmodels
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type WebAuthnCredential struct {
+	gorm.Model
+	UserID       uint       `gorm:"not null;index"`
+	Name         string     `gorm:"type:varchar(100)"`
+	CredentialID []byte     `gorm:"not null;uniqueIndex"`
+	PublicKey    []byte     `gorm:"not null"`
+	SignCount    uint32     `gorm:"not null;default:0"`
+	Transports   string     `gorm:"type:varchar(255)"`
+	AAGUID       []byte     `gorm:"column:aaguid"`
+	LastUsedAt   *time.Time `gorm:"default:null"`
+}
+
+func (WebAuthnCredential) TableName() string {
+	return "webauthn_credential"
+}
+
+var _ DBModel = (*WebAuthnCredential)(nil)
@@ -0,0 +1,123 @@
+package authrepositories
+
+import (
+	"strings"
+	"time"
+
+	contractsprovider "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	dbmodels "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/models"
+	reposhared "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/shared"
+
+	"gorm.io/gorm"
+)
+
+// APIKeyRepository is the repository for the API key model
+type APIKeyRepository struct {
+	reposhared.RepositoryBase[authdtos.APIKeyCreate, authdtos.APIKeyUpdate, sharedmodels.APIKey, dbmodels.APIKey]
+}
+
+var _ authcontracts.IAPIKeyRepository = (*APIKeyRepository)(nil)
+
+// GetByHash retrieves an API key by the hash of the key
+func (ar *APIKeyRepository) GetByHash(hash []byte) (*sharedmodels.APIKey, *application_errors.ApplicationError) {
+	var ormModel dbmodels.APIKey
+
+	if err := ar.DB.Where("hash = ?", hash).First(&ormModel).Error; err != nil {
+		ar.Logger.Debug("Error fetching API key by hash", err)
+		return nil, reposhared.MapOrmError(err)
+	}
+	return ar.ModelConverter.ToDomain(&ormModel), nil
+}
+
+// GetByUserID retrieves the API keys of a user
+func (ar *APIKeyRepository) GetByUserID(userID uint) ([]sharedmodels.APIKey, *application_errors.ApplicationError) {
+	var ormModels []dbmodels.APIKey
+
+	if err := ar.DB.Where("user_id = ?", userID).Order("id").Find(&ormModels).Error; err != nil {
+		ar.Logger.Debug("Error fetching API keys of user", err)
+		return nil, reposhared.MapOrmError(err)
+	}
+
+	apiKeys := make([]sharedmodels.APIKey, 0, len(ormModels))
+	for i := range ormModels {
+		apiKeys = append(apiKeys, *ar.ModelConverter.ToDomain(&ormModels[i]))
+	}
+	return apiKeys, nil
+}
+
+// Touch records the time of the last use of the key
+func (ar *APIKeyRepository) Touch(id uint) *application_errors.ApplicationError {
+	if err := ar.DB.Model(&dbmodels.APIKey{}).Where("id = ?", id).Update("last_used_at", time.Now()).Error; err != nil {
+		ar.Logger.Debug("Error recording API key use", err)
+		return reposhared.MapOrmError(err)
+	}
+	return nil
+}
+
+// APIKeyConverter is the converter for the API key model
+type APIKeyConverter struct{}
+
+var _ reposhared.ModelConverter[authdtos.APIKeyCreate, authdtos.APIKeyUpdate, sharedmodels.APIKey, dbmodels.APIKey] = (*APIKeyConverter)(nil)
+
+// ToGormCreate converts an API key create model to an API key gorm model
+func (ac *APIKeyConverter) ToGormCreate(model authdtos.APIKeyCreate) *dbmodels.APIKey {
+	return &dbmodels.APIKey{
+		UserID:    model.UserID,
+		Name:      model.Name,
+		Prefix:    model.Prefix,
+		Hash:      model.Hash,
+		Scopes:    strings.Join(model.Scopes, " "),
+		ExpiresAt: model.ExpiresAt,
+	}
+}
+
+// ToDomain converts an API key gorm model to an API key domain model
+func (ac *APIKeyConverter) ToDomain(ormModel *dbmodels.APIKey) *sharedmodels.APIKey {
+	return &sharedmodels.APIKey{
+		DBBaseModel: sharedmodels.DBBaseModel{
+			ID:        ormModel.ID,
+			CreatedAt: ormModel.CreatedAt,
+			UpdatedAt: ormModel.UpdatedAt,
+			DeletedAt: ormModel.DeletedAt.Time,
+		},
+		APIKeyBase: sharedmodels.APIKeyBase{
+			UserID:     ormModel.UserID,
+			Name:       ormModel.Name,
+			Prefix:     ormModel.Prefix,
+			Hash:       ormModel.Hash,
+			Scopes:     strings.Fields(ormModel.Scopes),
+			ExpiresAt:  ormModel.ExpiresAt,
+			LastUsedAt: ormModel.LastUsedAt,
+			RevokedAt:  ormModel.RevokedAt,
+		},
+	}
+}
+
+// ToGormUpdate converts an API key update model to an API key gorm model
+func (ac *APIKeyConverter) ToGormUpdate(model authdtos.APIKeyUpdate) *dbmodels.APIKey {
+	apiKey := &dbmodels.APIKey{}
+
+	apiKey.RevokedAt = model.RevokedAt
+	apiKey.ID = model.ID
+	return apiKey
+}
+
+// NewAPIKeyRepository creates a new API key repository
+func NewAPIKeyRepository(db *gorm.DB, logger contractsprovider.ILoggerProvider) *APIKeyRepository {
+	return &APIKeyRepository{
+		RepositoryBase: reposhared.RepositoryBase[
+			authdtos.APIKeyCreate,
+			authdtos.APIKeyUpdate,
+			sharedmodels.APIKey,
+			dbmodels.APIKey,
+		]{
+			DB:             db,
+			ModelConverter: &APIKeyConverter{},
+			Logger:         logger,
+		},
+	}
+}
@@ -0,0 +1,89 @@
+package authrepositories
+
+import (
+	contractsprovider "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	dbmodels "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/models"
+	reposhared "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/shared"
+
+	"gorm.io/gorm"
+)
+
+// ExternalIdentityRepository is the repository for the external identity model
+type ExternalIdentityRepository struct {
+	reposhared.RepositoryBase[authdtos.ExternalIdentityCreate, authdtos.ExternalIdentityUpdate, sharedmodels.ExternalIdentity, dbmodels.ExternalIdentity]
+}
+
+var _ authcontracts.IExternalIdentityRepository = (*ExternalIdentityRepository)(nil)
+
+// GetByProviderSubject retrieves the identity of an account at an identity provider
+func (er *ExternalIdentityRepository) GetByProviderSubject(provider string, subject string) (*sharedmodels.ExternalIdentity, *application_errors.ApplicationError) {
+	var ormModel dbmodels.ExternalIdentity
+
+	if err := er.DB.Where("provider = ? AND subject = ?", provider, subject).First(&ormModel).Error; err != nil {
+		er.Logger.Debug("Error fetching external identity by provider and subject", err)
+		return nil, reposhared.MapOrmError(err)
+	}
+	return er.ModelConverter.ToDomain(&ormModel), nil
+}
+
+// ExternalIdentityConverter is the converter for the external identity model
+type ExternalIdentityConverter struct{}
+
+var _ reposhared.ModelConverter[authdtos.ExternalIdentityCreate, authdtos.ExternalIdentityUpdate, sharedmodels.ExternalIdentity, dbmodels.ExternalIdentity] = (*ExternalIdentityConverter)(nil)
+
+// ToGormCreate converts an external identity create model to an external identity gorm model
+func (ec *ExternalIdentityConverter) ToGormCreate(model authdtos.ExternalIdentityCreate) *dbmodels.ExternalIdentity {
+	return &dbmodels.ExternalIdentity{
+		UserID:   model.UserID,
+		Provider: model.Provider,
+		Subject:  model.Subject,
+		Email:    model.Email,
+	}
+}
+
+// ToDomain converts an external identity gorm model to an external identity domain model
+func (ec *ExternalIdentityConverter) ToDomain(ormModel *dbmodels.ExternalIdentity) *sharedmodels.ExternalIdentity {
+	return &sharedmodels.ExternalIdentity{
+		DBBaseModel: sharedmodels.DBBaseModel{
+			ID:        ormModel.ID,
+			CreatedAt: ormModel.CreatedAt,
+			UpdatedAt: ormModel.UpdatedAt,
+			DeletedAt: ormModel.DeletedAt.Time,
+		},
+		ExternalIdentityBase: sharedmodels.ExternalIdentityBase{
+			UserID:   ormModel.UserID,
+			Provider: ormModel.Provider,
+			Subject:  ormModel.Subject,
+			Email:    ormModel.Email,
+		},
+	}
+}
+
+// ToGormUpdate converts an external identity update model to an external identity gorm model
+func (ec *ExternalIdentityConverter) ToGormUpdate(model authdtos.ExternalIdentityUpdate) *dbmodels.ExternalIdentity {
+	identity := &dbmodels.ExternalIdentity{}
+
+	identity.Email = model.Email
+	identity.ID = model.ID
+	return identity
+}
+
+// NewExternalIdentityRepository creates a new external identity repository
+func NewExternalIdentityRepository(db *gorm.DB, logger contractsprovider.ILoggerProvider) *ExternalIdentityRepository {
+	return &ExternalIdentityRepository{
+		RepositoryBase: reposhared.RepositoryBase[
+			authdtos.ExternalIdentityCreate,
+			authdtos.ExternalIdentityUpdate,
+			sharedmodels.ExternalIdentity,
+			dbmodels.ExternalIdentity,
+		]{
+			DB:             db,
+			ModelConverter: &ExternalIdentityConverter{},
+			Logger:         logger,
+		},
+	}
+}
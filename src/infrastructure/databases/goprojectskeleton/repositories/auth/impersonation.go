@@ -0,0 +1,95 @@
+package authrepositories
+
+import (
+	contractsprovider "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	dbmodels "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/models"
+	reposhared "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/shared"
+
+	"gorm.io/gorm"
+)
+
+// ImpersonationRepository is the repository for the impersonation model
+type ImpersonationRepository struct {
+	reposhared.RepositoryBase[authdtos.ImpersonationCreate, authdtos.ImpersonationUpdate, sharedmodels.Impersonation, dbmodels.Impersonation]
+}
+
+var _ authcontracts.IImpersonationRepository = (*ImpersonationRepository)(nil)
+
+// GetByTokenID retrieves an impersonation by the ID of its access token
+func (ir *ImpersonationRepository) GetByTokenID(tokenID string) (*sharedmodels.Impersonation, *application_errors.ApplicationError) {
+	var ormModel dbmodels.Impersonation
+
+	if err := ir.DB.Where("token_id = ?", tokenID).First(&ormModel).Error; err != nil {
+		ir.Logger.Debug("Error fetching impersonation by token ID", err)
+		return nil, reposhared.MapOrmError(err)
+	}
+	return ir.ModelConverter.ToDomain(&ormModel), nil
+}
+
+// ImpersonationConverter is the converter for the impersonation model
+type ImpersonationConverter struct{}
+
+var _ reposhared.ModelConverter[authdtos.ImpersonationCreate, authdtos.ImpersonationUpdate, sharedmodels.Impersonation, dbmodels.Impersonation] = (*ImpersonationConverter)(nil)
+
+// ToGormCreate converts an impersonation create model to an impersonation
+// gorm model
+func (ic *ImpersonationConverter) ToGormCreate(model authdtos.ImpersonationCreate) *dbmodels.Impersonation {
+	return &dbmodels.Impersonation{
+		AdminID:   model.AdminID,
+		UserID:    model.UserID,
+		TokenID:   model.TokenID,
+		Reason:    model.Reason,
+		ExpiresAt: model.ExpiresAt,
+	}
+}
+
+// ToDomain converts an impersonation gorm model to an impersonation domain
+// model
+func (ic *ImpersonationConverter) ToDomain(ormModel *dbmodels.Impersonation) *sharedmodels.Impersonation {
+	return &sharedmodels.Impersonation{
+		DBBaseModel: sharedmodels.DBBaseModel{
+			ID:        ormModel.ID,
+			CreatedAt: ormModel.CreatedAt,
+			UpdatedAt: ormModel.UpdatedAt,
+			DeletedAt: ormModel.DeletedAt.Time,
+		},
+		ImpersonationBase: sharedmodels.ImpersonationBase{
+			AdminID:   ormModel.AdminID,
+			UserID:    ormModel.UserID,
+			TokenID:   ormModel.TokenID,
+			Reason:    ormModel.Reason,
+			ExpiresAt: ormModel.ExpiresAt,
+			EndedAt:   ormModel.EndedAt,
+		},
+	}
+}
+
+// ToGormUpdate converts an impersonation update model to an impersonation
+// gorm model
+func (ic *ImpersonationConverter) ToGormUpdate(model authdtos.ImpersonationUpdate) *dbmodels.Impersonation {
+	impersonation := &dbmodels.Impersonation{}
+
+	impersonation.EndedAt = model.EndedAt
+	impersonation.ID = model.ID
+	return impersonation
+}
+
+// NewImpersonationRepository creates a new impersonation repository
+func NewImpersonationRepository(db *gorm.DB, logger contractsprovider.ILoggerProvider) *ImpersonationRepository {
+	return &ImpersonationRepository{
+		RepositoryBase: reposhared.RepositoryBase[
+			authdtos.ImpersonationCreate,
+			authdtos.ImpersonationUpdate,
+			sharedmodels.Impersonation,
+			dbmodels.Impersonation,
+		]{
+			DB:             db,
+			ModelConverter: &ImpersonationConverter{},
+			Logger:         logger,
+		},
+	}
+}
@@ -0,0 +1,101 @@
+package authrepositories
+
+import (
+	"strings"
+
+	contractsprovider "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	dbmodels "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/models"
+	reposhared "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/shared"
+
+	"gorm.io/gorm"
+)
+
+// OAuthClientRepository is the repository for the OAuth client model
+type OAuthClientRepository struct {
+	reposhared.RepositoryBase[authdtos.OAuthClientCreate, authdtos.OAuthClientUpdate, sharedmodels.OAuthClient, dbmodels.OAuthClient]
+}
+
+var _ authcontracts.IOAuthClientRepository = (*OAuthClientRepository)(nil)
+
+// GetByClientID retrieves a client by its client ID
+func (or *OAuthClientRepository) GetByClientID(clientID string) (*sharedmodels.OAuthClient, *application_errors.ApplicationError) {
+	var ormModel dbmodels.OAuthClient
+
+	if err := or.DB.Where("client_id = ?", clientID).First(&ormModel).Error; err != nil {
+		or.Logger.Debug("Error fetching OAuth client by client ID", err)
+		return nil, reposhared.MapOrmError(err)
+	}
+	return or.ModelConverter.ToDomain(&ormModel), nil
+}
+
+// OAuthClientConverter is the converter for the OAuth client model
+type OAuthClientConverter struct{}
+
+var _ reposhared.ModelConverter[authdtos.OAuthClientCreate, authdtos.OAuthClientUpdate, sharedmodels.OAuthClient, dbmodels.OAuthClient] = (*OAuthClientConverter)(nil)
+
+// ToGormCreate converts an OAuth client create model to an OAuth client gorm model
+func (oc *OAuthClientConverter) ToGormCreate(model authdtos.OAuthClientCreate) *dbmodels.OAuthClient {
+	return &dbmodels.OAuthClient{
+		Name:         model.Name,
+		ClientID:     model.ClientID,
+		SecretHash:   model.SecretHash,
+		RedirectURIs: strings.Join(model.RedirectURIs, " "),
+		Scopes:       strings.Join(model.Scopes, " "),
+		GrantTypes:   strings.Join(model.GrantTypes, " "),
+		FirstParty:   model.FirstParty,
+		OwnerID:      model.OwnerID,
+	}
+}
+
+// ToDomain converts an OAuth client gorm model to an OAuth client domain model
+func (oc *OAuthClientConverter) ToDomain(ormModel *dbmodels.OAuthClient) *sharedmodels.OAuthClient {
+	return &sharedmodels.OAuthClient{
+		DBBaseModel: sharedmodels.DBBaseModel{
+			ID:        ormModel.ID,
+			CreatedAt: ormModel.CreatedAt,
+			UpdatedAt: ormModel.UpdatedAt,
+			DeletedAt: ormModel.DeletedAt.Time,
+		},
+		OAuthClientBase: sharedmodels.OAuthClientBase{
+			Name:         ormModel.Name,
+			ClientID:     ormModel.ClientID,
+			SecretHash:   ormModel.SecretHash,
+			RedirectURIs: strings.Fields(ormModel.RedirectURIs),
+			Scopes:       strings.Fields(ormModel.Scopes),
+			GrantTypes:   strings.Fields(ormModel.GrantTypes),
+			FirstParty:   ormModel.FirstParty,
+			OwnerID:      ormModel.OwnerID,
+		},
+	}
+}
+
+// ToGormUpdate converts an OAuth client update model to an OAuth client gorm model
+func (oc *OAuthClientConverter) ToGormUpdate(model authdtos.OAuthClientUpdate) *dbmodels.OAuthClient {
+	client := &dbmodels.OAuthClient{}
+
+	client.Name = model.Name
+	client.RedirectURIs = strings.Join(model.RedirectURIs, " ")
+	client.Scopes = strings.Join(model.Scopes, " ")
+	client.ID = model.ID
+	return client
+}
+
+// NewOAuthClientRepository creates a new OAuth client repository
+func NewOAuthClientRepository(db *gorm.DB, logger contractsprovider.ILoggerProvider) *OAuthClientRepository {
+	return &OAuthClientRepository{
+		RepositoryBase: reposhared.RepositoryBase[
+			authdtos.OAuthClientCreate,
+			authdtos.OAuthClientUpdate,
+			sharedmodels.OAuthClient,
+			dbmodels.OAuthClient,
+		]{
+			DB:             db,
+			ModelConverter: &OAuthClientConverter{},
+			Logger:         logger,
+		},
+	}
+}
@@ -0,0 +1,89 @@
+package authrepositories
+
+import (
+	"strings"
+
+	contractsprovider "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	dbmodels "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/models"
+	reposhared "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/shared"
+
+	"gorm.io/gorm"
+)
+
+// OAuthConsentRepository is the repository for the OAuth consent model
+type OAuthConsentRepository struct {
+	reposhared.RepositoryBase[authdtos.OAuthConsentCreate, authdtos.OAuthConsentUpdate, sharedmodels.OAuthConsent, dbmodels.OAuthConsent]
+}
+
+var _ authcontracts.IOAuthConsentRepository = (*OAuthConsentRepository)(nil)
+
+// GetByUserAndClient retrieves the consent of a user to a client
+func (or *OAuthConsentRepository) GetByUserAndClient(userID uint, clientID string) (*sharedmodels.OAuthConsent, *application_errors.ApplicationError) {
+	var ormModel dbmodels.OAuthConsent
+
+	if err := or.DB.Where("user_id = ? AND client_id = ?", userID, clientID).First(&ormModel).Error; err != nil {
+		or.Logger.Debug("Error fetching OAuth consent by user and client", err)
+		return nil, reposhared.MapOrmError(err)
+	}
+	return or.ModelConverter.ToDomain(&ormModel), nil
+}
+
+// OAuthConsentConverter is the converter for the OAuth consent model
+type OAuthConsentConverter struct{}
+
+var _ reposhared.ModelConverter[authdtos.OAuthConsentCreate, authdtos.OAuthConsentUpdate, sharedmodels.OAuthConsent, dbmodels.OAuthConsent] = (*OAuthConsentConverter)(nil)
+
+// ToGormCreate converts an OAuth consent create model to an OAuth consent gorm model
+func (oc *OAuthConsentConverter) ToGormCreate(model authdtos.OAuthConsentCreate) *dbmodels.OAuthConsent {
+	return &dbmodels.OAuthConsent{
+		UserID:   model.UserID,
+		ClientID: model.ClientID,
+		Scopes:   strings.Join(model.Scopes, " "),
+	}
+}
+
+// ToDomain converts an OAuth consent gorm model to an OAuth consent domain model
+func (oc *OAuthConsentConverter) ToDomain(ormModel *dbmodels.OAuthConsent) *sharedmodels.OAuthConsent {
+	return &sharedmodels.OAuthConsent{
+		DBBaseModel: sharedmodels.DBBaseModel{
+			ID:        ormModel.ID,
+			CreatedAt: ormModel.CreatedAt,
+			UpdatedAt: ormModel.UpdatedAt,
+			DeletedAt: ormModel.DeletedAt.Time,
+		},
+		OAuthConsentBase: sharedmodels.OAuthConsentBase{
+			UserID:   ormModel.UserID,
+			ClientID: ormModel.ClientID,
+			Scopes:   strings.Fields(ormModel.Scopes),
+		},
+	}
+}
+
+// ToGormUpdate converts an OAuth consent update model to an OAuth consent gorm model
+func (oc *OAuthConsentConverter) ToGormUpdate(model authdtos.OAuthConsentUpdate) *dbmodels.OAuthConsent {
+	consent := &dbmodels.OAuthConsent{}
+
+	consent.Scopes = strings.Join(model.Scopes, " ")
+	consent.ID = model.ID
+	return consent
+}
+
+// NewOAuthConsentRepository creates a new OAuth consent repository
+func NewOAuthConsentRepository(db *gorm.DB, logger contractsprovider.ILoggerProvider) *OAuthConsentRepository {
+	return &OAuthConsentRepository{
+		RepositoryBase: reposhared.RepositoryBase[
+			authdtos.OAuthConsentCreate,
+			authdtos.OAuthConsentUpdate,
+			sharedmodels.OAuthConsent,
+			dbmodels.OAuthConsent,
+		]{
+			DB:             db,
+			ModelConverter: &OAuthConsentConverter{},
+			Logger:         logger,
+		},
+	}
+}
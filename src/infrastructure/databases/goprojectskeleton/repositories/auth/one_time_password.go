@@ -30,6 +30,42 @@ func (or *OneTimePasswordRepository) GetByPasswordHash(tokenHash []byte) (*share
 	return or.ModelConverter.ToDomain(&ormModel), nil
 }
 
+// GetByTransactionHash retrieves a one time password by the hash of its transaction
+func (or *OneTimePasswordRepository) GetByTransactionHash(transactionHash []byte) (*sharedmodels.OneTimePassword, *application_errors.ApplicationError) {
+	var ormModel dbmodels.OneTimePassword
+
+	if err := or.DB.Where("transaction_hash = ?", transactionHash).First(&ormModel).Error; err != nil {
+		or.Logger.Debug("Error fetching one-time password by transaction hash", err)
+		return nil, reposhared.MapOrmError(err)
+	}
+	return or.ModelConverter.ToDomain(&ormModel), nil
+}
+
+// IncrementAttempts records a failed attempt while the password has attempts left,
+// the condition makes concurrent attempts unable to exceed the maximum
+func (or *OneTimePasswordRepository) IncrementAttempts(id uint, maxAttempts int) (bool, *application_errors.ApplicationError) {
+	tx := or.DB.Model(&dbmodels.OneTimePassword{}).
+		Where("id = ? AND attempts < ?", id, maxAttempts).
+		Update("attempts", gorm.Expr("attempts + 1"))
+	if tx.Error != nil {
+		or.Logger.Debug("Error incrementing one-time password attempts", tx.Error)
+		return false, reposhared.MapOrmError(tx.Error)
+	}
+	return tx.RowsAffected == 1, nil
+}
+
+// Use marks the password as used, only one of concurrent requests succeeds
+func (or *OneTimePasswordRepository) Use(id uint) (bool, *application_errors.ApplicationError) {
+	tx := or.DB.Model(&dbmodels.OneTimePassword{}).
+		Where("id = ? AND is_used = ?", id, false).
+		Update("is_used", true)
+	if tx.Error != nil {
+		or.Logger.Debug("Error using one-time password", tx.Error)
+		return false, reposhared.MapOrmError(tx.Error)
+	}
+	return tx.RowsAffected == 1, nil
+}
+
 // OneTimePasswordConverter is the converter for the one time password model
 type OneTimePasswordConverter struct{}
 
@@ -38,11 +74,12 @@ var _ reposhared.ModelConverter[authdtos.OneTimePasswordCreate, authdtos.OneTime
 // ToGormCreate converts a one time password create model to a one time password gorm model
 func (uc *OneTimePasswordConverter) ToGormCreate(model authdtos.OneTimePasswordCreate) *dbmodels.OneTimePassword {
 	return &dbmodels.OneTimePassword{
-		Purpose: string(model.Purpose),
-		Hash:    model.Hash,
-		UserID:  model.UserID,
-		Expires: model.Expires,
-		IsUsed:  false,
+		Purpose:         string(model.Purpose),
+		Hash:            model.Hash,
+		TransactionHash: model.TransactionHash,
+		UserID:          model.UserID,
+		Expires:         model.Expires,
+		IsUsed:          false,
 	}
 }
 
@@ -56,11 +93,13 @@ func (uc *OneTimePasswordConverter) ToDomain(ormModel *dbmodels.OneTimePassword)
 			DeletedAt: ormModel.DeletedAt.Time,
 		},
 		OneTimePasswordBase: sharedmodels.OneTimePasswordBase{
-			Purpose: sharedmodels.OneTimePasswordPurpose(ormModel.Purpose),
-			Hash:    ormModel.Hash,
-			UserID:  ormModel.UserID,
-			Expires: ormModel.Expires,
-			IsUsed:  ormModel.IsUsed,
+			Purpose:         sharedmodels.OneTimePasswordPurpose(ormModel.Purpose),
+			Hash:            ormModel.Hash,
+			TransactionHash: ormModel.TransactionHash,
+			Attempts:        ormModel.Attempts,
+			UserID:          ormModel.UserID,
+			Expires:         ormModel.Expires,
+			IsUsed:          ormModel.IsUsed,
 		},
 	}
 }
@@ -0,0 +1,98 @@
+package authrepositories
+
+import (
+	contractsprovider "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	dbmodels "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/models"
+	reposhared "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/shared"
+
+	"gorm.io/gorm"
+)
+
+// RecoveryCodeRepository is the repository for the recovery code model
+type RecoveryCodeRepository struct {
+	reposhared.RepositoryBase[authdtos.RecoveryCodeCreate, authdtos.RecoveryCodeUpdate, sharedmodels.RecoveryCode, dbmodels.RecoveryCode]
+}
+
+var _ authcontracts.IRecoveryCodeRepository = (*RecoveryCodeRepository)(nil)
+
+// DeleteByUserID hard deletes every recovery code of a user
+func (rr *RecoveryCodeRepository) DeleteByUserID(userID uint) *application_errors.ApplicationError {
+	if err := rr.DB.Unscoped().Where("user_id = ?", userID).Delete(&dbmodels.RecoveryCode{}).Error; err != nil {
+		rr.Logger.Debug("Error deleting recovery codes of user", err)
+		return reposhared.MapOrmError(err)
+	}
+	return nil
+}
+
+// Use marks the recovery code as used only if it is still unused,
+// so concurrent logins with the same code cannot both succeed
+func (rr *RecoveryCodeRepository) Use(userID uint, hash []byte) (bool, *application_errors.ApplicationError) {
+	tx := rr.DB.Model(&dbmodels.RecoveryCode{}).
+		Where("user_id = ? AND hash = ? AND is_used = ?", userID, hash, false).
+		Update("is_used", true)
+	if tx.Error != nil {
+		rr.Logger.Debug("Error using recovery code", tx.Error)
+		return false, reposhared.MapOrmError(tx.Error)
+	}
+	return tx.RowsAffected == 1, nil
+}
+
+// RecoveryCodeConverter is the converter for the recovery code model
+type RecoveryCodeConverter struct{}
+
+var _ reposhared.ModelConverter[authdtos.RecoveryCodeCreate, authdtos.RecoveryCodeUpdate, sharedmodels.RecoveryCode, dbmodels.RecoveryCode] = (*RecoveryCodeConverter)(nil)
+
+// ToGormCreate converts a recovery code create model to a recovery code gorm model
+func (rc *RecoveryCodeConverter) ToGormCreate(model authdtos.RecoveryCodeCreate) *dbmodels.RecoveryCode {
+	return &dbmodels.RecoveryCode{
+		UserID: model.UserID,
+		Hash:   model.Hash,
+		IsUsed: false,
+	}
+}
+
+// ToDomain converts a recovery code gorm model to a recovery code domain model
+func (rc *RecoveryCodeConverter) ToDomain(ormModel *dbmodels.RecoveryCode) *sharedmodels.RecoveryCode {
+	return &sharedmodels.RecoveryCode{
+		DBBaseModel: sharedmodels.DBBaseModel{
+			ID:        ormModel.ID,
+			CreatedAt: ormModel.CreatedAt,
+			UpdatedAt: ormModel.UpdatedAt,
+			DeletedAt: ormModel.DeletedAt.Time,
+		},
+		RecoveryCodeBase: sharedmodels.RecoveryCodeBase{
+			UserID: ormModel.UserID,
+			Hash:   ormModel.Hash,
+			IsUsed: ormModel.IsUsed,
+		},
+	}
+}
+
+// ToGormUpdate converts a recovery code update model to a recovery code gorm model
+func (rc *RecoveryCodeConverter) ToGormUpdate(model authdtos.RecoveryCodeUpdate) *dbmodels.RecoveryCode {
+	recoveryCode := &dbmodels.RecoveryCode{}
+
+	recoveryCode.IsUsed = model.IsUsed
+	recoveryCode.ID = model.ID
+	return recoveryCode
+}
+
+// NewRecoveryCodeRepository creates a new recovery code repository
+func NewRecoveryCodeRepository(db *gorm.DB, logger contractsprovider.ILoggerProvider) *RecoveryCodeRepository {
+	return &RecoveryCodeRepository{
+		RepositoryBase: reposhared.RepositoryBase[
+			authdtos.RecoveryCodeCreate,
+			authdtos.RecoveryCodeUpdate,
+			sharedmodels.RecoveryCode,
+			dbmodels.RecoveryCode,
+		]{
+			DB:             db,
+			ModelConverter: &RecoveryCodeConverter{},
+			Logger:         logger,
+		},
+	}
+}
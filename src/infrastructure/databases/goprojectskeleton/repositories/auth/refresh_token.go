@@ -0,0 +1,135 @@
+package authrepositories
+
+import (
+	contractsprovider "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	dbmodels "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/models"
+	reposhared "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/shared"
+
+	"gorm.io/gorm"
+)
+
+// RefreshTokenRepository is the repository for the refresh token model
+type RefreshTokenRepository struct {
+	reposhared.RepositoryBase[authdtos.RefreshTokenCreate, authdtos.RefreshTokenUpdate, sharedmodels.RefreshToken, dbmodels.RefreshToken]
+}
+
+var _ authcontracts.IRefreshTokenRepository = (*RefreshTokenRepository)(nil)
+
+// GetByTokenHash retrieves a refresh token by its hash
+func (rr *RefreshTokenRepository) GetByTokenHash(tokenHash []byte) (*sharedmodels.RefreshToken, *application_errors.ApplicationError) {
+	var ormModel dbmodels.RefreshToken
+
+	if err := rr.DB.Where("hash = ?", tokenHash).First(&ormModel).Error; err != nil {
+		rr.Logger.Debug("Error fetching refresh token by hash", err)
+		return nil, reposhared.MapOrmError(err)
+	}
+	return rr.ModelConverter.ToDomain(&ormModel), nil
+}
+
+// Rotate marks the refresh token as rotated only if it is still active, so
+// concurrent refreshes with the same token cannot both succeed
+func (rr *RefreshTokenRepository) Rotate(id uint) (bool, *application_errors.ApplicationError) {
+	tx := rr.DB.Model(&dbmodels.RefreshToken{}).
+		Where("id = ? AND is_rotated = ? AND is_revoked = ?", id, false, false).
+		Update("is_rotated", true)
+	if tx.Error != nil {
+		rr.Logger.Debug("Error rotating refresh token", tx.Error)
+		return false, reposhared.MapOrmError(tx.Error)
+	}
+	return tx.RowsAffected == 1, nil
+}
+
+// RevokeFamily revokes every refresh token of the family
+func (rr *RefreshTokenRepository) RevokeFamily(familyID string) *application_errors.ApplicationError {
+	if err := rr.DB.Model(&dbmodels.RefreshToken{}).
+		Where("family_id = ? AND is_revoked = ?", familyID, false).
+		Update("is_revoked", true).Error; err != nil {
+		rr.Logger.Debug("Error revoking refresh token family", err)
+		return reposhared.MapOrmError(err)
+	}
+	return nil
+}
+
+// RevokeAllByUser revokes every refresh token of the user
+func (rr *RefreshTokenRepository) RevokeAllByUser(userID uint) *application_errors.ApplicationError {
+	if err := rr.DB.Model(&dbmodels.RefreshToken{}).
+		Where("user_id = ? AND is_revoked = ?", userID, false).
+		Update("is_revoked", true).Error; err != nil {
+		rr.Logger.Debug("Error revoking refresh tokens of user", err)
+		return reposhared.MapOrmError(err)
+	}
+	return nil
+}
+
+// RefreshTokenConverter is the converter for the refresh token model
+type RefreshTokenConverter struct{}
+
+var _ reposhared.ModelConverter[authdtos.RefreshTokenCreate, authdtos.RefreshTokenUpdate, sharedmodels.RefreshToken, dbmodels.RefreshToken] = (*RefreshTokenConverter)(nil)
+
+// ToGormCreate converts a refresh token create model to a refresh token gorm model
+func (rc *RefreshTokenConverter) ToGormCreate(model authdtos.RefreshTokenCreate) *dbmodels.RefreshToken {
+	return &dbmodels.RefreshToken{
+		UserID:    model.UserID,
+		FamilyID:  model.FamilyID,
+		Hash:      model.Hash,
+		Expires:   model.Expires,
+		ClientID:  model.ClientID,
+		Scope:     model.Scope,
+		TenantID:  model.TenantID,
+		IsRotated: false,
+		IsRevoked: false,
+	}
+}
+
+// ToDomain converts a refresh token gorm model to a refresh token domain model
+func (rc *RefreshTokenConverter) ToDomain(ormModel *dbmodels.RefreshToken) *sharedmodels.RefreshToken {
+	return &sharedmodels.RefreshToken{
+		DBBaseModel: sharedmodels.DBBaseModel{
+			ID:        ormModel.ID,
+			CreatedAt: ormModel.CreatedAt,
+			UpdatedAt: ormModel.UpdatedAt,
+			DeletedAt: ormModel.DeletedAt.Time,
+		},
+		RefreshTokenBase: sharedmodels.RefreshTokenBase{
+			UserID:    ormModel.UserID,
+			FamilyID:  ormModel.FamilyID,
+			Hash:      ormModel.Hash,
+			IsRotated: ormModel.IsRotated,
+			IsRevoked: ormModel.IsRevoked,
+			Expires:   ormModel.Expires,
+			ClientID:  ormModel.ClientID,
+			Scope:     ormModel.Scope,
+			TenantID:  ormModel.TenantID,
+		},
+	}
+}
+
+// ToGormUpdate converts a refresh token update model to a refresh token gorm model
+func (rc *RefreshTokenConverter) ToGormUpdate(model authdtos.RefreshTokenUpdate) *dbmodels.RefreshToken {
+	refreshToken := &dbmodels.RefreshToken{}
+
+	refreshToken.IsRotated = model.IsRotated
+	refreshToken.IsRevoked = model.IsRevoked
+	refreshToken.ID = model.ID
+	return refreshToken
+}
+
+// NewRefreshTokenRepository creates a new refresh token repository
+func NewRefreshTokenRepository(db *gorm.DB, logger contractsprovider.ILoggerProvider) *RefreshTokenRepository {
+	return &RefreshTokenRepository{
+		RepositoryBase: reposhared.RepositoryBase[
+			authdtos.RefreshTokenCreate,
+			authdtos.RefreshTokenUpdate,
+			sharedmodels.RefreshToken,
+			dbmodels.RefreshToken,
+		]{
+			DB:             db,
+			ModelConverter: &RefreshTokenConverter{},
+			Logger:         logger,
+		},
+	}
+}
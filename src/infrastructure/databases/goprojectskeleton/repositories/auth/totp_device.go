@@ -0,0 +1,110 @@
+package authrepositories
+
+import (
+	contractsprovider "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	dbmodels "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/models"
+	reposhared "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/shared"
+
+	"gorm.io/gorm"
+)
+
+// TOTPDeviceRepository is the repository for the TOTP device model
+type TOTPDeviceRepository struct {
+	reposhared.RepositoryBase[authdtos.TOTPDeviceCreate, authdtos.TOTPDeviceUpdate, sharedmodels.TOTPDevice, dbmodels.TOTPDevice]
+}
+
+var _ authcontracts.ITOTPDeviceRepository = (*TOTPDeviceRepository)(nil)
+
+// GetByUserID retrieves the TOTP device of a user
+func (tr *TOTPDeviceRepository) GetByUserID(userID uint) (*sharedmodels.TOTPDevice, *application_errors.ApplicationError) {
+	var ormModel dbmodels.TOTPDevice
+
+	if err := tr.DB.Where("user_id = ?", userID).First(&ormModel).Error; err != nil {
+		tr.Logger.Debug("Error fetching TOTP device by user", err)
+		return nil, reposhared.MapOrmError(err)
+	}
+	return tr.ModelConverter.ToDomain(&ormModel), nil
+}
+
+// DeleteByUserID hard deletes the TOTP device of a user, so the user can enroll a new one
+func (tr *TOTPDeviceRepository) DeleteByUserID(userID uint) *application_errors.ApplicationError {
+	if err := tr.DB.Unscoped().Where("user_id = ?", userID).Delete(&dbmodels.TOTPDevice{}).Error; err != nil {
+		tr.Logger.Debug("Error deleting TOTP device of user", err)
+		return reposhared.MapOrmError(err)
+	}
+	return nil
+}
+
+// UseStep records the time step only if it is later than the last used one,
+// so concurrent logins with the same code cannot both succeed
+func (tr *TOTPDeviceRepository) UseStep(id uint, step int64) (bool, *application_errors.ApplicationError) {
+	tx := tr.DB.Model(&dbmodels.TOTPDevice{}).
+		Where("id = ? AND last_used_step < ?", id, step).
+		Update("last_used_step", step)
+	if tx.Error != nil {
+		tr.Logger.Debug("Error using TOTP step", tx.Error)
+		return false, reposhared.MapOrmError(tx.Error)
+	}
+	return tx.RowsAffected == 1, nil
+}
+
+// TOTPDeviceConverter is the converter for the TOTP device model
+type TOTPDeviceConverter struct{}
+
+var _ reposhared.ModelConverter[authdtos.TOTPDeviceCreate, authdtos.TOTPDeviceUpdate, sharedmodels.TOTPDevice, dbmodels.TOTPDevice] = (*TOTPDeviceConverter)(nil)
+
+// ToGormCreate converts a TOTP device create model to a TOTP device gorm model
+func (tc *TOTPDeviceConverter) ToGormCreate(model authdtos.TOTPDeviceCreate) *dbmodels.TOTPDevice {
+	return &dbmodels.TOTPDevice{
+		UserID:      model.UserID,
+		Secret:      model.Secret,
+		IsConfirmed: false,
+	}
+}
+
+// ToDomain converts a TOTP device gorm model to a TOTP device domain model
+func (tc *TOTPDeviceConverter) ToDomain(ormModel *dbmodels.TOTPDevice) *sharedmodels.TOTPDevice {
+	return &sharedmodels.TOTPDevice{
+		DBBaseModel: sharedmodels.DBBaseModel{
+			ID:        ormModel.ID,
+			CreatedAt: ormModel.CreatedAt,
+			UpdatedAt: ormModel.UpdatedAt,
+			DeletedAt: ormModel.DeletedAt.Time,
+		},
+		TOTPDeviceBase: sharedmodels.TOTPDeviceBase{
+			UserID:       ormModel.UserID,
+			Secret:       ormModel.Secret,
+			IsConfirmed:  ormModel.IsConfirmed,
+			LastUsedStep: ormModel.LastUsedStep,
+		},
+	}
+}
+
+// ToGormUpdate converts a TOTP device update model to a TOTP device gorm model
+func (tc *TOTPDeviceConverter) ToGormUpdate(model authdtos.TOTPDeviceUpdate) *dbmodels.TOTPDevice {
+	device := &dbmodels.TOTPDevice{}
+
+	device.IsConfirmed = model.IsConfirmed
+	device.ID = model.ID
+	return device
+}
+
+// NewTOTPDeviceRepository creates a new TOTP device repository
+func NewTOTPDeviceRepository(db *gorm.DB, logger contractsprovider.ILoggerProvider) *TOTPDeviceRepository {
+	return &TOTPDeviceRepository{
+		RepositoryBase: reposhared.RepositoryBase[
+			authdtos.TOTPDeviceCreate,
+			authdtos.TOTPDeviceUpdate,
+			sharedmodels.TOTPDevice,
+			dbmodels.TOTPDevice,
+		]{
+			DB:             db,
+			ModelConverter: &TOTPDeviceConverter{},
+			Logger:         logger,
+		},
+	}
+}
@@ -0,0 +1,133 @@
+package authrepositories
+
+import (
+	"strings"
+	"time"
+
+	contractsprovider "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	dbmodels "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/models"
+	reposhared "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/shared"
+
+	"gorm.io/gorm"
+)
+
+// WebAuthnCredentialRepository is the repository for the WebAuthn credential model
+type WebAuthnCredentialRepository struct {
+	reposhared.RepositoryBase[authdtos.WebAuthnCredentialCreate, authdtos.WebAuthnCredentialUpdate, sharedmodels.WebAuthnCredential, dbmodels.WebAuthnCredential]
+}
+
+var _ authcontracts.IWebAuthnCredentialRepository = (*WebAuthnCredentialRepository)(nil)
+
+// GetByCredentialID retrieves a passkey by the credential ID of the authenticator
+func (wr *WebAuthnCredentialRepository) GetByCredentialID(credentialID []byte) (*sharedmodels.WebAuthnCredential, *application_errors.ApplicationError) {
+	var ormModel dbmodels.WebAuthnCredential
+
+	if err := wr.DB.Where("credential_id = ?", credentialID).First(&ormModel).Error; err != nil {
+		wr.Logger.Debug("Error fetching WebAuthn credential by credential ID", err)
+		return nil, reposhared.MapOrmError(err)
+	}
+	return wr.ModelConverter.ToDomain(&ormModel), nil
+}
+
+// GetByUserID retrieves the passkeys of a user
+func (wr *WebAuthnCredentialRepository) GetByUserID(userID uint) ([]sharedmodels.WebAuthnCredential, *application_errors.ApplicationError) {
+	var ormModels []dbmodels.WebAuthnCredential
+
+	if err := wr.DB.Where("user_id = ?", userID).Order("id").Find(&ormModels).Error; err != nil {
+		wr.Logger.Debug("Error fetching WebAuthn credentials of user", err)
+		return nil, reposhared.MapOrmError(err)
+	}
+
+	credentials := make([]sharedmodels.WebAuthnCredential, 0, len(ormModels))
+	for i := range ormModels {
+		credentials = append(credentials, *wr.ModelConverter.ToDomain(&ormModels[i]))
+	}
+	return credentials, nil
+}
+
+// UseSignCount records the sign count only if it is greater than the stored
+// one, authenticators without a counter always report zero
+func (wr *WebAuthnCredentialRepository) UseSignCount(id uint, signCount uint32) (bool, *application_errors.ApplicationError) {
+	tx := wr.DB.Model(&dbmodels.WebAuthnCredential{}).
+		Where("id = ? AND (sign_count < ? OR (sign_count = 0 AND ? = 0))", id, signCount, signCount).
+		Updates(map[string]any{"sign_count": signCount, "last_used_at": time.Now()})
+	if tx.Error != nil {
+		wr.Logger.Debug("Error using WebAuthn sign count", tx.Error)
+		return false, reposhared.MapOrmError(tx.Error)
+	}
+	return tx.RowsAffected == 1, nil
+}
+
+// WebAuthnCredentialConverter is the converter for the WebAuthn credential model
+type WebAuthnCredentialConverter struct{}
+
+var _ reposhared.ModelConverter[authdtos.WebAuthnCredentialCreate, authdtos.WebAuthnCredentialUpdate, sharedmodels.WebAuthnCredential, dbmodels.WebAuthnCredential] = (*WebAuthnCredentialConverter)(nil)
+
+// ToGormCreate converts a WebAuthn credential create model to a WebAuthn credential gorm model
+func (wc *WebAuthnCredentialConverter) ToGormCreate(model authdtos.WebAuthnCredentialCreate) *dbmodels.WebAuthnCredential {
+	return &dbmodels.WebAuthnCredential{
+		UserID:       model.UserID,
+		Name:         model.Name,
+		CredentialID: model.CredentialID,
+		PublicKey:    model.PublicKey,
+		SignCount:    model.SignCount,
+		Transports:   strings.Join(model.Transports, ","),
+		AAGUID:       model.AAGUID,
+	}
+}
+
+// ToDomain converts a WebAuthn credential gorm model to a WebAuthn credential domain model
+func (wc *WebAuthnCredentialConverter) ToDomain(ormModel *dbmodels.WebAuthnCredential) *sharedmodels.WebAuthnCredential {
+	var transports []string
+	if ormModel.Transports != "" {
+		transports = strings.Split(ormModel.Transports, ",")
+	}
+
+	return &sharedmodels.WebAuthnCredential{
+		DBBaseModel: sharedmodels.DBBaseModel{
+			ID:        ormModel.ID,
+			CreatedAt: ormModel.CreatedAt,
+			UpdatedAt: ormModel.UpdatedAt,
+			DeletedAt: ormModel.DeletedAt.Time,
+		},
+		WebAuthnCredentialBase: sharedmodels.WebAuthnCredentialBase{
+			UserID:       ormModel.UserID,
+			Name:         ormModel.Name,
+			CredentialID: ormModel.CredentialID,
+			PublicKey:    ormModel.PublicKey,
+			SignCount:    ormModel.SignCount,
+			Transports:   transports,
+			AAGUID:       ormModel.AAGUID,
+			LastUsedAt:   ormModel.LastUsedAt,
+		},
+	}
+}
+
+// ToGormUpdate converts a WebAuthn credential update model to a WebAuthn credential gorm model
+func (wc *WebAuthnCredentialConverter) ToGormUpdate(model authdtos.WebAuthnCredentialUpdate) *dbmodels.WebAuthnCredential {
+	credential := &dbmodels.WebAuthnCredential{}
+
+	credential.Name = model.Name
+	credential.ID = model.ID
+	return credential
+}
+
+// NewWebAuthnCredentialRepository creates a new WebAuthn credential repository
+func NewWebAuthnCredentialRepository(db *gorm.DB, logger contractsprovider.ILoggerProvider) *WebAuthnCredentialRepository {
+	return &WebAuthnCredentialRepository{
+		RepositoryBase: reposhared.RepositoryBase[
+			authdtos.WebAuthnCredentialCreate,
+			authdtos.WebAuthnCredentialUpdate,
+			sharedmodels.WebAuthnCredential,
+			dbmodels.WebAuthnCredential,
+		]{
+			DB:             db,
+			ModelConverter: &WebAuthnCredentialConverter{},
+			Logger:         logger,
+		},
+	}
+}
@@ -0,0 +1,152 @@
+// Package outboxrepositories contains the repository for the outbox task model
+package outboxrepositories
+
+import (
+	"time"
+
+	contractsproviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	contractsrepositories "github.com/simon3640/goprojectskeleton/src/application/contracts/repositories"
+	dtos "github.com/simon3640/goprojectskeleton/src/application/shared/DTOs"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	dbmodels "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/models"
+	reposhared "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/shared"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// OutboxTaskRepository is the repository for the outbox task model
+type OutboxTaskRepository struct {
+	reposhared.RepositoryBase[dtos.OutboxTaskCreate, dtos.OutboxTaskUpdate, sharedmodels.OutboxTask, dbmodels.OutboxTask]
+}
+
+var _ contractsrepositories.IOutboxTaskRepository = (*OutboxTaskRepository)(nil)
+
+// WithContext returns the repository bound to the context, its writes take
+// part in the unit of work the context runs in
+func (or *OutboxTaskRepository) WithContext(ctx *app_context.AppContext) contractsrepositories.IOutboxTaskRepository {
+	return NewOutboxTaskRepository(or.DB.WithContext(ctx), or.Logger)
+}
+
+// GetByTaskID retrieves an outbox task by its task ID
+func (or *OutboxTaskRepository) GetByTaskID(taskID string) (*sharedmodels.OutboxTask, *applicationerrors.ApplicationError) {
+	var ormModel dbmodels.OutboxTask
+
+	if err := or.DB.Where("task_id = ?", taskID).First(&ormModel).Error; err != nil {
+		or.Logger.Debug("Error fetching outbox task by task id", err)
+		return nil, reposhared.MapOrmError(err)
+	}
+	return or.ModelConverter.ToDomain(&ormModel), nil
+}
+
+// ClaimDue locks the due pending tasks, skipping the ones locked by other
+// dispatchers, and leases them until now+lease
+func (or *OutboxTaskRepository) ClaimDue(now time.Time, limit int, lease time.Duration) ([]sharedmodels.OutboxTask, *applicationerrors.ApplicationError) {
+	var ormModels []dbmodels.OutboxTask
+
+	err := or.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", string(sharedmodels.OutboxTaskStatusPending), now).
+			Order("next_attempt_at").
+			Limit(limit).
+			Find(&ormModels).Error; err != nil {
+			return err
+		}
+		if len(ormModels) == 0 {
+			return nil
+		}
+		ids := make([]uint, len(ormModels))
+		for i, m := range ormModels {
+			ids[i] = m.ID
+		}
+		return tx.Model(&dbmodels.OutboxTask{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		or.Logger.Debug("Error claiming outbox tasks", err)
+		return nil, reposhared.MapOrmError(err)
+	}
+
+	tasks := make([]sharedmodels.OutboxTask, len(ormModels))
+	for i := range ormModels {
+		tasks[i] = *or.ModelConverter.ToDomain(&ormModels[i])
+	}
+	return tasks, nil
+}
+
+// OutboxTaskConverter is the converter for the outbox task model
+type OutboxTaskConverter struct{}
+
+var _ reposhared.ModelConverter[dtos.OutboxTaskCreate, dtos.OutboxTaskUpdate, sharedmodels.OutboxTask, dbmodels.OutboxTask] = (*OutboxTaskConverter)(nil)
+
+// ToGormCreate converts an outbox task create model to an outbox task gorm model
+func (oc *OutboxTaskConverter) ToGormCreate(model dtos.OutboxTaskCreate) *dbmodels.OutboxTask {
+	return &dbmodels.OutboxTask{
+		TaskID:        model.TaskID,
+		Name:          model.Name,
+		Payload:       model.Payload,
+		Locale:        model.Locale,
+		Status:        string(model.Status),
+		Attempts:      model.Attempts,
+		MaxAttempts:   model.MaxAttempts,
+		NextAttemptAt: model.NextAttemptAt,
+		UserID:        model.UserID,
+	}
+}
+
+// ToDomain converts an outbox task gorm model to an outbox task domain model
+func (oc *OutboxTaskConverter) ToDomain(ormModel *dbmodels.OutboxTask) *sharedmodels.OutboxTask {
+	return &sharedmodels.OutboxTask{
+		DBBaseModel: sharedmodels.DBBaseModel{
+			ID:        ormModel.ID,
+			CreatedAt: ormModel.CreatedAt,
+			UpdatedAt: ormModel.UpdatedAt,
+			DeletedAt: ormModel.DeletedAt.Time,
+		},
+		OutboxTaskBase: sharedmodels.OutboxTaskBase{
+			TaskID:        ormModel.TaskID,
+			Name:          ormModel.Name,
+			Payload:       ormModel.Payload,
+			Locale:        ormModel.Locale,
+			Status:        sharedmodels.OutboxTaskStatus(ormModel.Status),
+			Attempts:      ormModel.Attempts,
+			MaxAttempts:   ormModel.MaxAttempts,
+			NextAttemptAt: ormModel.NextAttemptAt,
+			LastError:     ormModel.LastError,
+			Result:        ormModel.Result,
+			UserID:        ormModel.UserID,
+		},
+	}
+}
+
+// ToGormUpdate converts an outbox task update model to an outbox task gorm model
+func (oc *OutboxTaskConverter) ToGormUpdate(model dtos.OutboxTaskUpdate) *dbmodels.OutboxTask {
+	outboxTask := &dbmodels.OutboxTask{
+		Status:        string(model.Status),
+		Attempts:      model.Attempts,
+		NextAttemptAt: model.NextAttemptAt,
+		LastError:     model.LastError,
+		Result:        model.Result,
+	}
+	outboxTask.ID = model.ID
+	return outboxTask
+}
+
+// NewOutboxTaskRepository creates a new outbox task repository
+func NewOutboxTaskRepository(db *gorm.DB, logger contractsproviders.ILoggerProvider) *OutboxTaskRepository {
+	return &OutboxTaskRepository{
+		RepositoryBase: reposhared.RepositoryBase[
+			dtos.OutboxTaskCreate,
+			dtos.OutboxTaskUpdate,
+			sharedmodels.OutboxTask,
+			dbmodels.OutboxTask,
+		]{
+			DB:             db,
+			ModelConverter: &OutboxTaskConverter{},
+			Logger:         logger,
+		},
+	}
+}
@@ -2,10 +2,12 @@
 package shared
 
 import (
+	"context"
 	"fmt"
 
 	contractsproviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
 	contractsrepositories "github.com/simon3640/goprojectskeleton/src/application/contracts/repositories"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
 	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
 	domain_utils "github.com/simon3640/goprojectskeleton/src/domain/shared/utils"
 
@@ -15,6 +17,11 @@ import (
 // RepositoryBase is the abstract base repository for the database models
 // It contains the database connection, the logger and the model converter
 // It implements the IRepositoryBase interface
+// Queries on TenantScoped models are scoped to the tenant of the AppContext
+// of the connection, see TenantFromContext. The factories of the scoped
+// repositories bind the context of the request to the connection.
+// Repositories bound to an AppContext take part in the unit of work it runs
+// in, see Conn.
 type RepositoryBase[CreateModel any, UpdateModel any, Model any, DBModel any] struct {
 	DB             *gorm.DB
 	Logger         contractsproviders.ILoggerProvider
@@ -34,6 +41,74 @@ func SetUpRepositoryBase[CreateModel, UpdateModel, Model, DBModel any](db *gorm.
 
 var _ contractsrepositories.IRepositoryBase[any, any, any, any] = (*RepositoryBase[any, any, any, any])(nil)
 
+// TenantScoped is implemented by the database models that belong to an
+// organization. TenantCondition is the condition on the organization ID
+// selecting the rows of an organization.
+type TenantScoped interface {
+	TenantCondition() string
+}
+
+// TenantStamped is implemented by the TenantScoped models holding the
+// organization they belong to. StampTenant sets it on the rows created in a
+// tenant.
+type TenantStamped interface {
+	StampTenant(tenantID uint)
+}
+
+// TenantFromContext returns the organization the user of the AppContext acts
+// in. Connections bound to a context without tenant, or without AppContext,
+// are not scoped.
+func TenantFromContext(ctx context.Context) (uint, bool) {
+	appContext, ok := ctx.(*app_context.AppContext)
+	if !ok {
+		return 0, false
+	}
+	return appContext.TenantID()
+}
+
+// Conn returns the connection of the repository, the transaction of the
+// unit of work its context runs in if any (see UnitOfWork)
+func (rb *RepositoryBase[CreateModel, UpdateModel, Model, DBModel]) Conn() *gorm.DB {
+	if rb.DB.Statement.Context == nil {
+		return rb.DB
+	}
+	if tx, ok := TransactionFromContext(rb.DB.Statement.Context); ok {
+		return tx
+	}
+	return rb.DB
+}
+
+// Tenant returns the organization the context of the connection is scoped
+// to, and whether it is scoped
+func (rb *RepositoryBase[CreateModel, UpdateModel, Model, DBModel]) Tenant() (uint, bool) {
+	if rb.DB.Statement.Context == nil {
+		return 0, false
+	}
+	return TenantFromContext(rb.DB.Statement.Context)
+}
+
+// Scoped returns the connection scoped to the tenant of its context for the
+// model of the repository, and whether it is scoped. The custom queries of
+// the repositories start from it.
+func (rb *RepositoryBase[CreateModel, UpdateModel, Model, DBModel]) Scoped() (*gorm.DB, bool) {
+	return rb.ScopedTo(new(DBModel))
+}
+
+// ScopedTo returns the connection scoped to the tenant of its context for
+// the given model, and whether it is scoped. Models that are not
+// TenantScoped are not scoped.
+func (rb *RepositoryBase[CreateModel, UpdateModel, Model, DBModel]) ScopedTo(model any) (*gorm.DB, bool) {
+	scopedModel, ok := model.(TenantScoped)
+	if !ok {
+		return rb.Conn(), false
+	}
+	tenantID, scoped := rb.Tenant()
+	if !scoped {
+		return rb.Conn(), false
+	}
+	return rb.Conn().Where(scopedModel.TenantCondition(), tenantID), true
+}
+
 // FilterToGorm converts a filter to a GORM filter
 func FilterToGorm(f domain_utils.Filter) (string, []interface{}, error) {
 	switch f.Operator {
@@ -84,7 +159,13 @@ func SortToGorm(s domain_utils.Sort) string {
 func (rb *RepositoryBase[CreateModel, UpdateModel, Model, DBModel]) Create(entity CreateModel) (*Model, *applicationerrors.ApplicationError) {
 	// Convertir a modelo de GORM
 	_entity := rb.ModelConverter.ToGormCreate(entity)
-	if err := rb.DB.Create(_entity).Error; err != nil {
+	// Entities created in a tenant belong to it
+	if stamped, ok := any(_entity).(TenantStamped); ok {
+		if tenantID, scoped := rb.Tenant(); scoped {
+			stamped.StampTenant(tenantID)
+		}
+	}
+	if err := rb.Conn().Create(_entity).Error; err != nil {
 		appErr := MapOrmError(err)
 		rb.Logger.Debug("Error creating entity", appErr.ToError())
 		return nil, appErr
@@ -97,7 +178,8 @@ func (rb *RepositoryBase[CreateModel, UpdateModel, Model, DBModel]) Create(entit
 // GetByID retrieves an entity by its ID
 func (rb *RepositoryBase[CreateModel, UpdateModel, Model, DBModel]) GetByID(id uint) (*Model, *applicationerrors.ApplicationError) {
 	var entity DBModel
-	if err := rb.DB.First(&entity, id).Error; err != nil {
+	db, _ := rb.Scoped()
+	if err := db.First(&entity, id).Error; err != nil {
 		appErr := MapOrmError(err)
 		rb.Logger.Debug("Error retrieving entity", appErr.ToError())
 		return nil, appErr
@@ -110,7 +192,14 @@ func (rb *RepositoryBase[CreateModel, UpdateModel, Model, DBModel]) GetByID(id u
 func (rb *RepositoryBase[CreateModel, UpdateModel, Model, DBModel]) Update(id uint, entity UpdateModel) (*Model, *applicationerrors.ApplicationError) {
 	updateData := rb.ModelConverter.ToGormUpdate(entity)
 
-	if err := rb.DB.Model(new(DBModel)).Where("id = ?", id).Updates(updateData).Error; err != nil {
+	db, scoped := rb.Scoped()
+	if scoped {
+		// Entities of other tenants are not found
+		if _, appErr := rb.GetByID(id); appErr != nil {
+			return nil, appErr
+		}
+	}
+	if err := db.Model(new(DBModel)).Where("id = ?", id).Updates(updateData).Error; err != nil {
 		appErr := MapOrmError(err)
 		rb.Logger.Debug("Error updating entity", appErr.ToError())
 		return nil, appErr
@@ -122,21 +211,31 @@ func (rb *RepositoryBase[CreateModel, UpdateModel, Model, DBModel]) Update(id ui
 
 // SoftDelete soft deletes an entity
 func (rb *RepositoryBase[CreateModel, UpdateModel, Model, DBModel]) SoftDelete(id uint) *applicationerrors.ApplicationError {
-	if err := rb.DB.Delete(new(DBModel), id).Error; err != nil {
+	db, scoped := rb.Scoped()
+	res := db.Delete(new(DBModel), id)
+	if err := res.Error; err != nil {
 		appErr := MapOrmError(err)
 		rb.Logger.Debug("Error deleting entity", appErr.ToError())
 		return appErr
 	}
+	if scoped && res.RowsAffected == 0 {
+		return MapOrmError(gorm.ErrRecordNotFound)
+	}
 	return nil
 }
 
 // Delete hard deletes an entity
 func (rb *RepositoryBase[CreateModel, UpdateModel, Model, DBModel]) Delete(id uint) *applicationerrors.ApplicationError {
-	if err := rb.DB.Unscoped().Delete(new(DBModel), id).Error; err != nil {
+	db, scoped := rb.Scoped()
+	res := db.Unscoped().Delete(new(DBModel), id)
+	if err := res.Error; err != nil {
 		appErr := MapOrmError(err)
 		rb.Logger.Debug("Error hard deleting entity", appErr.ToError())
 		return appErr
 	}
+	if scoped && res.RowsAffected == 0 {
+		return MapOrmError(gorm.ErrRecordNotFound)
+	}
 	return nil
 }
 
@@ -145,7 +244,8 @@ func (rb *RepositoryBase[CreateModel, UpdateModel, Model, DBModel]) GetAll(paylo
 	var entities []DBModel
 	// Apply filters from payload
 
-	query := rb.DB.Model(new(DBModel))
+	db, _ := rb.Scoped()
+	query := db.Model(new(DBModel))
 	if payload != nil {
 		for _, filter := range payload.Filters {
 			gormCondition, args, err := FilterToGorm(filter)
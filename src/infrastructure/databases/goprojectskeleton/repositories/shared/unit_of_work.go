@@ -0,0 +1,69 @@
+package shared
+
+import (
+	"context"
+
+	contractsproviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	contractsrepositories "github.com/simon3640/goprojectskeleton/src/application/contracts/repositories"
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+
+	"gorm.io/gorm"
+)
+
+// UnitOfWork runs functions in a transaction of the database. The
+// transaction is held by the AppContext, the repositories bound to it run
+// their queries in the transaction (see RepositoryBase.Conn).
+type UnitOfWork struct {
+	DB     *gorm.DB
+	Logger contractsproviders.ILoggerProvider
+}
+
+var _ contractsrepositories.IUnitOfWork = (*UnitOfWork)(nil)
+
+// Do runs fn in a transaction, or in a savepoint of the transaction the
+// AppContext already runs in
+func (u *UnitOfWork) Do(ctx *app_context.AppContext, fn func() *applicationerrors.ApplicationError) *applicationerrors.ApplicationError {
+	previous := ctx.Transaction()
+	db, ok := previous.(*gorm.DB)
+	if !ok {
+		db = u.DB.WithContext(ctx)
+	}
+
+	var appErr *applicationerrors.ApplicationError
+	err := db.Transaction(func(tx *gorm.DB) error {
+		ctx.SetTransaction(tx)
+		defer ctx.SetTransaction(previous)
+		if appErr = fn(); appErr != nil {
+			return appErr.ToError()
+		}
+		return nil
+	})
+	if appErr != nil {
+		return appErr
+	}
+	if err != nil {
+		u.Logger.Debug("Error committing transaction", err)
+		return MapOrmError(err)
+	}
+	return nil
+}
+
+// TransactionFromContext returns the transaction of the unit of work the
+// AppContext of the context runs in, if any
+func TransactionFromContext(ctx context.Context) (*gorm.DB, bool) {
+	appContext, ok := ctx.(*app_context.AppContext)
+	if !ok {
+		return nil, false
+	}
+	tx, ok := appContext.Transaction().(*gorm.DB)
+	return tx, ok
+}
+
+// NewUnitOfWork creates a new unit of work of the database
+func NewUnitOfWork(db *gorm.DB, logger contractsproviders.ILoggerProvider) *UnitOfWork {
+	return &UnitOfWork{
+		DB:     db,
+		Logger: logger,
+	}
+}
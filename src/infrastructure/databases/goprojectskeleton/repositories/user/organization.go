@@ -0,0 +1,139 @@
+package userrepositories
+
+import (
+	"context"
+
+	contractsprovider "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	contracts_repositories "github.com/simon3640/goprojectskeleton/src/application/contracts/repositories"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+	dbmodels "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/models"
+	reposhared "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/shared"
+
+	"gorm.io/gorm"
+)
+
+// OrganizationRepository is the repository for the organization model
+type OrganizationRepository struct {
+	reposhared.RepositoryBase[usermodels.OrganizationCreate, usermodels.OrganizationUpdate, usermodels.Organization, dbmodels.Organization]
+}
+
+var _ contracts_repositories.IOrganizationRepository = (*OrganizationRepository)(nil)
+
+// AddMember makes the user a member of the organization. Contexts scoped to
+// a tenant only add members to it.
+func (or *OrganizationRepository) AddMember(organizationID uint, userID uint) *applicationerrors.ApplicationError {
+	if tenantID, scoped := or.Tenant(); scoped && tenantID != organizationID {
+		return reposhared.MapOrmError(gorm.ErrRecordNotFound)
+	}
+	membership := dbmodels.Membership{OrganizationID: organizationID, UserID: userID}
+	if err := or.Conn().Create(&membership).Error; err != nil {
+		or.Logger.Debug("Error adding organization member", err)
+		return reposhared.MapOrmError(err)
+	}
+	return nil
+}
+
+// RemoveMember removes the user from the organization. Contexts scoped to a
+// tenant only remove members of it.
+func (or *OrganizationRepository) RemoveMember(organizationID uint, userID uint) *applicationerrors.ApplicationError {
+	db, _ := or.ScopedTo(&dbmodels.Membership{})
+	res := db.Where("organization_id = ? AND user_id = ?", organizationID, userID).Delete(&dbmodels.Membership{})
+	if err := res.Error; err != nil {
+		or.Logger.Debug("Error removing organization member", err)
+		return reposhared.MapOrmError(err)
+	}
+	if res.RowsAffected == 0 {
+		return reposhared.MapOrmError(gorm.ErrRecordNotFound)
+	}
+	return nil
+}
+
+// OrganizationConverter is the converter for the organization model
+type OrganizationConverter struct{}
+
+var _ reposhared.ModelConverter[usermodels.OrganizationCreate, usermodels.OrganizationUpdate, usermodels.Organization, dbmodels.Organization] = (*OrganizationConverter)(nil)
+
+// ToGormCreate converts an organization create model to an organization gorm model
+func (oc *OrganizationConverter) ToGormCreate(model usermodels.OrganizationCreate) *dbmodels.Organization {
+	return &dbmodels.Organization{
+		Name:     model.Name,
+		Slug:     model.Slug,
+		IsActive: model.IsActive,
+	}
+}
+
+// ToDomain converts an organization gorm model to an organization domain model
+func (oc *OrganizationConverter) ToDomain(ormModel *dbmodels.Organization) *usermodels.Organization {
+	return &usermodels.Organization{
+		ID: ormModel.ID,
+		OrganizationBase: usermodels.OrganizationBase{
+			Name:     ormModel.Name,
+			Slug:     ormModel.Slug,
+			IsActive: ormModel.IsActive,
+		},
+	}
+}
+
+// ToGormUpdate converts an organization update model to an organization gorm model
+func (oc *OrganizationConverter) ToGormUpdate(model usermodels.OrganizationUpdate) *dbmodels.Organization {
+	organization := &dbmodels.Organization{}
+
+	if model.Name != nil {
+		organization.Name = *model.Name
+	}
+
+	if model.IsActive != nil {
+		organization.IsActive = *model.IsActive
+	}
+
+	organization.ID = model.ID
+	return organization
+}
+
+// MembershipConverter is the converter for the membership model
+type MembershipConverter struct{}
+
+var _ reposhared.ModelConverter[usermodels.MembershipCreate, usermodels.MembershipUpdate, usermodels.Membership, dbmodels.Membership] = (*MembershipConverter)(nil)
+
+// ToGormCreate converts a membership create model to a membership gorm model
+func (mc *MembershipConverter) ToGormCreate(model usermodels.MembershipCreate) *dbmodels.Membership {
+	return &dbmodels.Membership{
+		OrganizationID: model.OrganizationID,
+		UserID:         model.UserID,
+	}
+}
+
+// ToDomain converts a membership gorm model to a membership domain model
+func (mc *MembershipConverter) ToDomain(ormModel *dbmodels.Membership) *usermodels.Membership {
+	return &usermodels.Membership{
+		OrganizationID: ormModel.OrganizationID,
+		UserID:         ormModel.UserID,
+	}
+}
+
+// ToGormUpdate converts a membership update model to a membership gorm model
+func (mc *MembershipConverter) ToGormUpdate(model usermodels.MembershipUpdate) *dbmodels.Membership {
+	return &dbmodels.Membership{
+		OrganizationID: model.OrganizationID,
+		UserID:         model.UserID,
+	}
+}
+
+// NewOrganizationRepository creates a new organization repository bound to
+// the context of the request, its queries are scoped to the tenant of an
+// AppContext
+func NewOrganizationRepository(ctx context.Context, db *gorm.DB, logger contractsprovider.ILoggerProvider) *OrganizationRepository {
+	return &OrganizationRepository{
+		RepositoryBase: reposhared.RepositoryBase[
+			usermodels.OrganizationCreate,
+			usermodels.OrganizationUpdate,
+			usermodels.Organization,
+			dbmodels.Organization,
+		]{
+			DB:             db.WithContext(ctx),
+			ModelConverter: &OrganizationConverter{},
+			Logger:         logger,
+		},
+	}
+}
@@ -0,0 +1,204 @@
+package userrepositories
+
+import (
+	"errors"
+	"slices"
+
+	contractsprovider "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	contracts_repositories "github.com/simon3640/goprojectskeleton/src/application/contracts/repositories"
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+	dbmodels "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/models"
+	reposhared "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/shared"
+
+	"gorm.io/gorm"
+)
+
+// PermissionRepository is the repository for the permission model
+type PermissionRepository struct {
+	reposhared.RepositoryBase[usermodels.PermissionCreate, usermodels.PermissionUpdate, usermodels.Permission, dbmodels.Permission]
+}
+
+var _ contracts_repositories.IPermissionRepository = (*PermissionRepository)(nil)
+
+var errUnknownPermission = errors.New("unknown permission")
+
+// GetRolePermissions gets the keys of the permissions granted to a role
+func (pr *PermissionRepository) GetRolePermissions(roleID uint) ([]string, *application_errors.ApplicationError) {
+	keys := []string{}
+	if err := pr.DB.Model(&dbmodels.Permission{}).
+		Joins("JOIN role_permission ON role_permission.permission_id = permission.id").
+		Where("role_permission.role_id = ?", roleID).
+		Pluck("permission.key", &keys).Error; err != nil {
+		pr.Logger.Debug("Error retrieving role permissions", err)
+		return nil, reposhared.MapOrmError(err)
+	}
+	return keys, nil
+}
+
+// SetRolePermissions replaces the permissions granted to a role by those
+// with the given keys in a transaction, unknown keys are rejected
+func (pr *PermissionRepository) SetRolePermissions(roleID uint, keys []string) *application_errors.ApplicationError {
+	keys = slices.Compact(slices.Sorted(slices.Values(keys)))
+	err := pr.DB.Transaction(func(tx *gorm.DB) error {
+		var permissions []dbmodels.Permission
+		if err := tx.Where("key IN ?", keys).Find(&permissions).Error; err != nil {
+			return err
+		}
+		if len(permissions) != len(keys) {
+			return errUnknownPermission
+		}
+		if err := tx.Where("role_id = ?", roleID).Delete(&dbmodels.RolePermission{}).Error; err != nil {
+			return err
+		}
+		if len(permissions) == 0 {
+			return nil
+		}
+		grants := make([]dbmodels.RolePermission, 0, len(permissions))
+		for _, permission := range permissions {
+			grants = append(grants, dbmodels.RolePermission{RoleID: roleID, PermissionID: permission.ID})
+		}
+		return tx.Create(&grants).Error
+	})
+	if errors.Is(err, errUnknownPermission) {
+		return application_errors.NewApplicationError(status.InvalidInput, messages.MessageKeysInstance.UNKNOWN_PERMISSION, "unknown permission")
+	}
+	if err != nil {
+		pr.Logger.Debug("Error setting role permissions", err)
+		return reposhared.MapOrmError(err)
+	}
+	return nil
+}
+
+// GetUserPermissions gets the permissions granted or revoked to a user
+func (pr *PermissionRepository) GetUserPermissions(userID uint) ([]usermodels.UserPermission, *application_errors.ApplicationError) {
+	var ormModels []dbmodels.UserPermission
+	if err := pr.DB.Preload("Permission").Where("user_id = ?", userID).Find(&ormModels).Error; err != nil {
+		pr.Logger.Debug("Error retrieving user permissions", err)
+		return nil, reposhared.MapOrmError(err)
+	}
+	permissions := make([]usermodels.UserPermission, 0, len(ormModels))
+	for _, ormModel := range ormModels {
+		permissions = append(permissions, usermodels.UserPermission{
+			UserID:        ormModel.UserID,
+			PermissionKey: ormModel.Permission.Key,
+			Granted:       ormModel.Granted,
+		})
+	}
+	return permissions, nil
+}
+
+// PermissionConverter is the converter for the permission model
+type PermissionConverter struct{}
+
+var _ reposhared.ModelConverter[usermodels.PermissionCreate, usermodels.PermissionUpdate, usermodels.Permission, dbmodels.Permission] = (*PermissionConverter)(nil)
+
+// ToGormCreate converts a permission create model to a permission gorm model
+func (pc *PermissionConverter) ToGormCreate(model usermodels.PermissionCreate) *dbmodels.Permission {
+	return &dbmodels.Permission{
+		Key:         model.Key,
+		Description: model.Description,
+	}
+}
+
+// ToDomain converts a permission gorm model to a permission domain model
+func (pc *PermissionConverter) ToDomain(ormModel *dbmodels.Permission) *usermodels.Permission {
+	return &usermodels.Permission{
+		ID: ormModel.ID,
+		PermissionBase: usermodels.PermissionBase{
+			Key:         ormModel.Key,
+			Description: ormModel.Description,
+		},
+	}
+}
+
+// ToGormUpdate converts a permission update model to a permission gorm model
+func (pc *PermissionConverter) ToGormUpdate(model usermodels.PermissionUpdate) *dbmodels.Permission {
+	permission := &dbmodels.Permission{}
+
+	if model.Description != nil {
+		permission.Description = *model.Description
+	}
+
+	permission.ID = model.ID
+	return permission
+}
+
+// RolePermissionConverter is the converter for the role permission model
+type RolePermissionConverter struct{}
+
+var _ reposhared.ModelConverter[usermodels.RolePermission, usermodels.RolePermission, usermodels.RolePermission, dbmodels.RolePermission] = (*RolePermissionConverter)(nil)
+
+// ToGormCreate converts a role permission model to a role permission gorm model
+func (rc *RolePermissionConverter) ToGormCreate(model usermodels.RolePermission) *dbmodels.RolePermission {
+	return &dbmodels.RolePermission{
+		RoleID:       model.RoleID,
+		PermissionID: model.PermissionID,
+	}
+}
+
+// ToDomain converts a role permission gorm model to a role permission domain model
+func (rc *RolePermissionConverter) ToDomain(ormModel *dbmodels.RolePermission) *usermodels.RolePermission {
+	return &usermodels.RolePermission{
+		RoleID:       ormModel.RoleID,
+		PermissionID: ormModel.PermissionID,
+	}
+}
+
+// ToGormUpdate converts a role permission model to a role permission gorm model
+func (rc *RolePermissionConverter) ToGormUpdate(model usermodels.RolePermission) *dbmodels.RolePermission {
+	return rc.ToGormCreate(model)
+}
+
+// UserPermissionConverter is the converter for the user permission model
+type UserPermissionConverter struct{}
+
+var _ reposhared.ModelConverter[usermodels.UserPermissionCreate, usermodels.UserPermissionUpdate, usermodels.UserPermission, dbmodels.UserPermission] = (*UserPermissionConverter)(nil)
+
+// ToGormCreate converts a user permission create model to a user permission gorm model
+func (uc *UserPermissionConverter) ToGormCreate(model usermodels.UserPermissionCreate) *dbmodels.UserPermission {
+	return &dbmodels.UserPermission{
+		UserID:       model.UserID,
+		PermissionID: model.PermissionID,
+		Granted:      model.Granted,
+	}
+}
+
+// ToDomain converts a user permission gorm model to a user permission domain model
+func (uc *UserPermissionConverter) ToDomain(ormModel *dbmodels.UserPermission) *usermodels.UserPermission {
+	return &usermodels.UserPermission{
+		UserID:        ormModel.UserID,
+		PermissionKey: ormModel.Permission.Key,
+		Granted:       ormModel.Granted,
+	}
+}
+
+// ToGormUpdate converts a user permission update model to a user permission gorm model
+func (uc *UserPermissionConverter) ToGormUpdate(model usermodels.UserPermissionUpdate) *dbmodels.UserPermission {
+	userPermission := &dbmodels.UserPermission{}
+
+	if model.Granted != nil {
+		userPermission.Granted = *model.Granted
+	}
+
+	userPermission.ID = model.ID
+	return userPermission
+}
+
+// NewPermissionRepository creates a new permission repository
+func NewPermissionRepository(db *gorm.DB, logger contractsprovider.ILoggerProvider) *PermissionRepository {
+	return &PermissionRepository{
+		RepositoryBase: reposhared.RepositoryBase[
+			usermodels.PermissionCreate,
+			usermodels.PermissionUpdate,
+			usermodels.Permission,
+			dbmodels.Permission,
+		]{
+			DB:             db,
+			ModelConverter: &PermissionConverter{},
+			Logger:         logger,
+		},
+	}
+}
@@ -3,6 +3,7 @@ package userrepositories
 import (
 	contractsprovider "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
 	usercontracts "github.com/simon3640/goprojectskeleton/src/application/modules/user/contracts"
+	applicationerrors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
 	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
 	dbmodels "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/models"
 	reposhared "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/shared"
@@ -17,6 +18,17 @@ type RoleRepository struct {
 
 var _ usercontracts.IRoleRepository = (*RoleRepository)(nil)
 
+// HasUsers reports whether any user of any organization holds the role, the
+// users deleted still reference it
+func (rr *RoleRepository) HasUsers(id uint) (bool, *applicationerrors.ApplicationError) {
+	var count int64
+	if err := rr.DB.Unscoped().Model(&dbmodels.User{}).Where("role_id = ?", id).Count(&count).Error; err != nil {
+		rr.Logger.Debug("Error counting users of role", err)
+		return false, reposhared.MapOrmError(err)
+	}
+	return count > 0, nil
+}
+
 // RoleConverter is the converter for the role model
 type RoleConverter struct{}
 
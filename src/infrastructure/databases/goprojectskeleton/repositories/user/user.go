@@ -2,6 +2,8 @@
 package userrepositories
 
 import (
+	"context"
+
 	contractsproviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
 	usercontracts "github.com/simon3640/goprojectskeleton/src/application/modules/user/contracts"
 
@@ -23,27 +25,12 @@ type UserRepository struct {
 	reposhared.RepositoryBase[userdtos.UserCreate, userdtos.UserUpdate, usermodels.User, dbmodels.User]
 }
 
-// CreateWithPassword creates a new user with a password
-func (ur *UserRepository) CreateWithPassword(input userdtos.UserAndPasswordCreate) (*usermodels.User, *applicationerrors.ApplicationError) {
-	// Convert input to 2 models UserCreate and UserInDB
-	userCreate := ur.ModelConverter.ToGormCreate(input.UserCreate)
-	tx := ur.DB.Begin()
-	defer func() {
-		if r := recover(); r != nil {
-			tx.Rollback()
-		}
-	}()
-	if err := tx.Create(userCreate).Error; err != nil {
-		ur.Logger.Debug("Error creating user", err)
-		tx.Rollback()
-		return nil, reposhared.MapOrmError(err)
-	}
-	userInDB := ur.ModelConverter.ToDomain(userCreate)
-	// Create password
+// CreatePassword creates the active password of a user from its hash
+func (ur *UserRepository) CreatePassword(userID uint, passwordHash string) *applicationerrors.ApplicationError {
 	passwordCreate := passworddtos.PasswordCreate{
 		PasswordBase: passwordmodels.PasswordBase{
-			UserID:   userInDB.ID,
-			Hash:     input.Password,
+			UserID:   userID,
+			Hash:     passwordHash,
 			IsActive: true,
 		},
 	}
@@ -54,23 +41,20 @@ func (ur *UserRepository) CreateWithPassword(input userdtos.UserAndPasswordCreat
 		IsActive:  passwordCreate.IsActive,
 		UserID:    passwordCreate.UserID,
 	}
-	if err := tx.Create(&passwordModel).Error; err != nil {
+	// The password is created in the unit of work of the context, if any
+	if err := ur.Conn().Create(&passwordModel).Error; err != nil {
 		ur.Logger.Debug("Error creating password for user", err)
-		tx.Rollback()
-		return nil, reposhared.MapOrmError(err)
+		return reposhared.MapOrmError(err)
 	}
-
-	if err := tx.Commit().Error; err != nil {
-		return nil, reposhared.DefaultORMError
-	}
-
-	return userInDB, nil
+	return nil
 }
 
-// GetUserWithRole gets a user with their role
+// GetUserWithRole gets a user with their role and the organizations they are
+// a member of. The user acts in the organization they joined first.
 func (ur *UserRepository) GetUserWithRole(id uint) (*usermodels.UserWithRole, *applicationerrors.ApplicationError) {
 	var userWithRole dbmodels.User
-	if err := ur.DB.Preload("Role").First(&userWithRole, id).Error; err != nil {
+	db, _ := ur.Scoped()
+	if err := db.Preload("Role").First(&userWithRole, id).Error; err != nil {
 		ur.Logger.Debug("Error retrieving user with role", err)
 		return nil, reposhared.MapOrmError(err)
 	}
@@ -79,7 +63,7 @@ func (ur *UserRepository) GetUserWithRole(id uint) (*usermodels.UserWithRole, *a
 		UserBase: usermodels.UserBase{
 			Name:     userWithRole.Name,
 			Email:    userWithRole.Email,
-			Phone:    userWithRole.Phone,
+			Phone:    phoneToDomain(userWithRole.Phone),
 			Status:   &userStatus,
 			RoleID:   userWithRole.RoleID,
 			OTPLogin: userWithRole.OTPLogin,
@@ -94,13 +78,28 @@ func (ur *UserRepository) GetUserWithRole(id uint) (*usermodels.UserWithRole, *a
 			Priority: userWithRole.Role.Priority,
 		},
 	})
+
+	var organizations []uint
+	memberships, _ := ur.ScopedTo(&dbmodels.Membership{})
+	if err := memberships.Model(&dbmodels.Membership{}).
+		Where("user_id = ?", id).
+		Order("created_at").
+		Pluck("organization_id", &organizations).Error; err != nil {
+		ur.Logger.Debug("Error retrieving user organizations", err)
+		return nil, reposhared.MapOrmError(err)
+	}
+	userWithRoleModel.SetOrganizations(organizations)
+	if len(organizations) > 0 {
+		userWithRoleModel.SetTenant(organizations[0])
+	}
 	return &userWithRoleModel, nil
 }
 
 // GetByEmailOrPhone gets a user by email or phone
 func (ur *UserRepository) GetByEmailOrPhone(emailOrPhone string) (*usermodels.User, *applicationerrors.ApplicationError) {
 	var user dbmodels.User
-	if err := ur.DB.Where("email = ? OR phone = ?", emailOrPhone, emailOrPhone).First(&user).Error; err != nil {
+	db, _ := ur.Scoped()
+	if err := db.Where("email = ? OR phone = ?", emailOrPhone, emailOrPhone).First(&user).Error; err != nil {
 		ur.Logger.Debug("Error retrieving user by email or phone", err)
 		return nil, reposhared.MapOrmError(err)
 	}
@@ -120,7 +119,7 @@ func (uc *UserConverter) ToGormCreate(model userdtos.UserCreate) *dbmodels.User
 	return &dbmodels.User{
 		Name:     model.Name,
 		Email:    model.Email,
-		Phone:    model.Phone,
+		Phone:    phoneToGorm(model.Phone),
 		Status:   string(*model.Status),
 		RoleID:   model.RoleID,
 		OTPLogin: model.OTPLogin,
@@ -140,7 +139,7 @@ func (uc *UserConverter) ToDomain(ormModel *dbmodels.User) *usermodels.User {
 		UserBase: usermodels.UserBase{
 			Name:     ormModel.Name,
 			Email:    ormModel.Email,
-			Phone:    ormModel.Phone,
+			Phone:    phoneToDomain(ormModel.Phone),
 			Status:   &userStatus,
 			RoleID:   ormModel.RoleID,
 			OTPLogin: ormModel.OTPLogin,
@@ -161,7 +160,7 @@ func (uc *UserConverter) ToGormUpdate(model userdtos.UserUpdate) *dbmodels.User
 	}
 
 	if model.Phone != nil {
-		user.Phone = *model.Phone
+		user.Phone = phoneToGorm(*model.Phone)
 	}
 
 	if model.Status != nil {
@@ -177,8 +176,25 @@ func (uc *UserConverter) ToGormUpdate(model userdtos.UserUpdate) *dbmodels.User
 	return user
 }
 
-// NewUserRepository creates a new user repository
-func NewUserRepository(db *gorm.DB, logger contractsproviders.ILoggerProvider) *UserRepository {
+// phoneToGorm stores users without phone with a NULL phone, which the unique
+// index does not compare
+func phoneToGorm(phone string) *string {
+	if phone == "" {
+		return nil
+	}
+	return &phone
+}
+
+func phoneToDomain(phone *string) string {
+	if phone == nil {
+		return ""
+	}
+	return *phone
+}
+
+// NewUserRepository creates a new user repository bound to the context of
+// the request, its queries are scoped to the tenant of an AppContext
+func NewUserRepository(ctx context.Context, db *gorm.DB, logger contractsproviders.ILoggerProvider) *UserRepository {
 	return &UserRepository{
 		RepositoryBase: reposhared.RepositoryBase[
 			userdtos.UserCreate,
@@ -186,7 +202,7 @@ func NewUserRepository(db *gorm.DB, logger contractsproviders.ILoggerProvider) *
 			usermodels.User,
 			dbmodels.User,
 		]{
-			DB:             db,
+			DB:             db.WithContext(ctx),
 			ModelConverter: &UserConverter{},
 			Logger:         logger,
 		},
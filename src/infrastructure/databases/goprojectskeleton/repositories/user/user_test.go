@@ -0,0 +1,118 @@
+package userrepositories
+
+import (
+	"context"
+	"testing"
+
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
+	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// dryRunDatabase returns a connection that builds the queries without
+// running them, and the SQL of the queries built
+func dryRunDatabase(t *testing.T) (*gorm.DB, *[]string) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	assert.NoError(t, err)
+	queries := []string{}
+	err = db.Callback().Query().After("gorm:query").Register("test:queries", func(tx *gorm.DB) {
+		queries = append(queries, tx.Statement.SQL.String())
+	})
+	assert.NoError(t, err)
+	return db, &queries
+}
+
+func TestNewUserRepository_TenantScoped(t *testing.T) {
+	assert := assert.New(t)
+
+	// The connection is not bound to the context of the request
+	db, queries := dryRunDatabase(t)
+	user := dtomocks.UserWithRole
+	user.SetOrganizations([]uint{3})
+	user.SetTenant(3)
+
+	repository := NewUserRepository(app_context.NewContextWithUser(&user), db, new(providersmocks.MockLoggerProvider))
+	_, _ = repository.GetByID(5)
+
+	assert.Len(*queries, 1)
+	assert.Contains((*queries)[0], `"organization_id" = $`)
+}
+
+func TestNewUserRepository_OtherTenant(t *testing.T) {
+	assert := assert.New(t)
+
+	// A request of the organization 4 reads a user of another organization
+	db, queries := dryRunDatabase(t)
+	var vars [][]interface{}
+	err := db.Callback().Query().After("gorm:query").Register("test:vars", func(tx *gorm.DB) {
+		vars = append(vars, tx.Statement.Vars)
+	})
+	assert.NoError(err)
+	user := dtomocks.UserWithRole
+	user.SetOrganizations([]uint{4})
+	user.SetTenant(4)
+
+	repository := NewUserRepository(app_context.NewContextWithUser(&user), db, new(providersmocks.MockLoggerProvider))
+	_, _ = repository.GetUserWithRole(5)
+	_, _ = repository.GetByEmailOrPhone("user@tenant-a.com")
+
+	// The users are looked up among the members of the organization 4 only
+	assert.Len(*queries, 3)
+	for i, query := range *queries {
+		assert.Contains(query, `"organization_id" = $`)
+		assert.Contains(vars[i], uint(4))
+	}
+
+	organizations := NewOrganizationRepository(app_context.NewContextWithUser(&user), db, new(providersmocks.MockLoggerProvider))
+	appErr := organizations.AddMember(3, 5)
+	assert.NotNil(appErr)
+	assert.Len(*queries, 3)
+}
+
+func TestNewUserRepository_NoTenant(t *testing.T) {
+	assert := assert.New(t)
+
+	db, queries := dryRunDatabase(t)
+
+	repository := NewUserRepository(context.Background(), db, new(providersmocks.MockLoggerProvider))
+	_, _ = repository.GetByID(5)
+
+	assert.Len(*queries, 1)
+	assert.NotContains((*queries)[0], "membership")
+}
+
+func TestNewUserRepository_UnitOfWork(t *testing.T) {
+	assert := assert.New(t)
+
+	db, queries := dryRunDatabase(t)
+	tx, txQueries := dryRunDatabase(t)
+	appCtx := app_context.NewVoidAppContext()
+
+	// The queries run in the transaction the context runs in
+	repository := NewUserRepository(appCtx, db, new(providersmocks.MockLoggerProvider))
+	appCtx.SetTransaction(tx)
+	_, _ = repository.GetByID(5)
+	appCtx.SetTransaction(nil)
+	_, _ = repository.GetByID(6)
+
+	assert.Len(*txQueries, 1)
+	assert.Len(*queries, 1)
+}
+
+func TestRoleRepository_HasUsers(t *testing.T) {
+	assert := assert.New(t)
+
+	db, queries := dryRunDatabase(t)
+
+	repository := NewRoleRepository(db, new(providersmocks.MockLoggerProvider))
+	_, _ = repository.HasUsers(3)
+
+	assert.Len(*queries, 1)
+	assert.Contains((*queries)[0], "role_id = $1")
+	// The users deleted still reference the role
+	assert.NotContains((*queries)[0], "deleted_at")
+}
@@ -0,0 +1,125 @@
+package authhandlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authusecases "github.com/simon3640/goprojectskeleton/src/application/modules/auth/use_cases"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	database "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton"
+	authrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/auth"
+	handlers "github.com/simon3640/goprojectskeleton/src/infrastructure/handlers/shared"
+	"github.com/simon3640/goprojectskeleton/src/infrastructure/providers"
+)
+
+// CreateAPIKey create an API key for the authenticated user
+// @Summary      Create an API key
+// @Description  This endpoint creates a named API key restricted to the given scopes until it expires. The key is only
+// @Description  returned once, it authenticates machine clients in the X-API-Key header instead of a JWT.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Param        request body authdtos.APIKeyRequest true "Name, scopes and expiration of the key"
+// @Success      201 {object} authdtos.APIKeyCreated "API key created"
+// @Failure      400 {object} map[string]string "Validation error"
+// @Failure      401 {object} map[string]string "Unauthorized"
+// @Router       /api/auth/api-key [post]
+// @Security Bearer
+func CreateAPIKey(ctx handlers.HandlerContext) {
+	if ctx.Context.User == nil {
+		http.Error(ctx.ResponseWriter, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var request authdtos.APIKeyRequest
+	if err := json.NewDecoder(*ctx.Body).Decode(&request); err != nil {
+		http.Error(ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+	request.UserID = ctx.Context.User.ID
+	uc := authusecases.NewCreateAPIKeyUseCase(
+		authrepositories.NewAPIKeyRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		providers.HashProviderInstance,
+	)
+	ucResult := usecase.Intercept(uc, "create_api_key_use_case").Execute(
+		ctx.Context,
+		ctx.Locale,
+		request,
+	)
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
+	}
+	handlers.NewRequestResolver[authdtos.APIKeyCreated]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
+}
+
+// GetAPIKeys list the API keys of the authenticated user
+// @Summary      List API keys
+// @Description  This endpoint lists the API keys of the authenticated user by their prefix, including the expired and
+// @Description  revoked ones. The keys themselves are never returned.
+// @Tags         Auth
+// @Produce      json
+// @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Success      200 {array} sharedmodels.APIKey "API keys"
+// @Failure      401 {object} map[string]string "Unauthorized"
+// @Router       /api/auth/api-key [get]
+// @Security Bearer
+func GetAPIKeys(ctx handlers.HandlerContext) {
+	if ctx.Context.User == nil {
+		http.Error(ctx.ResponseWriter, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	uc := authusecases.NewGetAPIKeysUseCase(
+		authrepositories.NewAPIKeyRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+	)
+	ucResult := usecase.Intercept(uc, "get_api_keys_use_case").Execute(
+		ctx.Context,
+		ctx.Locale,
+		ctx.Context.User.ID,
+	)
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
+	}
+	handlers.NewRequestResolver[[]sharedmodels.APIKey]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
+}
+
+// RevokeAPIKey revoke an API key by ID
+// @Summary      Revoke an API key
+// @Description  This endpoint revokes an API key of the authenticated user, admins can revoke the key of any user
+// @Tags         Auth
+// @Produce      json
+// @Param        id path int true "ID of the API key"
+// @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Success      200 {object} bool "API key revoked"
+// @Failure      401 {object} map[string]string "Unauthorized"
+// @Failure      404 {object} map[string]string "API key not found"
+// @Router       /api/auth/api-key/{id} [delete]
+// @Security Bearer
+func RevokeAPIKey(ctx handlers.HandlerContext) {
+	if ctx.Context.User == nil {
+		http.Error(ctx.ResponseWriter, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Params["id"])
+	if err != nil {
+		http.Error(ctx.ResponseWriter, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	uc := authusecases.NewRevokeAPIKeyUseCase(
+		authrepositories.NewAPIKeyRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+	)
+	ucResult := usecase.Intercept(uc, "revoke_api_key_use_case").Execute(
+		ctx.Context,
+		ctx.Locale,
+		uint(id),
+	)
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
+	}
+	handlers.NewRequestResolver[bool]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
+}
@@ -0,0 +1,107 @@
+package authhandlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authusecases "github.com/simon3640/goprojectskeleton/src/application/modules/auth/use_cases"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/settings"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	database "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton"
+	authrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/auth"
+	userrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/user"
+	handlers "github.com/simon3640/goprojectskeleton/src/infrastructure/handlers/shared"
+	"github.com/simon3640/goprojectskeleton/src/infrastructure/providers"
+)
+
+// ImpersonateUser start acting as another user
+// @Summary      Impersonate a user
+// @Description  This endpoint returns a short-lived access token of the user for the admin to reproduce their issues.
+// @Description  The token carries an act claim naming the admin, it has no refresh token and every impersonation is
+// @Description  recorded. Password creation, user deletion and the other dangerous actions are refused with it.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Param        id path int true "ID of the user to impersonate"
+// @Param        request body authdtos.ImpersonationRequest false "Reason of the impersonation"
+// @Success      201 {object} authdtos.ImpersonationStarted "Access token of the impersonation"
+// @Failure      400 {object} map[string]string "Validation error"
+// @Failure      401 {object} map[string]string "Not allowed to impersonate or user cannot be impersonated"
+// @Failure      404 {object} map[string]string "User not found"
+// @Router       /api/auth/impersonate/{id} [post]
+// @Security Bearer
+func ImpersonateUser(ctx handlers.HandlerContext) {
+	if ctx.Context.User == nil {
+		http.Error(ctx.ResponseWriter, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	id, err := strconv.Atoi(ctx.Params["id"])
+	if err != nil {
+		http.Error(ctx.ResponseWriter, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	var request authdtos.ImpersonationRequest
+	if err := json.NewDecoder(*ctx.Body).Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+	request.UserID = uint(id)
+
+	uc := authusecases.NewImpersonateUserUseCase(
+		userrepositories.NewUserRepository(ctx.Context, database.GoProjectSkeletondb.DB, providers.Logger),
+		authrepositories.NewImpersonationRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		providers.JWTProviderInstance,
+		providers.HashProviderInstance,
+		userrepositories.NewPermissionRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		providers.CacheProviderInstance,
+		time.Duration(settings.AppSettingsInstance.ImpersonationTTL)*time.Second,
+	)
+	ucResult := usecase.Intercept(uc, impersonateUserUseCase).Execute(
+		ctx.Context,
+		ctx.Locale,
+		request,
+	)
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
+	}
+	handlers.NewRequestResolver[authdtos.ImpersonationStarted]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
+}
+
+// EndImpersonation stop acting as another user
+// @Summary      End an impersonation
+// @Description  This endpoint is called with the access token of an impersonation, the token is revoked and the end
+// @Description  of the impersonation is recorded.
+// @Tags         Auth
+// @Produce      json
+// @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Success      200 {object} bool "Impersonation ended"
+// @Failure      401 {object} map[string]string "Not an impersonation"
+// @Router       /api/auth/impersonate [delete]
+// @Security Bearer
+func EndImpersonation(ctx handlers.HandlerContext) {
+	if ctx.Context.User == nil {
+		http.Error(ctx.ResponseWriter, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	uc := authusecases.NewEndImpersonationUseCase(
+		authrepositories.NewImpersonationRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		providers.NewTokenRevocationProvider(providers.CacheProviderInstance),
+	)
+	ucResult := usecase.Intercept(uc, endImpersonationUseCase).Execute(
+		ctx.Context,
+		ctx.Locale,
+		ctx.Context.User.ID,
+	)
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
+	}
+	handlers.NewRequestResolver[bool]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
+}
@@ -0,0 +1,32 @@
+package authhandlers
+
+import (
+	"time"
+
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authusecases "github.com/simon3640/goprojectskeleton/src/application/modules/auth/use_cases"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	handlers "github.com/simon3640/goprojectskeleton/src/infrastructure/handlers/shared"
+	"github.com/simon3640/goprojectskeleton/src/infrastructure/providers"
+)
+
+// GetJWKS publish the public keys that verify the issued tokens
+// @Summary      JSON Web Key Set
+// @Description  This endpoint returns the public keys that verify the issued tokens, selected by the kid header of the token. Keys that will sign after a rotation are published in advance, symmetric keys are never published.
+// @Tags         Auth
+// @Produce      json
+// @Success      200 {object} authdtos.JWKS "JSON Web Key Set"
+// @Router       /api/.well-known/jwks.json [get]
+func GetJWKS(ctx handlers.HandlerContext) {
+	uc := authusecases.NewGetJWKSUseCase(providers.JWTProviderInstance)
+	ucResult := usecase.Intercept(uc, "get_jwks_use_case").Execute(
+		ctx.Context,
+		ctx.Locale,
+		time.Now(),
+	)
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE:  string(handlers.APPLICATION_JSON),
+		handlers.CACHE_CONTROL: "public, max-age=300",
+	}
+	handlers.NewRequestResolver[authdtos.JWKS]().ResolveRaw(ctx.ResponseWriter, ucResult, headers)
+}
@@ -5,8 +5,8 @@ import (
 	"net/http"
 
 	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authpipes "github.com/simon3640/goprojectskeleton/src/application/modules/auth/pipes"
 	authusecases "github.com/simon3640/goprojectskeleton/src/application/modules/auth/use_cases"
-	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
 	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
 	database "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton"
 	authrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/auth"
@@ -19,14 +19,16 @@ import (
 // Login login with email and password and get JWT tokens
 // @Summary      Login and get JWT tokens
 // @Description  This endpoint allows a user to log in and receive JWT access and refresh tokens
+// @Description  Users with an authenticator app enrolled must send its code, or a recovery code, as totpCode
 // @Tags         Auth
 // @Accept       json
 // @Produce      json
 // @Param        request body authdtos.UserCredentials true "User credentials"
 // @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
-// @Success      200 {object} authdtos.Token "Tokens generated successfully"
-// @Success 	 204 {object} nil "OTP login enabled, OTP Sended to user email or phone"
+// @Param Idempotency-Key header string false "Key replaying the first response of retried requests"
+// @Success      200 {object} authdtos.AuthResponse "Tokens generated successfully, or the transactionId to log in with the OTP sent to the users with OTP login enabled"
 // @Failure      400 {object} map[string]string "Validation error"
+// @Failure      401 {object} map[string]string "MFA code required or invalid"
 // @Router       /api/auth/login [post]
 func Login(ctx handlers.HandlerContext) {
 	var userCredentials authdtos.UserCredentials
@@ -36,31 +38,40 @@ func Login(ctx handlers.HandlerContext) {
 	}
 
 	passwordRepository := passwordrepositories.NewPasswordRepository(database.GoProjectSkeletondb.DB, providers.Logger)
-	userRepository := userrepositories.NewUserRepository(database.GoProjectSkeletondb.DB, providers.Logger)
+	userRepository := userrepositories.NewUserRepository(ctx.Context, database.GoProjectSkeletondb.DB, providers.Logger)
 	otpRepository := authrepositories.NewOneTimePasswordRepository(database.GoProjectSkeletondb.DB, providers.Logger)
+	refreshTokenRepository := authrepositories.NewRefreshTokenRepository(database.GoProjectSkeletondb.DB, providers.Logger)
+	oneTimeTokenRepository := authrepositories.NewOneTimeTokenRepository(database.GoProjectSkeletondb.DB, providers.Logger)
 
-	uc := authusecases.NewAuthenticateUseCase(
+	authenticateUC := authusecases.NewAuthenticateUseCase(
 		passwordRepository,
 		userRepository,
-		otpRepository,
+		oneTimeTokenRepository,
 		providers.HashProviderInstance,
-		providers.JWTProviderInstance,
 		providers.CacheProviderInstance,
+		newMFAService(),
+	)
+	startOTPLoginUC := authusecases.NewStartOTPLoginUseCase(otpRepository, providers.HashProviderInstance)
+	issueLoginTokensUC := authusecases.NewIssueLoginTokensUseCase(
+		refreshTokenRepository,
+		providers.HashProviderInstance,
+		providers.JWTProviderInstance,
 	)
 
-	ucResult := usecase.InstrumentUseCase(
-		uc,
-		ctx.Context,
-		ctx.Locale,
-		userCredentials,
-		observability.GetObservabilityComponents().Tracer,
-		observability.GetObservabilityComponents().Metrics,
-		observability.GetObservabilityComponents().Clock,
-		"authenticate_use_case",
+	ucResult := handlers.Idempotent(ctx, providers.CacheProviderInstance, "POST /auth/login", userCredentials,
+		func() *usecase.UseCaseResult[authdtos.AuthResponse] {
+			return authpipes.NewLoginPipe(
+				ctx.Context,
+				ctx.Locale,
+				authenticateUC,
+				startOTPLoginUC,
+				issueLoginTokensUC,
+			).Execute(userCredentials)
+		},
 	)
 
 	headers := map[handlers.HTTPHeaderTypeEnum]string{
 		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
 	}
-	handlers.NewRequestResolver[authdtos.Token]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
+	handlers.NewRequestResolver[authdtos.AuthResponse]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
 }
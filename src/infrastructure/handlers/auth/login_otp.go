@@ -2,11 +2,11 @@
 package authhandlers
 
 import (
+	"encoding/json"
 	"net/http"
 
 	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
 	authusecases "github.com/simon3640/goprojectskeleton/src/application/modules/auth/use_cases"
-	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
 	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
 	database "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton"
 	authrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/auth"
@@ -17,41 +17,41 @@ import (
 
 // LoginOTP login with OTP and get JWT tokens
 // @Summary      Login with OTP and get JWT tokens
-// @Description  This endpoint allows a user to log in with OTP and receive JWT access and
+// @Description  This endpoint allows a user to log in with OTP and receive JWT access and refresh tokens
+// @Description  The OTP is only valid with the transactionId returned by the login, for a limited number of attempts
 // @Tags         Auth
 // @Accept       json
 // @Produce      json
-// @Param        otp path string true "One Time Password"
+// @Param        request body authdtos.OTPVerifyInput true "Login transaction and OTP"
 // @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
 // @Success      200 {object} authdtos.Token "Tokens generated successfully"
 // @Failure      400 {object} map[string]string "Validation error"
-// @Router       /api/auth/login-otp/{otp} [get]
+// @Failure      401 {object} map[string]string "Invalid OTP or transaction"
+// @Failure      429 {object} map[string]string "Too many invalid OTPs for the transaction"
+// @Router       /api/auth/login-otp [post]
 func LoginOTP(ctx handlers.HandlerContext) {
-	otp := ctx.Params["otp"]
-	if otp == "" {
-		http.Error(ctx.ResponseWriter, "otp is required", http.StatusBadRequest)
+	var otpVerifyInput authdtos.OTPVerifyInput
+	if err := json.NewDecoder(*ctx.Body).Decode(&otpVerifyInput); err != nil {
+		http.Error(ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
 		return
 	}
 
-	userRepository := userrepositories.NewUserRepository(database.GoProjectSkeletondb.DB, providers.Logger)
+	userRepository := userrepositories.NewUserRepository(ctx.Context, database.GoProjectSkeletondb.DB, providers.Logger)
 	otpRepository := authrepositories.NewOneTimePasswordRepository(database.GoProjectSkeletondb.DB, providers.Logger)
+	refreshTokenRepository := authrepositories.NewRefreshTokenRepository(database.GoProjectSkeletondb.DB, providers.Logger)
 
 	uc := authusecases.NewAuthenticateOTPUseCase(
 		userRepository,
 		otpRepository,
+		refreshTokenRepository,
 		providers.HashProviderInstance,
 		providers.JWTProviderInstance,
 	)
 
-	ucResult := usecase.InstrumentUseCase(
-		uc,
+	ucResult := usecase.Intercept(uc, "authenticate_otp_use_case").Execute(
 		ctx.Context,
 		ctx.Locale,
-		otp,
-		observability.GetObservabilityComponents().Tracer,
-		observability.GetObservabilityComponents().Metrics,
-		observability.GetObservabilityComponents().Clock,
-		"authenticate_otp_use_case",
+		otpVerifyInput,
 	)
 
 	headers := map[handlers.HTTPHeaderTypeEnum]string{
@@ -0,0 +1,79 @@
+package authhandlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	authusecases "github.com/simon3640/goprojectskeleton/src/application/modules/auth/use_cases"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	database "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton"
+	authrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/auth"
+	handlers "github.com/simon3640/goprojectskeleton/src/infrastructure/handlers/shared"
+	"github.com/simon3640/goprojectskeleton/src/infrastructure/providers"
+)
+
+// Logout close the session of a refresh token
+// @Summary      Logout
+// @Description  This endpoint revokes the refresh token and every token rotated from the same login
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Param        request body string true "Refresh token"
+// @Success      200 {object} bool "Session closed"
+// @Failure      400 {object} map[string]string "Validation error"
+// @Failure      401 {object} map[string]string "Invalid session"
+// @Router       /api/auth/logout [post]
+func Logout(ctx handlers.HandlerContext) {
+	var refreshToken string
+	if err := json.NewDecoder(*ctx.Body).Decode(&refreshToken); err != nil {
+		http.Error(ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	uc := authusecases.NewLogoutUseCase(
+		authrepositories.NewRefreshTokenRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		providers.HashProviderInstance,
+	)
+	ucResult := usecase.Intercept(uc, "logout_use_case").Execute(
+		ctx.Context,
+		ctx.Locale,
+		refreshToken,
+	)
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
+	}
+	handlers.NewRequestResolver[bool]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
+}
+
+// LogoutAll close every session of the authenticated user
+// @Summary      Logout from every session
+// @Description  This endpoint revokes every refresh token of the authenticated user
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Success      200 {object} bool "Sessions closed"
+// @Failure      401 {object} map[string]string "Unauthorized"
+// @Router       /api/auth/logout-all [post]
+// @Security Bearer
+func LogoutAll(ctx handlers.HandlerContext) {
+	if ctx.Context.User == nil {
+		http.Error(ctx.ResponseWriter, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	uc := authusecases.NewLogoutAllUseCase(
+		authrepositories.NewRefreshTokenRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		providers.NewTokenRevocationProvider(providers.CacheProviderInstance),
+	)
+	ucResult := usecase.Intercept(uc, "logout_all_use_case").Execute(
+		ctx.Context,
+		ctx.Locale,
+		ctx.Context.User.ID,
+	)
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
+	}
+	handlers.NewRequestResolver[bool]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
+}
@@ -0,0 +1,98 @@
+package authhandlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
+	authusecases "github.com/simon3640/goprojectskeleton/src/application/modules/auth/use_cases"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/settings"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	database "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton"
+	authrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/auth"
+	handlers "github.com/simon3640/goprojectskeleton/src/infrastructure/handlers/shared"
+	"github.com/simon3640/goprojectskeleton/src/infrastructure/providers"
+)
+
+// EnrollTOTP starts the enrollment of an authenticator app
+// @Summary      Enroll an authenticator app
+// @Description  This endpoint generates a new TOTP secret for the authenticated user and returns it with
+// @Description  its otpauth:// URI. The enrollment must be confirmed with a first code before it protects the logins.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Success      200 {object} authdtos.OTPEnrollRequest "TOTP secret and provisioning URI"
+// @Failure      401 {object} map[string]string "Unauthorized"
+// @Failure      409 {object} map[string]string "MFA already enabled"
+// @Router       /api/auth/mfa/totp/enroll [post]
+// @Security Bearer
+func EnrollTOTP(ctx handlers.HandlerContext) {
+	if ctx.Context.User == nil {
+		http.Error(ctx.ResponseWriter, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	uc := authusecases.NewEnrollTOTPUseCase(newMFAService())
+	ucResult := usecase.Intercept(uc, "enroll_totp_use_case").Execute(
+		ctx.Context,
+		ctx.Locale,
+		ctx.Context.User.ID,
+	)
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
+	}
+	handlers.NewRequestResolver[authdtos.OTPEnrollRequest]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
+}
+
+// ConfirmTOTP confirms the enrollment of an authenticator app
+// @Summary      Confirm an authenticator app
+// @Description  This endpoint confirms the enrollment with the current code of the authenticator app. From then on
+// @Description  the logins of the user require a code, the recovery codes returned are only shown once.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Param        request body authdtos.TOTPConfirm true "Authenticator app code"
+// @Success      200 {object} authdtos.RecoveryCodes "Recovery codes"
+// @Failure      400 {object} map[string]string "Invalid code"
+// @Failure      401 {object} map[string]string "Unauthorized"
+// @Failure      409 {object} map[string]string "No enrollment to confirm or MFA already enabled"
+// @Router       /api/auth/mfa/totp/confirm [post]
+// @Security Bearer
+func ConfirmTOTP(ctx handlers.HandlerContext) {
+	if ctx.Context.User == nil {
+		http.Error(ctx.ResponseWriter, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var confirm authdtos.TOTPConfirm
+	if err := json.NewDecoder(*ctx.Body).Decode(&confirm); err != nil {
+		http.Error(ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+	confirm.UserID = ctx.Context.User.ID
+
+	uc := authusecases.NewConfirmTOTPUseCase(newMFAService())
+	ucResult := usecase.Intercept(uc, "confirm_totp_use_case").Execute(
+		ctx.Context,
+		ctx.Locale,
+		confirm,
+	)
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
+	}
+	handlers.NewRequestResolver[authdtos.RecoveryCodes]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
+}
+
+// newMFAService creates the MFA service backed by the database
+func newMFAService() *authservices.MFAService {
+	return authservices.NewMFAService(
+		authrepositories.NewTOTPDeviceRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		authrepositories.NewRecoveryCodeRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		providers.NewTOTPProvider(settings.AppSettingsInstance.TOTPIssuer),
+		providers.NewEncryptionProvider(settings.AppSettingsInstance.EncryptionKey),
+		providers.HashProviderInstance,
+	)
+}
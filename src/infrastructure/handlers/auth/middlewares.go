@@ -0,0 +1,18 @@
+package authhandlers
+
+import (
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+)
+
+const (
+	impersonateUserUseCase  = "impersonate_user_use_case"
+	endImpersonationUseCase = "end_impersonation_use_case"
+)
+
+// RegisterUseCaseMiddlewares registers the middlewares of the use cases of
+// the auth module, executed every time they are intercepted
+func RegisterUseCaseMiddlewares() {
+	// The impersonations are audited
+	usecase.Register(impersonateUserUseCase, usecase.AuditLog())
+	usecase.Register(endImpersonationUseCase, usecase.AuditLog())
+}
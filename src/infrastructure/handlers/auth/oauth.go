@@ -0,0 +1,278 @@
+package authhandlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
+	authusecases "github.com/simon3640/goprojectskeleton/src/application/modules/auth/use_cases"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/settings"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	database "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton"
+	authrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/auth"
+	userrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/user"
+	handlers "github.com/simon3640/goprojectskeleton/src/infrastructure/handlers/shared"
+	"github.com/simon3640/goprojectskeleton/src/infrastructure/providers"
+)
+
+// RegisterOAuthClient register an OAuth2 client
+// @Summary      Register an OAuth2 client
+// @Description  This endpoint registers an application that delegates its login to this service. Only admins can
+// @Description  register clients. The client secret of confidential clients is only returned once.
+// @Tags         OAuth
+// @Accept       json
+// @Produce      json
+// @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Param        request body authdtos.OAuthClientCreate true "Client to register"
+// @Success      201 {object} authdtos.OAuthClientRegistration "Client registered"
+// @Failure      400 {object} map[string]string "Validation error"
+// @Failure      401 {object} map[string]string "Unauthorized"
+// @Router       /api/oauth/clients [post]
+// @Security Bearer
+func RegisterOAuthClient(ctx handlers.HandlerContext) {
+	if ctx.Context.User == nil {
+		http.Error(ctx.ResponseWriter, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var client authdtos.OAuthClientCreate
+	if err := json.NewDecoder(*ctx.Body).Decode(&client); err != nil {
+		http.Error(ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+	client.OwnerID = ctx.Context.User.ID
+	uc := authusecases.NewRegisterOAuthClientUseCase(
+		authrepositories.NewOAuthClientRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		providers.HashProviderInstance,
+	)
+	ucResult := usecase.Intercept(uc, "register_oauth_client_use_case").Execute(
+		ctx.Context,
+		ctx.Locale,
+		client,
+	)
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
+	}
+	handlers.NewRequestResolver[authdtos.OAuthClientRegistration]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
+}
+
+// AuthorizeOAuthClient authorize an OAuth2 client on behalf of the authenticated user
+// @Summary      Authorize an OAuth2 client
+// @Description  This endpoint is called by the login page of the service with the parameters of the authorization
+// @Description  request of the client. When the user has not consented to the requested scopes the response asks for
+// @Description  consent, the request is then repeated with consent. Otherwise it returns the redirect URI of the client
+// @Description  with the authorization code, which the client exchanges on /api/oauth/token with its PKCE verifier.
+// @Tags         OAuth
+// @Accept       json
+// @Produce      json
+// @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Param        request body authdtos.OAuthAuthorize true "Authorization request of the client"
+// @Success      200 {object} authdtos.OAuthAuthorization "Redirect URI with the code or consent required"
+// @Failure      400 {object} map[string]string "Invalid client, redirect URI or scope"
+// @Failure      401 {object} map[string]string "Unauthorized"
+// @Router       /api/oauth/authorize [post]
+// @Security Bearer
+func AuthorizeOAuthClient(ctx handlers.HandlerContext) {
+	if ctx.Context.User == nil {
+		http.Error(ctx.ResponseWriter, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var authorize authdtos.OAuthAuthorize
+	if err := json.NewDecoder(*ctx.Body).Decode(&authorize); err != nil {
+		http.Error(ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+	authorize.UserID = ctx.Context.User.ID
+	uc := authusecases.NewAuthorizeOAuthClientUseCase(
+		authrepositories.NewOAuthClientRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		authrepositories.NewOAuthConsentRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		providers.HashProviderInstance,
+		newOAuthCodeService(),
+	)
+	ucResult := usecase.Intercept(uc, "authorize_oauth_client_use_case").Execute(
+		ctx.Context,
+		ctx.Locale,
+		authorize,
+	)
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
+	}
+	handlers.NewRequestResolver[authdtos.OAuthAuthorization]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
+}
+
+// OAuthToken token endpoint of the OAuth2 clients
+// @Summary      OAuth2 token endpoint
+// @Description  This endpoint issues tokens to the OAuth2 clients for the authorization_code (with PKCE),
+// @Description  client_credentials and refresh_token grants. The client authenticates with client_id and
+// @Description  client_secret in the form, public clients only send their client_id.
+// @Tags         OAuth
+// @Accept       x-www-form-urlencoded
+// @Produce      json
+// @Param        grant_type formData string true "authorization_code, client_credentials or refresh_token"
+// @Param        client_id formData string true "Client ID"
+// @Param        client_secret formData string false "Client secret of confidential clients"
+// @Param        code formData string false "Authorization code"
+// @Param        redirect_uri formData string false "Redirect URI of the authorization request"
+// @Param        code_verifier formData string false "PKCE verifier"
+// @Param        refresh_token formData string false "Refresh token"
+// @Param        scope formData string false "Requested scopes"
+// @Success      200 {object} authdtos.OAuthTokenResponse "Tokens issued"
+// @Failure      400 {object} map[string]string "Invalid grant or scope"
+// @Failure      401 {object} map[string]string "Invalid client"
+// @Router       /api/oauth/token [post]
+func OAuthToken(ctx handlers.HandlerContext) {
+	form, err := readOAuthForm(ctx)
+	if err != nil {
+		http.Error(ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+	request := authdtos.OAuthTokenRequest{
+		OAuthClientCredentials: oauthClientCredentials(form),
+		GrantType:              form.Get("grant_type"),
+		Code:                   form.Get("code"),
+		RedirectURI:            form.Get("redirect_uri"),
+		CodeVerifier:           form.Get("code_verifier"),
+		RefreshToken:           form.Get("refresh_token"),
+		Scope:                  form.Get("scope"),
+	}
+	uc := authusecases.NewOAuthTokenUseCase(
+		authrepositories.NewOAuthClientRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		userrepositories.NewUserRepository(ctx.Context, database.GoProjectSkeletondb.DB, providers.Logger),
+		authrepositories.NewRefreshTokenRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		providers.JWTProviderInstance,
+		providers.HashProviderInstance,
+		providers.NewTokenRevocationProvider(providers.CacheProviderInstance),
+		newOAuthCodeService(),
+	)
+	ucResult := usecase.Intercept(uc, "oauth_token_use_case").Execute(
+		ctx.Context,
+		ctx.Locale,
+		request,
+	)
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE:  string(handlers.APPLICATION_JSON),
+		handlers.CACHE_CONTROL: "no-store",
+	}
+	handlers.NewRequestResolver[authdtos.OAuthTokenResponse]().ResolveRaw(ctx.ResponseWriter, ucResult, headers)
+}
+
+// IntrospectOAuthToken introspection endpoint of the OAuth2 clients (RFC 7662)
+// @Summary      OAuth2 token introspection
+// @Description  This endpoint tells a confidential client whether a token is active and returns its claims. Invalid,
+// @Description  expired and revoked tokens are reported as not active.
+// @Tags         OAuth
+// @Accept       x-www-form-urlencoded
+// @Produce      json
+// @Param        client_id formData string true "Client ID"
+// @Param        client_secret formData string true "Client secret"
+// @Param        token formData string true "Token to introspect"
+// @Param        token_type_hint formData string false "access_token or refresh_token"
+// @Success      200 {object} authdtos.OAuthIntrospection "Token introspected"
+// @Failure      400 {object} map[string]string "Validation error"
+// @Failure      401 {object} map[string]string "Invalid client"
+// @Router       /api/oauth/introspect [post]
+func IntrospectOAuthToken(ctx handlers.HandlerContext) {
+	reference, ok := readOAuthTokenReference(ctx)
+	if !ok {
+		return
+	}
+	uc := authusecases.NewIntrospectOAuthTokenUseCase(
+		authrepositories.NewOAuthClientRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		authrepositories.NewRefreshTokenRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		providers.JWTProviderInstance,
+		providers.HashProviderInstance,
+		providers.NewTokenRevocationProvider(providers.CacheProviderInstance),
+	)
+	ucResult := usecase.Intercept(uc, "introspect_oauth_token_use_case").Execute(
+		ctx.Context,
+		ctx.Locale,
+		reference,
+	)
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE:  string(handlers.APPLICATION_JSON),
+		handlers.CACHE_CONTROL: "no-store",
+	}
+	handlers.NewRequestResolver[authdtos.OAuthIntrospection]().ResolveRaw(ctx.ResponseWriter, ucResult, headers)
+}
+
+// RevokeOAuthToken revocation endpoint of the OAuth2 clients (RFC 7009)
+// @Summary      OAuth2 token revocation
+// @Description  This endpoint revokes a token issued to the client. Revoking a refresh token ends the session it
+// @Description  belongs to. Invalid and unknown tokens are answered as revoked.
+// @Tags         OAuth
+// @Accept       x-www-form-urlencoded
+// @Produce      json
+// @Param        client_id formData string true "Client ID"
+// @Param        client_secret formData string false "Client secret of confidential clients"
+// @Param        token formData string true "Token to revoke"
+// @Param        token_type_hint formData string false "access_token or refresh_token"
+// @Success      200 {object} bool "Token revoked"
+// @Failure      400 {object} map[string]string "Validation error"
+// @Failure      401 {object} map[string]string "Invalid client"
+// @Router       /api/oauth/revoke [post]
+func RevokeOAuthToken(ctx handlers.HandlerContext) {
+	reference, ok := readOAuthTokenReference(ctx)
+	if !ok {
+		return
+	}
+	uc := authusecases.NewRevokeOAuthTokenUseCase(
+		authrepositories.NewOAuthClientRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		authrepositories.NewRefreshTokenRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		providers.JWTProviderInstance,
+		providers.HashProviderInstance,
+		providers.NewTokenRevocationProvider(providers.CacheProviderInstance),
+	)
+	ucResult := usecase.Intercept(uc, "revoke_oauth_token_use_case").Execute(
+		ctx.Context,
+		ctx.Locale,
+		reference,
+	)
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
+	}
+	handlers.NewRequestResolver[bool]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
+}
+
+// readOAuthForm reads the form encoded body the OAuth2 endpoints are called with
+func readOAuthForm(ctx handlers.HandlerContext) (url.Values, error) {
+	body, err := io.ReadAll(*ctx.Body)
+	if err != nil {
+		return nil, err
+	}
+	return url.ParseQuery(string(body))
+}
+
+// readOAuthTokenReference reads the token of the introspection and revocation
+// endpoints, answering bad request when the body can not be read
+func readOAuthTokenReference(ctx handlers.HandlerContext) (authdtos.OAuthTokenReference, bool) {
+	form, err := readOAuthForm(ctx)
+	if err != nil {
+		http.Error(ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return authdtos.OAuthTokenReference{}, false
+	}
+	return authdtos.OAuthTokenReference{
+		OAuthClientCredentials: oauthClientCredentials(form),
+		Token:                  form.Get("token"),
+		TokenTypeHint:          form.Get("token_type_hint"),
+	}, true
+}
+
+func oauthClientCredentials(form url.Values) authdtos.OAuthClientCredentials {
+	return authdtos.OAuthClientCredentials{
+		ClientID:     form.Get("client_id"),
+		ClientSecret: form.Get("client_secret"),
+	}
+}
+
+// newOAuthCodeService creates the authorization code service backed by the cache
+func newOAuthCodeService() *authservices.OAuthCodeService {
+	return authservices.NewOAuthCodeService(
+		providers.CacheProviderInstance,
+		time.Duration(settings.AppSettingsInstance.OAuthCodeTTL)*time.Second,
+	)
+}
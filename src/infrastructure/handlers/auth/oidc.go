@@ -0,0 +1,186 @@
+package authhandlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
+	authusecases "github.com/simon3640/goprojectskeleton/src/application/modules/auth/use_cases"
+	userusecases "github.com/simon3640/goprojectskeleton/src/application/modules/user/use_cases"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/settings"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	database "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton"
+	authrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/auth"
+	userrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/user"
+	handlers "github.com/simon3640/goprojectskeleton/src/infrastructure/handlers/shared"
+	"github.com/simon3640/goprojectskeleton/src/infrastructure/providers"
+)
+
+// StartOIDCLogin starts a login with an identity provider
+// @Summary      Start a login with an identity provider
+// @Description  This endpoint returns the authorization URL of the configured identity provider the user must be
+// @Description  redirected to. The provider redirects back to its redirect URL with a code and the state.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Param        request body authdtos.OIDCAuthorize true "Name of the identity provider"
+// @Success      200 {object} authdtos.OIDCAuthorization "Authorization URL and state"
+// @Failure      400 {object} map[string]string "Validation error"
+// @Failure      404 {object} map[string]string "Identity provider not configured"
+// @Router       /api/auth/oidc/authorize [post]
+func StartOIDCLogin(ctx handlers.HandlerContext) {
+	var authorize authdtos.OIDCAuthorize
+	if err := json.NewDecoder(*ctx.Body).Decode(&authorize); err != nil {
+		http.Error(ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+	startOIDCAuthorization(ctx, authorize)
+}
+
+// OIDCLogin login with an identity provider and get JWT tokens
+// @Summary      Login with an identity provider and get JWT tokens
+// @Description  This endpoint exchanges the code the identity provider redirected back with and returns the same JWT
+// @Description  access and refresh tokens as the password login. The user is created on the first login when the
+// @Description  provider verified the email and no account uses it.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Param        request body authdtos.OIDCCallback true "State and code of the redirect"
+// @Success      200 {object} authdtos.Token "Tokens generated successfully"
+// @Failure      400 {object} map[string]string "Validation error"
+// @Failure      401 {object} map[string]string "Invalid or expired authorization"
+// @Failure      409 {object} map[string]string "An account with the email already exists"
+// @Router       /api/auth/oidc/callback [post]
+func OIDCLogin(ctx handlers.HandlerContext) {
+	var callback authdtos.OIDCCallback
+	if err := json.NewDecoder(*ctx.Body).Decode(&callback); err != nil {
+		http.Error(ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+	userRepository := userrepositories.NewUserRepository(ctx.Context, database.GoProjectSkeletondb.DB, providers.Logger)
+	uc := authusecases.NewOIDCLoginUseCase(
+		userRepository,
+		authrepositories.NewExternalIdentityRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		authrepositories.NewRefreshTokenRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		userusecases.NewCreateUserUseCase(
+			userRepository,
+			userrepositories.NewOrganizationRepository(ctx.Context, database.GoProjectSkeletondb.DB, providers.Logger),
+		),
+		providers.HashProviderInstance,
+		providers.JWTProviderInstance,
+		providers.OIDCProviderInstance,
+		newOIDCSessionService(),
+	)
+	ucResult := usecase.Intercept(uc, "oidc_login_use_case").Execute(
+		ctx.Context,
+		ctx.Locale,
+		callback,
+	)
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
+	}
+	handlers.NewRequestResolver[authdtos.Token]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
+}
+
+// StartOIDCLink starts linking an identity provider to the authenticated user
+// @Summary      Start linking an identity provider
+// @Description  This endpoint returns the authorization URL of the identity provider to link to the authenticated user.
+// @Description  The code the provider redirects back with must be sent to /api/auth/oidc/link/callback.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Param        request body authdtos.OIDCAuthorize true "Name of the identity provider"
+// @Success      200 {object} authdtos.OIDCAuthorization "Authorization URL and state"
+// @Failure      401 {object} map[string]string "Unauthorized"
+// @Failure      404 {object} map[string]string "Identity provider not configured"
+// @Router       /api/auth/oidc/link/authorize [post]
+// @Security Bearer
+func StartOIDCLink(ctx handlers.HandlerContext) {
+	if ctx.Context.User == nil {
+		http.Error(ctx.ResponseWriter, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var authorize authdtos.OIDCAuthorize
+	if err := json.NewDecoder(*ctx.Body).Decode(&authorize); err != nil {
+		http.Error(ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+	authorize.UserID = ctx.Context.User.ID
+	startOIDCAuthorization(ctx, authorize)
+}
+
+// LinkOIDCIdentity links an identity provider to the authenticated user
+// @Summary      Link an identity provider
+// @Description  This endpoint exchanges the code the identity provider redirected back with and links the account of
+// @Description  the provider to the authenticated user, who can then login with the provider.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Param        request body authdtos.OIDCCallback true "State and code of the redirect"
+// @Success      201 {object} sharedmodels.ExternalIdentity "Identity provider linked"
+// @Failure      400 {object} map[string]string "Validation error"
+// @Failure      401 {object} map[string]string "Unauthorized or invalid authorization"
+// @Failure      409 {object} map[string]string "Identity provider account linked to another user"
+// @Router       /api/auth/oidc/link/callback [post]
+// @Security Bearer
+func LinkOIDCIdentity(ctx handlers.HandlerContext) {
+	if ctx.Context.User == nil {
+		http.Error(ctx.ResponseWriter, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var callback authdtos.OIDCCallback
+	if err := json.NewDecoder(*ctx.Body).Decode(&callback); err != nil {
+		http.Error(ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+	callback.UserID = ctx.Context.User.ID
+	uc := authusecases.NewLinkOIDCIdentityUseCase(
+		authrepositories.NewExternalIdentityRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		providers.HashProviderInstance,
+		providers.OIDCProviderInstance,
+		newOIDCSessionService(),
+	)
+	ucResult := usecase.Intercept(uc, "link_oidc_identity_use_case").Execute(
+		ctx.Context,
+		ctx.Locale,
+		callback,
+	)
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
+	}
+	handlers.NewRequestResolver[sharedmodels.ExternalIdentity]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
+}
+
+func startOIDCAuthorization(ctx handlers.HandlerContext, authorize authdtos.OIDCAuthorize) {
+	uc := authusecases.NewStartOIDCAuthorizationUseCase(
+		providers.OIDCProviderInstance,
+		providers.HashProviderInstance,
+		newOIDCSessionService(),
+	)
+	ucResult := usecase.Intercept(uc, "start_oidc_authorization_use_case").Execute(
+		ctx.Context,
+		ctx.Locale,
+		authorize,
+	)
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
+	}
+	handlers.NewRequestResolver[authdtos.OIDCAuthorization]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
+}
+
+// newOIDCSessionService creates the OIDC session service backed by the cache
+func newOIDCSessionService() *authservices.OIDCSessionService {
+	return authservices.NewOIDCSessionService(
+		providers.CacheProviderInstance,
+		time.Duration(settings.AppSettingsInstance.OIDCStateTTL)*time.Second,
+	)
+}
@@ -33,7 +33,7 @@ func RequestPasswordReset(ctx handlers.HandlerContext) {
 		return
 	}
 
-	userRepository := userrepositories.NewUserRepository(database.GoProjectSkeletondb.DB, providers.Logger)
+	userRepository := userrepositories.NewUserRepository(ctx.Context, database.GoProjectSkeletondb.DB, providers.Logger)
 	oneTimeTokenRepository := authrepositories.NewOneTimeTokenRepository(database.GoProjectSkeletondb.DB, providers.Logger)
 
 	ucResetPasswordToken := authusecases.NewGetResetPasswordTokenUseCase(
@@ -6,8 +6,10 @@ import (
 
 	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
 	authusecases "github.com/simon3640/goprojectskeleton/src/application/modules/auth/use_cases"
-	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
 	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	database "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton"
+	authrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/auth"
+	userrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/user"
 	handlers "github.com/simon3640/goprojectskeleton/src/infrastructure/handlers/shared"
 	"github.com/simon3640/goprojectskeleton/src/infrastructure/providers"
 )
@@ -15,6 +17,8 @@ import (
 // RefreshAccessToken refresh JWT access token
 // @Summary      Refresh JWT access token
 // @Description  This endpoint allows a user to refresh their JWT access token using a valid refresh
+// @Description  token. The refresh token is rotated, reusing a rotated token closes the session.
+// @Description  Users that are no longer active can not refresh their tokens.
 // @Tags         Auth
 // @Accept       json
 // @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
@@ -22,6 +26,7 @@ import (
 // @Param        request body string true "Refresh token"
 // @Success      200 {object} authdtos.Token
 // @Failure      400 {object} map[string]string "Validation error"
+// @Failure      401 {object} map[string]string "Invalid session"
 // @Router       /api/auth/refresh [post]
 func RefreshAccessToken(ctx handlers.HandlerContext) {
 	var refreshToken string
@@ -31,17 +36,16 @@ func RefreshAccessToken(ctx handlers.HandlerContext) {
 	}
 
 	uc := authusecases.NewAuthenticationRefreshUseCase(
+		authrepositories.NewRefreshTokenRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		userrepositories.NewUserRepository(ctx.Context, database.GoProjectSkeletondb.DB, providers.Logger),
+		providers.HashProviderInstance,
 		providers.JWTProviderInstance,
+		providers.NewTokenRevocationProvider(providers.CacheProviderInstance),
 	)
-	ucResult := usecase.InstrumentUseCase(
-		uc,
+	ucResult := usecase.Intercept(uc, "authenticate_refresh_use_case").Execute(
 		ctx.Context,
 		ctx.Locale,
 		refreshToken,
-		observability.GetObservabilityComponents().Tracer,
-		observability.GetObservabilityComponents().Metrics,
-		observability.GetObservabilityComponents().Clock,
-		"authenticate_refresh_use_case",
 	)
 	headers := map[handlers.HTTPHeaderTypeEnum]string{
 		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
@@ -0,0 +1,57 @@
+package authhandlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authusecases "github.com/simon3640/goprojectskeleton/src/application/modules/auth/use_cases"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	database "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton"
+	authrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/auth"
+	userrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/user"
+	handlers "github.com/simon3640/goprojectskeleton/src/infrastructure/handlers/shared"
+	"github.com/simon3640/goprojectskeleton/src/infrastructure/providers"
+)
+
+// SwitchTenant switch the organization the user acts in
+// @Summary      Switch the organization the user acts in
+// @Description  This endpoint returns an access token acting in the given organization, 0 to act outside of every
+// @Description  organization. Members switch to their active organizations, super-admins to any of them. When the
+// @Description  refresh token of the session is given it is rotated and the new one keeps acting in the organization,
+// @Description  otherwise refreshing returns to the organization of the session.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Param        request body authdtos.TenantSwitch true "ID of the organization"
+// @Success      200 {object} authdtos.Token "Access token of the organization"
+// @Failure      401 {object} map[string]string "Not a member or inactive organization"
+// @Failure      404 {object} map[string]string "Organization not found"
+// @Router       /api/auth/tenant [post]
+// @Security Bearer
+func SwitchTenant(ctx handlers.HandlerContext) {
+	var tenantSwitch authdtos.TenantSwitch
+	if err := json.NewDecoder(*ctx.Body).Decode(&tenantSwitch); err != nil {
+		http.Error(ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	uc := authusecases.NewSwitchTenantUseCase(
+		// the organization switched to is outside of the current tenant
+		userrepositories.NewOrganizationRepository(context.Background(), database.GoProjectSkeletondb.DB, providers.Logger),
+		authrepositories.NewRefreshTokenRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		providers.JWTProviderInstance,
+		providers.HashProviderInstance,
+	)
+	ucResult := usecase.Intercept(uc, "switch_tenant_use_case").Execute(
+		ctx.Context,
+		ctx.Locale,
+		tenantSwitch,
+	)
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
+	}
+	handlers.NewRequestResolver[authdtos.Token]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
+}
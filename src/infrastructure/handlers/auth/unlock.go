@@ -0,0 +1,53 @@
+package authhandlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authusecases "github.com/simon3640/goprojectskeleton/src/application/modules/auth/use_cases"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	database "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton"
+	authrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/auth"
+	userrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/user"
+	handlers "github.com/simon3640/goprojectskeleton/src/infrastructure/handlers/shared"
+	"github.com/simon3640/goprojectskeleton/src/infrastructure/providers"
+)
+
+// UnlockAccount unlocks an account locked after too many failed logins
+// @Summary      Unlock an account
+// @Description  This endpoint unlocks an account locked after too many failed logins, with the token of the link emailed to its user
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param        request body authdtos.AccountUnlock true "Account unlock token"
+// @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Success      200 {boolean} bool "Account unlocked successfully"
+// @Failure      400 {object} map[string]string "Validation error"
+// @Failure      409 {object} map[string]string "Invalid or expired unlock token"
+// @Router       /api/auth/unlock [post]
+func UnlockAccount(ctx handlers.HandlerContext) {
+	var accountUnlock authdtos.AccountUnlock
+	if err := json.NewDecoder(*ctx.Body).Decode(&accountUnlock); err != nil {
+		http.Error(ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	uc := authusecases.NewUnlockAccountUseCase(
+		authrepositories.NewOneTimeTokenRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		userrepositories.NewUserRepository(ctx.Context, database.GoProjectSkeletondb.DB, providers.Logger),
+		providers.HashProviderInstance,
+		providers.CacheProviderInstance,
+	)
+
+	ucResult := usecase.Intercept(uc, "unlock_account_use_case").Execute(
+		ctx.Context,
+		ctx.Locale,
+		accountUnlock,
+	)
+
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
+	}
+	handlers.NewRequestResolver[bool]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
+}
@@ -0,0 +1,193 @@
+package authhandlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	authservices "github.com/simon3640/goprojectskeleton/src/application/modules/auth/services"
+	authusecases "github.com/simon3640/goprojectskeleton/src/application/modules/auth/use_cases"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/settings"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	database "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton"
+	authrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/auth"
+	userrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/user"
+	handlers "github.com/simon3640/goprojectskeleton/src/infrastructure/handlers/shared"
+	"github.com/simon3640/goprojectskeleton/src/infrastructure/providers"
+)
+
+// BeginWebAuthnRegistration starts the registration of a passkey
+// @Summary      Start a passkey registration
+// @Description  This endpoint returns the options for navigator.credentials.create to register a passkey for the
+// @Description  authenticated user. Binary values are base64url encoded and the challenge expires after WEBAUTHN_CHALLENGE_TTL.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Success      200 {object} authdtos.WebAuthnCreationOptions "Passkey creation options"
+// @Failure      401 {object} map[string]string "Unauthorized"
+// @Router       /api/auth/webauthn/register/begin [post]
+// @Security Bearer
+func BeginWebAuthnRegistration(ctx handlers.HandlerContext) {
+	if ctx.Context.User == nil {
+		http.Error(ctx.ResponseWriter, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	uc := authusecases.NewBeginWebAuthnRegistrationUseCase(
+		authrepositories.NewWebAuthnCredentialRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		newWebAuthnProvider(),
+		newWebAuthnSessionService(),
+	)
+	ucResult := usecase.Intercept(uc, "begin_webauthn_registration_use_case").Execute(
+		ctx.Context,
+		ctx.Locale,
+		ctx.Context.User.ID,
+	)
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
+	}
+	handlers.NewRequestResolver[authdtos.WebAuthnCreationOptions]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
+}
+
+// FinishWebAuthnRegistration completes the registration of a passkey
+// @Summary      Complete a passkey registration
+// @Description  This endpoint verifies the credential created by the authenticator for the pending registration
+// @Description  and stores the passkey. Only attestations of type none are accepted.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Param        request body authdtos.WebAuthnRegistrationFinish true "Passkey name and credential"
+// @Success      201 {object} sharedmodels.WebAuthnCredential "Passkey registered"
+// @Failure      400 {object} map[string]string "Invalid credential or no pending registration"
+// @Failure      401 {object} map[string]string "Unauthorized"
+// @Failure      409 {object} map[string]string "Passkey already registered"
+// @Router       /api/auth/webauthn/register/finish [post]
+// @Security Bearer
+func FinishWebAuthnRegistration(ctx handlers.HandlerContext) {
+	if ctx.Context.User == nil {
+		http.Error(ctx.ResponseWriter, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var finish authdtos.WebAuthnRegistrationFinish
+	if err := json.NewDecoder(*ctx.Body).Decode(&finish); err != nil {
+		http.Error(ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+	finish.UserID = ctx.Context.User.ID
+
+	uc := authusecases.NewFinishWebAuthnRegistrationUseCase(
+		authrepositories.NewWebAuthnCredentialRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		newWebAuthnProvider(),
+		newWebAuthnSessionService(),
+	)
+	ucResult := usecase.Intercept(uc, "finish_webauthn_registration_use_case").Execute(
+		ctx.Context,
+		ctx.Locale,
+		finish,
+	)
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
+	}
+	handlers.NewRequestResolver[sharedmodels.WebAuthnCredential]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
+}
+
+// BeginWebAuthnLogin starts a passkey login
+// @Summary      Start a passkey login
+// @Description  This endpoint returns the options for navigator.credentials.get and the sessionId the assertion must
+// @Description  be sent with. Without email the authenticator offers its discoverable passkeys.
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Param        request body authdtos.WebAuthnLoginBegin false "Email of the user"
+// @Success      200 {object} authdtos.WebAuthnLoginOptions "Passkey request options"
+// @Router       /api/auth/webauthn/login/begin [post]
+func BeginWebAuthnLogin(ctx handlers.HandlerContext) {
+	var begin authdtos.WebAuthnLoginBegin
+	// The body is optional, an empty one starts a login with discoverable passkeys
+	if err := json.NewDecoder(*ctx.Body).Decode(&begin); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	uc := authusecases.NewBeginWebAuthnLoginUseCase(
+		userrepositories.NewUserRepository(ctx.Context, database.GoProjectSkeletondb.DB, providers.Logger),
+		authrepositories.NewWebAuthnCredentialRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		newWebAuthnProvider(),
+		providers.HashProviderInstance,
+		newWebAuthnSessionService(),
+	)
+	ucResult := usecase.Intercept(uc, "begin_webauthn_login_use_case").Execute(
+		ctx.Context,
+		ctx.Locale,
+		begin,
+	)
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
+	}
+	handlers.NewRequestResolver[authdtos.WebAuthnLoginOptions]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
+}
+
+// FinishWebAuthnLogin login with a passkey and get JWT tokens
+// @Summary      Login with a passkey and get JWT tokens
+// @Description  This endpoint verifies the assertion of the authenticator for the pending login and returns the same
+// @Description  JWT access and refresh tokens as the password login
+// @Tags         Auth
+// @Accept       json
+// @Produce      json
+// @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Param        request body authdtos.WebAuthnLoginFinish true "Login session and assertion"
+// @Success      200 {object} authdtos.Token "Tokens generated successfully"
+// @Failure      400 {object} map[string]string "Validation error"
+// @Failure      401 {object} map[string]string "Invalid passkey or expired login"
+// @Router       /api/auth/webauthn/login/finish [post]
+func FinishWebAuthnLogin(ctx handlers.HandlerContext) {
+	var finish authdtos.WebAuthnLoginFinish
+	if err := json.NewDecoder(*ctx.Body).Decode(&finish); err != nil {
+		http.Error(ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	uc := authusecases.NewFinishWebAuthnLoginUseCase(
+		userrepositories.NewUserRepository(ctx.Context, database.GoProjectSkeletondb.DB, providers.Logger),
+		authrepositories.NewWebAuthnCredentialRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		authrepositories.NewRefreshTokenRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		providers.HashProviderInstance,
+		providers.JWTProviderInstance,
+		newWebAuthnProvider(),
+		newWebAuthnSessionService(),
+	)
+	ucResult := usecase.Intercept(uc, "finish_webauthn_login_use_case").Execute(
+		ctx.Context,
+		ctx.Locale,
+		finish,
+	)
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
+	}
+	handlers.NewRequestResolver[authdtos.Token]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
+}
+
+// newWebAuthnProvider creates the WebAuthn provider of the configured relying party
+func newWebAuthnProvider() *providers.WebAuthnProvider {
+	return providers.NewWebAuthnProvider(
+		settings.AppSettingsInstance.WebAuthnRPID,
+		settings.AppSettingsInstance.WebAuthnRPName,
+		settings.AppSettingsInstance.WebAuthnRPOrigins,
+	)
+}
+
+// newWebAuthnSessionService creates the WebAuthn session service backed by the cache
+func newWebAuthnSessionService() *authservices.WebAuthnSessionService {
+	return authservices.NewWebAuthnSessionService(
+		providers.CacheProviderInstance,
+		time.Duration(settings.AppSettingsInstance.WebAuthnChallengeTTL)*time.Second,
+	)
+}
@@ -38,6 +38,7 @@ func CreatePassword(ctx handlers.HandlerContext) {
 
 	ucResult := usecases_password.NewCreatePasswordUseCase(
 		passwordRepository, providers.HashProviderInstance,
+		providers.NewTokenRevocationProvider(providers.CacheProviderInstance),
 	).Execute(ctx.Context, ctx.Locale, passwordCreate)
 	headers := map[handlers.HTTPHeaderTypeEnum]string{
 		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
@@ -6,7 +6,6 @@ import (
 
 	passworddtos "github.com/simon3640/goprojectskeleton/src/application/modules/password/dtos"
 	usecases_password "github.com/simon3640/goprojectskeleton/src/application/modules/password/use_cases"
-	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
 	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
 	database "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton"
 	authrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/auth"
@@ -23,6 +22,7 @@ import (
 // @Accept json
 // @Produce json
 // @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Param Idempotency-Key header string false "Key replaying the first response of retried requests"
 // @Param request body passworddtos.PasswordTokenCreate true "Datos del usuario"
 // @Success 201 {object} bool "Token creado"
 // @Failure 400 {object} map[string]string "Error de validación"
@@ -42,16 +42,16 @@ func CreatePasswordToken(ctx handlers.HandlerContext) {
 		passwordRepository,
 		providers.HashProviderInstance,
 		oneTimeTokenRepository,
+		providers.NewTokenRevocationProvider(providers.CacheProviderInstance),
 	)
-	ucResult := usecase.InstrumentUseCase(
-		uc,
-		ctx.Context,
-		ctx.Locale,
-		passwordTokenCreate,
-		observability.GetObservabilityComponents().Tracer,
-		observability.GetObservabilityComponents().Metrics,
-		observability.GetObservabilityComponents().Clock,
-		"create_password_token_use_case",
+	ucResult := handlers.Idempotent(ctx, providers.CacheProviderInstance, "POST /password/reset-token", passwordTokenCreate,
+		func() *usecase.UseCaseResult[bool] {
+			return usecase.Intercept(uc, "create_password_token_use_case").Execute(
+				ctx.Context,
+				ctx.Locale,
+				passwordTokenCreate,
+			)
+		},
 	)
 
 	headers := map[handlers.HTTPHeaderTypeEnum]string{
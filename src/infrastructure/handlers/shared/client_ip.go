@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"net"
+	"strings"
+)
+
+// ClientIP returns the IP address of the client of a request. The remote
+// address of the connection is the client unless it is one of the trusted
+// proxies, the X-Forwarded-For header is then read from the right and the
+// first address that is not a trusted proxy is the client. The addresses at
+// the left of the header are set by the client and are never trusted.
+func ClientIP(forwardedFor string, remoteAddr string, trustedProxies []string) string {
+	ip := strings.TrimSpace(remoteAddr)
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+	if !isTrustedProxy(ip, trustedProxies) {
+		return ip
+	}
+
+	hops := strings.Split(forwardedFor, ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+		if hop == "" {
+			continue
+		}
+		if !isTrustedProxy(hop, trustedProxies) {
+			return hop
+		}
+		ip = hop
+	}
+	return ip
+}
+
+// isTrustedProxy reports whether the address is one of the trusted proxies,
+// given by address or CIDR range
+func isTrustedProxy(address string, trustedProxies []string) bool {
+	ip := net.ParseIP(address)
+	if ip == nil {
+		return false
+	}
+	for _, proxy := range trustedProxies {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		if _, network, err := net.ParseCIDR(proxy); err == nil {
+			if network.Contains(ip) {
+				return true
+			}
+			continue
+		}
+		if proxyIP := net.ParseIP(proxy); proxyIP != nil && proxyIP.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientIP(t *testing.T) {
+	trustedProxies := []string{"10.0.0.0/24", "192.0.2.10"}
+
+	cases := []struct {
+		name         string
+		forwardedFor string
+		remoteAddr   string
+		expected     string
+	}{
+		{name: "forwarded client", forwardedFor: "203.0.113.7", remoteAddr: "10.0.0.1:4321", expected: "203.0.113.7"},
+		{name: "chain of trusted proxies", forwardedFor: " 203.0.113.7 , 10.0.0.2", remoteAddr: "10.0.0.1:4321", expected: "203.0.113.7"},
+		{name: "trusted proxy by address", forwardedFor: "203.0.113.7", remoteAddr: "192.0.2.10:4321", expected: "203.0.113.7"},
+		{name: "spoofed header behind a trusted proxy", forwardedFor: "198.51.100.99, 203.0.113.7", remoteAddr: "10.0.0.1:4321", expected: "203.0.113.7"},
+		{name: "spoofed header without proxy", forwardedFor: "198.51.100.99", remoteAddr: "203.0.113.7:4321", expected: "203.0.113.7"},
+		{name: "spoofed trusted address without proxy", forwardedFor: "10.0.0.2", remoteAddr: "203.0.113.7:4321", expected: "203.0.113.7"},
+		{name: "untrusted proxy in the chain", forwardedFor: "198.51.100.99, 203.0.113.50, 10.0.0.2", remoteAddr: "10.0.0.1:4321", expected: "203.0.113.50"},
+		{name: "only trusted proxies", forwardedFor: "10.0.0.3, 10.0.0.2", remoteAddr: "10.0.0.1:4321", expected: "10.0.0.3"},
+		{name: "trusted proxy without header", remoteAddr: "10.0.0.1:4321", expected: "10.0.0.1"},
+		{name: "not proxied", remoteAddr: "198.51.100.4:4321", expected: "198.51.100.4"},
+		{name: "remote address without port", remoteAddr: "198.51.100.4", expected: "198.51.100.4"},
+		{name: "ipv6 remote address", remoteAddr: "[2001:db8::1]:4321", expected: "2001:db8::1"},
+		{name: "unknown client", expected: ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ClientIP(tc.forwardedFor, tc.remoteAddr, trustedProxies))
+		})
+	}
+}
+
+func TestClientIP_NoTrustedProxies(t *testing.T) {
+	// Without trusted proxies the header is ignored, whatever the client sets
+	assert.Equal(t, "203.0.113.7", ClientIP("198.51.100.99", "203.0.113.7:4321", nil))
+	assert.Equal(t, "203.0.113.7", ClientIP("198.51.100.99", "203.0.113.7:4321", []string{""}))
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	contractsproviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/settings"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+)
+
+// idempotencyKeyMaxLength is the maximum length of an Idempotency-Key header
+const idempotencyKeyMaxLength = 255
+
+// idempotencyLockTTL bounds the time a key stays locked by a request that never finishes
+const idempotencyLockTTL = time.Minute
+
+// idempotencyRecord is the first response stored for an idempotency key
+type idempotencyRecord struct {
+	Fingerprint string          `json:"fingerprint"`
+	Result      json.RawMessage `json:"result"`
+}
+
+// Idempotent executes the request at most once per Idempotency-Key.
+//
+// The first result is stored in the cache for IdempotencyTTL seconds, keyed by
+// the user and the route, and replayed on repeats with the
+// `idempotent-replayed` header. A repeat with a different body, or sent
+// while the first request is still running, is rejected with status.Conflict.
+//
+// Requests without Idempotency-Key are executed as usual. Server errors and
+// TooManyRequests are not stored, so the request can be retried with the same
+// key. Cache errors are logged and never fail the request.
+func Idempotent[D any](
+	ctx HandlerContext,
+	cache contractsproviders.ICacheProvider,
+	route string,
+	body any,
+	execute func() *usecase.UseCaseResult[D],
+) *usecase.UseCaseResult[D] {
+	if ctx.IdempotencyKey == "" || cache == nil {
+		return execute()
+	}
+	appMessages := locales.NewLocale(locales.EN_US)
+	if len(ctx.IdempotencyKey) > idempotencyKeyMaxLength {
+		return usecase.NewUseCaseResult[D]().SetError(
+			status.InvalidInput,
+			appMessages.Get(ctx.Locale, messages.MessageKeysInstance.INVALID_DATA),
+		)
+	}
+	logger := observability.GetObservabilityComponents().Logger
+
+	fingerprint, err := idempotencyFingerprint(body)
+	if err != nil {
+		logger.ErrorWithContext("Error computing idempotency fingerprint", err, ctx.Context)
+		return execute()
+	}
+	key := idempotencyCacheKey(ctx, route)
+
+	var record idempotencyRecord
+	found, appErr := cache.Get(key, &record)
+	if appErr != nil {
+		logger.ErrorWithContext("Error getting idempotency record", appErr.ToError(), ctx.Context)
+		return execute()
+	}
+	if found {
+		return replayIdempotent[D](ctx, record, fingerprint)
+	}
+
+	locks, appErr := cache.Increment(key+":lock", idempotencyLockTTL)
+	if appErr != nil {
+		logger.ErrorWithContext("Error locking idempotency key", appErr.ToError(), ctx.Context)
+		return execute()
+	}
+	if locks > 1 {
+		return usecase.NewUseCaseResult[D]().SetError(
+			status.Conflict,
+			appMessages.Get(ctx.Locale, messages.MessageKeysInstance.IDEMPOTENCY_KEY_IN_PROGRESS),
+		)
+	}
+	defer func() {
+		if appErr := cache.Delete(key + ":lock"); appErr != nil {
+			logger.ErrorWithContext("Error unlocking idempotency key", appErr.ToError(), ctx.Context)
+		}
+	}()
+
+	result := execute()
+	if result == nil || !storableStatus(result.StatusCode) {
+		return result
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		logger.ErrorWithContext("Error encoding idempotency record", err, ctx.Context)
+		return result
+	}
+	record = idempotencyRecord{Fingerprint: fingerprint, Result: data}
+	ttl := time.Duration(settings.AppSettingsInstance.IdempotencyTTL) * time.Second
+	if appErr := cache.Set(key, record, ttl); appErr != nil {
+		logger.ErrorWithContext("Error setting idempotency record", appErr.ToError(), ctx.Context)
+	}
+	return result
+}
+
+// replayIdempotent returns the stored result if the request matches the fingerprint
+func replayIdempotent[D any](ctx HandlerContext, record idempotencyRecord, fingerprint string) *usecase.UseCaseResult[D] {
+	if record.Fingerprint != fingerprint {
+		return usecase.NewUseCaseResult[D]().SetError(
+			status.Conflict,
+			locales.NewLocale(locales.EN_US).Get(ctx.Locale, messages.MessageKeysInstance.IDEMPOTENCY_KEY_CONFLICT),
+		)
+	}
+	result := usecase.NewUseCaseResult[D]()
+	if err := json.Unmarshal(record.Result, result); err != nil {
+		observability.GetObservabilityComponents().Logger.ErrorWithContext("Error decoding idempotency record", err, ctx.Context)
+		return usecase.NewUseCaseResult[D]().SetError(
+			status.InternalError,
+			locales.NewLocale(locales.EN_US).Get(ctx.Locale, messages.MessageKeysInstance.SOMETHING_WENT_WRONG),
+		)
+	}
+	if result.Headers == nil {
+		result.Headers = make(map[string]string)
+	}
+	result.AddHeader(IDEMPOTENT_REPLAYED.String(), "true")
+	return result
+}
+
+// idempotencyCacheKey builds the cache key of the request for its user and route
+func idempotencyCacheKey(ctx HandlerContext, route string) string {
+	actor := "anonymous"
+	if ctx.Context != nil && ctx.Context.User != nil {
+		actor = fmt.Sprintf("user:%d", ctx.Context.User.ID)
+	}
+	return "idempotency:" + route + ":" + actor + ":" + ctx.IdempotencyKey
+}
+
+// idempotencyFingerprint hashes the request body to detect conflicting repeats
+func idempotencyFingerprint(body any) (string, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// storableStatus reports whether a result with the status is replayed on repeats.
+// Server errors and TooManyRequests are transient, so they are not stored.
+func storableStatus(code status.ApplicationStatusEnum) bool {
+	httpStatus, ok := NewRequestResolver[any]().statusMapping[code]
+	return ok && httpStatus < 500 && httpStatus != 429
+}
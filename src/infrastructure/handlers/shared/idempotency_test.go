@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
+	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type idempotencyBody struct {
+	Email string `json:"email"`
+}
+
+func newIdempotencyContext(key string) HandlerContext {
+	return HandlerContext{
+		Context:        &app_context.AppContext{Context: context.Background()},
+		Locale:         locales.EN_US,
+		IdempotencyKey: key,
+	}
+}
+
+func TestIdempotentReplay(t *testing.T) {
+	assert := assert.New(t)
+
+	cache := providersmocks.NewMemoryCacheProvider()
+	calls := 0
+	execute := func() *usecase.UseCaseResult[string] {
+		calls++
+		return usecase.NewUseCaseResult[string]().SetData(status.Created, "created", "done")
+	}
+	ctx := newIdempotencyContext("key-1")
+	body := idempotencyBody{Email: "user@example.com"}
+
+	result := Idempotent(ctx, cache, "POST /user", body, execute)
+	assert.True(result.IsSuccess())
+	assert.Empty(result.Headers[IDEMPOTENT_REPLAYED.String()])
+
+	result = Idempotent(ctx, cache, "POST /user", body, execute)
+	assert.Equal(1, calls)
+	assert.True(result.IsSuccess())
+	assert.Equal(status.Created, result.StatusCode)
+	assert.Equal("created", *result.Data)
+	assert.Equal("true", result.Headers[IDEMPOTENT_REPLAYED.String()])
+
+	w := httptest.NewRecorder()
+	NewRequestResolver[string]().ResolveDTO(w, result, nil)
+	assert.Equal(201, w.Code)
+	assert.Equal("true", w.Header().Get("Idempotent-Replayed"))
+
+	// The key is scoped by route and by user
+	Idempotent(ctx, cache, "POST /auth/login", body, execute)
+	user := app_context.NewContextWithUser(&usermodels.UserWithRole{ID: 7})
+	Idempotent(HandlerContext{Context: user, Locale: locales.EN_US, IdempotencyKey: "key-1"}, cache, "POST /user", body, execute)
+	assert.Equal(3, calls)
+}
+
+func TestIdempotentConflict(t *testing.T) {
+	assert := assert.New(t)
+
+	cache := providersmocks.NewMemoryCacheProvider()
+	execute := func() *usecase.UseCaseResult[string] {
+		return usecase.NewUseCaseResult[string]().SetData(status.Created, "created", "done")
+	}
+	ctx := newIdempotencyContext("key-1")
+
+	Idempotent(ctx, cache, "POST /user", idempotencyBody{Email: "a@example.com"}, execute)
+	result := Idempotent(ctx, cache, "POST /user", idempotencyBody{Email: "b@example.com"}, execute)
+	assert.True(result.HasError())
+	assert.Equal(status.Conflict, result.StatusCode)
+
+	// A repeat sent while the first request is running is rejected
+	inner := Idempotent(newIdempotencyContext("key-2"), cache, "POST /user", idempotencyBody{}, func() *usecase.UseCaseResult[string] {
+		return Idempotent(newIdempotencyContext("key-2"), cache, "POST /user", idempotencyBody{}, execute)
+	})
+	assert.True(inner.HasError())
+	assert.Equal(status.Conflict, inner.StatusCode)
+}
+
+func TestIdempotentSkipsTransientErrorsAndMissingKey(t *testing.T) {
+	assert := assert.New(t)
+
+	cache := providersmocks.NewMemoryCacheProvider()
+	calls := 0
+	failing := func() *usecase.UseCaseResult[string] {
+		calls++
+		return usecase.NewUseCaseResult[string]().SetError(status.ProviderError, "provider down")
+	}
+	ctx := newIdempotencyContext("key-1")
+
+	Idempotent(ctx, cache, "POST /user", idempotencyBody{}, failing)
+	Idempotent(ctx, cache, "POST /user", idempotencyBody{}, failing)
+	assert.Equal(2, calls)
+
+	// Client errors are replayed
+	rejected := func() *usecase.UseCaseResult[string] {
+		calls++
+		return usecase.NewUseCaseResult[string]().SetError(status.InvalidInput, "invalid")
+	}
+	Idempotent(newIdempotencyContext("key-2"), cache, "POST /user", idempotencyBody{}, rejected)
+	result := Idempotent(newIdempotencyContext("key-2"), cache, "POST /user", idempotencyBody{}, rejected)
+	assert.Equal(3, calls)
+	assert.Equal(status.InvalidInput, result.StatusCode)
+	assert.Equal("invalid", *result.Error)
+
+	Idempotent(newIdempotencyContext(""), cache, "POST /user", idempotencyBody{}, rejected)
+	Idempotent(newIdempotencyContext(""), cache, "POST /user", idempotencyBody{}, rejected)
+	assert.Equal(5, calls)
+}
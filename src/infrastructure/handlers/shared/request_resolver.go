@@ -46,6 +46,9 @@ func (rr *RequestResolver[D]) ResolveDTO(
 	headersToAdd map[HTTPHeaderTypeEnum]string,
 ) {
 	rr.setHeaders(w, headersToAdd)
+	for key, value := range result.Headers {
+		w.Header().Set(key, value)
+	}
 
 	if result.HasError() {
 		w.WriteHeader(rr.statusMapping[result.StatusCode])
@@ -66,9 +69,32 @@ func (rr *RequestResolver[D]) ResolveDTO(
 		"data":    result.Data,
 		"details": result.Details,
 	}
+	if len(result.TaskIDs) > 0 {
+		resp["tasks"] = result.TaskIDs
+	}
 	json.NewEncoder(w).Encode(resp)
 }
 
+// ResolveRaw resolves the DTO without the data envelope, for responses whose
+// format is set by a standard, e.g. a JSON Web Key Set. Errors are resolved
+// as in ResolveDTO.
+func (rr *RequestResolver[D]) ResolveRaw(
+	w http.ResponseWriter,
+	result *usecase.UseCaseResult[D],
+	headersToAdd map[HTTPHeaderTypeEnum]string,
+) {
+	if result.HasError() || result.Data == nil {
+		rr.ResolveDTO(w, result, headersToAdd)
+		return
+	}
+	rr.setHeaders(w, headersToAdd)
+	for key, value := range result.Headers {
+		w.Header().Set(key, value)
+	}
+	w.WriteHeader(rr.statusMapping[result.StatusCode])
+	json.NewEncoder(w).Encode(result.Data)
+}
+
 func (rr *RequestResolver[D]) setHeaders(
 	w http.ResponseWriter, headersToAdd map[HTTPHeaderTypeEnum]string,
 ) {
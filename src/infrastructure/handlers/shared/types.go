@@ -26,6 +26,9 @@ const (
 	REFERRER            HTTPHeaderTypeEnum = "referrer"
 	CONTENT_TYPE        HTTPHeaderTypeEnum = "content-type"
 	AUTHORIZATION       HTTPHeaderTypeEnum = "authorization"
+	IDEMPOTENCY_KEY     HTTPHeaderTypeEnum = "idempotency-key"
+	IDEMPOTENT_REPLAYED HTTPHeaderTypeEnum = "idempotent-replayed"
+	CACHE_CONTROL       HTTPHeaderTypeEnum = "cache-control"
 )
 
 // SerializationTypeEnum is the type for the serialization type
@@ -97,6 +100,9 @@ type HandlerContext struct {
 	Body   *io.ReadCloser
 	Query  *Query
 
+	// IdempotencyKey is the Idempotency-Key header of the request, if any
+	IdempotencyKey string
+
 	ResponseWriter http.ResponseWriter
 }
 
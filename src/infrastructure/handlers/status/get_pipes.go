@@ -0,0 +1,57 @@
+package statushandlers
+
+import (
+	// The pipes register themselves in the pipe registry when imported
+	_ "github.com/simon3640/goprojectskeleton/src/application/modules/auth/pipes"
+	statusdtos "github.com/simon3640/goprojectskeleton/src/application/modules/status/dtos"
+	usecases "github.com/simon3640/goprojectskeleton/src/application/modules/status/use_cases"
+	_ "github.com/simon3640/goprojectskeleton/src/application/modules/user/pipes"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	handlers "github.com/simon3640/goprojectskeleton/src/infrastructure/handlers/shared"
+)
+
+// GetPipes list the registered pipes
+// @Summary This endpoint list the registered pipes
+// @Description List the steps, branches and background tasks of every registered pipe, exported as JSON, Graphviz DOT and Mermaid. Only for admins.
+// @Tags Status Check
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Success 200 {array} statusdtos.PipeDescription "Registered pipes"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Router /api/admin/pipe [get]
+func GetPipes(ctx handlers.HandlerContext) {
+	resolvePipes(ctx, "")
+}
+
+// GetPipe describe a registered pipe
+// @Summary This endpoint describe a registered pipe
+// @Description Get the steps, branches and background tasks of a registered pipe, exported as JSON, Graphviz DOT and Mermaid. Only for admins.
+// @Tags Status Check
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param name path string true "Pipe name"
+// @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Success 200 {array} statusdtos.PipeDescription "Registered pipe"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Pipe not found"
+// @Router /api/admin/pipe/{name} [get]
+func GetPipe(ctx handlers.HandlerContext) {
+	resolvePipes(ctx, ctx.Params["name"])
+}
+
+func resolvePipes(ctx handlers.HandlerContext, name string) {
+	uc := usecases.NewGetPipesUseCase()
+	ucResult := usecase.Intercept(uc, "get_pipes_use_case").Execute(
+		ctx.Context,
+		ctx.Locale,
+		name,
+	)
+
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
+	}
+	handlers.NewRequestResolver[[]statusdtos.PipeDescription]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
+}
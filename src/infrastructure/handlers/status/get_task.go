@@ -0,0 +1,42 @@
+package statushandlers
+
+import (
+	usecases "github.com/simon3640/goprojectskeleton/src/application/modules/status/use_cases"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	database "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton"
+	outboxrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/outbox"
+	handlers "github.com/simon3640/goprojectskeleton/src/infrastructure/handlers/shared"
+	"github.com/simon3640/goprojectskeleton/src/infrastructure/providers"
+)
+
+// GetTask get the status of a durable background task
+// @Summary This endpoint get the status of a background task
+// @Description Get the status, attempts and result of a durable background task by the task ID returned in the "tasks" field of a response.
+// @Description Users get the tasks of their requests, the users allowed to get the status of the pipes get every task.
+// @Description Anonymous requests, e.g. a sign-up, get no task ID.
+// @Tags Status Check
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID"
+// @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Success 200 {object} sharedmodels.OutboxTask "Background task"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 404 {object} map[string]string "Task not found"
+// @Router /api/task/{id} [get]
+// @Security Bearer
+func GetTask(ctx handlers.HandlerContext) {
+	uc := usecases.NewGetTaskUseCase(
+		outboxrepositories.NewOutboxTaskRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+	)
+	ucResult := usecase.Intercept(uc, "get_task_use_case").Execute(
+		ctx.Context,
+		ctx.Locale,
+		ctx.Params["id"],
+	)
+
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
+	}
+	handlers.NewRequestResolver[sharedmodels.OutboxTask]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
+}
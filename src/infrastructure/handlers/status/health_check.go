@@ -5,7 +5,6 @@ import (
 	"time"
 
 	usecases "github.com/simon3640/goprojectskeleton/src/application/modules/status/use_cases"
-	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
 	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
 	statusmodels "github.com/simon3640/goprojectskeleton/src/domain/status/models"
 	handlers "github.com/simon3640/goprojectskeleton/src/infrastructure/handlers/shared"
@@ -26,15 +25,10 @@ func GetHealthCheck(ctx handlers.HandlerContext) {
 	uc := usecases.NewGetStatusUseCase(
 		providers.NewApiStatusProvider(),
 	)
-	ucResult := usecase.InstrumentUseCase(
-		uc,
+	ucResult := usecase.Intercept(uc, "get_status_use_case").Execute(
 		ctx.Context,
 		ctx.Locale,
 		time.Now(),
-		observability.GetObservabilityComponents().Tracer,
-		observability.GetObservabilityComponents().Metrics,
-		observability.GetObservabilityComponents().Clock,
-		"get_status_use_case",
 	)
 
 	requestResolver := handlers.NewRequestResolver[statusmodels.Status]()
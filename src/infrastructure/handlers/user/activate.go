@@ -7,7 +7,6 @@ import (
 
 	userdtos "github.com/simon3640/goprojectskeleton/src/application/modules/user/dtos"
 	userusecases "github.com/simon3640/goprojectskeleton/src/application/modules/user/use_cases"
-	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
 	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
 	database "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton"
 	authrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/auth"
@@ -37,19 +36,14 @@ func ActivateUser(ctx handlers.HandlerContext) {
 	}
 
 	uc := userusecases.NewActivateUserUseCase(
-		userrepositories.NewUserRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		userrepositories.NewUserRepository(ctx.Context, database.GoProjectSkeletondb.DB, providers.Logger),
 		authrepositories.NewOneTimeTokenRepository(database.GoProjectSkeletondb.DB, providers.Logger),
 		providers.HashProviderInstance,
 	)
-	ucResult := usecase.InstrumentUseCase(
-		uc,
+	ucResult := usecase.Intercept(uc, "activate_user_use_case", invalidateUsers(allUsersTags)).Execute(
 		ctx.Context,
 		ctx.Locale,
 		userActivate,
-		observability.GetObservabilityComponents().Tracer,
-		observability.GetObservabilityComponents().Metrics,
-		observability.GetObservabilityComponents().Clock,
-		"activate_user_use_case",
 	)
 	headers := map[handlers.HTTPHeaderTypeEnum]string{
 		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
@@ -0,0 +1,65 @@
+package userhandlers
+
+import (
+	"fmt"
+	"time"
+
+	userdtos "github.com/simon3640/goprojectskeleton/src/application/modules/user/dtos"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/settings"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	handlers "github.com/simon3640/goprojectskeleton/src/infrastructure/handlers/shared"
+	"github.com/simon3640/goprojectskeleton/src/infrastructure/providers"
+)
+
+// allUsersCacheTag is carried by every cached user query
+const allUsersCacheTag = "user:*"
+
+// userCacheTags returns the cache tags of a query about the user with the given ID
+func userCacheTags(id uint) []string {
+	return []string{allUsersCacheTag, fmt.Sprintf("user:%d", id)}
+}
+
+// cacheUsers caches the results of a user query with the given tags
+func cacheUsers(key func(input any) string, tags func(input any) []string) usecase.Middleware {
+	return usecase.Cache(usecase.CacheOptions{
+		Provider: providers.CacheProviderInstance,
+		TTL:      time.Duration(settings.AppSettingsInstance.RedisTTL) * time.Second,
+		Key:      key,
+		Tags:     tags,
+	})
+}
+
+// invalidateUsers invalidates the cached user queries with the given tags
+func invalidateUsers(tags func(input any) []string) usecase.Middleware {
+	return usecase.InvalidateCache(providers.CacheProviderInstance, tags)
+}
+
+// invalidateUserTags invalidates the cached user queries with the given tags
+// outside of a middleware chain, e.g. after a pipe
+func invalidateUserTags(ctx handlers.HandlerContext, tags ...string) {
+	if err := usecase.InvalidateTags(providers.CacheProviderInstance, tags...); err != nil {
+		providers.Logger.ErrorWithContext("Error invalidating users cache", err.ToError(), ctx.Context)
+	}
+}
+
+// allUsersTags returns the tag carried by every cached user query
+func allUsersTags(any) []string {
+	return []string{allUsersCacheTag}
+}
+
+// userIDTags returns the tags of the user whose ID is the input
+func userIDTags(input any) []string {
+	id, _ := input.(uint)
+	return userCacheTags(id)
+}
+
+// userUpdateTags returns the tags of the updated user
+func userUpdateTags(input any) []string {
+	update, _ := input.(userdtos.UserUpdate)
+	return userCacheTags(update.ID)
+}
+
+// userIDKey returns the cache key of a query by user ID
+func userIDKey(input any) string {
+	return fmt.Sprintf("id:%v", input)
+}
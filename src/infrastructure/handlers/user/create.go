@@ -6,7 +6,6 @@ import (
 
 	userdtos "github.com/simon3640/goprojectskeleton/src/application/modules/user/dtos"
 	userusecases "github.com/simon3640/goprojectskeleton/src/application/modules/user/use_cases"
-	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
 	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
 	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
 	database "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton"
@@ -24,6 +23,7 @@ import (
 // @Produce json
 // @Param request body userdtos.UserCreate true "Datos del usuario"
 // @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Param Idempotency-Key header string false "Key replaying the first response of retried requests"
 // @Success 201 {object} usermodels.User "Usuario creado"
 // @Failure 400 {object} map[string]string "Error de validación"
 // @Router /api/user [post]
@@ -36,17 +36,17 @@ func CreateUser(ctx handlers.HandlerContext) {
 	}
 
 	uc := userusecases.NewCreateUserUseCase(
-		userrepositories.NewUserRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		userrepositories.NewUserRepository(ctx.Context, database.GoProjectSkeletondb.DB, providers.Logger),
+		userrepositories.NewOrganizationRepository(ctx.Context, database.GoProjectSkeletondb.DB, providers.Logger),
 	)
-	ucResult := usecase.InstrumentUseCase(
-		uc,
-		ctx.Context,
-		ctx.Locale,
-		userCreate,
-		observability.GetObservabilityComponents().Tracer,
-		observability.GetObservabilityComponents().Metrics,
-		observability.GetObservabilityComponents().Clock,
-		"create_user_use_case",
+	ucResult := handlers.Idempotent(ctx, providers.CacheProviderInstance, "POST /user", userCreate,
+		func() *usecase.UseCaseResult[usermodels.User] {
+			return usecase.Intercept(uc, "create_user_use_case", invalidateUsers(allUsersTags)).Execute(
+				ctx.Context,
+				ctx.Locale,
+				userCreate,
+			)
+		},
 	)
 
 	headers := map[handlers.HTTPHeaderTypeEnum]string{
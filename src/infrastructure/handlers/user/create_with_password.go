@@ -7,6 +7,7 @@ import (
 	userdtos "github.com/simon3640/goprojectskeleton/src/application/modules/user/dtos"
 	userpipes "github.com/simon3640/goprojectskeleton/src/application/modules/user/pipes"
 	userusecases "github.com/simon3640/goprojectskeleton/src/application/modules/user/use_cases"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
 	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
 	database "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton"
 	authrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/auth"
@@ -24,6 +25,7 @@ import (
 // @Produce json
 // @Param request body dtos.UserAndPasswordCreate true "Datos del usuario"
 // @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Param Idempotency-Key header string false "Key replaying the first response of retried requests"
 // @Success 201 {object} usermodels.User "Usuario creado"
 // @Failure 400 {object} map[string]string "Error de validación"
 // @Router /api/user-password [post]
@@ -38,15 +40,28 @@ func CreateUserAndPassword(ctx handlers.HandlerContext) {
 		providers.HashProviderInstance,
 		authrepositories.NewOneTimeTokenRepository(database.GoProjectSkeletondb.DB, providers.Logger),
 	)
+	userRepository := userrepositories.NewUserRepository(ctx.Context, database.GoProjectSkeletondb.DB, providers.Logger)
+	organizationRepository := userrepositories.NewOrganizationRepository(ctx.Context, database.GoProjectSkeletondb.DB, providers.Logger)
 	createUserPasswordUC := userusecases.NewCreateUserAndPasswordUseCase(
-		userrepositories.NewUserRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		userRepository,
 		providers.HashProviderInstance,
 	)
-	ucResult := userpipes.NewCreateUserPipe(ctx.Context,
-		ctx.Locale,
-		createUserPasswordUC,
-		createUserSendEmailUC,
-	).Execute(userCreate)
+	ucResult := handlers.Idempotent(ctx, providers.CacheProviderInstance, "POST /user-password", userCreate,
+		func() *usecase.UseCaseResult[usermodels.User] {
+			result := userpipes.NewCreateUserPipe(ctx.Context,
+				ctx.Locale,
+				createUserPasswordUC,
+				userusecases.NewDeleteCreatedUserUseCase(userRepository),
+				userusecases.NewCreateUserPasswordHashUseCase(userRepository),
+				userusecases.NewAddCreatedUserMembershipUseCase(organizationRepository),
+				createUserSendEmailUC,
+			).Execute(userCreate)
+			if result.IsSuccess() {
+				invalidateUserTags(ctx, allUsersCacheTag)
+			}
+			return result
+		},
+	)
 
 	headers := map[handlers.HTTPHeaderTypeEnum]string{
 		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
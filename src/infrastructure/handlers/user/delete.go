@@ -5,7 +5,6 @@ import (
 	"strconv"
 
 	userusecases "github.com/simon3640/goprojectskeleton/src/application/modules/user/use_cases"
-	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
 	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
 	database "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton"
 	userrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/user"
@@ -33,17 +32,13 @@ func DeleteUser(ctx handlers.HandlerContext) {
 	}
 
 	uc := userusecases.NewDeleteUserUseCase(
-		userrepositories.NewUserRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		userrepositories.NewUserRepository(ctx.Context, database.GoProjectSkeletondb.DB, providers.Logger),
+		providers.NewTokenRevocationProvider(providers.CacheProviderInstance),
 	)
-	ucResult := usecase.InstrumentUseCase(
-		uc,
+	ucResult := usecase.Intercept(uc, "delete_user_use_case", invalidateUsers(userIDTags)).Execute(
 		ctx.Context,
 		ctx.Locale,
 		uint(id),
-		observability.GetObservabilityComponents().Tracer,
-		observability.GetObservabilityComponents().Metrics,
-		observability.GetObservabilityComponents().Clock,
-		"delete_user_use_case",
 	)
 	headers := map[handlers.HTTPHeaderTypeEnum]string{
 		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
@@ -5,7 +5,6 @@ import (
 	"strconv"
 
 	userusecases "github.com/simon3640/goprojectskeleton/src/application/modules/user/use_cases"
-	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
 	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
 	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
 	database "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton"
@@ -34,17 +33,14 @@ func GetUser(ctx handlers.HandlerContext) {
 	}
 
 	uc := userusecases.NewGetUserUseCase(
-		userrepositories.NewUserRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		userrepositories.NewUserRepository(ctx.Context, database.GoProjectSkeletondb.DB, providers.Logger),
 	)
-	ucResult := usecase.InstrumentUseCase(
-		uc,
+	ucResult := usecase.Intercept(uc, "get_user_use_case",
+		cacheUsers(userIDKey, userIDTags),
+	).Execute(
 		ctx.Context,
 		ctx.Locale,
 		uint(id),
-		observability.GetObservabilityComponents().Tracer,
-		observability.GetObservabilityComponents().Metrics,
-		observability.GetObservabilityComponents().Clock,
-		"get_user_use_case",
 	)
 	headers := map[handlers.HTTPHeaderTypeEnum]string{
 		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
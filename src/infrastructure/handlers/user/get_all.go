@@ -3,7 +3,6 @@ package userhandlers
 import (
 	userdtos "github.com/simon3640/goprojectskeleton/src/application/modules/user/dtos"
 	userusecases "github.com/simon3640/goprojectskeleton/src/application/modules/user/use_cases"
-	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
 	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
 	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
 	domainutils "github.com/simon3640/goprojectskeleton/src/domain/shared/utils"
@@ -35,18 +34,14 @@ import (
 func GetAllUser(ctx handlers.HandlerContext) {
 	queryParams := domainutils.NewQueryPayloadBuilder[usermodels.User](ctx.Query.Sorts, ctx.Query.Filters, ctx.Query.Page, ctx.Query.PageSize)
 	uc := userusecases.NewGetAllUserUseCase(
-		userrepositories.NewUserRepository(database.GoProjectSkeletondb.DB, providers.Logger),
-		providers.CacheProviderInstance,
+		userrepositories.NewUserRepository(ctx.Context, database.GoProjectSkeletondb.DB, providers.Logger),
 	)
-	ucResult := usecase.InstrumentUseCase(
-		uc,
+	ucResult := usecase.Intercept(uc, "get_all_user_use_case",
+		cacheUsers(nil, allUsersTags),
+	).Execute(
 		ctx.Context,
 		ctx.Locale,
 		queryParams,
-		observability.GetObservabilityComponents().Tracer,
-		observability.GetObservabilityComponents().Metrics,
-		observability.GetObservabilityComponents().Clock,
-		"get_all_user_use_case",
 	)
 	headers := map[handlers.HTTPHeaderTypeEnum]string{
 		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
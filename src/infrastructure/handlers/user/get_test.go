@@ -0,0 +1,69 @@
+package userhandlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
+	sharedmodels "github.com/simon3640/goprojectskeleton/src/domain/shared/models"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+	database "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton"
+	handlers "github.com/simon3640/goprojectskeleton/src/infrastructure/handlers/shared"
+	"github.com/simon3640/goprojectskeleton/src/infrastructure/providers"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// useDryRunDatabase makes the repositories build their queries without
+// executing them, they find zero values
+func useDryRunDatabase(t *testing.T) {
+	db, err := gorm.Open(postgres.New(postgres.Config{DSN: "host=localhost"}), &gorm.Config{DryRun: true, DisableAutomaticPing: true})
+	assert.NoError(t, err)
+	original := database.GoProjectSkeletondb.DB
+	database.GoProjectSkeletondb.DB = db
+	t.Cleanup(func() {
+		database.GoProjectSkeletondb.DB = original
+	})
+}
+
+func useMemoryCache(t *testing.T) {
+	original := providers.CacheProviderInstance
+	providers.CacheProviderInstance = providersmocks.NewMemoryCacheProvider()
+	t.Cleanup(func() {
+		providers.CacheProviderInstance = original
+	})
+}
+
+// getUserAs gets the user with ID 1 as a user delegated the scopes
+func getUserAs(scopes ...string) *httptest.ResponseRecorder {
+	actor := usermodels.UserWithRole{ID: 1}
+	actor.SetPermissions([]string{usermodels.PermissionUserRead})
+	actor.SetScopes(scopes)
+	w := httptest.NewRecorder()
+	GetUser(handlers.HandlerContext{
+		Context:        &app_context.AppContext{Context: context.Background(), User: &actor},
+		Locale:         "en-US",
+		Params:         map[string]string{"id": "1"},
+		ResponseWriter: w,
+	})
+	return w
+}
+
+func TestGetUser_DelegatedActor(t *testing.T) {
+	useDryRunDatabase(t)
+	useMemoryCache(t)
+
+	w := getUserAs(sharedmodels.OAuthScopeProfile)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// The guards run before the cache, the cached user is not served to a
+	// client without the scope
+	w = getUserAs(sharedmodels.OAuthScopeUsersRead)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Contains(t, w.Body.String(), "scope")
+}
@@ -0,0 +1,178 @@
+package userhandlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	userdtos "github.com/simon3640/goprojectskeleton/src/application/modules/user/dtos"
+	userusecases "github.com/simon3640/goprojectskeleton/src/application/modules/user/use_cases"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	domainutils "github.com/simon3640/goprojectskeleton/src/domain/shared/utils"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+	database "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton"
+	userrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/user"
+	handlers "github.com/simon3640/goprojectskeleton/src/infrastructure/handlers/shared"
+	"github.com/simon3640/goprojectskeleton/src/infrastructure/providers"
+)
+
+// CreateOrganization create an organization
+// @Summary      Create an organization
+// @Description  This endpoint creates an organization without members, they are added with POST /api/organization/{id}/member.
+// @Description  Only super-admins, with the tenant:cross permission, create organizations.
+// @Tags         Organization
+// @Accept       json
+// @Produce      json
+// @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Param        request body usermodels.OrganizationCreate true "Name, slug and status of the organization"
+// @Success      201 {object} usermodels.Organization "Organization created"
+// @Failure      400 {object} map[string]string "Validation error"
+// @Failure      401 {object} map[string]string "Unauthorized"
+// @Failure      409 {object} map[string]string "Organization already exists"
+// @Router       /api/organization [post]
+// @Security Bearer
+func CreateOrganization(ctx handlers.HandlerContext) {
+	var organizationCreate usermodels.OrganizationCreate
+	if err := json.NewDecoder(*ctx.Body).Decode(&organizationCreate); err != nil {
+		http.Error(ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	uc := userusecases.NewCreateOrganizationUseCase(
+		userrepositories.NewOrganizationRepository(ctx.Context, database.GoProjectSkeletondb.DB, providers.Logger),
+	)
+	ucResult := usecase.Intercept(uc, "create_organization_use_case").Execute(
+		ctx.Context,
+		ctx.Locale,
+		organizationCreate,
+	)
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
+	}
+	handlers.NewRequestResolver[usermodels.Organization]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
+}
+
+// GetAllOrganization get all organizations
+// @Summary Get all organizations
+// @Description Retrieve the organizations with support for filtering, sorting, and pagination.
+// @Description Users acting in an organization only get their organization.
+// @Tags Organization
+// @Accept json
+// @Produce json
+// @Security Bearer
+//
+// @Param filter query []string false "Filter organizations in the format column:operator:value (e.g. Slug:eq:acme, IsActive:eq:true)"
+// @Param sort query []string false "Sort organizations in the format column:asc|desc (e.g. Name:asc)"
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Number of items per page (default: 10)"
+// @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+//
+// @Success 200 {object} userdtos.OrganizationMultiResponse "List of organizations"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/organization [get]
+func GetAllOrganization(ctx handlers.HandlerContext) {
+	queryParams := domainutils.NewQueryPayloadBuilder[usermodels.Organization](ctx.Query.Sorts, ctx.Query.Filters, ctx.Query.Page, ctx.Query.PageSize)
+	uc := userusecases.NewGetAllOrganizationUseCase(
+		userrepositories.NewOrganizationRepository(ctx.Context, database.GoProjectSkeletondb.DB, providers.Logger),
+	)
+	ucResult := usecase.Intercept(uc, "get_all_organization_use_case").Execute(
+		ctx.Context,
+		ctx.Locale,
+		queryParams,
+	)
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
+	}
+	handlers.NewRequestResolver[userdtos.OrganizationMultiResponse]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
+}
+
+// AddOrganizationMember add a member to an organization
+// @Summary      Add a member to an organization
+// @Description  This endpoint makes a user a member of the organization the caller acts in, super-admins add members
+// @Description  to any organization.
+// @Tags         Organization
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "ID of the organization"
+// @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Param        request body userdtos.OrganizationMember true "ID of the user"
+// @Success      201 {object} userdtos.OrganizationMember "Member added"
+// @Failure      400 {object} map[string]string "Validation error"
+// @Failure      401 {object} map[string]string "Unauthorized or other organization"
+// @Failure      409 {object} map[string]string "Already a member, or unknown user"
+// @Router       /api/organization/{id}/member [post]
+// @Security Bearer
+func AddOrganizationMember(ctx handlers.HandlerContext) {
+	id, err := strconv.Atoi(ctx.Params["id"])
+	if err != nil {
+		http.Error(ctx.ResponseWriter, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	var member userdtos.OrganizationMember
+	if err := json.NewDecoder(*ctx.Body).Decode(&member); err != nil {
+		http.Error(ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	member.OrganizationID = uint(id)
+	uc := userusecases.NewAddOrganizationMemberUseCase(
+		userrepositories.NewOrganizationRepository(ctx.Context, database.GoProjectSkeletondb.DB, providers.Logger),
+	)
+	ucResult := usecase.Intercept(uc, "add_organization_member_use_case",
+		invalidateUsers(allUsersTags),
+	).Execute(
+		ctx.Context,
+		ctx.Locale,
+		member,
+	)
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
+	}
+	handlers.NewRequestResolver[userdtos.OrganizationMember]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
+}
+
+// RemoveOrganizationMember remove a member from an organization
+// @Summary      Remove a member from an organization
+// @Description  This endpoint removes a user from the organization the caller acts in, super-admins remove members
+// @Description  from any organization.
+// @Tags         Organization
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "ID of the organization"
+// @Param        user_id path int true "ID of the user"
+// @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Success      200 {object} bool "Member removed"
+// @Failure      401 {object} map[string]string "Unauthorized or other organization"
+// @Failure      404 {object} map[string]string "Not a member"
+// @Router       /api/organization/{id}/member/{user_id} [delete]
+// @Security Bearer
+func RemoveOrganizationMember(ctx handlers.HandlerContext) {
+	id, err := strconv.Atoi(ctx.Params["id"])
+	if err != nil {
+		http.Error(ctx.ResponseWriter, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+	userID, err := strconv.Atoi(ctx.Params["user_id"])
+	if err != nil {
+		http.Error(ctx.ResponseWriter, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	uc := userusecases.NewRemoveOrganizationMemberUseCase(
+		userrepositories.NewOrganizationRepository(ctx.Context, database.GoProjectSkeletondb.DB, providers.Logger),
+	)
+	ucResult := usecase.Intercept(uc, "remove_organization_member_use_case",
+		invalidateUsers(allUsersTags),
+	).Execute(
+		ctx.Context,
+		ctx.Locale,
+		userdtos.OrganizationMember{OrganizationID: uint(id), UserID: uint(userID)},
+	)
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
+	}
+	handlers.NewRequestResolver[bool]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
+}
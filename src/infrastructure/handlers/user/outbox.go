@@ -0,0 +1,22 @@
+package userhandlers
+
+import (
+	userpipes "github.com/simon3640/goprojectskeleton/src/application/modules/user/pipes"
+	userusecases "github.com/simon3640/goprojectskeleton/src/application/modules/user/use_cases"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	database "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton"
+	authrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/auth"
+	"github.com/simon3640/goprojectskeleton/src/infrastructure/providers"
+)
+
+// RegisterOutboxHandlers registers the durable tasks of the user module
+// so the outbox dispatcher can deliver them after a restart
+func RegisterOutboxHandlers() {
+	usecase.RegisterDurableStep(
+		userpipes.CreateUserSendEmailTask,
+		userpipes.NewCreateUserSendEmailStep(userusecases.NewCreateUserSendEmailUseCase(
+			providers.HashProviderInstance,
+			authrepositories.NewOneTimeTokenRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		)),
+	)
+}
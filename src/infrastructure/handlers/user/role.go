@@ -0,0 +1,283 @@
+package userhandlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	userdtos "github.com/simon3640/goprojectskeleton/src/application/modules/user/dtos"
+	userusecases "github.com/simon3640/goprojectskeleton/src/application/modules/user/use_cases"
+	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
+	domainutils "github.com/simon3640/goprojectskeleton/src/domain/shared/utils"
+	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
+	database "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton"
+	userrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/user"
+	handlers "github.com/simon3640/goprojectskeleton/src/infrastructure/handlers/shared"
+	"github.com/simon3640/goprojectskeleton/src/infrastructure/providers"
+)
+
+// CreateRole create a role
+// @Summary      Create a role
+// @Description  This endpoint creates a role without permissions, they are set with PUT /api/role/{id}/permission.
+// @Tags         Role
+// @Accept       json
+// @Produce      json
+// @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Param        request body usermodels.RoleCreate true "Key, status and priority of the role"
+// @Success      201 {object} usermodels.Role "Role created"
+// @Failure      400 {object} map[string]string "Validation error"
+// @Failure      401 {object} map[string]string "Unauthorized"
+// @Failure      409 {object} map[string]string "Role already exists"
+// @Router       /api/role [post]
+// @Security Bearer
+func CreateRole(ctx handlers.HandlerContext) {
+	var roleCreate usermodels.RoleCreate
+	if err := json.NewDecoder(*ctx.Body).Decode(&roleCreate); err != nil {
+		http.Error(ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	uc := userusecases.NewCreateRoleUseCase(
+		userrepositories.NewRoleRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+	)
+	ucResult := usecase.Intercept(uc, "create_role_use_case").Execute(
+		ctx.Context,
+		ctx.Locale,
+		roleCreate,
+	)
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
+	}
+	handlers.NewRequestResolver[usermodels.Role]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
+}
+
+// GetRole get a role by ID
+// @Summary      Get a role
+// @Description  This endpoint gets a role by ID
+// @Tags         Role
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "ID of the role"
+// @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Success      200 {object} usermodels.Role "Role"
+// @Failure      401 {object} map[string]string "Unauthorized"
+// @Failure      404 {object} map[string]string "Role not found"
+// @Router       /api/role/{id} [get]
+// @Security Bearer
+func GetRole(ctx handlers.HandlerContext) {
+	id, err := strconv.Atoi(ctx.Params["id"])
+	if err != nil {
+		http.Error(ctx.ResponseWriter, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	uc := userusecases.NewGetRoleUseCase(
+		userrepositories.NewRoleRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+	)
+	ucResult := usecase.Intercept(uc, "get_role_use_case").Execute(
+		ctx.Context,
+		ctx.Locale,
+		uint(id),
+	)
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
+	}
+	handlers.NewRequestResolver[usermodels.Role]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
+}
+
+// GetAllRole get all roles
+// @Summary Get all roles
+// @Description Retrieve all roles with support for filtering, sorting, and pagination.
+// @Tags Role
+// @Accept json
+// @Produce json
+// @Security Bearer
+//
+// @Param filter query []string false "Filter roles in the format column:operator:value (e.g. Key:eq:admin, Priority:gt:1)"
+// @Param sort query []string false "Sort roles in the format column:asc|desc (e.g. Priority:asc, Key:desc)"
+// @Param page query int false "Page number (default: 1)"
+// @Param page_size query int false "Number of items per page (default: 10)"
+// @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+//
+// @Success 200 {object} userdtos.RoleMultiResponse "List of roles"
+// @Failure 400 {object} map[string]string "Bad request"
+// @Failure 401 {object} map[string]string "Unauthorized"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /api/role [get]
+func GetAllRole(ctx handlers.HandlerContext) {
+	queryParams := domainutils.NewQueryPayloadBuilder[usermodels.Role](ctx.Query.Sorts, ctx.Query.Filters, ctx.Query.Page, ctx.Query.PageSize)
+	uc := userusecases.NewGetAllRoleUseCase(
+		userrepositories.NewRoleRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+	)
+	ucResult := usecase.Intercept(uc, "get_all_role_use_case").Execute(
+		ctx.Context,
+		ctx.Locale,
+		queryParams,
+	)
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
+	}
+	handlers.NewRequestResolver[userdtos.RoleMultiResponse]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
+}
+
+// UpdateRole update a role by ID
+// @Summary      Update a role
+// @Description  This endpoint updates a role by ID, the built-in admin and user roles can not be renamed
+// @Tags         Role
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "ID of the role"
+// @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Param        request body usermodels.RoleUpdateBase true "Fields of the role to update"
+// @Success      200 {object} usermodels.Role "Role updated"
+// @Failure      400 {object} map[string]string "Validation error"
+// @Failure      401 {object} map[string]string "Unauthorized"
+// @Failure      409 {object} map[string]string "Built-in role"
+// @Router       /api/role/{id} [patch]
+// @Security Bearer
+func UpdateRole(ctx handlers.HandlerContext) {
+	id, err := strconv.Atoi(ctx.Params["id"])
+	if err != nil {
+		http.Error(ctx.ResponseWriter, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	var roleUpdate usermodels.RoleUpdate
+	if err := json.NewDecoder(*ctx.Body).Decode(&roleUpdate); err != nil {
+		http.Error(ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	roleUpdate.ID = uint(id)
+	uc := userusecases.NewUpdateRoleUseCase(
+		userrepositories.NewRoleRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+	)
+	ucResult := usecase.Intercept(uc, "update_role_use_case").Execute(
+		ctx.Context,
+		ctx.Locale,
+		roleUpdate,
+	)
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
+	}
+	handlers.NewRequestResolver[usermodels.Role]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
+}
+
+// DeleteRole delete a role by ID
+// @Summary      Delete a role
+// @Description  This endpoint deletes a role by ID with its permissions. The built-in admin and user roles and the
+// @Description  roles with users can not be deleted.
+// @Tags         Role
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "ID of the role"
+// @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Success      200 {object} bool "Role deleted"
+// @Failure      401 {object} map[string]string "Unauthorized"
+// @Failure      404 {object} map[string]string "Role not found"
+// @Failure      409 {object} map[string]string "Built-in role or role with users"
+// @Router       /api/role/{id} [delete]
+// @Security Bearer
+func DeleteRole(ctx handlers.HandlerContext) {
+	id, err := strconv.Atoi(ctx.Params["id"])
+	if err != nil {
+		http.Error(ctx.ResponseWriter, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	uc := userusecases.NewDeleteRoleUseCase(
+		userrepositories.NewRoleRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		userrepositories.NewPermissionRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		providers.CacheProviderInstance,
+	)
+	ucResult := usecase.Intercept(uc, "delete_role_use_case").Execute(
+		ctx.Context,
+		ctx.Locale,
+		uint(id),
+	)
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
+	}
+	handlers.NewRequestResolver[bool]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
+}
+
+// GetRolePermissions get the permissions of a role
+// @Summary      Get the permissions of a role
+// @Description  This endpoint gets the keys of the permissions granted to a role
+// @Tags         Role
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "ID of the role"
+// @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Success      200 {object} userdtos.RolePermissions "Permissions of the role"
+// @Failure      401 {object} map[string]string "Unauthorized"
+// @Failure      404 {object} map[string]string "Role not found"
+// @Router       /api/role/{id}/permission [get]
+// @Security Bearer
+func GetRolePermissions(ctx handlers.HandlerContext) {
+	id, err := strconv.Atoi(ctx.Params["id"])
+	if err != nil {
+		http.Error(ctx.ResponseWriter, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	uc := userusecases.NewGetRolePermissionsUseCase(
+		userrepositories.NewRoleRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		userrepositories.NewPermissionRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+	)
+	ucResult := usecase.Intercept(uc, "get_role_permissions_use_case").Execute(
+		ctx.Context,
+		ctx.Locale,
+		uint(id),
+	)
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
+	}
+	handlers.NewRequestResolver[userdtos.RolePermissions]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
+}
+
+// SetRolePermissions replace the permissions of a role
+// @Summary      Set the permissions of a role
+// @Description  This endpoint replaces the permissions granted to a role by the given permission keys. The permissions
+// @Description  of the admin role can not be changed.
+// @Tags         Role
+// @Accept       json
+// @Produce      json
+// @Param        id path int true "ID of the role"
+// @Param Accept-Language header string false "Locale for response messages" Enums(en-US, es-ES) default(en-US)
+// @Param        request body userdtos.RolePermissions true "Keys of the permissions of the role"
+// @Success      200 {object} userdtos.RolePermissions "Permissions of the role"
+// @Failure      400 {object} map[string]string "Validation error or unknown permission"
+// @Failure      401 {object} map[string]string "Unauthorized"
+// @Failure      409 {object} map[string]string "Admin role"
+// @Router       /api/role/{id}/permission [put]
+// @Security Bearer
+func SetRolePermissions(ctx handlers.HandlerContext) {
+	id, err := strconv.Atoi(ctx.Params["id"])
+	if err != nil {
+		http.Error(ctx.ResponseWriter, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	var rolePermissions userdtos.RolePermissions
+	if err := json.NewDecoder(*ctx.Body).Decode(&rolePermissions); err != nil {
+		http.Error(ctx.ResponseWriter, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	rolePermissions.RoleID = uint(id)
+	uc := userusecases.NewSetRolePermissionsUseCase(
+		userrepositories.NewRoleRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		userrepositories.NewPermissionRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+		providers.CacheProviderInstance,
+	)
+	ucResult := usecase.Intercept(uc, "set_role_permissions_use_case").Execute(
+		ctx.Context,
+		ctx.Locale,
+		rolePermissions,
+	)
+	headers := map[handlers.HTTPHeaderTypeEnum]string{
+		handlers.CONTENT_TYPE: string(handlers.APPLICATION_JSON),
+	}
+	handlers.NewRequestResolver[userdtos.RolePermissions]().ResolveDTO(ctx.ResponseWriter, ucResult, headers)
+}
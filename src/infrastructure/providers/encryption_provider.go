@@ -0,0 +1,89 @@
+package providers
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+
+	contractsProviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+)
+
+// EncryptionProvider encrypts secrets at rest with AES-256-GCM.
+// The key is the SHA-256 of the configured secret, every ciphertext is
+// prefixed with its random nonce.
+type EncryptionProvider struct {
+	key [32]byte
+}
+
+var _ contractsProviders.IEncryptionProvider = (*EncryptionProvider)(nil)
+
+// Encrypt encrypts the plaintext
+func (ep *EncryptionProvider) Encrypt(plaintext []byte) ([]byte, *application_errors.ApplicationError) {
+	aead, err := ep.aead()
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, application_errors.NewApplicationError(
+			status.ProviderError,
+			messages.MessageKeysInstance.SOMETHING_WENT_WRONG,
+			"failed to generate nonce",
+		)
+	}
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// Decrypt decrypts a ciphertext returned by Encrypt
+func (ep *EncryptionProvider) Decrypt(ciphertext []byte) ([]byte, *application_errors.ApplicationError) {
+	aead, err := ep.aead()
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, application_errors.NewApplicationError(
+			status.ProviderError,
+			messages.MessageKeysInstance.SOMETHING_WENT_WRONG,
+			"ciphertext too short",
+		)
+	}
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	plaintext, openErr := aead.Open(nil, nonce, sealed, nil)
+	if openErr != nil {
+		return nil, application_errors.NewApplicationError(
+			status.ProviderError,
+			messages.MessageKeysInstance.SOMETHING_WENT_WRONG,
+			"failed to decrypt ciphertext",
+		)
+	}
+	return plaintext, nil
+}
+
+func (ep *EncryptionProvider) aead() (cipher.AEAD, *application_errors.ApplicationError) {
+	block, err := aes.NewCipher(ep.key[:])
+	if err != nil {
+		return nil, application_errors.NewApplicationError(
+			status.ProviderError,
+			messages.MessageKeysInstance.SOMETHING_WENT_WRONG,
+			err.Error(),
+		)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, application_errors.NewApplicationError(
+			status.ProviderError,
+			messages.MessageKeysInstance.SOMETHING_WENT_WRONG,
+			err.Error(),
+		)
+	}
+	return aead, nil
+}
+
+// NewEncryptionProvider creates an encryption provider with a key derived from the secret
+func NewEncryptionProvider(secret string) *EncryptionProvider {
+	return &EncryptionProvider{key: sha256.Sum256([]byte(secret))}
+}
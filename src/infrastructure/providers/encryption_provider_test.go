@@ -0,0 +1,44 @@
+package providers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncryptionProvider_EncryptDecrypt(t *testing.T) {
+	assert := assert.New(t)
+	encryptionProvider := NewEncryptionProvider("secret")
+
+	ciphertext, err := encryptionProvider.Encrypt([]byte("TOTP secret"))
+	assert.Nil(err)
+	assert.NotContains(string(ciphertext), "TOTP secret")
+
+	plaintext, err := encryptionProvider.Decrypt(ciphertext)
+	assert.Nil(err)
+	assert.Equal("TOTP secret", string(plaintext))
+
+	// Every encryption uses a new nonce
+	other, err := encryptionProvider.Encrypt([]byte("TOTP secret"))
+	assert.Nil(err)
+	assert.NotEqual(ciphertext, other)
+}
+
+func TestEncryptionProvider_DecryptRejectsInvalidCiphertext(t *testing.T) {
+	assert := assert.New(t)
+	encryptionProvider := NewEncryptionProvider("secret")
+
+	ciphertext, err := encryptionProvider.Encrypt([]byte("TOTP secret"))
+	assert.Nil(err)
+
+	_, err = NewEncryptionProvider("other secret").Decrypt(ciphertext)
+	assert.NotNil(err)
+
+	tampered := append([]byte{}, ciphertext...)
+	tampered[len(tampered)-1] ^= 0xff
+	_, err = encryptionProvider.Decrypt(tampered)
+	assert.NotNil(err)
+
+	_, err = encryptionProvider.Decrypt([]byte("short"))
+	assert.NotNil(err)
+}
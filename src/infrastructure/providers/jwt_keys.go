@@ -0,0 +1,178 @@
+package providers
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"sort"
+	"time"
+
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// JWT signing algorithms
+const (
+	JWTAlgorithmHS256 = "HS256"
+	JWTAlgorithmRS256 = "RS256"
+	JWTAlgorithmES256 = "ES256"
+	JWTAlgorithmEdDSA = "EdDSA"
+)
+
+// JWTKey is a key that signs and verifies tokens.
+// A key signs the tokens from ActiveFrom until the next key becomes active,
+// then it only verifies them for the grace period of the provider.
+type JWTKey struct {
+	ID         string
+	Algorithm  string
+	ActiveFrom time.Time
+
+	method          jwt.SigningMethod
+	signingKey      any
+	verificationKey any
+}
+
+// jwtKeyConfig is a signing key as configured in JWT_SIGNING_KEYS.
+// Key is the PEM encoded private key, or the secret for HS256,
+// KeyFile is read instead when Key is empty.
+type jwtKeyConfig struct {
+	Kid        string    `json:"kid"`
+	Alg        string    `json:"alg"`
+	Key        string    `json:"key"`
+	KeyFile    string    `json:"keyFile"`
+	ActiveFrom time.Time `json:"activeFrom"`
+}
+
+// NewJWTKey creates a key from its private key material, PEM encoded for the
+// asymmetric algorithms and the raw secret for HS256
+func NewJWTKey(id string, algorithm string, material []byte, activeFrom time.Time) (*JWTKey, error) {
+	key := &JWTKey{ID: id, Algorithm: algorithm, ActiveFrom: activeFrom}
+	switch algorithm {
+	case JWTAlgorithmHS256:
+		if len(material) == 0 {
+			return nil, fmt.Errorf("jwt key %q: empty secret", id)
+		}
+		key.method = jwt.SigningMethodHS256
+		key.signingKey = material
+		key.verificationKey = material
+	case JWTAlgorithmRS256:
+		private, err := jwt.ParseRSAPrivateKeyFromPEM(material)
+		if err != nil {
+			return nil, fmt.Errorf("jwt key %q: %w", id, err)
+		}
+		key.method = jwt.SigningMethodRS256
+		key.signingKey = private
+		key.verificationKey = &private.PublicKey
+	case JWTAlgorithmES256:
+		private, err := jwt.ParseECPrivateKeyFromPEM(material)
+		if err != nil {
+			return nil, fmt.Errorf("jwt key %q: %w", id, err)
+		}
+		if private.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("jwt key %q: ES256 requires a P-256 key", id)
+		}
+		key.method = jwt.SigningMethodES256
+		key.signingKey = private
+		key.verificationKey = &private.PublicKey
+	case JWTAlgorithmEdDSA:
+		private, err := jwt.ParseEdPrivateKeyFromPEM(material)
+		if err != nil {
+			return nil, fmt.Errorf("jwt key %q: %w", id, err)
+		}
+		edPrivate, ok := private.(ed25519.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("jwt key %q: EdDSA requires an Ed25519 key", id)
+		}
+		key.method = jwt.SigningMethodEdDSA
+		key.signingKey = edPrivate
+		key.verificationKey = edPrivate.Public()
+	default:
+		return nil, fmt.Errorf("jwt key %q: unsupported algorithm %q", id, algorithm)
+	}
+	return key, nil
+}
+
+// ParseJWTKeys parses the signing keys configured as a JSON array of
+// {"kid", "alg", "key" or "keyFile", "activeFrom"}. The algorithm defaults to
+// the given one. An empty configuration returns no keys, which is only valid
+// for HS256 since the secret key is used then.
+func ParseJWTKeys(raw string, defaultAlgorithm string) ([]JWTKey, error) {
+	if raw == "" {
+		if defaultAlgorithm != "" && defaultAlgorithm != JWTAlgorithmHS256 {
+			return nil, fmt.Errorf("jwt signing keys are required for %s", defaultAlgorithm)
+		}
+		return nil, nil
+	}
+
+	var configs []jwtKeyConfig
+	if err := json.Unmarshal([]byte(raw), &configs); err != nil {
+		return nil, fmt.Errorf("invalid jwt signing keys: %w", err)
+	}
+
+	keys := make([]JWTKey, 0, len(configs))
+	seen := make(map[string]bool, len(configs))
+	for _, config := range configs {
+		if config.Kid == "" || seen[config.Kid] {
+			return nil, fmt.Errorf("jwt signing keys need a unique kid, got %q", config.Kid)
+		}
+		seen[config.Kid] = true
+
+		material := []byte(config.Key)
+		if config.Key == "" && config.KeyFile != "" {
+			data, err := os.ReadFile(config.KeyFile)
+			if err != nil {
+				return nil, fmt.Errorf("jwt key %q: %w", config.Kid, err)
+			}
+			material = data
+		}
+		algorithm := config.Alg
+		if algorithm == "" {
+			algorithm = defaultAlgorithm
+		}
+		key, err := NewJWTKey(config.Kid, algorithm, material, config.ActiveFrom)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, *key)
+	}
+	return keys, nil
+}
+
+// sortJWTKeys sorts the keys by activation time, the order of rotation
+func sortJWTKeys(keys []JWTKey) {
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i].ActiveFrom.Before(keys[j].ActiveFrom)
+	})
+}
+
+// publicJWK returns the public JSON Web Key of the key,
+// false for symmetric keys, which must never be published
+func (k *JWTKey) publicJWK() (authdtos.JWK, bool) {
+	jwk := authdtos.JWK{Use: "sig", Alg: k.Algorithm, Kid: k.ID}
+	switch public := k.verificationKey.(type) {
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+	case *ecdsa.PublicKey:
+		size := (public.Curve.Params().BitSize + 7) / 8
+		jwk.Kty = "EC"
+		jwk.Crv = public.Curve.Params().Name
+		jwk.X = base64.RawURLEncoding.EncodeToString(public.X.FillBytes(make([]byte, size)))
+		jwk.Y = base64.RawURLEncoding.EncodeToString(public.Y.FillBytes(make([]byte, size)))
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(public)
+	default:
+		return authdtos.JWK{}, false
+	}
+	return jwk, true
+}
@@ -0,0 +1,101 @@
+package providers
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newPEMKey generates a PKCS#8 PEM encoded private key for the algorithm
+func newPEMKey(t *testing.T, algorithm string) string {
+	t.Helper()
+	var private crypto.PrivateKey
+	var err error
+	switch algorithm {
+	case JWTAlgorithmRS256:
+		private, err = rsa.GenerateKey(rand.Reader, 2048)
+	case JWTAlgorithmES256:
+		private, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case JWTAlgorithmEdDSA:
+		_, private, err = ed25519.GenerateKey(rand.Reader)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+// rawJWTKeys encodes signing keys as configured in JWT_SIGNING_KEYS
+func rawJWTKeys(t *testing.T, keys ...jwtKeyConfig) string {
+	t.Helper()
+	raw, err := json.Marshal(keys)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(raw)
+}
+
+func TestParseJWTKeys(t *testing.T) {
+	assert := assert.New(t)
+
+	activeFrom := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	keys, err := ParseJWTKeys(rawJWTKeys(t,
+		jwtKeyConfig{Kid: "rsa", Key: newPEMKey(t, JWTAlgorithmRS256), ActiveFrom: activeFrom},
+		jwtKeyConfig{Kid: "ec", Alg: JWTAlgorithmES256, Key: newPEMKey(t, JWTAlgorithmES256)},
+		jwtKeyConfig{Kid: "ed", Alg: JWTAlgorithmEdDSA, Key: newPEMKey(t, JWTAlgorithmEdDSA)},
+		jwtKeyConfig{Kid: "hmac", Alg: JWTAlgorithmHS256, Key: "secret"},
+	), JWTAlgorithmRS256)
+
+	assert.NoError(err)
+	assert.Len(keys, 4)
+	assert.Equal(JWTAlgorithmRS256, keys[0].Algorithm)
+	assert.Equal(activeFrom, keys[0].ActiveFrom)
+
+	// Only the public keys are published
+	for _, key := range keys[:3] {
+		jwk, ok := key.publicJWK()
+		assert.True(ok)
+		assert.Equal(key.ID, jwk.Kid)
+		assert.Equal("sig", jwk.Use)
+	}
+	_, ok := keys[3].publicJWK()
+	assert.False(ok)
+}
+
+func TestParseJWTKeys_Invalid(t *testing.T) {
+	rsaKey := newPEMKey(t, JWTAlgorithmRS256)
+
+	tests := []struct {
+		name      string
+		raw       string
+		algorithm string
+	}{
+		{name: "asymmetric algorithm without keys", raw: "", algorithm: JWTAlgorithmRS256},
+		{name: "invalid JSON", raw: "not json", algorithm: JWTAlgorithmRS256},
+		{name: "missing kid", raw: rawJWTKeys(t, jwtKeyConfig{Key: rsaKey}), algorithm: JWTAlgorithmRS256},
+		{name: "duplicated kid", raw: rawJWTKeys(t, jwtKeyConfig{Kid: "a", Key: rsaKey}, jwtKeyConfig{Kid: "a", Key: rsaKey}), algorithm: JWTAlgorithmRS256},
+		{name: "key of another algorithm", raw: rawJWTKeys(t, jwtKeyConfig{Kid: "a", Key: rsaKey}), algorithm: JWTAlgorithmES256},
+		{name: "unsupported algorithm", raw: rawJWTKeys(t, jwtKeyConfig{Kid: "a", Key: rsaKey}), algorithm: "none"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseJWTKeys(tt.raw, tt.algorithm)
+			assert.Error(t, err)
+		})
+	}
+}
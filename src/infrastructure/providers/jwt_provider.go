@@ -2,9 +2,13 @@ package providers
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
 	"time"
 
 	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
 	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
@@ -20,9 +24,15 @@ type Config struct {
 	AccessTTL  time.Duration
 	RefreshTTL time.Duration
 	ClockSkew  time.Duration
+	// Keys are the signing keys sorted by activation time
+	Keys []JWTKey
+	// KeyGracePeriod is the time a key keeps verifying tokens after the next key becomes active
+	KeyGracePeriod time.Duration
 }
 
-// JWTProvider is the implementation of the JWT provider
+// JWTProvider is the implementation of the JWT provider.
+// Tokens are signed with the active key and carry its ID in the kid header,
+// so keys can be rotated without invalidating the tokens already issued.
 type JWTProvider struct {
 	config Config
 }
@@ -39,9 +49,35 @@ func (jp *JWTProvider) Setup(Secret string, Issuer string, Audience string,
 		AccessTTL:  time.Duration(AccessTTL) * time.Second,
 		RefreshTTL: time.Duration(RefreshTTL) * time.Second,
 		ClockSkew:  time.Duration(ClockSkew) * time.Second,
+		// Without signing keys the secret signs with HS256, without kid
+		Keys: []JWTKey{{
+			Algorithm:       JWTAlgorithmHS256,
+			method:          jwt.SigningMethodHS256,
+			signingKey:      []byte(Secret),
+			verificationKey: []byte(Secret),
+		}},
 	}
 }
 
+// SetupKeys replaces the secret with the signing keys configured in rawKeys,
+// see ParseJWTKeys. Without signing keys the secret is kept.
+func (jp *JWTProvider) SetupKeys(rawKeys string, algorithm string, gracePeriod int64) *application_errors.ApplicationError {
+	keys, err := ParseJWTKeys(rawKeys, algorithm)
+	if err != nil {
+		return application_errors.NewApplicationError(
+			status.ApplicationInitializationError,
+			messages.MessageKeysInstance.SOMETHING_WENT_WRONG,
+			err.Error(),
+		)
+	}
+	jp.config.KeyGracePeriod = time.Duration(gracePeriod) * time.Second
+	if len(keys) > 0 {
+		sortJWTKeys(keys)
+		jp.config.Keys = keys
+	}
+	return nil
+}
+
 // NewJWTProvider creates a new JWT provider
 func NewJWTProvider() *JWTProvider {
 	return &JWTProvider{}
@@ -51,23 +87,19 @@ func NewJWTProvider() *JWTProvider {
 func (jp *JWTProvider) GenerateAccessToken(ctx context.Context,
 	subject string,
 	claimsMap authcontracts.JWTCLaims) (string, time.Time, *application_errors.ApplicationError) {
-	now := time.Now().Add(jp.config.ClockSkew)
-	exp := now.Add(jp.config.AccessTTL)
-	claims := jwt.MapClaims{
-		"iss": jp.config.Issuer,
-		"aud": jp.config.Audience,
-		"sub": subject,
-		"iat": now.Unix(),
-		"nbf": now.Add(-jp.config.ClockSkew).Unix(),
-		"exp": exp.Unix(),
-		"typ": "access",
+	claims, exp, err := jp.newClaims(subject, "access", jp.config.AccessTTL)
+	if err != nil {
+		return "", time.Time{}, application_errors.NewApplicationError(
+			status.InternalError,
+			messages.MessageKeysInstance.SOMETHING_WENT_WRONG,
+			err.Error(),
+		)
 	}
 
 	for k, v := range claimsMap {
 		claims[k] = v
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signedToken, err := token.SignedString(jp.config.Secret)
+	signedToken, err := jp.sign(claims)
 	if err != nil {
 		return "", time.Time{}, application_errors.NewApplicationError(
 			status.InternalError,
@@ -81,19 +113,15 @@ func (jp *JWTProvider) GenerateAccessToken(ctx context.Context,
 // GenerateRefreshToken generates a refresh token
 func (jp *JWTProvider) GenerateRefreshToken(ctx context.Context,
 	subject string) (string, time.Time, *application_errors.ApplicationError) {
-	now := time.Now().Add(jp.config.ClockSkew)
-	exp := now.Add(jp.config.RefreshTTL)
-	claims := jwt.MapClaims{
-		"iss": jp.config.Issuer,
-		"aud": jp.config.Audience,
-		"sub": subject,
-		"iat": now.Unix(),
-		"nbf": now.Add(-jp.config.ClockSkew).Unix(),
-		"exp": exp.Unix(),
-		"typ": "refresh",
+	claims, exp, err := jp.newClaims(subject, "refresh", jp.config.RefreshTTL)
+	if err != nil {
+		return "", time.Time{}, application_errors.NewApplicationError(
+			status.InternalError,
+			messages.MessageKeysInstance.SOMETHING_WENT_WRONG,
+			err.Error(),
+		)
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signedToken, err := token.SignedString(jp.config.Secret)
+	signedToken, err := jp.sign(claims)
 	if err == nil {
 		return signedToken, exp, nil
 	}
@@ -107,10 +135,13 @@ func (jp *JWTProvider) GenerateRefreshToken(ctx context.Context,
 // ParseTokenAndValidate parses and validates a token
 func (jp *JWTProvider) ParseTokenAndValidate(tokenString string) (authcontracts.JWTCLaims, *application_errors.ApplicationError) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, jwt.ErrTokenMalformed
+		kid, _ := token.Header["kid"].(string)
+		key := jp.verificationKey(kid, time.Now())
+		// The algorithm must be the one of the key, e.g. a public key is never an HMAC secret
+		if key == nil || token.Method.Alg() != key.method.Alg() {
+			return nil, jwt.ErrTokenUnverifiable
 		}
-		return []byte(jp.config.Secret), nil
+		return key.verificationKey, nil
 	}, jwt.WithAudience(jp.config.Audience), jwt.WithIssuer(jp.config.Issuer), jwt.WithLeeway(jp.config.ClockSkew))
 	if err != nil {
 		// TODO: handle token validation and parsing errors
@@ -136,6 +167,99 @@ func (jp *JWTProvider) ParseTokenAndValidate(tokenString string) (authcontracts.
 	}
 }
 
+// PublicKeys returns the public keys that verify the tokens at the given time.
+// Keys that are not active yet are published too, so verifiers know them
+// before they sign any token.
+func (jp *JWTProvider) PublicKeys(at time.Time) authdtos.JWKS {
+	jwks := authdtos.JWKS{Keys: []authdtos.JWK{}}
+	for i := range jp.config.Keys {
+		if jp.retired(i, at) {
+			continue
+		}
+		if jwk, ok := jp.config.Keys[i].publicJWK(); ok {
+			jwks.Keys = append(jwks.Keys, jwk)
+		}
+	}
+	return jwks
+}
+
+// sign signs the claims with the active key
+func (jp *JWTProvider) sign(claims jwt.MapClaims) (string, error) {
+	key := jp.signingKey(time.Now())
+	if key == nil {
+		return "", errors.New("no active jwt signing key")
+	}
+	token := jwt.NewWithClaims(key.method, claims)
+	if key.ID != "" {
+		token.Header["kid"] = key.ID
+	}
+	return token.SignedString(key.signingKey)
+}
+
+// signingKey returns the last key that became active at the given time
+func (jp *JWTProvider) signingKey(at time.Time) *JWTKey {
+	var active *JWTKey
+	for i := range jp.config.Keys {
+		if jp.config.Keys[i].ActiveFrom.After(at) {
+			break
+		}
+		active = &jp.config.Keys[i]
+	}
+	return active
+}
+
+// verificationKey returns the key with the given ID unless it is retired
+func (jp *JWTProvider) verificationKey(kid string, at time.Time) *JWTKey {
+	for i := range jp.config.Keys {
+		if jp.config.Keys[i].ID == kid && !jp.retired(i, at) {
+			return &jp.config.Keys[i]
+		}
+	}
+	return nil
+}
+
+// retired reports whether the grace period of the key at the given index,
+// which starts when the next key becomes active, is over
+func (jp *JWTProvider) retired(index int, at time.Time) bool {
+	if index+1 >= len(jp.config.Keys) {
+		return false
+	}
+	return at.After(jp.config.Keys[index+1].ActiveFrom.Add(jp.config.KeyGracePeriod))
+}
+
+// newClaims returns the registered claims of a token of the given type.
+// iat is the real issue time, to the microsecond, so it can be compared with
+// revocation times within the same second; the clock skew is only tolerated
+// on nbf and on validation.
+// jti makes every token unique, even when issued within the same second.
+func (jp *JWTProvider) newClaims(subject string, typ string, ttl time.Duration) (jwt.MapClaims, time.Time, error) {
+	jti, err := newTokenID()
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+	now := time.Now()
+	exp := now.Add(ttl)
+	return jwt.MapClaims{
+		"iss": jp.config.Issuer,
+		"aud": jp.config.Audience,
+		"sub": subject,
+		"iat": float64(now.UnixMicro()) / 1e6,
+		"nbf": now.Add(-jp.config.ClockSkew).Unix(),
+		"exp": exp.Unix(),
+		"typ": typ,
+		"jti": jti,
+	}, exp, nil
+}
+
+// newTokenID returns a random token identifier
+func newTokenID() (string, error) {
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(id), nil
+}
+
 // JWTProviderInstance is the instance of the JWT provider
 var JWTProviderInstance *JWTProvider
 
@@ -7,6 +7,7 @@ import (
 
 	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
 
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -57,6 +58,11 @@ func TestJWTProvider_GenerateRefreshToken(t *testing.T) {
 	assert.NotEmpty(token)
 	assert.True(exp.After(time.Now()))
 	assert.True(time.Until(exp) <= jwtProvider.config.RefreshTTL+jwtProvider.config.ClockSkew)
+
+	// Tokens issued within the same second are still unique
+	other, _, err := jwtProvider.GenerateRefreshToken(ctx, subject)
+	assert.Nil(err)
+	assert.NotEqual(token, other)
 }
 
 func TestJWTProvider_ParseTokenAndValidate(t *testing.T) {
@@ -86,6 +92,9 @@ func TestJWTProvider_ParseTokenAndValidate(t *testing.T) {
 	assert.NotNil(parsedClaims)
 	assert.Equal(subject, parsedClaims["sub"])
 	assert.Equal("admin", parsedClaims["role"])
+	// Every token can be revoked by its ID, iat is the real issue time
+	assert.NotEmpty(parsedClaims["jti"])
+	assert.LessOrEqual(parsedClaims["iat"], float64(time.Now().UnixMicro())/1e6)
 }
 
 func TestJWTProvider_ParseTokenAndValidate_InvalidToken(t *testing.T) {
@@ -118,3 +127,100 @@ func TestJWTProvider_ParseTokenAndValidate_InvalidToken(t *testing.T) {
 	assert.IsType(&application_errors.ApplicationError{}, err)
 	assert.Nil(parsedClaims)
 }
+
+func newRotatingJWTProvider(raw string, algorithm string, gracePeriod int64) *JWTProvider {
+	jwtProvider := NewJWTProvider()
+	jwtProvider.Setup(
+		"secret",
+		"test-issuer",
+		"test-audience",
+		3600,
+		86400,
+		60,
+	)
+	if err := jwtProvider.SetupKeys(raw, algorithm, gracePeriod); err != nil {
+		panic(err.ToError())
+	}
+	return jwtProvider
+}
+
+func TestJWTProvider_AsymmetricAlgorithms(t *testing.T) {
+	for _, algorithm := range []string{JWTAlgorithmRS256, JWTAlgorithmES256, JWTAlgorithmEdDSA} {
+		t.Run(algorithm, func(t *testing.T) {
+			assert := assert.New(t)
+
+			raw := rawJWTKeys(t, jwtKeyConfig{Kid: "key-1", Key: newPEMKey(t, algorithm)})
+			jwtProvider := newRotatingJWTProvider(raw, algorithm, 3600)
+
+			token, _, err := jwtProvider.GenerateAccessToken(context.Background(), "test-subject", nil)
+			assert.Nil(err)
+			parsed, _, parseErr := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+			assert.NoError(parseErr)
+			assert.Equal(algorithm, parsed.Method.Alg())
+			assert.Equal("key-1", parsed.Header["kid"])
+
+			claims, err := jwtProvider.ParseTokenAndValidate(token)
+			assert.Nil(err)
+			assert.Equal("test-subject", claims["sub"])
+
+			jwks := jwtProvider.PublicKeys(time.Now())
+			assert.Len(jwks.Keys, 1)
+			assert.Equal("key-1", jwks.Keys[0].Kid)
+			assert.Equal(algorithm, jwks.Keys[0].Alg)
+
+			// Another provider with the same kid but a different key rejects the token
+			other := newRotatingJWTProvider(rawJWTKeys(t, jwtKeyConfig{Kid: "key-1", Key: newPEMKey(t, algorithm)}), algorithm, 3600)
+			_, err = other.ParseTokenAndValidate(token)
+			assert.NotNil(err)
+		})
+	}
+}
+
+func TestJWTProvider_KeyRotation(t *testing.T) {
+	assert := assert.New(t)
+
+	now := time.Now()
+	oldKey := jwtKeyConfig{Kid: "old", Key: newPEMKey(t, JWTAlgorithmES256), ActiveFrom: now.Add(-48 * time.Hour)}
+	newKey := jwtKeyConfig{Kid: "new", Key: newPEMKey(t, JWTAlgorithmES256), ActiveFrom: now.Add(time.Hour)}
+
+	// Before the rotation the old key signs and the next one is already published
+	jwtProvider := newRotatingJWTProvider(rawJWTKeys(t, newKey, oldKey), JWTAlgorithmES256, 3600)
+	oldToken, _, err := jwtProvider.GenerateAccessToken(context.Background(), "test-subject", nil)
+	assert.Nil(err)
+	assert.Equal("old", jwtProvider.signingKey(now).ID)
+	assert.Len(jwtProvider.PublicKeys(now).Keys, 2)
+
+	// After the rotation the new key signs and the old one verifies during the grace period
+	rotation := newKey.ActiveFrom
+	assert.Equal("new", jwtProvider.signingKey(rotation).ID)
+	assert.NotNil(jwtProvider.verificationKey("old", rotation.Add(30*time.Minute)))
+	assert.Len(jwtProvider.PublicKeys(rotation.Add(30*time.Minute)).Keys, 2)
+
+	// Then the old key is retired
+	assert.Nil(jwtProvider.verificationKey("old", rotation.Add(2*time.Hour)))
+	jwks := jwtProvider.PublicKeys(rotation.Add(2 * time.Hour))
+	assert.Len(jwks.Keys, 1)
+	assert.Equal("new", jwks.Keys[0].Kid)
+
+	// Tokens of the old key stay valid while it is not retired
+	_, err = jwtProvider.ParseTokenAndValidate(oldToken)
+	assert.Nil(err)
+}
+
+func TestJWTProvider_RejectsAlgorithmConfusion(t *testing.T) {
+	assert := assert.New(t)
+
+	raw := rawJWTKeys(t, jwtKeyConfig{Kid: "key-1", Key: newPEMKey(t, JWTAlgorithmRS256)})
+	jwtProvider := newRotatingJWTProvider(raw, JWTAlgorithmRS256, 3600)
+
+	// A token signed with HS256 under the kid of an RSA key is rejected
+	claims, _, _ := jwtProvider.newClaims("test-subject", "access", time.Hour)
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token.Header["kid"] = "key-1"
+	signed, signErr := token.SignedString([]byte("secret"))
+	assert.NoError(signErr)
+
+	_, err := jwtProvider.ParseTokenAndValidate(signed)
+	assert.NotNil(err)
+	assert.Empty(newRotatingJWTProvider("", JWTAlgorithmHS256, 0).PublicKeys(time.Now()).Keys)
+}
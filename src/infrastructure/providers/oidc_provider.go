@@ -0,0 +1,465 @@
+package providers
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	oidcDefaultScope   = "openid email profile"
+	oidcHTTPTimeout    = 10 * time.Second
+	oidcClockSkew      = time.Minute
+	oidcMaxResponseLen = 1 << 20
+)
+
+// OIDCProviderConfig is an identity provider as configured in OIDC_PROVIDERS
+type OIDCProviderConfig struct {
+	Name         string   `json:"name"`
+	Issuer       string   `json:"issuer"`
+	ClientID     string   `json:"clientId"`
+	ClientSecret string   `json:"clientSecret"`
+	RedirectURL  string   `json:"redirectUrl"`
+	Scopes       []string `json:"scopes"`
+}
+
+// oidcDiscovery is the part of the discovery document of an issuer in use
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// oidcIssuer is a configured identity provider with its discovery document
+// and signing keys, both fetched on first use
+type oidcIssuer struct {
+	config    OIDCProviderConfig
+	discovery *oidcDiscovery
+	keys      map[string]any
+}
+
+// oidcIDTokenClaims are the claims of an ID token in use
+type oidcIDTokenClaims struct {
+	jwt.RegisteredClaims
+	Email         string `json:"email"`
+	EmailVerified any    `json:"email_verified"`
+	Name          string `json:"name"`
+	Nonce         string `json:"nonce"`
+}
+
+// OIDCProvider is the OpenID Connect relying party of the configured identity
+// providers. It uses the authorization code flow with PKCE and verifies the ID
+// tokens with the keys published by the issuers.
+type OIDCProvider struct {
+	client  *http.Client
+	mu      sync.Mutex
+	issuers map[string]*oidcIssuer
+}
+
+var _ authcontracts.IOIDCProvider = (*OIDCProvider)(nil)
+
+// ParseOIDCProviders parses the identity providers configured as a JSON array
+// of {"name", "issuer", "clientId", "clientSecret", "redirectUrl", "scopes"}.
+// An empty configuration returns no providers.
+func ParseOIDCProviders(raw string) ([]OIDCProviderConfig, error) {
+	if raw == "" {
+		return nil, nil
+	}
+
+	var configs []OIDCProviderConfig
+	if err := json.Unmarshal([]byte(raw), &configs); err != nil {
+		return nil, fmt.Errorf("invalid oidc providers: %w", err)
+	}
+
+	seen := make(map[string]bool, len(configs))
+	for _, config := range configs {
+		if config.Name == "" || seen[config.Name] {
+			return nil, fmt.Errorf("oidc providers need a unique name, got %q", config.Name)
+		}
+		seen[config.Name] = true
+		if config.Issuer == "" || config.ClientID == "" || config.RedirectURL == "" {
+			return nil, fmt.Errorf("oidc provider %q: issuer, clientId and redirectUrl are required", config.Name)
+		}
+	}
+	return configs, nil
+}
+
+// Setup replaces the identity providers with the ones configured in rawProviders,
+// see ParseOIDCProviders
+func (op *OIDCProvider) Setup(rawProviders string) *application_errors.ApplicationError {
+	configs, err := ParseOIDCProviders(rawProviders)
+	if err != nil {
+		return application_errors.NewApplicationError(
+			status.ApplicationInitializationError,
+			messages.MessageKeysInstance.SOMETHING_WENT_WRONG,
+			err.Error(),
+		)
+	}
+
+	issuers := make(map[string]*oidcIssuer, len(configs))
+	for _, config := range configs {
+		issuers[config.Name] = &oidcIssuer{config: config}
+	}
+
+	op.mu.Lock()
+	defer op.mu.Unlock()
+	op.issuers = issuers
+	return nil
+}
+
+// AuthorizationURL returns the URL of the authorization endpoint of the provider
+func (op *OIDCProvider) AuthorizationURL(ctx context.Context, provider string, state string, nonce string, codeVerifier string) (string, *application_errors.ApplicationError) {
+	issuer, appErr := op.issuer(ctx, provider)
+	if appErr != nil {
+		return "", appErr
+	}
+
+	scope := oidcDefaultScope
+	if len(issuer.config.Scopes) > 0 {
+		scope = strings.Join(issuer.config.Scopes, " ")
+	}
+	challenge := sha256.Sum256([]byte(codeVerifier))
+
+	query := url.Values{}
+	query.Set("response_type", "code")
+	query.Set("client_id", issuer.config.ClientID)
+	query.Set("redirect_uri", issuer.config.RedirectURL)
+	query.Set("scope", scope)
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:]))
+	query.Set("code_challenge_method", "S256")
+
+	separator := "?"
+	if strings.Contains(issuer.discovery.AuthorizationEndpoint, "?") {
+		separator = "&"
+	}
+	return issuer.discovery.AuthorizationEndpoint + separator + query.Encode(), nil
+}
+
+// Exchange exchanges the code at the token endpoint of the provider and
+// verifies the ID token of the response
+func (op *OIDCProvider) Exchange(ctx context.Context, provider string, code string, codeVerifier string) (*authdtos.OIDCClaims, *application_errors.ApplicationError) {
+	issuer, appErr := op.issuer(ctx, provider)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", issuer.config.RedirectURL)
+	form.Set("client_id", issuer.config.ClientID)
+	form.Set("code_verifier", codeVerifier)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, issuer.discovery.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, oidcProviderError(err)
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+	if issuer.config.ClientSecret != "" {
+		request.SetBasicAuth(url.QueryEscape(issuer.config.ClientID), url.QueryEscape(issuer.config.ClientSecret))
+	}
+
+	var tokens struct {
+		IDToken string `json:"id_token"`
+	}
+	if err := op.do(request, &tokens); err != nil {
+		return nil, oidcInvalidToken(err)
+	}
+	if tokens.IDToken == "" {
+		return nil, oidcInvalidToken(errors.New("token response without id_token"))
+	}
+
+	claims, err := op.verifyIDToken(ctx, provider, issuer, tokens.IDToken)
+	if err != nil {
+		return nil, oidcInvalidToken(err)
+	}
+	return claims, nil
+}
+
+// verifyIDToken verifies the signature, issuer, audience and expiration of
+// the ID token, the keys of the issuer are fetched again for an unknown kid
+func (op *OIDCProvider) verifyIDToken(ctx context.Context, provider string, issuer *oidcIssuer, idToken string) (*authdtos.OIDCClaims, error) {
+	refreshed := false
+	keyFunc := func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := lookupOIDCKey(issuer.keys, kid)
+		if !ok && !refreshed {
+			refreshed = true
+			keys, err := op.fetchKeys(ctx, issuer.discovery.JWKSURI)
+			if err != nil {
+				return nil, err
+			}
+			op.mu.Lock()
+			if current, found := op.issuers[provider]; found {
+				current.keys = keys
+			}
+			op.mu.Unlock()
+			issuer.keys = keys
+			key, ok = lookupOIDCKey(keys, kid)
+		}
+		if !ok {
+			return nil, fmt.Errorf("unknown signing key %q", kid)
+		}
+		return key, nil
+	}
+
+	var claims oidcIDTokenClaims
+	if _, err := jwt.ParseWithClaims(idToken, &claims, keyFunc,
+		jwt.WithValidMethods([]string{JWTAlgorithmRS256, JWTAlgorithmES256, JWTAlgorithmEdDSA}),
+		jwt.WithIssuer(issuer.discovery.Issuer),
+		jwt.WithAudience(issuer.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(oidcClockSkew),
+	); err != nil {
+		return nil, err
+	}
+	if claims.Subject == "" {
+		return nil, errors.New("id token without subject")
+	}
+
+	emailVerified := false
+	switch verified := claims.EmailVerified.(type) {
+	case bool:
+		emailVerified = verified
+	case string:
+		emailVerified = verified == "true"
+	}
+
+	return &authdtos.OIDCClaims{
+		Subject:       claims.Subject,
+		Email:         strings.ToLower(strings.TrimSpace(claims.Email)),
+		EmailVerified: emailVerified,
+		Name:          claims.Name,
+		Nonce:         claims.Nonce,
+	}, nil
+}
+
+// issuer returns the configured provider, fetching its discovery document
+// and keys on first use
+func (op *OIDCProvider) issuer(ctx context.Context, provider string) (*oidcIssuer, *application_errors.ApplicationError) {
+	op.mu.Lock()
+	issuer, ok := op.issuers[provider]
+	var cached oidcIssuer
+	if ok {
+		cached = *issuer
+	}
+	op.mu.Unlock()
+	if !ok {
+		return nil, application_errors.NewApplicationError(
+			status.NotFound,
+			messages.MessageKeysInstance.OIDC_PROVIDER_NOT_FOUND,
+			fmt.Sprintf("oidc provider %q is not configured", provider),
+		)
+	}
+	if cached.discovery != nil {
+		return &cached, nil
+	}
+
+	discovery, err := op.discover(ctx, cached.config.Issuer)
+	if err != nil {
+		return nil, oidcProviderError(err)
+	}
+	keys, err := op.fetchKeys(ctx, discovery.JWKSURI)
+	if err != nil {
+		return nil, oidcProviderError(err)
+	}
+
+	op.mu.Lock()
+	issuer.discovery = discovery
+	issuer.keys = keys
+	cached = *issuer
+	op.mu.Unlock()
+	return &cached, nil
+}
+
+// discover fetches the discovery document of the issuer
+func (op *OIDCProvider) discover(ctx context.Context, issuerURL string) (*oidcDiscovery, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		strings.TrimSuffix(issuerURL, "/")+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var discovery oidcDiscovery
+	if err := op.do(request, &discovery); err != nil {
+		return nil, err
+	}
+	if strings.TrimSuffix(discovery.Issuer, "/") != strings.TrimSuffix(issuerURL, "/") {
+		return nil, fmt.Errorf("discovered issuer %q does not match %q", discovery.Issuer, issuerURL)
+	}
+	if discovery.AuthorizationEndpoint == "" || discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, fmt.Errorf("incomplete discovery document of %q", issuerURL)
+	}
+	return &discovery, nil
+}
+
+// fetchKeys fetches the signing keys published by the issuer, keys of
+// unsupported types are skipped
+func (op *OIDCProvider) fetchKeys(ctx context.Context, jwksURI string) (map[string]any, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, jwksURI, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var jwks authdtos.JWKS
+	if err := op.do(request, &jwks); err != nil {
+		return nil, err
+	}
+
+	keys := make(map[string]any, len(jwks.Keys))
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		key, err := parsePublicJWK(jwk)
+		if err != nil {
+			continue
+		}
+		keys[jwk.Kid] = key
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no supported signing keys at %q", jwksURI)
+	}
+	return keys, nil
+}
+
+// do sends the request and decodes the JSON response into dest
+func (op *OIDCProvider) do(request *http.Request, dest any) error {
+	response, err := op.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, oidcMaxResponseLen))
+	if err != nil {
+		return err
+	}
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s %s: status %d", request.Method, request.URL.Redacted(), response.StatusCode)
+	}
+	return json.Unmarshal(body, dest)
+}
+
+// lookupOIDCKey returns the key with the kid, a token without kid is accepted
+// when the issuer publishes a single key
+func lookupOIDCKey(keys map[string]any, kid string) (any, bool) {
+	if key, ok := keys[kid]; ok {
+		return key, true
+	}
+	if kid == "" && len(keys) == 1 {
+		for _, key := range keys {
+			return key, true
+		}
+	}
+	return nil, false
+}
+
+// parsePublicJWK parses a public RSA, P-256 or Ed25519 JSON Web Key
+func parsePublicJWK(jwk authdtos.JWK) (any, error) {
+	decode := base64.RawURLEncoding.DecodeString
+	switch jwk.Kty {
+	case "RSA":
+		n, err := decode(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decode(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if len(n) == 0 || !exponent.IsInt64() || exponent.Int64() < 3 {
+			return nil, errors.New("invalid RSA key")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+	case "EC":
+		if jwk.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %q", jwk.Crv)
+		}
+		x, err := decode(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decode(jwk.Y)
+		if err != nil {
+			return nil, err
+		}
+		key := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !key.Curve.IsOnCurve(key.X, key.Y) {
+			return nil, errors.New("invalid EC key")
+		}
+		return key, nil
+	case "OKP":
+		if jwk.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", jwk.Crv)
+		}
+		x, err := decode(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", jwk.Kty)
+	}
+}
+
+func oidcProviderError(err error) *application_errors.ApplicationError {
+	return application_errors.NewApplicationError(
+		status.ProviderError,
+		messages.MessageKeysInstance.SOMETHING_WENT_WRONG,
+		err.Error(),
+	)
+}
+
+func oidcInvalidToken(err error) *application_errors.ApplicationError {
+	return application_errors.NewApplicationError(
+		status.Unauthorized,
+		messages.MessageKeysInstance.OIDC_INVALID_TOKEN,
+		err.Error(),
+	)
+}
+
+// NewOIDCProvider creates a new OIDC provider without identity providers
+func NewOIDCProvider(client *http.Client) *OIDCProvider {
+	if client == nil {
+		client = &http.Client{Timeout: oidcHTTPTimeout}
+	}
+	return &OIDCProvider{client: client, issuers: map[string]*oidcIssuer{}}
+}
+
+// OIDCProviderInstance is the instance of the OIDC provider
+var OIDCProviderInstance *OIDCProvider
+
+func init() {
+	OIDCProviderInstance = NewOIDCProvider(nil)
+}
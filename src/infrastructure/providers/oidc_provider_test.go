@@ -0,0 +1,247 @@
+package providers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	oidcTestClientID = "test-client"
+	oidcTestVerifier = "test-verifier"
+	oidcTestCode     = "test-code"
+)
+
+// oidcTestServer is a stub identity provider that issues an ID token for
+// oidcTestCode when the PKCE verifier matches
+type oidcTestServer struct {
+	*httptest.Server
+	key    *rsa.PrivateKey
+	claims jwt.MapClaims
+	kid    string
+}
+
+func newOIDCTestServer(t *testing.T) *oidcTestServer {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := &oidcTestServer{key: key, kid: "key-1"}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 server.URL,
+			"authorization_endpoint": server.URL + "/authorize",
+			"token_endpoint":         server.URL + "/token",
+			"jwks_uri":               server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(authdtos.JWKS{Keys: []authdtos.JWK{{
+			Kty: "RSA",
+			Use: "sig",
+			Alg: JWTAlgorithmRS256,
+			Kid: server.kid,
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if r.PostFormValue("code") != oidcTestCode || r.PostFormValue("code_verifier") != oidcTestVerifier {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, server.claims)
+		token.Header["kid"] = server.kid
+		signed, err := token.SignedString(key)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{"id_token": signed, "token_type": "Bearer"})
+	})
+	server.Server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	server.claims = jwt.MapClaims{
+		"iss":            server.URL,
+		"aud":            oidcTestClientID,
+		"sub":            "subject-1",
+		"email":          "Test@Example.com",
+		"email_verified": true,
+		"name":           "Test User",
+		"nonce":          "test-nonce",
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"iat":            time.Now().Unix(),
+	}
+	return server
+}
+
+func newTestOIDCProvider(t *testing.T, server *oidcTestServer) *OIDCProvider {
+	t.Helper()
+	provider := NewOIDCProvider(server.Client())
+	raw, _ := json.Marshal([]OIDCProviderConfig{{
+		Name:         "test",
+		Issuer:       server.URL,
+		ClientID:     oidcTestClientID,
+		ClientSecret: "secret",
+		RedirectURL:  "https://app.example.com/callback",
+	}})
+	if err := provider.Setup(string(raw)); err != nil {
+		t.Fatal(err)
+	}
+	return provider
+}
+
+func TestParseOIDCProviders(t *testing.T) {
+	assert := assert.New(t)
+
+	providers, err := ParseOIDCProviders("")
+	assert.NoError(err)
+	assert.Empty(providers)
+
+	providers, err = ParseOIDCProviders(`[{"name":"google","issuer":"https://accounts.google.com","clientId":"id","redirectUrl":"https://app/cb","scopes":["openid","email"]}]`)
+	assert.NoError(err)
+	assert.Len(providers, 1)
+	assert.Equal("google", providers[0].Name)
+	assert.Equal([]string{"openid", "email"}, providers[0].Scopes)
+
+	_, err = ParseOIDCProviders(`[{"name":"a","issuer":"https://a","clientId":"id","redirectUrl":"https://app/cb"},{"name":"a","issuer":"https://b","clientId":"id","redirectUrl":"https://app/cb"}]`)
+	assert.Error(err)
+
+	_, err = ParseOIDCProviders(`[{"name":"a","clientId":"id"}]`)
+	assert.Error(err)
+
+	_, err = ParseOIDCProviders(`not json`)
+	assert.Error(err)
+}
+
+func TestOIDCProvider_AuthorizationURL(t *testing.T) {
+	assert := assert.New(t)
+	server := newOIDCTestServer(t)
+	provider := newTestOIDCProvider(t, server)
+
+	authorizationURL, appErr := provider.AuthorizationURL(context.Background(), "test", "state", "nonce", oidcTestVerifier)
+	assert.Nil(appErr)
+
+	parsed, err := url.Parse(authorizationURL)
+	assert.NoError(err)
+	assert.Equal(server.URL+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+	query := parsed.Query()
+	challenge := sha256.Sum256([]byte(oidcTestVerifier))
+	assert.Equal("code", query.Get("response_type"))
+	assert.Equal(oidcTestClientID, query.Get("client_id"))
+	assert.Equal("https://app.example.com/callback", query.Get("redirect_uri"))
+	assert.Equal(oidcDefaultScope, query.Get("scope"))
+	assert.Equal("state", query.Get("state"))
+	assert.Equal("nonce", query.Get("nonce"))
+	assert.Equal(base64.RawURLEncoding.EncodeToString(challenge[:]), query.Get("code_challenge"))
+	assert.Equal("S256", query.Get("code_challenge_method"))
+}
+
+func TestOIDCProvider_UnknownProvider(t *testing.T) {
+	assert := assert.New(t)
+	server := newOIDCTestServer(t)
+	provider := newTestOIDCProvider(t, server)
+
+	_, appErr := provider.AuthorizationURL(context.Background(), "unknown", "state", "nonce", oidcTestVerifier)
+	assert.NotNil(appErr)
+	assert.Equal(status.NotFound, appErr.Code)
+}
+
+func TestOIDCProvider_Exchange(t *testing.T) {
+	assert := assert.New(t)
+	server := newOIDCTestServer(t)
+	provider := newTestOIDCProvider(t, server)
+
+	claims, appErr := provider.Exchange(context.Background(), "test", oidcTestCode, oidcTestVerifier)
+	assert.Nil(appErr)
+	assert.Equal("subject-1", claims.Subject)
+	assert.Equal("test@example.com", claims.Email)
+	assert.True(claims.EmailVerified)
+	assert.Equal("Test User", claims.Name)
+	assert.Equal("test-nonce", claims.Nonce)
+}
+
+func TestOIDCProvider_ExchangeWrongVerifier(t *testing.T) {
+	assert := assert.New(t)
+	server := newOIDCTestServer(t)
+	provider := newTestOIDCProvider(t, server)
+
+	_, appErr := provider.Exchange(context.Background(), "test", oidcTestCode, "other-verifier")
+	assert.NotNil(appErr)
+	assert.Equal(status.Unauthorized, appErr.Code)
+}
+
+func TestOIDCProvider_ExchangeRejectsInvalidTokens(t *testing.T) {
+	tests := []struct {
+		name  string
+		claim string
+		value any
+	}{
+		{name: "wrong audience", claim: "aud", value: "other-client"},
+		{name: "wrong issuer", claim: "iss", value: "https://evil.example.com"},
+		{name: "expired", claim: "exp", value: time.Now().Add(-time.Hour).Unix()},
+		{name: "without expiration", claim: "exp", value: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := assert.New(t)
+			server := newOIDCTestServer(t)
+			provider := newTestOIDCProvider(t, server)
+			if tt.value == nil {
+				delete(server.claims, tt.claim)
+			} else {
+				server.claims[tt.claim] = tt.value
+			}
+
+			_, appErr := provider.Exchange(context.Background(), "test", oidcTestCode, oidcTestVerifier)
+			assert.NotNil(appErr)
+			assert.Equal(status.Unauthorized, appErr.Code)
+		})
+	}
+}
+
+func TestOIDCProvider_ExchangeRefetchesRotatedKeys(t *testing.T) {
+	assert := assert.New(t)
+	server := newOIDCTestServer(t)
+	provider := newTestOIDCProvider(t, server)
+
+	// discovery and keys are cached on first use
+	_, appErr := provider.AuthorizationURL(context.Background(), "test", "state", "nonce", oidcTestVerifier)
+	assert.Nil(appErr)
+
+	server.kid = "key-2"
+	claims, appErr := provider.Exchange(context.Background(), "test", oidcTestCode, oidcTestVerifier)
+	assert.Nil(appErr)
+	assert.Equal("subject-1", claims.Subject)
+}
+
+func TestParsePublicJWK_RejectsUnsupportedKeys(t *testing.T) {
+	assert := assert.New(t)
+
+	_, err := parsePublicJWK(authdtos.JWK{Kty: "oct"})
+	assert.Error(err)
+	_, err = parsePublicJWK(authdtos.JWK{Kty: "EC", Crv: "P-384"})
+	assert.Error(err)
+	_, err = parsePublicJWK(authdtos.JWK{Kty: "OKP", Crv: "Ed25519", X: "AAAA"})
+	assert.Error(err)
+}
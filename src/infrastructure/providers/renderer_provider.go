@@ -51,12 +51,18 @@ type RenderOTPEmail struct {
 	RendererBase[email_models.OneTimePasswordEmailData]
 }
 
+type RenderAccountLockedEmail struct {
+	RendererBase[email_models.AccountLockedEmailData]
+}
+
 var RenderNewUserEmailInstance *RenderNewUserEmail
 var RenderResetPasswordEmailInstance *RenderResetPasswordEmail
 var RenderOTPEmailInstance *RenderOTPEmail
+var RenderAccountLockedEmailInstance *RenderAccountLockedEmail
 
 func init() {
 	RenderNewUserEmailInstance = &RenderNewUserEmail{}
 	RenderResetPasswordEmailInstance = &RenderResetPasswordEmail{}
 	RenderOTPEmailInstance = &RenderOTPEmail{}
+	RenderAccountLockedEmailInstance = &RenderAccountLockedEmail{}
 }
@@ -0,0 +1,78 @@
+package providers
+
+import (
+	"fmt"
+	"time"
+
+	contractsProviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/settings"
+)
+
+// TokenRevocationProvider stores the revoked tokens in the cache.
+//
+// Revoked token IDs are kept until the token expires. The revocation of every
+// token of a user is a watermark, the unix time in microseconds until which
+// the tokens issued to the user are invalid, kept for the refresh token TTL so it outlives every
+// token it covers. Without cache nothing is revoked.
+type TokenRevocationProvider struct {
+	cache contractsProviders.ICacheProvider
+}
+
+var _ contractsProviders.ITokenRevocationProvider = (*TokenRevocationProvider)(nil)
+
+// RevokeToken revokes the token with the given ID until it expires
+func (tp *TokenRevocationProvider) RevokeToken(tokenID string, expiresAt time.Time) *application_errors.ApplicationError {
+	ttl := time.Until(expiresAt)
+	if tp.cache == nil || ttl <= 0 {
+		return nil
+	}
+	return tp.cache.Set(revokedTokenKey(tokenID), true, ttl)
+}
+
+// IsTokenRevoked reports whether the token with the given ID was revoked
+func (tp *TokenRevocationProvider) IsTokenRevoked(tokenID string) (bool, *application_errors.ApplicationError) {
+	if tp.cache == nil {
+		return false, nil
+	}
+	var revoked bool
+	found, err := tp.cache.Get(revokedTokenKey(tokenID), &revoked)
+	if err != nil {
+		return false, err
+	}
+	return found && revoked, nil
+}
+
+// RevokeUserTokens revokes every token issued to the user until now
+func (tp *TokenRevocationProvider) RevokeUserTokens(userID uint) *application_errors.ApplicationError {
+	if tp.cache == nil {
+		return nil
+	}
+	ttl := time.Duration(settings.AppSettingsInstance.JWTRefreshTTL) * time.Second
+	return tp.cache.Set(revokedUserKey(userID), time.Now().UnixMicro(), ttl)
+}
+
+// UserTokensRevokedBefore returns the time before which the tokens issued to the user are revoked
+func (tp *TokenRevocationProvider) UserTokensRevokedBefore(userID uint) (time.Time, *application_errors.ApplicationError) {
+	if tp.cache == nil {
+		return time.Time{}, nil
+	}
+	watermark, err := tp.cache.GetInt64(revokedUserKey(userID))
+	if err != nil || watermark == 0 {
+		return time.Time{}, err
+	}
+	return time.UnixMicro(watermark), nil
+}
+
+func revokedTokenKey(tokenID string) string {
+	return "auth:revoked:token:" + tokenID
+}
+
+func revokedUserKey(userID uint) string {
+	return fmt.Sprintf("auth:revoked:user:%d", userID)
+}
+
+// NewTokenRevocationProvider creates a token revocation provider backed by the cache
+func NewTokenRevocationProvider(cache contractsProviders.ICacheProvider) *TokenRevocationProvider {
+	return &TokenRevocationProvider{cache: cache}
+}
@@ -0,0 +1,67 @@
+package providers
+
+import (
+	"testing"
+	"time"
+
+	providersmocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/providers"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenRevocationProvider_RevokeToken(t *testing.T) {
+	assert := assert.New(t)
+
+	revocationProvider := NewTokenRevocationProvider(providersmocks.NewMemoryCacheProvider())
+
+	revoked, err := revocationProvider.IsTokenRevoked("token-id")
+	assert.Nil(err)
+	assert.False(revoked)
+
+	assert.Nil(revocationProvider.RevokeToken("token-id", time.Now().Add(time.Hour)))
+	revoked, err = revocationProvider.IsTokenRevoked("token-id")
+	assert.Nil(err)
+	assert.True(revoked)
+
+	// Expired tokens do not need to be stored
+	assert.Nil(revocationProvider.RevokeToken("expired-id", time.Now().Add(-time.Hour)))
+	revoked, err = revocationProvider.IsTokenRevoked("expired-id")
+	assert.Nil(err)
+	assert.False(revoked)
+}
+
+func TestTokenRevocationProvider_RevokeUserTokens(t *testing.T) {
+	assert := assert.New(t)
+
+	revocationProvider := NewTokenRevocationProvider(providersmocks.NewMemoryCacheProvider())
+
+	revokedBefore, err := revocationProvider.UserTokensRevokedBefore(1)
+	assert.Nil(err)
+	assert.True(revokedBefore.IsZero())
+
+	// The watermark keeps the time to the microsecond
+	now := time.Now().Truncate(time.Microsecond)
+	assert.Nil(revocationProvider.RevokeUserTokens(1))
+	revokedBefore, err = revocationProvider.UserTokensRevokedBefore(1)
+	assert.Nil(err)
+	assert.False(revokedBefore.Before(now))
+
+	revokedBefore, err = revocationProvider.UserTokensRevokedBefore(2)
+	assert.Nil(err)
+	assert.True(revokedBefore.IsZero())
+}
+
+func TestTokenRevocationProvider_WithoutCache(t *testing.T) {
+	assert := assert.New(t)
+
+	revocationProvider := NewTokenRevocationProvider(nil)
+
+	assert.Nil(revocationProvider.RevokeToken("token-id", time.Now().Add(time.Hour)))
+	assert.Nil(revocationProvider.RevokeUserTokens(1))
+	revoked, err := revocationProvider.IsTokenRevoked("token-id")
+	assert.Nil(err)
+	assert.False(revoked)
+	revokedBefore, err := revocationProvider.UserTokensRevokedBefore(1)
+	assert.Nil(err)
+	assert.True(revokedBefore.IsZero())
+}
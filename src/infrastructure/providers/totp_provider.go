@@ -0,0 +1,126 @@
+package providers
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	contractsProviders "github.com/simon3640/goprojectskeleton/src/application/contracts/providers"
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+)
+
+const (
+	totpSecretSize = 20 // bytes, the size of the SHA-1 output recommended by RFC 4226
+	totpDigits     = 6
+	totpModulo     = 1000000 // 10^totpDigits
+	totpPeriod     = 30      // seconds
+	totpSkew       = 1       // time steps accepted before and after the current one
+
+	recoveryCodeAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ234567"
+	recoveryCodeLength   = 10
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TOTPProvider implements RFC 6238 time-based one-time passwords with the
+// parameters every authenticator app supports: SHA-1, 6 digits, 30 seconds.
+type TOTPProvider struct {
+	issuer string
+}
+
+var _ contractsProviders.ITOTPProvider = (*TOTPProvider)(nil)
+
+// GenerateSecret generates a new random secret
+func (tp *TOTPProvider) GenerateSecret() (string, *application_errors.ApplicationError) {
+	secret := make([]byte, totpSecretSize)
+	if _, err := rand.Read(secret); err != nil {
+		return "", application_errors.NewApplicationError(
+			status.ProviderError,
+			messages.MessageKeysInstance.SOMETHING_WENT_WRONG,
+			"failed to generate TOTP secret",
+		)
+	}
+	return totpEncoding.EncodeToString(secret), nil
+}
+
+// ProvisioningURI returns the otpauth:// URI of the secret, usually shown as a QR code
+func (tp *TOTPProvider) ProvisioningURI(secret string, accountName string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", tp.issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(totpDigits))
+	query.Set("period", fmt.Sprint(totpPeriod))
+	label := url.PathEscape(tp.issuer + ":" + accountName)
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+// ValidateCode validates the code against the time steps around the given time
+func (tp *TOTPProvider) ValidateCode(secret string, code string, at time.Time) (int64, bool) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return 0, false
+	}
+	code = strings.ReplaceAll(code, " ", "")
+	if len(code) != totpDigits {
+		return 0, false
+	}
+
+	current := at.Unix() / totpPeriod
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if subtle.ConstantTimeCompare([]byte(totpCode(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// GenerateRecoveryCodes generates codes of the form XXXXX-XXXXX
+func (tp *TOTPProvider) GenerateRecoveryCodes(count int) ([]string, *application_errors.ApplicationError) {
+	codes := make([]string, 0, count)
+	random := make([]byte, recoveryCodeLength)
+	for i := 0; i < count; i++ {
+		if _, err := rand.Read(random); err != nil {
+			return nil, application_errors.NewApplicationError(
+				status.ProviderError,
+				messages.MessageKeysInstance.SOMETHING_WENT_WRONG,
+				"failed to generate recovery codes",
+			)
+		}
+		code := make([]byte, recoveryCodeLength)
+		for j, b := range random {
+			// 256 is a multiple of the alphabet size so the modulo is unbiased
+			code[j] = recoveryCodeAlphabet[int(b)%len(recoveryCodeAlphabet)]
+		}
+		half := recoveryCodeLength / 2
+		codes = append(codes, string(code[:half])+"-"+string(code[half:]))
+	}
+	return codes, nil
+}
+
+// totpCode computes the HOTP value of RFC 4226 for the time step
+func totpCode(key []byte, step int64) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, value%totpModulo)
+}
+
+// NewTOTPProvider creates a TOTP provider, the issuer names the application in authenticator apps
+func NewTOTPProvider(issuer string) *TOTPProvider {
+	return &TOTPProvider{issuer: issuer}
+}
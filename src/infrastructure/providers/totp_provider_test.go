@@ -0,0 +1,104 @@
+package providers
+
+import (
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rfc6238Secret is the SHA-1 secret of the RFC 6238 test vectors, base32 encoded
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPProvider_ValidateCode(t *testing.T) {
+	totpProvider := NewTOTPProvider("GoProjectSkeleton")
+
+	// The last 6 digits of the RFC 6238 SHA-1 test vectors
+	cases := []struct {
+		name string
+		at   int64
+		code string
+	}{
+		{name: "T=59", at: 59, code: "287082"},
+		{name: "T=1111111109", at: 1111111109, code: "081804"},
+		{name: "T=1234567890", at: 1234567890, code: "005924"},
+		{name: "T=2000000000", at: 2000000000, code: "279037"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert := assert.New(t)
+			step, valid := totpProvider.ValidateCode(rfc6238Secret, tc.code, time.Unix(tc.at, 0))
+			assert.True(valid)
+			assert.Equal(tc.at/totpPeriod, step)
+		})
+	}
+}
+
+func TestTOTPProvider_ValidateCodeSkew(t *testing.T) {
+	assert := assert.New(t)
+	totpProvider := NewTOTPProvider("GoProjectSkeleton")
+	generatedAt := time.Unix(1111111109, 0)
+
+	// A code is accepted one step before and after its own
+	_, valid := totpProvider.ValidateCode(rfc6238Secret, "081804", generatedAt.Add(totpPeriod*time.Second))
+	assert.True(valid)
+	_, valid = totpProvider.ValidateCode(rfc6238Secret, "081804", generatedAt.Add(3*totpPeriod*time.Second))
+	assert.False(valid)
+
+	_, valid = totpProvider.ValidateCode(rfc6238Secret, "000000", generatedAt)
+	assert.False(valid)
+	_, valid = totpProvider.ValidateCode(rfc6238Secret, "81804", generatedAt)
+	assert.False(valid)
+	_, valid = totpProvider.ValidateCode("not base32!", "081804", generatedAt)
+	assert.False(valid)
+}
+
+func TestTOTPProvider_GenerateSecret(t *testing.T) {
+	assert := assert.New(t)
+	totpProvider := NewTOTPProvider("GoProjectSkeleton")
+
+	secret, err := totpProvider.GenerateSecret()
+	assert.Nil(err)
+	key, decodeErr := totpEncoding.DecodeString(secret)
+	assert.NoError(decodeErr)
+	assert.Len(key, totpSecretSize)
+
+	// The code of the current step is valid for a new secret
+	now := time.Now()
+	step, valid := totpProvider.ValidateCode(secret, totpCode(key, now.Unix()/totpPeriod), now)
+	assert.True(valid)
+	assert.Equal(now.Unix()/totpPeriod, step)
+}
+
+func TestTOTPProvider_ProvisioningURI(t *testing.T) {
+	assert := assert.New(t)
+	totpProvider := NewTOTPProvider("GoProjectSkeleton")
+
+	uri, err := url.Parse(totpProvider.ProvisioningURI(rfc6238Secret, "user@example.com"))
+	assert.NoError(err)
+	assert.Equal("otpauth", uri.Scheme)
+	assert.Equal("totp", uri.Host)
+	assert.Equal("/GoProjectSkeleton:user@example.com", uri.Path)
+	assert.Equal(rfc6238Secret, uri.Query().Get("secret"))
+	assert.Equal("GoProjectSkeleton", uri.Query().Get("issuer"))
+	assert.Equal("6", uri.Query().Get("digits"))
+	assert.Equal("30", uri.Query().Get("period"))
+}
+
+func TestTOTPProvider_GenerateRecoveryCodes(t *testing.T) {
+	assert := assert.New(t)
+	totpProvider := NewTOTPProvider("GoProjectSkeleton")
+
+	codes, err := totpProvider.GenerateRecoveryCodes(10)
+	assert.Nil(err)
+	assert.Len(codes, 10)
+	seen := map[string]bool{}
+	for _, code := range codes {
+		assert.Regexp(regexp.MustCompile(`^[A-Z2-7]{5}-[A-Z2-7]{5}$`), code)
+		assert.False(seen[code])
+		seen[code] = true
+	}
+}
@@ -0,0 +1,133 @@
+package providers
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// cborMaxDepth bounds the nesting of the CBOR items sent by authenticators
+const cborMaxDepth = 16
+
+var errCBORTruncated = errors.New("cbor: unexpected end of data")
+
+// decodeCBOR decodes the first CBOR item of data, the subset authenticators
+// use: integers, byte and text strings, arrays, maps and simple values.
+// Integers decode to int64, maps to map[any]any and it returns the number of
+// bytes the item used.
+func decodeCBOR(data []byte) (any, int, error) {
+	return decodeCBORItem(data, 0)
+}
+
+func decodeCBORItem(data []byte, depth int) (any, int, error) {
+	if depth > cborMaxDepth {
+		return nil, 0, errors.New("cbor: nesting too deep")
+	}
+	if len(data) == 0 {
+		return nil, 0, errCBORTruncated
+	}
+
+	major := data[0] >> 5
+	argument, offset, err := decodeCBORArgument(data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	switch major {
+	case 0:
+		if argument > 1<<63-1 {
+			return nil, 0, errors.New("cbor: integer overflow")
+		}
+		return int64(argument), offset, nil
+	case 1:
+		if argument > 1<<63-1 {
+			return nil, 0, errors.New("cbor: integer overflow")
+		}
+		return -1 - int64(argument), offset, nil
+	case 2, 3:
+		if argument > uint64(len(data)-offset) {
+			return nil, 0, errCBORTruncated
+		}
+		end := offset + int(argument)
+		if major == 3 {
+			return string(data[offset:end]), end, nil
+		}
+		return append([]byte(nil), data[offset:end]...), end, nil
+	case 4:
+		if argument > uint64(len(data)-offset) {
+			return nil, 0, errCBORTruncated
+		}
+		items := make([]any, 0, argument)
+		for i := uint64(0); i < argument; i++ {
+			item, size, err := decodeCBORItem(data[offset:], depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			items = append(items, item)
+			offset += size
+		}
+		return items, offset, nil
+	case 5:
+		if argument > uint64(len(data)-offset) {
+			return nil, 0, errCBORTruncated
+		}
+		items := make(map[any]any, argument)
+		for i := uint64(0); i < argument; i++ {
+			key, size, err := decodeCBORItem(data[offset:], depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			offset += size
+			switch key.(type) {
+			case int64, string:
+			default:
+				return nil, 0, errors.New("cbor: unsupported map key")
+			}
+			value, size, err := decodeCBORItem(data[offset:], depth+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			offset += size
+			items[key] = value
+		}
+		return items, offset, nil
+	case 7:
+		switch data[0] & 0x1f {
+		case 20:
+			return false, offset, nil
+		case 21:
+			return true, offset, nil
+		case 22, 23:
+			return nil, offset, nil
+		}
+	}
+	return nil, 0, fmt.Errorf("cbor: unsupported item 0x%02x", data[0])
+}
+
+// decodeCBORArgument decodes the argument of the head of an item, indefinite
+// lengths are not used by authenticators and are rejected
+func decodeCBORArgument(data []byte) (uint64, int, error) {
+	info := data[0] & 0x1f
+	switch {
+	case info < 24:
+		return uint64(info), 1, nil
+	case info <= 27:
+		size := 1 << (info - 24)
+		if len(data) < 1+size {
+			return 0, 0, errCBORTruncated
+		}
+		var argument uint64
+		switch size {
+		case 1:
+			argument = uint64(data[1])
+		case 2:
+			argument = uint64(binary.BigEndian.Uint16(data[1:3]))
+		case 4:
+			argument = uint64(binary.BigEndian.Uint32(data[1:5]))
+		case 8:
+			argument = binary.BigEndian.Uint64(data[1:9])
+		}
+		return argument, 1 + size, nil
+	}
+	return 0, 0, fmt.Errorf("cbor: unsupported item 0x%02x", data[0])
+}
@@ -0,0 +1,336 @@
+package providers
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	authcontracts "github.com/simon3640/goprojectskeleton/src/application/modules/auth/contracts"
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	application_errors "github.com/simon3640/goprojectskeleton/src/application/shared/errors"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/locales/messages"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+)
+
+const (
+	webAuthnChallengeSize = 32
+
+	webAuthnTypeCreate = "webauthn.create"
+	webAuthnTypeGet    = "webauthn.get"
+
+	// authenticator data flags
+	webAuthnFlagUserPresent       = 0x01
+	webAuthnFlagAttestedData      = 0x40
+	webAuthnAuthenticatorDataSize = 37 // rpIdHash, flags and signCount
+
+	// COSE key parameters
+	coseKeyType      = 1
+	coseKeyAlgorithm = 3
+	coseKeyCurve     = -1
+	coseKeyX         = -2
+	coseKeyY         = -3
+	coseKeyRSAN      = -1
+	coseKeyRSAE      = -2
+	coseKeyTypeOKP   = 1
+	coseKeyTypeEC2   = 2
+	coseKeyTypeRSA   = 3
+	coseCurveP256    = 1
+	coseCurveEd25519 = 6
+)
+
+// WebAuthnProvider verifies the registration and login ceremonies of
+// WebAuthn authenticators for one relying party. Attestations are not
+// verified, the "none" format is requested, so a passkey proves the user
+// holds its private key but not the model of the authenticator.
+type WebAuthnProvider struct {
+	rpID    string
+	rpName  string
+	origins []string
+}
+
+var _ authcontracts.IWebAuthnProvider = (*WebAuthnProvider)(nil)
+
+// webAuthnClientData is the client data the browser signs with the challenge
+type webAuthnClientData struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	Origin    string `json:"origin"`
+}
+
+// webAuthnAuthenticatorData is the parsed authenticator data
+type webAuthnAuthenticatorData struct {
+	rpIDHash     []byte
+	flags        byte
+	signCount    uint32
+	aaguid       []byte
+	credentialID []byte
+	publicKey    []byte
+}
+
+// RelyingParty returns the application the passkeys are scoped to
+func (wp *WebAuthnProvider) RelyingParty() authdtos.WebAuthnRelyingParty {
+	return authdtos.WebAuthnRelyingParty{ID: wp.rpID, Name: wp.rpName}
+}
+
+// NewChallenge generates the random challenge of a ceremony
+func (wp *WebAuthnProvider) NewChallenge() ([]byte, *application_errors.ApplicationError) {
+	challenge := make([]byte, webAuthnChallengeSize)
+	if _, err := rand.Read(challenge); err != nil {
+		return nil, application_errors.NewApplicationError(
+			status.ProviderError,
+			messages.MessageKeysInstance.SOMETHING_WENT_WRONG,
+			"failed to generate WebAuthn challenge",
+		)
+	}
+	return challenge, nil
+}
+
+// VerifyRegistration verifies a new credential signed the challenge for this
+// relying party and returns its ID and public key
+func (wp *WebAuthnProvider) VerifyRegistration(
+	challenge []byte,
+	credential authdtos.WebAuthnRegistrationCredential,
+) (*authdtos.WebAuthnVerifiedCredential, *application_errors.ApplicationError) {
+	if _, err := wp.verifyClientData(credential.Response.ClientDataJSON, webAuthnTypeCreate, challenge); err != nil {
+		return nil, invalidWebAuthnCredential(err)
+	}
+
+	rawAttestation, err := authdtos.DecodeWebAuthnBase64(credential.Response.AttestationObject)
+	if err != nil {
+		return nil, invalidWebAuthnCredential(err)
+	}
+	decoded, _, err := decodeCBOR(rawAttestation)
+	if err != nil {
+		return nil, invalidWebAuthnCredential(err)
+	}
+	attestation, ok := decoded.(map[any]any)
+	if !ok {
+		return nil, invalidWebAuthnCredential(errors.New("attestation object is not a map"))
+	}
+	if format, _ := attestation["fmt"].(string); format != "none" {
+		return nil, invalidWebAuthnCredential(fmt.Errorf("unsupported attestation format %q", format))
+	}
+	rawAuthData, ok := attestation["authData"].([]byte)
+	if !ok {
+		return nil, invalidWebAuthnCredential(errors.New("attestation object without authData"))
+	}
+
+	authData, err := wp.verifyAuthenticatorData(rawAuthData)
+	if err != nil {
+		return nil, invalidWebAuthnCredential(err)
+	}
+	if authData.flags&webAuthnFlagAttestedData == 0 {
+		return nil, invalidWebAuthnCredential(errors.New("authenticator data without attested credential"))
+	}
+	rawID, err := authdtos.DecodeWebAuthnBase64(credential.RawID)
+	if err != nil || !bytes.Equal(rawID, authData.credentialID) {
+		return nil, invalidWebAuthnCredential(errors.New("rawId does not match the attested credential"))
+	}
+	if _, err := parseCOSEKey(authData.publicKey); err != nil {
+		return nil, invalidWebAuthnCredential(err)
+	}
+
+	return &authdtos.WebAuthnVerifiedCredential{
+		CredentialID: authData.credentialID,
+		PublicKey:    authData.publicKey,
+		SignCount:    authData.signCount,
+		AAGUID:       authData.aaguid,
+	}, nil
+}
+
+// VerifyAssertion verifies the credential with the COSE encoded public key
+// signed the challenge for this relying party and returns the sign count of
+// the authenticator
+func (wp *WebAuthnProvider) VerifyAssertion(
+	challenge []byte,
+	publicKey []byte,
+	credential authdtos.WebAuthnAssertionCredential,
+) (uint32, *application_errors.ApplicationError) {
+	clientDataJSON, err := wp.verifyClientData(credential.Response.ClientDataJSON, webAuthnTypeGet, challenge)
+	if err != nil {
+		return 0, invalidWebAuthnCredential(err)
+	}
+	rawAuthData, err := authdtos.DecodeWebAuthnBase64(credential.Response.AuthenticatorData)
+	if err != nil {
+		return 0, invalidWebAuthnCredential(err)
+	}
+	authData, err := wp.verifyAuthenticatorData(rawAuthData)
+	if err != nil {
+		return 0, invalidWebAuthnCredential(err)
+	}
+	signature, err := authdtos.DecodeWebAuthnBase64(credential.Response.Signature)
+	if err != nil {
+		return 0, invalidWebAuthnCredential(err)
+	}
+
+	key, err := parseCOSEKey(publicKey)
+	if err != nil {
+		return 0, invalidWebAuthnCredential(err)
+	}
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signed := append(append([]byte(nil), rawAuthData...), clientDataHash[:]...)
+	if !verifyCOSESignature(key, signed, signature) {
+		return 0, invalidWebAuthnCredential(errors.New("invalid signature"))
+	}
+	return authData.signCount, nil
+}
+
+// verifyClientData checks the client data is of the ceremony, for the
+// challenge and from an allowed origin and returns its raw JSON
+func (wp *WebAuthnProvider) verifyClientData(encoded string, ceremony string, challenge []byte) ([]byte, error) {
+	raw, err := authdtos.DecodeWebAuthnBase64(encoded)
+	if err != nil {
+		return nil, err
+	}
+	var clientData webAuthnClientData
+	if err := json.Unmarshal(raw, &clientData); err != nil {
+		return nil, fmt.Errorf("invalid client data: %w", err)
+	}
+	if clientData.Type != ceremony {
+		return nil, fmt.Errorf("client data type %q, expected %q", clientData.Type, ceremony)
+	}
+	signedChallenge, err := authdtos.DecodeWebAuthnBase64(clientData.Challenge)
+	if err != nil || subtle.ConstantTimeCompare(signedChallenge, challenge) != 1 {
+		return nil, errors.New("challenge mismatch")
+	}
+	for _, origin := range wp.origins {
+		if clientData.Origin == origin {
+			return raw, nil
+		}
+	}
+	return nil, fmt.Errorf("origin %q not allowed", clientData.Origin)
+}
+
+// verifyAuthenticatorData parses the authenticator data and checks it is
+// for this relying party with the user present
+func (wp *WebAuthnProvider) verifyAuthenticatorData(raw []byte) (*webAuthnAuthenticatorData, error) {
+	if len(raw) < webAuthnAuthenticatorDataSize {
+		return nil, errors.New("authenticator data too short")
+	}
+	authData := &webAuthnAuthenticatorData{
+		rpIDHash:  raw[:32],
+		flags:     raw[32],
+		signCount: binary.BigEndian.Uint32(raw[33:37]),
+	}
+	rpIDHash := sha256.Sum256([]byte(wp.rpID))
+	if subtle.ConstantTimeCompare(authData.rpIDHash, rpIDHash[:]) != 1 {
+		return nil, errors.New("relying party ID mismatch")
+	}
+	if authData.flags&webAuthnFlagUserPresent == 0 {
+		return nil, errors.New("user not present")
+	}
+	if authData.flags&webAuthnFlagAttestedData == 0 {
+		return authData, nil
+	}
+
+	// aaguid (16), credential ID length (2), credential ID, COSE public key
+	rest := raw[webAuthnAuthenticatorDataSize:]
+	if len(rest) < 18 {
+		return nil, errors.New("attested credential data too short")
+	}
+	authData.aaguid = rest[:16]
+	idLength := int(binary.BigEndian.Uint16(rest[16:18]))
+	rest = rest[18:]
+	if len(rest) < idLength {
+		return nil, errors.New("credential ID truncated")
+	}
+	authData.credentialID = rest[:idLength]
+	_, keyLength, err := decodeCBOR(rest[idLength:])
+	if err != nil {
+		return nil, fmt.Errorf("invalid credential public key: %w", err)
+	}
+	authData.publicKey = rest[idLength : idLength+keyLength]
+	return authData, nil
+}
+
+// coseKey is a parsed COSE public key and its algorithm
+type coseKey struct {
+	algorithm int64
+	publicKey crypto.PublicKey
+}
+
+// parseCOSEKey parses the COSE public keys of the supported algorithms
+func parseCOSEKey(raw []byte) (*coseKey, error) {
+	decoded, _, err := decodeCBOR(raw)
+	if err != nil {
+		return nil, err
+	}
+	parameters, ok := decoded.(map[any]any)
+	if !ok {
+		return nil, errors.New("COSE key is not a map")
+	}
+	keyType, _ := parameters[int64(coseKeyType)].(int64)
+	algorithm, _ := parameters[int64(coseKeyAlgorithm)].(int64)
+
+	switch {
+	case algorithm == authdtos.WebAuthnAlgES256 && keyType == coseKeyTypeEC2:
+		curve, _ := parameters[int64(coseKeyCurve)].(int64)
+		x, _ := parameters[int64(coseKeyX)].([]byte)
+		y, _ := parameters[int64(coseKeyY)].([]byte)
+		if curve != coseCurveP256 || len(x) != 32 || len(y) != 32 {
+			return nil, errors.New("invalid ES256 key")
+		}
+		publicKey := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !publicKey.Curve.IsOnCurve(publicKey.X, publicKey.Y) {
+			return nil, errors.New("ES256 key is not on the curve")
+		}
+		return &coseKey{algorithm: algorithm, publicKey: publicKey}, nil
+	case algorithm == authdtos.WebAuthnAlgEdDSA && keyType == coseKeyTypeOKP:
+		curve, _ := parameters[int64(coseKeyCurve)].(int64)
+		x, _ := parameters[int64(coseKeyX)].([]byte)
+		if curve != coseCurveEd25519 || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid EdDSA key")
+		}
+		return &coseKey{algorithm: algorithm, publicKey: ed25519.PublicKey(x)}, nil
+	case algorithm == authdtos.WebAuthnAlgRS256 && keyType == coseKeyTypeRSA:
+		n, _ := parameters[int64(coseKeyRSAN)].([]byte)
+		e, _ := parameters[int64(coseKeyRSAE)].([]byte)
+		exponent := new(big.Int).SetBytes(e)
+		if len(n) < 256 || !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid RS256 key")
+		}
+		publicKey := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}
+		return &coseKey{algorithm: algorithm, publicKey: publicKey}, nil
+	}
+	return nil, fmt.Errorf("unsupported COSE key type %d with algorithm %d", keyType, algorithm)
+}
+
+// verifyCOSESignature verifies the signature of the data with the key
+func verifyCOSESignature(key *coseKey, data []byte, signature []byte) bool {
+	switch publicKey := key.publicKey.(type) {
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(data)
+		return ecdsa.VerifyASN1(publicKey, digest[:], signature)
+	case ed25519.PublicKey:
+		return ed25519.Verify(publicKey, data, signature)
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(data)
+		return rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, digest[:], signature) == nil
+	}
+	return false
+}
+
+func invalidWebAuthnCredential(err error) *application_errors.ApplicationError {
+	return application_errors.NewApplicationError(
+		status.Unauthorized,
+		messages.MessageKeysInstance.INVALID_WEBAUTHN_CREDENTIAL,
+		err.Error(),
+	)
+}
+
+// NewWebAuthnProvider creates a WebAuthn provider for the relying party ID,
+// the domain of the application, accepting ceremonies from the given origins
+func NewWebAuthnProvider(rpID string, rpName string, origins []string) *WebAuthnProvider {
+	return &WebAuthnProvider{rpID: rpID, rpName: rpName, origins: origins}
+}
@@ -0,0 +1,282 @@
+package providers
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"testing"
+
+	authdtos "github.com/simon3640/goprojectskeleton/src/application/modules/auth/dtos"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/status"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testWebAuthnRPID   = "example.com"
+	testWebAuthnOrigin = "https://example.com"
+)
+
+// encodeTestCBOR encodes the values the software authenticator sends
+func encodeTestCBOR(value any) []byte {
+	head := func(major byte, argument uint64) []byte {
+		switch {
+		case argument < 24:
+			return []byte{major<<5 | byte(argument)}
+		case argument < 1<<8:
+			return []byte{major<<5 | 24, byte(argument)}
+		default:
+			return binary.BigEndian.AppendUint16([]byte{major<<5 | 25}, uint16(argument))
+		}
+	}
+	switch v := value.(type) {
+	case int:
+		if v < 0 {
+			return head(1, uint64(-1-v))
+		}
+		return head(0, uint64(v))
+	case []byte:
+		return append(head(2, uint64(len(v))), v...)
+	case string:
+		return append(head(3, uint64(len(v))), v...)
+	case [][2]any:
+		// map with ordered entries
+		encoded := head(5, uint64(len(v)))
+		for _, entry := range v {
+			encoded = append(encoded, encodeTestCBOR(entry[0])...)
+			encoded = append(encoded, encodeTestCBOR(entry[1])...)
+		}
+		return encoded
+	}
+	panic("unsupported test CBOR value")
+}
+
+// softwareAuthenticator is a WebAuthn authenticator for the tests
+type softwareAuthenticator struct {
+	credentialID []byte
+	signer       crypto.Signer
+	coseKey      []byte
+	signCount    uint32
+}
+
+func newSoftwareAuthenticator(t *testing.T, algorithm int) *softwareAuthenticator {
+	credentialID := make([]byte, 16)
+	_, _ = rand.Read(credentialID)
+	authenticator := &softwareAuthenticator{credentialID: credentialID}
+
+	switch algorithm {
+	case authdtos.WebAuthnAlgES256:
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		assert.NoError(t, err)
+		authenticator.signer = key
+		authenticator.coseKey = encodeTestCBOR([][2]any{
+			{1, 2}, {3, authdtos.WebAuthnAlgES256}, {-1, 1},
+			{-2, key.X.FillBytes(make([]byte, 32))}, {-3, key.Y.FillBytes(make([]byte, 32))},
+		})
+	case authdtos.WebAuthnAlgEdDSA:
+		public, private, err := ed25519.GenerateKey(rand.Reader)
+		assert.NoError(t, err)
+		authenticator.signer = private
+		authenticator.coseKey = encodeTestCBOR([][2]any{
+			{1, 1}, {3, authdtos.WebAuthnAlgEdDSA}, {-1, 6}, {-2, []byte(public)},
+		})
+	}
+	return authenticator
+}
+
+func (a *softwareAuthenticator) authenticatorData(rpID string, flags byte, attested bool) []byte {
+	rpIDHash := sha256.Sum256([]byte(rpID))
+	data := append(rpIDHash[:], flags)
+	data = binary.BigEndian.AppendUint32(data, a.signCount)
+	if attested {
+		data = append(data, make([]byte, 16)...)
+		data = binary.BigEndian.AppendUint16(data, uint16(len(a.credentialID)))
+		data = append(data, a.credentialID...)
+		data = append(data, a.coseKey...)
+	}
+	return data
+}
+
+func testClientData(ceremony string, challenge []byte, origin string) []byte {
+	clientData, _ := json.Marshal(map[string]any{
+		"type":      ceremony,
+		"challenge": base64.RawURLEncoding.EncodeToString(challenge),
+		"origin":    origin,
+	})
+	return clientData
+}
+
+func (a *softwareAuthenticator) register(challenge []byte, rpID string, origin string) authdtos.WebAuthnRegistrationCredential {
+	attestation := encodeTestCBOR([][2]any{
+		{"fmt", "none"},
+		{"attStmt", [][2]any{}},
+		{"authData", a.authenticatorData(rpID, webAuthnFlagUserPresent|webAuthnFlagAttestedData, true)},
+	})
+	id := base64.RawURLEncoding.EncodeToString(a.credentialID)
+	return authdtos.WebAuthnRegistrationCredential{
+		ID:    id,
+		RawID: id,
+		Type:  authdtos.WebAuthnPublicKeyType,
+		Response: authdtos.WebAuthnAttestationResponse{
+			ClientDataJSON:    base64.RawURLEncoding.EncodeToString(testClientData("webauthn.create", challenge, origin)),
+			AttestationObject: base64.RawURLEncoding.EncodeToString(attestation),
+		},
+	}
+}
+
+func (a *softwareAuthenticator) assert(t *testing.T, challenge []byte, rpID string, origin string) authdtos.WebAuthnAssertionCredential {
+	a.signCount++
+	authData := a.authenticatorData(rpID, webAuthnFlagUserPresent, false)
+	clientData := testClientData("webauthn.get", challenge, origin)
+	clientDataHash := sha256.Sum256(clientData)
+	signed := append(append([]byte(nil), authData...), clientDataHash[:]...)
+
+	var signature []byte
+	var err error
+	if _, ok := a.signer.(ed25519.PrivateKey); ok {
+		signature, err = a.signer.Sign(rand.Reader, signed, crypto.Hash(0))
+	} else {
+		digest := sha256.Sum256(signed)
+		signature, err = a.signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	}
+	assert.NoError(t, err)
+
+	id := base64.RawURLEncoding.EncodeToString(a.credentialID)
+	return authdtos.WebAuthnAssertionCredential{
+		ID:    id,
+		RawID: id,
+		Type:  authdtos.WebAuthnPublicKeyType,
+		Response: authdtos.WebAuthnAssertionResponse{
+			ClientDataJSON:    base64.RawURLEncoding.EncodeToString(clientData),
+			AuthenticatorData: base64.RawURLEncoding.EncodeToString(authData),
+			Signature:         base64.RawURLEncoding.EncodeToString(signature),
+		},
+	}
+}
+
+func TestWebAuthnProvider_RegistrationAndAssertion(t *testing.T) {
+	algorithms := map[string]int{"ES256": authdtos.WebAuthnAlgES256, "EdDSA": authdtos.WebAuthnAlgEdDSA}
+
+	for name, algorithm := range algorithms {
+		t.Run(name, func(t *testing.T) {
+			assert := assert.New(t)
+			provider := NewWebAuthnProvider(testWebAuthnRPID, "Example", []string{testWebAuthnOrigin})
+			authenticator := newSoftwareAuthenticator(t, algorithm)
+
+			challenge, err := provider.NewChallenge()
+			assert.Nil(err)
+			verified, err := provider.VerifyRegistration(challenge, authenticator.register(challenge, testWebAuthnRPID, testWebAuthnOrigin))
+			assert.Nil(err)
+			assert.Equal(authenticator.credentialID, verified.CredentialID)
+			assert.Equal(authenticator.coseKey, verified.PublicKey)
+
+			challenge, _ = provider.NewChallenge()
+			signCount, err := provider.VerifyAssertion(challenge, verified.PublicKey, authenticator.assert(t, challenge, testWebAuthnRPID, testWebAuthnOrigin))
+			assert.Nil(err)
+			assert.Equal(uint32(1), signCount)
+		})
+	}
+}
+
+func TestWebAuthnProvider_RejectsInvalidRegistrations(t *testing.T) {
+	provider := NewWebAuthnProvider(testWebAuthnRPID, "Example", []string{testWebAuthnOrigin})
+	authenticator := newSoftwareAuthenticator(t, authdtos.WebAuthnAlgES256)
+	challenge, _ := provider.NewChallenge()
+	otherChallenge, _ := provider.NewChallenge()
+
+	cases := []struct {
+		name       string
+		credential authdtos.WebAuthnRegistrationCredential
+	}{
+		{name: "other challenge", credential: authenticator.register(otherChallenge, testWebAuthnRPID, testWebAuthnOrigin)},
+		{name: "other origin", credential: authenticator.register(challenge, testWebAuthnRPID, "https://evil.example")},
+		{name: "other relying party", credential: authenticator.register(challenge, "evil.example", testWebAuthnOrigin)},
+		{name: "mismatched raw id", credential: func() authdtos.WebAuthnRegistrationCredential {
+			credential := authenticator.register(challenge, testWebAuthnRPID, testWebAuthnOrigin)
+			credential.RawID = base64.RawURLEncoding.EncodeToString([]byte("other"))
+			return credential
+		}()},
+		{name: "login ceremony", credential: func() authdtos.WebAuthnRegistrationCredential {
+			credential := authenticator.register(challenge, testWebAuthnRPID, testWebAuthnOrigin)
+			credential.Response.ClientDataJSON = base64.RawURLEncoding.EncodeToString(testClientData("webauthn.get", challenge, testWebAuthnOrigin))
+			return credential
+		}()},
+		{name: "malformed attestation", credential: func() authdtos.WebAuthnRegistrationCredential {
+			credential := authenticator.register(challenge, testWebAuthnRPID, testWebAuthnOrigin)
+			credential.Response.AttestationObject = base64.RawURLEncoding.EncodeToString([]byte{0xa3, 0x63})
+			return credential
+		}()},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			verified, err := provider.VerifyRegistration(challenge, tc.credential)
+			assert.Nil(t, verified)
+			if assert.NotNil(t, err) {
+				assert.Equal(t, status.Unauthorized, err.Code)
+			}
+		})
+	}
+}
+
+func TestWebAuthnProvider_RejectsInvalidAssertions(t *testing.T) {
+	provider := NewWebAuthnProvider(testWebAuthnRPID, "Example", []string{testWebAuthnOrigin})
+	authenticator := newSoftwareAuthenticator(t, authdtos.WebAuthnAlgES256)
+	otherAuthenticator := newSoftwareAuthenticator(t, authdtos.WebAuthnAlgES256)
+	challenge, _ := provider.NewChallenge()
+	otherChallenge, _ := provider.NewChallenge()
+
+	cases := []struct {
+		name       string
+		credential authdtos.WebAuthnAssertionCredential
+	}{
+		{name: "other challenge", credential: authenticator.assert(t, otherChallenge, testWebAuthnRPID, testWebAuthnOrigin)},
+		{name: "other origin", credential: authenticator.assert(t, challenge, testWebAuthnRPID, "https://evil.example")},
+		{name: "other relying party", credential: authenticator.assert(t, challenge, "evil.example", testWebAuthnOrigin)},
+		{name: "other key", credential: otherAuthenticator.assert(t, challenge, testWebAuthnRPID, testWebAuthnOrigin)},
+		{name: "tampered authenticator data", credential: func() authdtos.WebAuthnAssertionCredential {
+			credential := authenticator.assert(t, challenge, testWebAuthnRPID, testWebAuthnOrigin)
+			authenticator.signCount += 10
+			credential.Response.AuthenticatorData = base64.RawURLEncoding.EncodeToString(
+				authenticator.authenticatorData(testWebAuthnRPID, webAuthnFlagUserPresent, false),
+			)
+			return credential
+		}()},
+		{name: "user not present", credential: func() authdtos.WebAuthnAssertionCredential {
+			credential := authenticator.assert(t, challenge, testWebAuthnRPID, testWebAuthnOrigin)
+			credential.Response.AuthenticatorData = base64.RawURLEncoding.EncodeToString(
+				authenticator.authenticatorData(testWebAuthnRPID, 0, false),
+			)
+			return credential
+		}()},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := provider.VerifyAssertion(challenge, authenticator.coseKey, tc.credential)
+			if assert.NotNil(t, err) {
+				assert.Equal(t, status.Unauthorized, err.Code)
+			}
+		})
+	}
+}
+
+func TestDecodeCBOR(t *testing.T) {
+	assert := assert.New(t)
+
+	value, size, err := decodeCBOR(encodeTestCBOR([][2]any{{"a", -7}, {3, []byte{1, 2}}}))
+	assert.NoError(err)
+	assert.Equal(8, size)
+	assert.Equal(map[any]any{"a": int64(-7), int64(3): []byte{1, 2}}, value)
+
+	_, _, err = decodeCBOR([]byte{0x5a, 0xff, 0xff, 0xff, 0xff})
+	assert.Error(err, "byte string longer than the data")
+	_, _, err = decodeCBOR([]byte{0x9f})
+	assert.Error(err, "indefinite length")
+}
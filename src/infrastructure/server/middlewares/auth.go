@@ -7,10 +7,10 @@ import (
 	authusecases "github.com/simon3640/goprojectskeleton/src/application/modules/auth/use_cases"
 	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
 	"github.com/simon3640/goprojectskeleton/src/application/shared/locales"
-	"github.com/simon3640/goprojectskeleton/src/application/shared/observability"
 	usecase "github.com/simon3640/goprojectskeleton/src/application/shared/use_case"
 	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
 	database "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton"
+	authrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/auth"
 	userrepositories "github.com/simon3640/goprojectskeleton/src/infrastructure/databases/goprojectskeleton/repositories/user"
 	"github.com/simon3640/goprojectskeleton/src/infrastructure/providers"
 
@@ -20,21 +20,24 @@ import (
 func AuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		token := c.GetHeader("Authorization")
+		if token == "" {
+			token = c.GetHeader("X-API-Key")
+		}
 
 		appContext := app_context.AppContext{Context: c.Request.Context()}
 		uc := authusecases.NewAuthUserUseCase(
-			userrepositories.NewUserRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+			userrepositories.NewUserRepository(c.Request.Context(), database.GoProjectSkeletondb.DB, providers.Logger),
 			providers.JWTProviderInstance,
+			providers.NewTokenRevocationProvider(providers.CacheProviderInstance),
+			authrepositories.NewAPIKeyRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+			providers.HashProviderInstance,
+			userrepositories.NewPermissionRepository(database.GoProjectSkeletondb.DB, providers.Logger),
+			providers.CacheProviderInstance,
 		)
-		uc_result := usecase.InstrumentUseCase(
-			uc,
+		uc_result := usecase.Intercept(uc, "auth_user_use_case").Execute(
 			&appContext,
 			locales.EN_US,
 			token,
-			observability.GetObservabilityComponents().Tracer,
-			observability.GetObservabilityComponents().Metrics,
-			observability.GetObservabilityComponents().Clock,
-			"auth_user_use_case",
 		)
 
 		if uc_result.HasError() {
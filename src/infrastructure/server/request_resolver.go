@@ -55,6 +55,9 @@ func (rr *RequestResolver[D]) ResolveDTO(
 
 	content["data"] = result.Data
 	content["details"] = result.Details
+	if len(result.TaskIDs) > 0 {
+		content["tasks"] = result.TaskIDs
+	}
 	rr.getHeaders(ctx, headersToAdd)
 
 	return &content, rr.statusMapping[result.StatusCode]
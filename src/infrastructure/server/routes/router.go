@@ -15,6 +15,10 @@ func Router(r *gin.RouterGroup) {
 	r.GET("/status", wrapHandler(statushandlers.GetHealthCheck))
 	private := r.Group("/")
 	private.Use(middlewares.AuthMiddleware())
+	private.GET("/task/:id", wrapHandler(statushandlers.GetTask))
+	// Admin routes
+	private.GET("/admin/pipe", wrapHandler(statushandlers.GetPipes))
+	private.GET("/admin/pipe/:name", wrapHandler(statushandlers.GetPipe))
 	// User routes
 	r.POST("/user", wrapHandler(userhandlers.CreateUser))
 	private.GET("/user/:id", wrapHandler(userhandlers.GetUser))
@@ -25,6 +29,21 @@ func Router(r *gin.RouterGroup) {
 	r.POST("/user/activate", wrapHandler(userhandlers.ActivateUser))
 	r.POST("/user/resend-welcome-email", wrapHandler(userhandlers.ResendWelcomeEmail))
 
+	// Role routes
+	private.POST("/role", wrapHandler(userhandlers.CreateRole))
+	private.GET("/role", middlewares.QueryMiddleware(), wrapHandler(userhandlers.GetAllRole))
+	private.GET("/role/:id", wrapHandler(userhandlers.GetRole))
+	private.PATCH("/role/:id", wrapHandler(userhandlers.UpdateRole))
+	private.DELETE("/role/:id", wrapHandler(userhandlers.DeleteRole))
+	private.GET("/role/:id/permission", wrapHandler(userhandlers.GetRolePermissions))
+	private.PUT("/role/:id/permission", wrapHandler(userhandlers.SetRolePermissions))
+
+	// Organization routes
+	private.POST("/organization", wrapHandler(userhandlers.CreateOrganization))
+	private.GET("/organization", middlewares.QueryMiddleware(), wrapHandler(userhandlers.GetAllOrganization))
+	private.POST("/organization/:id/member", wrapHandler(userhandlers.AddOrganizationMember))
+	private.DELETE("/organization/:id/member/:user_id", wrapHandler(userhandlers.RemoveOrganizationMember))
+
 	// Password routes
 	private.POST("/password", wrapHandler(passwordhandlers.CreatePassword))
 	r.POST("/password/reset-token", wrapHandler(passwordhandlers.CreatePasswordToken))
@@ -32,7 +51,34 @@ func Router(r *gin.RouterGroup) {
 	// Auth routes
 	r.POST("/auth/login", wrapHandler(authhandlers.Login))
 	r.POST("/auth/refresh", wrapHandler(authhandlers.RefreshAccessToken))
+	r.POST("/auth/logout", wrapHandler(authhandlers.Logout))
+	private.POST("/auth/logout-all", wrapHandler(authhandlers.LogoutAll))
+	private.POST("/auth/mfa/totp/enroll", wrapHandler(authhandlers.EnrollTOTP))
+	private.POST("/auth/mfa/totp/confirm", wrapHandler(authhandlers.ConfirmTOTP))
+	private.POST("/auth/webauthn/register/begin", wrapHandler(authhandlers.BeginWebAuthnRegistration))
+	private.POST("/auth/webauthn/register/finish", wrapHandler(authhandlers.FinishWebAuthnRegistration))
+	r.POST("/auth/webauthn/login/begin", wrapHandler(authhandlers.BeginWebAuthnLogin))
+	r.POST("/auth/webauthn/login/finish", wrapHandler(authhandlers.FinishWebAuthnLogin))
+	r.POST("/auth/oidc/authorize", wrapHandler(authhandlers.StartOIDCLogin))
+	r.POST("/auth/oidc/callback", wrapHandler(authhandlers.OIDCLogin))
+	private.POST("/auth/oidc/link/authorize", wrapHandler(authhandlers.StartOIDCLink))
+	private.POST("/auth/oidc/link/callback", wrapHandler(authhandlers.LinkOIDCIdentity))
 	r.GET("/auth/password-reset/:identifier", wrapHandler(authhandlers.RequestPasswordReset))
-	r.GET("/auth/login-otp/:otp", wrapHandler(authhandlers.LoginOTP))
+	r.POST("/auth/login-otp", wrapHandler(authhandlers.LoginOTP))
+	r.POST("/auth/unlock", wrapHandler(authhandlers.UnlockAccount))
+	private.POST("/auth/api-key", wrapHandler(authhandlers.CreateAPIKey))
+	private.GET("/auth/api-key", wrapHandler(authhandlers.GetAPIKeys))
+	private.DELETE("/auth/api-key/:id", wrapHandler(authhandlers.RevokeAPIKey))
+	private.POST("/auth/tenant", wrapHandler(authhandlers.SwitchTenant))
+	private.POST("/auth/impersonate/:id", wrapHandler(authhandlers.ImpersonateUser))
+	private.DELETE("/auth/impersonate", wrapHandler(authhandlers.EndImpersonation))
+	r.GET("/.well-known/jwks.json", wrapHandler(authhandlers.GetJWKS))
+
+	// OAuth routes
+	private.POST("/oauth/clients", wrapHandler(authhandlers.RegisterOAuthClient))
+	private.POST("/oauth/authorize", wrapHandler(authhandlers.AuthorizeOAuthClient))
+	r.POST("/oauth/token", wrapHandler(authhandlers.OAuthToken))
+	r.POST("/oauth/introspect", wrapHandler(authhandlers.IntrospectOAuthToken))
+	r.POST("/oauth/revoke", wrapHandler(authhandlers.RevokeOAuthToken))
 
 }
@@ -2,6 +2,7 @@ package routes
 
 import (
 	app_context "github.com/simon3640/goprojectskeleton/src/application/shared/context"
+	"github.com/simon3640/goprojectskeleton/src/application/shared/settings"
 	usermodels "github.com/simon3640/goprojectskeleton/src/domain/user/models"
 	handlers "github.com/simon3640/goprojectskeleton/src/infrastructure/handlers/shared"
 
@@ -28,6 +29,7 @@ func wrapHandler(h func(handlers.HandlerContext)) gin.HandlerFunc {
 		if user, ok := user.(usermodels.UserWithRole); ok {
 			appContext.AddUserToContext(&user)
 		}
+		appContext.AddClientIPToContext(handlers.ClientIP(c.GetHeader(handlers.FORWARDED_FOR.String()), c.Request.RemoteAddr, settings.AppSettingsInstance.TrustedProxies))
 		hContext := handlers.NewHandlerContext(&appContext,
 			&locale,
 			params,
@@ -35,6 +37,7 @@ func wrapHandler(h func(handlers.HandlerContext)) gin.HandlerFunc {
 			query,
 			c.Writer,
 		)
+		hContext.IdempotencyKey = c.GetHeader("Idempotency-Key")
 
 		h(hContext)
 	}
@@ -1,6 +1,7 @@
 package integrationtest
 
 import (
+	"context"
 	"testing"
 
 	authmocks "github.com/simon3640/goprojectskeleton/src/application/modules/auth/mocks"
@@ -16,7 +17,7 @@ import (
 func TestOneTimePasswordGetByPasswordHash(t *testing.T) {
 	assert := assert.New(t)
 	oneTimePasswordRepository := authrepositories.NewOneTimePasswordRepository(database.GoProjectSkeletondb.DB, providers.Logger)
-	userRepository := userrepositories.NewUserRepository(database.GoProjectSkeletondb.DB, providers.Logger)
+	userRepository := userrepositories.NewUserRepository(context.Background(), database.GoProjectSkeletondb.DB, providers.Logger)
 
 	// Create user to link the one-time token
 	userCreated, _ := userRepository.Create(dtomocks.UserCreate)
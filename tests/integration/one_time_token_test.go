@@ -1,6 +1,7 @@
 package integrationtest
 
 import (
+	"context"
 	"testing"
 
 	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
@@ -15,7 +16,7 @@ import (
 func TestOneTimeTokenGetByTokenHash(t *testing.T) {
 	assert := assert.New(t)
 	oneTimeTokenRepository := authrepositories.NewOneTimeTokenRepository(database.GoProjectSkeletondb.DB, providers.Logger)
-	userRepository := userrepositories.NewUserRepository(database.GoProjectSkeletondb.DB, providers.Logger)
+	userRepository := userrepositories.NewUserRepository(context.Background(), database.GoProjectSkeletondb.DB, providers.Logger)
 
 	// Create user to link the one-time token
 	userCreated, _ := userRepository.Create(dtomocks.UserCreate)
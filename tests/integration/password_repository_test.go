@@ -1,6 +1,7 @@
 package integrationtest
 
 import (
+	"context"
 	"testing"
 
 	passwordmocks "github.com/simon3640/goprojectskeleton/src/application/modules/password/mocks"
@@ -32,7 +33,7 @@ func TestPasswordCreate(t *testing.T) {
 func TestPasswordGetActivePassword(t *testing.T) {
 	assert := assert.New(t)
 	passwordRepository := passwordrepositories.NewPasswordRepository(database.GoProjectSkeletondb.DB, providers.Logger)
-	userRepository := userrepositories.NewUserRepository(database.GoProjectSkeletondb.DB, providers.Logger)
+	userRepository := userrepositories.NewUserRepository(context.Background(), database.GoProjectSkeletondb.DB, providers.Logger)
 
 	// Create user to link the password
 	userCreated, appErr := userRepository.Create(dtomocks.UserCreate)
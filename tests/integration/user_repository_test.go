@@ -1,6 +1,7 @@
 package integrationtest
 
 import (
+	"context"
 	"testing"
 
 	dtomocks "github.com/simon3640/goprojectskeleton/src/application/shared/mocks/dtos"
@@ -11,12 +12,14 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func TestUserRepository_CreateWithPassword(t *testing.T) {
+func TestUserRepository_CreatePassword(t *testing.T) {
 	assert := assert.New(t)
-	userRepository := userrepositories.NewUserRepository(database.GoProjectSkeletondb.DB, providers.Logger)
+	userRepository := userrepositories.NewUserRepository(context.Background(), database.GoProjectSkeletondb.DB, providers.Logger)
 
-	// Test Create With password
-	createdUser, appErr := userRepository.CreateWithPassword(dtomocks.UserAndPasswordCreate)
+	// Test Create the user and their password
+	createdUser, appErr := userRepository.Create(dtomocks.UserAndPasswordCreate.UserCreate)
+	assert.Nil(appErr)
+	appErr = userRepository.CreatePassword(createdUser.ID, dtomocks.UserAndPasswordCreate.Password)
 
 	assert.Nil(appErr)
 	assert.NotNil(createdUser)
@@ -35,9 +38,9 @@ func TestUserRepository_CreateWithPassword(t *testing.T) {
 
 func TestUserRepository_GetUserWithRole(t *testing.T) {
 	assert := assert.New(t)
-	userRepository := userrepositories.NewUserRepository(database.GoProjectSkeletondb.DB, providers.Logger)
+	userRepository := userrepositories.NewUserRepository(context.Background(), database.GoProjectSkeletondb.DB, providers.Logger)
 	// Create user to test Get User With Role
-	createdUser, appErr := userRepository.CreateWithPassword(dtomocks.UserAndPasswordCreate)
+	createdUser, appErr := userRepository.Create(dtomocks.UserAndPasswordCreate.UserCreate)
 
 	// Test Get User With Role
 	userWithRole, appErr := userRepository.GetUserWithRole(createdUser.ID)
@@ -57,9 +60,9 @@ func TestUserRepository_GetUserWithRole(t *testing.T) {
 
 func TestUserRepository_GetByEmailOrPhone(t *testing.T) {
 	assert := assert.New(t)
-	userRepository := userrepositories.NewUserRepository(database.GoProjectSkeletondb.DB, providers.Logger)
+	userRepository := userrepositories.NewUserRepository(context.Background(), database.GoProjectSkeletondb.DB, providers.Logger)
 
-	createdUser, _ := userRepository.CreateWithPassword(dtomocks.UserAndPasswordCreate)
+	createdUser, _ := userRepository.Create(dtomocks.UserAndPasswordCreate.UserCreate)
 
 	// Test Get By Email
 	userByEmail, appErr := userRepository.GetByEmailOrPhone(createdUser.Email)